@@ -0,0 +1,41 @@
+// bin-import загружает локальный справочник BIN из CSV-файла. Первая строка - заголовок,
+// колонки: prefix (обязательная), bank_name, bank_code, nspk_code, payment_network, country.
+// Существующие префиксы обновляются.
+//
+//	ORDER_CONFIG_PATH=config/local.yaml go run ./cmd/bin-import -file bins.csv
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/LavaJover/shvark-order-service/internal/config"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/repository"
+	"github.com/LavaJover/shvark-order-service/internal/usecase"
+)
+
+func main() {
+	path := flag.String("file", "", "CSV-файл справочника BIN")
+	flag.Parse()
+
+	if *path == "" {
+		log.Fatalf("-file is required")
+	}
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("failed to open %s: %v", *path, err)
+	}
+	defer file.Close()
+
+	cfg := config.MustLoad()
+	db := postgres.MustInitDB(cfg)
+
+	binUsecase := usecase.NewDefaultBinUsecase(repository.NewDefaultBinRepository(db))
+	imported, err := binUsecase.ImportBins(file)
+	if err != nil {
+		log.Fatalf("imported %d bins before error: %v", imported, err)
+	}
+	log.Printf("imported %d bins from %s", imported, *path)
+}
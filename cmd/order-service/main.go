@@ -35,7 +35,7 @@ func main() {
     }
 
     // Инициализация антифрода (теперь отдельно)
-    antiFraudSystem, err := setup.InitializeAntiFraud(deps)
+    antiFraudSystem, err := setup.InitializeAntiFraud(deps, useCases.TrafficCache, useCases.CascadeUpdater)
     if err != nil {
         log.Fatalf("Failed to initialize anti-fraud system: %v", err)
     }
@@ -43,6 +43,8 @@ func main() {
     // Создание и запуск gRPC сервера
     grpcServer := setupGRPCServer(useCases, antiFraudSystem)
     
+    outboxRelay := setup.InitializeOutboxRelay(deps)
+
     // Запуск фоновых задач
     ctx, cancel := context.WithCancel(context.Background())
     defer cancel()
@@ -51,9 +53,33 @@ func main() {
         useCases.OrderUsecase,
         useCases.DisputeUsecase, 
         useCases.DeviceUsecase,
+        outboxRelay,
+        deps.Config.OutboxConfig.PollInterval,
+        useCases.CallbackQueue,
+        deps.Config.CallbackConfig.PollInterval,
+        deps.Config.WaitlistConfig.PollInterval,
+        deps.Config.UnmatchedPaymentsConfig.PollInterval,
+        deps.Config.OutboxConfig.CleanupInterval,
+        useCases.DeviceAuthUsecase,
+        deps.Config.DeviceAuthConfig.NonceCleanupInterval,
     )
     bgTasks.StartAll(ctx)
     
+    // Перезагрузка кэша каскада подбора реквизитов
+    if useCases.CascadeUpdater != nil {
+        go useCases.CascadeUpdater.Start(ctx)
+    }
+
+    // Пересчет скоров маршрутизации
+    if useCases.RoutingScores != nil {
+        go useCases.RoutingScores.Start(ctx)
+    }
+
+    // Снятие истекших приостановок реквизитов
+    if useCases.BankDetailHealth != nil {
+        go useCases.BankDetailHealth.Start(ctx)
+    }
+
     // Запуск планировщика антифрода
     go antiFraudSystem.Scheduler.Start(ctx)
 
@@ -82,7 +108,9 @@ func main() {
 }
 
 func setupGRPCServer(useCases *setup.UseCases, antiFraudSystem *setup.AntiFraudSystem) *grpc.Server {
-    server := grpc.NewServer()
+    server := grpc.NewServer(
+        grpc.UnaryInterceptor(grpcapi.DeviceAuthInterceptor(useCases.DeviceAuthUsecase)),
+    )
     
     // Регистрация всех обработчиков
     orderpb.RegisterOrderServiceServer(server, 
@@ -91,19 +119,21 @@ func setupGRPCServer(useCases *setup.UseCases, antiFraudSystem *setup.AntiFraudS
             useCases.DisputeUsecase, 
             useCases.BankDetailUsecase, 
             useCases.AutomaticUsecase,
+            useCases.CallbackQueue,
+            useCases.NotificationTemplateUsecase,
         ))
     
     orderpb.RegisterTrafficServiceServer(server, 
         grpcapi.NewTrafficHandler(useCases.TrafficUsecase))
     
     orderpb.RegisterBankDetailServiceServer(server, 
-        grpcapi.NewBankDetailHandler(useCases.BankDetailUsecase))
+        grpcapi.NewBankDetailHandler(useCases.BankDetailUsecase, useCases.BankDetailHealth, useCases.BinUsecase))
     
     orderpb.RegisterTeamRelationsServiceServer(server, 
         grpcapi.NewTeamRelationsHandler(useCases.TeamRelationsUsecase))
     
     orderpb.RegisterDeviceServiceServer(server, 
-        grpcapi.NewDeviceHandler(useCases.DeviceUsecase))
+        grpcapi.NewDeviceHandler(useCases.DeviceUsecase, useCases.DeviceAuthUsecase))
     
     // Используем antiFraudSystem.UseCase вместо useCases.AntiFraudUseCase
     orderpb.RegisterAntiFraudServiceServer(server, 
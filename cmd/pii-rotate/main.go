@@ -0,0 +1,138 @@
+// pii-rotate перешифровывает персональные данные реквизитов (номер карты, телефон, владелец)
+// активным ключом из конфигурации pii: шифрует открытые значения, оставшиеся с момента до
+// включения шифрования, и значения, зашифрованные прежними ключами. Прежние ключи должны
+// оставаться в конфигурации до завершения ротации. Номера карт в automatic_logs не шифруются,
+// а маскируются: новые записи пишутся маскированными, старые маскируются здесь же.
+//
+//	ORDER_CONFIG_PATH=config/local.yaml go run ./cmd/pii-rotate -batch 500
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/LavaJover/shvark-order-service/internal/config"
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/pii"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres"
+	"gorm.io/gorm"
+)
+
+// piiTables - таблицы с колонками персональных данных реквизитов
+var piiTables = []string{"bank_detail_models", "order_models"}
+
+// piiRow - сырые значения колонок, без расшифровки pii.EncryptedString
+type piiRow struct {
+	ID         string
+	CardNumber string
+	Phone      string
+	Owner      string
+}
+
+func main() {
+	batch := flag.Int("batch", 500, "строк за один запрос")
+	dryRun := flag.Bool("dry-run", false, "только посчитать строки для перешифровки")
+	flag.Parse()
+
+	cfg := config.MustLoad()
+	db := postgres.MustInitDB(cfg)
+
+	piiCipher := pii.Default()
+	if piiCipher == nil {
+		log.Fatalf("pii encryption is not configured: set pii.active_key_id and pii.keys")
+	}
+
+	for _, table := range piiTables {
+		rotated, err := rotateTable(db, piiCipher, table, *batch, *dryRun)
+		if err != nil {
+			log.Fatalf("%s: rotated %d rows before error: %v", table, rotated, err)
+		}
+		log.Printf("%s: %d rows need rotation to key %s, dry run: %v", table, rotated, piiCipher.ActiveKeyID(), *dryRun)
+	}
+
+	masked, err := maskAutomaticLogs(db, *batch, *dryRun)
+	if err != nil {
+		log.Fatalf("automatic_logs: masked %d rows before error: %v", masked, err)
+	}
+	log.Printf("automatic_logs: %d rows need card number masking, dry run: %v", masked, *dryRun)
+}
+
+// maskAutomaticLogs маскирует открытые номера карт в логах автоматических платежей,
+// записанных до маскирования. Маскированный номер всегда содержит '*'
+func maskAutomaticLogs(db *gorm.DB, batch int, dryRun bool) (int, error) {
+	masked := 0
+	lastID := ""
+	for {
+		var rows []piiRow
+		err := db.Table("automatic_logs").
+			Select("id::text as id, card_number").
+			Where("id::text > ? AND COALESCE(card_number, '') <> '' AND card_number NOT LIKE '%*%'", lastID).
+			Order("id::text").
+			Limit(batch).
+			Scan(&rows).Error
+		if err != nil {
+			return masked, err
+		}
+		if len(rows) == 0 {
+			return masked, nil
+		}
+		lastID = rows[len(rows)-1].ID
+
+		for _, row := range rows {
+			masked++
+			if dryRun {
+				continue
+			}
+			if err := db.Table("automatic_logs").Where("id::text = ?", row.ID).
+				Update("card_number", domain.MaskCardNumber(row.CardNumber)).Error; err != nil {
+				return masked - 1, err
+			}
+		}
+	}
+}
+
+// rotateTable обходит таблицу по id (включая удаленные реквизиты) и перешифровывает строки,
+// в которых хотя бы одно значение открыто или зашифровано не активным ключом
+func rotateTable(db *gorm.DB, piiCipher *pii.Cipher, table string, batch int, dryRun bool) (int, error) {
+	rotated := 0
+	lastID := ""
+	for {
+		var rows []piiRow
+		err := db.Table(table).
+			Select("id::text as id, COALESCE(card_number, '') as card_number, COALESCE(phone, '') as phone, COALESCE(owner, '') as owner").
+			Where("id::text > ?", lastID).
+			Order("id::text").
+			Limit(batch).
+			Scan(&rows).Error
+		if err != nil {
+			return rotated, err
+		}
+		if len(rows) == 0 {
+			return rotated, nil
+		}
+		lastID = rows[len(rows)-1].ID
+
+		for _, row := range rows {
+			if !piiCipher.NeedsRotation(row.CardNumber) && !piiCipher.NeedsRotation(row.Phone) && !piiCipher.NeedsRotation(row.Owner) {
+				continue
+			}
+			rotated++
+			if dryRun {
+				continue
+			}
+
+			updates := make(map[string]interface{}, 3)
+			for column, value := range map[string]string{"card_number": row.CardNumber, "phone": row.Phone, "owner": row.Owner} {
+				plaintext, err := piiCipher.Decrypt(value)
+				if err != nil {
+					return rotated - 1, err
+				}
+				// Valuer pii.EncryptedString зашифрует значение активным ключом
+				updates[column] = pii.EncryptedString(plaintext)
+			}
+			if err := db.Table(table).Where("id::text = ?", row.ID).Updates(updates).Error; err != nil {
+				return rotated - 1, err
+			}
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
     "log"
     "time"
     
+    "github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
+    publisher "github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
     "github.com/LavaJover/shvark-order-service/internal/infrastructure/usdt"
     "github.com/LavaJover/shvark-order-service/internal/usecase"
     orderuc "github.com/LavaJover/shvark-order-service/internal/usecase/order"
@@ -15,17 +17,44 @@ type BackgroundTasks struct {
     OrderUsecase    orderuc.OrderUsecase
     DisputeUsecase  disputeuc.DisputeUsecase
     DeviceUsecase   usecase.DeviceUsecase
+    OutboxRelay     *publisher.OutboxRelay
+    OutboxPollInterval time.Duration
+    CallbackQueue   *notifier.CallbackQueue
+    CallbackPollInterval time.Duration
+    WaitlistPollInterval time.Duration
+    UnmatchedPollInterval time.Duration
+    OutboxCleanupInterval time.Duration
+    DeviceAuthUsecase usecase.DeviceAuthUsecase
+    NonceCleanupInterval time.Duration
 }
 
 func NewBackgroundTasks(
     orderUC orderuc.OrderUsecase, 
     disputeUC disputeuc.DisputeUsecase, 
     deviceUC usecase.DeviceUsecase,
+    outboxRelay *publisher.OutboxRelay,
+    outboxPollInterval time.Duration,
+    callbackQueue *notifier.CallbackQueue,
+    callbackPollInterval time.Duration,
+    waitlistPollInterval time.Duration,
+    unmatchedPollInterval time.Duration,
+    outboxCleanupInterval time.Duration,
+    deviceAuthUC usecase.DeviceAuthUsecase,
+    nonceCleanupInterval time.Duration,
 ) *BackgroundTasks {
     return &BackgroundTasks{
         OrderUsecase:   orderUC,
         DisputeUsecase: disputeUC,
         DeviceUsecase:  deviceUC,
+        OutboxRelay:    outboxRelay,
+        OutboxPollInterval: outboxPollInterval,
+        CallbackQueue:  callbackQueue,
+        CallbackPollInterval: callbackPollInterval,
+        WaitlistPollInterval: waitlistPollInterval,
+        UnmatchedPollInterval: unmatchedPollInterval,
+        OutboxCleanupInterval: outboxCleanupInterval,
+        DeviceAuthUsecase: deviceAuthUC,
+        NonceCleanupInterval: nonceCleanupInterval,
     }
 }
 
@@ -34,6 +63,12 @@ func (bt *BackgroundTasks) StartAll(ctx context.Context) {
     go bt.startCryptoRatesUpdate(ctx)
     go bt.startAutoAcceptExpiredDisputes(ctx)
     go bt.startDeviceOfflineCheck(ctx)
+    go bt.startOutboxRelay(ctx)
+    go bt.startOutboxCleanup(ctx)
+    go bt.startCallbackDelivery(ctx)
+    go bt.startWaitlistMatcher(ctx)
+    go bt.startUnmatchedPaymentMatcher(ctx)
+    go bt.startDeviceNonceCleanup(ctx)
 }
 
 func (bt *BackgroundTasks) startOrderAutoCancel(ctx context.Context) {
@@ -101,4 +136,114 @@ func (bt *BackgroundTasks) startDeviceOfflineCheck(ctx context.Context) {
             }
         }
     }
-}
\ No newline at end of file
+}
+
+// startOutboxRelay - доставка событий из outbox в Kafka
+func (bt *BackgroundTasks) startOutboxRelay(ctx context.Context) {
+    ticker := time.NewTicker(bt.OutboxPollInterval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            if err := bt.OutboxRelay.RelayPending(ctx); err != nil {
+                log.Printf("Outbox relay error: %v", err)
+            }
+        }
+    }
+}
+
+// startOutboxCleanup - удаление давно отправленных событий outbox
+func (bt *BackgroundTasks) startOutboxCleanup(ctx context.Context) {
+    if bt.OutboxCleanupInterval <= 0 {
+        return
+    }
+    ticker := time.NewTicker(bt.OutboxCleanupInterval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            if err := bt.OutboxRelay.PurgeSent(ctx); err != nil {
+                log.Printf("Outbox cleanup error: %v", err)
+            }
+        }
+    }
+}
+
+// startCallbackDelivery - доставка колбэков мерчантам из очереди
+func (bt *BackgroundTasks) startCallbackDelivery(ctx context.Context) {
+    ticker := time.NewTicker(bt.CallbackPollInterval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            if err := bt.CallbackQueue.DeliverDue(ctx); err != nil {
+                log.Printf("Callback delivery error: %v", err)
+            }
+        }
+    }
+}
+
+// startWaitlistMatcher - подбор реквизитов для сделок в очереди ожидания.
+// Кроме опроса по таймеру, проход запускается сразу после закрытия или отмены сделки
+func (bt *BackgroundTasks) startWaitlistMatcher(ctx context.Context) {
+    ticker := time.NewTicker(bt.WaitlistPollInterval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        case <-bt.OrderUsecase.CapacityFreed():
+        }
+        if err := bt.OrderUsecase.MatchWaitingOrders(ctx); err != nil {
+            log.Printf("Waitlist matcher error: %v", err)
+        }
+    }
+}
+
+// startUnmatchedPaymentMatcher - сопоставление непривязанных платежей со сделками, назначенными позже
+func (bt *BackgroundTasks) startUnmatchedPaymentMatcher(ctx context.Context) {
+    ticker := time.NewTicker(bt.UnmatchedPollInterval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            if err := bt.OrderUsecase.MatchUnmatchedPayments(ctx); err != nil {
+                log.Printf("Unmatched payment matcher error: %v", err)
+            }
+        }
+    }
+}
+
+// startDeviceNonceCleanup - удаление подписей запросов устройств, повтор которых уже отклоняется по времени
+func (bt *BackgroundTasks) startDeviceNonceCleanup(ctx context.Context) {
+    if bt.NonceCleanupInterval <= 0 {
+        return
+    }
+    ticker := time.NewTicker(bt.NonceCleanupInterval)
+    defer ticker.Stop()
+    
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            if err := bt.DeviceAuthUsecase.PurgeExpiredNonces(); err != nil {
+                log.Printf("Device nonce cleanup error: %v", err)
+            }
+        }
+    }
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/repository/antifraud/rules"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/repository/antifraud/strategies"
 	"github.com/LavaJover/shvark-order-service/internal/usecase"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/cascade"
 )
 
 type AntiFraudSystem struct {
@@ -20,11 +21,22 @@ type AntiFraudSystem struct {
     UseCase     usecase.AntiFraudUseCase
 }
 
-func InitializeAntiFraud(deps *Dependencies) (*AntiFraudSystem, error) {
+func InitializeAntiFraud(deps *Dependencies, trafficCache *usecase.TrafficCache, cascadeUpdater *cascade.CacheUpdater) (*AntiFraudSystem, error) {
     antifraudLogger := slog.Default()
     
     // Создаем engine точно как в исходном коде
     antifraudEngine := engine.NewAntiFraudEngine(deps.DB, antifraudLogger)
+    // Антифрод пишет блокировки трафика напрямую в БД - сбрасываем кэш трафика и обновляем кэш каскада
+    if trafficCache != nil || cascadeUpdater != nil {
+        antifraudEngine.SetTrafficChangedHook(func(traderID string) {
+            if trafficCache != nil {
+                trafficCache.InvalidateTrader(traderID)
+            }
+            if cascadeUpdater != nil {
+                cascadeUpdater.InvalidateTrader(traderID)
+            }
+        })
+    }
     
     // Получаем snapshotManager из engine (как в исходном коде)
     snapshotManager := antifraudEngine.GetSnapshotManager()
@@ -16,6 +16,7 @@ type Dependencies struct {
     DB                  *gorm.DB
     OrderPublisher      *publisher.KafkaPublisher
     DisputePublisher    *publisher.KafkaPublisher
+    EnvelopePublisher   *publisher.KafkaPublisher
     Repositories        *Repositories
 }
 
@@ -27,6 +28,16 @@ type Repositories struct {
     DeviceRepo        domain.DeviceRepository
     DisputeRepo       domain.DisputeRepository
     AntiFraudRepo     domain.AntiFraudRepository
+    OutboxRepo        domain.OutboxRepository
+    CallbackDeliveryRepo domain.CallbackDeliveryRepository
+    CallbackSettingsRepo domain.MerchantCallbackSettingsRepository
+    MatchingSettingsRepo domain.MerchantMatchingSettingsRepository
+    RoutingScoreRepo  domain.RoutingScoreRepository
+    BankDetailHealthRepo domain.BankDetailHealthRepository
+    BinRepo           domain.BinRepository
+    NotificationTemplateRepo domain.NotificationTemplateRepository
+    UnmatchedPaymentRepo domain.UnmatchedPaymentRepository
+    DeviceCredentialRepo domain.DeviceCredentialRepository
 }
 
 func InitializeDependencies() (*Dependencies, error) {
@@ -44,6 +55,11 @@ func InitializeDependencies() (*Dependencies, error) {
         return nil, fmt.Errorf("dispute publisher: %w", err)
     }
     
+    envelopePublisher, err := initEnvelopePublisher(cfg)
+    if err != nil {
+        return nil, fmt.Errorf("envelope publisher: %w", err)
+    }
+    
     repos := &Repositories{
         OrderRepo:         repository.NewDefaultOrderRepository(db),
         BankDetailRepo:    repository.NewDefaultBankDetailRepo(db),
@@ -52,6 +68,16 @@ func InitializeDependencies() (*Dependencies, error) {
         DeviceRepo:        repository.NewDefaultDeviceRepository(db),
         DisputeRepo:       repository.NewDefaultDisputeRepository(db),
         AntiFraudRepo:     repository.NewAntiFraudRepository(db),
+        OutboxRepo:        repository.NewDefaultOutboxRepository(db),
+        CallbackDeliveryRepo: repository.NewDefaultCallbackDeliveryRepository(db),
+        CallbackSettingsRepo: repository.NewDefaultMerchantCallbackSettingsRepository(db),
+        MatchingSettingsRepo: repository.NewDefaultMerchantMatchingSettingsRepository(db),
+        RoutingScoreRepo:  repository.NewDefaultRoutingScoreRepository(db),
+        BankDetailHealthRepo: repository.NewDefaultBankDetailHealthRepository(db),
+        BinRepo:           repository.NewDefaultBinRepository(db),
+        NotificationTemplateRepo: repository.NewDefaultNotificationTemplateRepository(db),
+        UnmatchedPaymentRepo: repository.NewDefaultUnmatchedPaymentRepository(db),
+        DeviceCredentialRepo: repository.NewDefaultDeviceCredentialRepository(db),
     }
     
     return &Dependencies{
@@ -59,6 +85,7 @@ func InitializeDependencies() (*Dependencies, error) {
         DB:               db,
         OrderPublisher:   orderPublisher,
         DisputePublisher: disputePublisher,
+        EnvelopePublisher: envelopePublisher,
         Repositories:     repos,
     }, nil
 }
@@ -85,4 +112,17 @@ func initDisputePublisher(cfg *config.OrderConfig) (*publisher.KafkaPublisher, e
         TLSEnabled: cfg.KafkaService.TLSEnabled,
     }
     return publisher.NewKafkaPublisher(config)
+}
+
+// initEnvelopePublisher - топик версионированных событий EventEnvelope
+func initEnvelopePublisher(cfg *config.OrderConfig) (*publisher.KafkaPublisher, error) {
+    config := publisher.KafkaConfig{
+        Brokers:   []string{fmt.Sprintf("%s:%s", cfg.KafkaService.Host, cfg.KafkaService.Port)},
+        Topic:     "order-events-v1",
+        Username:  cfg.KafkaService.Username,
+        Password:  cfg.KafkaService.Password,
+        Mechanism: cfg.KafkaService.Mechanism,
+        TLSEnabled: cfg.KafkaService.TLSEnabled,
+    }
+    return publisher.NewKafkaPublisher(config)
 }
\ No newline at end of file
@@ -0,0 +1,21 @@
+package setup
+
+import (
+	publisher "github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/metrics"
+)
+
+// InitializeOutboxRelay создает ретранслятор событий из таблицы outbox в Kafka
+func InitializeOutboxRelay(deps *Dependencies) *publisher.OutboxRelay {
+    return publisher.NewOutboxRelay(
+        deps.Repositories.OutboxRepo,
+        deps.OrderPublisher,
+        deps.DisputePublisher,
+        deps.EnvelopePublisher,
+        metrics.NewOutboxMetrics(),
+        deps.Config.OutboxConfig.BatchSize,
+        deps.Config.OutboxConfig.MaxBackoff,
+        deps.Config.OutboxConfig.ClaimLease,
+        deps.Config.OutboxConfig.SentRetention,
+    )
+}
@@ -5,20 +5,39 @@ import (
 
 	"github.com/LavaJover/shvark-order-service/internal/config"
 	"github.com/LavaJover/shvark-order-service/internal/delivery/http/handlers"
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
+	publisher "github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/metrics"
 	"github.com/LavaJover/shvark-order-service/internal/usecase"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/cascade"
 	disputeuc "github.com/LavaJover/shvark-order-service/internal/usecase/dispute"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/health"
 	orderuc "github.com/LavaJover/shvark-order-service/internal/usecase/order"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/routing"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/selection"
 )
 
 type UseCases struct {
     OrderUsecase        orderuc.OrderUsecase
     TrafficUsecase      usecase.TrafficUsecase
     BankDetailUsecase   usecase.BankDetailUsecase
+    BinUsecase          usecase.BinUsecase
+    NotificationTemplateUsecase usecase.NotificationTemplateUsecase
     TeamRelationsUsecase usecase.TeamRelationsUsecase
     DeviceUsecase       usecase.DeviceUsecase
+    DeviceAuthUsecase   usecase.DeviceAuthUsecase
     DisputeUsecase      disputeuc.DisputeUsecase
     AutomaticUsecase    usecase.AutomaticUsecase
+    CallbackQueue       *notifier.CallbackQueue
+    // nil, если каскад подбора реквизитов выключен
+    CascadeUpdater      *cascade.CacheUpdater
+    // nil, если скоры маршрутизации выключены
+    RoutingScores       *routing.Service
+    // nil, если кэш трафика выключен
+    TrafficCache        *usecase.TrafficCache
+    // nil, если приостановка реквизитов по здоровью выключена
+    BankDetailHealth    *health.Monitor
 }
 
 func InitializeUseCases(deps *Dependencies) (*UseCases, error) {
@@ -27,11 +46,59 @@ func InitializeUseCases(deps *Dependencies) (*UseCases, error) {
         return nil, fmt.Errorf("wallet handler: %w", err)
     }
     
-    trafficUsecase := usecase.NewDefaultTrafficUsecase(deps.Repositories.TrafficRepo)
-    bankDetailUsecase := usecase.NewDefaultBankDetailUsecase(deps.Repositories.BankDetailRepo)
+    var trafficCache *usecase.TrafficCache
+    if deps.Config.OrderCreationConfig.TrafficCacheTTL > 0 {
+        trafficCache = usecase.NewTrafficCache(deps.Config.OrderCreationConfig.TrafficCacheTTL)
+    }
+    cascadeEngine, cascadeUpdater := initCascade(deps, walletHandler)
+    // Изменения реквизитов и трафика сразу попадают в кэш каскада
+    var cascadeTraffic usecase.TrafficInvalidator
+    var cascadeBankDetails usecase.BankDetailInvalidator
+    if cascadeUpdater != nil {
+        cascadeTraffic = cascadeUpdater
+        cascadeBankDetails = cascadeUpdater
+    }
+    trafficUsecase := usecase.NewDefaultTrafficUsecase(deps.Repositories.TrafficRepo, trafficCache, cascadeTraffic)
+    bankDetailUsecase := usecase.NewDefaultBankDetailUsecase(deps.Repositories.BankDetailRepo, deps.Repositories.TrafficRepo, deps.Repositories.BinRepo, cascadeBankDetails)
+    binUsecase := usecase.NewDefaultBinUsecase(deps.Repositories.BinRepo)
+    notificationTemplateUsecase := usecase.NewDefaultNotificationTemplateUsecase(
+        deps.Repositories.NotificationTemplateRepo,
+        deps.Config.NotificationParserConfig.CacheTTL,
+    )
+    // Шаблоны доступны через API всегда, сверка уведомлений - только если включена
+    var notificationParser usecase.NotificationTemplateUsecase
+    if deps.Config.NotificationParserConfig.Enabled {
+        notificationParser = notificationTemplateUsecase
+    }
     teamRelationsUsecase := usecase.NewDefaultTeamRelationsUsecase(deps.Repositories.TeamRelationsRepo)
     deviceUsecase := usecase.NewDefaultDeviceUsecase(deps.Repositories.DeviceRepo)
+    deviceAuthUsecase, err := usecase.NewDefaultDeviceAuthUsecase(
+        deps.Repositories.DeviceRepo,
+        deps.Repositories.DeviceCredentialRepo,
+        deps.Config.DeviceAuthConfig.Mode,
+        deps.Config.DeviceAuthConfig.ReplayWindow,
+        deps.Config.DeviceAuthConfig.RotationGrace,
+    )
+    if err != nil {
+        return nil, err
+    }
     orderMetrics := metrics.NewOrderMetrics()
+    callbackQueue := notifier.NewCallbackQueue(
+        deps.Repositories.CallbackDeliveryRepo,
+        deps.Repositories.CallbackSettingsRepo,
+        deps.Config.CallbackConfig.BatchSize,
+        deps.Config.CallbackConfig.MaxAttempts,
+        deps.Config.CallbackConfig.BaseDelay,
+        deps.Config.CallbackConfig.MaxDelay,
+        deps.Config.CallbackConfig.ClaimLease,
+    )
+    eventWriter := publisher.NewEventWriter(deps.Config.KafkaService.EventFormat, deps.Config.KafkaService.FullRequisiteEvents)
+    routingScores := initRoutingScores(deps)
+    bankDetailHealth := initBankDetailHealth(deps, eventWriter, cascadeEngine)
+    selectionStrategy := domain.SelectionStrategy(deps.Config.OrderCreationConfig.SelectionStrategy)
+    if !selectionStrategy.Valid() {
+        return nil, fmt.Errorf("unknown selection strategy: %s", selectionStrategy)
+    }
     
     orderUsecase := orderuc.NewDefaultOrderUsecase(
         deps.Repositories.OrderRepo,
@@ -41,6 +108,23 @@ func InitializeUseCases(deps *Dependencies) (*UseCases, error) {
         deps.OrderPublisher,
         teamRelationsUsecase,
         orderMetrics,
+        deps.Repositories.OutboxRepo,
+        callbackQueue,
+        deps.Repositories.CallbackSettingsRepo,
+        eventWriter,
+        deps.Config.RecoveryConfig.AutoRecoverWindow,
+        deps.Config.OrderCreationConfig.FreezeMaxAttempts,
+        deps.Repositories.MatchingSettingsRepo,
+        deps.Config.WaitlistConfig.DefaultWaitWindow,
+        deps.Config.WaitlistConfig.BatchSize,
+        cascadeEngine,
+        selection.NewRegistry(selectionStrategy, deps.Config.OrderCreationConfig.SelectionSeed),
+        routingScores,
+        bankDetailHealth,
+        notificationParser,
+        automaticMatchingPolicy(deps.Config.AutomaticMatchingConfig),
+        deps.Repositories.UnmatchedPaymentRepo,
+        deps.Config.UnmatchedPaymentsConfig.DeferredMatchWindow,
     )
     
     disputeUsecase := disputeuc.NewDefaultDisputeUsecase(
@@ -51,6 +135,9 @@ func InitializeUseCases(deps *Dependencies) (*UseCases, error) {
         deps.DisputePublisher,
         teamRelationsUsecase,
         deps.Repositories.BankDetailRepo,
+        callbackQueue,
+        eventWriter,
+        bankDetailHealth,
     )
     
     automaticUsecase := usecase.NewDefaultAutomaticUsecase(deps.Repositories.OrderRepo)
@@ -59,13 +146,98 @@ func InitializeUseCases(deps *Dependencies) (*UseCases, error) {
         OrderUsecase:        orderUsecase,
         TrafficUsecase:      trafficUsecase,
         BankDetailUsecase:   bankDetailUsecase,
+        BinUsecase:          binUsecase,
+        NotificationTemplateUsecase: notificationTemplateUsecase,
         TeamRelationsUsecase: teamRelationsUsecase,
         DeviceUsecase:       deviceUsecase,
+        DeviceAuthUsecase:   deviceAuthUsecase,
         DisputeUsecase:      disputeUsecase,
         AutomaticUsecase:    automaticUsecase,
+        CallbackQueue:       callbackQueue,
+        CascadeUpdater:      cascadeUpdater,
+        RoutingScores:       routingScores,
+        TrafficCache:        trafficCache,
+        BankDetailHealth:    bankDetailHealth,
     }, nil
 }
 
+// initCascade создает in-memory каскад подбора реквизитов, если он включен в конфиге
+func initCascade(deps *Dependencies, walletHandler *handlers.HTTPWalletHandler) (*cascade.CascadeMatchEngine, *cascade.CacheUpdater) {
+    if !deps.Config.CascadeConfig.Enabled {
+        return nil, nil
+    }
+
+    cascadeMetrics := metrics.NewCascadeMetrics()
+    cache := cascade.NewBankDetailCache()
+    updater := cascade.NewCacheUpdater(
+        cache,
+        deps.Repositories.BankDetailRepo,
+        deps.Repositories.TrafficRepo,
+        deps.Repositories.OrderRepo,
+        walletHandler,
+        cascadeMetrics,
+        deps.Config.CascadeConfig.RefreshInterval,
+    )
+    return cascade.NewCascadeMatchEngine(cache, cascadeMetrics), updater
+}
+
+// initRoutingScores создает пересчет скоров маршрутизации, если он включен в конфиге
+func initRoutingScores(deps *Dependencies) *routing.Service {
+    if !deps.Config.RoutingScoreConfig.Enabled {
+        return nil
+    }
+    return routing.NewService(
+        deps.Repositories.RoutingScoreRepo,
+        deps.Config.RoutingScoreConfig.Window,
+        deps.Config.RoutingScoreConfig.MinOrders,
+        deps.Config.RoutingScoreConfig.RefreshInterval,
+    )
+}
+
+// initBankDetailHealth создает отслеживание здоровья реквизитов, если оно включено в конфиге
+func initBankDetailHealth(deps *Dependencies, eventWriter *publisher.EventWriter, cascadeEngine *cascade.CascadeMatchEngine) *health.Monitor {
+    cfg := deps.Config.BankDetailHealthConfig
+    if !cfg.Enabled {
+        return nil
+    }
+    return health.NewMonitor(
+        deps.Repositories.BankDetailHealthRepo,
+        deps.Repositories.BankDetailRepo,
+        deps.Repositories.OrderRepo,
+        deps.Repositories.OutboxRepo,
+        eventWriter,
+        domain.BankDetailHealthPolicy{
+            MaxConsecutiveCancels: cfg.MaxConsecutiveCancels,
+            MaxWindowCancels:      cfg.MaxWindowCancels,
+            CancelWindow:          cfg.CancelWindow,
+            MaxWindowDisputes:     cfg.MaxWindowDisputes,
+            DisputeWindow:         cfg.DisputeWindow,
+            Cooldown:              cfg.Cooldown,
+        },
+        cfg.CheckInterval,
+        cascadeEngine,
+    )
+}
+
+func automaticMatchingPolicy(cfg config.AutomaticMatchingConfig) domain.AutomaticMatchingPolicy {
+    bankTolerances := make(map[string]domain.AmountTolerance, len(cfg.BankTolerances))
+    for bankCode, tolerance := range cfg.BankTolerances {
+        bankTolerances[bankCode] = domain.AmountTolerance{
+            Absolute: tolerance.AmountTolerance,
+            Percent:  tolerance.AmountTolerancePercent,
+        }
+    }
+    return domain.AutomaticMatchingPolicy{
+        DefaultTolerance: domain.AmountTolerance{
+            Absolute: cfg.AmountTolerance,
+            Percent:  cfg.AmountTolerancePercent,
+        },
+        BankTolerances: bankTolerances,
+        Window:         cfg.MatchWindow,
+        ClockSkew:      cfg.ClockSkew,
+    }
+}
+
 func initWalletHandler(cfg *config.OrderConfig) (*handlers.HTTPWalletHandler, error) {
     return handlers.NewHTTPWalletHandler(fmt.Sprintf("%s:%s", cfg.WalletService.Host, cfg.WalletService.Port))
 }
\ No newline at end of file
@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"time"
+
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
@@ -14,6 +16,19 @@ type OrderConfig struct {
 	BankingService `yaml:"banking-service"`
 	WalletService  `yaml:"wallet-service"`
 	KafkaService   `yaml:"kafka-service"`
+	OutboxConfig   `yaml:"outbox"`
+	CallbackConfig `yaml:"callbacks"`
+	RecoveryConfig `yaml:"recovery"`
+	OrderCreationConfig `yaml:"order_creation"`
+	WaitlistConfig `yaml:"waitlist"`
+	CascadeConfig  `yaml:"cascade"`
+	RoutingScoreConfig `yaml:"routing_scores"`
+	BankDetailHealthConfig `yaml:"bank_detail_health"`
+	PIIConfig 	   `yaml:"pii"`
+	NotificationParserConfig `yaml:"notification_parser"`
+	AutomaticMatchingConfig `yaml:"automatic_matching"`
+	UnmatchedPaymentsConfig `yaml:"unmatched_payments"`
+	DeviceAuthConfig 	   `yaml:"device_auth"`
 }
 
 type KafkaService struct {
@@ -23,6 +38,146 @@ type KafkaService struct {
 	Password 	string `yaml:"password"`
 	Mechanism 	string `yaml:"mechanism"`
 	TLSEnabled 	bool   `yaml:"tls_enabled"`
+	// Формат событий: legacy, envelope или both (на время миграции потребителей)
+	EventFormat string `yaml:"event_format" env-default:"legacy"`
+	// Потребители (order, dispute, envelope), получающие в событиях полные реквизиты, остальным они маскируются.
+	// По умолчанию реквизиты маскируются всем: потребителей с полными реквизитами оператор включает явно
+	FullRequisiteEvents []string `yaml:"full_requisite_events"`
+}
+
+// OutboxConfig - параметры ретранслятора outbox-событий в Kafka
+type OutboxConfig struct {
+	BatchSize 		int 			`yaml:"batch_size" env-default:"100"`
+	PollInterval 	time.Duration 	`yaml:"poll_interval" env-default:"1s"`
+	MaxBackoff 		time.Duration 	`yaml:"max_backoff" env-default:"5m"`
+	// На сколько экземпляр забирает событие на отправку, после этого его может отправить другой экземпляр
+	ClaimLease 		time.Duration 	`yaml:"claim_lease" env-default:"5m"`
+	// Сколько хранятся отправленные события (0 - не удаляются) и как часто они удаляются
+	SentRetention 	time.Duration 	`yaml:"sent_retention" env-default:"72h"`
+	CleanupInterval time.Duration 	`yaml:"cleanup_interval" env-default:"1h"`
+}
+
+// CallbackConfig - параметры доставки колбэков мерчантам
+type CallbackConfig struct {
+	BatchSize 		int 			`yaml:"batch_size" env-default:"50"`
+	PollInterval 	time.Duration 	`yaml:"poll_interval" env-default:"2s"`
+	MaxAttempts 	int 			`yaml:"max_attempts" env-default:"15"`
+	BaseDelay 		time.Duration 	`yaml:"base_delay" env-default:"30s"`
+	MaxDelay 		time.Duration 	`yaml:"max_delay" env-default:"2h"`
+	// На сколько экземпляр забирает пачку колбэков на отправку, после этого их может отправить другой экземпляр.
+	// Пачка отправляется последовательно, поэтому lease должен быть больше batch_size x таймаут запроса (20s)
+	ClaimLease 		time.Duration 	`yaml:"claim_lease" env-default:"20m"`
+}
+
+// RecoveryConfig - восстановление отмененных сделок, оплаченных с опозданием
+type RecoveryConfig struct {
+	// Сколько после отмены автоматика может закрыть сделку по уведомлению об оплате (0 - выключено)
+	AutoRecoverWindow 	time.Duration 	`yaml:"auto_recover_window" env-default:"30m"`
+}
+
+// OrderCreationConfig - параметры создания сделок
+type OrderCreationConfig struct {
+	// Сколько реквизитов попробовать, если заморозка средств трейдера не удалась
+	FreezeMaxAttempts 	int 	`yaml:"freeze_max_attempts" env-default:"3"`
+	// Стратегия выбора реквизита и трейдера, если мерчант не задал свою
+	SelectionStrategy 	string 	`yaml:"selection_strategy" env-default:"weighted_random"`
+	// Зерно генератора случайных чисел стратегий (0 - от текущего времени)
+	SelectionSeed 		int64 	`yaml:"selection_seed" env-default:"0"`
+	// Сколько живет запись кэша трафика на экземпляре сервиса (0 - без кэша)
+	TrafficCacheTTL 	time.Duration 	`yaml:"traffic_cache_ttl" env-default:"5s"`
+}
+
+// WaitlistConfig - ожидание реквизитов для сделок мерчантов, включивших этот режим
+type WaitlistConfig struct {
+	// Окно ожидания, если мерчант не задал свое
+	DefaultWaitWindow 	time.Duration 	`yaml:"default_wait_window" env-default:"3m"`
+	PollInterval 		time.Duration 	`yaml:"poll_interval" env-default:"5s"`
+	BatchSize 			int 			`yaml:"batch_size" env-default:"50"`
+}
+
+// CascadeConfig - подбор реквизитов по in-memory кэшу вместо SQL-запроса с блокировкой
+type CascadeConfig struct {
+	Enabled 			bool 			`yaml:"enabled" env-default:"false"`
+	// Как часто кэш полностью перезагружается из БД и кошелька
+	RefreshInterval 	time.Duration 	`yaml:"refresh_interval" env-default:"30s"`
+}
+
+// RoutingScoreConfig - множитель веса кандидата по истории его сделок
+type RoutingScoreConfig struct {
+	Enabled 			bool 			`yaml:"enabled" env-default:"false"`
+	RefreshInterval 	time.Duration 	`yaml:"refresh_interval" env-default:"10m"`
+	// За какой период учитываются сделки
+	Window 				time.Duration 	`yaml:"window" env-default:"168h"`
+	// Минимум закрытых сделок, чтобы скор отличался от нейтрального
+	MinOrders 			int 			`yaml:"min_orders" env-default:"20"`
+}
+
+// BankDetailHealthConfig - автоматическая приостановка реквизита после серии отмен и споров, 0 - порог выключен
+type BankDetailHealthConfig struct {
+	Enabled 				bool 			`yaml:"enabled" env-default:"false"`
+	MaxConsecutiveCancels 	int32 			`yaml:"max_consecutive_cancels" env-default:"5"`
+	MaxWindowCancels 		int32 			`yaml:"max_window_cancels" env-default:"10"`
+	CancelWindow 			time.Duration 	`yaml:"cancel_window" env-default:"1h"`
+	MaxWindowDisputes 		int32 			`yaml:"max_window_disputes" env-default:"3"`
+	DisputeWindow 			time.Duration 	`yaml:"dispute_window" env-default:"24h"`
+	// На сколько приостанавливается реквизит
+	Cooldown 				time.Duration 	`yaml:"cooldown" env-default:"1h"`
+	// Как часто снимаются истекшие приостановки
+	CheckInterval 			time.Duration 	`yaml:"check_interval" env-default:"1m"`
+}
+
+// PIIConfig - шифрование персональных данных реквизитов в БД (номер карты, телефон, владелец) и секретов устройств
+type PIIConfig struct {
+	// Ключ, которым шифруются новые значения. Пусто - шифрование выключено
+	ActiveKeyID 	string 				`yaml:"active_key_id" env:"PII_ACTIVE_KEY_ID"`
+	// id ключа -> 32 байта ключа в base64. Старые ключи нужны для чтения до ротации (cmd/pii-rotate)
+	Keys 			map[string]string 	`yaml:"keys" env:"PII_ENCRYPTION_KEYS"`
+}
+
+// NotificationParserConfig - серверный разбор уведомлений банков шаблонами для сверки с данными устройства
+type NotificationParserConfig struct {
+	// Выключен - шаблонами можно управлять, но уведомления обрабатываются только по данным устройства
+	Enabled 	bool 			`yaml:"enabled" env-default:"false"`
+	// Как часто экземпляр перечитывает шаблоны, измененные на других экземплярах
+	CacheTTL 	time.Duration 	`yaml:"cache_ttl" env-default:"1m"`
+}
+
+// AutomaticMatchingConfig - сопоставление уведомлений об оплате со сделками. Допуск суммы - большее из
+// абсолютного и процентного, допуск в параметрах трафика важнее допуска банка и допуска по умолчанию
+type AutomaticMatchingConfig struct {
+	AmountTolerance 		float64 						`yaml:"amount_tolerance" env-default:"0"`
+	AmountTolerancePercent 	float64 						`yaml:"amount_tolerance_percent" env-default:"0"`
+	// Код банка (payment_system в уведомлении) -> допуск банка
+	BankTolerances 			map[string]AmountToleranceConfig 	`yaml:"bank_tolerances"`
+	// Сделка должна быть создана не раньше чем за match_window до получения уведомления, 0 - без ограничения
+	MatchWindow 			time.Duration 					`yaml:"match_window" env-default:"2h"`
+	// Допустимое отставание часов устройства: сделка может быть создана чуть позже времени уведомления
+	ClockSkew 				time.Duration 					`yaml:"clock_skew" env-default:"1m"`
+}
+
+type AmountToleranceConfig struct {
+	AmountTolerance 		float64 `yaml:"amount_tolerance"`
+	AmountTolerancePercent 	float64 `yaml:"amount_tolerance_percent"`
+}
+
+// UnmatchedPaymentsConfig - уведомления об оплате, для которых не нашлось сделки
+type UnmatchedPaymentsConfig struct {
+	// Сколько платеж сопоставляется со сделками, назначенными на устройство позже, 0 - только вручную
+	DeferredMatchWindow 	time.Duration 	`yaml:"deferred_match_window" env-default:"10m"`
+	PollInterval 			time.Duration 	`yaml:"poll_interval" env-default:"15s"`
+}
+
+// DeviceAuthConfig - подпись запросов приложения устройства (ProcessAutomaticPayment, UpdateDeviceLiveness)
+type DeviceAuthConfig struct {
+	// off - не проверять, log - проверять и писать отказы в аудит, enforce - отклонять запросы без верной подписи
+	Mode 			string 			`yaml:"mode" env:"DEVICE_AUTH_MODE" env-default:"off"`
+	// Допустимое расхождение времени подписи с временем сервера, в этом окне повтор запроса отклоняется
+	// на всех экземплярах: подписи принятых запросов хранятся в device_request_nonces
+	ReplayWindow 	time.Duration 	`yaml:"replay_window" env-default:"5m"`
+	// Сколько прежний секрет действует после ротации
+	RotationGrace 	time.Duration 	`yaml:"rotation_grace" env-default:"24h"`
+	// Как часто удалять подписи принятых запросов, срок повтора которых истек, 0 - не удалять
+	NonceCleanupInterval 	time.Duration 	`yaml:"nonce_cleanup_interval" env-default:"5m"`
 }
 
 type GRPCServer struct {
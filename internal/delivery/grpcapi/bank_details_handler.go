@@ -2,23 +2,31 @@ package grpcapi
 
 import (
 	"context"
+	"errors"
 	"math"
 	"time"
 
 	"github.com/LavaJover/shvark-order-service/internal/delivery/grpcapi/mappers"
+	"github.com/LavaJover/shvark-order-service/internal/domain"
 	"github.com/LavaJover/shvark-order-service/internal/usecase"
 	bankdetaildto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/bank_detail"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/health"
 	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type BankDetailHandler struct {
 	bankDetailUsecase usecase.BankDetailUsecase
+	// nil - приостановка реквизитов по здоровью выключена
+	health *health.Monitor
+	binUsecase usecase.BinUsecase
 	orderpb.UnimplementedBankDetailServiceServer
 }
 
-func NewBankDetailHandler(bankDetailUsecase usecase.BankDetailUsecase) *BankDetailHandler {
-	return &BankDetailHandler{bankDetailUsecase: bankDetailUsecase}
+func NewBankDetailHandler(bankDetailUsecase usecase.BankDetailUsecase, healthMonitor *health.Monitor, binUsecase usecase.BinUsecase) *BankDetailHandler {
+	return &BankDetailHandler{bankDetailUsecase: bankDetailUsecase, health: healthMonitor, binUsecase: binUsecase}
 }
 
 func (h *BankDetailHandler) CreateBankDetail(ctx context.Context, r *orderpb.CreateBankDetailRequest) (*orderpb.CreateBankDetailResponse, error) {
@@ -29,10 +37,16 @@ func (h *BankDetailHandler) CreateBankDetail(ctx context.Context, r *orderpb.Cre
 			MaxAmountMonth: r.MaxAmountMonth,
 			MaxQuantityDay: int32(r.MaxQuantityDay),
 			MaxQuantityMonth: int32(r.MaxQuantityMonth),
+			MaxAmountHour: r.MaxAmountHour,
+			MaxQuantityHour: r.MaxQuantityHour,
+			MaxAmountWeek: r.MaxAmountWeek,
+			MaxQuantityWeek: r.MaxQuantityWeek,
 			MinOrderAmount: float32(r.MinAmount),
 			MaxOrderAmount: float32(r.MaxAmount),
 			Delay: r.Delay.AsDuration(),
 			Enabled: r.Enabled,
+			LimitMode: r.LimitMode,
+			Timezone: r.Timezone,
 		},
 		DeviceInfo: bankdetaildto.DeviceInfo{
 			DeviceID: r.DeviceId,
@@ -58,7 +72,7 @@ func (h *BankDetailHandler) CreateBankDetail(ctx context.Context, r *orderpb.Cre
 
 	err := h.bankDetailUsecase.CreateBankDetail(&createBankDetailInput)
 	if err != nil {
-		return nil, err
+		return nil, bankDetailStatusError(err)
 	}
 	return &orderpb.CreateBankDetailResponse{
 		BankDetailId: "",
@@ -77,10 +91,16 @@ func (h *BankDetailHandler) UpdateBankDetail(ctx context.Context, r *orderpb.Upd
 			MaxAmountMonth: r.BankDetail.MaxAmountMonth,
 			MaxQuantityDay: int32(r.BankDetail.MaxQuantityDay),
 			MaxQuantityMonth: int32(r.BankDetail.MaxQuantityMonth),
+			MaxAmountHour: r.BankDetail.MaxAmountHour,
+			MaxQuantityHour: r.BankDetail.MaxQuantityHour,
+			MaxAmountWeek: r.BankDetail.MaxAmountWeek,
+			MaxQuantityWeek: r.BankDetail.MaxQuantityWeek,
 			MinOrderAmount: float32(r.BankDetail.MinAmount),
 			MaxOrderAmount: float32(r.BankDetail.MaxAmount),
 			Delay: r.BankDetail.Delay.AsDuration(),
 			Enabled: r.BankDetail.Enabled,
+			LimitMode: r.BankDetail.LimitMode,
+			Timezone: r.BankDetail.Timezone,
 		},
 		DeviceInfo: bankdetaildto.DeviceInfo{
 			DeviceID: r.BankDetail.DeviceId,
@@ -105,12 +125,20 @@ func (h *BankDetailHandler) UpdateBankDetail(ctx context.Context, r *orderpb.Upd
 	}
 	err := h.bankDetailUsecase.UpdateBankDetail(&updateBankDetailInput)
 	if err != nil {
-		return nil, err
+		return nil, bankDetailStatusError(err)
 	}
 
 	return &orderpb.UpdateBankDetailResponse{}, nil
 }
 
+// bankDetailStatusError - неверные параметры реквизита возвращаются как InvalidArgument
+func bankDetailStatusError(err error) error {
+	if errors.Is(err, domain.ErrInvalidBankDetail) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
 func (h *BankDetailHandler) DeleteBankDetail(ctx context.Context, r *orderpb.DeleteBankDetailRequest) (*orderpb.DeleteBankDetailResponse, error) {
 	bankDetailID := r.BankDetailId
 	err := h.bankDetailUsecase.DeleteBankDetail(bankDetailID)
@@ -152,6 +180,12 @@ func (h *BankDetailHandler) GetBankDetailByID(ctx context.Context, r *orderpb.Ge
 			InflowCurrency: bankDetail.InflowCurrency,
 			BankCode: bankDetail.BankCode,
 			NspkCode: bankDetail.NspkCode,
+			MaxAmountHour: bankDetail.MaxAmountHour,
+			MaxQuantityHour: bankDetail.MaxQuantityHour,
+			MaxAmountWeek: bankDetail.MaxAmountWeek,
+			MaxQuantityWeek: bankDetail.MaxQuantityWeek,
+			LimitMode: string(bankDetail.LimitMode),
+			Timezone: bankDetail.Timezone,
 		},
 	}, nil
 }
@@ -194,6 +228,12 @@ func (h *BankDetailHandler) GetBankDetailsByTraderID(ctx context.Context, r *ord
 			InflowCurrency: bankDetail.InflowCurrency,
 			BankCode: bankDetail.BankCode,
 			NspkCode: bankDetail.NspkCode,
+			MaxAmountHour: bankDetail.MaxAmountHour,
+			MaxQuantityHour: bankDetail.MaxQuantityHour,
+			MaxAmountWeek: bankDetail.MaxAmountWeek,
+			MaxQuantityWeek: bankDetail.MaxQuantityWeek,
+			LimitMode: string(bankDetail.LimitMode),
+			Timezone: bankDetail.Timezone,
 		}
 	}
 
@@ -260,4 +300,95 @@ func (h *BankDetailHandler) GetBankDetails(ctx context.Context, r *orderpb.GetBa
 	}
 
 	return response, nil
-}
\ No newline at end of file
+}
+
+func (h *BankDetailHandler) SetBankDetailSchedule(ctx context.Context, r *orderpb.SetBankDetailScheduleRequest) (*orderpb.SetBankDetailScheduleResponse, error) {
+	if r.Schedule == nil {
+		return nil, status.Error(codes.InvalidArgument, "schedule is required")
+	}
+	if err := h.bankDetailUsecase.SetBankDetailSchedule(r.BankDetailId, mappers.ToDomainSchedule(r.Schedule)); err != nil {
+		return nil, err
+	}
+
+	return &orderpb.SetBankDetailScheduleResponse{}, nil
+}
+
+func (h *BankDetailHandler) GetBankDetailSchedule(ctx context.Context, r *orderpb.GetBankDetailScheduleRequest) (*orderpb.GetBankDetailScheduleResponse, error) {
+	schedule, err := h.bankDetailUsecase.GetBankDetailSchedule(r.BankDetailId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.GetBankDetailScheduleResponse{
+		Schedule: mappers.ToProtoSchedule(schedule),
+	}, nil
+}
+
+func (h *BankDetailHandler) DeleteBankDetailSchedule(ctx context.Context, r *orderpb.DeleteBankDetailScheduleRequest) (*orderpb.DeleteBankDetailScheduleResponse, error) {
+	if err := h.bankDetailUsecase.DeleteBankDetailSchedule(r.BankDetailId); err != nil {
+		return nil, err
+	}
+
+	return &orderpb.DeleteBankDetailScheduleResponse{}, nil
+}
+
+func (h *BankDetailHandler) GetEffectiveAvailability(ctx context.Context, r *orderpb.GetEffectiveAvailabilityRequest) (*orderpb.GetEffectiveAvailabilityResponse, error) {
+	if r.TraderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "trader_id is required")
+	}
+	availability, err := h.bankDetailUsecase.GetEffectiveAvailability(r.TraderId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get availability: %v", err)
+	}
+
+	return mappers.ToProtoTraderAvailability(availability), nil
+}
+
+func (h *BankDetailHandler) GetBankDetailHealth(ctx context.Context, r *orderpb.GetBankDetailHealthRequest) (*orderpb.GetBankDetailHealthResponse, error) {
+	if h.health == nil {
+		return nil, status.Error(codes.FailedPrecondition, "bank detail health tracking is disabled")
+	}
+	if r.BankDetailId == "" {
+		return nil, status.Error(codes.InvalidArgument, "bank_detail_id is required")
+	}
+	bankDetailHealth, err := h.health.GetHealth(r.BankDetailId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get bank detail health: %v", err)
+	}
+
+	return &orderpb.GetBankDetailHealthResponse{
+		Health: mappers.ToProtoBankDetailHealth(bankDetailHealth),
+	}, nil
+}
+
+func (h *BankDetailHandler) ResumeBankDetail(ctx context.Context, r *orderpb.ResumeBankDetailRequest) (*orderpb.ResumeBankDetailResponse, error) {
+	if h.health == nil {
+		return nil, status.Error(codes.FailedPrecondition, "bank detail health tracking is disabled")
+	}
+	if r.BankDetailId == "" {
+		return nil, status.Error(codes.InvalidArgument, "bank_detail_id is required")
+	}
+	bankDetailHealth, err := h.health.Resume(r.BankDetailId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to resume bank detail: %v", err)
+	}
+
+	return &orderpb.ResumeBankDetailResponse{
+		Health: mappers.ToProtoBankDetailHealth(bankDetailHealth),
+	}, nil
+}
+
+func (h *BankDetailHandler) LookupBin(ctx context.Context, r *orderpb.LookupBinRequest) (*orderpb.LookupBinResponse, error) {
+	bin, err := h.binUsecase.LookupBin(r.CardNumber)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if bin == nil {
+		return &orderpb.LookupBinResponse{Found: false}, nil
+	}
+
+	return &orderpb.LookupBinResponse{
+		Found: true,
+		Bin:   mappers.ToProtoBin(bin),
+	}, nil
+}
@@ -0,0 +1,173 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/usecase"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Заголовки подписи запроса устройства. Подпись - domain.DeviceRequestSignature от полного имени
+// метода, времени (unix-секунды) и канонической формы тела запроса (canonicalPayload)
+const (
+	DeviceIDHeader        = "x-device-id"
+	DeviceTimestampHeader = "x-device-timestamp"
+	DeviceSignatureHeader = "x-device-signature"
+)
+
+// deviceMethod - разбор запроса метода, который вызывает приложение устройства
+type deviceMethod struct {
+	// Устройство и трейдер, от имени которых отправлен запрос
+	claims func(req interface{}) (deviceID, traderID string)
+	// Подписываемые поля запроса в порядке номеров полей proto
+	payload func(req interface{}, p *canonicalPayload)
+}
+
+// deviceMethods - методы, которые вызывает приложение устройства. Новое поле запроса нужно
+// добавить и в payload, иначе оно не будет защищено подписью
+var deviceMethods = map[string]deviceMethod{
+	orderpb.OrderService_ProcessAutomaticPayment_FullMethodName: {
+		claims: func(req interface{}) (string, string) {
+			r := req.(*orderpb.ProcessAutomaticPaymentRequest)
+			return r.Group, r.TraderId
+		},
+		payload: func(req interface{}, p *canonicalPayload) {
+			r := req.(*orderpb.ProcessAutomaticPaymentRequest)
+			p.value(r.Group)
+			p.float(r.Amount)
+			p.value(r.PaymentSystem)
+			p.value(r.Direction)
+			p.list(r.Methods)
+			p.int(r.ReceivedAt)
+			p.value(r.Text)
+			p.dict(r.Metadata)
+			p.value(r.TraderId)
+		},
+	},
+	orderpb.DeviceService_UpdateDeviceLiveness_FullMethodName: {
+		claims: func(req interface{}) (string, string) {
+			return req.(*orderpb.UpdateDeviceLivenessRequest).DeviceId, ""
+		},
+		payload: func(req interface{}, p *canonicalPayload) {
+			p.value(req.(*orderpb.UpdateDeviceLivenessRequest).DeviceId)
+		},
+	},
+}
+
+// canonicalPayload - каноническая форма тела запроса для подписи. Сериализация protobuf
+// (даже детерминированная) отличается между языками и версиями библиотек, поэтому подписывается
+// не она, а поля запроса:
+//   - строка - "<длина в байтах UTF-8>:<строка>", без разделителей между полями;
+//   - целое - десятичная запись как строка: 1718000000 -> "10:1718000000";
+//   - дробное - кратчайшая десятичная запись без экспоненты и без завершающих нулей
+//     как строка: 1500 -> "4:1500", 1500.5 -> "6:1500.5";
+//   - список - число элементов как целое, затем элементы;
+//   - map - число пар как целое, затем ключ и значение каждой пары по возрастанию ключа (побайтово).
+//
+// Пустое поле пишется как "0:", пустой список или map - как "1:0"
+type canonicalPayload struct {
+	bytes.Buffer
+}
+
+func (p *canonicalPayload) value(v string) {
+	fmt.Fprintf(&p.Buffer, "%d:%s", len(v), v)
+}
+
+func (p *canonicalPayload) int(v int64) {
+	p.value(strconv.FormatInt(v, 10))
+}
+
+func (p *canonicalPayload) float(v float64) {
+	p.value(strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+func (p *canonicalPayload) list(values []string) {
+	p.int(int64(len(values)))
+	for _, v := range values {
+		p.value(v)
+	}
+}
+
+func (p *canonicalPayload) dict(values map[string]string) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	p.int(int64(len(keys)))
+	for _, key := range keys {
+		p.value(key)
+		p.value(values[key])
+	}
+}
+
+// DeviceAuthInterceptor проверяет подпись запросов устройств. В режиме log запрос с неверной
+// подписью выполняется, отказ только пишется в аудит; в режиме enforce запрос отклоняется
+func DeviceAuthInterceptor(authUc usecase.DeviceAuthUsecase) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method, ok := deviceMethods[info.FullMethod]
+		if !ok || authUc.Mode() == domain.DeviceAuthModeOff {
+			return handler(ctx, req)
+		}
+
+		if err := authenticateDevice(ctx, authUc, info.FullMethod, req, method); err != nil {
+			if authUc.Mode() == domain.DeviceAuthModeEnforce {
+				return nil, err
+			}
+			slog.Warn("device request not authenticated, allowed in log mode", "method", info.FullMethod, "error", err.Error())
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authenticateDevice(ctx context.Context, authUc usecase.DeviceAuthUsecase, fullMethod string, req interface{}, method deviceMethod) error {
+	var payload canonicalPayload
+	method.payload(req, &payload)
+
+	authReq := &domain.DeviceAuthRequest{
+		Method:  fullMethod,
+		Payload: payload.Bytes(),
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		authReq.DeviceID = firstHeader(md, DeviceIDHeader)
+		authReq.Timestamp = firstHeader(md, DeviceTimestampHeader)
+		authReq.Signature = firstHeader(md, DeviceSignatureHeader)
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		authReq.PeerAddr = p.Addr.String()
+	}
+	authReq.ClaimedDeviceID, authReq.ClaimedTraderID = method.claims(req)
+
+	_, err := authUc.AuthenticateDevice(authReq)
+	var authErr *domain.DeviceAuthError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &authErr):
+		if authErr.Reason == domain.DeviceAuthDeviceMismatch || authErr.Reason == domain.DeviceAuthTraderMismatch {
+			return status.Error(codes.PermissionDenied, authErr.Error())
+		}
+		return status.Error(codes.Unauthenticated, authErr.Error())
+	default:
+		return status.Errorf(codes.Unavailable, "failed to authenticate device: %v", err)
+	}
+}
+
+func firstHeader(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,193 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestCanonicalPayload(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(p *canonicalPayload)
+		want  string
+	}{
+		{name: "string", write: func(p *canonicalPayload) { p.value("SBP") }, want: "3:SBP"},
+		{name: "empty string", write: func(p *canonicalPayload) { p.value("") }, want: "0:"},
+		{name: "length in bytes, not runes", write: func(p *canonicalPayload) { p.value("₽") }, want: "3:₽"},
+		{name: "integer", write: func(p *canonicalPayload) { p.int(1718000000) }, want: "10:1718000000"},
+		{name: "negative integer", write: func(p *canonicalPayload) { p.int(-5) }, want: "2:-5"},
+		{name: "whole float", write: func(p *canonicalPayload) { p.float(1500) }, want: "4:1500"},
+		{name: "fractional float", write: func(p *canonicalPayload) { p.float(1500.5) }, want: "6:1500.5"},
+		{name: "shortest float", write: func(p *canonicalPayload) { p.float(0.1) }, want: "3:0.1"},
+		{name: "large float without exponent", write: func(p *canonicalPayload) { p.float(1e21) }, want: "22:1000000000000000000000"},
+		{name: "small float without exponent", write: func(p *canonicalPayload) { p.float(0.000001) }, want: "8:0.000001"},
+		{name: "zero float", write: func(p *canonicalPayload) { p.float(0) }, want: "1:0"},
+		{name: "list", write: func(p *canonicalPayload) { p.list([]string{"sms", "push"}) }, want: "1:23:sms4:push"},
+		{name: "empty list", write: func(p *canonicalPayload) { p.list(nil) }, want: "1:0"},
+		{name: "empty map", write: func(p *canonicalPayload) { p.dict(map[string]string{}) }, want: "1:0"},
+		{name: "nil map", write: func(p *canonicalPayload) { p.dict(nil) }, want: "1:0"},
+		{
+			name:  "map keys sorted by bytes",
+			write: func(p *canonicalPayload) { p.dict(map[string]string{"b": "2", "a": "1", "B": "3"}) },
+			want:  "1:31:B1:31:a1:11:b1:2",
+		},
+		{
+			name:  "separators inside values stay unambiguous",
+			write: func(p *canonicalPayload) { p.value("1:a"); p.value("") },
+			want:  "3:1:a0:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p canonicalPayload
+			tt.write(&p)
+			if got := p.String(); got != tt.want {
+				t.Errorf("payload = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Эталон для разработчиков приложения устройства: каноническое тело ProcessAutomaticPayment и его подпись
+func TestProcessAutomaticPaymentSignatureVector(t *testing.T) {
+	req := &orderpb.ProcessAutomaticPaymentRequest{
+		Group:         "dev-1",
+		Amount:        1500.5,
+		PaymentSystem: "SBP",
+		Direction:     "in",
+		Methods:       []string{"sms", "push"},
+		ReceivedAt:    1718000000,
+		Text:          "Перевод 1 500,50р",
+		Metadata:      map[string]string{"sim": "2", "app": "1.2"},
+		TraderId:      "trader-1",
+	}
+	method := orderpb.OrderService_ProcessAutomaticPayment_FullMethodName
+
+	var payload canonicalPayload
+	deviceMethods[method].payload(req, &payload)
+	wantPayload := "5:dev-16:1500.53:SBP2:in1:23:sms4:push10:171800000025:Перевод 1 500,50р1:23:app3:1.23:sim1:28:trader-1"
+	if payload.String() != wantPayload {
+		t.Fatalf("payload = %q, want %q", payload.String(), wantPayload)
+	}
+
+	signature := domain.DeviceRequestSignature("device-secret", method, "1718000000", payload.Bytes())
+	wantSignature := "96d746f7d9be489349c9bfd08b9225ed300c11992174e15d7fc72696cba64a10"
+	if signature != wantSignature {
+		t.Errorf("signature = %s, want %s", signature, wantSignature)
+	}
+}
+
+// fakeDeviceAuth - результат проверки задается тестом, запросы запоминаются
+type fakeDeviceAuth struct {
+	mode     string
+	err      error
+	requests []*domain.DeviceAuthRequest
+}
+
+func (f *fakeDeviceAuth) Mode() string { return f.mode }
+
+func (f *fakeDeviceAuth) IssueDeviceSecret(deviceID string) (*domain.DeviceCredential, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeDeviceAuth) RotateDeviceSecret(deviceID, traderID string) (*domain.DeviceCredential, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeDeviceAuth) RevokeDeviceSecrets(deviceID, traderID string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeDeviceAuth) AuthenticateDevice(req *domain.DeviceAuthRequest) (*domain.Device, error) {
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &domain.Device{DeviceID: req.DeviceID}, nil
+}
+
+func (f *fakeDeviceAuth) PurgeExpiredNonces() error { return nil }
+
+func TestDeviceAuthInterceptor(t *testing.T) {
+	livenessMethod := orderpb.DeviceService_UpdateDeviceLiveness_FullMethodName
+	tests := []struct {
+		name              string
+		mode              string
+		method            string
+		authErr           error
+		wantCode          codes.Code
+		wantHandler       bool
+		wantAuthenticated bool
+	}{
+		{name: "off mode skips the check", mode: domain.DeviceAuthModeOff, method: livenessMethod,
+			authErr: &domain.DeviceAuthError{Reason: domain.DeviceAuthBadSignature}, wantHandler: true},
+		{name: "other methods are not checked", mode: domain.DeviceAuthModeEnforce, method: orderpb.DeviceService_GetDeviceStatus_FullMethodName,
+			authErr: &domain.DeviceAuthError{Reason: domain.DeviceAuthBadSignature}, wantHandler: true},
+		{name: "log mode lets a bad request through", mode: domain.DeviceAuthModeLog, method: livenessMethod,
+			authErr: &domain.DeviceAuthError{Reason: domain.DeviceAuthBadSignature}, wantHandler: true, wantAuthenticated: true},
+		{name: "log mode lets a valid request through", mode: domain.DeviceAuthModeLog, method: livenessMethod,
+			wantHandler: true, wantAuthenticated: true},
+		{name: "enforce mode rejects a bad signature", mode: domain.DeviceAuthModeEnforce, method: livenessMethod,
+			authErr: &domain.DeviceAuthError{Reason: domain.DeviceAuthBadSignature}, wantCode: codes.Unauthenticated, wantAuthenticated: true},
+		{name: "enforce mode rejects a replay", mode: domain.DeviceAuthModeEnforce, method: livenessMethod,
+			authErr: &domain.DeviceAuthError{Reason: domain.DeviceAuthReplay}, wantCode: codes.Unauthenticated, wantAuthenticated: true},
+		{name: "enforce mode rejects another trader's request", mode: domain.DeviceAuthModeEnforce, method: livenessMethod,
+			authErr: &domain.DeviceAuthError{Reason: domain.DeviceAuthTraderMismatch}, wantCode: codes.PermissionDenied, wantAuthenticated: true},
+		{name: "enforce mode rejects another device's request", mode: domain.DeviceAuthModeEnforce, method: livenessMethod,
+			authErr: &domain.DeviceAuthError{Reason: domain.DeviceAuthDeviceMismatch}, wantCode: codes.PermissionDenied, wantAuthenticated: true},
+		{name: "enforce mode reports storage failures as unavailable", mode: domain.DeviceAuthModeEnforce, method: livenessMethod,
+			authErr: errors.New("connection refused"), wantCode: codes.Unavailable, wantAuthenticated: true},
+		{name: "enforce mode lets a valid request through", mode: domain.DeviceAuthModeEnforce, method: livenessMethod,
+			wantHandler: true, wantAuthenticated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := &fakeDeviceAuth{mode: tt.mode, err: tt.authErr}
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+				DeviceIDHeader, "device-1",
+				DeviceTimestampHeader, "1718000000",
+				DeviceSignatureHeader, "abc",
+			))
+			handlerCalled := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerCalled = true
+				return &orderpb.UpdateDeviceLivenessResponse{Success: true}, nil
+			}
+
+			var req interface{} = &orderpb.UpdateDeviceLivenessRequest{DeviceId: "device-1"}
+			if tt.method == orderpb.DeviceService_GetDeviceStatus_FullMethodName {
+				req = &orderpb.GetDeviceStatusRequest{DeviceId: "device-1"}
+			}
+			_, err := DeviceAuthInterceptor(auth)(ctx, req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("code = %s, want %s (error %v)", status.Code(err), tt.wantCode, err)
+			}
+			if handlerCalled != tt.wantHandler {
+				t.Errorf("handler called = %v, want %v", handlerCalled, tt.wantHandler)
+			}
+			if authenticated := len(auth.requests) > 0; authenticated != tt.wantAuthenticated {
+				t.Fatalf("authenticated = %v, want %v", authenticated, tt.wantAuthenticated)
+			}
+			if !tt.wantAuthenticated {
+				return
+			}
+
+			authReq := auth.requests[0]
+			if authReq.Method != livenessMethod || authReq.DeviceID != "device-1" || authReq.Timestamp != "1718000000" ||
+				authReq.Signature != "abc" || string(authReq.Payload) != "8:device-1" || authReq.ClaimedDeviceID != "device-1" {
+				t.Errorf("auth request = %+v", authReq)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package grpcapi
 
 import (
 	"context"
+	"errors"
 	"log"
 
+	"github.com/LavaJover/shvark-order-service/internal/domain"
 	"github.com/LavaJover/shvark-order-service/internal/usecase"
 	devicedto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/device"
 	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
@@ -13,12 +15,14 @@ import (
 
 type DeviceHandler struct {
 	deviceUc usecase.DeviceUsecase
+	deviceAuthUc usecase.DeviceAuthUsecase
 	orderpb.UnimplementedDeviceServiceServer
 }
 
-func NewDeviceHandler(deviceUc usecase.DeviceUsecase) *DeviceHandler {
+func NewDeviceHandler(deviceUc usecase.DeviceUsecase, deviceAuthUc usecase.DeviceAuthUsecase) *DeviceHandler {
 	return &DeviceHandler{
 		deviceUc: deviceUc,
+		deviceAuthUc: deviceAuthUc,
 	}
 }
 
@@ -29,12 +33,22 @@ func (h *DeviceHandler) CreateDevice(ctx context.Context, r *orderpb.CreateDevic
 		Enabled: r.Enabled,
 	}
 
-	err := h.deviceUc.CreateDevice(&createDeviceInput)
+	device, err := h.deviceUc.CreateDevice(&createDeviceInput)
 	if err != nil {
 		return nil, err
 	}
 
-	return &orderpb.CreateDeviceResponse{}, nil
+	// Секрет выдается при создании: приложение получает его при привязке устройства
+	credential, err := h.deviceAuthUc.IssueDeviceSecret(device.DeviceID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "device %s created, but secret was not issued, rotate it: %v", device.DeviceID, err)
+	}
+
+	return &orderpb.CreateDeviceResponse{
+		DeviceId: device.DeviceID,
+		DeviceSecret: credential.Secret,
+		SecretId: credential.ID,
+	}, nil
 }
 
 func (h *DeviceHandler) GetTraderDevices(ctx context.Context, r *orderpb.GetTraderDevicesRequest) (*orderpb.GetTraderDevicesResponse, error) {
@@ -167,4 +181,42 @@ func (h *DeviceHandler) GetTraderDevicesStatus(ctx context.Context, req *orderpb
     return &orderpb.GetTraderDevicesStatusResponse{
         Devices: deviceStatuses,
     }, nil
-}
\ No newline at end of file
+}
+func (h *DeviceHandler) RotateDeviceSecret(ctx context.Context, r *orderpb.RotateDeviceSecretRequest) (*orderpb.RotateDeviceSecretResponse, error) {
+	if r.DeviceId == "" || r.TraderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id and trader_id are required")
+	}
+
+	credential, err := h.deviceAuthUc.RotateDeviceSecret(r.DeviceId, r.TraderId)
+	if err != nil {
+		return nil, deviceSecretStatusError(err, "failed to rotate device secret")
+	}
+
+	return &orderpb.RotateDeviceSecretResponse{
+		DeviceSecret: credential.Secret,
+		SecretId: credential.ID,
+	}, nil
+}
+
+func (h *DeviceHandler) RevokeDeviceSecrets(ctx context.Context, r *orderpb.RevokeDeviceSecretsRequest) (*orderpb.RevokeDeviceSecretsResponse, error) {
+	if r.DeviceId == "" || r.TraderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id and trader_id are required")
+	}
+
+	if err := h.deviceAuthUc.RevokeDeviceSecrets(r.DeviceId, r.TraderId); err != nil {
+		return nil, deviceSecretStatusError(err, "failed to revoke device secrets")
+	}
+
+	return &orderpb.RevokeDeviceSecretsResponse{}, nil
+}
+
+func deviceSecretStatusError(err error, message string) error {
+	switch {
+	case errors.Is(err, domain.ErrDeviceNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrDeviceNotOwned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
@@ -0,0 +1,20 @@
+package grpcapi
+
+import (
+	"errors"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatusError переводит доменные ошибки смены статуса сделки в gRPC-коды
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrConcurrentModification):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, domain.ErrInvalidStatusTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
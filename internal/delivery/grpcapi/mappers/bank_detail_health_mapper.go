@@ -0,0 +1,34 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ToProtoBankDetailHealth(health *domain.BankDetailHealth) *orderpb.BankDetailHealth {
+	return &orderpb.BankDetailHealth{
+		BankDetailId:       health.BankDetailID,
+		TraderId:           health.TraderID,
+		ConsecutiveCancels: health.ConsecutiveCancels,
+		WindowCancels:      health.WindowCancels,
+		WindowDisputes:     health.WindowDisputes,
+		Suspensions:        health.Suspensions,
+		LastCancelAt:       toProtoTimestamp(health.LastCancelAt),
+		LastDisputeAt:      toProtoTimestamp(health.LastDisputeAt),
+		LastSuspendedAt:    toProtoTimestamp(health.LastSuspendedAt),
+		ResumedAt:          toProtoTimestamp(health.ResumedAt),
+		SuspendedUntil:     toProtoTimestamp(health.SuspendedUntil),
+		SuspendReason:      health.SuspendReason,
+	}
+}
+
+// toProtoTimestamp - nil для незаданного времени
+func toProtoTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -30,5 +30,22 @@ func ToProtoBankDetail(bankDetail *domain.BankDetail) *orderpb.BankDetail {
 		InflowCurrency: bankDetail.InflowCurrency,
 		BankCode: bankDetail.BankCode,
 		NspkCode: bankDetail.NspkCode,
+		MaxAmountHour: bankDetail.MaxAmountHour,
+		MaxQuantityHour: bankDetail.MaxQuantityHour,
+		MaxAmountWeek: bankDetail.MaxAmountWeek,
+		MaxQuantityWeek: bankDetail.MaxQuantityWeek,
+		LimitMode: string(bankDetail.LimitMode),
+		Timezone: bankDetail.Timezone,
+		BinMismatch: bankDetail.BinMismatch,
 	}
-}
\ No newline at end of file
+}
+func ToProtoBin(bin *domain.BinRecord) *orderpb.BinRecord {
+	return &orderpb.BinRecord{
+		Prefix: bin.Prefix,
+		BankName: bin.BankName,
+		BankCode: bin.BankCode,
+		NspkCode: bin.NspkCode,
+		PaymentNetwork: bin.PaymentNetwork,
+		Country: bin.Country,
+	}
+}
@@ -0,0 +1,92 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ToDomainSchedule(schedule *orderpb.Schedule) *domain.Schedule {
+	if schedule == nil {
+		return nil
+	}
+
+	result := &domain.Schedule{Timezone: schedule.Timezone}
+	for _, interval := range schedule.Weekly {
+		result.Weekly = append(result.Weekly, domain.ScheduleInterval{
+			Weekday: time.Weekday(interval.Weekday),
+			TimeRange: domain.TimeRange{
+				StartMinute: interval.StartMinute,
+				EndMinute:   interval.EndMinute,
+			},
+		})
+	}
+	for _, exception := range schedule.Exceptions {
+		ranges := make([]domain.TimeRange, len(exception.Ranges))
+		for i, r := range exception.Ranges {
+			ranges[i] = domain.TimeRange{StartMinute: r.StartMinute, EndMinute: r.EndMinute}
+		}
+		result.Exceptions = append(result.Exceptions, domain.ScheduleException{
+			Date:   exception.Date,
+			Ranges: ranges,
+		})
+	}
+	return result
+}
+
+func ToProtoSchedule(schedule *domain.Schedule) *orderpb.Schedule {
+	if schedule == nil {
+		return nil
+	}
+
+	result := &orderpb.Schedule{Timezone: schedule.Timezone}
+	for _, interval := range schedule.Weekly {
+		result.Weekly = append(result.Weekly, &orderpb.ScheduleInterval{
+			Weekday:     int32(interval.Weekday),
+			StartMinute: interval.StartMinute,
+			EndMinute:   interval.EndMinute,
+		})
+	}
+	for _, exception := range schedule.Exceptions {
+		ranges := make([]*orderpb.TimeRange, len(exception.Ranges))
+		for i, r := range exception.Ranges {
+			ranges[i] = &orderpb.TimeRange{StartMinute: r.StartMinute, EndMinute: r.EndMinute}
+		}
+		result.Exceptions = append(result.Exceptions, &orderpb.ScheduleException{
+			Date:   exception.Date,
+			Ranges: ranges,
+		})
+	}
+	return result
+}
+
+func ToProtoTraderAvailability(availability *domain.TraderAvailability) *orderpb.GetEffectiveAvailabilityResponse {
+	response := &orderpb.GetEffectiveAvailabilityResponse{
+		TraderId:    availability.TraderID,
+		CheckedAt:   timestamppb.New(availability.CheckedAt),
+		BankDetails: make([]*orderpb.BankDetailAvailability, len(availability.BankDetails)),
+		Traffic:     make([]*orderpb.TrafficAvailability, len(availability.Traffic)),
+	}
+	for i, item := range availability.BankDetails {
+		response.BankDetails[i] = &orderpb.BankDetailAvailability{
+			BankDetailId: item.BankDetailID,
+			Enabled:      item.Enabled,
+			InSchedule:   item.InSchedule,
+			Available:    item.Available,
+			Reason:       item.Reason,
+		}
+	}
+	for i, item := range availability.Traffic {
+		response.Traffic[i] = &orderpb.TrafficAvailability{
+			TrafficId:  item.TrafficID,
+			MerchantId: item.MerchantID,
+			Enabled:    item.Enabled,
+			InSchedule: item.InSchedule,
+			Available:  item.Available,
+			Reason:     item.Reason,
+		}
+	}
+	return response
+}
@@ -2,6 +2,7 @@ package grpcapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
@@ -28,6 +29,8 @@ type OrderHandler struct {
 	disputeUc disputeuc.DisputeUsecase
 	bankDetailUc usecase.BankDetailUsecase
 	automaticUc usecase.AutomaticUsecase
+	callbacks *notifier.CallbackQueue
+	notificationTemplateUc usecase.NotificationTemplateUsecase
 	orderpb.UnimplementedOrderServiceServer
 }
 
@@ -36,19 +39,24 @@ func NewOrderHandler(
 	disputeUc disputeuc.DisputeUsecase,
 	bankDetailUc usecase.BankDetailUsecase,
 	automaticUc usecase.AutomaticUsecase,
+	callbacks *notifier.CallbackQueue,
+	notificationTemplateUc usecase.NotificationTemplateUsecase,
 	) *OrderHandler {
 	return &OrderHandler{
 		uc: uc,
 		disputeUc: disputeUc,
 		bankDetailUc: bankDetailUc,
 		automaticUc: automaticUc,
+		callbacks: callbacks,
+		notificationTemplateUc: notificationTemplateUc,
 	}
 }
 
-func (h *OrderHandler) CreatePayInOrder(ctx context.Context, r *orderpb.CreatePayInOrderRequest) (*orderpb.CreatePayInOrderResponse, error) {
+// payInOrderInput переводит запрос на создание пай-ин сделки во входные данные usecase
+func payInOrderInput(r *orderpb.CreatePayInOrderRequest) orderdto.CreatePayInOrderInput {
     amountCrypto := r.AmountFiat / usdt.UsdtRubRates
 
-    createOrderInput := orderdto.CreatePayInOrderInput{
+    return orderdto.CreatePayInOrderInput{
         MerchantParams: orderdto.MerchantParams{
             MerchantID: r.MerchantId,
             MerchantOrderID: r.MerchantOrderId,
@@ -72,22 +80,31 @@ func (h *OrderHandler) CreatePayInOrder(ctx context.Context, r *orderpb.CreatePa
         Type: "DEPOSIT",
         ExpiresAt: r.ExpiresAt.AsTime(),
     }
+}
+
+func (h *OrderHandler) CreatePayInOrder(ctx context.Context, r *orderpb.CreatePayInOrderRequest) (*orderpb.CreatePayInOrderResponse, error) {
+    createOrderInput := payInOrderInput(r)
     
     // ИСПОЛЬЗУЕМ АТОМАРНЫЙ МЕТОД вместо обычного
     createOrderOutput, err := h.uc.CreatePayInOrderAtomic(&createOrderInput)
     if err != nil {
         if createOrderInput.AdvancedParams.CallbackUrl != "" {
-            notifier.SendCallback(
-                createOrderInput.AdvancedParams.CallbackUrl,
-                createOrderInput.MerchantParams.MerchantOrderID,
-                string(domain.StatusFailed),
-                0, 0, 0,
-            )
+            h.callbacks.Enqueue(&domain.CallbackDelivery{
+                MerchantID: createOrderInput.MerchantID,
+                MerchantOrderID: createOrderInput.MerchantOrderID,
+                ClientID: createOrderInput.ClientID,
+                CallbackUrl: createOrderInput.AdvancedParams.CallbackUrl,
+                OrderStatus: string(domain.StatusFailed),
+                AmountFiat: createOrderInput.AmountFiat,
+                AmountCrypto: createOrderInput.AmountCrypto,
+                Currency: createOrderInput.Currency,
+                UsdRate: createOrderInput.CryptoRate,
+            })
         }
         return nil, err
     }
 
-    return &orderpb.CreatePayInOrderResponse{
+    response := &orderpb.CreatePayInOrderResponse{
         Order: &orderpb.Order{
             OrderId: createOrderOutput.Order.ID,
             Status: string(createOrderOutput.Order.Status),
@@ -121,8 +138,15 @@ func (h *OrderHandler) CreatePayInOrder(ctx context.Context, r *orderpb.CreatePa
             UpdatedAt: timestamppb.New(createOrderOutput.Order.UpdatedAt),
             Recalculated: createOrderOutput.Order.Recalculated,
             CryptoRubRate: createOrderOutput.Order.AmountInfo.CryptoRate,
+            OriginalAmountFiat: createOrderOutput.Order.AmountInfo.OriginalAmountFiat,
         },
-    }, nil
+    }
+    // Сделка ждет реквизитов - реквизит придет в колбэке PENDING
+    if createOrderOutput.Order.Status == domain.StatusWaiting {
+        response.Order.BankDetail = nil
+    }
+
+    return response, nil
 }
 
 func (h *OrderHandler) CreatePayOutOrder(ctx context.Context, r *orderpb.CreatePayOutOrderRequest) (*orderpb.CreatePayOutOrderResponse, error) {
@@ -192,6 +216,7 @@ func (h *OrderHandler) CreatePayOutOrder(ctx context.Context, r *orderpb.CreateP
             UpdatedAt: timestamppb.New(createOrderOutput.Order.UpdatedAt),
             Recalculated: createOrderOutput.Order.Recalculated,
             CryptoRubRate: createOrderOutput.Order.AmountInfo.CryptoRate,
+            OriginalAmountFiat: createOrderOutput.Order.AmountInfo.OriginalAmountFiat,
         },
     }, nil
 }
@@ -202,7 +227,7 @@ func (h *OrderHandler) ApproveOrder(ctx context.Context, r *orderpb.ApproveOrder
 		fmt.Println("Ошибка подтверждения сделки: ", err.Error())
 		return &orderpb.ApproveOrderResponse{
 			Message: "failed to approve order",
-		}, err
+		}, toStatusError(err)
 	}else {
 		return &orderpb.ApproveOrderResponse{
 			Message: "Order approved successfully",
@@ -254,6 +279,7 @@ func (h *OrderHandler) GetOrderByID(ctx context.Context, r *orderpb.GetOrderByID
 			UpdatedAt: timestamppb.New(order.UpdatedAt),
 			Recalculated: order.Recalculated,
 			CryptoRubRate: order.AmountInfo.CryptoRate,
+			OriginalAmountFiat: order.AmountInfo.OriginalAmountFiat,
 			MerchantId: order.MerchantInfo.MerchantID,
 			Metrics: &orderpb.OrderMetrics{
 				CompletedAt: timestamppb.New(order.Metrics.CompletedAt),
@@ -309,6 +335,7 @@ func (h *OrderHandler) GetOrderByMerchantOrderID(ctx context.Context, r *orderpb
 			UpdatedAt: timestamppb.New(order.UpdatedAt),
 			Recalculated: order.Recalculated,
 			CryptoRubRate: order.AmountInfo.CryptoRate,
+			OriginalAmountFiat: order.AmountInfo.OriginalAmountFiat,
 			MerchantId: order.MerchantInfo.MerchantID,
 			Metrics: &orderpb.OrderMetrics{
 				CompletedAt: timestamppb.New(order.Metrics.CompletedAt),
@@ -401,6 +428,7 @@ func (h *OrderHandler) GetOrdersByTraderID(ctx context.Context, r *orderpb.GetOr
 			UpdatedAt: timestamppb.New(order.Order.UpdatedAt),
 			Recalculated: order.Order.Recalculated,
 			CryptoRubRate: order.Order.AmountInfo.CryptoRate,
+			OriginalAmountFiat: order.Order.AmountInfo.OriginalAmountFiat,
 			MerchantId: order.Order.MerchantInfo.MerchantID,
 			Metrics: &orderpb.OrderMetrics{
 				CompletedAt: timestamppb.New(order.Order.Metrics.CompletedAt),
@@ -427,7 +455,7 @@ func (h *OrderHandler) CancelOrder(ctx context.Context, r *orderpb.CancelOrderRe
 	if err := h.uc.CancelOrder(orderID); err != nil {
 		return &orderpb.CancelOrderResponse{
 			Message: "Failed to cancel order",
-		}, err
+		}, toStatusError(err)
 	}
 
 	return &orderpb.CancelOrderResponse{
@@ -438,7 +466,7 @@ func (h *OrderHandler) CancelOrder(ctx context.Context, r *orderpb.CancelOrderRe
 func (h *OrderHandler) AcceptOrder(ctx context.Context, r *orderpb.AcceptOrderRequest) (*orderpb.AcceptOrderResponse, error) {
 	orderID := r.OrderId
 	if err := h.uc.AcceptOrder(orderID); err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &orderpb.AcceptOrderResponse{}, nil
@@ -455,7 +483,7 @@ func (h *OrderHandler) CreateOrderDispute(ctx context.Context, r *orderpb.Create
 		Reason: r.DisputeReason,
 	}
 	if err := h.disputeUc.CreateDispute(&createDisputeInput); err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 	return &orderpb.CreateOrderDisputeResponse{
 		DisputeId: "",
@@ -466,7 +494,7 @@ func (h *OrderHandler) AcceptOrderDispute(ctx context.Context, r *orderpb.Accept
 	disputeID := r.DisputeId
 	if err := h.disputeUc.AcceptDispute(disputeID); err != nil {
 		slog.Error("failed to accept dispute", "error", err.Error())
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &orderpb.AcceptOrderDisputeResponse{
@@ -477,7 +505,7 @@ func (h *OrderHandler) AcceptOrderDispute(ctx context.Context, r *orderpb.Accept
 func (h *OrderHandler) RejectOrderDispute(ctx context.Context, r *orderpb.RejectOrderDisputeRequest) (*orderpb.RejectOrderDisputeResponse, error) {
 	disputeID := r.DisputeId
 	if err := h.disputeUc.RejectDispute(disputeID); err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &orderpb.RejectOrderDisputeResponse{
@@ -511,7 +539,7 @@ func (h *OrderHandler) FreezeOrderDispute(ctx context.Context, r *orderpb.Freeze
 	disputeID := r.DisputeId
 	err := h.disputeUc.FreezeDispute(disputeID)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 	return &orderpb.FreezeOrderDisputeResponse{}, nil
 }
@@ -564,6 +592,7 @@ func (h *OrderHandler) GetOrderDisputes(ctx context.Context, r *orderpb.GetOrder
 				CreatedAt: timestamppb.New(order.CreatedAt),
 				UpdatedAt: timestamppb.New(order.UpdatedAt),
 				CryptoRubRate: order.AmountInfo.CryptoRate,
+				OriginalAmountFiat: order.AmountInfo.OriginalAmountFiat,
 				Type: string(order.Type),
 				BankDetail: &orderpb.BankDetail{
 					BankDetailId: bankDetailId,
@@ -834,6 +863,7 @@ func (h *OrderHandler) GetAllOrders(
 			UpdatedAt: timestamppb.New(order.UpdatedAt),
 			Recalculated: order.Recalculated,
 			CryptoRubRate: order.AmountInfo.CryptoRate,
+			OriginalAmountFiat: order.AmountInfo.OriginalAmountFiat,
 			MerchantId: order.MerchantInfo.MerchantID,
 			Type: string(order.Type),
 			Metrics: &orderpb.OrderMetrics{
@@ -1026,4 +1056,521 @@ func (h *OrderHandler) GetAutomaticStats(ctx context.Context, req *orderpb.GetAu
             DeviceStats:        deviceStats,
         },
     }, nil
-}
\ No newline at end of file
+}
+func (h *OrderHandler) GetOrderHistory(ctx context.Context, r *orderpb.GetOrderHistoryRequest) (*orderpb.GetOrderHistoryResponse, error) {
+	if r.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	transitions, err := h.uc.GetOrderHistory(r.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	pbTransitions := make([]*orderpb.OrderStatusTransition, len(transitions))
+	for i, transition := range transitions {
+		pbTransitions[i] = &orderpb.OrderStatusTransition{
+			Id: transition.ID,
+			OrderId: transition.OrderID,
+			FromStatus: string(transition.FromStatus),
+			ToStatus: string(transition.ToStatus),
+			Actor: transition.Actor,
+			Operation: transition.Operation,
+			Reason: transition.Reason,
+			CreatedAt: timestamppb.New(transition.CreatedAt),
+		}
+	}
+
+	return &orderpb.GetOrderHistoryResponse{
+		Transitions: pbTransitions,
+	}, nil
+}
+
+func (h *OrderHandler) RecoverCanceledOrder(ctx context.Context, r *orderpb.RecoverCanceledOrderRequest) (*orderpb.RecoverCanceledOrderResponse, error) {
+	if r.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	if r.PaidAmountFiat < 0 {
+		return nil, status.Error(codes.InvalidArgument, "paid_amount_fiat must not be negative")
+	}
+
+	if err := h.uc.RecoverCanceledOrder(r.OrderId, r.PaidAmountFiat, r.Reason); err != nil {
+		slog.Error("failed to recover canceled order", "order_id", r.OrderId, "error", err.Error())
+		return nil, toStatusError(err)
+	}
+
+	return &orderpb.RecoverCanceledOrderResponse{
+		Message: "order recovered",
+	}, nil
+}
+
+func (h *OrderHandler) GetCallbackDeliveries(ctx context.Context, r *orderpb.GetCallbackDeliveriesRequest) (*orderpb.GetCallbackDeliveriesResponse, error) {
+	if r.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	deliveries, err := h.uc.GetCallbackDeliveries(r.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	pbDeliveries := make([]*orderpb.CallbackDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		pbDeliveries[i] = toPbCallbackDelivery(delivery)
+	}
+
+	return &orderpb.GetCallbackDeliveriesResponse{
+		Deliveries: pbDeliveries,
+	}, nil
+}
+
+func (h *OrderHandler) ResendCallback(ctx context.Context, r *orderpb.ResendCallbackRequest) (*orderpb.ResendCallbackResponse, error) {
+	if r.DeliveryId == "" {
+		return nil, status.Error(codes.InvalidArgument, "delivery_id is required")
+	}
+
+	delivery, err := h.uc.ResendCallback(r.DeliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.ResendCallbackResponse{
+		Delivery: toPbCallbackDelivery(delivery),
+	}, nil
+}
+
+func toPbCallbackDelivery(delivery *domain.CallbackDelivery) *orderpb.CallbackDelivery {
+	pbDelivery := &orderpb.CallbackDelivery{
+		Id: delivery.ID,
+		OrderId: delivery.OrderID,
+		MerchantOrderId: delivery.MerchantOrderID,
+		CallbackUrl: delivery.CallbackUrl,
+		OrderStatus: delivery.OrderStatus,
+		Status: string(delivery.Status),
+		Attempts: int32(delivery.Attempts),
+		LastHttpCode: int32(delivery.LastHTTPCode),
+		LastError: delivery.LastError,
+		NextAttemptAt: timestamppb.New(delivery.NextAttemptAt),
+		CreatedAt: timestamppb.New(delivery.CreatedAt),
+	}
+	if delivery.DeliveredAt != nil {
+		pbDelivery.DeliveredAt = timestamppb.New(*delivery.DeliveredAt)
+	}
+	return pbDelivery
+}
+
+func (h *OrderHandler) GetMerchantCallbackSettings(ctx context.Context, r *orderpb.GetMerchantCallbackSettingsRequest) (*orderpb.GetMerchantCallbackSettingsResponse, error) {
+	if r.MerchantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "merchant_id is required")
+	}
+
+	settings, err := h.uc.GetMerchantCallbackSettings(r.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.GetMerchantCallbackSettingsResponse{
+		Settings: toPbMerchantCallbackSettings(settings),
+	}, nil
+}
+
+func (h *OrderHandler) SetMerchantCallbackSettings(ctx context.Context, r *orderpb.SetMerchantCallbackSettingsRequest) (*orderpb.SetMerchantCallbackSettingsResponse, error) {
+	if r.MerchantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "merchant_id is required")
+	}
+
+	settings, err := h.uc.SetMerchantCallbackSettings(
+		r.MerchantId,
+		domain.CallbackFormat(r.Format),
+		r.RotateSecret,
+		r.RemoveSecret,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &orderpb.SetMerchantCallbackSettingsResponse{
+		Settings: toPbMerchantCallbackSettings(settings),
+	}
+	if r.RotateSecret {
+		response.Secret = settings.Secret
+	}
+	return response, nil
+}
+
+func toPbMerchantCallbackSettings(settings *domain.MerchantCallbackSettings) *orderpb.MerchantCallbackSettings {
+	return &orderpb.MerchantCallbackSettings{
+		MerchantId: settings.MerchantID,
+		Format: string(settings.Format),
+		Signed: settings.Secret != "",
+	}
+}
+
+func (h *OrderHandler) GetMerchantMatchingSettings(ctx context.Context, r *orderpb.GetMerchantMatchingSettingsRequest) (*orderpb.GetMerchantMatchingSettingsResponse, error) {
+	if r.MerchantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "merchant_id is required")
+	}
+
+	settings, err := h.uc.GetMerchantMatchingSettings(r.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.GetMerchantMatchingSettingsResponse{
+		Settings: toPbMerchantMatchingSettings(settings),
+	}, nil
+}
+
+func (h *OrderHandler) SetMerchantMatchingSettings(ctx context.Context, r *orderpb.SetMerchantMatchingSettingsRequest) (*orderpb.SetMerchantMatchingSettingsResponse, error) {
+	if r.Settings == nil || r.Settings.MerchantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "settings.merchant_id is required")
+	}
+
+	settings, err := h.uc.SetMerchantMatchingSettings(&domain.MerchantMatchingSettings{
+		MerchantID: r.Settings.MerchantId,
+		WaitForRequisites: r.Settings.WaitForRequisites,
+		WaitWindow: r.Settings.WaitWindow.AsDuration(),
+		SelectionStrategy: domain.SelectionStrategy(r.Settings.SelectionStrategy),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.SetMerchantMatchingSettingsResponse{
+		Settings: toPbMerchantMatchingSettings(settings),
+	}, nil
+}
+
+func toPbMerchantMatchingSettings(settings *domain.MerchantMatchingSettings) *orderpb.MerchantMatchingSettings {
+	pbSettings := &orderpb.MerchantMatchingSettings{
+		MerchantId: settings.MerchantID,
+		WaitForRequisites: settings.WaitForRequisites,
+		SelectionStrategy: string(settings.SelectionStrategy),
+	}
+	if settings.WaitWindow > 0 {
+		pbSettings.WaitWindow = durationpb.New(settings.WaitWindow)
+	}
+	return pbSettings
+}
+
+// ExplainBankDetailSelection показывает, почему для запроса на пай-ин не нашелся реквизит.
+// Сделка не создается, средства не замораживаются
+func (h *OrderHandler) ExplainBankDetailSelection(ctx context.Context, r *orderpb.CreatePayInOrderRequest) (*orderpb.ExplainBankDetailSelectionResponse, error) {
+    input := payInOrderInput(r)
+    candidates, err := h.uc.ExplainBankDetailSelection(&input)
+    if err != nil {
+        return nil, status.Error(codes.Internal, err.Error())
+    }
+
+    response := &orderpb.ExplainBankDetailSelectionResponse{
+        Candidates: make([]*orderpb.BankDetailSelectionCandidate, len(candidates)),
+    }
+    for i, candidate := range candidates {
+        response.Candidates[i] = &orderpb.BankDetailSelectionCandidate{
+            BankDetailId: candidate.BankDetailID,
+            TraderId:     candidate.TraderID,
+            Stage:        string(candidate.Stage),
+            Reason:       candidate.Reason,
+            Eliminated:   candidate.Eliminated,
+            Weight:       candidate.Weight,
+            Probability:  candidate.Probability,
+        }
+        if !candidate.Eliminated {
+            response.EligibleCount++
+        }
+    }
+
+    return response, nil
+}
+
+func (h *OrderHandler) GetRoutingScores(ctx context.Context, r *orderpb.GetRoutingScoresRequest) (*orderpb.GetRoutingScoresResponse, error) {
+	page, limit := r.Page, r.Limit
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	scores, total, err := h.uc.GetRoutingScores(domain.RoutingScoreFilter{
+		SubjectType: domain.RoutingSubject(r.SubjectType),
+		TraderID: r.TraderId,
+		Page: int(page),
+		Limit: int(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &orderpb.GetRoutingScoresResponse{
+		Scores: make([]*orderpb.RoutingScore, len(scores)),
+		Pagination: &orderpb.Pagination{
+			CurrentPage: int64(page),
+			TotalPages: int64(math.Ceil(float64(total) / float64(limit))),
+			TotalItems: total,
+			ItemsPerPage: int64(limit),
+		},
+	}
+	for i, score := range scores {
+		response.Scores[i] = toPbRoutingScore(score)
+	}
+	return response, nil
+}
+
+func (h *OrderHandler) SetRoutingScoreOverride(ctx context.Context, r *orderpb.SetRoutingScoreOverrideRequest) (*orderpb.SetRoutingScoreOverrideResponse, error) {
+	if r.SubjectId == "" {
+		return nil, status.Error(codes.InvalidArgument, "subject_id is required")
+	}
+
+	score, err := h.uc.SetRoutingScoreOverride(domain.RoutingSubject(r.SubjectType), r.SubjectId, r.Override)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.SetRoutingScoreOverrideResponse{
+		Score: toPbRoutingScore(score),
+	}, nil
+}
+
+func toPbRoutingScore(score *domain.RoutingScore) *orderpb.RoutingScore {
+	pbScore := &orderpb.RoutingScore{
+		SubjectType: string(score.SubjectType),
+		SubjectId: score.SubjectID,
+		TraderId: score.TraderID,
+		Score: score.Score,
+		Override: score.Override,
+		EffectiveScore: score.Effective(),
+		Orders: int32(score.Orders),
+		CompletionRate: score.CompletionRate,
+		MedianCompleteTime: durationpb.New(score.MedianCompleteTime),
+		DisputeRate: score.DisputeRate,
+		AutomaticShare: score.AutomaticShare,
+	}
+	if !score.CalculatedAt.IsZero() {
+		pbScore.CalculatedAt = timestamppb.New(score.CalculatedAt)
+	}
+	return pbScore
+}
+
+func (h *OrderHandler) CreateNotificationTemplate(ctx context.Context, r *orderpb.CreateNotificationTemplateRequest) (*orderpb.CreateNotificationTemplateResponse, error) {
+	if r.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "template is required")
+	}
+
+	template, err := h.notificationTemplateUc.CreateNotificationTemplate(toDomainNotificationTemplate(r.Template))
+	if err != nil {
+		return nil, notificationTemplateStatusError(err)
+	}
+
+	return &orderpb.CreateNotificationTemplateResponse{
+		Template: toPbNotificationTemplate(template),
+	}, nil
+}
+
+func (h *OrderHandler) UpdateNotificationTemplate(ctx context.Context, r *orderpb.UpdateNotificationTemplateRequest) (*orderpb.UpdateNotificationTemplateResponse, error) {
+	if r.Template == nil || r.Template.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "template.id is required")
+	}
+
+	template, err := h.notificationTemplateUc.UpdateNotificationTemplate(toDomainNotificationTemplate(r.Template))
+	if err != nil {
+		return nil, notificationTemplateStatusError(err)
+	}
+
+	return &orderpb.UpdateNotificationTemplateResponse{
+		Template: toPbNotificationTemplate(template),
+	}, nil
+}
+
+func (h *OrderHandler) DeleteNotificationTemplate(ctx context.Context, r *orderpb.DeleteNotificationTemplateRequest) (*orderpb.DeleteNotificationTemplateResponse, error) {
+	if r.TemplateId == "" {
+		return nil, status.Error(codes.InvalidArgument, "template_id is required")
+	}
+	if err := h.notificationTemplateUc.DeleteNotificationTemplate(r.TemplateId); err != nil {
+		return nil, notificationTemplateStatusError(err)
+	}
+
+	return &orderpb.DeleteNotificationTemplateResponse{}, nil
+}
+
+func (h *OrderHandler) GetNotificationTemplates(ctx context.Context, r *orderpb.GetNotificationTemplatesRequest) (*orderpb.GetNotificationTemplatesResponse, error) {
+	templates, err := h.notificationTemplateUc.ListNotificationTemplates(r.BankCode, r.EnabledOnly)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	pbTemplates := make([]*orderpb.NotificationTemplate, len(templates))
+	for i, template := range templates {
+		pbTemplates[i] = toPbNotificationTemplate(template)
+	}
+	return &orderpb.GetNotificationTemplatesResponse{Templates: pbTemplates}, nil
+}
+
+// TestNotificationTemplate разбирает текст шаблоном из запроса, не сохраняя его
+func (h *OrderHandler) TestNotificationTemplate(ctx context.Context, r *orderpb.TestNotificationTemplateRequest) (*orderpb.TestNotificationTemplateResponse, error) {
+	if r.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "template is required")
+	}
+
+	parsed, err := h.notificationTemplateUc.TestNotificationTemplate(toDomainNotificationTemplate(r.Template), r.Text)
+	if err != nil {
+		return nil, notificationTemplateStatusError(err)
+	}
+	if parsed == nil {
+		return &orderpb.TestNotificationTemplateResponse{Matched: false}, nil
+	}
+	return &orderpb.TestNotificationTemplateResponse{
+		Matched: true,
+		Parsed:  toPbParsedNotification(parsed),
+	}, nil
+}
+
+func notificationTemplateStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidNotificationTemplate):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrNotificationTemplateNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toDomainNotificationTemplate(pbTemplate *orderpb.NotificationTemplate) *domain.NotificationTemplate {
+	template := &domain.NotificationTemplate{
+		ID:              pbTemplate.Id,
+		BankCode:        pbTemplate.BankCode,
+		Name:            pbTemplate.Name,
+		Pattern:         pbTemplate.Pattern,
+		Direction:       pbTemplate.Direction,
+		DefaultCurrency: pbTemplate.DefaultCurrency,
+		Priority:        pbTemplate.Priority,
+		Enabled:         pbTemplate.Enabled,
+	}
+	for _, sample := range pbTemplate.Samples {
+		domainSample := domain.NotificationSample{Text: sample.Text}
+		if sample.Expected != nil {
+			domainSample.Expected = &domain.ParsedNotification{
+				Amount:     sample.Expected.Amount,
+				Currency:   sample.Expected.Currency,
+				SenderName: sample.Expected.SenderName,
+				CardTail:   sample.Expected.CardTail,
+			}
+		}
+		template.Samples = append(template.Samples, domainSample)
+	}
+	return template
+}
+
+func toPbNotificationTemplate(template *domain.NotificationTemplate) *orderpb.NotificationTemplate {
+	pbTemplate := &orderpb.NotificationTemplate{
+		Id:              template.ID,
+		BankCode:        template.BankCode,
+		Name:            template.Name,
+		Pattern:         template.Pattern,
+		Direction:       template.Direction,
+		DefaultCurrency: template.DefaultCurrency,
+		Priority:        template.Priority,
+		Enabled:         template.Enabled,
+		CreatedAt:       timestamppb.New(template.CreatedAt),
+		UpdatedAt:       timestamppb.New(template.UpdatedAt),
+	}
+	for _, sample := range template.Samples {
+		pbSample := &orderpb.NotificationSample{Text: sample.Text}
+		if sample.Expected != nil {
+			pbSample.Expected = toPbParsedNotification(sample.Expected)
+		}
+		pbTemplate.Samples = append(pbTemplate.Samples, pbSample)
+	}
+	return pbTemplate
+}
+
+func toPbParsedNotification(parsed *domain.ParsedNotification) *orderpb.ParsedNotification {
+	return &orderpb.ParsedNotification{
+		TemplateId: parsed.TemplateID,
+		BankCode:   parsed.BankCode,
+		Amount:     parsed.Amount,
+		Currency:   parsed.Currency,
+		SenderName: parsed.SenderName,
+		CardTail:   parsed.CardTail,
+		Direction:  parsed.Direction,
+	}
+}
+
+func (h *OrderHandler) ListUnmatchedPayments(ctx context.Context, r *orderpb.ListUnmatchedPaymentsRequest) (*orderpb.ListUnmatchedPaymentsResponse, error) {
+	page, limit := r.Page, r.Limit
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	payments, total, err := h.uc.ListUnmatchedPayments(domain.UnmatchedPaymentFilter{
+		DeviceID: r.DeviceId,
+		TraderID: r.TraderId,
+		Status: domain.UnmatchedPaymentStatus(r.Status),
+		Page: int(page),
+		Limit: int(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &orderpb.ListUnmatchedPaymentsResponse{
+		Payments: make([]*orderpb.UnmatchedPayment, len(payments)),
+		Pagination: &orderpb.Pagination{
+			CurrentPage: int64(page),
+			TotalPages: int64(math.Ceil(float64(total) / float64(limit))),
+			TotalItems: total,
+			ItemsPerPage: int64(limit),
+		},
+	}
+	for i, payment := range payments {
+		response.Payments[i] = toPbUnmatchedPayment(payment)
+	}
+	return response, nil
+}
+
+func (h *OrderHandler) LinkPaymentToOrder(ctx context.Context, r *orderpb.LinkPaymentToOrderRequest) (*orderpb.LinkPaymentToOrderResponse, error) {
+	if r.PaymentId == "" || r.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "payment_id and order_id are required")
+	}
+
+	payment, err := h.uc.LinkPaymentToOrder(r.PaymentId, r.OrderId, r.Reason)
+	if err != nil {
+		slog.Error("failed to link payment to order", "payment_id", r.PaymentId, "order_id", r.OrderId, "error", err.Error())
+		switch {
+		case errors.Is(err, domain.ErrUnmatchedPaymentNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, domain.ErrUnmatchedPaymentAlreadyLinked):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, toStatusError(err)
+	}
+
+	return &orderpb.LinkPaymentToOrderResponse{
+		Payment: toPbUnmatchedPayment(payment),
+	}, nil
+}
+
+func toPbUnmatchedPayment(payment *domain.UnmatchedPayment) *orderpb.UnmatchedPayment {
+	pbPayment := &orderpb.UnmatchedPayment{
+		Id: payment.ID,
+		DeviceId: payment.DeviceID,
+		TraderId: payment.TraderID,
+		Amount: payment.Amount,
+		PaymentSystem: payment.PaymentSystem,
+		Direction: payment.Direction,
+		Text: payment.Text,
+		ReceivedAt: timestamppb.New(payment.ReceivedAt),
+		Status: string(payment.Status),
+		OrderId: payment.OrderID,
+		LinkedBy: payment.LinkedBy,
+		CreatedAt: timestamppb.New(payment.CreatedAt),
+		CandidateOrderIds: payment.CandidateOrderIDs,
+	}
+	if payment.LinkedAt != nil {
+		pbPayment.LinkedAt = timestamppb.New(*payment.LinkedAt)
+	}
+	return pbPayment
+}
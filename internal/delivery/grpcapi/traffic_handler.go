@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/LavaJover/shvark-order-service/internal/delivery/grpcapi/mappers"
 	"github.com/LavaJover/shvark-order-service/internal/domain"
 	"github.com/LavaJover/shvark-order-service/internal/usecase"
 	trafficdto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/traffic"
@@ -42,9 +43,18 @@ func (h *TrafficHandler) AddTraffic(ctx context.Context, r *orderpb.AddTrafficRe
 		},
 		BusinessParams: domain.TrafficBusinessParams{
 			MerchantDealsDuration: r.BusinessParams.MerchantDealsDuration.AsDuration(),
+			UniqueAmountStep: r.BusinessParams.UniqueAmountStep,
+			UniqueAmountMaxSteps: r.BusinessParams.UniqueAmountMaxSteps,
+			AmountTolerance: r.BusinessParams.AmountTolerance,
+			AmountTolerancePercent: r.BusinessParams.AmountTolerancePercent,
+			SelectionStrategy: domain.SelectionStrategy(r.BusinessParams.SelectionStrategy),
 		},
 	}
 
+	if !traffic.BusinessParams.SelectionStrategy.Valid() {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown selection strategy: %s", traffic.BusinessParams.SelectionStrategy)
+	}
+
 	if err := h.trafficUsecase.AddTraffic(traffic); err != nil {
 		return &orderpb.AddTrafficResponse{
 			Message: "failed to add new traffic",
@@ -83,6 +93,14 @@ func (h *TrafficHandler) EditTraffic(ctx context.Context, r *orderpb.EditTraffic
 
 	if r.BusinessParams != nil {
 		input.BusinessParams.MerchantDealsDuration = r.BusinessParams.MerchantDealsDuration.AsDuration()
+		input.BusinessParams.UniqueAmountStep = r.BusinessParams.UniqueAmountStep
+		input.BusinessParams.UniqueAmountMaxSteps = r.BusinessParams.UniqueAmountMaxSteps
+		input.BusinessParams.AmountTolerance = r.BusinessParams.AmountTolerance
+		input.BusinessParams.AmountTolerancePercent = r.BusinessParams.AmountTolerancePercent
+		input.BusinessParams.SelectionStrategy = r.BusinessParams.SelectionStrategy
+		if !domain.SelectionStrategy(input.BusinessParams.SelectionStrategy).Valid() {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown selection strategy: %s", input.BusinessParams.SelectionStrategy)
+		}
 	}
 
 	if err := h.trafficUsecase.EditTraffic(input); err != nil {
@@ -138,6 +156,11 @@ func (h *TrafficHandler) GetTrafficRecords(ctx context.Context, r *orderpb.GetTr
 			},
 			BusinessParams: &orderpb.TrafficBusinessParameters{
 				MerchantDealsDuration: durationpb.New(trafficRecord.BusinessParams.MerchantDealsDuration),
+				UniqueAmountStep: trafficRecord.BusinessParams.UniqueAmountStep,
+				UniqueAmountMaxSteps: trafficRecord.BusinessParams.UniqueAmountMaxSteps,
+				AmountTolerance: trafficRecord.BusinessParams.AmountTolerance,
+				AmountTolerancePercent: trafficRecord.BusinessParams.AmountTolerancePercent,
+				SelectionStrategy: string(trafficRecord.BusinessParams.SelectionStrategy),
 			},
 		}
 	}
@@ -283,6 +306,11 @@ func (h *TrafficHandler) GetTraderTraffic(ctx context.Context, req *orderpb.GetT
             },
             BusinessParams: &orderpb.TrafficBusinessParameters{
                 MerchantDealsDuration: durationpb.New(traffic.BusinessParams.MerchantDealsDuration),
+                UniqueAmountStep: traffic.BusinessParams.UniqueAmountStep,
+                UniqueAmountMaxSteps: traffic.BusinessParams.UniqueAmountMaxSteps,
+                AmountTolerance: traffic.BusinessParams.AmountTolerance,
+                AmountTolerancePercent: traffic.BusinessParams.AmountTolerancePercent,
+                SelectionStrategy: string(traffic.BusinessParams.SelectionStrategy),
             },
         })
     }
@@ -291,3 +319,33 @@ func (h *TrafficHandler) GetTraderTraffic(ctx context.Context, req *orderpb.GetT
         Records: records,
     }, nil
 }
+
+func (h *TrafficHandler) SetTrafficSchedule(ctx context.Context, r *orderpb.SetTrafficScheduleRequest) (*orderpb.SetTrafficScheduleResponse, error) {
+	if r.Schedule == nil {
+		return nil, status.Error(codes.InvalidArgument, "schedule is required")
+	}
+	if err := h.trafficUsecase.SetTrafficSchedule(r.TrafficId, mappers.ToDomainSchedule(r.Schedule)); err != nil {
+		return nil, err
+	}
+
+	return &orderpb.SetTrafficScheduleResponse{}, nil
+}
+
+func (h *TrafficHandler) GetTrafficSchedule(ctx context.Context, r *orderpb.GetTrafficScheduleRequest) (*orderpb.GetTrafficScheduleResponse, error) {
+	schedule, err := h.trafficUsecase.GetTrafficSchedule(r.TrafficId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.GetTrafficScheduleResponse{
+		Schedule: mappers.ToProtoSchedule(schedule),
+	}, nil
+}
+
+func (h *TrafficHandler) DeleteTrafficSchedule(ctx context.Context, r *orderpb.DeleteTrafficScheduleRequest) (*orderpb.DeleteTrafficScheduleResponse, error) {
+	if err := h.trafficUsecase.DeleteTrafficSchedule(r.TrafficId); err != nil {
+		return nil, err
+	}
+
+	return &orderpb.DeleteTrafficScheduleResponse{}, nil
+}
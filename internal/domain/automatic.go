@@ -2,6 +2,15 @@ package domain
 
 import "time"
 
+const (
+    // AutomaticActionParseMismatch - текст уведомления, разобранный на сервере, не совпал с данными устройства
+    AutomaticActionParseMismatch = "parse_mismatch"
+    // AutomaticActionDuplicate - в лог: устройство повторно прислало уже записанное уведомление
+    AutomaticActionDuplicate = "duplicate"
+    // AutomaticActionAlreadyProcessed - в ответ на повтор уведомления
+    AutomaticActionAlreadyProcessed = "already_processed"
+)
+
 type AutomaticLogFilter struct {
     DeviceID  string
     TraderID  string
@@ -30,7 +39,7 @@ type AutomaticLog struct {
     ErrorMessage   string
     ProcessingTime int64
     BankName       string
-    CardNumber     string
+    CardNumber     string // маскированный номер карты реквизита
     CreatedAt      time.Time
 }
 
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// AutomaticActionAmbiguous - уведомлению одинаково подходят сделки на разных реквизитах, ни одна не закрыта
+const AutomaticActionAmbiguous = "ambiguous"
+
+// AmountTolerance - допустимое расхождение суммы уведомления с суммой сделки. Берется большее
+// из абсолютного допуска и процента от суммы сделки
+type AmountTolerance struct {
+	Absolute float64
+	Percent  float64
+}
+
+func (t AmountTolerance) IsZero() bool {
+	return t.Absolute <= 0 && t.Percent <= 0
+}
+
+// Allowed - допустимое расхождение для сделки на сумму orderAmount
+func (t AmountTolerance) Allowed(orderAmount float64) float64 {
+	return math.Max(t.Absolute, orderAmount*t.Percent/100)
+}
+
+// AutomaticMatchingPolicy - правила сопоставления уведомления об оплате с активными сделками
+type AutomaticMatchingPolicy struct {
+	// Допуск суммы по умолчанию и допуски банков по коду банка. Допуск трафика важнее допуска банка
+	DefaultTolerance AmountTolerance
+	BankTolerances   map[string]AmountTolerance
+	// Сделка должна быть создана не раньше чем за Window до получения уведомления (0 - без ограничения)
+	Window time.Duration
+	// Расхождение часов устройства и сервера: сделка может быть создана чуть позже времени уведомления
+	ClockSkew time.Duration
+}
+
+// Tolerance - допуск суммы для сделки: трафика, если он задан, иначе банка, иначе по умолчанию
+func (p *AutomaticMatchingPolicy) Tolerance(traffic *Traffic, bankCode string) AmountTolerance {
+	if traffic != nil {
+		trafficTolerance := AmountTolerance{
+			Absolute: traffic.BusinessParams.AmountTolerance,
+			Percent:  traffic.BusinessParams.AmountTolerancePercent,
+		}
+		if !trafficTolerance.IsZero() {
+			return trafficTolerance
+		}
+	}
+	if bankTolerance, ok := p.BankTolerances[bankCode]; ok {
+		return bankTolerance
+	}
+	return p.DefaultTolerance
+}
+
+// InWindow - сделка создана до получения уведомления (с учетом расхождения часов) и не раньше окна
+func (p *AutomaticMatchingPolicy) InWindow(createdAt, receivedAt time.Time) bool {
+	if createdAt.After(receivedAt.Add(p.ClockSkew)) {
+		return false
+	}
+	return p.Window <= 0 || !createdAt.Before(receivedAt.Add(-p.Window))
+}
+
+// MatchCandidate - сделка, подходящая под уведомление, и расхождение ее суммы с суммой уведомления
+type MatchCandidate struct {
+	Order      *Order
+	AmountDiff float64
+}
+
+// diffKopecks - расхождение в копейках. Суммы сравниваются с точностью до копеек целыми числами:
+// сравнение с эпсилоном не транзитивно, и сортировка по нему зависела бы от порядка сделок
+func diffKopecks(diff float64) int64 {
+	return int64(math.Round(diff * 100))
+}
+
+// PickMatchCandidate выбирает одну сделку: с ближайшей суммой, при равенстве - самую старую.
+// Если с той же ближайшей суммой есть сделки на других реквизитах, деньги могли прийти на любой
+// из них - выбор неоднозначен, возвращается nil и эти сделки
+func PickMatchCandidate(candidates []MatchCandidate) (*Order, []*Order) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if di, dj := diffKopecks(candidates[i].AmountDiff), diffKopecks(candidates[j].AmountDiff); di != dj {
+			return di < dj
+		}
+		return candidates[i].Order.CreatedAt.Before(candidates[j].Order.CreatedAt)
+	})
+
+	best := candidates[0]
+	bestDiff := diffKopecks(best.AmountDiff)
+	tied := []*Order{best.Order}
+	ambiguous := false
+	for _, candidate := range candidates[1:] {
+		if diffKopecks(candidate.AmountDiff) != bestDiff {
+			break
+		}
+		tied = append(tied, candidate.Order)
+		if !sameBankDetail(candidate.Order, best.Order) {
+			ambiguous = true
+		}
+	}
+	if ambiguous {
+		return nil, tied
+	}
+	return best.Order, nil
+}
+
+func sameBankDetail(a, b *Order) bool {
+	return a.BankDetailID != nil && b.BankDetailID != nil && *a.BankDetailID == *b.BankDetailID
+}
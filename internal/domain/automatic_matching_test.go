@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutomaticMatchingTolerance(t *testing.T) {
+	policy := &AutomaticMatchingPolicy{
+		DefaultTolerance: AmountTolerance{Absolute: 1},
+		BankTolerances: map[string]AmountTolerance{
+			"sber": {Absolute: 5, Percent: 0.5},
+		},
+	}
+	trafficWith := func(absolute, percent float64) *Traffic {
+		return &Traffic{BusinessParams: TrafficBusinessParams{AmountTolerance: absolute, AmountTolerancePercent: percent}}
+	}
+
+	tests := []struct {
+		name     string
+		traffic  *Traffic
+		bankCode string
+		want     AmountTolerance
+	}{
+		{name: "traffic before bank", traffic: trafficWith(10, 0), bankCode: "sber", want: AmountTolerance{Absolute: 10}},
+		{name: "traffic percent only", traffic: trafficWith(0, 1), bankCode: "sber", want: AmountTolerance{Percent: 1}},
+		{name: "zero traffic tolerance falls back to bank", traffic: trafficWith(0, 0), bankCode: "sber", want: AmountTolerance{Absolute: 5, Percent: 0.5}},
+		{name: "no traffic falls back to bank", bankCode: "sber", want: AmountTolerance{Absolute: 5, Percent: 0.5}},
+		{name: "traffic before default", traffic: trafficWith(2, 0), bankCode: "tinkoff", want: AmountTolerance{Absolute: 2}},
+		{name: "unknown bank falls back to default", traffic: trafficWith(0, 0), bankCode: "tinkoff", want: AmountTolerance{Absolute: 1}},
+		{name: "no bank code falls back to default", want: AmountTolerance{Absolute: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Tolerance(tt.traffic, tt.bankCode); got != tt.want {
+				t.Errorf("Tolerance = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountToleranceAllowed(t *testing.T) {
+	tolerance := AmountTolerance{Absolute: 5, Percent: 1}
+	if got := tolerance.Allowed(100); got != 5 {
+		t.Errorf("Allowed(100) = %v, want the absolute 5", got)
+	}
+	if got := tolerance.Allowed(1000); got != 10 {
+		t.Errorf("Allowed(1000) = %v, want 1%% = 10", got)
+	}
+}
+
+func TestAutomaticMatchingInWindow(t *testing.T) {
+	receivedAt := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	policy := &AutomaticMatchingPolicy{Window: 30 * time.Minute, ClockSkew: time.Minute}
+
+	tests := []struct {
+		name      string
+		policy    *AutomaticMatchingPolicy
+		createdAt time.Time
+		want      bool
+	}{
+		{name: "created at the notification time", policy: policy, createdAt: receivedAt, want: true},
+		{name: "created within the clock skew", policy: policy, createdAt: receivedAt.Add(time.Minute), want: true},
+		{name: "created after the clock skew", policy: policy, createdAt: receivedAt.Add(time.Minute + time.Nanosecond)},
+		{name: "created at the window start", policy: policy, createdAt: receivedAt.Add(-30 * time.Minute), want: true},
+		{name: "created before the window", policy: policy, createdAt: receivedAt.Add(-30*time.Minute - time.Nanosecond)},
+		{name: "no window", policy: &AutomaticMatchingPolicy{ClockSkew: time.Minute}, createdAt: receivedAt.Add(-72 * time.Hour), want: true},
+		{name: "no clock skew", policy: &AutomaticMatchingPolicy{Window: 30 * time.Minute}, createdAt: receivedAt.Add(time.Nanosecond)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.InWindow(tt.createdAt, receivedAt); got != tt.want {
+				t.Errorf("InWindow = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickMatchCandidate(t *testing.T) {
+	now := time.Now()
+	order := func(id, bankDetailID string, age time.Duration) *Order {
+		o := &Order{ID: id, CreatedAt: now.Add(-age)}
+		if bankDetailID != "" {
+			o.BankDetailID = &bankDetailID
+		}
+		return o
+	}
+
+	tests := []struct {
+		name       string
+		candidates []MatchCandidate
+		wantOrder  string
+		wantTied   []string
+	}{
+		{name: "no candidates"},
+		{
+			name: "closest amount wins over older",
+			candidates: []MatchCandidate{
+				{Order: order("old", "bd-1", 10*time.Minute), AmountDiff: 1},
+				{Order: order("close", "bd-2", time.Minute), AmountDiff: 0},
+			},
+			wantOrder: "close",
+		},
+		{
+			name: "oldest wins on the same bank detail",
+			candidates: []MatchCandidate{
+				{Order: order("new", "bd-1", time.Minute), AmountDiff: 0.5},
+				{Order: order("old", "bd-1", 10*time.Minute), AmountDiff: 0.5},
+			},
+			wantOrder: "old",
+		},
+		{
+			name: "diffs within a kopeck are equal",
+			candidates: []MatchCandidate{
+				{Order: order("new", "bd-1", time.Minute), AmountDiff: 0.001},
+				{Order: order("old", "bd-1", 10*time.Minute), AmountDiff: 0.004},
+			},
+			wantOrder: "old",
+		},
+		{
+			name: "a kopeck closer wins over older",
+			candidates: []MatchCandidate{
+				{Order: order("old", "bd-2", 10*time.Minute), AmountDiff: 0.01},
+				{Order: order("new", "bd-1", time.Minute), AmountDiff: 0},
+			},
+			wantOrder: "new",
+		},
+		{
+			name: "ambiguous across bank details",
+			candidates: []MatchCandidate{
+				{Order: order("first", "bd-1", 10*time.Minute), AmountDiff: 0},
+				{Order: order("second", "bd-2", time.Minute), AmountDiff: 0},
+				{Order: order("far", "bd-3", time.Hour), AmountDiff: 3},
+			},
+			wantTied: []string{"first", "second"},
+		},
+		{
+			name: "ambiguous without a bank detail",
+			candidates: []MatchCandidate{
+				{Order: order("first", "", 10*time.Minute), AmountDiff: 0},
+				{Order: order("second", "", time.Minute), AmountDiff: 0},
+			},
+			wantTied: []string{"first", "second"},
+		},
+		{
+			name: "other bank details with a farther amount are not ambiguous",
+			candidates: []MatchCandidate{
+				{Order: order("other", "bd-2", 10*time.Minute), AmountDiff: 0.02},
+				{Order: order("best", "bd-1", time.Minute), AmountDiff: 0.01},
+			},
+			wantOrder: "best",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, tied := PickMatchCandidate(tt.candidates)
+			if gotID := orderID(got); gotID != tt.wantOrder {
+				t.Errorf("picked %q, want %q", gotID, tt.wantOrder)
+			}
+			if len(tied) != len(tt.wantTied) {
+				t.Fatalf("tied %d orders, want %v", len(tied), tt.wantTied)
+			}
+			for i := range tied {
+				if tied[i].ID != tt.wantTied[i] {
+					t.Errorf("tied[%d] = %s, want %s", i, tied[i].ID, tt.wantTied[i])
+				}
+			}
+		})
+	}
+}
+
+// Выбор не зависит от порядка сделок: при сравнении с эпсилоном 0.003 ~ 0.006 ~ 0.009,
+// но 0.003 и 0.009 различаются, и результат сортировки зависел от исходного порядка
+func TestPickMatchCandidateIgnoresOrder(t *testing.T) {
+	now := time.Now()
+	bankDetailID := "bd-1"
+	candidates := []MatchCandidate{
+		{Order: &Order{ID: "closest", BankDetailID: &bankDetailID, CreatedAt: now}, AmountDiff: 0.003},
+		{Order: &Order{ID: "middle", BankDetailID: &bankDetailID, CreatedAt: now.Add(-time.Hour)}, AmountDiff: 0.006},
+		{Order: &Order{ID: "farthest", BankDetailID: &bankDetailID, CreatedAt: now.Add(-2 * time.Hour)}, AmountDiff: 0.009},
+	}
+	permutations := [][]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}
+
+	for _, permutation := range permutations {
+		shuffled := make([]MatchCandidate, len(candidates))
+		for i, idx := range permutation {
+			shuffled[i] = candidates[idx]
+		}
+		got, tied := PickMatchCandidate(shuffled)
+		if orderID(got) != "closest" || tied != nil {
+			t.Errorf("order %v: picked %q (tied %d), want closest", permutation, orderID(got), len(tied))
+		}
+	}
+}
+
+func orderID(order *Order) string {
+	if order == nil {
+		return ""
+	}
+	return order.ID
+}
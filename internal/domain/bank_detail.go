@@ -11,6 +11,13 @@ type BankDetail struct {
 	Country 		string
 	Currency 		string
 	InflowCurrency 	string
+	// Расписание работы, nil - реквизит доступен всегда
+	Schedule 		*Schedule
+	// Автоматическая приостановка по здоровью реквизита (BankDetailHealth), nil - не приостановлен
+	SuspendedUntil 	*time.Time
+	SuspendReason 	string
+	// Банк из справочника BIN по номеру карты не совпал с банком, который указал трейдер
+	BinMismatch 	bool
 	CreatedAt 		time.Time
 	UpdatedAt 		time.Time
 }
@@ -21,10 +28,19 @@ type SearchParams struct {
 	MaxAmountMonth  		float64
 	MaxQuantityDay			int32
 	MaxQuantityMonth		int32
+	// Часовые и недельные лимиты, 0 - без ограничения
+	MaxAmountHour			float64
+	MaxQuantityHour			int32
+	MaxAmountWeek			float64
+	MaxQuantityWeek			int32
 	MinOrderAmount 			float32
 	MaxOrderAmount 			float32
 	Delay 					time.Duration
 	Enabled 				bool
+	// Окна лимитов: календарные или скользящие (пусто - календарные)
+	LimitMode 				LimitMode
+	// IANA-имя часового пояса календарных окон, пусто - пояс сервера
+	Timezone 				string
 }
 
 type DeviceInfo struct {
@@ -75,6 +91,8 @@ type SuitablleBankDetailsQuery struct {
 	NspkCode string
 	PaymentSystem string
 	Currency string
+	// Мерчант сделки: реквизиты трейдеров с уникальными суммами не отбрасываются из-за сделки на ту же сумму
+	MerchantID string
 }
 
 type BankDetailRepository interface {
@@ -99,6 +117,34 @@ type BankDetailRepository interface {
 	FindSuitableBankDetailsInTx(searchQuery *SuitablleBankDetailsQuery) ([]*BankDetail, error)
 
 	FindSuitableBankDetailsWithLock(searchQuery *SuitablleBankDetailsQuery) ([]*BankDetail, error)
+
+	// Блокирует реквизит до конца транзакции и проверяет его по тем же условиям, что FindSuitableBankDetailsInTx
+	// (статические параметры, расписание, лимиты, одновременные сделки). nil - реквизит больше не подходит
+	LockBankDetailIfEligibleInTx(bankDetailID string, searchQuery *SuitablleBankDetailsQuery) (*BankDetail, error)
+
+	// Диагностика подбора: этапы static и limits для всех реквизитов валюты
+	ExplainSuitableBankDetails(searchQuery *SuitablleBankDetailsQuery) ([]*BankDetailSelectionCandidate, error)
+
+	// Счетчики сделок реквизитов для проверки лимитов (PENDING и COMPLETED в окнах лимитов каждого реквизита)
+	GetBankDetailCounters(windows map[string]LimitWindows) ([]*BankDetailCounters, error)
+
+	// Расписание работы реквизита, nil удаляет расписание
+	SetBankDetailSchedule(bankDetailID string, schedule *Schedule) error
+}
+
+// BankDetailCounters - счетчики сделок реквизита, по которым проверяются лимиты
+type BankDetailCounters struct {
+	BankDetailID    string
+	PendingCount    int32
+	HourCount       int32
+	HourAmount      float64
+	DayCount        int32
+	DayAmount       float64
+	WeekCount       int32
+	WeekAmount      float64
+	MonthCount      int32
+	MonthAmount     float64
+	LastCompletedAt *time.Time
 }
 
 type GetBankDetailsFilter struct {
@@ -0,0 +1,93 @@
+package domain
+
+import "time"
+
+// Причины автоматической приостановки реквизита
+const (
+	SuspendReasonConsecutiveCancels = "consecutive_cancels"
+	SuspendReasonWindowCancels      = "window_cancels"
+	SuspendReasonDisputes           = "disputes"
+)
+
+// BankDetailHealth - здоровье реквизита по его сделкам. Реквизит, который банк заблокировал,
+// обычно собирает отмены подряд, поэтому по этим счетчикам он приостанавливается на время остывания.
+// В отличие от антифрода трейдера, приостанавливается только сам реквизит, а не весь трафик
+type BankDetailHealth struct {
+	BankDetailID       string
+	TraderID           string
+	ConsecutiveCancels int32 // отмены подряд, сбрасываются завершенной сделкой и снятием приостановки
+	LastCancelAt       *time.Time
+	LastDisputeAt      *time.Time
+	Suspensions        int32 // сколько раз реквизит приостанавливался
+	LastSuspendedAt    *time.Time
+	// Снятие приостановки: отмены и споры до него в окнах политики не учитываются
+	ResumedAt *time.Time
+	UpdatedAt time.Time
+
+	// Счетчики в окнах политики, считаются по сделкам и не хранятся
+	WindowCancels  int32
+	WindowDisputes int32
+
+	// Текущая приостановка реквизита
+	SuspendedUntil *time.Time
+	SuspendReason  string
+}
+
+// BankDetailHealthPolicy - пороги автоматической приостановки реквизита, 0 - порог выключен
+type BankDetailHealthPolicy struct {
+	MaxConsecutiveCancels int32
+	MaxWindowCancels      int32
+	CancelWindow          time.Duration
+	MaxWindowDisputes     int32
+	DisputeWindow         time.Duration
+	// На сколько приостанавливается реквизит
+	Cooldown time.Duration
+}
+
+// Violation - причина приостановки реквизита по его счетчикам, пустая строка - реквизит здоров
+func (p *BankDetailHealthPolicy) Violation(health *BankDetailHealth) string {
+	switch {
+	case p.MaxConsecutiveCancels > 0 && health.ConsecutiveCancels >= p.MaxConsecutiveCancels:
+		return SuspendReasonConsecutiveCancels
+	case p.MaxWindowCancels > 0 && health.WindowCancels >= p.MaxWindowCancels:
+		return SuspendReasonWindowCancels
+	case p.MaxWindowDisputes > 0 && health.WindowDisputes >= p.MaxWindowDisputes:
+		return SuspendReasonDisputes
+	default:
+		return ""
+	}
+}
+
+// WindowStart - начало окна политики на момент now, но не раньше снятия последней приостановки
+func (h *BankDetailHealth) WindowStart(now time.Time, window time.Duration) time.Time {
+	start := now.Add(-window)
+	if h.ResumedAt != nil && h.ResumedAt.After(start) {
+		return *h.ResumedAt
+	}
+	return start
+}
+
+// IsSuspended - реквизит приостановлен в момент now
+func (b *BankDetail) IsSuspended(now time.Time) bool {
+	return b.SuspendedUntil != nil && now.Before(*b.SuspendedUntil)
+}
+
+type BankDetailHealthRepository interface {
+	// Здоровье реквизита, для реквизита без отмен и споров - пустая запись
+	GetBankDetailHealth(bankDetailID string) (*BankDetailHealth, error)
+	// Учитывает отмену сделки и возвращает обновленную запись
+	RecordBankDetailCancel(bankDetailID, traderID string, at time.Time) (*BankDetailHealth, error)
+	// Завершенная сделка сбрасывает счетчик отмен подряд
+	RecordBankDetailCompletion(bankDetailID string) error
+	// Учитывает открытый спор и возвращает обновленную запись
+	RecordBankDetailDispute(bankDetailID, traderID string, at time.Time) (*BankDetailHealth, error)
+	// Отмены и споры по сделкам реквизита не раньше since
+	CountBankDetailCancels(bankDetailID string, since time.Time) (int32, error)
+	CountBankDetailDisputes(bankDetailID string, since time.Time) (int32, error)
+	// Приостанавливает реквизит до until. false - реквизит уже приостановлен
+	SuspendBankDetail(bankDetailID, reason string, at, until time.Time) (bool, error)
+	// Снимает приостановку и сбрасывает счетчик отмен подряд. false - реквизит не был приостановлен
+	ResumeBankDetail(bankDetailID string, at time.Time) (bool, error)
+	// Реквизиты, у которых приостановка истекла к now, но еще не снята
+	FindExpiredSuspensions(now time.Time) ([]*BankDetail, error)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Длина префикса карты (BIN/IIN), по которой ищется банк-эмитент
+const (
+	MinBinLength = 6
+	MaxBinLength = 8
+)
+
+// BinRecord - запись локального справочника BIN: банк-эмитент карт с префиксом Prefix
+type BinRecord struct {
+	Prefix         string
+	BankName       string
+	BankCode       string
+	NspkCode       string
+	PaymentNetwork string // MIR, VISA, MASTERCARD ...
+	Country        string
+	UpdatedAt      time.Time
+}
+
+// Matches - совпадает ли банк из справочника с банком, который указал трейдер.
+// Сравниваются коды банка, а если код не указан - названия без учета регистра
+func (b *BinRecord) Matches(bankInfo BankInfo) bool {
+	if bankInfo.BankCode != "" && b.BankCode != "" {
+		return strings.EqualFold(bankInfo.BankCode, b.BankCode)
+	}
+	if bankInfo.BankName != "" && b.BankName != "" {
+		return strings.EqualFold(strings.TrimSpace(bankInfo.BankName), strings.TrimSpace(b.BankName))
+	}
+	return true
+}
+
+// BinCandidates - префиксы номера карты от самого длинного к самому короткому
+func BinCandidates(cardNumber string) []string {
+	var prefixes []string
+	for length := MaxBinLength; length >= MinBinLength; length-- {
+		if len(cardNumber) >= length {
+			prefixes = append(prefixes, cardNumber[:length])
+		}
+	}
+	return prefixes
+}
+
+type BinRepository interface {
+	// Запись с самым длинным префиксом номера карты, nil - банк не найден
+	FindBin(cardNumber string) (*BinRecord, error)
+	// Добавляет или обновляет записи справочника, возвращает количество сохраненных
+	UpsertBins(records []*BinRecord) (int, error)
+}
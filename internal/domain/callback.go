@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+type CallbackDeliveryStatus string
+
+const (
+	CallbackPending    CallbackDeliveryStatus = "PENDING"
+	CallbackDelivered  CallbackDeliveryStatus = "DELIVERED"
+	CallbackDeadLetter CallbackDeliveryStatus = "DEAD_LETTER" // попытки исчерпаны или мерчант отклонил колбэк
+)
+
+// CallbackDelivery - колбэк мерчанту о смене статуса сделки, доставляемый фоновым воркером
+type CallbackDelivery struct {
+	ID                   string
+	OrderID              string // пустой, если сделка не была создана (например, FAILED при создании)
+	MerchantID           string
+	MerchantOrderID      string
+	ClientID             string
+	CallbackUrl          string
+	OrderStatus          string // статус сделки, о котором сообщаем мерчанту
+	AmountFiat           float64
+	AmountCrypto         float64
+	OriginalAmountFiat   float64 // сумма мерчанта до сдвига для уникальности (0 - не известна)
+	Currency             string
+	UsdRate              float64
+	ReconciliationSum    float64
+	ReconciliationAmount float64
+	ReconciliationRate   float64
+	Status               CallbackDeliveryStatus
+	Attempts             int
+	LastHTTPCode         int
+	LastError            string
+	NextAttemptAt        time.Time
+	DeliveredAt          *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+type CallbackDeliveryRepository interface {
+	CreateCallbackDelivery(delivery *CallbackDelivery) error
+	// ClaimDueCallbackDeliveries забирает на отправку колбэки, готовые к отправке, и откладывает их на lease,
+	// чтобы другие экземпляры их не отправили повторно.
+	// Колбэк не выдается, пока по той же сделке есть более ранний недоставленный колбэк.
+	ClaimDueCallbackDeliveries(limit int, lease time.Duration) ([]*CallbackDelivery, error)
+	UpdateCallbackDelivery(delivery *CallbackDelivery) error
+	GetCallbackDeliveryByID(deliveryID string) (*CallbackDelivery, error)
+	GetCallbackDeliveriesByOrder(orderID, merchantOrderID string) ([]*CallbackDelivery, error)
+}
+
+type CallbackFormat string
+
+const (
+	CallbackFormatGet  CallbackFormat = "GET"  // устаревший формат: параметры в строке запроса
+	CallbackFormatJSON CallbackFormat = "JSON" // POST с телом CallbackPayload
+)
+
+// MerchantCallbackSettings - настройки колбэков мерчанта.
+// Если Secret задан, колбэки подписываются HMAC-SHA256.
+type MerchantCallbackSettings struct {
+	MerchantID string
+	Format     CallbackFormat
+	Secret     string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type MerchantCallbackSettingsRepository interface {
+	GetMerchantCallbackSettings(merchantID string) (*MerchantCallbackSettings, error)
+	SaveMerchantCallbackSettings(settings *MerchantCallbackSettings) error
+}
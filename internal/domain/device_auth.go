@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// Режимы проверки подписи запросов устройств
+const (
+	// Подпись не проверяется
+	DeviceAuthModeOff = "off"
+	// Подпись проверяется, ошибки пишутся в аудит, но запрос выполняется (на время перевыпуска секретов)
+	DeviceAuthModeLog = "log"
+	// Запрос без верной подписи отклоняется
+	DeviceAuthModeEnforce = "enforce"
+)
+
+// Причины отказа в аудите проверки устройств
+const (
+	DeviceAuthMissingCredentials = "missing_credentials"
+	DeviceAuthUnknownDevice      = "unknown_device"
+	DeviceAuthNoActiveSecret     = "no_active_secret"
+	DeviceAuthBadTimestamp       = "bad_timestamp"
+	DeviceAuthReplay             = "replay"
+	DeviceAuthBadSignature       = "bad_signature"
+	DeviceAuthDeviceMismatch     = "device_mismatch"
+	DeviceAuthTraderMismatch     = "trader_mismatch"
+)
+
+var (
+	ErrDeviceNotFound = errors.New("device not found")
+	// Ротацию и отзыв секретов запросил не владелец устройства
+	ErrDeviceNotOwned = errors.New("device belongs to another trader")
+)
+
+// DeviceAuthError - запрос устройства не прошел проверку, Reason - одна из причин DeviceAuth*
+type DeviceAuthError struct {
+	Reason string
+}
+
+func (e *DeviceAuthError) Error() string {
+	return "device authentication failed: " + e.Reason
+}
+
+// DeviceCredential - секрет устройства для подписи запросов. Выдается при создании устройства
+// и при ротации. После ротации прежний секрет действует до ExpiresAt, чтобы устройство успело
+// получить новый. Отозванный секрет не действует
+type DeviceCredential struct {
+	ID        string
+	DeviceID  string
+	Secret    string
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+}
+
+func (c *DeviceCredential) Active(now time.Time) bool {
+	return c.RevokedAt == nil && (c.ExpiresAt == nil || now.Before(*c.ExpiresAt))
+}
+
+// DeviceAuthRequest - подпись запроса устройства и то, от чьего имени он отправлен
+type DeviceAuthRequest struct {
+	Method    string
+	PeerAddr  string
+	DeviceID  string // из подписи
+	Timestamp string
+	Signature string
+	// Сериализованное тело запроса
+	Payload []byte
+	// Устройство (group, device_id) и трейдер (trader_id) из тела запроса
+	ClaimedDeviceID string
+	ClaimedTraderID string
+}
+
+// DeviceAuthFailure - запись аудита безопасности о запросе устройства, не прошедшем проверку
+type DeviceAuthFailure struct {
+	ID              string
+	DeviceID        string
+	ClaimedDeviceID string
+	ClaimedTraderID string
+	Method          string
+	PeerAddr        string
+	Reason          string
+	Enforced        bool // запрос отклонен (false - режим log)
+	CreatedAt       time.Time
+}
+
+type DeviceCredentialRepository interface {
+	CreateDeviceCredential(credential *DeviceCredential) error
+	// Секреты устройства, действующие в момент now, от новых к старым
+	GetActiveDeviceCredentials(deviceID string, now time.Time) ([]*DeviceCredential, error)
+	// Ограничивает срок действия текущих секретов устройства (ротация)
+	ExpireDeviceCredentials(deviceID string, expiresAt time.Time) error
+	RevokeDeviceCredentials(deviceID string, revokedAt time.Time) error
+	SaveDeviceAuthFailure(failure *DeviceAuthFailure) error
+	// Запоминает подпись запроса устройства до expiresAt. false - подпись уже была (повтор запроса)
+	SaveDeviceRequestNonce(deviceID, signature string, expiresAt time.Time) (bool, error)
+	// Удаляет подписи, срок которых истек до now
+	DeleteExpiredDeviceRequestNonces(now time.Time) (int64, error)
+}
+
+// DeviceRequestSignature - подпись запроса устройства: HMAC-SHA256 секретом устройства
+// от "<метод>.<timestamp>.<тело запроса>" в hex. Метод - полное имя gRPC-метода
+// (/order.OrderService/ProcessAutomaticPayment), чтобы подпись одного метода не подходила к другому
+func DeviceRequestSignature(secret, method, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(method))
+	mac.Write([]byte("."))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
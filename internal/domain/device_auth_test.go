@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// Эталонная подпись для разработчиков приложения устройства: HMAC-SHA256("device-secret",
+// "/order.DeviceService/UpdateDeviceLiveness.1718000000.5:dev-1") в hex
+func TestDeviceRequestSignatureVector(t *testing.T) {
+	got := DeviceRequestSignature("device-secret", "/order.DeviceService/UpdateDeviceLiveness", "1718000000", []byte("5:dev-1"))
+	want := "420fbb67b437f2af99db89aa94c826111cb044b8aba7f3f46190d8aab497b15a"
+	if got != want {
+		t.Errorf("signature = %s, want %s", got, want)
+	}
+}
+
+// Подпись зависит от каждой части запроса: секрета, метода, времени и тела
+func TestDeviceRequestSignatureCoversEveryPart(t *testing.T) {
+	base := DeviceRequestSignature("device-secret", "/order.DeviceService/UpdateDeviceLiveness", "1718000000", []byte("5:dev-1"))
+	variants := map[string]string{
+		"secret":    DeviceRequestSignature("other-secret", "/order.DeviceService/UpdateDeviceLiveness", "1718000000", []byte("5:dev-1")),
+		"method":    DeviceRequestSignature("device-secret", "/order.OrderService/ProcessAutomaticPayment", "1718000000", []byte("5:dev-1")),
+		"timestamp": DeviceRequestSignature("device-secret", "/order.DeviceService/UpdateDeviceLiveness", "1718000001", []byte("5:dev-1")),
+		"payload":   DeviceRequestSignature("device-secret", "/order.DeviceService/UpdateDeviceLiveness", "1718000000", []byte("5:dev-2")),
+	}
+	for part, signature := range variants {
+		if signature == base {
+			t.Errorf("changing the %s does not change the signature", part)
+		}
+	}
+}
+
+func TestDeviceCredentialActive(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name       string
+		credential DeviceCredential
+		want       bool
+	}{
+		{name: "no expiry", credential: DeviceCredential{}, want: true},
+		{name: "within rotation grace", credential: DeviceCredential{ExpiresAt: &future}, want: true},
+		{name: "rotation grace over", credential: DeviceCredential{ExpiresAt: &past}},
+		{name: "revoked", credential: DeviceCredential{RevokedAt: &past}},
+		{name: "revoked within rotation grace", credential: DeviceCredential{ExpiresAt: &future, RevokedAt: &past}},
+	}
+	for _, tt := range tests {
+		if got := tt.credential.Active(now); got != tt.want {
+			t.Errorf("%s: Active = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
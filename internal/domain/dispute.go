@@ -40,6 +40,10 @@ type GetDisputesFilter struct {
 
 type DisputeRepository interface {
 	CreateDispute(dispute *Dispute) error
+	// Создает диспут и переводит сделку в DISPUTE_CREATED в одной транзакции, walletFunc - заморозка
+	// суммы диспута, выполняется последней. Если переход отклонен или заморозка не удалась, не остается
+	// ни диспута, ни заморозки
+	OpenDispute(dispute *Dispute, transition *OrderStatusTransition, events []*OutboxEvent, walletFunc func() error) error
 	UpdateDisputeStatus(disputeID string, status DisputeStatus) error
 	GetDisputeByID(disputeID string) (*Dispute, error)
 	GetDisputeByOrderID(orderID string) (*Dispute, error)
@@ -50,9 +54,12 @@ type DisputeRepository interface {
 		disputeID string,
 		orderID string,
 		newDisputeStatus DisputeStatus,
-		newOrderStatus OrderStatus,
+		oldOrderStatus, newOrderStatus OrderStatus,
 		newOrderAmountFiat, newOrderAmountCrypto, newOrderCryptoRate float64,
 		operation string, // добавляем параметр операции
+		actor, reason string,
+		events []*OutboxEvent,
+		callback *CallbackDelivery, // колбэк мерчанту, ставится в очередь в той же транзакции, nil - без колбэка
 		walletFunc func() error,
 	) error
 }
\ No newline at end of file
@@ -9,4 +9,8 @@ var (
 	ErrOpenDisputeFailed = errors.New("failed to open dispute")
 	ErrResolveDisputeFailed = errors.New("failed to resolve dispute")
 	ErrCancelOrder = errors.New("failed to cancel order")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrConcurrentModification = errors.New("order was modified concurrently")
+	ErrNoUniqueAmount = errors.New("no unique amount left on bank detail")
+	ErrInvalidBankDetail = errors.New("invalid bank detail")
 )
\ No newline at end of file
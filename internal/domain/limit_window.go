@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"sync"
+	"time"
+)
+
+// LimitMode - как отсчитываются окна лимитов реквизита
+type LimitMode string
+
+const (
+	LimitModeCalendar LimitMode = "calendar" // с начала часа, суток, недели (понедельник) и месяца в часовом поясе реквизита
+	LimitModeRolling  LimitMode = "rolling"  // последние 1 час, 24 часа, 7 дней и 30 дней
+)
+
+// Valid - известный режим. Пустая строка допустима и означает календарные окна
+func (m LimitMode) Valid() bool {
+	switch m {
+	case "", LimitModeCalendar, LimitModeRolling:
+		return true
+	}
+	return false
+}
+
+// Длина скользящих окон
+const (
+	rollingHour  = time.Hour
+	rollingDay   = 24 * time.Hour
+	rollingWeek  = 7 * 24 * time.Hour
+	rollingMonth = 30 * 24 * time.Hour
+)
+
+// LimitWindows - начала окон, за которые считаются сделки для часовых, дневных, недельных и месячных лимитов
+type LimitWindows struct {
+	HourStart  time.Time
+	DayStart   time.Time
+	WeekStart  time.Time
+	MonthStart time.Time
+}
+
+// Earliest - самое раннее из начал окон
+func (w LimitWindows) Earliest() time.Time {
+	earliest := w.HourStart
+	for _, start := range []time.Time{w.DayStart, w.WeekStart, w.MonthStart} {
+		if start.Before(earliest) {
+			earliest = start
+		}
+	}
+	return earliest
+}
+
+// LimitWindows - окна лимитов реквизита на момент now. Календарные окна считаются в часовом
+// поясе реквизита, без пояса (или с неизвестным поясом) - в поясе сервера
+func (p *SearchParams) LimitWindows(now time.Time) LimitWindows {
+	if p.LimitMode == LimitModeRolling {
+		return LimitWindows{
+			HourStart:  now.Add(-rollingHour),
+			DayStart:   now.Add(-rollingDay),
+			WeekStart:  now.Add(-rollingWeek),
+			MonthStart: now.Add(-rollingMonth),
+		}
+	}
+
+	local := now.In(limitLocation(p.Timezone))
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	// Неделя начинается с понедельника
+	daysSinceMonday := (int(local.Weekday()) + 6) % 7
+	return LimitWindows{
+		HourStart:  time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, local.Location()),
+		DayStart:   dayStart,
+		WeekStart:  dayStart.AddDate(0, 0, -daysSinceMonday),
+		MonthStart: time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, local.Location()),
+	}
+}
+
+// ValidateTimezone проверяет IANA-имя часового пояса. Пустая строка - пояс сервера
+func ValidateTimezone(name string) error {
+	if name == "" {
+		return nil
+	}
+	_, err := loadLimitLocation(name)
+	return err
+}
+
+// Загруженные часовые пояса: окна считаются на каждый подбор реквизитов
+var limitLocations sync.Map // name -> *time.Location
+
+func limitLocation(name string) *time.Location {
+	if name == "" {
+		return time.Local
+	}
+	location, err := loadLimitLocation(name)
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
+
+func loadLimitLocation(name string) (*time.Location, error) {
+	if cached, ok := limitLocations.Load(name); ok {
+		return cached.(*time.Location), nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	limitLocations.Store(name, location)
+	return location, nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "strings"
+
+// MaskCardNumber оставляет первые и последние 4 цифры карты: 2200 **** **** 1234
+func MaskCardNumber(cardNumber string) string {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(cardNumber)
+	if len(digits) < 12 {
+		return strings.Repeat("*", len(digits))
+	}
+	return digits[:4] + " **** **** " + digits[len(digits)-4:]
+}
+
+// MaskPhone оставляет код страны и последние 4 цифры телефона: +7******4567
+func MaskPhone(phone string) string {
+	if len(phone) < 8 {
+		return strings.Repeat("*", len(phone))
+	}
+	visible := 1
+	if strings.HasPrefix(phone, "+") {
+		visible = 2
+	}
+	return phone[:visible] + strings.Repeat("*", len(phone)-visible-4) + phone[len(phone)-4:]
+}
+
+// MaskOwner оставляет первое слово имени и инициалы остальных: Иван П.
+func MaskOwner(owner string) string {
+	words := strings.Fields(owner)
+	if len(words) == 0 {
+		return ""
+	}
+	masked := []string{words[0]}
+	for _, word := range words[1:] {
+		masked = append(masked, string([]rune(word)[:1])+".")
+	}
+	return strings.Join(masked, " ")
+}
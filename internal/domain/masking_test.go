@@ -0,0 +1,63 @@
+package domain
+
+import "testing"
+
+func TestMaskCardNumber(t *testing.T) {
+	tests := []struct {
+		card string
+		want string
+	}{
+		{card: "2200700012345678", want: "2200 **** **** 5678"},
+		{card: "2200 7000 1234 5678", want: "2200 **** **** 5678"},
+		{card: "2200-7000-1234-5678", want: "2200 **** **** 5678"},
+		{card: "2200700012345678901", want: "2200 **** **** 8901"},
+		{card: "220070001234", want: "2200 **** **** 1234"},
+		{card: "22007000123", want: "***********"},
+		{card: "1234", want: "****"},
+		{card: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := MaskCardNumber(tt.card); got != tt.want {
+			t.Errorf("MaskCardNumber(%q) = %q, want %q", tt.card, got, tt.want)
+		}
+	}
+}
+
+func TestMaskPhone(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  string
+	}{
+		{phone: "+79991234567", want: "+7******4567"},
+		{phone: "89991234567", want: "8******4567"},
+		{phone: "12345678", want: "1***5678"},
+		{phone: "+1234567", want: "+1**4567"},
+		{phone: "1234567", want: "*******"},
+		{phone: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := MaskPhone(tt.phone); got != tt.want {
+			t.Errorf("MaskPhone(%q) = %q, want %q", tt.phone, got, tt.want)
+		}
+	}
+}
+
+func TestMaskOwner(t *testing.T) {
+	tests := []struct {
+		owner string
+		want  string
+	}{
+		{owner: "Иван Петров", want: "Иван П."},
+		{owner: "Иван Петрович Сидоров", want: "Иван П. С."},
+		{owner: "  Иван   Петров ", want: "Иван П."},
+		{owner: "Иван", want: "Иван"},
+		{owner: "IVAN PETROV", want: "IVAN P."},
+		{owner: "   ", want: ""},
+		{owner: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := MaskOwner(tt.owner); got != tt.want {
+			t.Errorf("MaskOwner(%q) = %q, want %q", tt.owner, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// SelectionStrategy - способ выбора одного кандидата (реквизита или трейдера) из подходящих
+type SelectionStrategy string
+
+const (
+	StrategyWeightedRandom        SelectionStrategy = "weighted_random"         // случайно, с весом по приоритету трейдера
+	StrategyRoundRobin            SelectionStrategy = "round_robin"             // по очереди
+	StrategyLeastRecentlyAssigned SelectionStrategy = "least_recently_assigned" // дольше всех без новой сделки
+	StrategyLowestLoad            SelectionStrategy = "lowest_load"             // меньше всего активных сделок
+	StrategyHighestCompletionRate SelectionStrategy = "highest_completion_rate" // лучшая доля завершенных сделок
+)
+
+// Valid - известная стратегия. Пустая строка допустима и означает стратегию по умолчанию
+func (s SelectionStrategy) Valid() bool {
+	switch s {
+	case "", StrategyWeightedRandom, StrategyRoundRobin, StrategyLeastRecentlyAssigned,
+		StrategyLowestLoad, StrategyHighestCompletionRate:
+		return true
+	}
+	return false
+}
+
+// MerchantMatchingSettings - настройки подбора реквизитов для сделок мерчанта.
+// При WaitForRequisites сделка без подходящих реквизитов не падает в FAILED,
+// а ждет освобождения мощностей в статусе WAITING не дольше WaitWindow.
+// SelectionStrategy выбирает реквизит для пай-инов и трейдера для пай-аутов мерчанта.
+type MerchantMatchingSettings struct {
+	MerchantID        string
+	WaitForRequisites bool
+	WaitWindow        time.Duration     // 0 - окно ожидания по умолчанию из конфигурации
+	SelectionStrategy SelectionStrategy // пусто - стратегия по умолчанию из конфигурации
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type MerchantMatchingSettingsRepository interface {
+	GetMerchantMatchingSettings(merchantID string) (*MerchantMatchingSettings, error)
+	SaveMerchantMatchingSettings(settings *MerchantMatchingSettings) error
+}
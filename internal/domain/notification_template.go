@@ -0,0 +1,244 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Направления платежа в уведомлении банка, как их передает приложение устройства
+const (
+	NotificationDirectionIn  = "in"
+	NotificationDirectionOut = "out"
+)
+
+// Именованные группы шаблона уведомления. Обязательна только amount
+const (
+	NotificationGroupAmount   = "amount"
+	NotificationGroupCurrency = "currency"
+	NotificationGroupSender   = "sender"
+	NotificationGroupCardTail = "card_tail"
+)
+
+var (
+	ErrNotificationTemplateNotFound = errors.New("notification template not found")
+	ErrInvalidNotificationTemplate  = errors.New("invalid notification template")
+)
+
+// NotificationTemplate - шаблон SMS или push-уведомления банка. Pattern - регулярное выражение
+// с именованными группами amount, currency, sender, card_tail. Шаблон описывает один вид
+// уведомлений, поэтому направление платежа задается самим шаблоном
+type NotificationTemplate struct {
+	ID        string
+	BankCode  string // код банка, как payment_system в уведомлении от устройства
+	Name      string
+	Pattern   string
+	Direction string // in, out
+	// Валюта уведомлений без группы currency
+	DefaultCurrency string
+	// Шаблоны банка проверяются по убыванию приоритета
+	Priority int32
+	Enabled  bool
+	// Образцы реальных уведомлений с ожидаемым результатом разбора. Шаблон не сохраняется,
+	// если хотя бы один образец разбирается не так, как ожидается
+	Samples   []NotificationSample
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NotificationSample - образец уведомления для проверки шаблона. Expected = nil - шаблон
+// не должен разбирать этот текст (например, уведомление о списании похожего вида)
+type NotificationSample struct {
+	Text     string
+	Expected *ParsedNotification
+}
+
+// ParsedNotification - данные, извлеченные из текста уведомления
+type ParsedNotification struct {
+	TemplateID string
+	BankCode   string
+	Amount     float64
+	Currency   string
+	SenderName string
+	CardTail   string
+	Direction  string
+}
+
+// Compile проверяет шаблон и компилирует его регулярное выражение
+func (t *NotificationTemplate) Compile() (*regexp.Regexp, error) {
+	if strings.TrimSpace(t.BankCode) == "" {
+		return nil, fmt.Errorf("bank_code is required")
+	}
+	if t.Direction != NotificationDirectionIn && t.Direction != NotificationDirectionOut {
+		return nil, fmt.Errorf("invalid direction %q, expected %s or %s", t.Direction, NotificationDirectionIn, NotificationDirectionOut)
+	}
+	re, err := regexp.Compile(t.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	if re.SubexpIndex(NotificationGroupAmount) < 0 {
+		return nil, fmt.Errorf("pattern must contain named group (?P<%s>...)", NotificationGroupAmount)
+	}
+	return re, nil
+}
+
+// Validate компилирует шаблон и прогоняет через него образцы уведомлений
+func (t *NotificationTemplate) Validate() error {
+	re, err := t.Compile()
+	if err != nil {
+		return err
+	}
+	for i, sample := range t.Samples {
+		parsed, ok := t.Parse(re, sample.Text)
+		switch {
+		case sample.Expected == nil && ok:
+			return fmt.Errorf("sample %d: expected no match, got amount %.2f", i+1, parsed.Amount)
+		case sample.Expected == nil:
+			continue
+		case !ok:
+			return fmt.Errorf("sample %d: pattern does not match", i+1)
+		}
+		if diff := notificationDiff(sample.Expected, parsed); diff != "" {
+			return fmt.Errorf("sample %d: %s", i+1, diff)
+		}
+	}
+	return nil
+}
+
+// Parse разбирает текст уведомления скомпилированным шаблоном re. false - текст не подходит под шаблон
+func (t *NotificationTemplate) Parse(re *regexp.Regexp, text string) (*ParsedNotification, bool) {
+	match := re.FindStringSubmatch(normalizeNotificationText(text))
+	if match == nil {
+		return nil, false
+	}
+	group := func(name string) string {
+		if index := re.SubexpIndex(name); index >= 0 {
+			return strings.TrimSpace(match[index])
+		}
+		return ""
+	}
+
+	amount, err := ParseNotificationAmount(group(NotificationGroupAmount))
+	if err != nil {
+		return nil, false
+	}
+	currency := NormalizeNotificationCurrency(group(NotificationGroupCurrency))
+	if currency == "" {
+		currency = t.DefaultCurrency
+	}
+	return &ParsedNotification{
+		TemplateID: t.ID,
+		BankCode:   t.BankCode,
+		Amount:     amount,
+		Currency:   currency,
+		SenderName: group(NotificationGroupSender),
+		CardTail:   group(NotificationGroupCardTail),
+		Direction:  t.Direction,
+	}, true
+}
+
+// notificationDiff - расхождение разобранного образца с ожидаемым, пустая строка - совпадает.
+// Пустые ожидаемые поля не проверяются
+func notificationDiff(expected, parsed *ParsedNotification) string {
+	switch {
+	case math.Abs(expected.Amount-parsed.Amount) >= 0.005:
+		return fmt.Sprintf("amount %.2f, expected %.2f", parsed.Amount, expected.Amount)
+	case expected.Currency != "" && expected.Currency != parsed.Currency:
+		return fmt.Sprintf("currency %q, expected %q", parsed.Currency, expected.Currency)
+	case expected.SenderName != "" && expected.SenderName != parsed.SenderName:
+		return fmt.Sprintf("sender %q, expected %q", parsed.SenderName, expected.SenderName)
+	case expected.CardTail != "" && expected.CardTail != parsed.CardTail:
+		return fmt.Sprintf("card tail %q, expected %q", parsed.CardTail, expected.CardTail)
+	default:
+		return ""
+	}
+}
+
+// Mismatches - расхождения разобранного уведомления с данными, которые прислало устройство
+func (p *ParsedNotification) Mismatches(amount float64, bankCode, direction string) []string {
+	var mismatches []string
+	if math.Abs(p.Amount-amount) >= 0.005 {
+		mismatches = append(mismatches, fmt.Sprintf("amount: text %.2f, device %.2f", p.Amount, amount))
+	}
+	if !strings.EqualFold(p.BankCode, bankCode) {
+		mismatches = append(mismatches, fmt.Sprintf("payment_system: text %s, device %s", p.BankCode, bankCode))
+	}
+	if direction != "" && p.Direction != direction {
+		mismatches = append(mismatches, fmt.Sprintf("direction: text %s, device %s", p.Direction, direction))
+	}
+	return mismatches
+}
+
+// normalizeNotificationText заменяет неразрывные и узкие пробелы обычными: банки используют
+// их в суммах, и шаблонам не нужно учитывать каждый вариант
+func normalizeNotificationText(text string) string {
+	return strings.NewReplacer("\u00a0", " ", "\u202f", " ", "\u2009", " ").Replace(text)
+}
+
+// ParseNotificationAmount разбирает сумму в записи банков: "1 234,56", "1,234.56", "1234.5", "1.234,56"
+func ParseNotificationAmount(value string) (float64, error) {
+	value = strings.ReplaceAll(normalizeNotificationText(value), " ", "")
+	if value == "" {
+		return 0, fmt.Errorf("empty amount")
+	}
+
+	lastComma := strings.LastIndex(value, ",")
+	lastDot := strings.LastIndex(value, ".")
+	switch {
+	case lastComma >= 0 && lastDot >= 0:
+		// Десятичный разделитель - последний из двух, второй разделяет разряды
+		if lastComma > lastDot {
+			value = strings.ReplaceAll(value, ".", "")
+			value = strings.Replace(value, ",", ".", 1)
+		} else {
+			value = strings.ReplaceAll(value, ",", "")
+		}
+	case lastComma >= 0:
+		// Одна запятая с 1-2 цифрами после нее - десятичная, иначе разделитель разрядов
+		if strings.Count(value, ",") == 1 && len(value)-lastComma-1 <= 2 {
+			value = strings.Replace(value, ",", ".", 1)
+		} else {
+			value = strings.ReplaceAll(value, ",", "")
+		}
+	case lastDot >= 0 && (strings.Count(value, ".") > 1 || len(value)-lastDot-1 == 3):
+		// Точки как разделитель разрядов: "1.234.567", "12.500"
+		value = strings.ReplaceAll(value, ".", "")
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount <= 0 {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
+
+// NormalizeNotificationCurrency приводит обозначение валюты в уведомлении к коду ISO 4217
+func NormalizeNotificationCurrency(value string) string {
+	switch strings.ToUpper(strings.TrimSuffix(strings.TrimSpace(value), ".")) {
+	case "":
+		return ""
+	case "₽", "Р", "РУБ", "RUR", "RUB":
+		return "RUB"
+	case "$", "USD":
+		return "USD"
+	case "€", "EUR":
+		return "EUR"
+	case "₸", "KZT", "ТГ", "ТЕНГЕ":
+		return "KZT"
+	default:
+		return strings.ToUpper(strings.TrimSpace(value))
+	}
+}
+
+type NotificationTemplateRepository interface {
+	CreateNotificationTemplate(template *NotificationTemplate) error
+	UpdateNotificationTemplate(template *NotificationTemplate) error
+	DeleteNotificationTemplate(templateID string) error
+	GetNotificationTemplate(templateID string) (*NotificationTemplate, error)
+	// Шаблоны банка (пустой bankCode - все банки), по убыванию приоритета
+	ListNotificationTemplates(bankCode string, enabledOnly bool) ([]*NotificationTemplate, error)
+}
@@ -0,0 +1,158 @@
+package domain
+
+import "testing"
+
+// Шаблоны в том виде, в каком их заводит саппорт, и образцы реальных уведомлений банков
+var sampleTemplates = map[string]*NotificationTemplate{
+	"sber_sms_in": {
+		ID:              "sber_sms_in",
+		BankCode:        "sber",
+		Pattern:         `^СЧ[ЕЁ]Т(?P<card_tail>\d{4}) \d{2}:\d{2} Перевод (?P<amount>[\d ]+(?:,\d{1,2})?)(?P<currency>р) от (?P<sender>.+?) Баланс`,
+		Direction:       NotificationDirectionIn,
+		DefaultCurrency: "RUB",
+	},
+	"tinkoff_push_in": {
+		ID:              "tinkoff_push_in",
+		BankCode:        "tinkoff",
+		Pattern:         `^Пополнение на (?P<amount>[\d ]+(?:,\d{1,2})?) (?P<currency>₽), счет RUB\. (?P<sender>.+?) Доступно`,
+		Direction:       NotificationDirectionIn,
+		DefaultCurrency: "RUB",
+	},
+	"alfa_sms_in": {
+		ID:              "alfa_sms_in",
+		BankCode:        "alfa",
+		Pattern:         `^Поступление (?P<amount>[\d ]+(?:,\d{2})?) (?P<currency>RUR) на счет \*(?P<card_tail>\d{4}) от (?P<sender>.+)$`,
+		Direction:       NotificationDirectionIn,
+		DefaultCurrency: "RUB",
+	},
+	"vtb_push_in": {
+		ID:              "vtb_push_in",
+		BankCode:        "vtb",
+		Pattern:         `^Поступление (?P<amount>[\d.,]+) ?(?P<currency>₽|руб\.?) Счет \*(?P<card_tail>\d{4})`,
+		Direction:       NotificationDirectionIn,
+		DefaultCurrency: "RUB",
+	},
+}
+
+func TestNotificationTemplateParseSamples(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		text     string
+		want     *ParsedNotification // nil - текст не должен разбираться шаблоном
+	}{
+		{
+			name:     "sber transfer",
+			template: "sber_sms_in",
+			text:     "СЧЁТ1234 10:15 Перевод 1 500р от Иван И. Баланс: 12 345,67р",
+			want:     &ParsedNotification{Amount: 1500, Currency: "RUB", SenderName: "Иван И.", CardTail: "1234"},
+		},
+		{
+			name:     "sber transfer with kopecks",
+			template: "sber_sms_in",
+			text:     "СЧЕТ9876 23:59 Перевод 2 350,50р от Анна С. Баланс: 4 000р",
+			want:     &ParsedNotification{Amount: 2350.5, Currency: "RUB", SenderName: "Анна С.", CardTail: "9876"},
+		},
+		{
+			name:     "sber purchase is not an incoming transfer",
+			template: "sber_sms_in",
+			text:     "СЧЁТ1234 10:20 Покупка 500р Пятерочка Баланс: 11 845,67р",
+		},
+		{
+			name:     "tinkoff push with non-breaking spaces",
+			template: "tinkoff_push_in",
+			text:     "Пополнение на 2\u00a0000\u00a0₽, счет RUB. Иван П. Доступно 5\u00a0000\u00a0₽",
+			want:     &ParsedNotification{Amount: 2000, Currency: "RUB", SenderName: "Иван П."},
+		},
+		{
+			name:     "tinkoff push with narrow no-break space",
+			template: "tinkoff_push_in",
+			text:     "Пополнение на 15\u202f000,25 ₽, счет RUB. Олег К. Доступно 20\u202f000 ₽",
+			want:     &ParsedNotification{Amount: 15000.25, Currency: "RUB", SenderName: "Олег К."},
+		},
+		{
+			name:     "alfa transfer in RUR",
+			template: "alfa_sms_in",
+			text:     "Поступление 10 000,50 RUR на счет *5678 от ПЕТРОВ П.П.",
+			want:     &ParsedNotification{Amount: 10000.5, Currency: "RUB", SenderName: "ПЕТРОВ П.П.", CardTail: "5678"},
+		},
+		{
+			name:     "vtb push with thousands dot",
+			template: "vtb_push_in",
+			text:     "Поступление 12.500 ₽ Счет *4321 от Сергей Н.",
+			want:     &ParsedNotification{Amount: 12500, Currency: "RUB", CardTail: "4321"},
+		},
+		{
+			name:     "vtb push with rub abbreviation",
+			template: "vtb_push_in",
+			text:     "Поступление 999,99руб. Счет *4321",
+			want:     &ParsedNotification{Amount: 999.99, Currency: "RUB", CardTail: "4321"},
+		},
+		{
+			name:     "sber text does not match tinkoff template",
+			template: "tinkoff_push_in",
+			text:     "СЧЁТ1234 10:15 Перевод 1 500р от Иван И. Баланс: 12 345,67р",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := sampleTemplates[tt.template]
+			re, err := template.Compile()
+			if err != nil {
+				t.Fatalf("template %s does not compile: %v", tt.template, err)
+			}
+			parsed, ok := template.Parse(re, tt.text)
+			if tt.want == nil {
+				if ok {
+					t.Fatalf("expected no match, got amount %.2f", parsed.Amount)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("template %s does not match %q", tt.template, tt.text)
+			}
+			if diff := notificationDiff(tt.want, parsed); diff != "" {
+				t.Errorf("%s", diff)
+			}
+			if parsed.TemplateID != template.ID || parsed.BankCode != template.BankCode || parsed.Direction != template.Direction {
+				t.Errorf("parsed template %s, bank %s, direction %s, want %s, %s, %s",
+					parsed.TemplateID, parsed.BankCode, parsed.Direction, template.ID, template.BankCode, template.Direction)
+			}
+		})
+	}
+}
+
+func TestParseNotificationAmount(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{value: "1500", want: 1500},
+		{value: "1 234,56", want: 1234.56},
+		{value: "1\u00a0234,56", want: 1234.56},
+		{value: "1,234.56", want: 1234.56},
+		{value: "1.234,56", want: 1234.56},
+		{value: "1234.5", want: 1234.5},
+		{value: "12.500", want: 12500},
+		{value: "1.234.567", want: 1234567},
+		{value: "12,500", want: 12500},
+		{value: "99,9", want: 99.9},
+		{value: "", wantErr: true},
+		{value: "0", wantErr: true},
+		{value: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseNotificationAmount(tt.value)
+		switch {
+		case tt.wantErr && err == nil:
+			t.Errorf("ParseNotificationAmount(%q) = %.2f, want error", tt.value, got)
+		case !tt.wantErr && err != nil:
+			t.Errorf("ParseNotificationAmount(%q) failed: %v", tt.value, err)
+		case !tt.wantErr && got != tt.want:
+			t.Errorf("ParseNotificationAmount(%q) = %.2f, want %.2f", tt.value, got, tt.want)
+		}
+	}
+}
@@ -21,6 +21,7 @@ const (
 	StatusCanceled 		  OrderStatus = "CANCELED"
 	StatusCompleted 	  OrderStatus = "COMPLETED"
 	StatusDisputeCreated  OrderStatus = "DISPUTE"
+	StatusWaiting 		  OrderStatus = "WAITING" // реквизитов нет, сделка ждет освобождения мощностей
 )
 
 type Order struct {
@@ -44,6 +45,9 @@ type Order struct {
 	ExpiresAt 		time.Time
 	CreatedAt 		time.Time
 	UpdatedAt 		time.Time
+
+	// Версия записи, увеличивается при каждой смене статуса (оптимистичная блокировка)
+	Version			int64
 }
 
 type RequisiteDetails struct {
@@ -78,6 +82,8 @@ type AmountInfo struct {
 	AmountCrypto 	float64
 	CryptoRate 		float64
 	Currency 		string
+	// Сумма, запрошенная мерчантом. Отличается от AmountFiat, если сумма сдвинута для уникальности на реквизите
+	OriginalAmountFiat float64
 }
 
 type OrderFilters struct {
@@ -130,8 +136,8 @@ type AllOrdersFilters struct {
 }
 
 type OrderRepository interface {
-	CreateOrder(order *Order) error
-	UpdateOrderStatus(orderID string, newStatus OrderStatus) error
+	// Сделка, первая запись истории ее статусов и события о ней пишутся в одной транзакции
+	CreateOrder(order *Order, events []*OutboxEvent) error
 	GetOrderByID(orderID string) (*Order, error)
 	GetOrderByMerchantOrderID(merchantOrderID string) (*Order, error)
 	GetOrdersByTraderID(
@@ -141,6 +147,8 @@ type OrderRepository interface {
 		filters OrderFilters,
 		) ([]*Order, int64, error)
 	GetOrdersByBankDetailID(bankDetailID string) ([]*Order, error)
+	// Суммы активных (PENDING) сделок на реквизите
+	GetPendingAmountsByBankDetailID(bankDetailID string) ([]float64, error)
 	FindExpiredOrders() ([]*Order, error)
 	GetCreatedOrdersByClientID(clientID string) ([]*Order, error)
 	GetOrderStatistics(traderID string, dateFrom, dateTo time.Time) (*OrderStatistics, error)
@@ -149,15 +157,59 @@ type OrderRepository interface {
 
 	GetAllOrders(filter *AllOrdersFilters, sort string, page, limit int32) ([]*Order, int64, error)
 
+	// Переход валидируется OrderStateMachine и сохраняется в истории статусов,
+	// события пишутся в outbox в той же транзакции
 	ProcessOrderCriticalOperation(
-		orderID string, 
-		newStatus OrderStatus, 
-		operation string, // добавляем параметр операции
+		transition *OrderStatusTransition,
+		events []*OutboxEvent,
+		walletFunc func() error,
+	) error
+	// То же, что ProcessOrderCriticalOperation, но вместе со статусом обновляет суммы сделки
+	ProcessOrderCriticalOperationWithAmounts(
+		transition *OrderStatusTransition,
+		amounts AmountInfo,
+		events []*OutboxEvent,
+		walletFunc func() error,
+	) error
+	// То же, что ProcessOrderCriticalOperation, но в той же транзакции привязывает к сделке платеж
+	// из очереди непривязанных (ErrUnmatchedPaymentAlreadyLinked, если он уже привязан).
+	// amounts = nil - суммы сделки не меняются
+	ProcessOrderCriticalOperationWithPaymentLink(
+		transition *OrderStatusTransition,
+		amounts *AmountInfo,
+		link *UnmatchedPaymentLink,
+		events []*OutboxEvent,
 		walletFunc func() error,
 	) error
+	// Переносит сделку на реквизит и трейдера из order, переход сохраняется в истории статусов,
+	// события пишутся в outbox в той же транзакции
+	ReassignOrderRequisites(transition *OrderStatusTransition, order *Order, events []*OutboxEvent) error
+	// Все активные (PENDING) сделки
+	FindPendingOrders() ([]*Order, error)
+	// Сделки в ожидании реквизитов, самые старые первыми
+	FindWaitingOrders(limit int) ([]*Order, error)
+	// Статистика сделок типа orderType по реквизитам (byTrader = false) или трейдерам.
+	// Completed и Canceled считаются по сделкам, созданным не раньше since
+	GetAssignmentStats(ids []string, byTrader bool, orderType OrderType, since time.Time) (map[string]*AssignmentStats, error)
+	GetOrderHistory(orderID string) ([]*OrderStatusTransition, error)
 
 	CheckDuplicatePayment(ctx context.Context, orderID string, paymentHash string) (bool, error)
+	// Записывает уведомление перед обработкой. false - уведомление с тем же хэшем уже записано,
+	// возвращается его запись. Незавершенная запись, взятая в обработку раньше staleBefore,
+	// перехватывается: обработка могла прерваться вместе с экземпляром сервиса. Запись, обработка
+	// которой дошла до закрытия сделки, не перехватывается никогда
+	ClaimPaymentNotification(ctx context.Context, log *PaymentProcessingLog, staleBefore time.Time) (bool, *PaymentProcessingLog, error)
+	// Отмечает перед закрытием сделки orderID, что обработка дошла до закрытия. Ошибка, если запись
+	// перехвачена другим обработчиком: закрывать сделку тогда нельзя
+	MarkPaymentNotificationApproving(ctx context.Context, log *PaymentProcessingLog, orderID string) error
+	// Сохраняет результат обработки уведомления
+	CompletePaymentNotification(ctx context.Context, log *PaymentProcessingLog) error
+	// Удаляет незавершенную запись этого обработчика, чтобы повтор уведомления обработался заново.
+	// Запись, обработка которой дошла до закрытия сделки, не удаляется
+	ReleasePaymentNotification(ctx context.Context, log *PaymentProcessingLog) error
 	FindPendingOrdersByDeviceID(deviceID string) ([]*Order, error)
+	// Пай-ин сделки устройства, отмененные actor не раньше canceledSince (по времени перехода в CANCELED)
+	FindRecentlyCanceledOrdersByDeviceID(deviceID, actor string, canceledSince time.Time) ([]*Order, error)
 
 	SaveAutomaticLog(ctx context.Context, log *AutomaticLog) error
     GetAutomaticLogs(ctx context.Context, filter *AutomaticLogFilter) ([]*AutomaticLog, error)
@@ -171,25 +223,29 @@ type OrderRepository interface {
 	Rollback() error
 	
 	// Методы для работы в транзакции
-	CreateOrderInTx(order *Order) error
+	CreateOrderInTx(order *Order, events []*OutboxEvent) error
 	GetCreatedOrdersByClientIDInTx(clientID string) ([]*Order, error)
+	ReassignOrderRequisitesInTx(transition *OrderStatusTransition, order *Order, events []*OutboxEvent) error
 }
 
+// PaymentProcessingLog - запись об уведомлении об оплате для идемпотентной обработки
 type PaymentProcessingLog struct {
-	ID           string    `gorm:"primaryKey;type:uuid"`
-	OrderID      string    `gorm:"type:uuid;not null;index"`
-	PaymentHash  string    `gorm:"not null;index"` // Хэш уведомления для идемпотентности
-	Amount       float64   `gorm:"not null"`
-	PaymentSystem string   `gorm:"not null"`
-	ProcessedAt  time.Time `gorm:"not null"`
-	Success      bool      `gorm:"not null"`
-	Error        string    
-	Metadata     string    `gorm:"type:jsonb"` // Дополнительные данные
+	ID            string
+	PaymentHash   string // хэш устройства, суммы, времени получения и текста уведомления
+	DeviceID      string
+	OrderID       string // первая закрытая по уведомлению сделка
+	Amount        float64
+	PaymentSystem string
+	ProcessedAt   time.Time // когда уведомление взято в обработку
+	CompletedAt   *time.Time // nil - уведомление еще обрабатывается
+	Success       bool
+	Error         string
+	Result        *AutomaticPaymentResult
 }
 
 type OrderProcessingResult struct {
 	OrderID string `json:"order_id"`
-	Action  string `json:"action"` // approved, failed, already_processed
+	Action  string `json:"action"` // approved, recovered, failed, already_processed
 	Success bool   `json:"success"`
 	Error   string `json:"error,omitempty"`
 }
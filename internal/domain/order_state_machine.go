@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Операции, меняющие статус сделки
+const (
+	OrderOpCreate        = "create"
+	OrderOpAccept        = "accept"
+	OrderOpApprove       = "approve"
+	OrderOpAutoApprove   = "auto_approve"
+	OrderOpCancel        = "cancel"
+	OrderOpRecover       = "recover" // закрытие отмененной сделки, оплаченной клиентом с опозданием
+	OrderOpReassign      = "reassign" // смена реквизита сделки (например, не удалась заморозка у трейдера)
+	OrderOpAssign        = "assign" // назначение реквизита сделке из очереди ожидания
+	OrderOpWaitExpire    = "wait_expire" // реквизит так и не нашелся за окно ожидания
+	OrderOpDisputeOpen   = "dispute_open"
+	OrderOpDisputeAccept = "dispute_accept"
+	OrderOpDisputeReject = "dispute_reject"
+	OrderOpDisputeFreeze = "dispute_freeze"
+)
+
+// Инициаторы перехода
+const (
+	ActorSystem    = "system"    // внутренняя логика сервиса
+	ActorMerchant  = "merchant"  // создание сделки мерчантом
+	ActorOperator  = "operator"  // ручное действие через API (трейдер / саппорт)
+	ActorAutomatic = "automatic" // автоматика по уведомлению с устройства
+	ActorScheduler = "scheduler" // фоновые задачи (таймауты, автопринятие диспутов)
+)
+
+// OrderStatusTransition - запись в истории статусов сделки
+type OrderStatusTransition struct {
+	ID         string
+	OrderID    string
+	FromStatus OrderStatus // пустой статус - сделка только создана
+	ToStatus   OrderStatus
+	Actor      string
+	Operation  string
+	Reason     string
+	CreatedAt  time.Time
+	// Колбэк мерчанту о новом статусе, ставится в очередь доставки в той же транзакции. nil - без колбэка
+	Callback *CallbackDelivery
+}
+
+type orderTransitionRule struct {
+	from []OrderStatus
+	to   []OrderStatus
+}
+
+// OrderStateMachine описывает допустимые переходы между статусами сделки.
+// Каждая операция разрешена только из определенных статусов и только в определенные статусы.
+type OrderStateMachine struct {
+	rules map[string]orderTransitionRule
+}
+
+func NewOrderStateMachine() *OrderStateMachine {
+	return &OrderStateMachine{
+		rules: map[string]orderTransitionRule{
+			OrderOpCreate: {
+				from: []OrderStatus{""},
+				to:   []OrderStatus{StatusCreated, StatusPending, StatusFailed, StatusWaiting},
+			},
+			OrderOpAccept: {
+				from: []OrderStatus{StatusCreated},
+				to:   []OrderStatus{StatusPending},
+			},
+			OrderOpApprove: {
+				from: []OrderStatus{StatusPending},
+				to:   []OrderStatus{StatusCompleted},
+			},
+			OrderOpAutoApprove: {
+				from: []OrderStatus{StatusPending},
+				to:   []OrderStatus{StatusCompleted},
+			},
+			OrderOpCancel: {
+				from: []OrderStatus{StatusPending, StatusDisputeCreated},
+				to:   []OrderStatus{StatusCanceled},
+			},
+			// Статус не меняется, смена реквизита фиксируется в истории
+			OrderOpReassign: {
+				from: []OrderStatus{StatusPending},
+				to:   []OrderStatus{StatusPending},
+			},
+			OrderOpAssign: {
+				from: []OrderStatus{StatusWaiting},
+				to:   []OrderStatus{StatusPending},
+			},
+			OrderOpWaitExpire: {
+				from: []OrderStatus{StatusWaiting},
+				to:   []OrderStatus{StatusFailed},
+			},
+			OrderOpRecover: {
+				from: []OrderStatus{StatusCanceled},
+				to:   []OrderStatus{StatusCompleted},
+			},
+			OrderOpDisputeOpen: {
+				from: []OrderStatus{StatusCanceled, StatusCompleted},
+				to:   []OrderStatus{StatusDisputeCreated},
+			},
+			OrderOpDisputeAccept: {
+				from: []OrderStatus{StatusDisputeCreated},
+				to:   []OrderStatus{StatusCompleted},
+			},
+			// При отклонении диспута сделка возвращается в исходный статус
+			OrderOpDisputeReject: {
+				from: []OrderStatus{StatusDisputeCreated},
+				to:   []OrderStatus{StatusCanceled, StatusCompleted},
+			},
+			// Заморозка диспута не меняет статус сделки, но фиксируется в истории
+			OrderOpDisputeFreeze: {
+				from: []OrderStatus{StatusDisputeCreated},
+				to:   []OrderStatus{StatusDisputeCreated},
+			},
+		},
+	}
+}
+
+// CanApply проверяет, можно ли выполнить операцию над сделкой в текущем статусе
+func (sm *OrderStateMachine) CanApply(operation string, from OrderStatus) bool {
+	rule, ok := sm.rules[operation]
+	if !ok {
+		return false
+	}
+	return containsStatus(rule.from, from)
+}
+
+// Validate проверяет переход from -> to для указанной операции
+func (sm *OrderStateMachine) Validate(operation string, from, to OrderStatus) error {
+	rule, ok := sm.rules[operation]
+	if !ok {
+		return fmt.Errorf("%w: unknown operation %q", ErrInvalidStatusTransition, operation)
+	}
+	if !containsStatus(rule.from, from) || !containsStatus(rule.to, to) {
+		return fmt.Errorf("%w: %s -> %s (operation %s)", ErrInvalidStatusTransition, from, to, operation)
+	}
+	return nil
+}
+
+// ValidateDisputeReject проверяет отклонение диспута: сделка возвращается только в статус original,
+// который был у нее до открытия диспута (отмененная сделка не может стать завершенной)
+func (sm *OrderStateMachine) ValidateDisputeReject(from, to, original OrderStatus) error {
+	if err := sm.Validate(OrderOpDisputeReject, from, to); err != nil {
+		return err
+	}
+	if to != original {
+		return fmt.Errorf("%w: %s -> %s (operation %s), order was %s before the dispute",
+			ErrInvalidStatusTransition, from, to, OrderOpDisputeReject, original)
+	}
+	return nil
+}
+
+func containsStatus(statuses []OrderStatus, status OrderStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
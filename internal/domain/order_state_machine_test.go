@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+var allOrderStatuses = []OrderStatus{
+	"", StatusCreated, StatusPending, StatusWaiting, StatusCompleted, StatusCanceled, StatusFailed, StatusDisputeCreated,
+}
+
+// Все допустимые переходы: операция -> from -> to. Остальные сочетания должны отклоняться
+var allowedTransitions = map[string]map[OrderStatus][]OrderStatus{
+	OrderOpCreate:        {"": {StatusCreated, StatusPending, StatusFailed, StatusWaiting}},
+	OrderOpAccept:        {StatusCreated: {StatusPending}},
+	OrderOpApprove:       {StatusPending: {StatusCompleted}},
+	OrderOpAutoApprove:   {StatusPending: {StatusCompleted}},
+	OrderOpCancel:        {StatusPending: {StatusCanceled}, StatusDisputeCreated: {StatusCanceled}},
+	OrderOpReassign:      {StatusPending: {StatusPending}},
+	OrderOpAssign:        {StatusWaiting: {StatusPending}},
+	OrderOpWaitExpire:    {StatusWaiting: {StatusFailed}},
+	OrderOpRecover:       {StatusCanceled: {StatusCompleted}},
+	OrderOpDisputeOpen:   {StatusCanceled: {StatusDisputeCreated}, StatusCompleted: {StatusDisputeCreated}},
+	OrderOpDisputeAccept: {StatusDisputeCreated: {StatusCompleted}},
+	OrderOpDisputeReject: {StatusDisputeCreated: {StatusCanceled, StatusCompleted}},
+	OrderOpDisputeFreeze: {StatusDisputeCreated: {StatusDisputeCreated}},
+}
+
+func TestOrderStateMachineTransitionTable(t *testing.T) {
+	sm := NewOrderStateMachine()
+	for operation, rules := range allowedTransitions {
+		for _, from := range allOrderStatuses {
+			_, canApply := rules[from]
+			if got := sm.CanApply(operation, from); got != canApply {
+				t.Errorf("CanApply(%s, %q) = %v, want %v", operation, from, got, canApply)
+			}
+
+			for _, to := range allOrderStatuses {
+				allowed := containsStatus(rules[from], to)
+				err := sm.Validate(operation, from, to)
+				switch {
+				case allowed && err != nil:
+					t.Errorf("Validate(%s, %q -> %q) failed: %v", operation, from, to, err)
+				case !allowed && !errors.Is(err, ErrInvalidStatusTransition):
+					t.Errorf("Validate(%s, %q -> %q) = %v, want ErrInvalidStatusTransition", operation, from, to, err)
+				}
+			}
+		}
+	}
+}
+
+func TestOrderStateMachineUnknownOperation(t *testing.T) {
+	sm := NewOrderStateMachine()
+	if sm.CanApply("teleport", StatusPending) {
+		t.Errorf("CanApply accepted an unknown operation")
+	}
+	if err := sm.Validate("teleport", StatusPending, StatusCompleted); !errors.Is(err, ErrInvalidStatusTransition) {
+		t.Errorf("Validate(unknown) = %v, want ErrInvalidStatusTransition", err)
+	}
+}
+
+func TestOrderStateMachineValidateDisputeReject(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     OrderStatus
+		to       OrderStatus
+		original OrderStatus
+		wantErr  bool
+	}{
+		{name: "canceled order returns to canceled", from: StatusDisputeCreated, to: StatusCanceled, original: StatusCanceled},
+		{name: "completed order returns to completed", from: StatusDisputeCreated, to: StatusCompleted, original: StatusCompleted},
+		{name: "canceled order cannot become completed", from: StatusDisputeCreated, to: StatusCompleted, original: StatusCanceled, wantErr: true},
+		{name: "completed order cannot become canceled", from: StatusDisputeCreated, to: StatusCanceled, original: StatusCompleted, wantErr: true},
+		{name: "order without an open dispute", from: StatusCanceled, to: StatusCanceled, original: StatusCanceled, wantErr: true},
+		{name: "target outside the reject rule", from: StatusDisputeCreated, to: StatusPending, original: StatusPending, wantErr: true},
+	}
+
+	sm := NewOrderStateMachine()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sm.ValidateDisputeReject(tt.from, tt.to, tt.original)
+			if tt.wantErr && !errors.Is(err, ErrInvalidStatusTransition) {
+				t.Errorf("ValidateDisputeReject(%q -> %q, original %q) = %v, want ErrInvalidStatusTransition", tt.from, tt.to, tt.original, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("ValidateDisputeReject(%q -> %q, original %q) failed: %v", tt.from, tt.to, tt.original, err)
+			}
+		})
+	}
+}
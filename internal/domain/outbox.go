@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "PENDING"
+	OutboxSent    OutboxStatus = "SENT"
+)
+
+// Тип агрегата определяет, в какой топик Kafka уходит событие
+const (
+	OutboxAggregateOrder   = "order"
+	OutboxAggregateDispute = "dispute"
+	// Версионированный EventEnvelope, публикуется в отдельный топик
+	OutboxAggregateEnvelope = "envelope"
+)
+
+// OutboxEvent - событие, сохраненное в БД в одной транзакции с изменением состояния
+// и отправляемое в Kafka фоновым релеем
+type OutboxEvent struct {
+	ID            string
+	AggregateType string
+	AggregateID   string
+	Key           string // ключ сообщения Kafka
+	Payload       []byte
+	Status        OutboxStatus
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// OutboxLag - отставание релея от записи событий
+type OutboxLag struct {
+	PendingCount    int64
+	OldestPendingAt *time.Time
+}
+
+type OutboxRepository interface {
+	// EnqueueOutboxEvent сохраняет событие вне критической операции (например, приостановка реквизита)
+	EnqueueOutboxEvent(event *OutboxEvent) error
+	// ClaimPendingOutboxEvents забирает на отправку до limit событий в порядке записи: по одному,
+	// самому раннему неотправленному событию каждого агрегата, если его время попытки наступило.
+	// Забранные события откладываются на lease, чтобы их не отправил параллельно другой экземпляр
+	ClaimPendingOutboxEvents(limit int, lease time.Duration) ([]*OutboxEvent, error)
+	MarkOutboxEventSent(eventID string) error
+	ScheduleOutboxRetry(eventID string, attempts int, lastError string, nextAttemptAt time.Time) error
+	// DeleteSentOutboxEvents удаляет до limit отправленных событий, отправленных раньше sentBefore
+	DeleteSentOutboxEvents(sentBefore time.Time, limit int) (int64, error)
+	GetOutboxLag() (*OutboxLag, error)
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Платежные системы с обязательными полями реквизита
+const (
+	PaymentSystemSBP = "SBP" // перевод по номеру телефона: телефон и банк получателя
+	PaymentSystemC2C = "C2C" // перевод на карту: номер карты
+)
+
+const (
+	minCardNumberLength = 13
+	maxCardNumberLength = 19
+	// E.164: до 15 цифр без ведущего нуля
+	minPhoneDigits = 10
+	maxPhoneDigits = 15
+)
+
+// Normalize приводит номер карты и телефон к каноническому виду и проверяет обязательные
+// для платежной системы поля. Номер карты проверяется по алгоритму Луна, телефон - по E.164
+func (p *PaymentDetails) Normalize() error {
+	if p.CardNumber != "" {
+		cardNumber, err := NormalizeCardNumber(p.CardNumber)
+		if err != nil {
+			return err
+		}
+		p.CardNumber = cardNumber
+	}
+	if p.Phone != "" {
+		phone, err := NormalizePhone(p.Phone)
+		if err != nil {
+			return err
+		}
+		p.Phone = phone
+	}
+	p.BankCode = strings.TrimSpace(p.BankCode)
+	p.NspkCode = strings.TrimSpace(p.NspkCode)
+
+	switch p.PaymentSystem {
+	case PaymentSystemSBP:
+		if p.Phone == "" {
+			return fmt.Errorf("phone is required for payment system %s", p.PaymentSystem)
+		}
+		if p.BankCode == "" && p.NspkCode == "" {
+			return fmt.Errorf("bank_code or nspk_code is required for payment system %s", p.PaymentSystem)
+		}
+	case PaymentSystemC2C:
+		if p.CardNumber == "" {
+			return fmt.Errorf("card_number is required for payment system %s", p.PaymentSystem)
+		}
+	}
+	return nil
+}
+
+// NormalizeCardNumber убирает пробелы и дефисы и проверяет длину и контрольную цифру номера карты
+func NormalizeCardNumber(cardNumber string) (string, error) {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(cardNumber))
+	if !isDigits(digits) {
+		return "", fmt.Errorf("card number must contain only digits")
+	}
+	if len(digits) < minCardNumberLength || len(digits) > maxCardNumberLength {
+		return "", fmt.Errorf("card number must be %d to %d digits long", minCardNumberLength, maxCardNumberLength)
+	}
+	if !LuhnValid(digits) {
+		return "", fmt.Errorf("card number failed checksum validation")
+	}
+	return digits, nil
+}
+
+// LuhnValid - проверка контрольной цифры по алгоритму Луна, digits - только цифры
+func LuhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// NormalizePhone приводит телефон к E.164 (+79991234567). Российские номера допускаются
+// в местном формате: 8XXXXXXXXXX и XXXXXXXXXX без кода страны. Любые 10 цифр без + считаются
+// российским номером без кода страны, в том числе городские номера
+func NormalizePhone(phone string) (string, error) {
+	digits := strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(strings.TrimSpace(phone))
+	international := strings.HasPrefix(digits, "+")
+	digits = strings.TrimPrefix(digits, "+")
+	if !isDigits(digits) {
+		return "", fmt.Errorf("phone must contain only digits and an optional leading +")
+	}
+
+	if !international {
+		switch {
+		case len(digits) == 11 && digits[0] == '8':
+			digits = "7" + digits[1:]
+		case len(digits) == 10:
+			digits = "7" + digits
+		}
+	}
+	if len(digits) < minPhoneDigits || len(digits) > maxPhoneDigits || digits[0] == '0' {
+		return "", fmt.Errorf("phone %q is not a valid E.164 number", phone)
+	}
+	return "+" + digits, nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
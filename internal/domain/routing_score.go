@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// RoutingSubject - чему присвоен скор маршрутизации
+type RoutingSubject string
+
+const (
+	RoutingSubjectBankDetail RoutingSubject = "bank_detail"
+	RoutingSubjectTrader     RoutingSubject = "trader"
+)
+
+// RoutingStats - история пай-ин сделок реквизита или трейдера за окно расчета скора
+type RoutingStats struct {
+	SubjectID             string
+	TraderID              string
+	Completed             int
+	Canceled              int
+	Disputed              int     // сделки, по которым открывался спор
+	AutomaticCompleted    int     // завершенные по уведомлению автоматики
+	MedianCompleteSeconds float64 // медиана времени от создания до завершения
+}
+
+// RoutingScore - множитель веса кандидата при выборе реквизита или трейдера.
+// 1 - нейтральный, больше - кандидат конвертирует лучше среднего по платформе.
+// Override задает админ, он заменяет рассчитанный Score и не сбрасывается пересчетом
+type RoutingScore struct {
+	SubjectType        RoutingSubject
+	SubjectID          string
+	TraderID           string
+	Score              float64
+	Override           *float64
+	Orders             int // закрытые сделки за окно расчета
+	CompletionRate     float64
+	MedianCompleteTime time.Duration
+	DisputeRate        float64
+	AutomaticShare     float64
+	CalculatedAt       time.Time
+}
+
+// Effective - скор, с которым кандидат участвует в выборе
+func (s *RoutingScore) Effective() float64 {
+	if s.Override != nil {
+		return *s.Override
+	}
+	return s.Score
+}
+
+type RoutingScoreFilter struct {
+	SubjectType RoutingSubject
+	TraderID    string
+	Page        int
+	Limit       int
+}
+
+type RoutingScoreRepository interface {
+	// Статистика пай-ин сделок, созданных не раньше since, по реквизитам или трейдерам
+	GetRoutingStats(subjectType RoutingSubject, since time.Time) ([]*RoutingStats, error)
+	// Сохраняет скоры, рассчитанные в calculatedAt, не трогая переопределения админов.
+	// Скоры, которых нет в пересчете (нет сделок за окно), сбрасываются в нейтральные
+	SaveRoutingScores(scores []*RoutingScore, calculatedAt time.Time) error
+	GetRoutingScores(filter RoutingScoreFilter) ([]*RoutingScore, int64, error)
+	GetAllRoutingScores() ([]*RoutingScore, error)
+	// override = nil снимает переопределение
+	SetRoutingScoreOverride(subjectType RoutingSubject, subjectID string, traderID string, override *float64) (*RoutingScore, error)
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Schedule - недельное расписание работы реквизита или трафика с исключениями (праздники, особые дни).
+// Вне расписания реквизит или трафик не участвует в подборе. nil - расписания нет, доступен всегда
+type Schedule struct {
+	// IANA-имя часового пояса расписания, пусто - пояс сервера
+	Timezone string
+	// Интервалы работы по дням недели. Без интервалов работа круглосуточная, кроме дней-исключений
+	Weekly []ScheduleInterval
+	// Особые дни, заменяют недельные интервалы своего дня
+	Exceptions []ScheduleException
+}
+
+// TimeRange - интервал времени суток в минутах от полуночи [StartMinute, EndMinute).
+// EndMinute меньше StartMinute - интервал переходит через полночь на следующий день
+type TimeRange struct {
+	StartMinute int32
+	EndMinute   int32
+}
+
+// ScheduleInterval - интервал работы в день недели
+type ScheduleInterval struct {
+	Weekday time.Weekday
+	TimeRange
+}
+
+// ScheduleException - особый день в поясе расписания. Без интервалов - выходной
+type ScheduleException struct {
+	Date   string // YYYY-MM-DD
+	Ranges []TimeRange
+}
+
+const (
+	minutesPerDay      = 24 * 60
+	scheduleDateLayout = "2006-01-02"
+)
+
+// fullDay - круглосуточная работа для дней без недельных интервалов
+var fullDay = []TimeRange{{StartMinute: 0, EndMinute: minutesPerDay}}
+
+// IsAvailable - попадает ли now в расписание
+func (s *Schedule) IsAvailable(now time.Time) bool {
+	if s == nil {
+		return true
+	}
+
+	local := now.In(limitLocation(s.Timezone))
+	minute := int32(local.Hour()*60 + local.Minute())
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+
+	for _, r := range s.rangesOn(today) {
+		if r.EndMinute > r.StartMinute {
+			if minute >= r.StartMinute && minute < r.EndMinute {
+				return true
+			}
+		} else if minute >= r.StartMinute {
+			return true
+		}
+	}
+	// Ночные интервалы предыдущего дня
+	for _, r := range s.rangesOn(today.AddDate(0, 0, -1)) {
+		if r.EndMinute < r.StartMinute && minute < r.EndMinute {
+			return true
+		}
+	}
+	return false
+}
+
+// rangesOn - интервалы работы в день day: из исключения на эту дату или из недельного расписания
+func (s *Schedule) rangesOn(day time.Time) []TimeRange {
+	date := day.Format(scheduleDateLayout)
+	for _, exception := range s.Exceptions {
+		if exception.Date == date {
+			return exception.Ranges
+		}
+	}
+	if len(s.Weekly) == 0 {
+		return fullDay
+	}
+	var ranges []TimeRange
+	for _, interval := range s.Weekly {
+		if interval.Weekday == day.Weekday() {
+			ranges = append(ranges, interval.TimeRange)
+		}
+	}
+	return ranges
+}
+
+// Validate проверяет часовой пояс, дни недели, интервалы и даты исключений
+func (s *Schedule) Validate() error {
+	if err := ValidateTimezone(s.Timezone); err != nil {
+		return fmt.Errorf("invalid schedule timezone %q: %w", s.Timezone, err)
+	}
+	for _, interval := range s.Weekly {
+		if interval.Weekday < time.Sunday || interval.Weekday > time.Saturday {
+			return fmt.Errorf("invalid schedule weekday %d", interval.Weekday)
+		}
+		if err := interval.TimeRange.validate(); err != nil {
+			return err
+		}
+	}
+	dates := make(map[string]bool, len(s.Exceptions))
+	for _, exception := range s.Exceptions {
+		if _, err := time.Parse(scheduleDateLayout, exception.Date); err != nil {
+			return fmt.Errorf("invalid schedule exception date %q, expected YYYY-MM-DD", exception.Date)
+		}
+		if dates[exception.Date] {
+			return fmt.Errorf("duplicate schedule exception date %s", exception.Date)
+		}
+		dates[exception.Date] = true
+		for _, r := range exception.Ranges {
+			if err := r.validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r TimeRange) validate() error {
+	if r.StartMinute < 0 || r.StartMinute >= minutesPerDay {
+		return fmt.Errorf("invalid schedule start minute %d, expected 0..%d", r.StartMinute, minutesPerDay-1)
+	}
+	if r.EndMinute < 0 || r.EndMinute > minutesPerDay {
+		return fmt.Errorf("invalid schedule end minute %d, expected 0..%d", r.EndMinute, minutesPerDay)
+	}
+	if r.StartMinute == r.EndMinute {
+		return fmt.Errorf("empty schedule range at minute %d", r.StartMinute)
+	}
+	return nil
+}
+
+// BankDetailAvailability - доступность реквизита для подбора в момент проверки
+type BankDetailAvailability struct {
+	BankDetailID string
+	Enabled      bool
+	InSchedule   bool
+	Available    bool
+	Reason       string // disabled, suspended, outside_schedule, пусто - доступен
+}
+
+// TrafficAvailability - доступность трафика трейдера с мерчантом в момент проверки
+type TrafficAvailability struct {
+	TrafficID  string
+	MerchantID string
+	Enabled    bool
+	InSchedule bool
+	Available  bool
+	Reason     string // disabled, причина блокировки, outside_schedule, пусто - доступен
+}
+
+// TraderAvailability - что из реквизитов и трафика трейдера участвует в подборе прямо сейчас
+type TraderAvailability struct {
+	TraderID    string
+	CheckedAt   time.Time
+	BankDetails []*BankDetailAvailability
+	Traffic     []*TrafficAvailability
+}
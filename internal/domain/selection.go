@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// SelectionStage - этап подбора реквизита для пай-ин сделки
+type SelectionStage string
+
+const (
+	SelectionStageStatic    SelectionStage = "static"    // включен, сумма, платежная система, валюта, банк
+	SelectionStageLimits    SelectionStage = "limits"    // лимиты реквизита, задержка, сделка на ту же сумму
+	SelectionStageTraffic   SelectionStage = "traffic"   // трафик трейдера с мерчантом и его блокировки
+	SelectionStageBalance   SelectionStage = "balance"   // доступный баланс трейдера
+	SelectionStageWeighting SelectionStage = "weighting" // взвешенный выбор по приоритету трейдера
+)
+
+// BankDetailSelectionCandidate - результат подбора для одного реквизита.
+// Для отсеянного кандидата Stage - этап, на котором он отсеян, Reason - причина.
+// Прошедший все фильтры кандидат имеет Stage = weighting, пустой Reason и вероятность выбора
+type BankDetailSelectionCandidate struct {
+	BankDetailID string
+	TraderID     string
+	Stage        SelectionStage
+	Reason       string
+	Eliminated   bool
+	Weight       float64
+	Probability  float64
+}
+
+// AssignmentStats - статистика сделок реквизита или трейдера для стратегий выбора
+type AssignmentStats struct {
+	ID             string     // реквизит или трейдер
+	PendingCount   int        // активные сделки
+	LastAssignedAt *time.Time // последняя назначенная сделка
+	Completed      int        // завершенные за окно статистики
+	Canceled       int        // отмененные за окно статистики
+}
@@ -24,6 +24,37 @@ type Traffic struct {
 
 	// Бизнес-параметры
 	BusinessParams		TrafficBusinessParams
+
+	// Расписание работы, nil - трафик открыт всегда
+	Schedule 			*Schedule
+}
+
+// LockReason - причина, по которой трафик не принимает сделки, пустая строка - трафик открыт
+func (t *Traffic) LockReason() string {
+	switch {
+	case !t.ActivityParams.AntifraudUnlocked:
+		return "antifraud_locked"
+	case !t.ActivityParams.ManuallyUnlocked:
+		return "manually_locked"
+	case !t.ActivityParams.MerchantUnlocked:
+		return "merchant_locked"
+	case !t.ActivityParams.TraderUnlocked:
+		return "trader_locked"
+	default:
+		return ""
+	}
+}
+
+// UnavailableReason - причина, по которой трафик не принимает сделки в момент now: блокировка
+// или время вне расписания. Пустая строка - трафик открыт
+func (t *Traffic) UnavailableReason(now time.Time) string {
+	if reason := t.LockReason(); reason != "" {
+		return reason
+	}
+	if !t.Schedule.IsAvailable(now) {
+		return "outside_schedule"
+	}
+	return ""
 }
 
 type TrafficActivityParams struct {
@@ -45,6 +76,16 @@ type TrafficLockDetails struct {
 
 type TrafficBusinessParams struct {
 	MerchantDealsDuration time.Duration
+	// Шаг сдвига суммы сделки, чтобы на реквизите не было двух активных сделок на одну сумму (0 - выключено)
+	UniqueAmountStep 		float64
+	// Сколько шагов вверх можно сдвинуть сумму
+	UniqueAmountMaxSteps 	int32
+	// Допуск суммы при автоматическом закрытии сделок (абсолютный и в процентах от суммы сделки),
+	// оба 0 - допуск банка или общий из конфигурации
+	AmountTolerance 		float64
+	AmountTolerancePercent 	float64
+	// Стратегия выбора реквизита трейдера для сделок мерчанта, пусто - стратегия мерчанта
+	SelectionStrategy 		SelectionStrategy
 }
 
 type TrafficRepository interface {
@@ -70,4 +111,7 @@ type TrafficRepository interface {
 	}, error)
 	GetTrafficByTraderID(traderID string) ([]*Traffic, error) // НОВОЕ
 	GetTrafficByMerchantID(merchantID string) ([]*Traffic, error)
+	GetTrafficByTradersForMerchant(merchantID string, traderIDs []string) ([]*Traffic, error)
+	// Расписание работы трафика, nil удаляет расписание
+	SetTrafficSchedule(trafficID string, schedule *Schedule) error
 }
\ No newline at end of file
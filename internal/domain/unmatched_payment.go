@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type UnmatchedPaymentStatus string
+
+const (
+	// Ожидает привязки к сделке оператором или отложенным сопоставлением
+	UnmatchedPaymentPending UnmatchedPaymentStatus = "PENDING"
+	// Привязан к сделке, сделка закрыта или восстановлена
+	UnmatchedPaymentLinked UnmatchedPaymentStatus = "LINKED"
+)
+
+var (
+	ErrUnmatchedPaymentNotFound      = errors.New("unmatched payment not found")
+	ErrUnmatchedPaymentAlreadyLinked = errors.New("unmatched payment already linked")
+)
+
+// UnmatchedPayment - уведомление об оплате, для которого не нашлось сделки. Деньги пришли трейдеру,
+// поэтому платеж хранится, пока оператор не привяжет его к сделке или сделка не найдется позже
+type UnmatchedPayment struct {
+	ID            string
+	PaymentHash   string // хэш уведомления, как в PaymentProcessingLog
+	DeviceID      string
+	TraderID      string
+	Amount        float64
+	PaymentSystem string
+	Direction     string
+	Text          string
+	ReceivedAt    time.Time
+	Status        UnmatchedPaymentStatus
+	// Сделки, которым платеж подходит одинаково, пусто - подходящих сделок не нашлось
+	CandidateOrderIDs []string
+	// Заполняются при привязке
+	OrderID   string
+	LinkedBy  string // automatic, operator
+	LinkedAt  *time.Time
+	CreatedAt time.Time
+}
+
+// UnmatchedPaymentLink - привязка платежа к сделке, сохраняемая вместе с закрытием сделки
+type UnmatchedPaymentLink struct {
+	PaymentID string
+	OrderID   string
+	LinkedBy  string // automatic, operator
+	LinkedAt  time.Time
+}
+
+type UnmatchedPaymentFilter struct {
+	DeviceID string
+	TraderID string
+	Status   UnmatchedPaymentStatus // пусто - все
+	Page     int
+	Limit    int
+}
+
+type UnmatchedPaymentRepository interface {
+	// Повтор уведомления с тем же хэшем не создает вторую запись
+	CreateUnmatchedPayment(payment *UnmatchedPayment) error
+	GetUnmatchedPaymentByID(paymentID string) (*UnmatchedPayment, error)
+	ListUnmatchedPayments(filter UnmatchedPaymentFilter) ([]*UnmatchedPayment, int64, error)
+	// Непривязанные платежи, записанные не раньше since, от старых к новым
+	FindPendingUnmatchedPayments(since time.Time) ([]*UnmatchedPayment, error)
+}
@@ -0,0 +1,216 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	jsonnotifier "github.com/LavaJover/shvark-order-service/internal/infrastructure/notifier"
+	"gorm.io/gorm"
+)
+
+// CallbackQueue сохраняет колбэки мерчантам в БД и доставляет их с ретраями.
+// Колбэк, для которого исчерпаны попытки, переводится в DEAD_LETTER и может быть переотправлен вручную.
+// Формат (GET или JSON) и секрет подписи берутся из настроек мерчанта в момент отправки.
+type CallbackQueue struct {
+	repo        domain.CallbackDeliveryRepository
+	settings    domain.MerchantCallbackSettingsRepository
+	batchSize   int
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	claimLease  time.Duration
+}
+
+func NewCallbackQueue(
+	repo domain.CallbackDeliveryRepository,
+	settings domain.MerchantCallbackSettingsRepository,
+	batchSize int,
+	maxAttempts int,
+	baseDelay time.Duration,
+	maxDelay time.Duration,
+	claimLease time.Duration,
+) *CallbackQueue {
+	return &CallbackQueue{
+		repo:        repo,
+		settings:    settings,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		baseDelay:   baseDelay,
+		maxDelay:    maxDelay,
+		claimLease:  claimLease,
+	}
+}
+
+// OrderCallback формирует колбэк о статусе сделки
+func OrderCallback(order *domain.Order, status string) *domain.CallbackDelivery {
+	return &domain.CallbackDelivery{
+		OrderID:            order.ID,
+		MerchantID:         order.MerchantInfo.MerchantID,
+		MerchantOrderID:    order.MerchantInfo.MerchantOrderID,
+		ClientID:           order.MerchantInfo.ClientID,
+		CallbackUrl:        order.CallbackUrl,
+		OrderStatus:        status,
+		AmountFiat:         order.AmountInfo.AmountFiat,
+		AmountCrypto:       order.AmountInfo.AmountCrypto,
+		OriginalAmountFiat: order.AmountInfo.OriginalAmountFiat,
+		Currency:           order.AmountInfo.Currency,
+		UsdRate:            order.AmountInfo.CryptoRate,
+	}
+}
+
+// Enqueue ставит колбэк в очередь доставки
+func (q *CallbackQueue) Enqueue(delivery *domain.CallbackDelivery) {
+	if err := q.repo.CreateCallbackDelivery(delivery); err != nil {
+		log.Printf("callback error: failed to enqueue %s for %s: %v", delivery.OrderStatus, delivery.MerchantOrderID, err)
+	}
+}
+
+// DeliverDue отправляет очередную пачку колбэков, срок отправки которых наступил.
+// Пачка забирается на claimLease, поэтому один колбэк не отправляют несколько экземпляров сразу
+func (q *CallbackQueue) DeliverDue(ctx context.Context) error {
+	deliveries, err := q.repo.ClaimDueCallbackDeliveries(q.batchSize, q.claimLease)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := q.deliver(delivery); err != nil {
+			log.Printf("callback error: failed to update delivery %s: %v", delivery.ID, err)
+		}
+	}
+	return nil
+}
+
+// Resend возвращает колбэк в очередь для немедленной повторной отправки
+func (q *CallbackQueue) Resend(deliveryID string) (*domain.CallbackDelivery, error) {
+	delivery, err := q.repo.GetCallbackDeliveryByID(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Status = domain.CallbackPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	if err := q.repo.UpdateCallbackDelivery(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (q *CallbackQueue) deliver(delivery *domain.CallbackDelivery) error {
+	delivery.Attempts++
+
+	httpCode, err := q.send(delivery)
+	delivery.LastHTTPCode = httpCode
+
+	switch {
+	case err == nil:
+		now := time.Now()
+		delivery.Status = domain.CallbackDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+		log.Printf("callback success: %s %s sent to %s (attempt %d/%d)",
+			delivery.MerchantOrderID, delivery.OrderStatus, delivery.CallbackUrl, delivery.Attempts, q.maxAttempts)
+
+	case !isRetryableCallbackCode(httpCode) || delivery.Attempts >= q.maxAttempts:
+		delivery.Status = domain.CallbackDeadLetter
+		delivery.LastError = err.Error()
+		log.Printf("callback failed: %s %s moved to dead letter after %d attempts: %v",
+			delivery.MerchantOrderID, delivery.OrderStatus, delivery.Attempts, err)
+
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = time.Now().Add(q.backoff(delivery.Attempts))
+		log.Printf("callback attempt failed: %s %s (attempt %d/%d), next at %s: %v",
+			delivery.MerchantOrderID, delivery.OrderStatus, delivery.Attempts, q.maxAttempts,
+			delivery.NextAttemptAt.Format(time.RFC3339), err)
+	}
+
+	return q.repo.UpdateCallbackDelivery(delivery)
+}
+
+func (q *CallbackQueue) send(delivery *domain.CallbackDelivery) (int, error) {
+	settings := q.merchantSettings(delivery.MerchantID)
+
+	if settings.Format == domain.CallbackFormatJSON {
+		return jsonnotifier.DeliverCallback(delivery.CallbackUrl, settings.Secret, jsonnotifier.CallbackPayload{
+			OrderID:              delivery.OrderID,
+			MerchantOrderID:      delivery.MerchantOrderID,
+			Status:               delivery.OrderStatus,
+			AmountFiat:           delivery.AmountFiat,
+			AmountCrypto:         delivery.AmountCrypto,
+			OriginalAmountFiat:   delivery.OriginalAmountFiat,
+			Currency:             delivery.Currency,
+			ConfirmedAt:          delivery.CreatedAt,
+			ClientID:             delivery.ClientID,
+			UsdRate:              delivery.UsdRate,
+			ReconciliationSum:    delivery.ReconciliationSum,
+			ReconciliationAmount: delivery.ReconciliationAmount,
+			ReconciliationRate:   delivery.ReconciliationRate,
+		})
+	}
+
+	targetURL, err := BuildCallbackURL(
+		delivery.CallbackUrl,
+		delivery.MerchantOrderID,
+		delivery.OrderStatus,
+		delivery.ReconciliationSum,
+		delivery.ReconciliationAmount,
+		delivery.ReconciliationRate,
+		delivery.AmountFiat,
+		delivery.OriginalAmountFiat,
+	)
+	if err != nil {
+		// Невалидный URL не исправится ретраями
+		return http.StatusBadRequest, err
+	}
+	return DeliverCallback(targetURL, settings.Secret)
+}
+
+// merchantSettings возвращает настройки мерчанта; без настроек используется неподписанный GET
+func (q *CallbackQueue) merchantSettings(merchantID string) *domain.MerchantCallbackSettings {
+	defaultSettings := &domain.MerchantCallbackSettings{MerchantID: merchantID, Format: domain.CallbackFormatGet}
+	if merchantID == "" {
+		return defaultSettings
+	}
+	settings, err := q.settings.GetMerchantCallbackSettings(merchantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("callback warning: failed to load settings for merchant %s: %v", merchantID, err)
+		}
+		return defaultSettings
+	}
+	return settings
+}
+
+// backoff - экспоненциальная задержка: baseDelay, 2*baseDelay, 4*baseDelay ... но не больше maxDelay
+func (q *CallbackQueue) backoff(attempts int) time.Duration {
+	delay := q.baseDelay
+	for i := 1; i < attempts && delay < q.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > q.maxDelay {
+		delay = q.maxDelay
+	}
+	return delay
+}
+
+// isRetryableCallbackCode - ошибки клиента (4xx) не ретраим, кроме таймаута и rate limit
+func isRetryableCallbackCode(httpCode int) bool {
+	if httpCode == http.StatusRequestTimeout || httpCode == http.StatusTooManyRequests {
+		return true
+	}
+	return httpCode < 400 || httpCode >= 500
+}
+
+// GetDeliveries возвращает журнал доставки колбэков по сделке
+func (q *CallbackQueue) GetDeliveries(orderID, merchantOrderID string) ([]*domain.CallbackDelivery, error) {
+	return q.repo.GetCallbackDeliveriesByOrder(orderID, merchantOrderID)
+}
@@ -2,94 +2,79 @@ package notifier
 
 import (
 	"fmt"
-	"log"
-	"math"
 	"net/http"
 	"net/url"
 	"strconv"
 	"time"
 
 	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/pkg/callbacksig"
 )
 
-func SendCallback(
+var callbackClient = &http.Client{
+    Timeout: 20 * time.Second, // Увеличенный таймаут
+}
+
+// BuildCallbackURL формирует URL колбэка с параметрами статуса сделки
+func BuildCallbackURL(
     callbackUrl, 
     internalID, 
     status string,
     reconciliationSum,
     reconciliationAmount,
-    reconciliationRate float64,
-) {
-    go func() {
-        // Парсим базовый URL
-        parsedURL, err := url.Parse(callbackUrl)
-        if err != nil {
-            log.Printf("callback error: invalid URL '%s': %v", callbackUrl, err)
-            return
-        }
+    reconciliationRate,
+    amountFiat,
+    originalAmountFiat float64,
+) (string, error) {
+    // Парсим базовый URL
+    parsedURL, err := url.Parse(callbackUrl)
+    if err != nil {
+        return "", fmt.Errorf("invalid URL '%s': %w", callbackUrl, err)
+    }
 
-        // Добавляем параметры в URL
-        query := parsedURL.Query()
-        query.Set("id", internalID)
-        query.Set("status", status)
-        if status == string(domain.StatusCompleted) {
-            query.Set("usdRate", strconv.FormatFloat(reconciliationRate, 'f', 6, 64))
-        }
-        if reconciliationSum != 0 && reconciliationAmount != 0 && reconciliationRate != 0 {
-            query.Set("reconciliationSum", strconv.FormatFloat(reconciliationSum, 'f', 6, 64))
-            query.Set("reconciliationAmount", strconv.FormatFloat(reconciliationAmount, 'f', 6, 64))
-            query.Set("reconciliationRate", strconv.FormatFloat(reconciliationRate, 'f', 6, 64))
-        }
-        parsedURL.RawQuery = query.Encode()
-        targetURL := parsedURL.String()
+    // Добавляем параметры в URL
+    query := parsedURL.Query()
+    query.Set("id", internalID)
+    query.Set("status", status)
+    if status == string(domain.StatusCompleted) {
+        query.Set("usdRate", strconv.FormatFloat(reconciliationRate, 'f', 6, 64))
+    }
+    if reconciliationSum != 0 && reconciliationAmount != 0 && reconciliationRate != 0 {
+        query.Set("reconciliationSum", strconv.FormatFloat(reconciliationSum, 'f', 6, 64))
+        query.Set("reconciliationAmount", strconv.FormatFloat(reconciliationAmount, 'f', 6, 64))
+        query.Set("reconciliationRate", strconv.FormatFloat(reconciliationRate, 'f', 6, 64))
+    }
+    // Сумма сделки сдвинута для уникальности - сообщаем мерчанту обе суммы
+    if originalAmountFiat != 0 && originalAmountFiat != amountFiat {
+        query.Set("amount", strconv.FormatFloat(amountFiat, 'f', 2, 64))
+        query.Set("originalAmount", strconv.FormatFloat(originalAmountFiat, 'f', 2, 64))
+    }
+    parsedURL.RawQuery = query.Encode()
+    return parsedURL.String(), nil
+}
 
-        // Конфигурация ретраев
-        maxAttempts := 3
-        baseDelay := time.Second // Начальная задержка
-        client := &http.Client{
-            Timeout: 20 * time.Second, // Увеличенный таймаут
+// DeliverCallback выполняет одну попытку доставки колбэка.
+// Если задан secret, подписывается строка запроса (см. callbacksig.Sign).
+// Возвращает HTTP-код ответа (0, если ответа не было).
+func DeliverCallback(targetURL, secret string) (int, error) {
+    req, err := http.NewRequest("GET", targetURL, nil)
+    if err != nil {
+        return http.StatusBadRequest, err
+    }
+    if secret != "" {
+        for header, value := range callbacksig.SignatureHeaders(secret, []byte(req.URL.RawQuery), time.Now()) {
+            req.Header.Set(header, value)
         }
+    }
 
-        var lastError error
-        for attempt := 0; attempt < maxAttempts; attempt++ {
-            // Выполняем HTTP-запрос
-            resp, err := client.Get(targetURL)
-            if err == nil {
-                // Закрываем тело при успешном ответе
-                defer resp.Body.Close()
-                
-                // Обрабатываем статус ответа
-                switch {
-                case resp.StatusCode >= 200 && resp.StatusCode < 300:
-                    log.Printf("callback success: sent to %s (attempt %d/%d)", 
-                        targetURL, attempt+1, maxAttempts)
-                    return
-                    
-                case resp.StatusCode >= 400 && resp.StatusCode < 500:
-                    log.Printf("callback warning: client error %s for %s (attempt %d/%d)", 
-                        resp.Status, targetURL, attempt+1, maxAttempts)
-                    return
-                    
-                default:
-                    lastError = fmt.Errorf("server error: %s", resp.Status)
-                }
-            } else {
-                lastError = err
-            }
-
-            // Логируем неудачную попытку
-            log.Printf("callback attempt failed: %s (attempt %d/%d): %v", 
-                targetURL, attempt+1, maxAttempts, lastError)
-            
-            // Рассчитываем экспоненциальную задержку
-            if attempt < maxAttempts-1 {
-                delay := time.Duration(math.Pow(2, float64(attempt))) * baseDelay
-                time.Sleep(delay)
-            }
-        }
+    resp, err := callbackClient.Do(req)
+    if err != nil {
+        return 0, err
+    }
+    defer resp.Body.Close()
 
-        // Финальная ошибка после всех попыток
-        log.Printf("callback failed after %d attempts: %s: %v", 
-            maxAttempts, targetURL, lastError)
-    }()
-}
\ No newline at end of file
+    if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        return resp.StatusCode, fmt.Errorf("unexpected response: %s", resp.Status)
+    }
+    return resp.StatusCode, nil
+}
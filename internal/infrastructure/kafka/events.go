@@ -0,0 +1,292 @@
+package publisher
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// EnvelopeSchemaVersion - текущая версия схемы EventEnvelope
+const EnvelopeSchemaVersion = 1
+
+// EventFormat определяет, в каких форматах публикуются события
+type EventFormat string
+
+const (
+	EventFormatLegacy   EventFormat = "legacy"   // только OrderEvent/DisputeEvent со строковым статусом
+	EventFormatEnvelope EventFormat = "envelope" // только EventEnvelope
+	EventFormatBoth     EventFormat = "both"     // оба формата на время миграции потребителей
+)
+
+// EventTypeName возвращает строковое имя типа события (order.created, dispute.opened ...)
+func EventTypeName(eventType orderpb.EventType) string {
+	switch eventType {
+	case orderpb.EventType_EVENT_TYPE_ORDER_CREATED:
+		return "order.created"
+	case orderpb.EventType_EVENT_TYPE_ORDER_COMPLETED:
+		return "order.completed"
+	case orderpb.EventType_EVENT_TYPE_ORDER_CANCELED:
+		return "order.canceled"
+	case orderpb.EventType_EVENT_TYPE_DISPUTE_OPENED:
+		return "dispute.opened"
+	case orderpb.EventType_EVENT_TYPE_BANK_DETAIL_SUSPENDED:
+		return "bank_detail.suspended"
+	case orderpb.EventType_EVENT_TYPE_BANK_DETAIL_RESUMED:
+		return "bank_detail.resumed"
+	default:
+		return strings.ToLower(eventType.String())
+	}
+}
+
+// EventWriter формирует outbox-события по сделкам и диспутам в форматах,
+// включенных конфигурацией
+type EventWriter struct {
+	format EventFormat
+	// Потребители (типы агрегатов outbox: order, dispute, envelope), которым нужны полные реквизиты.
+	// Остальным номер карты, телефон и владелец уходят маскированными
+	fullRequisites map[string]bool
+}
+
+func NewEventWriter(format string, fullRequisites []string) *EventWriter {
+	w := &EventWriter{format: EventFormatLegacy, fullRequisites: make(map[string]bool, len(fullRequisites))}
+	switch EventFormat(format) {
+	case EventFormatEnvelope, EventFormatBoth:
+		w.format = EventFormat(format)
+	}
+	for _, aggregateType := range fullRequisites {
+		w.fullRequisites[strings.TrimSpace(aggregateType)] = true
+	}
+	return w
+}
+
+// maskRequisites маскирует реквизиты в событии для потребителя aggregateType, если ему не нужны полные
+func (w *EventWriter) maskRequisites(aggregateType string, cardNumber, phone, owner *string) {
+	if w.fullRequisites[aggregateType] {
+		return
+	}
+	*cardNumber = domain.MaskCardNumber(*cardNumber)
+	*phone = domain.MaskPhone(*phone)
+	*owner = domain.MaskOwner(*owner)
+}
+
+func (w *EventWriter) maskEnvelope(envelope *orderpb.EventEnvelope) {
+	if envelope.Requisite != nil {
+		w.maskRequisites(domain.OutboxAggregateEnvelope, &envelope.Requisite.CardNumber, &envelope.Requisite.Phone, &envelope.Requisite.Owner)
+	}
+}
+
+func (w *EventWriter) legacyEnabled() bool {
+	return w.format == EventFormatLegacy || w.format == EventFormatBoth
+}
+
+func (w *EventWriter) envelopeEnabled() bool {
+	return w.format == EventFormatEnvelope || w.format == EventFormatBoth
+}
+
+// OrderEvents возвращает события по сделке. legacyStatus - текст статуса для старого формата
+func (w *EventWriter) OrderEvents(eventType orderpb.EventType, order *domain.Order, legacyStatus string) ([]*domain.OutboxEvent, error) {
+	var events []*domain.OutboxEvent
+
+	if w.legacyEnabled() {
+		legacyEvent := legacyOrderEvent(order, legacyStatus)
+		w.maskRequisites(domain.OutboxAggregateOrder, &legacyEvent.CardNumber, &legacyEvent.Phone, &legacyEvent.Owner)
+		event, err := NewOrderOutboxEvent(legacyEvent)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if w.envelopeEnabled() {
+		envelope := NewOrderEnvelope(eventType, order)
+		w.maskEnvelope(envelope)
+		event, err := NewEnvelopeOutboxEvent(envelope)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// DisputeEvents возвращает события по диспуту. legacyStatus - текст статуса для старого формата
+func (w *EventWriter) DisputeEvents(eventType orderpb.EventType, order *domain.Order, dispute *domain.Dispute, legacyStatus string) ([]*domain.OutboxEvent, error) {
+	var events []*domain.OutboxEvent
+
+	if w.legacyEnabled() {
+		legacyEvent := DisputeEvent{
+			DisputeID:         dispute.ID,
+			OrderID:           dispute.OrderID,
+			TraderID:          order.RequisiteDetails.TraderID,
+			OrderAmountFiat:   order.AmountInfo.AmountFiat,
+			DisputeAmountFiat: dispute.DisputeAmountFiat,
+			ProofUrl:          dispute.ProofUrl,
+			Reason:            dispute.Reason,
+			Status:            legacyStatus,
+			BankName:          order.RequisiteDetails.BankName,
+			Phone:             order.RequisiteDetails.Phone,
+			CardNumber:        order.RequisiteDetails.CardNumber,
+			Owner:             order.RequisiteDetails.Owner,
+		}
+		w.maskRequisites(domain.OutboxAggregateDispute, &legacyEvent.CardNumber, &legacyEvent.Phone, &legacyEvent.Owner)
+		event, err := NewDisputeOutboxEvent(legacyEvent)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if w.envelopeEnabled() {
+		envelope := NewOrderEnvelope(eventType, order)
+		envelope.Dispute = &orderpb.DisputeEventData{
+			DisputeId:           dispute.ID,
+			Status:              string(dispute.Status),
+			Reason:              dispute.Reason,
+			ProofUrl:            dispute.ProofUrl,
+			DisputeAmountFiat:   dispute.DisputeAmountFiat,
+			DisputeAmountCrypto: dispute.DisputeAmountCrypto,
+			DisputeCryptoRate:   dispute.DisputeCryptoRate,
+		}
+		w.maskEnvelope(envelope)
+		event, err := NewEnvelopeOutboxEvent(envelope)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// BankDetailEvents возвращает событие по приостановке реквизита или ее снятию для уведомления трейдера.
+// Ключ сообщения - ID трейдера. Событие без сделки есть только в формате envelope (в старом формате
+// у сообщения в топике сделок был бы пустой ID сделки), поэтому оно публикуется конвертом
+// при любом формате событий по сделкам
+func (w *EventWriter) BankDetailEvents(eventType orderpb.EventType, bankDetail *domain.BankDetail, health *domain.BankDetailHealth) ([]*domain.OutboxEvent, error) {
+	data := &orderpb.BankDetailEventData{
+		BankDetailId:       bankDetail.ID,
+		Reason:             bankDetail.SuspendReason,
+		ConsecutiveCancels: health.ConsecutiveCancels,
+		WindowCancels:      health.WindowCancels,
+		WindowDisputes:     health.WindowDisputes,
+	}
+	if bankDetail.SuspendedUntil != nil {
+		data.SuspendedUntil = timestamppb.New(*bankDetail.SuspendedUntil)
+	}
+	envelope := &orderpb.EventEnvelope{
+		SchemaVersion: EnvelopeSchemaVersion,
+		EventId:       uuid.New().String(),
+		EventType:     eventType,
+		OccurredAt:    timestamppb.New(time.Now()),
+		TraderId:      bankDetail.TraderID,
+		Requisite: &orderpb.EventRequisite{
+			BankName:      bankDetail.BankName,
+			PaymentSystem: bankDetail.PaymentSystem,
+			Phone:         bankDetail.Phone,
+			CardNumber:    bankDetail.CardNumber,
+			Owner:         bankDetail.Owner,
+		},
+		BankDetail: data,
+	}
+	w.maskEnvelope(envelope)
+	event, err := NewEnvelopeOutboxEvent(envelope)
+	if err != nil {
+		return nil, err
+	}
+	event.AggregateID = bankDetail.ID
+	event.Key = bankDetail.TraderID
+	return []*domain.OutboxEvent{event}, nil
+}
+
+// NewOrderEnvelope заполняет EventEnvelope данными сделки
+func NewOrderEnvelope(eventType orderpb.EventType, order *domain.Order) *orderpb.EventEnvelope {
+	return &orderpb.EventEnvelope{
+		SchemaVersion:   EnvelopeSchemaVersion,
+		EventId:         uuid.New().String(),
+		EventType:       eventType,
+		OccurredAt:      timestamppb.New(time.Now()),
+		OrderId:         order.ID,
+		MerchantId:      order.MerchantInfo.MerchantID,
+		MerchantOrderId: order.MerchantInfo.MerchantOrderID,
+		TraderId:        order.RequisiteDetails.TraderID,
+		OrderType:       string(order.Type),
+		OrderStatus:     string(envelopeOrderStatus(eventType, order)),
+		Automatic:       order.Metrics.AutomaticCompleted,
+		Amounts: &orderpb.EventAmounts{
+			AmountFiat:   order.AmountInfo.AmountFiat,
+			AmountCrypto: order.AmountInfo.AmountCrypto,
+			CryptoRate:   order.AmountInfo.CryptoRate,
+			Currency:     order.AmountInfo.Currency,
+		},
+		Requisite: &orderpb.EventRequisite{
+			BankName:      order.RequisiteDetails.BankName,
+			PaymentSystem: order.RequisiteDetails.PaymentSystem,
+			Phone:         order.RequisiteDetails.Phone,
+			CardNumber:    order.RequisiteDetails.CardNumber,
+			Owner:         order.RequisiteDetails.Owner,
+		},
+	}
+}
+
+// NewEnvelopeOutboxEvent упаковывает EventEnvelope в событие outbox. Колонка payload - jsonb,
+// поэтому конверт хранится в protojson, в Kafka он уходит в бинарном protobuf (EnvelopeMessage).
+// Ключ сообщения - ID сделки, чтобы события одной сделки шли по порядку
+func NewEnvelopeOutboxEvent(envelope *orderpb.EventEnvelope) (*domain.OutboxEvent, error) {
+	payload, err := protojson.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.OutboxEvent{
+		AggregateType: domain.OutboxAggregateEnvelope,
+		AggregateID:   envelope.OrderId,
+		Key:           envelope.OrderId,
+		Payload:       payload,
+	}, nil
+}
+
+// EnvelopeMessage переводит конверт из outbox (protojson) в бинарный protobuf для Kafka
+func EnvelopeMessage(payload []byte) ([]byte, error) {
+	var envelope orderpb.EventEnvelope
+	if err := protojson.Unmarshal(payload, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode envelope payload: %w", err)
+	}
+	return proto.Marshal(&envelope)
+}
+
+// envelopeOrderStatus - статус сделки после события (сделка передается в состоянии до перехода)
+func envelopeOrderStatus(eventType orderpb.EventType, order *domain.Order) domain.OrderStatus {
+	switch eventType {
+	case orderpb.EventType_EVENT_TYPE_ORDER_CREATED:
+		return domain.StatusPending
+	case orderpb.EventType_EVENT_TYPE_ORDER_COMPLETED:
+		return domain.StatusCompleted
+	case orderpb.EventType_EVENT_TYPE_ORDER_CANCELED:
+		return domain.StatusCanceled
+	case orderpb.EventType_EVENT_TYPE_DISPUTE_OPENED:
+		return domain.StatusDisputeCreated
+	default:
+		return order.Status
+	}
+}
+
+func legacyOrderEvent(order *domain.Order, status string) OrderEvent {
+	return OrderEvent{
+		OrderID:    order.ID,
+		TraderID:   order.RequisiteDetails.TraderID,
+		Status:     status,
+		AmountFiat: order.AmountInfo.AmountFiat,
+		Currency:   order.AmountInfo.Currency,
+		BankName:   order.RequisiteDetails.BankName,
+		Phone:      order.RequisiteDetails.Phone,
+		CardNumber: order.RequisiteDetails.CardNumber,
+		Owner:      order.RequisiteDetails.Owner,
+	}
+}
@@ -0,0 +1,61 @@
+package publisher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
+	"google.golang.org/protobuf/proto"
+)
+
+// События по реквизиту уходят трейдеру при любом формате событий по сделкам
+func TestBankDetailEventsPublishedInEveryFormat(t *testing.T) {
+	suspendedUntil := time.Now().Add(time.Hour)
+	bankDetail := &domain.BankDetail{
+		ID:             "bank-detail-1",
+		TraderInfo:     domain.TraderInfo{TraderID: "trader-1"},
+		SuspendReason:  domain.SuspendReasonConsecutiveCancels,
+		SuspendedUntil: &suspendedUntil,
+		PaymentDetails: domain.PaymentDetails{
+			CardNumber: "2200700012345678",
+		},
+	}
+	health := &domain.BankDetailHealth{ConsecutiveCancels: 3}
+
+	for _, format := range []EventFormat{EventFormatLegacy, EventFormatEnvelope, EventFormatBoth} {
+		t.Run(string(format), func(t *testing.T) {
+			events, err := NewEventWriter(string(format), nil).BankDetailEvents(orderpb.EventType_EVENT_TYPE_BANK_DETAIL_SUSPENDED, bankDetail, health)
+			if err != nil {
+				t.Fatalf("BankDetailEvents failed: %v", err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("got %d events, want 1", len(events))
+			}
+
+			event := events[0]
+			if event.AggregateType != domain.OutboxAggregateEnvelope || event.AggregateID != bankDetail.ID || event.Key != "trader-1" {
+				t.Errorf("event aggregate %s/%s key %s, want %s/%s key trader-1",
+					event.AggregateType, event.AggregateID, event.Key, domain.OutboxAggregateEnvelope, bankDetail.ID)
+			}
+
+			message, err := EnvelopeMessage(event.Payload)
+			if err != nil {
+				t.Fatalf("EnvelopeMessage failed: %v", err)
+			}
+			var envelope orderpb.EventEnvelope
+			if err := proto.Unmarshal(message, &envelope); err != nil {
+				t.Fatalf("envelope does not unmarshal: %v", err)
+			}
+			if envelope.EventType != orderpb.EventType_EVENT_TYPE_BANK_DETAIL_SUSPENDED || envelope.TraderId != "trader-1" {
+				t.Errorf("envelope type %s trader %s", envelope.EventType, envelope.TraderId)
+			}
+			if envelope.BankDetail.GetBankDetailId() != bankDetail.ID || envelope.BankDetail.GetConsecutiveCancels() != 3 {
+				t.Errorf("bank detail data %v", envelope.BankDetail)
+			}
+			if envelope.Requisite.GetCardNumber() == bankDetail.CardNumber {
+				t.Errorf("card number is not masked: %s", envelope.Requisite.GetCardNumber())
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/segmentio/kafka-go"
+)
+
+// NewOrderOutboxEvent упаковывает OrderEvent в событие outbox
+func NewOrderOutboxEvent(event OrderEvent) (*domain.OutboxEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.OutboxEvent{
+		AggregateType: domain.OutboxAggregateOrder,
+		AggregateID:   event.OrderID,
+		Key:           event.TraderID,
+		Payload:       payload,
+	}, nil
+}
+
+// NewDisputeOutboxEvent упаковывает DisputeEvent в событие outbox
+func NewDisputeOutboxEvent(event DisputeEvent) (*domain.OutboxEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.OutboxEvent{
+		AggregateType: domain.OutboxAggregateDispute,
+		AggregateID:   event.DisputeID,
+		Key:           event.TraderID,
+		Payload:       payload,
+	}, nil
+}
+
+// PublishRaw отправляет уже сериализованное сообщение (используется релеем outbox)
+func (k *KafkaPublisher) PublishRaw(ctx context.Context, key string, payload []byte) error {
+	return k.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(key),
+		Value: payload,
+		Time:  time.Now(),
+	})
+}
@@ -0,0 +1,155 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/metrics"
+)
+
+// Сколько отправленных событий удаляется за один запрос очистки
+const purgeBatchSize = 1000
+
+// OutboxRelay читает неотправленные события из outbox и публикует их в Kafka.
+// Экземпляры сервиса могут работать с одной базой параллельно: событие забирает на отправку
+// один экземпляр, события одного агрегата отправляются по одному в порядке записи
+type OutboxRelay struct {
+	repo          domain.OutboxRepository
+	publishers    map[string]*KafkaPublisher
+	metrics       *metrics.OutboxMetrics
+	batchSize     int
+	maxBackoff    time.Duration
+	claimLease    time.Duration
+	sentRetention time.Duration
+}
+
+func NewOutboxRelay(
+	repo domain.OutboxRepository,
+	orderPublisher *KafkaPublisher,
+	disputePublisher *KafkaPublisher,
+	envelopePublisher *KafkaPublisher,
+	outboxMetrics *metrics.OutboxMetrics,
+	batchSize int,
+	maxBackoff time.Duration,
+	claimLease time.Duration,
+	sentRetention time.Duration,
+) *OutboxRelay {
+	return &OutboxRelay{
+		repo: repo,
+		publishers: map[string]*KafkaPublisher{
+			domain.OutboxAggregateOrder:    orderPublisher,
+			domain.OutboxAggregateDispute:  disputePublisher,
+			domain.OutboxAggregateEnvelope: envelopePublisher,
+		},
+		metrics:       outboxMetrics,
+		batchSize:     batchSize,
+		maxBackoff:    maxBackoff,
+		claimLease:    claimLease,
+		sentRetention: sentRetention,
+	}
+}
+
+// RelayPending публикует очередную пачку событий.
+// При ошибке публикации событие откладывается с экспоненциальной задержкой. Следующие события
+// того же агрегата не забираются, пока оно не отправлено, события других агрегатов отправляются дальше
+func (r *OutboxRelay) RelayPending(ctx context.Context) error {
+	events, err := r.repo.ClaimPendingOutboxEvents(r.batchSize, r.claimLease)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := r.publish(ctx, event); err != nil {
+			attempts := event.Attempts + 1
+			nextAttemptAt := time.Now().Add(r.backoff(attempts))
+			r.metrics.RecordPublishFailure(event.AggregateType)
+			log.Printf("❌ [OUTBOX] Failed to publish event %s (%s, attempt %d): %v", event.ID, event.AggregateType, attempts, err)
+
+			if err := r.repo.ScheduleOutboxRetry(event.ID, attempts, err.Error(), nextAttemptAt); err != nil {
+				return fmt.Errorf("failed to schedule outbox retry: %w", err)
+			}
+			continue
+		}
+
+		if err := r.repo.MarkOutboxEventSent(event.ID); err != nil {
+			return fmt.Errorf("failed to mark outbox event as sent: %w", err)
+		}
+		r.metrics.RecordPublished(event.AggregateType, time.Since(event.CreatedAt))
+	}
+
+	return r.updateLagMetrics()
+}
+
+// PurgeSent удаляет события, отправленные раньше чем sentRetention назад (0 - не удалять).
+// В отправленных событиях остаются реквизиты, поэтому они не хранятся дольше, чем нужно для разбора инцидентов
+func (r *OutboxRelay) PurgeSent(ctx context.Context) error {
+	if r.sentRetention <= 0 {
+		return nil
+	}
+	sentBefore := time.Now().Add(-r.sentRetention)
+
+	var total int64
+	for ctx.Err() == nil {
+		deleted, err := r.repo.DeleteSentOutboxEvents(sentBefore, purgeBatchSize)
+		if err != nil {
+			return err
+		}
+		total += deleted
+		if deleted < purgeBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		log.Printf("🧹 [OUTBOX] Purged %d sent events older than %s", total, r.sentRetention)
+	}
+	return ctx.Err()
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, event *domain.OutboxEvent) error {
+	kafkaPublisher, ok := r.publishers[event.AggregateType]
+	if !ok || kafkaPublisher == nil {
+		return fmt.Errorf("no publisher for aggregate type %q", event.AggregateType)
+	}
+
+	payload := event.Payload
+	if event.AggregateType == domain.OutboxAggregateEnvelope {
+		message, err := EnvelopeMessage(payload)
+		if err != nil {
+			return err
+		}
+		payload = message
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return kafkaPublisher.PublishRaw(publishCtx, event.Key, payload)
+}
+
+// backoff: 1s, 2s, 4s ... но не больше maxBackoff
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	delay := time.Duration(math.Pow(2, float64(attempts-1))) * time.Second
+	if delay <= 0 || delay > r.maxBackoff {
+		return r.maxBackoff
+	}
+	return delay
+}
+
+func (r *OutboxRelay) updateLagMetrics() error {
+	lag, err := r.repo.GetOutboxLag()
+	if err != nil {
+		return err
+	}
+
+	var oldestAge time.Duration
+	if lag.OldestPendingAt != nil {
+		oldestAge = time.Since(*lag.OldestPendingAt)
+	}
+	r.metrics.SetLag(lag.PendingCount, oldestAge)
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// CascadeMetrics - метрики in-memory каскада подбора реквизитов
+type CascadeMetrics struct {
+	// Фильтры каскада
+	CascadeFilterDuration  prometheus.HistogramVec
+	CascadeCandidatesAfter prometheus.HistogramVec
+	CascadeAbortedTotal    prometheus.CounterVec
+
+	// Кэш реквизитов
+	CascadeCacheRefreshDuration prometheus.Histogram
+	CascadeCacheRefreshFailures prometheus.Counter
+	CascadeCacheBankDetails     prometheus.Gauge
+	CascadeCacheUpdatedAt       prometheus.Gauge
+}
+
+func NewCascadeMetrics() *CascadeMetrics {
+	return &CascadeMetrics{
+		CascadeFilterDuration: *promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "cascade_filter_duration_seconds",
+				Help:    "Время работы фильтра каскада подбора реквизитов",
+				Buckets: prometheus.ExponentialBuckets(0.00001, 4, 10), // 10us ... ~2.6s
+			},
+			[]string{"filter"},
+		),
+
+		CascadeCandidatesAfter: *promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "cascade_candidates_after_filter",
+				Help:    "Количество реквизитов, прошедших фильтр каскада",
+				Buckets: prometheus.ExponentialBuckets(1, 2, 12), // 1 ... 2048
+			},
+			[]string{"filter"},
+		),
+
+		CascadeAbortedTotal: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "cascade_aborted_total",
+				Help: "Количество подборов, на которых фильтр отсеял всех кандидатов",
+			},
+			[]string{"filter"},
+		),
+
+		CascadeCacheRefreshDuration: promauto.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "cascade_cache_refresh_duration_seconds",
+				Help:    "Время полной перезагрузки кэша реквизитов",
+				Buckets: prometheus.ExponentialBuckets(0.01, 2, 12), // 10ms ... ~20s
+			},
+		),
+
+		CascadeCacheRefreshFailures: promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "cascade_cache_refresh_failures_total",
+				Help: "Количество неудачных перезагрузок кэша реквизитов",
+			},
+		),
+
+		CascadeCacheBankDetails: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "cascade_cache_bank_details",
+				Help: "Количество реквизитов в кэше каскада",
+			},
+		),
+
+		CascadeCacheUpdatedAt: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "cascade_cache_updated_at_seconds",
+				Help: "Время последней успешной перезагрузки кэша (unix)",
+			},
+		),
+	}
+}
+
+func (m *CascadeMetrics) RecordFilter(filter string, duration time.Duration, candidates int) {
+	m.CascadeFilterDuration.WithLabelValues(filter).Observe(duration.Seconds())
+	m.CascadeCandidatesAfter.WithLabelValues(filter).Observe(float64(candidates))
+	if candidates == 0 {
+		m.CascadeAbortedTotal.WithLabelValues(filter).Inc()
+	}
+}
+
+func (m *CascadeMetrics) RecordCacheRefresh(duration time.Duration, bankDetails int) {
+	m.CascadeCacheRefreshDuration.Observe(duration.Seconds())
+	m.CascadeCacheBankDetails.Set(float64(bankDetails))
+	m.CascadeCacheUpdatedAt.Set(float64(time.Now().Unix()))
+}
+
+func (m *CascadeMetrics) RecordCacheRefreshFailure() {
+	m.CascadeCacheRefreshFailures.Inc()
+}
@@ -78,6 +78,9 @@ type OrderMetrics struct {
 
 	// Ошибки
 	OrderErrorsTotal prometheus.CounterVec
+
+	// Переносы сделки на другой реквизит при неудачной заморозке
+	FreezeFallbackTotal prometheus.CounterVec
 }
 
 // NewOrderMetrics создает новый экземпляр метрик
@@ -357,6 +360,14 @@ func NewOrderMetrics() *OrderMetrics {
 			},
 			[]string{"merchant_id", "error_type"},
 		),
+
+		FreezeFallbackTotal: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "order_freeze_fallback_total",
+				Help: "Попытки перенести сделку на следующий реквизит после неудачной заморозки",
+			},
+			[]string{"merchant_id", "payment_system", "outcome"}, // outcome: reassigned, exhausted
+		),
 	}
 }
 
@@ -443,4 +454,9 @@ func (m *OrderMetrics) RecordError(merchantID, errorType string) {
 func (m *OrderMetrics) RecordOrderPendingRequisites(merchantID, paymentSystem, currency string, amountFiat float64) {
     m.OrdersPendingRequisitesTotal.WithLabelValues(merchantID, paymentSystem, currency).Inc()
     m.OrdersPendingRequisitesAmountTotal.WithLabelValues(merchantID, paymentSystem, currency).Add(amountFiat)
-}
\ No newline at end of file
+}
+
+// RecordFreezeFallback записывает перенос сделки на другой реквизит после неудачной заморозки
+func (m *OrderMetrics) RecordFreezeFallback(merchantID, paymentSystem, outcome string) {
+	m.FreezeFallbackTotal.WithLabelValues(merchantID, paymentSystem, outcome).Inc()
+}
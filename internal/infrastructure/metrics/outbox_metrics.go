@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// OutboxMetrics - метрики релея transactional outbox
+type OutboxMetrics struct {
+	// Отставание релея
+	OutboxPendingEvents    prometheus.Gauge
+	OutboxOldestPendingAge prometheus.Gauge
+
+	// Публикация
+	OutboxPublishedTotal       prometheus.CounterVec
+	OutboxPublishFailuresTotal prometheus.CounterVec
+	OutboxPublishDelay         prometheus.HistogramVec
+}
+
+func NewOutboxMetrics() *OutboxMetrics {
+	return &OutboxMetrics{
+		OutboxPendingEvents: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "outbox_pending_events",
+				Help: "Количество неотправленных событий в outbox",
+			},
+		),
+
+		OutboxOldestPendingAge: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "outbox_oldest_pending_age_seconds",
+				Help: "Возраст самого старого неотправленного события в outbox",
+			},
+		),
+
+		OutboxPublishedTotal: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "outbox_published_total",
+				Help: "Количество событий outbox, опубликованных в Kafka",
+			},
+			[]string{"aggregate_type"},
+		),
+
+		OutboxPublishFailuresTotal: *promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "outbox_publish_failures_total",
+				Help: "Количество неудачных попыток публикации событий outbox",
+			},
+			[]string{"aggregate_type"},
+		),
+
+		OutboxPublishDelay: *promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "outbox_publish_delay_seconds",
+				Help:    "Время от записи события в outbox до публикации в Kafka",
+				Buckets: prometheus.ExponentialBuckets(0.1, 2, 12), // 100ms ... ~3.5min
+			},
+			[]string{"aggregate_type"},
+		),
+	}
+}
+
+func (m *OutboxMetrics) RecordPublished(aggregateType string, delay time.Duration) {
+	m.OutboxPublishedTotal.WithLabelValues(aggregateType).Inc()
+	m.OutboxPublishDelay.WithLabelValues(aggregateType).Observe(delay.Seconds())
+}
+
+func (m *OutboxMetrics) RecordPublishFailure(aggregateType string) {
+	m.OutboxPublishFailuresTotal.WithLabelValues(aggregateType).Inc()
+}
+
+func (m *OutboxMetrics) SetLag(pendingCount int64, oldestPendingAge time.Duration) {
+	m.OutboxPendingEvents.Set(float64(pendingCount))
+	m.OutboxOldestPendingAge.Set(oldestPendingAge.Seconds())
+}
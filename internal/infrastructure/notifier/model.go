@@ -6,9 +6,14 @@ type CallbackPayload struct {
 	OrderID 		string 		`json:"order_id"`
 	MerchantOrderID string 		`json:"merchant_order_id"`
 	Status 			string 		`json:"status"`
-	AmountFiat 		float64		`json:"amount64"`
+	AmountFiat 		float64		`json:"amount_fiat"`
 	AmountCrypto 	float64		`json:"amount_crypto"`
+	OriginalAmountFiat float64	`json:"original_amount_fiat,omitempty"`
 	Currency 		string 		`json:"currency"`
 	ConfirmedAt 	time.Time	`json:"confirmed_at"`
 	ClientID		string 		`json:"client_id"`
-}
\ No newline at end of file
+	UsdRate			float64		`json:"usd_rate,omitempty"`
+	ReconciliationSum 		float64 `json:"reconciliation_sum,omitempty"`
+	ReconciliationAmount 	float64 `json:"reconciliation_amount,omitempty"`
+	ReconciliationRate 		float64 `json:"reconciliation_rate,omitempty"`
+}
@@ -3,38 +3,46 @@ package notifier
 import (
 	"bytes"
 	"encoding/json"
-	"log"
+	"fmt"
 	"net/http"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/pkg/callbacksig"
 )
 
-func SendCallback(callbackURL string, payload CallbackPayload) {
-	go func() {
-		body, err := json.Marshal(payload)
-		if err != nil {
-			log.Printf("Failed to marshal callback: %v\n", err)
-			return
-		}
+var callbackClient = &http.Client{
+	Timeout: 20 * time.Second,
+}
 
-		req, err := http.NewRequest("POST", callbackURL, bytes.NewBuffer(body))
-		if err != nil {
-			log.Printf("Failed to create callback request: %v\n", err)
-			return
-		}
+// DeliverCallback отправляет JSON-колбэк POST-запросом.
+// Если задан secret, запрос подписывается (см. callbacksig.Sign).
+// Возвращает HTTP-код ответа (0, если ответа не было).
+func DeliverCallback(callbackURL, secret string, payload CallbackPayload) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal callback: %w", err)
+	}
 
-		req.Header.Set("Content-Type", "application/json")
-		// Header с HMAC сигнатурой
+	req, err := http.NewRequest("POST", callbackURL, bytes.NewBuffer(body))
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("failed to create callback request: %w", err)
+	}
 
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			log.Printf("Callback failed: %v\n", err)
-			return
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		for header, value := range callbacksig.SignatureHeaders(secret, body, time.Now()) {
+			req.Header.Set(header, value)
 		}
-		defer resp.Body.Close()
+	}
 
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			log.Printf("Callback sent to %s\n", callbackURL)
-		}else {
-			log.Printf("Callback returned status %d", resp.StatusCode)
-		}
-	}()
-}
\ No newline at end of file
+	resp, err := callbackClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,165 @@
+package pii
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Зашифрованное значение: enc:v1:<id ключа>:<ключ данных, зашифрованный мастер-ключом>:<данные>.
+// Каждое значение шифруется своим ключом данных (AES-256-GCM), ключ данных - мастер-ключом из конфига.
+// Значения без префикса считаются открытыми: они остались с момента до включения шифрования
+const (
+	valuePrefix  = "enc:v1:"
+	keySize      = 32
+	valueParts   = 3
+	keyIDMaxSize = 32
+)
+
+var ErrUnknownKey = errors.New("unknown pii encryption key")
+
+// Cipher шифрует значения активным мастер-ключом и расшифровывает любым из известных.
+// Ротация: новый ключ добавляется в конфиг и делается активным, старые остаются
+// для чтения, пока cmd/pii-rotate не перешифрует строки
+type Cipher struct {
+	activeKeyID string
+	keys        map[string]cipher.AEAD
+}
+
+// NewCipher - keys: id ключа -> 32 байта ключа в base64
+func NewCipher(activeKeyID string, keys map[string]string) (*Cipher, error) {
+	if _, ok := keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active pii key %q is not configured", activeKeyID)
+	}
+	c := &Cipher{activeKeyID: activeKeyID, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, encoded := range keys {
+		if id == "" || len(id) > keyIDMaxSize || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid pii key id %q", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("pii key %q is not valid base64: %w", id, err)
+		}
+		if len(key) != keySize {
+			return nil, fmt.Errorf("pii key %q must be %d bytes, got %d", id, keySize, len(key))
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		c.keys[id] = aead
+	}
+	return c, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt шифрует значение активным ключом. Пустая строка не шифруется
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	data, err := seal(dataAEAD, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	wrappedKey, err := seal(c.keys[c.activeKeyID], dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	return valuePrefix + c.activeKeyID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.RawStdEncoding.EncodeToString(data), nil
+}
+
+// Decrypt расшифровывает значение. Открытое значение возвращается как есть
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, valuePrefix), ":", valueParts)
+	if len(parts) != valueParts {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	masterAEAD, ok := c.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownKey, parts[0])
+	}
+
+	wrappedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	data, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	dataKey, err := open(masterAEAD, wrappedKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dataAEAD, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation - значение открытое или зашифровано не активным ключом
+func (c *Cipher) NeedsRotation(value string) bool {
+	if value == "" {
+		return false
+	}
+	return !strings.HasPrefix(value, valuePrefix+c.activeKeyID+":")
+}
+
+// ActiveKeyID - ключ, которым шифруются новые значения
+func (c *Cipher) ActiveKeyID() string {
+	return c.activeKeyID
+}
+
+// IsEncrypted - значение зашифровано (в любой версии ключа)
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, valuePrefix)
+}
+
+// seal шифрует data, nonce идет перед шифротекстом
+func seal(aead cipher.AEAD, data []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, data, nil), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
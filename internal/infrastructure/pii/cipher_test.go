@@ -0,0 +1,186 @@
+package pii
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testKey - ключ из 32 одинаковых байт в base64, как в конфиге
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, keySize))
+}
+
+func newTestCipher(t *testing.T, activeKeyID string, keys map[string]string) *Cipher {
+	t.Helper()
+	c, err := NewCipher(activeKeyID, keys)
+	if err != nil {
+		t.Fatalf("NewCipher failed: %v", err)
+	}
+	return c
+}
+
+func TestNewCipherRejectsInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		activeKeyID string
+		keys        map[string]string
+	}{
+		{name: "active key is not configured", activeKeyID: "k2", keys: map[string]string{"k1": testKey(1)}},
+		{name: "empty key id", activeKeyID: "", keys: map[string]string{"": testKey(1)}},
+		{name: "key id with a separator", activeKeyID: "k:1", keys: map[string]string{"k:1": testKey(1)}},
+		{name: "key id too long", activeKeyID: strings.Repeat("k", keyIDMaxSize+1), keys: map[string]string{strings.Repeat("k", keyIDMaxSize+1): testKey(1)}},
+		{name: "key is not base64", activeKeyID: "k1", keys: map[string]string{"k1": "not base64!"}},
+		{name: "key is too short", activeKeyID: "k1", keys: map[string]string{"k1": base64.StdEncoding.EncodeToString(make([]byte, 16))}},
+		{name: "inactive key is invalid", activeKeyID: "k1", keys: map[string]string{"k1": testKey(1), "k0": "short"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCipher(tt.activeKeyID, tt.keys); err == nil {
+				t.Errorf("NewCipher accepted invalid keys")
+			}
+		})
+	}
+}
+
+func TestCipherRoundTrip(t *testing.T) {
+	c := newTestCipher(t, "k1", map[string]string{"k1": testKey(1)})
+
+	for _, plaintext := range []string{"2200700012345678", "+79991234567", "Иван Петров", "a:b:c", strings.Repeat("x", 4096)} {
+		ciphertext, err := c.Encrypt(plaintext)
+		if err != nil {
+			t.Fatalf("Encrypt(%q) failed: %v", plaintext, err)
+		}
+		if !strings.HasPrefix(ciphertext, "enc:v1:k1:") || strings.Contains(ciphertext, plaintext) {
+			t.Errorf("Encrypt(%q) = %q, want an enc:v1:k1 value without the plaintext", plaintext, ciphertext)
+		}
+		decrypted, err := c.Decrypt(ciphertext)
+		if err != nil {
+			t.Fatalf("Decrypt(%q) failed: %v", ciphertext, err)
+		}
+		if decrypted != plaintext {
+			t.Errorf("Decrypt = %q, want %q", decrypted, plaintext)
+		}
+	}
+}
+
+func TestCipherEncryptsEachValueWithItsOwnKey(t *testing.T) {
+	c := newTestCipher(t, "k1", map[string]string{"k1": testKey(1)})
+	first, _ := c.Encrypt("2200700012345678")
+	second, _ := c.Encrypt("2200700012345678")
+	if first == second {
+		t.Errorf("the same value encrypted twice gives the same ciphertext")
+	}
+}
+
+func TestCipherPlainValues(t *testing.T) {
+	c := newTestCipher(t, "k1", map[string]string{"k1": testKey(1)})
+
+	if ciphertext, err := c.Encrypt(""); err != nil || ciphertext != "" {
+		t.Errorf("Encrypt(\"\") = %q, %v, want an empty value", ciphertext, err)
+	}
+	// Значения, записанные до включения шифрования, читаются как есть
+	if plaintext, err := c.Decrypt("2200700012345678"); err != nil || plaintext != "2200700012345678" {
+		t.Errorf("Decrypt(plain) = %q, %v", plaintext, err)
+	}
+}
+
+func TestCipherDecryptsWithOldKeyAfterRotation(t *testing.T) {
+	before := newTestCipher(t, "k1", map[string]string{"k1": testKey(1)})
+	after := newTestCipher(t, "k2", map[string]string{"k1": testKey(1), "k2": testKey(2)})
+
+	old, err := before.Encrypt("2200700012345678")
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	plaintext, err := after.Decrypt(old)
+	if err != nil {
+		t.Fatalf("Decrypt with the old key failed: %v", err)
+	}
+	if plaintext != "2200700012345678" {
+		t.Errorf("Decrypt = %q", plaintext)
+	}
+
+	rotated, err := after.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if !strings.HasPrefix(rotated, "enc:v1:k2:") {
+		t.Errorf("new values must use the active key: %q", rotated)
+	}
+
+	tests := []struct {
+		name   string
+		cipher *Cipher
+		value  string
+		want   bool
+	}{
+		{name: "old key value", cipher: after, value: old, want: true},
+		{name: "active key value", cipher: after, value: rotated},
+		{name: "plain value", cipher: after, value: "2200700012345678", want: true},
+		{name: "empty value", cipher: after, value: ""},
+		{name: "value of the active key before rotation", cipher: before, value: old},
+	}
+	for _, tt := range tests {
+		if got := tt.cipher.NeedsRotation(tt.value); got != tt.want {
+			t.Errorf("%s: NeedsRotation = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCipherRejectsUnknownKey(t *testing.T) {
+	before := newTestCipher(t, "k1", map[string]string{"k1": testKey(1)})
+	withoutOldKey := newTestCipher(t, "k2", map[string]string{"k2": testKey(2)})
+
+	old, _ := before.Encrypt("2200700012345678")
+	if _, err := withoutOldKey.Decrypt(old); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Decrypt = %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestCipherRejectsTamperedValues(t *testing.T) {
+	c := newTestCipher(t, "k1", map[string]string{"k1": testKey(1)})
+	value, err := c.Encrypt("2200700012345678")
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	parts := strings.Split(strings.TrimPrefix(value, valuePrefix), ":")
+	keyID, wrappedKey, data := parts[0], parts[1], parts[2]
+
+	// flip меняет первый символ base64, чтобы изменились байты, а не только неиспользуемые биты
+	flip := func(s string) string {
+		if s[0] == 'A' {
+			return "B" + s[1:]
+		}
+		return "A" + s[1:]
+	}
+	join := func(parts ...string) string {
+		return valuePrefix + strings.Join(parts, ":")
+	}
+	sameIDOtherKey := newTestCipher(t, "k1", map[string]string{"k1": testKey(9)})
+
+	tests := []struct {
+		name   string
+		cipher *Cipher
+		value  string
+	}{
+		{name: "tampered data", cipher: c, value: join(keyID, wrappedKey, flip(data))},
+		{name: "tampered data key", cipher: c, value: join(keyID, flip(wrappedKey), data)},
+		{name: "truncated data", cipher: c, value: join(keyID, wrappedKey, data[:8])},
+		{name: "swapped parts", cipher: c, value: join(keyID, data, wrappedKey)},
+		{name: "missing part", cipher: c, value: join(keyID, wrappedKey)},
+		{name: "not base64", cipher: c, value: join(keyID, wrappedKey, "!!!")},
+		{name: "other key material under the same id", cipher: sameIDOtherKey, value: value},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if plaintext, err := tt.cipher.Decrypt(tt.value); err == nil {
+				t.Errorf("Decrypt accepted a tampered value: %q", plaintext)
+			}
+		})
+	}
+}
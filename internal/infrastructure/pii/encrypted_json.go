@@ -0,0 +1,61 @@
+package pii
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// EncryptedJSON - jsonb-колонка с персональными данными внутри документа (полезная нагрузка outbox).
+// При включенном шифровании документ целиком шифруется и хранится как JSON-строка с шифротекстом,
+// при чтении расшифровывается. Открытые документы, записанные до включения шифрования, читаются как есть
+type EncryptedJSON []byte
+
+func (j EncryptedJSON) Value() (driver.Value, error) {
+	c := Default()
+	if c == nil || len(j) == 0 {
+		return []byte(j), nil
+	}
+	ciphertext, err := c.Encrypt(string(j))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ciphertext)
+}
+
+func (j *EncryptedJSON) Scan(src interface{}) error {
+	var value []byte
+	switch v := src.(type) {
+	case nil:
+		*j = nil
+		return nil
+	case string:
+		value = []byte(v)
+	case []byte:
+		value = append([]byte(nil), v...)
+	default:
+		return fmt.Errorf("unsupported type %T for encrypted json", src)
+	}
+
+	// Открытый документ - объект, зашифрованный - строка
+	var ciphertext string
+	if len(value) == 0 || value[0] != '"' || json.Unmarshal(value, &ciphertext) != nil || !IsEncrypted(ciphertext) {
+		*j = value
+		return nil
+	}
+	c := Default()
+	if c == nil {
+		return errNoCipher
+	}
+	plaintext, err := c.Decrypt(ciphertext)
+	if err != nil {
+		return err
+	}
+	*j = EncryptedJSON(plaintext)
+	return nil
+}
+
+// GormDataType - колонка остается jsonb
+func (EncryptedJSON) GormDataType() string {
+	return "jsonb"
+}
@@ -0,0 +1,112 @@
+package pii
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const testDocument = `{"card_number":"2200700012345678","owner":"Иван Петров"}`
+
+func TestEncryptedJSONWithoutCipher(t *testing.T) {
+	withDefaultCipher(t, nil)
+
+	value, err := EncryptedJSON(testDocument).Value()
+	if err != nil || string(value.([]byte)) != testDocument {
+		t.Errorf("Value = %s, %v, want the plain document", value, err)
+	}
+
+	var j EncryptedJSON
+	if err := j.Scan([]byte(testDocument)); err != nil || string(j) != testDocument {
+		t.Errorf("Scan(plain) = %s, %v", j, err)
+	}
+
+	ciphertext, _ := newTestCipher(t, "k1", map[string]string{"k1": testKey(1)}).Encrypt(testDocument)
+	stored, _ := json.Marshal(ciphertext)
+	if err := j.Scan(stored); !errors.Is(err, errNoCipher) {
+		t.Errorf("Scan(encrypted) = %v, want errNoCipher", err)
+	}
+}
+
+func TestEncryptedJSONRoundTrip(t *testing.T) {
+	withDefaultCipher(t, newTestCipher(t, "k1", map[string]string{"k1": testKey(1)}))
+
+	value, err := EncryptedJSON(testDocument).Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	// В jsonb хранится JSON-строка с шифротекстом
+	stored := value.([]byte)
+	var ciphertext string
+	if err := json.Unmarshal(stored, &ciphertext); err != nil || !IsEncrypted(ciphertext) {
+		t.Fatalf("Value = %s, want a JSON string with an encrypted value", stored)
+	}
+
+	for _, src := range []interface{}{stored, string(stored)} {
+		var j EncryptedJSON
+		if err := j.Scan(src); err != nil {
+			t.Fatalf("Scan(%T) failed: %v", src, err)
+		}
+		if string(j) != testDocument {
+			t.Errorf("Scan(%T) = %s", src, j)
+		}
+	}
+}
+
+func TestEncryptedJSONScan(t *testing.T) {
+	withDefaultCipher(t, newTestCipher(t, "k1", map[string]string{"k1": testKey(1)}))
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "NULL", src: nil, want: ""},
+		{name: "plain document written before encryption", src: []byte(testDocument), want: testDocument},
+		{name: "plain JSON string", src: `"hello"`, want: `"hello"`},
+		{name: "empty value", src: []byte{}, want: ""},
+		{name: "unknown key", src: `"enc:v1:k0:AAAA:AAAA"`, wantErr: true},
+		{name: "unsupported type", src: 42, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := EncryptedJSON("previous")
+			err := j.Scan(tt.src)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Scan accepted %v", tt.src)
+				}
+				return
+			}
+			if err != nil || string(j) != tt.want {
+				t.Errorf("Scan = %s, %v, want %s", j, err, tt.want)
+			}
+		})
+	}
+}
+
+// Драйвер переиспользует буфер, поэтому Scan копирует байты
+func TestEncryptedJSONScanCopiesBytes(t *testing.T) {
+	withDefaultCipher(t, nil)
+
+	src := []byte(testDocument)
+	var j EncryptedJSON
+	if err := j.Scan(src); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	src[0] = 'x'
+	if string(j) != testDocument {
+		t.Errorf("scanned document changed with the source buffer: %s", j)
+	}
+}
+
+func TestEncryptedJSONEmptyValueIsNotEncrypted(t *testing.T) {
+	withDefaultCipher(t, newTestCipher(t, "k1", map[string]string{"k1": testKey(1)}))
+
+	value, err := EncryptedJSON(nil).Value()
+	if err != nil || len(value.([]byte)) != 0 {
+		t.Errorf("Value = %v, %v, want an empty document", value, err)
+	}
+}
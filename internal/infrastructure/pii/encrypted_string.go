@@ -0,0 +1,71 @@
+package pii
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+var defaultCipher atomic.Pointer[Cipher]
+
+// SetDefault задает шифр для колонок EncryptedString. Вызывается один раз при старте,
+// до работы с БД. Без шифра значения пишутся открытыми
+func SetDefault(c *Cipher) {
+	defaultCipher.Store(c)
+}
+
+// Default - шифр колонок EncryptedString, nil - шифрование выключено
+func Default() *Cipher {
+	return defaultCipher.Load()
+}
+
+var errNoCipher = errors.New("encrypted pii value found but pii encryption is not configured")
+
+// EncryptedString - строковая колонка с персональными данными, шифруется при записи в БД
+// и расшифровывается при чтении. Для gorm это обычная строка: подходит и для моделей,
+// и для map в Updates
+type EncryptedString string
+
+func (s EncryptedString) Value() (driver.Value, error) {
+	c := Default()
+	if c == nil {
+		return string(s), nil
+	}
+	return c.Encrypt(string(s))
+}
+
+func (s *EncryptedString) Scan(src interface{}) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		*s = ""
+		return nil
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("unsupported type %T for encrypted string", src)
+	}
+
+	if !IsEncrypted(value) {
+		*s = EncryptedString(value)
+		return nil
+	}
+	c := Default()
+	if c == nil {
+		return errNoCipher
+	}
+	plaintext, err := c.Decrypt(value)
+	if err != nil {
+		return err
+	}
+	*s = EncryptedString(plaintext)
+	return nil
+}
+
+// GormDataType - колонка остается текстовой
+func (EncryptedString) GormDataType() string {
+	return "text"
+}
@@ -0,0 +1,98 @@
+package pii
+
+import (
+	"errors"
+	"testing"
+)
+
+// withDefaultCipher задает шифр колонок на время теста
+func withDefaultCipher(t *testing.T, c *Cipher) {
+	t.Helper()
+	previous := Default()
+	SetDefault(c)
+	t.Cleanup(func() { SetDefault(previous) })
+}
+
+func TestEncryptedStringWithoutCipher(t *testing.T) {
+	withDefaultCipher(t, nil)
+
+	value, err := EncryptedString("2200700012345678").Value()
+	if err != nil || value != "2200700012345678" {
+		t.Errorf("Value = %v, %v, want the plain value", value, err)
+	}
+
+	var s EncryptedString
+	if err := s.Scan("2200700012345678"); err != nil || s != "2200700012345678" {
+		t.Errorf("Scan(plain) = %q, %v", s, err)
+	}
+
+	encrypted, _ := newTestCipher(t, "k1", map[string]string{"k1": testKey(1)}).Encrypt("2200700012345678")
+	if err := s.Scan(encrypted); !errors.Is(err, errNoCipher) {
+		t.Errorf("Scan(encrypted) = %v, want errNoCipher", err)
+	}
+}
+
+func TestEncryptedStringRoundTrip(t *testing.T) {
+	withDefaultCipher(t, newTestCipher(t, "k1", map[string]string{"k1": testKey(1)}))
+
+	value, err := EncryptedString("2200700012345678").Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	stored, ok := value.(string)
+	if !ok || !IsEncrypted(stored) {
+		t.Fatalf("Value = %v, want an encrypted string", value)
+	}
+
+	// Драйвер может вернуть колонку и строкой, и байтами
+	for _, src := range []interface{}{stored, []byte(stored)} {
+		var s EncryptedString
+		if err := s.Scan(src); err != nil {
+			t.Fatalf("Scan(%T) failed: %v", src, err)
+		}
+		if s != "2200700012345678" {
+			t.Errorf("Scan(%T) = %q", src, s)
+		}
+	}
+}
+
+func TestEncryptedStringScan(t *testing.T) {
+	withDefaultCipher(t, newTestCipher(t, "k1", map[string]string{"k1": testKey(1)}))
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    EncryptedString
+		wantErr bool
+	}{
+		{name: "NULL", src: nil, want: ""},
+		{name: "plain value written before encryption", src: "+79991234567", want: "+79991234567"},
+		{name: "empty value", src: "", want: ""},
+		{name: "unknown key", src: "enc:v1:k0:AAAA:AAAA", wantErr: true},
+		{name: "unsupported type", src: 42, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := EncryptedString("previous")
+			err := s.Scan(tt.src)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Scan accepted %v", tt.src)
+				}
+				return
+			}
+			if err != nil || s != tt.want {
+				t.Errorf("Scan = %q, %v, want %q", s, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncryptedStringEmptyValueIsNotEncrypted(t *testing.T) {
+	withDefaultCipher(t, newTestCipher(t, "k1", map[string]string{"k1": testKey(1)}))
+
+	if value, err := EncryptedString("").Value(); err != nil || value != "" {
+		t.Errorf("Value = %v, %v, want an empty string", value, err)
+	}
+}
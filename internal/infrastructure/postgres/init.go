@@ -5,6 +5,7 @@ import (
 	"time"  // Добавьте этот импорт
 
 	"github.com/LavaJover/shvark-order-service/internal/config"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/pii"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/repository/antifraud/engine"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/repository/antifraud/rules"
@@ -17,6 +18,16 @@ import (
 
 func MustInitDB(cfg *config.OrderConfig) *gorm.DB {
 	dsn := cfg.OrderDB.Dsn
+
+	// Шифр персональных данных реквизитов нужен до первого чтения моделей
+	if cfg.PIIConfig.ActiveKeyID != "" {
+		piiCipher, err := pii.NewCipher(cfg.PIIConfig.ActiveKeyID, cfg.PIIConfig.Keys)
+		if err != nil {
+			log.Fatalf("failed to init pii cipher: %v", err)
+		}
+		pii.SetDefault(piiCipher)
+		log.Printf("✅ PII encryption enabled, active key %s", piiCipher.ActiveKeyID())
+	}
 	
 	// Создаем соединение с GORM
 	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{})
@@ -57,6 +68,19 @@ func MustInitDB(cfg *config.OrderConfig) *gorm.DB {
 		&engine.AntiFraudAuditLog{},
 		&engine.UnlockAuditLog{},
 		&models.AutomaticLogModel{},
+		&models.OrderStatusTransitionModel{},
+		&models.OutboxEventModel{},
+		&models.CallbackDeliveryModel{},
+		&models.MerchantCallbackSettingsModel{},
+		&models.MerchantMatchingSettingsModel{},
+		&models.RoutingScoreModel{},
+		&models.BankDetailHealthModel{},
+		&models.BinModel{},
+		&models.NotificationTemplateModel{},
+		&models.UnmatchedPaymentModel{},
+		&models.DeviceCredentialModel{},
+		&models.DeviceAuthFailureModel{},
+		&models.DeviceRequestNonceModel{},
 	)
 	if err != nil {
 		log.Printf("⚠️ AutoMigrate warnings: %v", err)
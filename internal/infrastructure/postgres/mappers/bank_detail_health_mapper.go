@@ -0,0 +1,20 @@
+package mappers
+
+import (
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+)
+
+func ToDomainBankDetailHealth(model *models.BankDetailHealthModel) *domain.BankDetailHealth {
+	return &domain.BankDetailHealth{
+		BankDetailID:       model.BankDetailID,
+		TraderID:           model.TraderID,
+		ConsecutiveCancels: model.ConsecutiveCancels,
+		LastCancelAt:       model.LastCancelAt,
+		LastDisputeAt:      model.LastDisputeAt,
+		Suspensions:        model.Suspensions,
+		LastSuspendedAt:    model.LastSuspendedAt,
+		ResumedAt:          model.ResumedAt,
+		UpdatedAt:          model.UpdatedAt,
+	}
+}
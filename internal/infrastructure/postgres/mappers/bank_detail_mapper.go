@@ -2,6 +2,7 @@ package mappers
 
 import (
 	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/pii"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
 )
 
@@ -14,10 +15,16 @@ func ToDomainBankDetail(model *models.BankDetailModel) *domain.BankDetail {
 			MaxAmountMonth: model.MaxAmountMonth,
 			MaxQuantityDay: model.MaxQuantityDay,
 			MaxQuantityMonth: model.MaxQuantityMonth,
+			MaxAmountHour: model.MaxAmountHour,
+			MaxQuantityHour: model.MaxQuantityHour,
+			MaxAmountWeek: model.MaxAmountWeek,
+			MaxQuantityWeek: model.MaxQuantityWeek,
 			MinOrderAmount: model.MinAmount,
 			MaxOrderAmount: model.MaxAmount,
 			Delay: model.Delay,
 			Enabled: model.Enabled,
+			LimitMode: domain.LimitMode(model.LimitMode),
+			Timezone: model.Timezone,
 		},
 		DeviceInfo: domain.DeviceInfo{
 			DeviceID: model.DeviceID,
@@ -26,9 +33,9 @@ func ToDomainBankDetail(model *models.BankDetailModel) *domain.BankDetail {
 			TraderID: model.TraderID,
 		},
 		PaymentDetails: domain.PaymentDetails{
-			Phone: model.Phone,
-			CardNumber: model.CardNumber,
-			Owner: model.Owner,
+			Phone: string(model.Phone),
+			CardNumber: string(model.CardNumber),
+			Owner: string(model.Owner),
 			PaymentSystem: model.PaymentSystem,
 			BankInfo: domain.BankInfo{
 				BankCode: model.BankCode,
@@ -39,6 +46,10 @@ func ToDomainBankDetail(model *models.BankDetailModel) *domain.BankDetail {
 		Country: model.Country,
 		Currency: model.Currency,
 		InflowCurrency: model.InflowCurrency,
+		Schedule: ToDomainSchedule(model.Schedule),
+		SuspendedUntil: model.SuspendedUntil,
+		SuspendReason: model.SuspendReason,
+		BinMismatch: model.BinMismatch,
 		CreatedAt: model.CreatedAt,
 		UpdatedAt: model.UpdatedAt,
 	}
@@ -59,15 +70,25 @@ func ToGORMBankDetail(bankDetail *domain.BankDetail) *models.BankDetailModel {
 		PaymentSystem: bankDetail.PaymentSystem,
 		Delay: bankDetail.Delay,
 		Enabled: bankDetail.Enabled,
-		CardNumber: bankDetail.CardNumber,
-		Phone: bankDetail.Phone,
-		Owner: bankDetail.Owner,
+		CardNumber: pii.EncryptedString(bankDetail.CardNumber),
+		Phone: pii.EncryptedString(bankDetail.Phone),
+		Owner: pii.EncryptedString(bankDetail.Owner),
 		MaxOrdersSimultaneosly: bankDetail.MaxOrdersSimultaneosly,
 		MaxAmountDay: bankDetail.MaxAmountDay,
 		MaxAmountMonth: bankDetail.MaxAmountMonth,
 		MaxQuantityDay: bankDetail.MaxQuantityDay,
 		MaxQuantityMonth: bankDetail.MaxQuantityMonth,
+		MaxAmountHour: bankDetail.MaxAmountHour,
+		MaxQuantityHour: bankDetail.MaxQuantityHour,
+		MaxAmountWeek: bankDetail.MaxAmountWeek,
+		MaxQuantityWeek: bankDetail.MaxQuantityWeek,
+		LimitMode: string(bankDetail.LimitMode),
+		Timezone: bankDetail.Timezone,
 		DeviceID: bankDetail.DeviceID,
+		Schedule: ToGORMSchedule(bankDetail.Schedule),
+		SuspendedUntil: bankDetail.SuspendedUntil,
+		SuspendReason: bankDetail.SuspendReason,
+		BinMismatch: bankDetail.BinMismatch,
 		CreatedAt: bankDetail.CreatedAt,
 		UpdatedAt: bankDetail.UpdatedAt,
 	}
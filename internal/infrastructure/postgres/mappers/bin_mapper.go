@@ -0,0 +1,30 @@
+package mappers
+
+import (
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+)
+
+func ToDomainBin(model *models.BinModel) *domain.BinRecord {
+	return &domain.BinRecord{
+		Prefix:         model.Prefix,
+		BankName:       model.BankName,
+		BankCode:       model.BankCode,
+		NspkCode:       model.NspkCode,
+		PaymentNetwork: model.PaymentNetwork,
+		Country:        model.Country,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+func ToGORMBin(record *domain.BinRecord) *models.BinModel {
+	return &models.BinModel{
+		Prefix:         record.Prefix,
+		BankName:       record.BankName,
+		BankCode:       record.BankCode,
+		NspkCode:       record.NspkCode,
+		PaymentNetwork: record.PaymentNetwork,
+		Country:        record.Country,
+		UpdatedAt:      record.UpdatedAt,
+	}
+}
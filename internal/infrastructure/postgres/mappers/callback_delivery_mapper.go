@@ -0,0 +1,82 @@
+package mappers
+
+import (
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+)
+
+func ToDomainCallbackDelivery(model *models.CallbackDeliveryModel) *domain.CallbackDelivery {
+	return &domain.CallbackDelivery{
+		ID:                   model.ID,
+		OrderID:              model.OrderID,
+		MerchantID:           model.MerchantID,
+		MerchantOrderID:      model.MerchantOrderID,
+		ClientID:             model.ClientID,
+		CallbackUrl:          model.CallbackUrl,
+		OrderStatus:          model.OrderStatus,
+		AmountFiat:           model.AmountFiat,
+		AmountCrypto:         model.AmountCrypto,
+		OriginalAmountFiat:   model.OriginalAmountFiat,
+		Currency:             model.Currency,
+		UsdRate:              model.UsdRate,
+		ReconciliationSum:    model.ReconciliationSum,
+		ReconciliationAmount: model.ReconciliationAmount,
+		ReconciliationRate:   model.ReconciliationRate,
+		Status:               model.Status,
+		Attempts:             model.Attempts,
+		LastHTTPCode:         model.LastHTTPCode,
+		LastError:            model.LastError,
+		NextAttemptAt:        model.NextAttemptAt,
+		DeliveredAt:          model.DeliveredAt,
+		CreatedAt:            model.CreatedAt,
+		UpdatedAt:            model.UpdatedAt,
+	}
+}
+
+func ToGORMCallbackDelivery(delivery *domain.CallbackDelivery) *models.CallbackDeliveryModel {
+	return &models.CallbackDeliveryModel{
+		ID:                   delivery.ID,
+		OrderID:              delivery.OrderID,
+		MerchantID:           delivery.MerchantID,
+		MerchantOrderID:      delivery.MerchantOrderID,
+		ClientID:             delivery.ClientID,
+		CallbackUrl:          delivery.CallbackUrl,
+		OrderStatus:          delivery.OrderStatus,
+		AmountFiat:           delivery.AmountFiat,
+		AmountCrypto:         delivery.AmountCrypto,
+		OriginalAmountFiat:   delivery.OriginalAmountFiat,
+		Currency:             delivery.Currency,
+		UsdRate:              delivery.UsdRate,
+		ReconciliationSum:    delivery.ReconciliationSum,
+		ReconciliationAmount: delivery.ReconciliationAmount,
+		ReconciliationRate:   delivery.ReconciliationRate,
+		Status:               delivery.Status,
+		Attempts:             delivery.Attempts,
+		LastHTTPCode:         delivery.LastHTTPCode,
+		LastError:            delivery.LastError,
+		NextAttemptAt:        delivery.NextAttemptAt,
+		DeliveredAt:          delivery.DeliveredAt,
+		CreatedAt:            delivery.CreatedAt,
+		UpdatedAt:            delivery.UpdatedAt,
+	}
+}
+
+func ToDomainMerchantCallbackSettings(model *models.MerchantCallbackSettingsModel) *domain.MerchantCallbackSettings {
+	return &domain.MerchantCallbackSettings{
+		MerchantID: model.MerchantID,
+		Format:     model.Format,
+		Secret:     model.Secret,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+}
+
+func ToGORMMerchantCallbackSettings(settings *domain.MerchantCallbackSettings) *models.MerchantCallbackSettingsModel {
+	return &models.MerchantCallbackSettingsModel{
+		MerchantID: settings.MerchantID,
+		Format:     settings.Format,
+		Secret:     settings.Secret,
+		CreatedAt:  settings.CreatedAt,
+		UpdatedAt:  settings.UpdatedAt,
+	}
+}
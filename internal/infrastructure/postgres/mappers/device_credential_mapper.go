@@ -0,0 +1,43 @@
+package mappers
+
+import (
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/pii"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+)
+
+func ToDomainDeviceCredential(model *models.DeviceCredentialModel) *domain.DeviceCredential {
+	return &domain.DeviceCredential{
+		ID:        model.ID,
+		DeviceID:  model.DeviceID,
+		Secret:    string(model.Secret),
+		CreatedAt: model.CreatedAt,
+		ExpiresAt: model.ExpiresAt,
+		RevokedAt: model.RevokedAt,
+	}
+}
+
+func ToGORMDeviceCredential(credential *domain.DeviceCredential) *models.DeviceCredentialModel {
+	return &models.DeviceCredentialModel{
+		ID:        credential.ID,
+		DeviceID:  credential.DeviceID,
+		Secret:    pii.EncryptedString(credential.Secret),
+		CreatedAt: credential.CreatedAt,
+		ExpiresAt: credential.ExpiresAt,
+		RevokedAt: credential.RevokedAt,
+	}
+}
+
+func ToGORMDeviceAuthFailure(failure *domain.DeviceAuthFailure) *models.DeviceAuthFailureModel {
+	return &models.DeviceAuthFailureModel{
+		ID:              failure.ID,
+		DeviceID:        failure.DeviceID,
+		ClaimedDeviceID: failure.ClaimedDeviceID,
+		ClaimedTraderID: failure.ClaimedTraderID,
+		Method:          failure.Method,
+		PeerAddr:        failure.PeerAddr,
+		Reason:          failure.Reason,
+		Enforced:        failure.Enforced,
+		CreatedAt:       failure.CreatedAt,
+	}
+}
@@ -0,0 +1,30 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+)
+
+func ToDomainMerchantMatchingSettings(model *models.MerchantMatchingSettingsModel) *domain.MerchantMatchingSettings {
+	return &domain.MerchantMatchingSettings{
+		MerchantID:        model.MerchantID,
+		WaitForRequisites: model.WaitForRequisites,
+		WaitWindow:        time.Duration(model.WaitWindowSeconds) * time.Second,
+		SelectionStrategy: domain.SelectionStrategy(model.SelectionStrategy),
+		CreatedAt:         model.CreatedAt,
+		UpdatedAt:         model.UpdatedAt,
+	}
+}
+
+func ToGORMMerchantMatchingSettings(settings *domain.MerchantMatchingSettings) *models.MerchantMatchingSettingsModel {
+	return &models.MerchantMatchingSettingsModel{
+		MerchantID:        settings.MerchantID,
+		WaitForRequisites: settings.WaitForRequisites,
+		WaitWindowSeconds: int64(settings.WaitWindow / time.Second),
+		SelectionStrategy: string(settings.SelectionStrategy),
+		CreatedAt:         settings.CreatedAt,
+		UpdatedAt:         settings.UpdatedAt,
+	}
+}
@@ -0,0 +1,92 @@
+package mappers
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+)
+
+func ToDomainNotificationTemplate(model *models.NotificationTemplateModel) *domain.NotificationTemplate {
+	return &domain.NotificationTemplate{
+		ID:              model.ID,
+		BankCode:        model.BankCode,
+		Name:            model.Name,
+		Pattern:         model.Pattern,
+		Direction:       model.Direction,
+		DefaultCurrency: model.DefaultCurrency,
+		Priority:        model.Priority,
+		Enabled:         model.Enabled,
+		Samples:         toDomainNotificationSamples(model.Samples),
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
+	}
+}
+
+func ToGORMNotificationTemplate(template *domain.NotificationTemplate) *models.NotificationTemplateModel {
+	return &models.NotificationTemplateModel{
+		ID:              template.ID,
+		BankCode:        template.BankCode,
+		Name:            template.Name,
+		Pattern:         template.Pattern,
+		Direction:       template.Direction,
+		DefaultCurrency: template.DefaultCurrency,
+		Priority:        template.Priority,
+		Enabled:         template.Enabled,
+		Samples:         toGORMNotificationSamples(template.Samples),
+		CreatedAt:       template.CreatedAt,
+		UpdatedAt:       template.UpdatedAt,
+	}
+}
+
+// toDomainNotificationSamples разбирает jsonb-колонку samples. NULL или нечитаемое значение - образцов нет
+func toDomainNotificationSamples(data []byte) []domain.NotificationSample {
+	if len(data) == 0 {
+		return nil
+	}
+	var sampleModels []models.NotificationSampleJSON
+	if err := json.Unmarshal(data, &sampleModels); err != nil {
+		log.Printf("failed to unmarshal notification samples: %v", err)
+		return nil
+	}
+
+	samples := make([]domain.NotificationSample, len(sampleModels))
+	for i, sample := range sampleModels {
+		samples[i] = domain.NotificationSample{Text: sample.Text}
+		if sample.Expected != nil {
+			samples[i].Expected = &domain.ParsedNotification{
+				Amount:     sample.Expected.Amount,
+				Currency:   sample.Expected.Currency,
+				SenderName: sample.Expected.SenderName,
+				CardTail:   sample.Expected.CardTail,
+			}
+		}
+	}
+	return samples
+}
+
+// toGORMNotificationSamples сериализует образцы для jsonb-колонки samples, без образцов - NULL
+func toGORMNotificationSamples(samples []domain.NotificationSample) []byte {
+	if len(samples) == 0 {
+		return nil
+	}
+	sampleModels := make([]models.NotificationSampleJSON, len(samples))
+	for i, sample := range samples {
+		sampleModels[i] = models.NotificationSampleJSON{Text: sample.Text}
+		if sample.Expected != nil {
+			sampleModels[i].Expected = &models.ParsedNotificationJSON{
+				Amount:     sample.Expected.Amount,
+				Currency:   sample.Expected.Currency,
+				SenderName: sample.Expected.SenderName,
+				CardTail:   sample.Expected.CardTail,
+			}
+		}
+	}
+	data, err := json.Marshal(sampleModels)
+	if err != nil {
+		log.Printf("failed to marshal notification samples: %v", err)
+		return nil
+	}
+	return data
+}
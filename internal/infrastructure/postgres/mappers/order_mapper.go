@@ -2,6 +2,7 @@ package mappers
 
 import (
 	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/pii"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
 )
 
@@ -19,6 +20,7 @@ func ToDomainOrder(model *models.OrderModel) *domain.Order {
 			AmountCrypto: model.AmountCrypto,
 			CryptoRate: model.CryptoRubRate,
 			Currency: model.Currency,
+			OriginalAmountFiat: originalAmountFiat(model.OriginalAmountFiat, model.AmountFiat),
 		},
 		BankDetailID: model.BankDetailsID,
 		Type: domain.OrderType(model.Type),
@@ -30,12 +32,13 @@ func ToDomainOrder(model *models.OrderModel) *domain.Order {
 		ExpiresAt: model.ExpiresAt,
 		CreatedAt: model.CreatedAt,
 		UpdatedAt: model.UpdatedAt,
+		Version: model.Version,
 
 		RequisiteDetails: domain.RequisiteDetails{
 			TraderID: model.TraderID,
-			CardNumber: model.CardNumber,
-			Phone: model.Phone,
-			Owner: model.Owner,
+			CardNumber: string(model.CardNumber),
+			Phone: string(model.Phone),
+			Owner: string(model.Owner),
 			PaymentSystem: model.PaymentSystem,
 			BankName: model.BankName,
 			BankCode: model.BankCode,
@@ -58,6 +61,7 @@ func ToGORMOrder(order *domain.Order) *models.OrderModel {
 		MerchantID: order.MerchantInfo.MerchantID,
 		AmountFiat: order.AmountInfo.AmountFiat,
 		AmountCrypto: order.AmountInfo.AmountCrypto,
+		OriginalAmountFiat: originalAmountFiat(order.AmountInfo.OriginalAmountFiat, order.AmountInfo.AmountFiat),
 		Currency: order.AmountInfo.Currency,
 		ClientID: order.MerchantInfo.ClientID,
 		Status: order.Status,
@@ -73,11 +77,12 @@ func ToGORMOrder(order *domain.Order) *models.OrderModel {
 		ExpiresAt: order.ExpiresAt,
 		CreatedAt: order.CreatedAt,
 		UpdatedAt: order.UpdatedAt,
+		Version: order.Version,
 
 		TraderID: order.RequisiteDetails.TraderID,
-		CardNumber: order.RequisiteDetails.CardNumber,
-		Phone: order.RequisiteDetails.Phone,
-		Owner: order.RequisiteDetails.Owner,
+		CardNumber: pii.EncryptedString(order.RequisiteDetails.CardNumber),
+		Phone: pii.EncryptedString(order.RequisiteDetails.Phone),
+		Owner: pii.EncryptedString(order.RequisiteDetails.Owner),
 		PaymentSystem: order.RequisiteDetails.PaymentSystem,
 		BankName: order.RequisiteDetails.BankName,
 		BankCode: order.RequisiteDetails.BankCode,
@@ -88,4 +93,12 @@ func ToGORMOrder(order *domain.Order) *models.OrderModel {
 		CompletedAt: order.Metrics.CompletedAt,
 		CanceledAt: order.Metrics.CanceledAt,
 	}
-}
\ No newline at end of file
+}
+
+// originalAmountFiat - сумма мерчанта; если сумма не сдвигалась (или сделка создана раньше), совпадает с amount_fiat
+func originalAmountFiat(original, amountFiat float64) float64 {
+	if original == 0 {
+		return amountFiat
+	}
+	return original
+}
@@ -0,0 +1,32 @@
+package mappers
+
+import (
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+)
+
+func ToDomainOrderStatusTransition(model *models.OrderStatusTransitionModel) *domain.OrderStatusTransition {
+	return &domain.OrderStatusTransition{
+		ID:         model.ID,
+		OrderID:    model.OrderID,
+		FromStatus: model.FromStatus,
+		ToStatus:   model.ToStatus,
+		Actor:      model.Actor,
+		Operation:  model.Operation,
+		Reason:     model.Reason,
+		CreatedAt:  model.CreatedAt,
+	}
+}
+
+func ToGORMOrderStatusTransition(transition *domain.OrderStatusTransition) *models.OrderStatusTransitionModel {
+	return &models.OrderStatusTransitionModel{
+		ID:         transition.ID,
+		OrderID:    transition.OrderID,
+		FromStatus: transition.FromStatus,
+		ToStatus:   transition.ToStatus,
+		Actor:      transition.Actor,
+		Operation:  transition.Operation,
+		Reason:     transition.Reason,
+		CreatedAt:  transition.CreatedAt,
+	}
+}
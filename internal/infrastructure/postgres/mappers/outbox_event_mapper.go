@@ -0,0 +1,39 @@
+package mappers
+
+import (
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/pii"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+)
+
+func ToDomainOutboxEvent(model *models.OutboxEventModel) *domain.OutboxEvent {
+	return &domain.OutboxEvent{
+		ID:            model.ID,
+		AggregateType: model.AggregateType,
+		AggregateID:   model.AggregateID,
+		Key:           model.Key,
+		Payload:       []byte(model.Payload),
+		Status:        model.Status,
+		Attempts:      model.Attempts,
+		LastError:     model.LastError,
+		NextAttemptAt: model.NextAttemptAt,
+		CreatedAt:     model.CreatedAt,
+		SentAt:        model.SentAt,
+	}
+}
+
+func ToGORMOutboxEvent(event *domain.OutboxEvent) *models.OutboxEventModel {
+	return &models.OutboxEventModel{
+		ID:            event.ID,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		Key:           event.Key,
+		Payload:       pii.EncryptedJSON(event.Payload),
+		Status:        event.Status,
+		Attempts:      event.Attempts,
+		LastError:     event.LastError,
+		NextAttemptAt: event.NextAttemptAt,
+		CreatedAt:     event.CreatedAt,
+		SentAt:        event.SentAt,
+	}
+}
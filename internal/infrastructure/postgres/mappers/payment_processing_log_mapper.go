@@ -0,0 +1,61 @@
+package mappers
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+)
+
+func ToDomainPaymentProcessingLog(model *models.PaymentProcessingLog) *domain.PaymentProcessingLog {
+	paymentLog := &domain.PaymentProcessingLog{
+		ID:            model.ID,
+		PaymentHash:   model.PaymentHash,
+		DeviceID:      model.DeviceID,
+		Amount:        model.Amount,
+		PaymentSystem: model.PaymentSystem,
+		ProcessedAt:   model.ProcessedAt,
+		CompletedAt:   model.CompletedAt,
+		Success:       model.Success,
+		Error:         model.Error,
+	}
+	if model.OrderID != nil {
+		paymentLog.OrderID = *model.OrderID
+	}
+	if len(model.Result) > 0 {
+		var result domain.AutomaticPaymentResult
+		if err := json.Unmarshal(model.Result, &result); err != nil {
+			log.Printf("failed to unmarshal payment processing result: %v", err)
+		} else {
+			paymentLog.Result = &result
+		}
+	}
+	return paymentLog
+}
+
+func ToGORMPaymentProcessingLog(paymentLog *domain.PaymentProcessingLog) *models.PaymentProcessingLog {
+	model := &models.PaymentProcessingLog{
+		ID:            paymentLog.ID,
+		PaymentHash:   paymentLog.PaymentHash,
+		DeviceID:      paymentLog.DeviceID,
+		Amount:        paymentLog.Amount,
+		PaymentSystem: paymentLog.PaymentSystem,
+		ProcessedAt:   paymentLog.ProcessedAt,
+		CompletedAt:   paymentLog.CompletedAt,
+		Success:       paymentLog.Success,
+		Error:         paymentLog.Error,
+	}
+	if paymentLog.OrderID != "" {
+		model.OrderID = &paymentLog.OrderID
+	}
+	if paymentLog.Result != nil {
+		result, err := json.Marshal(paymentLog.Result)
+		if err != nil {
+			log.Printf("failed to marshal payment processing result: %v", err)
+		} else {
+			model.Result = result
+		}
+	}
+	return model
+}
@@ -0,0 +1,40 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+)
+
+func ToDomainRoutingScore(model *models.RoutingScoreModel) *domain.RoutingScore {
+	return &domain.RoutingScore{
+		SubjectType:        domain.RoutingSubject(model.SubjectType),
+		SubjectID:          model.SubjectID,
+		TraderID:           model.TraderID,
+		Score:              model.Score,
+		Override:           model.Override,
+		Orders:             model.Orders,
+		CompletionRate:     model.CompletionRate,
+		MedianCompleteTime: time.Duration(model.MedianCompleteSeconds * float64(time.Second)),
+		DisputeRate:        model.DisputeRate,
+		AutomaticShare:     model.AutomaticShare,
+		CalculatedAt:       model.CalculatedAt,
+	}
+}
+
+func ToGORMRoutingScore(score *domain.RoutingScore) *models.RoutingScoreModel {
+	return &models.RoutingScoreModel{
+		SubjectType:           string(score.SubjectType),
+		SubjectID:             score.SubjectID,
+		TraderID:              score.TraderID,
+		Score:                 score.Score,
+		Override:              score.Override,
+		Orders:                score.Orders,
+		CompletionRate:        score.CompletionRate,
+		MedianCompleteSeconds: score.MedianCompleteTime.Seconds(),
+		DisputeRate:           score.DisputeRate,
+		AutomaticShare:        score.AutomaticShare,
+		CalculatedAt:          score.CalculatedAt,
+	}
+}
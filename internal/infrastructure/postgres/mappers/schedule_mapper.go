@@ -0,0 +1,77 @@
+package mappers
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+)
+
+// ToDomainSchedule разбирает jsonb-колонку schedule. NULL или нечитаемое значение - расписания нет
+func ToDomainSchedule(data []byte) *domain.Schedule {
+	if len(data) == 0 {
+		return nil
+	}
+	var model models.ScheduleJSON
+	if err := json.Unmarshal(data, &model); err != nil {
+		log.Printf("failed to unmarshal schedule: %v", err)
+		return nil
+	}
+
+	schedule := &domain.Schedule{Timezone: model.Timezone}
+	for _, interval := range model.Weekly {
+		schedule.Weekly = append(schedule.Weekly, domain.ScheduleInterval{
+			Weekday: time.Weekday(interval.Weekday),
+			TimeRange: domain.TimeRange{
+				StartMinute: interval.StartMinute,
+				EndMinute:   interval.EndMinute,
+			},
+		})
+	}
+	for _, exception := range model.Exceptions {
+		ranges := make([]domain.TimeRange, len(exception.Ranges))
+		for i, r := range exception.Ranges {
+			ranges[i] = domain.TimeRange{StartMinute: r.StartMinute, EndMinute: r.EndMinute}
+		}
+		schedule.Exceptions = append(schedule.Exceptions, domain.ScheduleException{
+			Date:   exception.Date,
+			Ranges: ranges,
+		})
+	}
+	return schedule
+}
+
+// ToGORMSchedule сериализует расписание для jsonb-колонки schedule, nil - NULL
+func ToGORMSchedule(schedule *domain.Schedule) []byte {
+	if schedule == nil {
+		return nil
+	}
+
+	model := models.ScheduleJSON{Timezone: schedule.Timezone}
+	for _, interval := range schedule.Weekly {
+		model.Weekly = append(model.Weekly, models.ScheduleIntervalJSON{
+			Weekday:     int(interval.Weekday),
+			StartMinute: interval.StartMinute,
+			EndMinute:   interval.EndMinute,
+		})
+	}
+	for _, exception := range schedule.Exceptions {
+		ranges := make([]models.TimeRangeJSON, len(exception.Ranges))
+		for i, r := range exception.Ranges {
+			ranges[i] = models.TimeRangeJSON{StartMinute: r.StartMinute, EndMinute: r.EndMinute}
+		}
+		model.Exceptions = append(model.Exceptions, models.ScheduleExceptionJSON{
+			Date:   exception.Date,
+			Ranges: ranges,
+		})
+	}
+
+	data, err := json.Marshal(model)
+	if err != nil {
+		log.Printf("failed to marshal schedule: %v", err)
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,57 @@
+package mappers
+
+import (
+	"strings"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+)
+
+func ToDomainUnmatchedPayment(model *models.UnmatchedPaymentModel) *domain.UnmatchedPayment {
+	payment := &domain.UnmatchedPayment{
+		ID:            model.ID,
+		PaymentHash:   model.PaymentHash,
+		DeviceID:      model.DeviceID,
+		TraderID:      model.TraderID,
+		Amount:        model.Amount,
+		PaymentSystem: model.PaymentSystem,
+		Direction:     model.Direction,
+		Text:          model.Text,
+		ReceivedAt:    model.ReceivedAt,
+		Status:        domain.UnmatchedPaymentStatus(model.Status),
+		LinkedBy:      model.LinkedBy,
+		LinkedAt:      model.LinkedAt,
+		CreatedAt:     model.CreatedAt,
+	}
+	if model.OrderID != nil {
+		payment.OrderID = *model.OrderID
+	}
+	if model.CandidateOrderIDs != "" {
+		payment.CandidateOrderIDs = strings.Split(model.CandidateOrderIDs, ",")
+	}
+	return payment
+}
+
+func ToGORMUnmatchedPayment(payment *domain.UnmatchedPayment) *models.UnmatchedPaymentModel {
+	model := &models.UnmatchedPaymentModel{
+		ID:            payment.ID,
+		PaymentHash:   payment.PaymentHash,
+		DeviceID:      payment.DeviceID,
+		TraderID:      payment.TraderID,
+		Amount:        payment.Amount,
+		PaymentSystem: payment.PaymentSystem,
+		Direction:     payment.Direction,
+		Text:          payment.Text,
+		ReceivedAt:    payment.ReceivedAt,
+		Status:        string(payment.Status),
+		LinkedBy:      payment.LinkedBy,
+		LinkedAt:      payment.LinkedAt,
+		CreatedAt:     payment.CreatedAt,
+
+		CandidateOrderIDs: strings.Join(payment.CandidateOrderIDs, ","),
+	}
+	if payment.OrderID != "" {
+		model.OrderID = &payment.OrderID
+	}
+	return model
+}
@@ -0,0 +1,8 @@
+-- +migrate Down
+
+-- Удаленные дубли не восстанавливаются. Пока в модели остается тег uniqueIndex,
+-- AutoMigrate создаст уникальный индекс заново при старте сервиса
+DROP INDEX IF EXISTS idx_payment_log_notification_hash;
+
+CREATE INDEX IF NOT EXISTS idx_payment_log_hash
+ON payment_processing_logs (payment_hash);
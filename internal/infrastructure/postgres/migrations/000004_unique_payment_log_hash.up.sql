@@ -0,0 +1,16 @@
+-- +migrate Up
+
+-- Уведомление обрабатывается один раз: запись в журнале одна на хэш уведомления.
+-- Раньше на один хэш могло быть несколько записей (по одной на сделку), оставляем самую раннюю
+DELETE FROM payment_processing_logs l
+USING payment_processing_logs d
+WHERE l.payment_hash = d.payment_hash
+  AND (d.processed_at, d.id) < (l.processed_at, l.id);
+
+-- Тот же индекс, что в теге gorm модели PaymentProcessingLog (AutoMigrate не создаст его при дублях)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_log_notification_hash
+ON payment_processing_logs (payment_hash);
+
+-- Неуникальные индексы по хэшу больше не нужны: из 000003 и из прежнего тега gorm
+DROP INDEX IF EXISTS idx_payment_log_hash;
+DROP INDEX IF EXISTS idx_payment_processing_logs_payment_hash;
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/pii"
 	"gorm.io/gorm"
 )
 
@@ -20,15 +21,28 @@ type BankDetailModel struct {
 	PaymentSystem 			string
 	Delay					time.Duration
 	Enabled 				bool
-	CardNumber 				string 
-	Phone 					string
-	Owner 					string
+	// Персональные данные шифруются при записи (pii.EncryptedString)
+	CardNumber 				pii.EncryptedString
+	Phone 					pii.EncryptedString
+	Owner 					pii.EncryptedString
 	MaxOrdersSimultaneosly  int32
 	MaxAmountDay			float64
 	MaxAmountMonth			float64
 	MaxQuantityDay			int32
 	MaxQuantityMonth		int32
+	MaxAmountHour			float64	`gorm:"not null;default:0"`
+	MaxQuantityHour			int32	`gorm:"not null;default:0"`
+	MaxAmountWeek			float64	`gorm:"not null;default:0"`
+	MaxQuantityWeek			int32	`gorm:"not null;default:0"`
+	LimitMode				string	`gorm:"not null;default:''"`
+	Timezone				string	`gorm:"not null;default:''"`
 	DeviceID				string
+	// Расписание работы (ScheduleJSON), NULL - без расписания
+	Schedule				[]byte	`gorm:"type:jsonb"`
+	// Автоматическая приостановка по здоровью реквизита
+	SuspendedUntil			*time.Time
+	SuspendReason			string	`gorm:"not null;default:''"`
+	BinMismatch				bool	`gorm:"not null;default:false"`
 	CreatedAt				time.Time
 	UpdatedAt 				time.Time
 	DeletedAt 				gorm.DeletedAt `gorm:"index"`
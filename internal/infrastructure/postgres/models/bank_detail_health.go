@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type BankDetailHealthModel struct {
+	BankDetailID       string `gorm:"primaryKey;type:uuid"`
+	TraderID           string `gorm:"index"`
+	ConsecutiveCancels int32  `gorm:"not null;default:0"`
+	LastCancelAt       *time.Time
+	LastDisputeAt      *time.Time
+	Suspensions        int32 `gorm:"not null;default:0"`
+	LastSuspendedAt    *time.Time
+	ResumedAt          *time.Time
+	UpdatedAt          time.Time
+}
+
+func (BankDetailHealthModel) TableName() string {
+	return "bank_detail_health"
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// BinModel - локальный справочник BIN: банк-эмитент по префиксу номера карты
+type BinModel struct {
+	Prefix         string `gorm:"primaryKey;size:8"`
+	BankName       string
+	BankCode       string
+	NspkCode       string
+	PaymentNetwork string
+	Country        string
+	UpdatedAt      time.Time
+}
+
+func (BinModel) TableName() string {
+	return "bin_directory"
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+type CallbackDeliveryModel struct {
+	ID                   string                        `gorm:"primaryKey;type:uuid"`
+	OrderID              string                        `gorm:"index"`
+	MerchantID           string                        `gorm:"index"`
+	MerchantOrderID      string                        `gorm:"index"`
+	ClientID             string
+	CallbackUrl          string                        `gorm:"type:text;not null"`
+	OrderStatus          string                        `gorm:"not null"`
+	AmountFiat           float64
+	AmountCrypto         float64
+	OriginalAmountFiat   float64
+	Currency             string
+	UsdRate              float64
+	ReconciliationSum    float64
+	ReconciliationAmount float64
+	ReconciliationRate   float64
+	Status               domain.CallbackDeliveryStatus `gorm:"not null;index:idx_callback_status_next_attempt"`
+	Attempts             int                           `gorm:"not null;default:0"`
+	LastHTTPCode         int
+	LastError            string                        `gorm:"type:text"`
+	NextAttemptAt        time.Time                     `gorm:"not null;index:idx_callback_status_next_attempt"`
+	DeliveredAt          *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+func (CallbackDeliveryModel) TableName() string {
+	return "callback_deliveries"
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/pii"
+)
+
+// DeviceCredentialModel - секрет устройства для подписи запросов, шифруется как персональные данные
+type DeviceCredentialModel struct {
+	ID        string              `gorm:"primaryKey;type:uuid"`
+	DeviceID  string              `gorm:"index;not null"`
+	Secret    pii.EncryptedString `gorm:"not null"`
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+}
+
+func (DeviceCredentialModel) TableName() string {
+	return "device_credentials"
+}
+
+// DeviceAuthFailureModel - аудит запросов устройств, не прошедших проверку подписи
+type DeviceAuthFailureModel struct {
+	ID              string `gorm:"primaryKey;type:uuid"`
+	DeviceID        string `gorm:"index"`
+	ClaimedDeviceID string
+	ClaimedTraderID string
+	Method          string
+	PeerAddr        string
+	Reason          string `gorm:"index;not null"`
+	Enforced        bool
+	CreatedAt       time.Time `gorm:"index"`
+}
+
+func (DeviceAuthFailureModel) TableName() string {
+	return "device_auth_failures"
+}
+
+// DeviceRequestNonceModel - подписи принятых запросов устройств. Уникальный ключ отклоняет повтор
+// запроса на любом экземпляре сервиса, запись удаляется после ExpiresAt
+type DeviceRequestNonceModel struct {
+	DeviceID  string    `gorm:"primaryKey"`
+	Signature string    `gorm:"primaryKey"`
+	ExpiresAt time.Time `gorm:"index;not null"`
+	CreatedAt time.Time
+}
+
+func (DeviceRequestNonceModel) TableName() string {
+	return "device_request_nonces"
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+type MerchantCallbackSettingsModel struct {
+	MerchantID string                `gorm:"primaryKey"`
+	Format     domain.CallbackFormat `gorm:"not null;default:GET"`
+	Secret     string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (MerchantCallbackSettingsModel) TableName() string {
+	return "merchant_callback_settings"
+}
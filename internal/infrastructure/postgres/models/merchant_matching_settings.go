@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type MerchantMatchingSettingsModel struct {
+	MerchantID        string `gorm:"primaryKey"`
+	WaitForRequisites bool   `gorm:"not null;default:false"`
+	WaitWindowSeconds int64  `gorm:"not null;default:0"`
+	SelectionStrategy string `gorm:"not null;default:''"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (MerchantMatchingSettingsModel) TableName() string {
+	return "merchant_matching_settings"
+}
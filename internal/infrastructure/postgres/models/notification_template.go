@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// NotificationTemplateModel - шаблон разбора уведомлений банка
+type NotificationTemplateModel struct {
+	ID              string `gorm:"primaryKey;type:uuid"`
+	BankCode        string `gorm:"index;not null"`
+	Name            string
+	Pattern         string `gorm:"type:text;not null"`
+	Direction       string `gorm:"not null"`
+	DefaultCurrency string
+	Priority        int32  `gorm:"not null"`
+	Enabled         bool   `gorm:"not null"`
+	Samples         []byte `gorm:"type:jsonb"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// NotificationSampleJSON - образец уведомления в jsonb-колонке samples
+type NotificationSampleJSON struct {
+	Text     string                  `json:"text"`
+	Expected *ParsedNotificationJSON `json:"expected,omitempty"`
+}
+
+type ParsedNotificationJSON struct {
+	Amount     float64 `json:"amount"`
+	Currency   string  `json:"currency,omitempty"`
+	SenderName string  `json:"sender_name,omitempty"`
+	CardTail   string  `json:"card_tail,omitempty"`
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/pii"
 )
 
 type OrderModel struct {
@@ -11,6 +12,7 @@ type OrderModel struct {
 	MerchantID 	  		string  			
 	AmountFiat 	  		float64				`gorm:"index:idx_amount"`
 	AmountCrypto  		float64	
+	OriginalAmountFiat 	float64
 	Currency 	  		string		
 	Country 	  		string
 	ClientID   	  		string
@@ -30,9 +32,10 @@ type OrderModel struct {
 	Type 				string
 	// Параметры реквизитв
 	TraderID 			string
-	CardNumber 			string 
-	Phone 				string
-	Owner 				string
+	// Персональные данные шифруются при записи (pii.EncryptedString)
+	CardNumber 			pii.EncryptedString
+	Phone 				pii.EncryptedString
+	Owner 				pii.EncryptedString
 	PaymentSystem 		string
 	BankName 			string
 	BankCode 			string
@@ -46,18 +49,28 @@ type OrderModel struct {
 	AcceptedAt			time.Time
 	CompletedAt			time.Time
 	CanceledAt			time.Time
+
+	// Оптимистичная блокировка смены статуса
+	Version				int64				`gorm:"not null;default:0"`
 }
 
+// PaymentProcessingLog - уведомление об оплате, принятое в обработку. Запись создается до поиска сделок
+// и по уникальному хэшу не дает обработать повторно присланное устройством уведомление
 type PaymentProcessingLog struct {
 	ID           string    `gorm:"primaryKey;type:uuid"`
-	OrderID      string    `gorm:"type:uuid;not null;index"`
-	PaymentHash  string    `gorm:"not null;index"` // Хэш уведомления для идемпотентности
+	OrderID      *string   `gorm:"type:uuid;index"` // первая закрытая по уведомлению сделка
+	PaymentHash  string    `gorm:"not null;uniqueIndex:idx_payment_log_notification_hash"` // Хэш уведомления для идемпотентности
+	DeviceID     string    `gorm:"not null;default:''"`
 	Amount       float64   `gorm:"not null"`
 	PaymentSystem string   `gorm:"not null"`
 	ProcessedAt  time.Time `gorm:"not null"`
+	// Завершение обработки, NULL - уведомление еще обрабатывается
+	CompletedAt  *time.Time
 	Success      bool      `gorm:"not null"`
 	Error        string    
 	Metadata     string    `gorm:"type:jsonb"` // Дополнительные данные
+	// Результат обработки (AutomaticPaymentResult), который получают повторы уведомления
+	Result       []byte    `gorm:"type:jsonb"`
 }
 
 type AutomaticPaymentResult struct {
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+// OrderStatusTransitionModel - история переходов статусов сделки
+type OrderStatusTransitionModel struct {
+	ID         string             `gorm:"primaryKey;type:uuid"`
+	OrderID    string             `gorm:"type:uuid;not null;index:idx_order_transitions_order_created"`
+	FromStatus domain.OrderStatus
+	ToStatus   domain.OrderStatus `gorm:"not null"`
+	Actor      string             `gorm:"not null"`
+	Operation  string             `gorm:"not null"`
+	Reason     string             `gorm:"type:text"`
+	CreatedAt  time.Time          `gorm:"index:idx_order_transitions_order_created"`
+}
+
+func (OrderStatusTransitionModel) TableName() string {
+	return "order_status_transitions"
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/pii"
+)
+
+// OutboxEventModel - событие outbox. В событиях для потребителей с полными реквизитами реквизиты
+// открыты, поэтому Payload шифруется как персональные данные
+type OutboxEventModel struct {
+	ID            string `gorm:"primaryKey;type:uuid"`
+	AggregateType string `gorm:"not null"`
+	AggregateID   string `gorm:"not null;index"`
+	Key           string
+	Payload       pii.EncryptedJSON   `gorm:"type:jsonb;not null"`
+	Status        domain.OutboxStatus `gorm:"not null;index:idx_outbox_status_next_attempt"`
+	Attempts      int                 `gorm:"not null;default:0"`
+	LastError     string              `gorm:"type:text"`
+	NextAttemptAt time.Time           `gorm:"not null;index:idx_outbox_status_next_attempt"`
+	CreatedAt     time.Time           `gorm:"index"`
+	SentAt        *time.Time          `gorm:"index"`
+}
+
+func (OutboxEventModel) TableName() string {
+	return "outbox_events"
+}
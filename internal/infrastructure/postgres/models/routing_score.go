@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type RoutingScoreModel struct {
+	SubjectType           string  `gorm:"primaryKey"`
+	SubjectID             string  `gorm:"primaryKey"`
+	TraderID              string  `gorm:"index"`
+	Score                 float64 `gorm:"not null;default:1"`
+	Override              *float64
+	Orders                int
+	CompletionRate        float64
+	MedianCompleteSeconds float64
+	DisputeRate           float64
+	AutomaticShare        float64
+	CalculatedAt          time.Time
+	UpdatedAt             time.Time
+}
+
+func (RoutingScoreModel) TableName() string {
+	return "routing_scores"
+}
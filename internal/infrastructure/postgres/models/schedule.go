@@ -0,0 +1,24 @@
+package models
+
+// ScheduleJSON - расписание работы реквизита или трафика в jsonb-колонке schedule
+type ScheduleJSON struct {
+	Timezone   string                  `json:"timezone,omitempty"`
+	Weekly     []ScheduleIntervalJSON  `json:"weekly,omitempty"`
+	Exceptions []ScheduleExceptionJSON `json:"exceptions,omitempty"`
+}
+
+type ScheduleIntervalJSON struct {
+	Weekday     int   `json:"weekday"`
+	StartMinute int32 `json:"start_minute"`
+	EndMinute   int32 `json:"end_minute"`
+}
+
+type ScheduleExceptionJSON struct {
+	Date   string          `json:"date"`
+	Ranges []TimeRangeJSON `json:"ranges,omitempty"`
+}
+
+type TimeRangeJSON struct {
+	StartMinute int32 `json:"start_minute"`
+	EndMinute   int32 `json:"end_minute"`
+}
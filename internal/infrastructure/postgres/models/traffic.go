@@ -35,6 +35,14 @@ type TrafficModel struct {
 	AntifraudRequired bool	
 
 	MerchantDealsDuration time.Duration
+	UniqueAmountStep 		float64
+	UniqueAmountMaxSteps 	int32
+	AmountTolerance 		float64 `gorm:"not null;default:0"`
+	AmountTolerancePercent 	float64 `gorm:"not null;default:0"`
+	SelectionStrategy 		string 	`gorm:"not null;default:''"`
+
+	// Расписание работы (ScheduleJSON), NULL - без расписания
+	Schedule 				[]byte	`gorm:"type:jsonb"`
 
 	CreatedAt 			time.Time
 	UpdatedAt 			time.Time
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// UnmatchedPaymentModel - уведомление об оплате без подходящей сделки
+type UnmatchedPaymentModel struct {
+	ID            string  `gorm:"primaryKey;type:uuid"`
+	PaymentHash   string  `gorm:"uniqueIndex;not null"`
+	DeviceID      string  `gorm:"index;not null"`
+	TraderID      string  `gorm:"index"`
+	Amount        float64 `gorm:"not null"`
+	PaymentSystem string
+	Direction     string
+	Text          string `gorm:"type:text"`
+	ReceivedAt    time.Time
+	Status        string  `gorm:"index;not null"`
+	OrderID       *string `gorm:"type:uuid"`
+	LinkedBy      string
+	LinkedAt      *time.Time
+	CreatedAt     time.Time `gorm:"index"`
+	// Сделки, которым платеж подходит одинаково: ID через запятую
+	CandidateOrderIDs string `gorm:"type:text"`
+}
+
+func (UnmatchedPaymentModel) TableName() string {
+	return "unmatched_payments"
+}
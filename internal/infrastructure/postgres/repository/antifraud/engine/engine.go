@@ -18,6 +18,7 @@ type AntiFraudEngine struct {
     strategies      map[string]strategies.AntiFraudStrategy
     logger          *slog.Logger
     snapshotManager *SnapshotManager // <-- УБЕДИТЕСЬ ЧТО ЭТО ПОЛЕ ЕСТЬ
+    onTrafficChanged func(traderID string)
 }
 
 func NewAntiFraudEngine(db *gorm.DB, logger *slog.Logger) *AntiFraudEngine {
@@ -36,6 +37,13 @@ func (e *AntiFraudEngine) GetSnapshotManager() *SnapshotManager {
     return e.snapshotManager // <-- УБЕДИТЕСЬ ЧТО ЭТОТ МЕТОД ЕСТЬ
 }
 
+// SetTrafficChangedHook задает функцию, которая вызывается после смены антифрод-блокировки трафика
+// трейдера (например, сброс кэша трафика)
+func (e *AntiFraudEngine) SetTrafficChangedHook(hook func(traderID string)) {
+    e.onTrafficChanged = hook
+    e.snapshotManager.onTrafficChanged = hook
+}
+
 // RegisterStrategy регистрирует новую стратегию
 func (e *AntiFraudEngine) RegisterStrategy(strategy strategies.AntiFraudStrategy) {
     e.strategies[strategy.Name()] = strategy
@@ -151,10 +159,14 @@ func (e *AntiFraudEngine) updateTrafficStatus(ctx context.Context, traderID stri
         "updated_at":         time.Now(),
     }
 
-    return e.db.WithContext(ctx).
+    err := e.db.WithContext(ctx).
         Model(&models.TrafficModel{}).
         Where("trader_id = ?", traderID).
         Updates(updates).Error
+    if e.onTrafficChanged != nil {
+        e.onTrafficChanged(traderID)
+    }
+    return err
 }
 
 // saveAuditLog сохраняет результат проверки для аудита
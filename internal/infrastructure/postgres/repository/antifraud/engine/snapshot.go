@@ -21,7 +21,8 @@ type UnlockSnapshot struct {
 
 // SnapshotManager управляет снепшотами разблокировок
 type SnapshotManager struct {
-    db *gorm.DB
+    db               *gorm.DB
+    onTrafficChanged func(traderID string)
 }
 
 func NewSnapshotManager(db *gorm.DB) *SnapshotManager {
@@ -53,7 +54,7 @@ func (sm *SnapshotManager) CreateUnlockSnapshot(
     var snapshotMap map[string]interface{}
     json.Unmarshal(snapshotJSON, &snapshotMap)
 
-    return sm.db.WithContext(ctx).
+    err := sm.db.WithContext(ctx).
         Model(&models.TrafficModel{}).
         Where("trader_id = ?", traderID).
         Updates(map[string]interface{}{
@@ -61,6 +62,10 @@ func (sm *SnapshotManager) CreateUnlockSnapshot(
             "updated_at":         time.Now(),
             "grace_period_until": gracePeriodUntil,
         }).Error
+    if sm.onTrafficChanged != nil {
+        sm.onTrafficChanged(traderID)
+    }
+    return err
 }
 
 // IsInGracePeriod проверяет, действует ли грейс-период
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DefaultBankDetailHealthRepository struct {
+	DB *gorm.DB
+}
+
+func NewDefaultBankDetailHealthRepository(db *gorm.DB) *DefaultBankDetailHealthRepository {
+	return &DefaultBankDetailHealthRepository{DB: db}
+}
+
+// GetBankDetailHealth - счетчики реквизита вместе с его текущей приостановкой
+func (r *DefaultBankDetailHealthRepository) GetBankDetailHealth(bankDetailID string) (*domain.BankDetailHealth, error) {
+	var bankDetail models.BankDetailModel
+	err := r.DB.Select("id", "trader_id", "suspended_until", "suspend_reason").
+		Where("id = ?", bankDetailID).
+		First(&bankDetail).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank detail: %w", err)
+	}
+
+	health := &domain.BankDetailHealth{BankDetailID: bankDetailID, TraderID: bankDetail.TraderID}
+	var healthModel models.BankDetailHealthModel
+	err = r.DB.Where("bank_detail_id = ?", bankDetailID).First(&healthModel).Error
+	switch {
+	case err == nil:
+		health = mappers.ToDomainBankDetailHealth(&healthModel)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, fmt.Errorf("failed to get bank detail health: %w", err)
+	}
+	health.SuspendedUntil = bankDetail.SuspendedUntil
+	health.SuspendReason = bankDetail.SuspendReason
+	return health, nil
+}
+
+func (r *DefaultBankDetailHealthRepository) RecordBankDetailCancel(bankDetailID, traderID string, at time.Time) (*domain.BankDetailHealth, error) {
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "bank_detail_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"trader_id":           traderID,
+			"consecutive_cancels": gorm.Expr("bank_detail_health.consecutive_cancels + 1"),
+			"last_cancel_at":      at,
+			"updated_at":          at,
+		}),
+	}).Create(&models.BankDetailHealthModel{
+		BankDetailID:       bankDetailID,
+		TraderID:           traderID,
+		ConsecutiveCancels: 1,
+		LastCancelAt:       &at,
+		UpdatedAt:          at,
+	}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to record bank detail cancel: %w", err)
+	}
+	return r.GetBankDetailHealth(bankDetailID)
+}
+
+func (r *DefaultBankDetailHealthRepository) RecordBankDetailCompletion(bankDetailID string) error {
+	err := r.DB.Model(&models.BankDetailHealthModel{}).
+		Where("bank_detail_id = ? AND consecutive_cancels > 0", bankDetailID).
+		Updates(map[string]interface{}{
+			"consecutive_cancels": 0,
+			"updated_at":          time.Now(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record bank detail completion: %w", err)
+	}
+	return nil
+}
+
+func (r *DefaultBankDetailHealthRepository) RecordBankDetailDispute(bankDetailID, traderID string, at time.Time) (*domain.BankDetailHealth, error) {
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "bank_detail_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"trader_id":       traderID,
+			"last_dispute_at": at,
+			"updated_at":      at,
+		}),
+	}).Create(&models.BankDetailHealthModel{
+		BankDetailID:  bankDetailID,
+		TraderID:      traderID,
+		LastDisputeAt: &at,
+		UpdatedAt:     at,
+	}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to record bank detail dispute: %w", err)
+	}
+	return r.GetBankDetailHealth(bankDetailID)
+}
+
+// CountBankDetailCancels считает отмены по истории статусов: сделка могла быть создана задолго до отмены
+func (r *DefaultBankDetailHealthRepository) CountBankDetailCancels(bankDetailID string, since time.Time) (int32, error) {
+	var count int32
+	err := r.DB.Raw(`
+        SELECT COUNT(*)
+        FROM order_status_transitions t
+        JOIN order_models o ON o.id = t.order_id
+        WHERE o.bank_details_id = ?
+          AND t.to_status = ?
+          AND t.created_at >= ?`,
+		bankDetailID, string(domain.StatusCanceled), since,
+	).Scan(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count bank detail cancels: %w", err)
+	}
+	return count, nil
+}
+
+func (r *DefaultBankDetailHealthRepository) CountBankDetailDisputes(bankDetailID string, since time.Time) (int32, error) {
+	var count int32
+	err := r.DB.Raw(`
+        SELECT COUNT(*)
+        FROM dispute_models d
+        JOIN order_models o ON d.order_id::text = o.id::text
+        WHERE o.bank_details_id = ?
+          AND d.created_at >= ?`,
+		bankDetailID, since,
+	).Scan(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count bank detail disputes: %w", err)
+	}
+	return count, nil
+}
+
+// SuspendBankDetail приостанавливает реквизит, если он еще не приостановлен или его приостановка истекла
+func (r *DefaultBankDetailHealthRepository) SuspendBankDetail(bankDetailID, reason string, at, until time.Time) (bool, error) {
+	suspended := false
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.BankDetailModel{}).
+			Where("id = ? AND (suspended_until IS NULL OR suspended_until <= ?)", bankDetailID, at).
+			Updates(map[string]interface{}{
+				"suspended_until": until,
+				"suspend_reason":  reason,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to suspend bank detail: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		suspended = true
+
+		err := tx.Model(&models.BankDetailHealthModel{}).
+			Where("bank_detail_id = ?", bankDetailID).
+			Updates(map[string]interface{}{
+				"suspensions":       gorm.Expr("suspensions + 1"),
+				"last_suspended_at": at,
+				"updated_at":        at,
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update bank detail health: %w", err)
+		}
+		return nil
+	})
+	return suspended, err
+}
+
+func (r *DefaultBankDetailHealthRepository) ResumeBankDetail(bankDetailID string, at time.Time) (bool, error) {
+	resumed := false
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.BankDetailModel{}).
+			Where("id = ? AND suspended_until IS NOT NULL", bankDetailID).
+			Updates(map[string]interface{}{
+				"suspended_until": nil,
+				"suspend_reason":  "",
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to resume bank detail: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		resumed = true
+
+		err := tx.Model(&models.BankDetailHealthModel{}).
+			Where("bank_detail_id = ?", bankDetailID).
+			Updates(map[string]interface{}{
+				"consecutive_cancels": 0,
+				"resumed_at":          at,
+				"updated_at":          at,
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update bank detail health: %w", err)
+		}
+		return nil
+	})
+	return resumed, err
+}
+
+func (r *DefaultBankDetailHealthRepository) FindExpiredSuspensions(now time.Time) ([]*domain.BankDetail, error) {
+	var bankDetailModels []models.BankDetailModel
+	err := r.DB.
+		Where("suspended_until IS NOT NULL AND suspended_until <= ?", now).
+		Find(&bankDetailModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired suspensions: %w", err)
+	}
+
+	bankDetails := make([]*domain.BankDetail, len(bankDetailModels))
+	for i := range bankDetailModels {
+		bankDetails[i] = mappers.ToDomainBankDetail(&bankDetailModels[i])
+	}
+	return bankDetails, nil
+}
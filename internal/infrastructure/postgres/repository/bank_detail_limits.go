@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"github.com/lib/pq"
+)
+
+// Окна лимитов у каждого реквизита свои (режим и часовой пояс), поэтому они считаются в Go
+// через domain.SearchParams.LimitWindows и передаются в SQL массивами. Все пути подбора
+// (FindSuitableBankDetails, WithLock, InTx) и диагностика используют общие запросы ниже.
+// Параметры: $1 - статус PENDING, $2 - статусы PENDING и COMPLETED, $3 - статус COMPLETED,
+// $4 - сумма сделки, $5 - ID реквизитов, $6..$9 - начала часового, дневного, недельного
+// и месячного окон реквизитов (в порядке $5), $10 - мерчант сделки
+const bankDetailStatsCTE = `
+    limit_windows AS (
+        SELECT * FROM unnest($5::text[], $6::timestamptz[], $7::timestamptz[], $8::timestamptz[], $9::timestamptz[])
+            AS w(bank_detail_id, hour_start, day_start, week_start, month_start)
+    ),
+    bank_detail_stats AS (
+        SELECT
+            w.bank_detail_id as bank_details_id_text,
+            -- Количество активных заказов
+            SUM(CASE WHEN o.status = $1 THEN 1 ELSE 0 END)::int as pending_count,
+            -- Статистика по окнам лимитов реквизита
+            SUM(CASE WHEN o.created_at >= w.hour_start THEN 1 ELSE 0 END)::int as hour_count,
+            SUM(CASE WHEN o.created_at >= w.hour_start THEN o.amount_fiat ELSE 0 END)::float as hour_amount,
+            SUM(CASE WHEN o.created_at >= w.day_start THEN 1 ELSE 0 END)::int as day_count,
+            SUM(CASE WHEN o.created_at >= w.day_start THEN o.amount_fiat ELSE 0 END)::float as day_amount,
+            SUM(CASE WHEN o.created_at >= w.week_start THEN 1 ELSE 0 END)::int as week_count,
+            SUM(CASE WHEN o.created_at >= w.week_start THEN o.amount_fiat ELSE 0 END)::float as week_amount,
+            SUM(CASE WHEN o.created_at >= w.month_start THEN 1 ELSE 0 END)::int as month_count,
+            SUM(CASE WHEN o.created_at >= w.month_start THEN o.amount_fiat ELSE 0 END)::float as month_amount,
+            -- Время последнего завершенного заказа
+            MAX(CASE WHEN o.status = $3 THEN o.created_at END) as last_completed_time,
+            -- Проверка на дублирующие заказы
+            SUM(CASE WHEN o.status = $1 AND o.amount_fiat = $4 THEN 1 ELSE 0 END)::int as duplicate_count
+        FROM order_models o
+        JOIN limit_windows w ON w.bank_detail_id = o.bank_details_id::text
+        WHERE o.status = ANY($2::text[])
+        GROUP BY w.bank_detail_id
+    )`
+
+// uniqueAmountAvailableQuery - трафик трейдера реквизита bd с мерчантом $10 работает с уникальными
+// суммами, и на реквизите осталась свободная сумма из $4 + k * unique_amount_step, k = 0..unique_amount_max_steps
+// (те же суммы перебирает applyUniqueAmount). Суммы сравниваются с точностью до копеек
+const uniqueAmountAvailableQuery = `
+          SELECT 1 FROM traffic_models t
+          WHERE t.trader_id::text = bd.trader_id::text AND t.merchant_id = $10 AND t.unique_amount_step > 0
+            AND (
+                SELECT COUNT(DISTINCT ROUND(o.amount_fiat::numeric, 2))
+                FROM order_models o
+                WHERE o.bank_details_id::text = bd.id::text
+                  AND o.status = $1
+                  AND ROUND(o.amount_fiat::numeric, 2) >= ROUND($4::numeric, 2)
+                  AND ROUND(o.amount_fiat::numeric, 2) <= ROUND(($4 + t.unique_amount_max_steps * t.unique_amount_step)::numeric, 2)
+                  AND ABS(ROUND(o.amount_fiat::numeric, 2) - ROUND(($4 + ROUND(((o.amount_fiat - $4) / t.unique_amount_step)::numeric) * t.unique_amount_step)::numeric, 2)) < 0.005
+            ) < t.unique_amount_max_steps + 1
+      `
+
+// bankDetailsWithinLimitsQuery - реквизиты из $5, которые проходят лимиты, задержку после
+// завершенной сделки и проверку активной сделки на ту же сумму
+const bankDetailsWithinLimitsQuery = `
+    WITH` + bankDetailStatsCTE + `
+    SELECT bd.*
+    FROM bank_detail_models bd
+    LEFT JOIN bank_detail_stats bds ON bd.id::text = bds.bank_details_id_text
+    WHERE bd.id::text = ANY($5::text[])
+      AND bd.enabled = true
+      AND bd.deleted_at IS NULL
+      AND COALESCE(bds.pending_count, 0) < bd.max_orders_simultaneosly
+      AND (bd.max_quantity_hour = 0 OR COALESCE(bds.hour_count, 0) + 1 <= bd.max_quantity_hour)
+      AND (bd.max_amount_hour = 0 OR COALESCE(bds.hour_amount, 0) + $4 <= bd.max_amount_hour)
+      AND COALESCE(bds.day_count, 0) + 1 <= bd.max_quantity_day
+      AND COALESCE(bds.day_amount, 0) + $4 <= bd.max_amount_day
+      AND (bd.max_quantity_week = 0 OR COALESCE(bds.week_count, 0) + 1 <= bd.max_quantity_week)
+      AND (bd.max_amount_week = 0 OR COALESCE(bds.week_amount, 0) + $4 <= bd.max_amount_week)
+      AND COALESCE(bds.month_count, 0) + 1 <= bd.max_quantity_month
+      AND COALESCE(bds.month_amount, 0) + $4 <= bd.max_amount_month
+      AND (bds.last_completed_time IS NULL OR bds.last_completed_time <= NOW() - (bd.delay / 1000000000.0) * INTERVAL '1 SECOND')
+      -- трафик с уникальными суммами сам сдвинет сумму сделки, такой реквизит не отбрасываем
+      AND (COALESCE(bds.duplicate_count, 0) = 0 OR EXISTS (` + uniqueAmountAvailableQuery + `))
+`
+
+// bankDetailConstraintStatsQuery - счетчики реквизитов из $5 и причины, по которым они не проходят
+// условия bankDetailsWithinLimitsQuery. Причины идут в порядке проверки, первая непустая - итоговая
+const bankDetailConstraintStatsQuery = `
+    WITH` + bankDetailStatsCTE + `
+    SELECT
+        bd.id::text as bank_detail_id,
+        bd.trader_id::text as trader_id,
+        bd.card_number,
+        bd.max_orders_simultaneosly,
+        bd.max_quantity_hour,
+        bd.max_amount_hour,
+        bd.max_quantity_day,
+        bd.max_amount_day,
+        bd.max_quantity_week,
+        bd.max_amount_week,
+        bd.max_quantity_month,
+        bd.max_amount_month,
+        bd.delay,
+        COALESCE(bds.pending_count, 0) as pending_count,
+        COALESCE(bds.hour_count, 0) as hour_count,
+        COALESCE(bds.hour_amount, 0) as hour_amount,
+        COALESCE(bds.day_count, 0) as day_count,
+        COALESCE(bds.day_amount, 0) as day_amount,
+        COALESCE(bds.week_count, 0) as week_count,
+        COALESCE(bds.week_amount, 0) as week_amount,
+        COALESCE(bds.month_count, 0) as month_count,
+        COALESCE(bds.month_amount, 0) as month_amount,
+        bds.last_completed_time,
+        COALESCE(bds.duplicate_count, 0) as duplicate_count,
+        ARRAY_REMOVE(ARRAY[
+            CASE WHEN COALESCE(bds.pending_count, 0) >= bd.max_orders_simultaneosly THEN 'max_simultaneous' END,
+            CASE WHEN bd.max_quantity_hour > 0 AND COALESCE(bds.hour_count, 0) + 1 > bd.max_quantity_hour THEN 'max_hour_count' END,
+            CASE WHEN bd.max_amount_hour > 0 AND COALESCE(bds.hour_amount, 0) + $4 > bd.max_amount_hour THEN 'max_hour_amount' END,
+            CASE WHEN COALESCE(bds.day_count, 0) + 1 > bd.max_quantity_day THEN 'max_day_count' END,
+            CASE WHEN COALESCE(bds.day_amount, 0) + $4 > bd.max_amount_day THEN 'max_day_amount' END,
+            CASE WHEN bd.max_quantity_week > 0 AND COALESCE(bds.week_count, 0) + 1 > bd.max_quantity_week THEN 'max_week_count' END,
+            CASE WHEN bd.max_amount_week > 0 AND COALESCE(bds.week_amount, 0) + $4 > bd.max_amount_week THEN 'max_week_amount' END,
+            CASE WHEN COALESCE(bds.month_count, 0) + 1 > bd.max_quantity_month THEN 'max_month_count' END,
+            CASE WHEN COALESCE(bds.month_amount, 0) + $4 > bd.max_amount_month THEN 'max_month_amount' END,
+            CASE WHEN bds.last_completed_time IS NOT NULL AND bds.last_completed_time > NOW() - (bd.delay / 1000000000.0) * INTERVAL '1 SECOND' THEN 'delay_not_passed' END,
+            CASE WHEN COALESCE(bds.duplicate_count, 0) > 0 AND NOT EXISTS (` + uniqueAmountAvailableQuery + `) THEN 'duplicate_order' END
+        ], NULL) as reasons
+    FROM bank_detail_models bd
+    LEFT JOIN bank_detail_stats bds ON bd.id::text = bds.bank_details_id_text
+    WHERE bd.id::text = ANY($5::text[])
+      AND bd.enabled = true
+      AND bd.deleted_at IS NULL
+`
+
+// bankDetailLimitsArgs - параметры $1..$10 общих запросов проверки лимитов
+func bankDetailLimitsArgs(bankDetails []models.BankDetailModel, searchQuery *domain.SuitablleBankDetailsQuery, now time.Time) []interface{} {
+	ids := make([]string, len(bankDetails))
+	windows := make(map[string]domain.LimitWindows, len(bankDetails))
+	for i := range bankDetails {
+		ids[i] = bankDetails[i].ID
+		windows[ids[i]] = limitWindowsOf(&bankDetails[i], now)
+	}
+	hourStarts, dayStarts, weekStarts, monthStarts := windowArrays(ids, windows)
+	pendingCompletedStatuses := []string{string(domain.StatusPending), string(domain.StatusCompleted)}
+
+	return []interface{}{
+		string(domain.StatusPending),       // $1
+		pq.Array(pendingCompletedStatuses), // $2
+		string(domain.StatusCompleted),     // $3
+		searchQuery.AmountFiat,             // $4
+		pq.Array(ids),                      // $5
+		hourStarts,                         // $6
+		dayStarts,                          // $7
+		weekStarts,                         // $8
+		monthStarts,                        // $9
+		searchQuery.MerchantID,             // $10
+	}
+}
+
+func limitWindowsOf(bankDetail *models.BankDetailModel, now time.Time) domain.LimitWindows {
+	params := domain.SearchParams{
+		LimitMode: domain.LimitMode(bankDetail.LimitMode),
+		Timezone:  bankDetail.Timezone,
+	}
+	return params.LimitWindows(now)
+}
+
+// windowArrays раскладывает окна реквизитов по массивам в порядке ids. Время передается строкой
+// со смещением, чтобы Postgres не трактовал его в поясе сессии
+func windowArrays(ids []string, windows map[string]domain.LimitWindows) (hour, day, week, month pq.StringArray) {
+	hour = make(pq.StringArray, len(ids))
+	day = make(pq.StringArray, len(ids))
+	week = make(pq.StringArray, len(ids))
+	month = make(pq.StringArray, len(ids))
+	for i, id := range ids {
+		window := windows[id]
+		hour[i] = window.HourStart.Format(time.RFC3339Nano)
+		day[i] = window.DayStart.Format(time.RFC3339Nano)
+		week[i] = window.WeekStart.Format(time.RFC3339Nano)
+		month[i] = window.MonthStart.Format(time.RFC3339Nano)
+	}
+	return hour, day, week, month
+}
+
+// GetBankDetailCounters считает по реквизитам те же счетчики, что и bankDetailStatsCTE, в переданных окнах.
+// Учитываются активные сделки и сделки с начала самого раннего окна реквизита, поэтому время последней
+// завершенной сделки, созданной раньше, не возвращается
+func (r *DefaultBankDetailRepo) GetBankDetailCounters(windows map[string]domain.LimitWindows) ([]*domain.BankDetailCounters, error) {
+	if len(windows) == 0 {
+		return []*domain.BankDetailCounters{}, nil
+	}
+
+	ids := make([]string, 0, len(windows))
+	for id := range windows {
+		ids = append(ids, id)
+	}
+	hourStarts, dayStarts, weekStarts, monthStarts := windowArrays(ids, windows)
+
+	sqlQuery := `
+        WITH limit_windows AS (
+            SELECT * FROM unnest($1::text[], $2::timestamptz[], $3::timestamptz[], $4::timestamptz[], $5::timestamptz[])
+                AS w(bank_detail_id, hour_start, day_start, week_start, month_start)
+        )
+        SELECT
+            w.bank_detail_id,
+            SUM(CASE WHEN o.status = $6 THEN 1 ELSE 0 END)::int as pending_count,
+            SUM(CASE WHEN o.created_at >= w.hour_start THEN 1 ELSE 0 END)::int as hour_count,
+            SUM(CASE WHEN o.created_at >= w.hour_start THEN o.amount_fiat ELSE 0 END)::float as hour_amount,
+            SUM(CASE WHEN o.created_at >= w.day_start THEN 1 ELSE 0 END)::int as day_count,
+            SUM(CASE WHEN o.created_at >= w.day_start THEN o.amount_fiat ELSE 0 END)::float as day_amount,
+            SUM(CASE WHEN o.created_at >= w.week_start THEN 1 ELSE 0 END)::int as week_count,
+            SUM(CASE WHEN o.created_at >= w.week_start THEN o.amount_fiat ELSE 0 END)::float as week_amount,
+            SUM(CASE WHEN o.created_at >= w.month_start THEN 1 ELSE 0 END)::int as month_count,
+            SUM(CASE WHEN o.created_at >= w.month_start THEN o.amount_fiat ELSE 0 END)::float as month_amount,
+            MAX(CASE WHEN o.status = $7 THEN o.created_at END) as last_completed_at
+        FROM order_models o
+        JOIN limit_windows w ON w.bank_detail_id = o.bank_details_id::text
+        WHERE o.status = ANY($8::text[])
+          AND (o.status = $6 OR o.created_at >= LEAST(w.hour_start, w.day_start, w.week_start, w.month_start))
+        GROUP BY w.bank_detail_id
+    `
+
+	var counters []*domain.BankDetailCounters
+	err := r.DB.Raw(sqlQuery,
+		pq.Array(ids),
+		hourStarts,
+		dayStarts,
+		weekStarts,
+		monthStarts,
+		string(domain.StatusPending),
+		string(domain.StatusCompleted),
+		pq.Array([]string{string(domain.StatusPending), string(domain.StatusCompleted)}),
+	).Scan(&counters).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank detail counters: %w", err)
+	}
+
+	return counters, nil
+}
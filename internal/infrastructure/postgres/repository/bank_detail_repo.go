@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/pii"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
 	"github.com/google/uuid"
@@ -47,9 +48,18 @@ func (r *DefaultBankDetailRepo) UpdateBankDetail(bankDetail *domain.BankDetail)
 		return err
 	}
 
+	// Нулевые значения Updates по структуре пропускает: 0 у часовых и недельных лимитов - без ограничения,
+	// пустые режим и пояс - календарные окна в поясе сервера, false у BinMismatch - банк совпал со справочником
 	if err := r.DB.Model(&models.BankDetailModel{}).Where("id = ?", bankDetailModel.ID).Updates(map[string]interface{}{
 		"enabled": bankDetail.Enabled,
 		"delay": bankDetail.Delay,
+		"max_amount_hour": bankDetail.MaxAmountHour,
+		"max_quantity_hour": bankDetail.MaxQuantityHour,
+		"max_amount_week": bankDetail.MaxAmountWeek,
+		"max_quantity_week": bankDetail.MaxQuantityWeek,
+		"limit_mode": string(bankDetail.LimitMode),
+		"timezone": bankDetail.Timezone,
+		"bin_mismatch": bankDetail.BinMismatch,
 	}).Error; err != nil {
 		return err
 	}
@@ -57,6 +67,22 @@ func (r *DefaultBankDetailRepo) UpdateBankDetail(bankDetail *domain.BankDetail)
 	return nil
 }
 
+// SetBankDetailSchedule сохраняет расписание работы реквизита, nil удаляет расписание
+func (r *DefaultBankDetailRepo) SetBankDetailSchedule(bankDetailID string, schedule *domain.Schedule) error {
+	var value interface{}
+	if schedule != nil {
+		value = mappers.ToGORMSchedule(schedule)
+	}
+	result := r.DB.Model(&models.BankDetailModel{}).Where("id = ?", bankDetailID).Update("schedule", value)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 func (r *DefaultBankDetailRepo) DeleteBankDetail(bankDetailID string) error {
 	return r.DB.Where("id = ?", bankDetailID).Delete(&models.BankDetailModel{}).Error
 }
@@ -139,6 +165,7 @@ func (r *DefaultBankDetailRepo) FindSuitableBankDetails(searchQuery *domain.Suit
         return nil, err
     }
     
+    baseCandidates = filterBySchedule(baseCandidates, time.Now())
     log.Printf("Stage 1 (findBaseCandidates): Found %d candidates", len(baseCandidates))
     
     if len(baseCandidates) == 0 {
@@ -168,7 +195,8 @@ func (r *DefaultBankDetailRepo) findBaseCandidates(searchQuery *domain.Suitablle
         Where("min_amount <= ? AND max_amount >= ?", searchQuery.AmountFiat, searchQuery.AmountFiat).
         Where("payment_system = ?", searchQuery.PaymentSystem).
         Where("currency = ?", searchQuery.Currency).
-        Where("deleted_at IS NULL")
+        Where("deleted_at IS NULL").
+        Where("suspended_until IS NULL OR suspended_until <= ?", time.Now())
     
     if searchQuery.BankCode != "" {
         query = query.Where("bank_code = ?", searchQuery.BankCode)
@@ -189,6 +217,17 @@ func (r *DefaultBankDetailRepo) findBaseCandidates(searchQuery *domain.Suitablle
     return baseCandidates, err
 }
 
+// filterBySchedule оставляет реквизиты, в расписание которых попадает now
+func filterBySchedule(bankDetails []models.BankDetailModel, now time.Time) []models.BankDetailModel {
+    filtered := bankDetails[:0]
+    for _, bankDetail := range bankDetails {
+        if mappers.ToDomainSchedule(bankDetail.Schedule).IsAvailable(now) {
+            filtered = append(filtered, bankDetail)
+        }
+    }
+    return filtered
+}
+
 // КАРДИНАЛЬНО ОПТИМИЗИРОВАННЫЙ этап 2 с использованием денормализации
 // Этап 2: Применяем динамические ограничения
 func (r *DefaultBankDetailRepo) applyDynamicConstraintsOptimized(baseCandidates []models.BankDetailModel, searchQuery *domain.SuitablleBankDetailsQuery) ([]*domain.BankDetail, error) {
@@ -198,182 +237,14 @@ func (r *DefaultBankDetailRepo) applyDynamicConstraintsOptimized(baseCandidates
         return []*domain.BankDetail{}, nil
     }
     
-    // Получаем IDs реквизитов (НЕ trader_id!)
-    bankDetailIDs := make([]string, len(baseCandidates))
-    for i, candidate := range baseCandidates {
-        bankDetailIDs[i] = candidate.ID
-    }
-    
-    // log.Printf("Checking dynamic constraints for %d bank details", len(bankDetailIDs))
-    
-    now := time.Now()
-    startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-    startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-    
-    // log.Printf("Time ranges: Now=%s, StartOfDay=%s, StartOfMonth=%s",
-    //     now.Format("2006-01-02 15:04:05"),
-    //     startOfDay.Format("2006-01-02 15:04:05"),
-    //     startOfMonth.Format("2006-01-02 15:04:05"))
-    
-    // ИСПРАВЛЕННЫЙ SQL - группируем по bank_details_id
-    sqlQuery := `
-        WITH bank_detail_stats AS (
-            SELECT 
-                bank_details_id::text as bank_details_id_text,
-                -- Количество активных заказов
-                SUM(CASE WHEN status = $1 THEN 1 ELSE 0 END)::int as pending_count,
-                -- Статистика за день
-                SUM(CASE WHEN status = ANY($2::text[]) AND created_at >= $3 THEN 1 ELSE 0 END)::int as day_count,
-                SUM(CASE WHEN status = ANY($4::text[]) AND created_at >= $5 THEN amount_fiat ELSE 0 END)::float as day_amount,
-                -- Статистика за месяц
-                SUM(CASE WHEN status = ANY($6::text[]) AND created_at >= $7 THEN 1 ELSE 0 END)::int as month_count,
-                SUM(CASE WHEN status = ANY($8::text[]) AND created_at >= $9 THEN amount_fiat ELSE 0 END)::float as month_amount,
-                -- Время последнего завершенного заказа
-                MAX(CASE WHEN status = $10 THEN created_at END) as last_completed_time,
-                -- Проверка на дублирующие заказы
-                SUM(CASE WHEN status = $11 AND amount_fiat = $12 THEN 1 ELSE 0 END)::int as duplicate_count
-            FROM order_models 
-            WHERE bank_details_id::text = ANY($13::text[])
-            GROUP BY bank_details_id
-        ),
-        debug_stats AS (
-            SELECT 
-                bd.id::text as bank_detail_id,
-                bd.trader_id::text as trader_id,
-                bd.card_number,
-                bd.max_orders_simultaneosly,
-                bd.max_quantity_day,
-                bd.max_amount_day,
-                bd.max_quantity_month,
-                bd.max_amount_month,
-                bd.delay,
-                COALESCE(bds.pending_count, 0) as pending_count,
-                COALESCE(bds.day_count, 0) as day_count,
-                COALESCE(bds.day_amount, 0) as day_amount,
-                COALESCE(bds.month_count, 0) as month_count,
-                COALESCE(bds.month_amount, 0) as month_amount,
-                bds.last_completed_time,
-                COALESCE(bds.duplicate_count, 0) as duplicate_count,
-                CASE WHEN COALESCE(bds.pending_count, 0) >= bd.max_orders_simultaneosly THEN 'max_simultaneous' ELSE NULL END as reason_1,
-                CASE WHEN COALESCE(bds.day_count, 0) + 1 > bd.max_quantity_day THEN 'max_day_count' ELSE NULL END as reason_2,
-                CASE WHEN COALESCE(bds.day_amount, 0) + $15 > bd.max_amount_day THEN 'max_day_amount' ELSE NULL END as reason_3,
-                CASE WHEN COALESCE(bds.month_count, 0) + 1 > bd.max_quantity_month THEN 'max_month_count' ELSE NULL END as reason_4,
-                CASE WHEN COALESCE(bds.month_amount, 0) + $16 > bd.max_amount_month THEN 'max_month_amount' ELSE NULL END as reason_5,
-                CASE WHEN bds.last_completed_time IS NOT NULL AND bds.last_completed_time > NOW() - (bd.delay / 1000000000.0) * INTERVAL '1 SECOND' THEN 'delay_not_passed' ELSE NULL END as reason_6,
-                CASE WHEN COALESCE(bds.duplicate_count, 0) > 0 THEN 'duplicate_order' ELSE NULL END as reason_7
-            FROM bank_detail_models bd
-            LEFT JOIN bank_detail_stats bds ON bd.id::text = bds.bank_details_id_text
-            WHERE bd.id::text = ANY($14::text[])
-              AND bd.enabled = true
-              AND bd.deleted_at IS NULL
-        )
-        SELECT * FROM debug_stats
-    `
-    
-    type DebugStats struct {
-        BankDetailID          string
-        TraderID              string
-        CardNumber            string
-        MaxOrdersSimultaneous int32
-        MaxQuantityDay        int32
-        MaxAmountDay          float64
-        MaxQuantityMonth      int32
-        MaxAmountMonth        float64
-        Delay                 time.Duration
-        PendingCount          int
-        DayCount              int
-        DayAmount             float64
-        MonthCount            int
-        MonthAmount           float64
-        LastCompletedTime     *time.Time
-        DuplicateCount        int
-        Reason1               *string
-        Reason2               *string
-        Reason3               *string
-        Reason4               *string
-        Reason5               *string
-        Reason6               *string
-        Reason7               *string
-    }
-    
-    var debugStats []DebugStats
-    
-    pendingCompletedStatuses := []string{string(domain.StatusPending), string(domain.StatusCompleted)}
-    
-    err := r.DB.Raw(sqlQuery,
-        string(domain.StatusPending),           // $1
-        pq.Array(pendingCompletedStatuses),     // $2
-        startOfDay,                             // $3
-        pq.Array(pendingCompletedStatuses),     // $4
-        startOfDay,                             // $5
-        pq.Array(pendingCompletedStatuses),     // $6
-        startOfMonth,                           // $7
-        pq.Array(pendingCompletedStatuses),     // $8
-        startOfMonth,                           // $9
-        string(domain.StatusCompleted),         // $10
-        string(domain.StatusPending),           // $11
-        searchQuery.AmountFiat,                 // $12
-        pq.Array(bankDetailIDs),               // $13 - bank_details_id
-        pq.Array(bankDetailIDs),               // $14 - bank_details_id
-        searchQuery.AmountFiat,                 // $15
-        searchQuery.AmountFiat,                 // $16
-    ).Scan(&debugStats).Error
-    
-    if err != nil {
+    if _, err := r.queryConstraintStats(baseCandidates, searchQuery); err != nil {
         log.Printf("ERROR: Debug stats query failed: %v", err)
         return nil, fmt.Errorf("failed to get debug stats: %w", err)
     }
     
-    finalQuery := `
-        WITH bank_detail_stats AS (
-            SELECT 
-                bank_details_id::text as bank_details_id_text,
-                SUM(CASE WHEN status = $1 THEN 1 ELSE 0 END)::int as pending_count,
-                SUM(CASE WHEN status = ANY($2::text[]) AND created_at >= $3 THEN 1 ELSE 0 END)::int as day_count,
-                SUM(CASE WHEN status = ANY($4::text[]) AND created_at >= $5 THEN amount_fiat ELSE 0 END)::float as day_amount,
-                SUM(CASE WHEN status = ANY($6::text[]) AND created_at >= $7 THEN 1 ELSE 0 END)::int as month_count,
-                SUM(CASE WHEN status = ANY($8::text[]) AND created_at >= $9 THEN amount_fiat ELSE 0 END)::float as month_amount,
-                MAX(CASE WHEN status = $10 THEN created_at END) as last_completed_time,
-                SUM(CASE WHEN status = $11 AND amount_fiat = $12 THEN 1 ELSE 0 END)::int as duplicate_count
-            FROM order_models 
-            WHERE bank_details_id::text = ANY($13::text[])
-            GROUP BY bank_details_id
-        )
-        SELECT bd.* 
-        FROM bank_detail_models bd
-        LEFT JOIN bank_detail_stats bds ON bd.id::text = bds.bank_details_id_text
-        WHERE bd.id::text = ANY($14::text[])
-          AND bd.enabled = true
-          AND bd.deleted_at IS NULL
-          AND COALESCE(bds.pending_count, 0) < bd.max_orders_simultaneosly
-          AND COALESCE(bds.day_count, 0) + 1 <= bd.max_quantity_day
-          AND COALESCE(bds.day_amount, 0) + $15 <= bd.max_amount_day
-          AND COALESCE(bds.month_count, 0) + 1 <= bd.max_quantity_month
-          AND COALESCE(bds.month_amount, 0) + $16 <= bd.max_amount_month
-          AND (bds.last_completed_time IS NULL OR bds.last_completed_time <= NOW() - (bd.delay / 1000000000.0) * INTERVAL '1 SECOND')
-          AND COALESCE(bds.duplicate_count, 0) = 0
-    `
-    
     var finalCandidates []models.BankDetailModel
     
-    err = r.DB.Raw(finalQuery,
-        string(domain.StatusPending),
-        pq.Array(pendingCompletedStatuses),
-        startOfDay,
-        pq.Array(pendingCompletedStatuses),
-        startOfDay,
-        pq.Array(pendingCompletedStatuses),
-        startOfMonth,
-        pq.Array(pendingCompletedStatuses),
-        startOfMonth,
-        string(domain.StatusCompleted),
-        string(domain.StatusPending),
-        searchQuery.AmountFiat,
-        pq.Array(bankDetailIDs),              // $13 - используем bank_details_id
-        pq.Array(bankDetailIDs),              // $14 - используем bank_details_id
-        searchQuery.AmountFiat,
-        searchQuery.AmountFiat,
-    ).Scan(&finalCandidates).Error
+    err := r.DB.Raw(bankDetailsWithinLimitsQuery, bankDetailLimitsArgs(baseCandidates, searchQuery, time.Now())...).Scan(&finalCandidates).Error
     
     if err != nil {
         log.Printf("ERROR: Final query failed: %v", err)
@@ -390,6 +261,133 @@ func (r *DefaultBankDetailRepo) applyDynamicConstraintsOptimized(baseCandidates
     return bankDetails, nil
 }
 
+// bankDetailConstraintStats - счетчики сделок реквизита в окнах его лимитов и причины,
+// по которым он не проходит динамические ограничения
+type bankDetailConstraintStats struct {
+    BankDetailID          string
+    TraderID              string
+    CardNumber            pii.EncryptedString
+    MaxOrdersSimultaneous int32 `gorm:"column:max_orders_simultaneosly"`
+    MaxQuantityHour       int32
+    MaxAmountHour         float64
+    MaxQuantityDay        int32
+    MaxAmountDay          float64
+    MaxQuantityWeek       int32
+    MaxAmountWeek         float64
+    MaxQuantityMonth      int32
+    MaxAmountMonth        float64
+    Delay                 time.Duration
+    PendingCount          int
+    HourCount             int
+    HourAmount            float64
+    DayCount              int
+    DayAmount             float64
+    WeekCount             int
+    WeekAmount            float64
+    MonthCount            int
+    MonthAmount           float64
+    LastCompletedTime     *time.Time
+    DuplicateCount        int
+    Reasons               pq.StringArray `gorm:"type:text[]"`
+}
+
+// reason возвращает первую причину отсева, пустая строка - реквизит проходит ограничения
+func (s *bankDetailConstraintStats) reason() string {
+    if len(s.Reasons) == 0 {
+        return ""
+    }
+    return s.Reasons[0]
+}
+
+// queryConstraintStats считает по реквизитам статистику сделок и причины отсева по лимитам
+func (r *DefaultBankDetailRepo) queryConstraintStats(bankDetails []models.BankDetailModel, searchQuery *domain.SuitablleBankDetailsQuery) ([]bankDetailConstraintStats, error) {
+    var stats []bankDetailConstraintStats
+    err := r.DB.Raw(bankDetailConstraintStatsQuery, bankDetailLimitsArgs(bankDetails, searchQuery, time.Now())...).Scan(&stats).Error
+    if err != nil {
+        return nil, err
+    }
+
+    return stats, nil
+}
+
+// ExplainSuitableBankDetails проходит этапы поиска реквизитов и для каждого реквизита валюты
+// сообщает, на каком этапе и почему он отсеян. Реквизиты, прошедшие статические параметры
+// и лимиты, возвращаются с этапом limits и пустой причиной
+func (r *DefaultBankDetailRepo) ExplainSuitableBankDetails(searchQuery *domain.SuitablleBankDetailsQuery) ([]*domain.BankDetailSelectionCandidate, error) {
+    var bankDetails []models.BankDetailModel
+    err := r.DB.Model(&models.BankDetailModel{}).
+        Where("currency = ?", searchQuery.Currency).
+        Where("deleted_at IS NULL").
+        Find(&bankDetails).Error
+    if err != nil {
+        return nil, err
+    }
+
+    candidates := make([]*domain.BankDetailSelectionCandidate, 0, len(bankDetails))
+    byID := make(map[string]*domain.BankDetailSelectionCandidate)
+    var staticPassed []models.BankDetailModel
+    for i := range bankDetails {
+        candidate := &domain.BankDetailSelectionCandidate{
+            BankDetailID: bankDetails[i].ID,
+            TraderID:     bankDetails[i].TraderID,
+            Stage:        domain.SelectionStageStatic,
+            Reason:       staticMismatchReason(&bankDetails[i], searchQuery),
+        }
+        candidate.Eliminated = candidate.Reason != ""
+        if !candidate.Eliminated {
+            candidate.Stage = domain.SelectionStageLimits
+            staticPassed = append(staticPassed, bankDetails[i])
+            byID[candidate.BankDetailID] = candidate
+        }
+        candidates = append(candidates, candidate)
+    }
+
+    if len(staticPassed) == 0 {
+        return candidates, nil
+    }
+
+    stats, err := r.queryConstraintStats(staticPassed, searchQuery)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get constraint stats: %w", err)
+    }
+    for i := range stats {
+        candidate, ok := byID[stats[i].BankDetailID]
+        if !ok {
+            continue
+        }
+        if reason := stats[i].reason(); reason != "" {
+            candidate.Reason = reason
+            candidate.Eliminated = true
+        }
+    }
+
+    return candidates, nil
+}
+
+// staticMismatchReason повторяет условия findBaseCandidates, пустая строка - реквизит подходит
+func staticMismatchReason(bankDetail *models.BankDetailModel, searchQuery *domain.SuitablleBankDetailsQuery) string {
+    switch {
+    case !bankDetail.Enabled:
+        return "disabled"
+    case bankDetail.SuspendedUntil != nil && time.Now().Before(*bankDetail.SuspendedUntil):
+        return "suspended"
+    case !mappers.ToDomainSchedule(bankDetail.Schedule).IsAvailable(time.Now()):
+        return "outside_schedule"
+    case float64(bankDetail.MinAmount) > searchQuery.AmountFiat:
+        return "amount_below_min"
+    case float64(bankDetail.MaxAmount) < searchQuery.AmountFiat:
+        return "amount_above_max"
+    case bankDetail.PaymentSystem != searchQuery.PaymentSystem:
+        return "payment_system_mismatch"
+    case searchQuery.BankCode != "" && bankDetail.BankCode != searchQuery.BankCode:
+        return "bank_code_mismatch"
+    case searchQuery.NspkCode != "" && bankDetail.NspkCode != searchQuery.NspkCode:
+        return "nspk_code_mismatch"
+    default:
+        return ""
+    }
+}
+
 
 func (r *DefaultBankDetailRepo) GetBankDetailsStatsByTraderID(traderID string) ([]*domain.BankDetailStat, error) {
 	var bankDetails []models.BankDetailModel
@@ -398,12 +396,13 @@ func (r *DefaultBankDetailRepo) GetBankDetailsStatsByTraderID(traderID string) (
 		return nil, err
 	}
 
-	today := time.Now().Truncate(24 * time.Hour)
-	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
-
+	now := time.Now()
 	stats := make([]*domain.BankDetailStat, 0, len(bankDetails))
 
 	for _, bd := range bankDetails {
+		// Сутки и месяц - окна лимитов реквизита, как при подборе
+		windows := limitWindowsOf(&bd, now)
+		today, monthStart := windows.DayStart, windows.MonthStart
 		var dayCount, monthCount int64
 		var daySum, monthSum float64
 
@@ -500,6 +499,7 @@ func (r *DefaultBankDetailRepo) FindSuitableBankDetailsWithLock(searchQuery *dom
         return nil, err
     }
     
+    baseCandidates = filterBySchedule(baseCandidates, time.Now())
     log.Printf("Stage 1 (findBaseCandidatesWithLock): Found %d candidates", len(baseCandidates))
     
     if len(baseCandidates) == 0 {
@@ -528,7 +528,8 @@ func (r *DefaultBankDetailRepo) findBaseCandidatesWithLock(tx *gorm.DB, searchQu
         Where("min_amount <= ? AND max_amount >= ?", searchQuery.AmountFiat, searchQuery.AmountFiat).
         Where("payment_system = ?", searchQuery.PaymentSystem).
         Where("currency = ?", searchQuery.Currency).
-        Where("deleted_at IS NULL")
+        Where("deleted_at IS NULL").
+        Where("suspended_until IS NULL OR suspended_until <= ?", time.Now())
     
     if searchQuery.BankCode != "" {
         query = query.Where("bank_code = ?", searchQuery.BankCode)
@@ -554,68 +555,9 @@ func (r *DefaultBankDetailRepo) applyDynamicConstraintsWithLock(tx *gorm.DB, ban
         return []*domain.BankDetail{}, nil
     }
     
-    bankDetailIDs := make([]string, len(bankDetails))
-    for i, candidate := range bankDetails {
-        bankDetailIDs[i] = candidate.ID
-    }
-    
-    now := time.Now()
-    startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-    startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-    
-    // SQL запрос для проверки лимитов с ДОБАВЛЕНИЕМ проверки на дубликаты
-    sqlQuery := `
-        WITH bank_detail_stats AS (
-            SELECT 
-                bank_details_id::text as bank_details_id_text,
-                COUNT(CASE WHEN status = $1 THEN 1 END) as pending_count,
-                COUNT(CASE WHEN status = ANY($2::text[]) AND created_at >= $3 THEN 1 END) as day_count,
-                COALESCE(SUM(CASE WHEN status = ANY($4::text[]) AND created_at >= $5 THEN amount_fiat END), 0) as day_amount,
-                COUNT(CASE WHEN status = ANY($6::text[]) AND created_at >= $7 THEN 1 END) as month_count,
-                COALESCE(SUM(CASE WHEN status = ANY($8::text[]) AND created_at >= $9 THEN amount_fiat END), 0) as month_amount,
-                MAX(CASE WHEN status = $10 THEN created_at END) as last_completed_time,
-                -- ВОССТАНАВЛИВАЕМ проверку на дубликаты
-                COUNT(CASE WHEN status = $11 AND amount_fiat = $12 THEN 1 END) as duplicate_count
-            FROM order_models 
-            WHERE bank_details_id::text = ANY($13::text[])
-            GROUP BY bank_details_id
-        )
-        SELECT bd.* 
-        FROM bank_detail_models bd
-        LEFT JOIN bank_detail_stats bds ON bd.id::text = bds.bank_details_id_text
-        WHERE bd.id::text = ANY($14::text[])
-          AND COALESCE(bds.pending_count, 0) < bd.max_orders_simultaneosly
-          AND COALESCE(bds.day_count, 0) + 1 <= bd.max_quantity_day
-          AND COALESCE(bds.day_amount, 0) + $15 <= bd.max_amount_day
-          AND COALESCE(bds.month_count, 0) + 1 <= bd.max_quantity_month
-          AND COALESCE(bds.month_amount, 0) + $16 <= bd.max_amount_month
-          AND (bds.last_completed_time IS NULL OR bds.last_completed_time <= NOW() - (bd.delay / 1000000000.0) * INTERVAL '1 SECOND')
-          -- ВОССТАНАВЛИВАЕМ условие по дубликатам
-          AND COALESCE(bds.duplicate_count, 0) = 0
-    `
-    
     var finalCandidates []models.BankDetailModel
     
-    pendingCompletedStatuses := []string{string(domain.StatusPending), string(domain.StatusCompleted)}
-    
-    err := tx.Raw(sqlQuery,
-        string(domain.StatusPending),           // $1
-        pq.Array(pendingCompletedStatuses),     // $2
-        startOfDay,                             // $3
-        pq.Array(pendingCompletedStatuses),     // $4
-        startOfDay,                             // $5
-        pq.Array(pendingCompletedStatuses),     // $6
-        startOfMonth,                           // $7
-        pq.Array(pendingCompletedStatuses),     // $8
-        startOfMonth,                           // $9
-        string(domain.StatusCompleted),         // $10
-        string(domain.StatusPending),           // $11 - статус для проверки дубликатов
-        searchQuery.AmountFiat,                 // $12 - сумма для проверки дубликатов
-        pq.Array(bankDetailIDs),                // $13
-        pq.Array(bankDetailIDs),                // $14
-        searchQuery.AmountFiat,                 // $15
-        searchQuery.AmountFiat,                 // $16
-    ).Scan(&finalCandidates).Error
+    err := tx.Raw(bankDetailsWithinLimitsQuery, bankDetailLimitsArgs(bankDetails, searchQuery, time.Now())...).Scan(&finalCandidates).Error
     
     if err != nil {
         log.Printf("ERROR: Final query with lock failed: %v", err)
@@ -657,7 +599,8 @@ func (r *DefaultBankDetailRepo) FindSuitableBankDetailsInTx(searchQuery *domain.
         Where("min_amount <= ? AND max_amount >= ?", searchQuery.AmountFiat, searchQuery.AmountFiat).
         Where("payment_system = ?", searchQuery.PaymentSystem).
         Where("currency = ?", searchQuery.Currency).
-        Where("deleted_at IS NULL")
+        Where("deleted_at IS NULL").
+        Where("suspended_until IS NULL OR suspended_until <= ?", time.Now())
     
     if searchQuery.BankCode != "" {
         query = query.Where("bank_code = ?", searchQuery.BankCode)
@@ -674,6 +617,7 @@ func (r *DefaultBankDetailRepo) FindSuitableBankDetailsInTx(searchQuery *domain.
         return nil, err
     }
     
+    baseCandidates = filterBySchedule(baseCandidates, time.Now())
     log.Printf("Stage 1 (findBaseCandidates with lock): Found %d candidates", len(baseCandidates))
     
     if len(baseCandidates) == 0 {
@@ -694,73 +638,57 @@ func (r *DefaultBankDetailRepo) FindSuitableBankDetailsInTx(searchQuery *domain.
     return finalCandidates, nil
 }
 
+// LockBankDetailIfEligibleInTx блокирует один реквизит и проверяет его заново. Нужен, когда реквизит
+// выбран без блокировки (каскад) или вне транзакции подбора (перенос сделки после сбоя заморозки):
+// под блокировкой параллельные сделки не превысят лимиты и число одновременных сделок реквизита
+func (r *DefaultBankDetailRepo) LockBankDetailIfEligibleInTx(bankDetailID string, searchQuery *domain.SuitablleBankDetailsQuery) (*domain.BankDetail, error) {
+    var candidates []models.BankDetailModel
+
+    query := r.DB.Model(&models.BankDetailModel{}).
+        Where("id = ?", bankDetailID).
+        Where("enabled = ?", true).
+        Where("min_amount <= ? AND max_amount >= ?", searchQuery.AmountFiat, searchQuery.AmountFiat).
+        Where("payment_system = ?", searchQuery.PaymentSystem).
+        Where("currency = ?", searchQuery.Currency).
+        Where("deleted_at IS NULL").
+        Where("suspended_until IS NULL OR suspended_until <= ?", time.Now())
+
+    if searchQuery.BankCode != "" {
+        query = query.Where("bank_code = ?", searchQuery.BankCode)
+    }
+
+    if searchQuery.NspkCode != "" {
+        query = query.Where("nspk_code = ?", searchQuery.NspkCode)
+    }
+
+    if err := query.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&candidates).Error; err != nil {
+        return nil, fmt.Errorf("failed to lock bank detail: %w", err)
+    }
+
+    candidates = filterBySchedule(candidates, time.Now())
+    if len(candidates) == 0 {
+        return nil, nil
+    }
+
+    eligible, err := r.applyDynamicConstraintsInTx(candidates, searchQuery)
+    if err != nil {
+        return nil, err
+    }
+    if len(eligible) == 0 {
+        return nil, nil
+    }
+    return eligible[0], nil
+}
+
 // applyDynamicConstraintsInTx применяет динамические ограничения в транзакции
 func (r *DefaultBankDetailRepo) applyDynamicConstraintsInTx(baseCandidates []models.BankDetailModel, searchQuery *domain.SuitablleBankDetailsQuery) ([]*domain.BankDetail, error) {
     if len(baseCandidates) == 0 {
         return []*domain.BankDetail{}, nil
     }
     
-    bankDetailIDs := make([]string, len(baseCandidates))
-    for i, candidate := range baseCandidates {
-        bankDetailIDs[i] = candidate.ID
-    }
-    
-    now := time.Now()
-    startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-    startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-    
-    finalQuery := `
-        WITH bank_detail_stats AS (
-            SELECT 
-                bank_details_id::text as bank_details_id_text,
-                COUNT(CASE WHEN status = $1 THEN 1 END) as pending_count,
-                COUNT(CASE WHEN status = ANY($2::text[]) AND created_at >= $3 THEN 1 END) as day_count,
-                COALESCE(SUM(CASE WHEN status = ANY($4::text[]) AND created_at >= $5 THEN amount_fiat END), 0) as day_amount,
-                COUNT(CASE WHEN status = ANY($6::text[]) AND created_at >= $7 THEN 1 END) as month_count,
-                COALESCE(SUM(CASE WHEN status = ANY($8::text[]) AND created_at >= $9 THEN amount_fiat END), 0) as month_amount,
-                MAX(CASE WHEN status = $10 THEN created_at END) as last_completed_time,
-                -- ВОССТАНАВЛИВАЕМ проверку на дубликаты
-                COUNT(CASE WHEN status = $11 AND amount_fiat = $12 THEN 1 END) as duplicate_count
-            FROM order_models 
-            WHERE bank_details_id::text = ANY($13::text[])
-            GROUP BY bank_details_id
-        )
-        SELECT bd.* 
-        FROM bank_detail_models bd
-        LEFT JOIN bank_detail_stats bds ON bd.id::text = bds.bank_details_id_text
-        WHERE bd.id::text = ANY($14::text[])
-          AND COALESCE(bds.pending_count, 0) < bd.max_orders_simultaneosly
-          AND COALESCE(bds.day_count, 0) + 1 <= bd.max_quantity_day
-          AND COALESCE(bds.day_amount, 0) + $15 <= bd.max_amount_day
-          AND COALESCE(bds.month_count, 0) + 1 <= bd.max_quantity_month
-          AND COALESCE(bds.month_amount, 0) + $16 <= bd.max_amount_month
-          AND (bds.last_completed_time IS NULL OR bds.last_completed_time <= NOW() - (bd.delay / 1000000000.0) * INTERVAL '1 SECOND')
-          -- ВОССТАНАВЛИВАЕМ условие по дубликатам
-          AND COALESCE(bds.duplicate_count, 0) = 0
-    `
-    
     var finalCandidates []models.BankDetailModel
     
-    pendingCompletedStatuses := []string{string(domain.StatusPending), string(domain.StatusCompleted)}
-    
-    err := r.DB.Raw(finalQuery,
-        string(domain.StatusPending),           // $1
-        pq.Array(pendingCompletedStatuses),     // $2
-        startOfDay,                             // $3
-        pq.Array(pendingCompletedStatuses),     // $4
-        startOfDay,                             // $5
-        pq.Array(pendingCompletedStatuses),     // $6
-        startOfMonth,                           // $7
-        pq.Array(pendingCompletedStatuses),     // $8
-        startOfMonth,                           // $9
-        string(domain.StatusCompleted),         // $10
-        string(domain.StatusPending),           // $11 - статус для проверки дубликатов
-        searchQuery.AmountFiat,                 // $12 - сумма для проверки дубликатов
-        pq.Array(bankDetailIDs),                // $13
-        pq.Array(bankDetailIDs),                // $14
-        searchQuery.AmountFiat,                 // $15
-        searchQuery.AmountFiat,                 // $16
-    ).Scan(&finalCandidates).Error
+    err := r.DB.Raw(bankDetailsWithinLimitsQuery, bankDetailLimitsArgs(baseCandidates, searchQuery, time.Now())...).Scan(&finalCandidates).Error
     
     if err != nil {
         log.Printf("ERROR: Final query in tx failed: %v", err)
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DefaultBinRepository struct {
+	DB *gorm.DB
+}
+
+func NewDefaultBinRepository(db *gorm.DB) *DefaultBinRepository {
+	return &DefaultBinRepository{DB: db}
+}
+
+func (r *DefaultBinRepository) FindBin(cardNumber string) (*domain.BinRecord, error) {
+	prefixes := domain.BinCandidates(cardNumber)
+	if len(prefixes) == 0 {
+		return nil, nil
+	}
+
+	var binModel models.BinModel
+	err := r.DB.
+		Where("prefix IN ?", prefixes).
+		Order("length(prefix) DESC").
+		First(&binModel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find bin: %w", err)
+	}
+	return mappers.ToDomainBin(&binModel), nil
+}
+
+func (r *DefaultBinRepository) UpsertBins(records []*domain.BinRecord) (int, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+	binModels := make([]*models.BinModel, len(records))
+	for i, record := range records {
+		binModels[i] = mappers.ToGORMBin(record)
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "prefix"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"bank_name", "bank_code", "nspk_code", "payment_network", "country", "updated_at",
+		}),
+	}).CreateInBatches(binModels, 500).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to upsert bins: %w", err)
+	}
+	return len(binModels), nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DefaultCallbackDeliveryRepository struct {
+	DB *gorm.DB
+}
+
+func NewDefaultCallbackDeliveryRepository(db *gorm.DB) *DefaultCallbackDeliveryRepository {
+	return &DefaultCallbackDeliveryRepository{DB: db}
+}
+
+func (r *DefaultCallbackDeliveryRepository) CreateCallbackDelivery(delivery *domain.CallbackDelivery) error {
+	return saveCallbackDelivery(r.DB, delivery)
+}
+
+// saveCallbackDelivery ставит колбэк в очередь доставки в рамках переданной транзакции
+func saveCallbackDelivery(tx *gorm.DB, delivery *domain.CallbackDelivery) error {
+	if delivery.ID == "" {
+		delivery.ID = uuid.New().String()
+	}
+	now := time.Now()
+	if delivery.NextAttemptAt.IsZero() {
+		delivery.NextAttemptAt = now
+	}
+	delivery.Status = domain.CallbackPending
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+
+	if err := tx.Create(mappers.ToGORMCallbackDelivery(delivery)).Error; err != nil {
+		return fmt.Errorf("failed to save callback delivery: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueCallbackDeliveries, как и ClaimPendingOutboxEvents, пропускает строки, заблокированные другим
+// экземпляром (SKIP LOCKED), а выбранные откладывает на lease - если экземпляр упадет, не отправив их,
+// колбэки заберет другой после истечения lease
+func (r *DefaultCallbackDeliveryRepository) ClaimDueCallbackDeliveries(limit int, lease time.Duration) ([]*domain.CallbackDelivery, error) {
+	var deliveryModels []models.CallbackDeliveryModel
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		// Сохраняем порядок статусов для мерчанта: COMPLETED не уйдет раньше недоставленного CREATED
+		if err := tx.Raw(`
+			SELECT cd.* FROM callback_deliveries cd
+			WHERE cd.status = ? AND cd.next_attempt_at <= ?
+			  AND NOT EXISTS (
+				SELECT 1 FROM callback_deliveries prev
+				WHERE prev.merchant_order_id = cd.merchant_order_id
+				  AND prev.callback_url = cd.callback_url
+				  AND prev.status = ?
+				  AND prev.created_at < cd.created_at
+			  )
+			ORDER BY cd.created_at ASC
+			LIMIT ?
+			FOR UPDATE OF cd SKIP LOCKED`,
+			domain.CallbackPending, now, domain.CallbackPending, limit,
+		).Scan(&deliveryModels).Error; err != nil {
+			return err
+		}
+		if len(deliveryModels) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(deliveryModels))
+		for i, deliveryModel := range deliveryModels {
+			ids[i] = deliveryModel.ID
+		}
+		return tx.Model(&models.CallbackDeliveryModel{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim callback deliveries: %w", err)
+	}
+
+	deliveries := make([]*domain.CallbackDelivery, len(deliveryModels))
+	for i, deliveryModel := range deliveryModels {
+		deliveries[i] = mappers.ToDomainCallbackDelivery(&deliveryModel)
+	}
+	return deliveries, nil
+}
+
+func (r *DefaultCallbackDeliveryRepository) UpdateCallbackDelivery(delivery *domain.CallbackDelivery) error {
+	delivery.UpdatedAt = time.Now()
+	return r.DB.Model(&models.CallbackDeliveryModel{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"status":          delivery.Status,
+			"attempts":        delivery.Attempts,
+			"last_http_code":  delivery.LastHTTPCode,
+			"last_error":      delivery.LastError,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"delivered_at":    delivery.DeliveredAt,
+			"updated_at":      delivery.UpdatedAt,
+		}).Error
+}
+
+func (r *DefaultCallbackDeliveryRepository) GetCallbackDeliveryByID(deliveryID string) (*domain.CallbackDelivery, error) {
+	var deliveryModel models.CallbackDeliveryModel
+	if err := r.DB.Where("id = ?", deliveryID).First(&deliveryModel).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainCallbackDelivery(&deliveryModel), nil
+}
+
+// GetCallbackDeliveriesByOrder возвращает колбэки по сделке, включая отправленные до ее создания
+func (r *DefaultCallbackDeliveryRepository) GetCallbackDeliveriesByOrder(orderID, merchantOrderID string) ([]*domain.CallbackDelivery, error) {
+	var deliveryModels []models.CallbackDeliveryModel
+	if err := r.DB.
+		Where("order_id = ? OR (order_id = '' AND merchant_order_id = ?)", orderID, merchantOrderID).
+		Order("created_at ASC").
+		Find(&deliveryModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to get callback deliveries: %w", err)
+	}
+
+	deliveries := make([]*domain.CallbackDelivery, len(deliveryModels))
+	for i, deliveryModel := range deliveryModels {
+		deliveries[i] = mappers.ToDomainCallbackDelivery(&deliveryModel)
+	}
+	return deliveries, nil
+}
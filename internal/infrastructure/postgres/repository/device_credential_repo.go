@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DefaultDeviceCredentialRepository struct {
+	DB *gorm.DB
+}
+
+func NewDefaultDeviceCredentialRepository(db *gorm.DB) *DefaultDeviceCredentialRepository {
+	return &DefaultDeviceCredentialRepository{DB: db}
+}
+
+func (r *DefaultDeviceCredentialRepository) CreateDeviceCredential(credential *domain.DeviceCredential) error {
+	if err := r.DB.Create(mappers.ToGORMDeviceCredential(credential)).Error; err != nil {
+		return fmt.Errorf("failed to create device credential: %w", err)
+	}
+	return nil
+}
+
+func (r *DefaultDeviceCredentialRepository) GetActiveDeviceCredentials(deviceID string, now time.Time) ([]*domain.DeviceCredential, error) {
+	var credentialModels []models.DeviceCredentialModel
+	err := r.DB.
+		Where("device_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", deviceID, now).
+		Order("created_at DESC").
+		Find(&credentialModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device credentials: %w", err)
+	}
+
+	credentials := make([]*domain.DeviceCredential, len(credentialModels))
+	for i := range credentialModels {
+		credentials[i] = mappers.ToDomainDeviceCredential(&credentialModels[i])
+	}
+	return credentials, nil
+}
+
+// ExpireDeviceCredentials не продлевает секреты, срок которых уже заканчивается раньше expiresAt
+func (r *DefaultDeviceCredentialRepository) ExpireDeviceCredentials(deviceID string, expiresAt time.Time) error {
+	err := r.DB.Model(&models.DeviceCredentialModel{}).
+		Where("device_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", deviceID, expiresAt).
+		Update("expires_at", expiresAt).Error
+	if err != nil {
+		return fmt.Errorf("failed to expire device credentials: %w", err)
+	}
+	return nil
+}
+
+func (r *DefaultDeviceCredentialRepository) RevokeDeviceCredentials(deviceID string, revokedAt time.Time) error {
+	err := r.DB.Model(&models.DeviceCredentialModel{}).
+		Where("device_id = ? AND revoked_at IS NULL", deviceID).
+		Update("revoked_at", revokedAt).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke device credentials: %w", err)
+	}
+	return nil
+}
+
+func (r *DefaultDeviceCredentialRepository) SaveDeviceAuthFailure(failure *domain.DeviceAuthFailure) error {
+	if err := r.DB.Create(mappers.ToGORMDeviceAuthFailure(failure)).Error; err != nil {
+		return fmt.Errorf("failed to save device auth failure: %w", err)
+	}
+	return nil
+}
+
+func (r *DefaultDeviceCredentialRepository) SaveDeviceRequestNonce(deviceID, signature string, expiresAt time.Time) (bool, error) {
+	result := r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.DeviceRequestNonceModel{
+		DeviceID:  deviceID,
+		Signature: signature,
+		ExpiresAt: expiresAt,
+	})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to save device request nonce: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *DefaultDeviceCredentialRepository) DeleteExpiredDeviceRequestNonces(now time.Time) (int64, error) {
+	result := r.DB.Where("expires_at < ?", now).Delete(&models.DeviceRequestNonceModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired device request nonces: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -88,6 +89,9 @@ func (r *DefaultDeviceRepository) GetDeviceByID(deviceID string) (*domain.Device
     var device models.DeviceModel
     err := r.DB.Where("id = ?", deviceID).First(&device).Error
     if err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil, domain.ErrDeviceNotFound
+        }
         return nil, err
     }
     
@@ -18,16 +18,31 @@ func NewDefaultDisputeRepository(db *gorm.DB) *DefaultDisputeRepository {
 	return &DefaultDisputeRepository{db: db}
 }
 
+// Соответствие операций над диспутом операциям над сделкой
+var disputeOrderOperations = map[string]string{
+	"accept": domain.OrderOpDisputeAccept,
+	"reject": domain.OrderOpDisputeReject,
+	"freeze": domain.OrderOpDisputeFreeze,
+}
+
 // ProcessOrderCriticalOperation - выполнение критичной операции в транзакции
 func (r *DefaultDisputeRepository) ProcessDisputeCriticalOperation(
     disputeID string,
 	orderID string,
     newDisputeStatus domain.DisputeStatus,
-	newOrderStatus domain.OrderStatus,
+	oldOrderStatus, newOrderStatus domain.OrderStatus,
 	newOrderAmountFiat, newOrderAmountCrypto, newOrderAmountCryptoRate float64,
     operation string, // добавляем параметр операции
+    actor, reason string,
+    events []*domain.OutboxEvent,
+    callback *domain.CallbackDelivery,
     walletFunc func() error,
 ) error {
+    orderOperation, ok := disputeOrderOperations[operation]
+    if !ok {
+        return fmt.Errorf("%w: unknown dispute operation %q", domain.ErrInvalidStatusTransition, operation)
+    }
+
     tx := r.db.Begin()
     defer func() {
         if r := recover(); r != nil {
@@ -36,47 +51,78 @@ func (r *DefaultDisputeRepository) ProcessDisputeCriticalOperation(
         }
     }()
 
-    if err := tx.Model(&models.DisputeModel{}).Where("id = ?", disputeID).Update("status", newDisputeStatus).Error; err != nil {
+    // Читаем текущее состояние сделки и проверяем допустимость перехода ее статуса
+    var currentOrder models.OrderModel
+    if err := tx.Select("id", "status", "version").
+        First(&currentOrder, "id = ?", orderID).Error; err != nil {
         tx.Rollback()
-        return fmt.Errorf("failed to update dispute status: %w", err)
+        return fmt.Errorf("failed to get order: %w", err)
     }
-
-	if operation == "accept" {
-		if err := tx.Model(&models.OrderModel{}).Where("id = ?", orderID).Updates(map[string]interface{}{
-			"amount_fiat": newOrderAmountFiat,
-			"amount_crypto": newOrderAmountCrypto,
-			"crypto_rub_rate": newOrderAmountCryptoRate,
-            "status": newOrderStatus,
-		}).Error; err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to update order amount values: %w", err)
-		}
-
-        if err := tx.Model(&models.DisputeModel{}).Where("id = ?", disputeID).Update("order_status_disputed", newOrderStatus).Error; err != nil {
+    if oldOrderStatus != "" && currentOrder.Status != oldOrderStatus {
+        tx.Rollback()
+        return fmt.Errorf("%w: expected status %s, got %s", domain.ErrConcurrentModification, oldOrderStatus, currentOrder.Status)
+    }
+    if orderOperation == domain.OrderOpDisputeReject {
+        var dispute models.DisputeModel
+        if err := tx.Select("id", "order_status_original").First(&dispute, "id = ?", disputeID).Error; err != nil {
             tx.Rollback()
-            return fmt.Errorf("failed to update order_status_disputed field in dispute model: %w", err)
+            return fmt.Errorf("failed to get dispute: %w", err)
         }
-	}else if operation == "reject" {
-        if err := tx.Model(&models.OrderModel{}).Where("id = ?", orderID).Updates(map[string]interface{}{
-            "status": newOrderStatus,
-		}).Error; err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to update order amount values: %w", err)
-		}
-        if err := tx.Model(&models.DisputeModel{}).Where("id = ?", disputeID).Update("order_status_disputed", newOrderStatus).Error; err != nil {
+        if err := orderStateMachine.ValidateDisputeReject(currentOrder.Status, newOrderStatus, domain.OrderStatus(dispute.OrderStatusOriginal)); err != nil {
             tx.Rollback()
-            return fmt.Errorf("failed to update order_status_disputed field in dispute model: %w", err)
+            return err
         }
-    }else if operation == "freeze" {
-        if err := tx.Model(&models.OrderModel{}).Where("id = ?", orderID).Updates(map[string]interface{}{
-            "status": newOrderStatus,
-		}).Error; err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to update order amount values: %w", err)
-		}
-        if err := tx.Model(&models.DisputeModel{}).Where("id = ?", disputeID).Update("order_status_disputed", newOrderStatus).Error; err != nil {
+    } else if err := orderStateMachine.Validate(orderOperation, currentOrder.Status, newOrderStatus); err != nil {
+        tx.Rollback()
+        return err
+    }
+
+    if err := tx.Model(&models.DisputeModel{}).Where("id = ?", disputeID).Update("status", newDisputeStatus).Error; err != nil {
+        tx.Rollback()
+        return fmt.Errorf("failed to update dispute status: %w", err)
+    }
+
+    // При принятии диспута вместе со статусом обновляются суммы сделки
+    var orderUpdates map[string]interface{}
+    if operation == "accept" {
+        orderUpdates = map[string]interface{}{
+            "amount_fiat": newOrderAmountFiat,
+            "amount_crypto": newOrderAmountCrypto,
+            "crypto_rub_rate": newOrderAmountCryptoRate,
+        }
+    }
+
+    // Compare-and-swap: параллельная операция над той же сделкой получит ErrConcurrentModification
+    if err := casOrderStatus(tx, orderID, currentOrder.Status, currentOrder.Version, newOrderStatus, orderUpdates); err != nil {
+        tx.Rollback()
+        return err
+    }
+
+    if err := tx.Model(&models.DisputeModel{}).Where("id = ?", disputeID).Update("order_status_disputed", newOrderStatus).Error; err != nil {
+        tx.Rollback()
+        return fmt.Errorf("failed to update order_status_disputed field in dispute model: %w", err)
+    }
+
+    if err := saveStatusTransition(tx, &domain.OrderStatusTransition{
+        OrderID:    orderID,
+        FromStatus: currentOrder.Status,
+        ToStatus:   newOrderStatus,
+        Actor:      actor,
+        Operation:  orderOperation,
+        Reason:     reason,
+    }); err != nil {
+        tx.Rollback()
+        return err
+    }
+
+    if err := saveOutboxEvents(tx, events); err != nil {
+        tx.Rollback()
+        return err
+    }
+    if callback != nil {
+        if err := saveCallbackDelivery(tx, callback); err != nil {
             tx.Rollback()
-            return fmt.Errorf("failed to update order_status_disputed field in dispute model: %w", err)
+            return err
         }
     }
 
@@ -113,6 +159,35 @@ func (r *DefaultDisputeRepository) CreateDispute(dispute *domain.Dispute) error
 	return nil
 }
 
+// OpenDispute создает диспут и переводит сделку в DISPUTE_CREATED в одной транзакции. walletFunc
+// (заморозка суммы диспута) выполняется последней: если переход отклонен, деньги трейдера
+// не замораживаются, а если не удалась заморозка, не остается ни диспута, ни смены статуса
+func (r *DefaultDisputeRepository) OpenDispute(
+    dispute *domain.Dispute,
+    transition *domain.OrderStatusTransition,
+    events []*domain.OutboxEvent,
+    walletFunc func() error,
+) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        disputeModel := mappers.ToGORMDispute(dispute)
+        if err := tx.Create(disputeModel).Error; err != nil {
+            return fmt.Errorf("failed to create dispute: %w", err)
+        }
+        dispute.ID = disputeModel.ID
+
+        if err := applyOrderTransition(tx, transition, nil, events); err != nil {
+            return err
+        }
+
+        if walletFunc != nil {
+            if err := walletFunc(); err != nil {
+                return fmt.Errorf("wallet operation failed: %w", err)
+            }
+        }
+        return nil
+    })
+}
+
 func (r *DefaultDisputeRepository) UpdateDisputeStatus(disputeID string, status domain.DisputeStatus) error {
 	return r.db.Model(&models.DisputeModel{ID: disputeID}).Update("status", status).Error
 }
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DefaultMerchantCallbackSettingsRepository struct {
+	DB *gorm.DB
+}
+
+func NewDefaultMerchantCallbackSettingsRepository(db *gorm.DB) *DefaultMerchantCallbackSettingsRepository {
+	return &DefaultMerchantCallbackSettingsRepository{DB: db}
+}
+
+func (r *DefaultMerchantCallbackSettingsRepository) GetMerchantCallbackSettings(merchantID string) (*domain.MerchantCallbackSettings, error) {
+	var settingsModel models.MerchantCallbackSettingsModel
+	if err := r.DB.Where("merchant_id = ?", merchantID).First(&settingsModel).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainMerchantCallbackSettings(&settingsModel), nil
+}
+
+func (r *DefaultMerchantCallbackSettingsRepository) SaveMerchantCallbackSettings(settings *domain.MerchantCallbackSettings) error {
+	now := time.Now()
+	if settings.CreatedAt.IsZero() {
+		settings.CreatedAt = now
+	}
+	settings.UpdatedAt = now
+
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "merchant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"format", "secret", "updated_at"}),
+	}).Create(mappers.ToGORMMerchantCallbackSettings(settings)).Error
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DefaultMerchantMatchingSettingsRepository struct {
+	DB *gorm.DB
+}
+
+func NewDefaultMerchantMatchingSettingsRepository(db *gorm.DB) *DefaultMerchantMatchingSettingsRepository {
+	return &DefaultMerchantMatchingSettingsRepository{DB: db}
+}
+
+func (r *DefaultMerchantMatchingSettingsRepository) GetMerchantMatchingSettings(merchantID string) (*domain.MerchantMatchingSettings, error) {
+	var settingsModel models.MerchantMatchingSettingsModel
+	if err := r.DB.Where("merchant_id = ?", merchantID).First(&settingsModel).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainMerchantMatchingSettings(&settingsModel), nil
+}
+
+func (r *DefaultMerchantMatchingSettingsRepository) SaveMerchantMatchingSettings(settings *domain.MerchantMatchingSettings) error {
+	now := time.Now()
+	if settings.CreatedAt.IsZero() {
+		settings.CreatedAt = now
+	}
+	settings.UpdatedAt = now
+
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "merchant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"wait_for_requisites", "wait_window_seconds", "selection_strategy", "updated_at"}),
+	}).Create(mappers.ToGORMMerchantMatchingSettings(settings)).Error
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"gorm.io/gorm"
+)
+
+type DefaultNotificationTemplateRepository struct {
+	DB *gorm.DB
+}
+
+func NewDefaultNotificationTemplateRepository(db *gorm.DB) *DefaultNotificationTemplateRepository {
+	return &DefaultNotificationTemplateRepository{DB: db}
+}
+
+func (r *DefaultNotificationTemplateRepository) CreateNotificationTemplate(template *domain.NotificationTemplate) error {
+	if err := r.DB.Create(mappers.ToGORMNotificationTemplate(template)).Error; err != nil {
+		return fmt.Errorf("failed to create notification template: %w", err)
+	}
+	return nil
+}
+
+// UpdateNotificationTemplate перезаписывает шаблон целиком, включая выключение и пустые образцы
+func (r *DefaultNotificationTemplateRepository) UpdateNotificationTemplate(template *domain.NotificationTemplate) error {
+	model := mappers.ToGORMNotificationTemplate(template)
+	result := r.DB.Model(&models.NotificationTemplateModel{}).
+		Where("id = ?", template.ID).
+		Updates(map[string]interface{}{
+			"bank_code":        model.BankCode,
+			"name":             model.Name,
+			"pattern":          model.Pattern,
+			"direction":        model.Direction,
+			"default_currency": model.DefaultCurrency,
+			"priority":         model.Priority,
+			"enabled":          model.Enabled,
+			"samples":          model.Samples,
+			"updated_at":       model.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update notification template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotificationTemplateNotFound
+	}
+	return nil
+}
+
+func (r *DefaultNotificationTemplateRepository) DeleteNotificationTemplate(templateID string) error {
+	result := r.DB.Where("id = ?", templateID).Delete(&models.NotificationTemplateModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete notification template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotificationTemplateNotFound
+	}
+	return nil
+}
+
+func (r *DefaultNotificationTemplateRepository) GetNotificationTemplate(templateID string) (*domain.NotificationTemplate, error) {
+	var model models.NotificationTemplateModel
+	if err := r.DB.Where("id = ?", templateID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotificationTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification template: %w", err)
+	}
+	return mappers.ToDomainNotificationTemplate(&model), nil
+}
+
+func (r *DefaultNotificationTemplateRepository) ListNotificationTemplates(bankCode string, enabledOnly bool) ([]*domain.NotificationTemplate, error) {
+	query := r.DB.Model(&models.NotificationTemplateModel{})
+	if bankCode != "" {
+		query = query.Where("bank_code = ?", bankCode)
+	}
+	if enabledOnly {
+		query = query.Where("enabled = ?", true)
+	}
+
+	var templateModels []models.NotificationTemplateModel
+	if err := query.Order("bank_code, priority DESC, created_at").Find(&templateModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list notification templates: %w", err)
+	}
+	templates := make([]*domain.NotificationTemplate, len(templateModels))
+	for i := range templateModels {
+		templates[i] = mappers.ToDomainNotificationTemplate(&templateModels[i])
+	}
+	return templates, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"github.com/google/uuid"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Тесты с БД запускаются, только если задан DSN тестовой базы postgres
+const testDSNEnv = "ORDER_SERVICE_TEST_DSN"
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&models.BankDetailModel{},
+		&models.OrderModel{},
+		&models.OrderStatusTransitionModel{},
+		&models.OutboxEventModel{},
+	); err != nil {
+		t.Fatalf("failed to migrate test db: %v", err)
+	}
+	return db
+}
+
+// Одновременные подтверждение и отмена одной сделки: ровно одна операция меняет статус,
+// вторая получает ErrConcurrentModification, версия увеличивается один раз
+func TestProcessOrderCriticalOperationApproveCancelRace(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewDefaultOrderRepository(db)
+
+	const rounds = 20
+	for round := 0; round < rounds; round++ {
+		orderID := uuid.New().String()
+		if err := db.Create(&models.OrderModel{
+			ID:        orderID,
+			Status:    domain.StatusPending,
+			Type:      string(domain.TypePayIn),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}).Error; err != nil {
+			t.Fatalf("failed to create order: %v", err)
+		}
+
+		operations := []struct {
+			operation string
+			toStatus  domain.OrderStatus
+		}{
+			{domain.OrderOpApprove, domain.StatusCompleted},
+			{domain.OrderOpCancel, domain.StatusCanceled},
+		}
+
+		var (
+			start sync.WaitGroup
+			done  sync.WaitGroup
+			errs  = make([]error, len(operations))
+		)
+		start.Add(1)
+		for i, op := range operations {
+			done.Add(1)
+			go func(i int, operation string, toStatus domain.OrderStatus) {
+				defer done.Done()
+				start.Wait()
+				errs[i] = repo.ProcessOrderCriticalOperation(&domain.OrderStatusTransition{
+					OrderID:    orderID,
+					FromStatus: domain.StatusPending,
+					ToStatus:   toStatus,
+					Actor:      domain.ActorOperator,
+					Operation:  operation,
+				}, nil, func() error {
+					// Держим транзакцию открытой, чтобы вторая операция пересеклась с ней
+					time.Sleep(10 * time.Millisecond)
+					return nil
+				})
+			}(i, op.operation, op.toStatus)
+		}
+		start.Done()
+		done.Wait()
+
+		winner := -1
+		for i, err := range errs {
+			switch {
+			case err == nil:
+				if winner != -1 {
+					t.Fatalf("round %d: both %s and %s succeeded", round, operations[winner].operation, operations[i].operation)
+				}
+				winner = i
+			case !errors.Is(err, domain.ErrConcurrentModification):
+				t.Fatalf("round %d: %s failed with unexpected error: %v", round, operations[i].operation, err)
+			}
+		}
+		if winner == -1 {
+			t.Fatalf("round %d: neither operation succeeded: %v", round, errs)
+		}
+
+		var order models.OrderModel
+		if err := db.First(&order, "id = ?", orderID).Error; err != nil {
+			t.Fatalf("round %d: failed to read order: %v", round, err)
+		}
+		if order.Status != operations[winner].toStatus {
+			t.Errorf("round %d: status = %s, want %s", round, order.Status, operations[winner].toStatus)
+		}
+		if order.Version != 1 {
+			t.Errorf("round %d: version = %d, want 1", round, order.Version)
+		}
+
+		var transitions int64
+		if err := db.Model(&models.OrderStatusTransitionModel{}).Where("order_id = ?", orderID).Count(&transitions).Error; err != nil {
+			t.Fatalf("round %d: failed to count transitions: %v", round, err)
+		}
+		if transitions != 1 {
+			t.Errorf("round %d: %d status transitions recorded, want 1", round, transitions)
+		}
+	}
+}
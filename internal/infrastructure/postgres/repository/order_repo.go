@@ -8,9 +8,13 @@ import (
 	"time"
 
 	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/pii"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DefaultOrderRepository struct {
@@ -21,11 +25,87 @@ func NewDefaultOrderRepository(db *gorm.DB) *DefaultOrderRepository {
 	return &DefaultOrderRepository{DB: db}
 }
 
+// Правила переходов статусов сделки, общие для всех репозиториев пакета
+var orderStateMachine = domain.NewOrderStateMachine()
+
 // ProcessOrderCriticalOperation - выполнение критичной операции в транзакции
 func (r *DefaultOrderRepository) ProcessOrderCriticalOperation(
-    orderID string, 
-    newStatus domain.OrderStatus, 
-    operation string, // добавляем параметр операции
+    transition *domain.OrderStatusTransition,
+    events []*domain.OutboxEvent,
+    walletFunc func() error,
+) error {
+    return r.processOrderCriticalOperation(transition, nil, nil, events, walletFunc)
+}
+
+// ProcessOrderCriticalOperationWithAmounts - критичная операция, меняющая вместе со статусом суммы сделки
+func (r *DefaultOrderRepository) ProcessOrderCriticalOperationWithAmounts(
+    transition *domain.OrderStatusTransition,
+    amounts domain.AmountInfo,
+    events []*domain.OutboxEvent,
+    walletFunc func() error,
+) error {
+    return r.processOrderCriticalOperation(transition, map[string]interface{}{
+        "amount_fiat":     amounts.AmountFiat,
+        "amount_crypto":   amounts.AmountCrypto,
+        "crypto_rub_rate": amounts.CryptoRate,
+    }, nil, events, walletFunc)
+}
+
+// ProcessOrderCriticalOperationWithPaymentLink - критичная операция, в той же транзакции привязывающая
+// к сделке непривязанный платеж: платеж не останется привязанным к незакрытой сделке и наоборот
+func (r *DefaultOrderRepository) ProcessOrderCriticalOperationWithPaymentLink(
+    transition *domain.OrderStatusTransition,
+    amounts *domain.AmountInfo,
+    link *domain.UnmatchedPaymentLink,
+    events []*domain.OutboxEvent,
+    walletFunc func() error,
+) error {
+    var orderUpdates map[string]interface{}
+    if amounts != nil {
+        orderUpdates = map[string]interface{}{
+            "amount_fiat":     amounts.AmountFiat,
+            "amount_crypto":   amounts.AmountCrypto,
+            "crypto_rub_rate": amounts.CryptoRate,
+        }
+    }
+    return r.processOrderCriticalOperation(transition, orderUpdates, link, events, walletFunc)
+}
+
+// ReassignOrderRequisites - смена реквизита, трейдера и условий трафика сделки
+func (r *DefaultOrderRepository) ReassignOrderRequisites(transition *domain.OrderStatusTransition, order *domain.Order, events []*domain.OutboxEvent) error {
+    return r.processOrderCriticalOperation(transition, requisiteUpdates(order), nil, events, nil)
+}
+
+// ReassignOrderRequisitesInTx - то же, что ReassignOrderRequisites, в уже открытой транзакции
+func (r *DefaultOrderRepository) ReassignOrderRequisitesInTx(transition *domain.OrderStatusTransition, order *domain.Order, events []*domain.OutboxEvent) error {
+    return applyOrderTransition(r.DB, transition, requisiteUpdates(order), events)
+}
+
+func requisiteUpdates(order *domain.Order) map[string]interface{} {
+    return map[string]interface{}{
+        "bank_details_id":       order.BankDetailID,
+        "amount_fiat":           order.AmountInfo.AmountFiat,
+        "amount_crypto":         order.AmountInfo.AmountCrypto,
+        "trader_id":             order.RequisiteDetails.TraderID,
+        "card_number":           pii.EncryptedString(order.RequisiteDetails.CardNumber),
+        "phone":                 pii.EncryptedString(order.RequisiteDetails.Phone),
+        "owner":                 pii.EncryptedString(order.RequisiteDetails.Owner),
+        "payment_system":        order.RequisiteDetails.PaymentSystem,
+        "bank_name":             order.RequisiteDetails.BankName,
+        "bank_code":             order.RequisiteDetails.BankCode,
+        "nspk_code":             order.RequisiteDetails.NspkCode,
+        "device_id":             order.RequisiteDetails.DeviceID,
+        "trader_reward_percent": order.TraderReward,
+        "platform_fee":          order.PlatformFee,
+        "expires_at":            order.ExpiresAt,
+    }
+}
+
+func (r *DefaultOrderRepository) processOrderCriticalOperation(
+    transition *domain.OrderStatusTransition,
+    orderUpdates map[string]interface{},
+    link *domain.UnmatchedPaymentLink,
+    events []*domain.OutboxEvent,
     walletFunc func() error,
 ) error {
     tx := r.DB.Begin()
@@ -36,13 +116,19 @@ func (r *DefaultOrderRepository) ProcessOrderCriticalOperation(
         }
     }()
 
-    // 1. Обновляем статус
-    if err := tx.Model(&models.OrderModel{}).Where("id = ?", orderID).Update("status", newStatus).Error; err != nil {
+    // 1-5. Проверяем и меняем статус, пишем историю, outbox и колбэк
+    if err := applyOrderTransition(tx, transition, orderUpdates, events); err != nil {
         tx.Rollback()
-        return fmt.Errorf("failed to update order status: %w", err)
+        return err
+    }
+    if link != nil {
+        if err := linkUnmatchedPayment(tx, link); err != nil {
+            tx.Rollback()
+            return err
+        }
     }
 
-    // 2. Выполняем операцию с кошельком
+    // 6. Выполняем операцию с кошельком
     if walletFunc != nil {
         if err := walletFunc(); err != nil {
             tx.Rollback()
@@ -50,28 +136,135 @@ func (r *DefaultOrderRepository) ProcessOrderCriticalOperation(
         }
     }
 
-    // // 3. Сохраняем состояние транзакции
-    // state := &models.OrderTransactionStateModel{
-    //     OrderID:         orderID,
-    //     Operation:       operation,
-    //     StatusChanged:   true,
-    //     WalletProcessed: walletFunc != nil,
-    //     CreatedAt:       time.Now(),
-    // }
-    // if err := tx.Create(state).Error; err != nil {
-    //     tx.Rollback()
-    //     return fmt.Errorf("failed to save transaction state: %w", err)
-    // }
-
     return tx.Commit().Error
 }
 
-func (r *DefaultOrderRepository) CreateOrder(order *domain.Order) error {
-	orderModel := mappers.ToGORMOrder(order)
-	if err := r.DB.Create(orderModel).Error; err != nil {
-		return err
-	}
-	return nil
+// applyOrderTransition выполняет переход статуса в рамках переданной транзакции:
+// проверка по OrderStateMachine, compare-and-swap, история статусов, outbox и колбэк мерчанту
+func applyOrderTransition(
+    tx *gorm.DB,
+    transition *domain.OrderStatusTransition,
+    orderUpdates map[string]interface{},
+    events []*domain.OutboxEvent,
+) error {
+    // 1. Читаем текущее состояние и проверяем допустимость перехода
+    var current models.OrderModel
+    if err := tx.Select("id", "status", "version").
+        First(&current, "id = ?", transition.OrderID).Error; err != nil {
+        return fmt.Errorf("failed to get order: %w", err)
+    }
+
+    // Статус уже изменен другой операцией после того, как вызывающий код его прочитал
+    if transition.FromStatus != "" && current.Status != transition.FromStatus {
+        return fmt.Errorf("%w: expected status %s, got %s", domain.ErrConcurrentModification, transition.FromStatus, current.Status)
+    }
+
+    if err := orderStateMachine.Validate(transition.Operation, current.Status, transition.ToStatus); err != nil {
+        return err
+    }
+    transition.FromStatus = current.Status
+
+    // 2. Обновляем статус (compare-and-swap по статусу и версии)
+    if err := casOrderStatus(tx, transition.OrderID, current.Status, current.Version, transition.ToStatus, orderUpdates); err != nil {
+        return err
+    }
+
+    // 3. Сохраняем переход в истории
+    if err := saveStatusTransition(tx, transition); err != nil {
+        return err
+    }
+
+    // 4. Сохраняем события в outbox - релей отправит их в Kafka после коммита
+    if err := saveOutboxEvents(tx, events); err != nil {
+        return err
+    }
+
+    // 5. Ставим колбэк мерчанту в очередь - он не потеряется, если сервис упадет сразу после коммита
+    if transition.Callback != nil {
+        return saveCallbackDelivery(tx, transition.Callback)
+    }
+    return nil
+}
+
+// casOrderStatus меняет статус сделки, только если ее статус и версия не изменились с момента чтения.
+// Параллельная операция, проигравшая гонку, получает domain.ErrConcurrentModification
+func casOrderStatus(tx *gorm.DB, orderID string, oldStatus domain.OrderStatus, oldVersion int64, newStatus domain.OrderStatus, extra map[string]interface{}) error {
+    updates := map[string]interface{}{
+        "status":  newStatus,
+        "version": gorm.Expr("version + 1"),
+    }
+    // Дополнительные поля, которые нужно обновить вместе со статусом
+    for k, v := range extra {
+        updates[k] = v
+    }
+
+    result := tx.Model(&models.OrderModel{}).
+        Where("id = ? AND status = ? AND version = ?", orderID, oldStatus, oldVersion).
+        Updates(updates)
+    if result.Error != nil {
+        return fmt.Errorf("failed to update order status: %w", result.Error)
+    }
+    if result.RowsAffected == 0 {
+        return fmt.Errorf("%w: order %s", domain.ErrConcurrentModification, orderID)
+    }
+    return nil
+}
+
+// saveStatusTransition записывает переход статуса сделки в рамках переданной транзакции
+func saveStatusTransition(tx *gorm.DB, transition *domain.OrderStatusTransition) error {
+    if transition.ID == "" {
+        transition.ID = uuid.New().String()
+    }
+    if transition.CreatedAt.IsZero() {
+        transition.CreatedAt = time.Now()
+    }
+    if err := tx.Create(mappers.ToGORMOrderStatusTransition(transition)).Error; err != nil {
+        return fmt.Errorf("failed to save order status transition: %w", err)
+    }
+    return nil
+}
+
+// createOrderWithHistory создает сделку, первую запись в истории ее статусов и события о ней в outbox
+func createOrderWithHistory(tx *gorm.DB, order *domain.Order, events []*domain.OutboxEvent) error {
+    if err := orderStateMachine.Validate(domain.OrderOpCreate, "", order.Status); err != nil {
+        return err
+    }
+    if err := tx.Create(mappers.ToGORMOrder(order)).Error; err != nil {
+        return err
+    }
+    if err := saveStatusTransition(tx, &domain.OrderStatusTransition{
+        OrderID:   order.ID,
+        ToStatus:  order.Status,
+        Actor:     domain.ActorMerchant,
+        Operation: domain.OrderOpCreate,
+    }); err != nil {
+        return err
+    }
+    return saveOutboxEvents(tx, events)
+}
+
+// GetOrderHistory возвращает историю статусов сделки в хронологическом порядке
+func (r *DefaultOrderRepository) GetOrderHistory(orderID string) ([]*domain.OrderStatusTransition, error) {
+    var transitionModels []models.OrderStatusTransitionModel
+    if err := r.DB.
+        Where("order_id = ?", orderID).
+        Order("created_at ASC").
+        Find(&transitionModels).Error; err != nil {
+        return nil, fmt.Errorf("failed to get order history: %w", err)
+    }
+
+    transitions := make([]*domain.OrderStatusTransition, len(transitionModels))
+    for i, transitionModel := range transitionModels {
+        transitions[i] = mappers.ToDomainOrderStatusTransition(&transitionModel)
+    }
+
+    return transitions, nil
+}
+
+func (r *DefaultOrderRepository) CreateOrder(order *domain.Order, events []*domain.OutboxEvent) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return createOrderWithHistory(tx, order, events)
+	})
 }
 
 func (r *DefaultOrderRepository) GetOrderByID(orderID string) (*domain.Order, error) {
@@ -96,19 +289,6 @@ func (r *DefaultOrderRepository) GetOrderByMerchantOrderID(merchantOrderID strin
 	return mappers.ToDomainOrder(&order), nil
 }
 
-func (r *DefaultOrderRepository) UpdateOrderStatus(orderID string, newStatus domain.OrderStatus) error {
-	updatedOrderModel := models.OrderModel{
-		ID: orderID,
-		Status: newStatus,
-	}
-
-	if err := r.DB.Updates(&updatedOrderModel).Error; err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (r *DefaultOrderRepository) GetOrdersByTraderID(
     traderID string, 
     page, limit int64, 
@@ -231,11 +411,21 @@ func (r *DefaultOrderRepository) GetOrdersByBankDetailID(bankDetailID string) ([
 	return orders, nil
 }
 
+func (r *DefaultOrderRepository) GetPendingAmountsByBankDetailID(bankDetailID string) ([]float64, error) {
+	var amounts []float64
+	if err := r.DB.Model(&models.OrderModel{}).
+		Where("bank_details_id = ? AND status = ?", bankDetailID, domain.StatusPending).
+		Pluck("amount_fiat", &amounts).Error; err != nil {
+		return nil, err
+	}
+	return amounts, nil
+}
+
 func (r *DefaultOrderRepository) GetCreatedOrdersByClientID(clientID string) ([]*domain.Order, error) {
 	var orderModels []models.OrderModel
 	if err := r.DB.Model(&models.OrderModel{}).Preload("BankDetail", func(db *gorm.DB) *gorm.DB {
 		return db.Unscoped() // отключаем фильтрацию по DeletedAt
-	}).Where("client_id = ? AND status = ?", clientID, domain.StatusPending).Find(&orderModels).Error; err != nil {
+	}).Where("client_id = ? AND status IN ?", clientID, []domain.OrderStatus{domain.StatusPending, domain.StatusWaiting}).Find(&orderModels).Error; err != nil {
 		return nil, err
 	}
 
@@ -544,6 +734,119 @@ func (r *DefaultOrderRepository) FindPendingOrdersByDeviceID(deviceID string) ([
 }
 
 
+// FindRecentlyCanceledOrdersByDeviceID - пай-ин сделки устройства, отмененные actor не раньше canceledSince.
+// Время отмены берется из истории статусов: updated_at меняется и после отмены
+func (r *DefaultOrderRepository) FindRecentlyCanceledOrdersByDeviceID(deviceID, actor string, canceledSince time.Time) ([]*domain.Order, error) {
+    var orders []models.OrderModel
+
+    err := r.DB.
+        Where("status = ?", domain.StatusCanceled).
+        Where("type = ?", domain.TypePayIn).
+        Where("device_id = ?", deviceID).
+        Where(`EXISTS (
+            SELECT 1 FROM order_status_transitions t
+            WHERE t.order_id = order_models.id
+              AND t.to_status = ? AND t.actor = ? AND t.created_at >= ?
+        )`, domain.StatusCanceled, actor, canceledSince).
+        Order("updated_at DESC").
+        Find(&orders).Error
+
+    if err != nil {
+        return nil, fmt.Errorf("failed to find recently canceled orders: %w", err)
+    }
+
+    domainOrders := make([]*domain.Order, len(orders))
+    for i, order := range orders {
+        domainOrders[i] = mappers.ToDomainOrder(&order)
+    }
+
+    return domainOrders, nil
+}
+
+// FindPendingOrders возвращает все активные (PENDING) сделки
+func (r *DefaultOrderRepository) FindPendingOrders() ([]*domain.Order, error) {
+    var orders []models.OrderModel
+
+    err := r.DB.
+        Where("status = ?", domain.StatusPending).
+        Find(&orders).Error
+
+    if err != nil {
+        return nil, fmt.Errorf("failed to find pending orders: %w", err)
+    }
+
+    domainOrders := make([]*domain.Order, len(orders))
+    for i, order := range orders {
+        domainOrders[i] = mappers.ToDomainOrder(&order)
+    }
+
+    return domainOrders, nil
+}
+
+// FindWaitingOrders - сделки в ожидании реквизитов в порядке создания
+func (r *DefaultOrderRepository) FindWaitingOrders(limit int) ([]*domain.Order, error) {
+    var orders []models.OrderModel
+
+    err := r.DB.
+        Where("status = ?", domain.StatusWaiting).
+        Order("created_at ASC").
+        Limit(limit).
+        Find(&orders).Error
+
+    if err != nil {
+        return nil, fmt.Errorf("failed to find waiting orders: %w", err)
+    }
+
+    domainOrders := make([]*domain.Order, len(orders))
+    for i, order := range orders {
+        domainOrders[i] = mappers.ToDomainOrder(&order)
+    }
+
+    return domainOrders, nil
+}
+
+// GetAssignmentStats - статистика сделок по реквизитам или трейдерам для стратегий выбора кандидата
+func (r *DefaultOrderRepository) GetAssignmentStats(ids []string, byTrader bool, orderType domain.OrderType, since time.Time) (map[string]*domain.AssignmentStats, error) {
+    result := make(map[string]*domain.AssignmentStats, len(ids))
+    if len(ids) == 0 {
+        return result, nil
+    }
+
+    column := "bank_details_id"
+    if byTrader {
+        column = "trader_id"
+    }
+    sqlQuery := `
+        SELECT
+            ` + column + `::text as id,
+            SUM(CASE WHEN status = $1 THEN 1 ELSE 0 END)::int as pending_count,
+            MAX(created_at) as last_assigned_at,
+            SUM(CASE WHEN status = $2 AND created_at >= $4 THEN 1 ELSE 0 END)::int as completed,
+            SUM(CASE WHEN status = $3 AND created_at >= $4 THEN 1 ELSE 0 END)::int as canceled
+        FROM order_models
+        WHERE ` + column + `::text = ANY($5::text[])
+          AND type = $6
+        GROUP BY ` + column
+
+    var stats []*domain.AssignmentStats
+    err := r.DB.Raw(sqlQuery,
+        string(domain.StatusPending),
+        string(domain.StatusCompleted),
+        string(domain.StatusCanceled),
+        since,
+        pq.Array(ids),
+        string(orderType),
+    ).Scan(&stats).Error
+    if err != nil {
+        return nil, fmt.Errorf("failed to get assignment stats: %w", err)
+    }
+
+    for _, stat := range stats {
+        result[stat.ID] = stat
+    }
+    return result, nil
+}
+
 // Метод для идемпотентности - проверка, не обрабатывалась ли уже сделка
 func (r *DefaultOrderRepository) CheckDuplicatePayment(ctx context.Context, orderID string, paymentHash string) (bool, error) {
 	var count int64
@@ -554,6 +857,88 @@ func (r *DefaultOrderRepository) CheckDuplicatePayment(ctx context.Context, orde
 	return count > 0, err
 }
 
+// ClaimPaymentNotification записывает уведомление по уникальному хэшу или перехватывает
+// незавершенную запись, зависшую дольше staleBefore
+func (r *DefaultOrderRepository) ClaimPaymentNotification(ctx context.Context, paymentLog *domain.PaymentProcessingLog, staleBefore time.Time) (bool, *domain.PaymentProcessingLog, error) {
+	model := mappers.ToGORMPaymentProcessingLog(paymentLog)
+	// metadata не заполняется, а пустая строка - невалидный jsonb
+	result := r.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "payment_hash"}}, DoNothing: true}).
+		Omit("Metadata").
+		Create(model)
+	if result.Error != nil {
+		return false, nil, fmt.Errorf("failed to claim payment notification: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return true, nil, nil
+	}
+
+	// id записи - токен обработчика: прежний обработчик после перехвата не перезапишет результат
+	result = r.DB.WithContext(ctx).Model(&models.PaymentProcessingLog{}).
+		Where("payment_hash = ? AND completed_at IS NULL AND order_id IS NULL AND processed_at < ?", paymentLog.PaymentHash, staleBefore).
+		Updates(map[string]interface{}{
+			"id":           paymentLog.ID,
+			"processed_at": paymentLog.ProcessedAt,
+		})
+	if result.Error != nil {
+		return false, nil, fmt.Errorf("failed to reclaim payment notification: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return true, nil, nil
+	}
+
+	var existing models.PaymentProcessingLog
+	err := r.DB.WithContext(ctx).Where("payment_hash = ?", paymentLog.PaymentHash).First(&existing).Error
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to get payment notification: %w", err)
+	}
+	return false, mappers.ToDomainPaymentProcessingLog(&existing), nil
+}
+
+// CompletePaymentNotification сохраняет результат, если запись не перехвачена другим обработчиком
+func (r *DefaultOrderRepository) CompletePaymentNotification(ctx context.Context, paymentLog *domain.PaymentProcessingLog) error {
+	model := mappers.ToGORMPaymentProcessingLog(paymentLog)
+	err := r.DB.WithContext(ctx).Model(&models.PaymentProcessingLog{}).
+		Where("payment_hash = ? AND id = ?", paymentLog.PaymentHash, paymentLog.ID).
+		Updates(map[string]interface{}{
+			"order_id":     model.OrderID,
+			"completed_at": model.CompletedAt,
+			"success":      model.Success,
+			"error":        model.Error,
+			"result":       model.Result,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to complete payment notification: %w", err)
+	}
+	return nil
+}
+
+// MarkPaymentNotificationApproving записывает сделку, которую закрывает обработчик. После этого
+// запись не перехватывается и не удаляется, даже если результат обработки не сохранится
+func (r *DefaultOrderRepository) MarkPaymentNotificationApproving(ctx context.Context, paymentLog *domain.PaymentProcessingLog, orderID string) error {
+	result := r.DB.WithContext(ctx).Model(&models.PaymentProcessingLog{}).
+		Where("payment_hash = ? AND id = ? AND completed_at IS NULL", paymentLog.PaymentHash, paymentLog.ID).
+		Update("order_id", orderID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark payment notification: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("payment notification %s was reclaimed by another handler", paymentLog.PaymentHash)
+	}
+	return nil
+}
+
+// ReleasePaymentNotification удаляет запись, только если она все еще принадлежит этому обработчику
+func (r *DefaultOrderRepository) ReleasePaymentNotification(ctx context.Context, paymentLog *domain.PaymentProcessingLog) error {
+	err := r.DB.WithContext(ctx).
+		Where("payment_hash = ? AND id = ? AND completed_at IS NULL AND order_id IS NULL", paymentLog.PaymentHash, paymentLog.ID).
+		Delete(&models.PaymentProcessingLog{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to release payment notification: %w", err)
+	}
+	return nil
+}
+
 // Логирование обработки платежа для идемпотентности
 func (r *DefaultOrderRepository) LogPaymentProcessing(ctx context.Context, log *models.PaymentProcessingLog) error {
 	return r.DB.Create(log).Error
@@ -790,19 +1175,15 @@ func (r *DefaultOrderRepository) Rollback() error {
 }
 
 // CreateOrderInTx создает заказ в транзакции
-func (r *DefaultOrderRepository) CreateOrderInTx(order *domain.Order) error {
-    orderModel := mappers.ToGORMOrder(order)
-    if err := r.DB.Create(orderModel).Error; err != nil {
-        return err
-    }
-    return nil
+func (r *DefaultOrderRepository) CreateOrderInTx(order *domain.Order, events []*domain.OutboxEvent) error {
+    return createOrderWithHistory(r.DB, order, events)
 }
 
 // GetCreatedOrdersByClientIDInTx проверяет идемпотентность в транзакции
 func (r *DefaultOrderRepository) GetCreatedOrdersByClientIDInTx(clientID string) ([]*domain.Order, error) {
     var orderModels []models.OrderModel
     if err := r.DB.Model(&models.OrderModel{}).
-        Where("client_id = ? AND status = ?", clientID, domain.StatusPending).
+        Where("client_id = ? AND status IN ?", clientID, []domain.OrderStatus{domain.StatusPending, domain.StatusWaiting}).
         Find(&orderModels).Error; err != nil {
         return nil, err
     }
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DefaultOutboxRepository struct {
+	DB *gorm.DB
+}
+
+func NewDefaultOutboxRepository(db *gorm.DB) *DefaultOutboxRepository {
+	return &DefaultOutboxRepository{DB: db}
+}
+
+// saveOutboxEvents записывает события в outbox в рамках переданной транзакции
+func saveOutboxEvents(tx *gorm.DB, events []*domain.OutboxEvent) error {
+	for _, event := range events {
+		if event.ID == "" {
+			event.ID = uuid.New().String()
+		}
+		now := time.Now()
+		if event.CreatedAt.IsZero() {
+			event.CreatedAt = now
+		}
+		if event.NextAttemptAt.IsZero() {
+			event.NextAttemptAt = now
+		}
+		event.Status = domain.OutboxPending
+
+		if err := tx.Create(mappers.ToGORMOutboxEvent(event)).Error; err != nil {
+			return fmt.Errorf("failed to save outbox event: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *DefaultOutboxRepository) EnqueueOutboxEvent(event *domain.OutboxEvent) error {
+	return saveOutboxEvents(r.DB, []*domain.OutboxEvent{event})
+}
+
+// ClaimPendingOutboxEvents выбирает только самое раннее неотправленное событие каждого агрегата,
+// даже если оно ждет повтора: следующее событие агрегата не уйдет в Kafka раньше предыдущего.
+// Строки, заблокированные другим экземпляром, пропускаются (SKIP LOCKED), а выбранные откладываются
+// на lease - если экземпляр упадет, не отправив их, события заберет другой после истечения lease
+func (r *DefaultOutboxRepository) ClaimPendingOutboxEvents(limit int, lease time.Duration) ([]*domain.OutboxEvent, error) {
+	var eventModels []models.OutboxEventModel
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Raw(`
+			SELECT e.* FROM outbox_events e
+			WHERE e.status = ? AND e.next_attempt_at <= ?
+			  AND NOT EXISTS (
+				SELECT 1 FROM outbox_events p
+				WHERE p.status = ?
+				  AND p.aggregate_type = e.aggregate_type
+				  AND p.aggregate_id = e.aggregate_id
+				  AND (p.created_at, p.id) < (e.created_at, e.id)
+			  )
+			ORDER BY e.created_at ASC
+			LIMIT ?
+			FOR UPDATE OF e SKIP LOCKED`,
+			domain.OutboxPending, now, domain.OutboxPending, limit,
+		).Scan(&eventModels).Error; err != nil {
+			return err
+		}
+		if len(eventModels) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(eventModels))
+		for i, eventModel := range eventModels {
+			ids[i] = eventModel.ID
+		}
+		return tx.Model(&models.OutboxEventModel{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	events := make([]*domain.OutboxEvent, len(eventModels))
+	for i, eventModel := range eventModels {
+		events[i] = mappers.ToDomainOutboxEvent(&eventModel)
+	}
+
+	return events, nil
+}
+
+func (r *DefaultOutboxRepository) MarkOutboxEventSent(eventID string) error {
+	return r.DB.Model(&models.OutboxEventModel{}).
+		Where("id = ?", eventID).
+		Updates(map[string]interface{}{
+			"status":  domain.OutboxSent,
+			"sent_at": time.Now(),
+		}).Error
+}
+
+func (r *DefaultOutboxRepository) ScheduleOutboxRetry(eventID string, attempts int, lastError string, nextAttemptAt time.Time) error {
+	return r.DB.Model(&models.OutboxEventModel{}).
+		Where("id = ?", eventID).
+		Updates(map[string]interface{}{
+			"attempts":        attempts,
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}).Error
+}
+
+func (r *DefaultOutboxRepository) DeleteSentOutboxEvents(sentBefore time.Time, limit int) (int64, error) {
+	result := r.DB.Exec(`
+		DELETE FROM outbox_events WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE status = ? AND sent_at < ?
+			LIMIT ?
+		)`, domain.OutboxSent, sentBefore, limit)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete sent outbox events: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *DefaultOutboxRepository) GetOutboxLag() (*domain.OutboxLag, error) {
+	var lag struct {
+		PendingCount    int64      `gorm:"column:pending_count"`
+		OldestPendingAt *time.Time `gorm:"column:oldest_pending_at"`
+	}
+	if err := r.DB.Raw(
+		"SELECT COUNT(*) AS pending_count, MIN(created_at) AS oldest_pending_at FROM outbox_events WHERE status = ?",
+		domain.OutboxPending,
+	).Scan(&lag).Error; err != nil {
+		return nil, fmt.Errorf("failed to get outbox lag: %w", err)
+	}
+
+	return &domain.OutboxLag{
+		PendingCount:    lag.PendingCount,
+		OldestPendingAt: lag.OldestPendingAt,
+	}, nil
+}
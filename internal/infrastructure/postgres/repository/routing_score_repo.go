@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DefaultRoutingScoreRepository struct {
+	DB *gorm.DB
+}
+
+func NewDefaultRoutingScoreRepository(db *gorm.DB) *DefaultRoutingScoreRepository {
+	return &DefaultRoutingScoreRepository{DB: db}
+}
+
+// GetRoutingStats считает по пай-ин сделкам окна завершенные, отмененные, спорные и закрытые автоматикой.
+// Время завершения берется из истории статусов, для сделок без истории - время последнего обновления
+func (r *DefaultRoutingScoreRepository) GetRoutingStats(subjectType domain.RoutingSubject, since time.Time) ([]*domain.RoutingStats, error) {
+	column := "o.bank_details_id"
+	if subjectType == domain.RoutingSubjectTrader {
+		column = "o.trader_id"
+	}
+
+	sqlQuery := `
+        SELECT
+            ` + column + `::text as subject_id,
+            MAX(o.trader_id) as trader_id,
+            COUNT(*) FILTER (WHERE o.status = $1) as completed,
+            COUNT(*) FILTER (WHERE o.status = $2) as canceled,
+            COUNT(*) FILTER (WHERE EXISTS (
+                SELECT 1 FROM dispute_models d WHERE d.order_id::text = o.id::text
+            )) as disputed,
+            COUNT(*) FILTER (WHERE o.status = $1 AND EXISTS (
+                SELECT 1 FROM automatic_logs a WHERE a.order_id = o.id AND a.action = 'approved' AND a.success
+            )) as automatic_completed,
+            COALESCE(percentile_cont(0.5) WITHIN GROUP (
+                ORDER BY EXTRACT(EPOCH FROM (COALESCE(t.completed_at, o.updated_at) - o.created_at))
+            ) FILTER (WHERE o.status = $1), 0) as median_complete_seconds
+        FROM order_models o
+        LEFT JOIN LATERAL (
+            SELECT MIN(created_at) as completed_at
+            FROM order_status_transitions
+            WHERE order_id = o.id AND to_status = $1
+        ) t ON true
+        WHERE o.type = $3
+          AND o.created_at >= $4
+          AND o.status IN ($1, $2)
+          AND ` + column + ` IS NOT NULL
+          AND ` + column + `::text <> ''
+        GROUP BY ` + column
+
+	var stats []*domain.RoutingStats
+	err := r.DB.Raw(sqlQuery,
+		string(domain.StatusCompleted),
+		string(domain.StatusCanceled),
+		string(domain.TypePayIn),
+		since,
+	).Scan(&stats).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routing stats: %w", err)
+	}
+	return stats, nil
+}
+
+func (r *DefaultRoutingScoreRepository) SaveRoutingScores(scores []*domain.RoutingScore, calculatedAt time.Time) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if len(scores) > 0 {
+			scoreModels := make([]*models.RoutingScoreModel, len(scores))
+			for i, score := range scores {
+				scoreModels[i] = mappers.ToGORMRoutingScore(score)
+			}
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "subject_type"}, {Name: "subject_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"trader_id", "score", "orders", "completion_rate", "median_complete_seconds",
+					"dispute_rate", "automatic_share", "calculated_at", "updated_at",
+				}),
+			}).CreateInBatches(scoreModels, 500).Error
+			if err != nil {
+				return fmt.Errorf("failed to save routing scores: %w", err)
+			}
+		}
+
+		err := tx.Model(&models.RoutingScoreModel{}).
+			Where("calculated_at < ?", calculatedAt).
+			Updates(map[string]interface{}{
+				"score":                   1,
+				"orders":                  0,
+				"completion_rate":         0,
+				"median_complete_seconds": 0,
+				"dispute_rate":            0,
+				"automatic_share":         0,
+				"calculated_at":           calculatedAt,
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to reset stale routing scores: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *DefaultRoutingScoreRepository) GetRoutingScores(filter domain.RoutingScoreFilter) ([]*domain.RoutingScore, int64, error) {
+	query := r.DB.Model(&models.RoutingScoreModel{})
+	if filter.SubjectType != "" {
+		query = query.Where("subject_type = ?", filter.SubjectType)
+	}
+	if filter.TraderID != "" {
+		query = query.Where("trader_id = ?", filter.TraderID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count failed: %w", err)
+	}
+
+	var scoreModels []models.RoutingScoreModel
+	err := query.
+		Order("subject_type, score DESC, subject_id").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&scoreModels).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get routing scores: %w", err)
+	}
+
+	scores := make([]*domain.RoutingScore, len(scoreModels))
+	for i := range scoreModels {
+		scores[i] = mappers.ToDomainRoutingScore(&scoreModels[i])
+	}
+	return scores, total, nil
+}
+
+func (r *DefaultRoutingScoreRepository) GetAllRoutingScores() ([]*domain.RoutingScore, error) {
+	var scoreModels []models.RoutingScoreModel
+	if err := r.DB.Find(&scoreModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to get routing scores: %w", err)
+	}
+
+	scores := make([]*domain.RoutingScore, len(scoreModels))
+	for i := range scoreModels {
+		scores[i] = mappers.ToDomainRoutingScore(&scoreModels[i])
+	}
+	return scores, nil
+}
+
+// SetRoutingScoreOverride задает переопределение скора. Если скор еще не рассчитывался,
+// создается нейтральная запись, чтобы переопределение действовало сразу
+func (r *DefaultRoutingScoreRepository) SetRoutingScoreOverride(subjectType domain.RoutingSubject, subjectID string, traderID string, override *float64) (*domain.RoutingScore, error) {
+	scoreModel := &models.RoutingScoreModel{
+		SubjectType: string(subjectType),
+		SubjectID:   subjectID,
+		TraderID:    traderID,
+		Score:       1,
+		Override:    override,
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subject_type"}, {Name: "subject_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"override", "updated_at"}),
+	}).Create(scoreModel).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to set routing score override: %w", err)
+	}
+
+	var saved models.RoutingScoreModel
+	err = r.DB.
+		Where("subject_type = ? AND subject_id = ?", subjectType, subjectID).
+		First(&saved).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routing score: %w", err)
+	}
+	return mappers.ToDomainRoutingScore(&saved), nil
+}
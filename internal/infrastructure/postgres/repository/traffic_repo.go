@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
 	trafficdto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/traffic"
 	"github.com/google/uuid"
@@ -34,7 +35,13 @@ func (r *DefaultTrafficRepository) CreateTraffic(traffic *domain.Traffic) error
 		ManuallyUnlocked: traffic.ActivityParams.ManuallyUnlocked,
 		AntifraudRequired: traffic.AntifraudParams.AntifraudRequired,
 		MerchantDealsDuration: traffic.BusinessParams.MerchantDealsDuration,
+		UniqueAmountStep: traffic.BusinessParams.UniqueAmountStep,
+		UniqueAmountMaxSteps: traffic.BusinessParams.UniqueAmountMaxSteps,
+		AmountTolerance: traffic.BusinessParams.AmountTolerance,
+		AmountTolerancePercent: traffic.BusinessParams.AmountTolerancePercent,
+		SelectionStrategy: string(traffic.BusinessParams.SelectionStrategy),
 		Name: traffic.Name,
+		Schedule: mappers.ToGORMSchedule(traffic.Schedule),
 	}
 
 	if err := r.DB.Create(&trafficModel).Error; err != nil {
@@ -85,6 +92,11 @@ func (r *DefaultTrafficRepository) UpdateTraffic(input *trafficdto.EditTrafficIn
 
 	if input.BusinessParams != nil {
 		updates["merchant_deals_duration"] = input.BusinessParams.MerchantDealsDuration
+		updates["unique_amount_step"] = input.BusinessParams.UniqueAmountStep
+		updates["unique_amount_max_steps"] = input.BusinessParams.UniqueAmountMaxSteps
+		updates["amount_tolerance"] = input.BusinessParams.AmountTolerance
+		updates["amount_tolerance_percent"] = input.BusinessParams.AmountTolerancePercent
+		updates["selection_strategy"] = input.BusinessParams.SelectionStrategy
 	}
 
 	// Добавляем updated_at
@@ -151,7 +163,13 @@ func (r *DefaultTrafficRepository) GetTrafficRecords(page, limit int32) ([]*doma
 			},
 			BusinessParams: domain.TrafficBusinessParams{
 				MerchantDealsDuration: trafficModel.MerchantDealsDuration,
+				UniqueAmountStep: trafficModel.UniqueAmountStep,
+				UniqueAmountMaxSteps: trafficModel.UniqueAmountMaxSteps,
+				AmountTolerance: trafficModel.AmountTolerance,
+				AmountTolerancePercent: trafficModel.AmountTolerancePercent,
+				SelectionStrategy: domain.SelectionStrategy(trafficModel.SelectionStrategy),
 			},
+			Schedule: mappers.ToDomainSchedule(trafficModel.Schedule),
 		}
 	}
 
@@ -184,7 +202,13 @@ func (r *DefaultTrafficRepository) GetTrafficByID(trafficID string) (*domain.Tra
 		},
 		BusinessParams: domain.TrafficBusinessParams{
 			MerchantDealsDuration: trafficModel.MerchantDealsDuration,
+			UniqueAmountStep: trafficModel.UniqueAmountStep,
+			UniqueAmountMaxSteps: trafficModel.UniqueAmountMaxSteps,
+			AmountTolerance: trafficModel.AmountTolerance,
+			AmountTolerancePercent: trafficModel.AmountTolerancePercent,
+			SelectionStrategy: domain.SelectionStrategy(trafficModel.SelectionStrategy),
 		},
+		Schedule: mappers.ToDomainSchedule(trafficModel.Schedule),
 	}, nil
 }
 
@@ -214,7 +238,13 @@ func (r *DefaultTrafficRepository) GetTrafficByTraderMerchant(traderID, merchant
 		},
 		BusinessParams: domain.TrafficBusinessParams{
 			MerchantDealsDuration: trafficModel.MerchantDealsDuration,
+			UniqueAmountStep: trafficModel.UniqueAmountStep,
+			UniqueAmountMaxSteps: trafficModel.UniqueAmountMaxSteps,
+			AmountTolerance: trafficModel.AmountTolerance,
+			AmountTolerancePercent: trafficModel.AmountTolerancePercent,
+			SelectionStrategy: domain.SelectionStrategy(trafficModel.SelectionStrategy),
 		},
+		Schedule: mappers.ToDomainSchedule(trafficModel.Schedule),
 	}, nil
 }
 
@@ -420,7 +450,13 @@ func (r *DefaultTrafficRepository) GetTrafficByTraderID(traderID string) ([]*dom
 			},
 			BusinessParams: domain.TrafficBusinessParams{
 				MerchantDealsDuration: tm.MerchantDealsDuration,
+				UniqueAmountStep: tm.UniqueAmountStep,
+				UniqueAmountMaxSteps: tm.UniqueAmountMaxSteps,
+				AmountTolerance: tm.AmountTolerance,
+				AmountTolerancePercent: tm.AmountTolerancePercent,
+				SelectionStrategy: domain.SelectionStrategy(tm.SelectionStrategy),
 			},
+			Schedule: mappers.ToDomainSchedule(tm.Schedule),
 		})
     }
 
@@ -457,9 +493,78 @@ func (r *DefaultTrafficRepository) GetTrafficByMerchantID(merchantID string) ([]
 			},
 			BusinessParams: domain.TrafficBusinessParams{
 				MerchantDealsDuration: tm.MerchantDealsDuration,
+				UniqueAmountStep: tm.UniqueAmountStep,
+				UniqueAmountMaxSteps: tm.UniqueAmountMaxSteps,
+				AmountTolerance: tm.AmountTolerance,
+				AmountTolerancePercent: tm.AmountTolerancePercent,
+				SelectionStrategy: domain.SelectionStrategy(tm.SelectionStrategy),
 			},
+			Schedule: mappers.ToDomainSchedule(tm.Schedule),
 		})
     }
 
     return traffics, nil
-}
\ No newline at end of file
+}
+
+// GetTrafficByTradersForMerchant - трафик нескольких трейдеров с мерчантом одним запросом
+func (r *DefaultTrafficRepository) GetTrafficByTradersForMerchant(merchantID string, traderIDs []string) ([]*domain.Traffic, error) {
+	if len(traderIDs) == 0 {
+		return []*domain.Traffic{}, nil
+	}
+
+	var trafficModels []models.TrafficModel
+	err := r.DB.Where("merchant_id = ? AND trader_id IN ?", merchantID, traderIDs).Find(&trafficModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	traffics := make([]*domain.Traffic, 0, len(trafficModels))
+	for _, tm := range trafficModels {
+		traffics = append(traffics, &domain.Traffic{
+			ID: tm.ID,
+			MerchantID: tm.MerchantID,
+			TraderID: tm.TraderID,
+			TraderRewardPercent: tm.TraderRewardPercent,
+			TraderPriority: tm.TraderPriority,
+			Enabled: tm.Enabled,
+			PlatformFee: tm.PlatformFee,
+			Name: tm.Name,
+			ActivityParams: domain.TrafficActivityParams{
+				MerchantUnlocked: tm.MerchantUnlocked,
+				TraderUnlocked: tm.TraderUnlocked,
+				AntifraudUnlocked: tm.AntifraudUnlocked,
+				ManuallyUnlocked: tm.ManuallyUnlocked,
+			},
+			AntifraudParams: domain.TrafficAntifraudParams{
+				AntifraudRequired: tm.AntifraudRequired,
+			},
+			BusinessParams: domain.TrafficBusinessParams{
+				MerchantDealsDuration: tm.MerchantDealsDuration,
+				UniqueAmountStep: tm.UniqueAmountStep,
+				UniqueAmountMaxSteps: tm.UniqueAmountMaxSteps,
+				AmountTolerance: tm.AmountTolerance,
+				AmountTolerancePercent: tm.AmountTolerancePercent,
+				SelectionStrategy: domain.SelectionStrategy(tm.SelectionStrategy),
+			},
+			Schedule: mappers.ToDomainSchedule(tm.Schedule),
+		})
+	}
+
+	return traffics, nil
+}
+
+// SetTrafficSchedule сохраняет расписание работы трафика, nil удаляет расписание
+func (r *DefaultTrafficRepository) SetTrafficSchedule(trafficID string, schedule *domain.Schedule) error {
+	var value interface{}
+	if schedule != nil {
+		value = mappers.ToGORMSchedule(schedule)
+	}
+	result := r.DB.Model(&models.TrafficModel{}).Where("id = ?", trafficID).Update("schedule", value)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DefaultUnmatchedPaymentRepository struct {
+	DB *gorm.DB
+}
+
+func NewDefaultUnmatchedPaymentRepository(db *gorm.DB) *DefaultUnmatchedPaymentRepository {
+	return &DefaultUnmatchedPaymentRepository{DB: db}
+}
+
+func (r *DefaultUnmatchedPaymentRepository) CreateUnmatchedPayment(payment *domain.UnmatchedPayment) error {
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "payment_hash"}},
+		DoNothing: true,
+	}).Create(mappers.ToGORMUnmatchedPayment(payment)).Error
+	if err != nil {
+		return fmt.Errorf("failed to create unmatched payment: %w", err)
+	}
+	return nil
+}
+
+func (r *DefaultUnmatchedPaymentRepository) GetUnmatchedPaymentByID(paymentID string) (*domain.UnmatchedPayment, error) {
+	var model models.UnmatchedPaymentModel
+	if err := r.DB.Where("id = ?", paymentID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUnmatchedPaymentNotFound
+		}
+		return nil, fmt.Errorf("failed to get unmatched payment: %w", err)
+	}
+	return mappers.ToDomainUnmatchedPayment(&model), nil
+}
+
+func (r *DefaultUnmatchedPaymentRepository) ListUnmatchedPayments(filter domain.UnmatchedPaymentFilter) ([]*domain.UnmatchedPayment, int64, error) {
+	query := r.DB.Model(&models.UnmatchedPaymentModel{})
+	if filter.DeviceID != "" {
+		query = query.Where("device_id = ?", filter.DeviceID)
+	}
+	if filter.TraderID != "" {
+		query = query.Where("trader_id = ?", filter.TraderID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count failed: %w", err)
+	}
+
+	var paymentModels []models.UnmatchedPaymentModel
+	err := query.
+		Order("created_at DESC").
+		Offset((filter.Page - 1) * filter.Limit).
+		Limit(filter.Limit).
+		Find(&paymentModels).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list unmatched payments: %w", err)
+	}
+
+	payments := make([]*domain.UnmatchedPayment, len(paymentModels))
+	for i := range paymentModels {
+		payments[i] = mappers.ToDomainUnmatchedPayment(&paymentModels[i])
+	}
+	return payments, total, nil
+}
+
+func (r *DefaultUnmatchedPaymentRepository) FindPendingUnmatchedPayments(since time.Time) ([]*domain.UnmatchedPayment, error) {
+	var paymentModels []models.UnmatchedPaymentModel
+	err := r.DB.
+		Where("status = ? AND created_at >= ?", domain.UnmatchedPaymentPending, since).
+		Order("created_at").
+		Find(&paymentModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending unmatched payments: %w", err)
+	}
+
+	payments := make([]*domain.UnmatchedPayment, len(paymentModels))
+	for i := range paymentModels {
+		payments[i] = mappers.ToDomainUnmatchedPayment(&paymentModels[i])
+	}
+	return payments, nil
+}
+
+// linkUnmatchedPayment переводит платеж в LINKED в транзакции закрытия сделки, только если он еще PENDING
+func linkUnmatchedPayment(tx *gorm.DB, link *domain.UnmatchedPaymentLink) error {
+	result := tx.Model(&models.UnmatchedPaymentModel{}).
+		Where("id = ? AND status = ?", link.PaymentID, domain.UnmatchedPaymentPending).
+		Updates(map[string]interface{}{
+			"status":    domain.UnmatchedPaymentLinked,
+			"order_id":  link.OrderID,
+			"linked_by": link.LinkedBy,
+			"linked_at": link.LinkedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to link unmatched payment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUnmatchedPaymentAlreadyLinked
+	}
+	return nil
+}
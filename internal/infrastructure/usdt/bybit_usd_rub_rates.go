@@ -236,7 +236,7 @@ func calculateCrossRate() {
 		// }
 
 		fmt.Printf("\n📊 Примерные расчёты (на основе USD/RUB ≈ 83):\n")
-		fmt.Printf("   BTC/RUB ≈ ₽%.0f\n", rubBtc)
+		fmt.Printf("   BTC/RUB ≈ %+v\n", rubBtc)
 		// fmt.Printf("   USD/RUB ≈ ₽%.2f\n", estimatedUsdRub)
 	}
 }
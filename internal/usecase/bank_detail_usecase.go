@@ -1,6 +1,10 @@
 package usecase
 
 import (
+	"fmt"
+	"log/slog"
+	"time"
+
 	"github.com/LavaJover/shvark-order-service/internal/domain"
 	bankdetaildto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/bank_detail"
 	"github.com/google/uuid"
@@ -21,14 +25,38 @@ type BankDetailUsecase interface {
 	GetBankDetails(input *bankdetaildto.GetBankDetailsInput) (*bankdetaildto.GetBankDetailsOutput, error)
 	FindSuitableBankDetailsWithLock(input *bankdetaildto.FindSuitableBankDetailsInput) ([]*domain.BankDetail, error)
 	GetBankDetailRepo() domain.BankDetailRepository
+
+	// Расписание работы реквизита
+	SetBankDetailSchedule(bankDetailID string, schedule *domain.Schedule) error
+	GetBankDetailSchedule(bankDetailID string) (*domain.Schedule, error)
+	DeleteBankDetailSchedule(bankDetailID string) error
+	// Что из реквизитов и трафика трейдера участвует в подборе прямо сейчас
+	GetEffectiveAvailability(traderID string) (*domain.TraderAvailability, error)
+}
+
+// BankDetailInvalidator - кэш, в котором есть реквизиты: его нужно обновить после изменения реквизита
+type BankDetailInvalidator interface {
+	InvalidateBankDetail(bankDetailID string)
 }
 
 type DefaultBankDetailUsecase struct {
 	bankDetailRepo domain.BankDetailRepository
+	trafficRepo    domain.TrafficRepository
+	// Справочник BIN для сверки банка карты, nil - без сверки
+	binRepo        domain.BinRepository
+	// Кэш каскада подбора реквизитов, nil - каскад выключен
+	cascade        BankDetailInvalidator
+}
+
+func NewDefaultBankDetailUsecase(bankDetailRepo domain.BankDetailRepository, trafficRepo domain.TrafficRepository, binRepo domain.BinRepository, cascade BankDetailInvalidator) *DefaultBankDetailUsecase {
+	return &DefaultBankDetailUsecase{bankDetailRepo: bankDetailRepo, trafficRepo: trafficRepo, binRepo: binRepo, cascade: cascade}
 }
 
-func NewDefaultBankDetailUsecase(bankDetailRepo domain.BankDetailRepository) *DefaultBankDetailUsecase {
-	return &DefaultBankDetailUsecase{bankDetailRepo: bankDetailRepo}
+// invalidate обновляет реквизит в кэше каскада после записи, даже неудачной: состояние БД после ошибки неизвестно
+func (uc *DefaultBankDetailUsecase) invalidate(bankDetailID string) {
+	if uc.cascade != nil {
+		uc.cascade.InvalidateBankDetail(bankDetailID)
+	}
 }
 
 // GetBankDetailRepo возвращает BankDetailRepository (для использования в транзакциях)
@@ -37,84 +65,167 @@ func (uc *DefaultBankDetailUsecase) GetBankDetailRepo() domain.BankDetailReposit
 }
 
 func (uc *DefaultBankDetailUsecase) CreateBankDetail(input *bankdetaildto.CreateBankDetailInput) error {
-	return uc.bankDetailRepo.CreateBankDetail(
-		&domain.BankDetail{
-			ID: uuid.New().String(),
-			SearchParams: domain.SearchParams{
-				MaxOrdersSimultaneosly: input.MaxOrdersSimultaneosly,
-				MaxAmountDay: input.MaxAmountDay,
-				MaxAmountMonth: input.MaxAmountMonth,
-				MaxQuantityDay: input.MaxQuantityDay,
-				MaxQuantityMonth: input.MaxQuantityMonth,
-				MinOrderAmount: input.MinOrderAmount,
-				MaxOrderAmount: input.MaxOrderAmount,
-				Delay: input.Delay,
-				Enabled: input.Enabled,
-			},
-			DeviceInfo: domain.DeviceInfo{
-				DeviceID: input.DeviceID,
-			},
-			TraderInfo: domain.TraderInfo{
-				TraderID: input.TraderID,
-			},
-			PaymentDetails: domain.PaymentDetails{
-				Phone: input.Phone,
-				CardNumber: input.CardNumber,
-				Owner: input.Owner,
-				PaymentSystem: input.PaymentSystem,
-				BankInfo: domain.BankInfo{
-					BankCode: input.BankCode,
-					BankName: input.BankName,
-					NspkCode: input.NspkCode,
-				},
+	if err := validateLimitParams(&input.SearchParams); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidBankDetail, err)
+	}
+	bankDetail := &domain.BankDetail{
+		ID: uuid.New().String(),
+		SearchParams: domain.SearchParams{
+			MaxOrdersSimultaneosly: input.MaxOrdersSimultaneosly,
+			MaxAmountDay: input.MaxAmountDay,
+			MaxAmountMonth: input.MaxAmountMonth,
+			MaxQuantityDay: input.MaxQuantityDay,
+			MaxQuantityMonth: input.MaxQuantityMonth,
+			MaxAmountHour: input.MaxAmountHour,
+			MaxQuantityHour: input.MaxQuantityHour,
+			MaxAmountWeek: input.MaxAmountWeek,
+			MaxQuantityWeek: input.MaxQuantityWeek,
+			MinOrderAmount: input.MinOrderAmount,
+			MaxOrderAmount: input.MaxOrderAmount,
+			Delay: input.Delay,
+			Enabled: input.Enabled,
+			LimitMode: domain.LimitMode(input.LimitMode),
+			Timezone: input.Timezone,
+		},
+		DeviceInfo: domain.DeviceInfo{
+			DeviceID: input.DeviceID,
+		},
+		TraderInfo: domain.TraderInfo{
+			TraderID: input.TraderID,
+		},
+		PaymentDetails: domain.PaymentDetails{
+			Phone: input.Phone,
+			CardNumber: input.CardNumber,
+			Owner: input.Owner,
+			PaymentSystem: input.PaymentSystem,
+			BankInfo: domain.BankInfo{
+				BankCode: input.BankCode,
+				BankName: input.BankName,
+				NspkCode: input.NspkCode,
 			},
-			Country: input.Country,
-			Currency: input.Currency,
-			InflowCurrency: input.InflowCurrency,
 		},
-	)
+		Country: input.Country,
+		Currency: input.Currency,
+		InflowCurrency: input.InflowCurrency,
+	}
+	if err := uc.checkPaymentDetails(bankDetail); err != nil {
+		return err
+	}
+	defer uc.invalidate(bankDetail.ID)
+	return uc.bankDetailRepo.CreateBankDetail(bankDetail)
 }
 
 func (uc *DefaultBankDetailUsecase) UpdateBankDetail(input *bankdetaildto.UpdateBankDetailInput) error {
-	return uc.bankDetailRepo.UpdateBankDetail(
-		&domain.BankDetail{
-			ID: input.ID,
-			SearchParams: domain.SearchParams{
-				MaxOrdersSimultaneosly: input.MaxOrdersSimultaneosly,
-				MaxAmountDay: input.MaxAmountDay,
-				MaxAmountMonth: input.MaxAmountMonth,
-				MaxQuantityDay: input.MaxQuantityDay,
-				MaxQuantityMonth: input.MaxQuantityMonth,
-				MinOrderAmount: input.MinOrderAmount,
-				MaxOrderAmount: input.MaxOrderAmount,
-				Delay: input.Delay,
-				Enabled: input.Enabled,
-			},
-			DeviceInfo: domain.DeviceInfo{
-				DeviceID: input.DeviceID,
-			},
-			TraderInfo: domain.TraderInfo{
-				TraderID: input.TraderID,
-			},
-			PaymentDetails: domain.PaymentDetails{
-				Phone: input.Phone,
-				CardNumber: input.CardNumber,
-				Owner: input.Owner,
-				PaymentSystem: input.PaymentSystem,
-				BankInfo: domain.BankInfo{
-					BankCode: input.BankCode,
-					BankName: input.BankName,
-					NspkCode: input.NspkCode,
-				},
+	if err := validateLimitParams(&input.SearchParams); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidBankDetail, err)
+	}
+	bankDetail := &domain.BankDetail{
+		ID: input.ID,
+		SearchParams: domain.SearchParams{
+			MaxOrdersSimultaneosly: input.MaxOrdersSimultaneosly,
+			MaxAmountDay: input.MaxAmountDay,
+			MaxAmountMonth: input.MaxAmountMonth,
+			MaxQuantityDay: input.MaxQuantityDay,
+			MaxQuantityMonth: input.MaxQuantityMonth,
+			MaxAmountHour: input.MaxAmountHour,
+			MaxQuantityHour: input.MaxQuantityHour,
+			MaxAmountWeek: input.MaxAmountWeek,
+			MaxQuantityWeek: input.MaxQuantityWeek,
+			MinOrderAmount: input.MinOrderAmount,
+			MaxOrderAmount: input.MaxOrderAmount,
+			Delay: input.Delay,
+			Enabled: input.Enabled,
+			LimitMode: domain.LimitMode(input.LimitMode),
+			Timezone: input.Timezone,
+		},
+		DeviceInfo: domain.DeviceInfo{
+			DeviceID: input.DeviceID,
+		},
+		TraderInfo: domain.TraderInfo{
+			TraderID: input.TraderID,
+		},
+		PaymentDetails: domain.PaymentDetails{
+			Phone: input.Phone,
+			CardNumber: input.CardNumber,
+			Owner: input.Owner,
+			PaymentSystem: input.PaymentSystem,
+			BankInfo: domain.BankInfo{
+				BankCode: input.BankCode,
+				BankName: input.BankName,
+				NspkCode: input.NspkCode,
 			},
-			Country: input.Country,
-			Currency: input.Currency,
-			InflowCurrency: input.InflowCurrency,
 		},
-	)
+		Country: input.Country,
+		Currency: input.Currency,
+		InflowCurrency: input.InflowCurrency,
+	}
+	if err := uc.checkPaymentDetails(bankDetail); err != nil {
+		return err
+	}
+	defer uc.invalidate(bankDetail.ID)
+	return uc.bankDetailRepo.UpdateBankDetail(bankDetail)
+}
+
+// checkPaymentDetails нормализует номер карты и телефон, проверяет обязательные поля платежной системы
+// и сверяет банк карты со справочником BIN. Не указанные трейдером банк и коды берутся из справочника,
+// расхождение с указанными помечается BinMismatch: реквизит сохраняется, решение за саппортом
+func (uc *DefaultBankDetailUsecase) checkPaymentDetails(bankDetail *domain.BankDetail) error {
+	if err := bankDetail.PaymentDetails.Normalize(); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidBankDetail, err)
+	}
+	if uc.binRepo == nil || bankDetail.CardNumber == "" {
+		return nil
+	}
+
+	bin, err := uc.binRepo.FindBin(bankDetail.CardNumber)
+	if err != nil {
+		// Справочник только подсказывает банк и не должен мешать сохранить реквизит
+		slog.Error("failed to look up card bin", "bank_detail_id", bankDetail.ID, "error", err)
+		return nil
+	}
+	if bin == nil {
+		return nil
+	}
+	if !bin.Matches(bankDetail.BankInfo) {
+		bankDetail.BinMismatch = true
+		slog.Warn("bank detail bank does not match bin directory",
+			"bank_detail_id", bankDetail.ID,
+			"trader_id", bankDetail.TraderID,
+			"declared_bank_code", bankDetail.BankCode,
+			"declared_bank_name", bankDetail.BankName,
+			"bin_bank_code", bin.BankCode,
+			"bin_bank_name", bin.BankName,
+		)
+		return nil
+	}
+	if bankDetail.BankName == "" {
+		bankDetail.BankName = bin.BankName
+	}
+	if bankDetail.BankCode == "" {
+		bankDetail.BankCode = bin.BankCode
+	}
+	if bankDetail.NspkCode == "" {
+		bankDetail.NspkCode = bin.NspkCode
+	}
+	return nil
+}
+
+// validateLimitParams проверяет режим окон лимитов и часовой пояс: при подборе неизвестный пояс молча заменился бы поясом сервера
+func validateLimitParams(params *bankdetaildto.SearchParams) error {
+	if !domain.LimitMode(params.LimitMode).Valid() {
+		return fmt.Errorf("unknown limit mode: %s", params.LimitMode)
+	}
+	if err := domain.ValidateTimezone(params.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", params.Timezone, err)
+	}
+	if params.MaxAmountHour < 0 || params.MaxQuantityHour < 0 || params.MaxAmountWeek < 0 || params.MaxQuantityWeek < 0 {
+		return fmt.Errorf("hour and week limits must not be negative")
+	}
+	return nil
 }
 
 func (uc *DefaultBankDetailUsecase) DeleteBankDetail(bankDetailID string) error {
+	defer uc.invalidate(bankDetailID)
 	return uc.bankDetailRepo.DeleteBankDetail(bankDetailID)
 }
 
@@ -196,4 +307,102 @@ func (uc *DefaultBankDetailUsecase) FindSuitableBankDetailsWithLock(input *bankd
             Currency:      input.Currency,
         },
     )
-}
\ No newline at end of file
+}
+
+func (uc *DefaultBankDetailUsecase) SetBankDetailSchedule(bankDetailID string, schedule *domain.Schedule) error {
+	if bankDetailID == "" {
+		return fmt.Errorf("bank_detail_id is required")
+	}
+	if schedule == nil {
+		return fmt.Errorf("schedule is required")
+	}
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	defer uc.invalidate(bankDetailID)
+	return uc.bankDetailRepo.SetBankDetailSchedule(bankDetailID, schedule)
+}
+
+// GetBankDetailSchedule возвращает расписание реквизита, nil - расписания нет
+func (uc *DefaultBankDetailUsecase) GetBankDetailSchedule(bankDetailID string) (*domain.Schedule, error) {
+	bankDetail, err := uc.bankDetailRepo.GetBankDetailByID(bankDetailID)
+	if err != nil {
+		return nil, err
+	}
+	if bankDetail.ID == "" {
+		return nil, fmt.Errorf("bank detail %s not found", bankDetailID)
+	}
+	return bankDetail.Schedule, nil
+}
+
+func (uc *DefaultBankDetailUsecase) DeleteBankDetailSchedule(bankDetailID string) error {
+	if bankDetailID == "" {
+		return fmt.Errorf("bank_detail_id is required")
+	}
+	defer uc.invalidate(bankDetailID)
+	return uc.bankDetailRepo.SetBankDetailSchedule(bankDetailID, nil)
+}
+
+// GetEffectiveAvailability проверяет реквизиты и трафик трейдера на текущий момент: включены ли они,
+// не заблокирован ли трафик и попадает ли время в расписание. Лимиты и баланс не проверяются
+func (uc *DefaultBankDetailUsecase) GetEffectiveAvailability(traderID string) (*domain.TraderAvailability, error) {
+	if traderID == "" {
+		return nil, fmt.Errorf("trader_id is required")
+	}
+
+	// Limit -1 - все реквизиты трейдера без пагинации
+	bankDetails, _, err := uc.bankDetailRepo.GetBankDetails(domain.GetBankDetailsFilter{
+		TraderID: &traderID,
+		Page: 1,
+		Limit: -1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trader bank details: %w", err)
+	}
+	traffics, err := uc.trafficRepo.GetTrafficByTraderID(traderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trader traffic: %w", err)
+	}
+
+	now := time.Now()
+	availability := &domain.TraderAvailability{
+		TraderID: traderID,
+		CheckedAt: now,
+		BankDetails: make([]*domain.BankDetailAvailability, len(bankDetails)),
+		Traffic: make([]*domain.TrafficAvailability, len(traffics)),
+	}
+	for i, bankDetail := range bankDetails {
+		item := &domain.BankDetailAvailability{
+			BankDetailID: bankDetail.ID,
+			Enabled: bankDetail.Enabled,
+			InSchedule: bankDetail.Schedule.IsAvailable(now),
+		}
+		switch {
+		case !item.Enabled:
+			item.Reason = "disabled"
+		case bankDetail.IsSuspended(now):
+			item.Reason = "suspended"
+		case !item.InSchedule:
+			item.Reason = "outside_schedule"
+		}
+		item.Available = item.Reason == ""
+		availability.BankDetails[i] = item
+	}
+	for i, traffic := range traffics {
+		item := &domain.TrafficAvailability{
+			TrafficID: traffic.ID,
+			MerchantID: traffic.MerchantID,
+			Enabled: traffic.Enabled,
+			InSchedule: traffic.Schedule.IsAvailable(now),
+		}
+		if !item.Enabled {
+			item.Reason = "disabled"
+		} else {
+			item.Reason = traffic.UnavailableReason(now)
+		}
+		item.Available = item.Reason == ""
+		availability.Traffic[i] = item
+	}
+
+	return availability, nil
+}
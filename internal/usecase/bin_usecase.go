@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+type BinUsecase interface {
+	// Банк-эмитент по номеру карты или его префиксу, nil - банка нет в справочнике
+	LookupBin(cardNumber string) (*domain.BinRecord, error)
+	// Загружает справочник из CSV с заголовком, возвращает количество сохраненных записей
+	ImportBins(reader io.Reader) (int, error)
+}
+
+type DefaultBinUsecase struct {
+	binRepo domain.BinRepository
+}
+
+func NewDefaultBinUsecase(binRepo domain.BinRepository) *DefaultBinUsecase {
+	return &DefaultBinUsecase{binRepo: binRepo}
+}
+
+func (uc *DefaultBinUsecase) LookupBin(cardNumber string) (*domain.BinRecord, error) {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(cardNumber))
+	if len(digits) < domain.MinBinLength || strings.Trim(digits, "0123456789") != "" {
+		return nil, fmt.Errorf("card number must start with at least %d digits", domain.MinBinLength)
+	}
+	return uc.binRepo.FindBin(digits)
+}
+
+// Колонки CSV справочника BIN. Обязателен только prefix, порядок колонок задается заголовком
+var binColumns = []string{"prefix", "bank_name", "bank_code", "nspk_code", "payment_network", "country"}
+
+// binImportBatch - сколько записей сохраняется за раз при импорте
+const binImportBatch = 1000
+
+func (uc *DefaultBinUsecase) ImportBins(reader io.Reader) (int, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	csvReader.FieldsPerRecord = -1
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read bin csv header: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		index[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	if _, ok := index["prefix"]; !ok {
+		return 0, fmt.Errorf("bin csv must have a prefix column")
+	}
+
+	now := time.Now()
+	imported := 0
+	batch := make([]*domain.BinRecord, 0, binImportBatch)
+	// Повтор префикса в одной пачке ломает upsert, поэтому побеждает последняя строка
+	inBatch := make(map[string]int, binImportBatch)
+	for line := 2; ; line++ {
+		row, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return imported, fmt.Errorf("failed to read bin csv line %d: %w", line, err)
+		}
+
+		values := make(map[string]string, len(binColumns))
+		for _, column := range binColumns {
+			if i, ok := index[column]; ok && i < len(row) {
+				values[column] = strings.TrimSpace(row[i])
+			}
+		}
+		prefix := values["prefix"]
+		if len(prefix) < domain.MinBinLength || len(prefix) > domain.MaxBinLength || strings.Trim(prefix, "0123456789") != "" {
+			return imported, fmt.Errorf("invalid bin prefix %q at line %d, expected %d to %d digits", prefix, line, domain.MinBinLength, domain.MaxBinLength)
+		}
+		record := &domain.BinRecord{
+			Prefix:         prefix,
+			BankName:       values["bank_name"],
+			BankCode:       values["bank_code"],
+			NspkCode:       values["nspk_code"],
+			PaymentNetwork: strings.ToUpper(values["payment_network"]),
+			Country:        strings.ToUpper(values["country"]),
+			UpdatedAt:      now,
+		}
+		if i, ok := inBatch[prefix]; ok {
+			batch[i] = record
+			continue
+		}
+		inBatch[prefix] = len(batch)
+		batch = append(batch, record)
+
+		if len(batch) == binImportBatch {
+			saved, err := uc.binRepo.UpsertBins(batch)
+			imported += saved
+			if err != nil {
+				return imported, err
+			}
+			batch = batch[:0]
+			inBatch = make(map[string]int, binImportBatch)
+		}
+	}
+
+	saved, err := uc.binRepo.UpsertBins(batch)
+	return imported + saved, err
+}
@@ -0,0 +1,440 @@
+package cascade
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+// BankDetailView - реквизит с денормализованными счетчиками сделок для быстрой проверки лимитов
+type BankDetailView struct {
+	BankDetail *domain.BankDetail
+
+	// Денормализованные счетчики (PENDING и COMPLETED, как в SQL-подборе)
+	PendingCount int32
+	PeriodCounters
+	LastCompletedAt *time.Time
+	// Окна лимитов реквизита, в которых посчитаны PeriodCounters
+	Windows domain.LimitWindows
+
+	// Суммы активных сделок для проверки сделки на ту же сумму
+	PendingAmounts map[float64]int
+}
+
+// PeriodCounters - количество и сумма сделок реквизита в окнах его лимитов
+type PeriodCounters struct {
+	HourCount   int32
+	HourAmount  float64
+	DayCount    int32
+	DayAmount   float64
+	WeekCount   int32
+	WeekAmount  float64
+	MonthCount  int32
+	MonthAmount float64
+}
+
+// add учитывает (sign = 1) или убирает (sign = -1) сделку в окнах, в которые она попадает
+func (p *PeriodCounters) add(windows domain.LimitWindows, createdAt time.Time, amount float64, sign int32) {
+	if !createdAt.Before(windows.HourStart) {
+		p.HourCount += sign
+		p.HourAmount += float64(sign) * amount
+	}
+	if !createdAt.Before(windows.DayStart) {
+		p.DayCount += sign
+		p.DayAmount += float64(sign) * amount
+	}
+	if !createdAt.Before(windows.WeekStart) {
+		p.WeekCount += sign
+		p.WeekAmount += float64(sign) * amount
+	}
+	if !createdAt.Before(windows.MonthStart) {
+		p.MonthCount += sign
+		p.MonthAmount += float64(sign) * amount
+	}
+}
+
+// since - счетчики, обнуленные для окон, которые начались заново после from
+func (p PeriodCounters) since(from, to domain.LimitWindows) PeriodCounters {
+	if to.HourStart.After(from.HourStart) {
+		p.HourCount, p.HourAmount = 0, 0
+	}
+	if to.DayStart.After(from.DayStart) {
+		p.DayCount, p.DayAmount = 0, 0
+	}
+	if to.WeekStart.After(from.WeekStart) {
+		p.WeekCount, p.WeekAmount = 0, 0
+	}
+	if to.MonthStart.After(from.MonthStart) {
+		p.MonthCount, p.MonthAmount = 0, 0
+	}
+	return p
+}
+
+// pendingOrder - вклад активной сделки в счетчики реквизита и кэшированный баланс трейдера
+type pendingOrder struct {
+	BankDetailID string
+	TraderID     string
+	AmountFiat   float64
+	AmountCrypto float64
+	CreatedAt    time.Time
+}
+
+// Snapshot - данные для полной перезагрузки кэша
+type Snapshot struct {
+	BankDetails   []*domain.BankDetail
+	Traffic       []*domain.Traffic
+	Counters      []*domain.BankDetailCounters
+	PendingOrders []*domain.Order
+	Balances      map[string]float64
+	// Момент, на который посчитаны Counters: от него отсчитываются окна лимитов реквизитов
+	CountedAt time.Time
+}
+
+// BankDetailCache - in-memory кэш включенных реквизитов. Полностью перезагружается CacheUpdater,
+// между перезагрузками счетчики обновляются инкрементально при создании и закрытии сделок
+type BankDetailCache struct {
+	mutex        sync.RWMutex
+	byCurrencyPS map[string]map[string][]*BankDetailView // currency -> payment_system -> реквизиты
+	byID         map[string]*BankDetailView
+	traffic      map[string]*domain.Traffic // trader_id:merchant_id
+	balances     map[string]float64         // trader_id -> доступный баланс
+	pending      map[string]*pendingOrder   // order_id -> активная сделка
+	lastUpdated  time.Time
+	// Растет при каждом точечном обновлении реквизита или трафика
+	generation uint64
+}
+
+func NewBankDetailCache() *BankDetailCache {
+	return &BankDetailCache{
+		byCurrencyPS: make(map[string]map[string][]*BankDetailView),
+		byID:         make(map[string]*BankDetailView),
+		traffic:      make(map[string]*domain.Traffic),
+		balances:     make(map[string]float64),
+		pending:      make(map[string]*pendingOrder),
+	}
+}
+
+func trafficKey(traderID, merchantID string) string {
+	return traderID + ":" + merchantID
+}
+
+// roundAmount - суммы сделок сравниваются с точностью до копеек
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// Generation - номер поколения кэша. Его нужно запомнить до загрузки снимка и передать
+// в ReplaceIfUnchanged, чтобы снимок, прочитанный до точечного обновления, его не затер
+func (c *BankDetailCache) Generation() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.generation
+}
+
+// Replace атомарно заменяет содержимое кэша снимком из БД
+func (c *BankDetailCache) Replace(snapshot *Snapshot) {
+	c.replace(snapshot, nil)
+}
+
+// ReplaceIfUnchanged заменяет содержимое кэша снимком, если с момента generation кэш не обновлялся точечно
+func (c *BankDetailCache) ReplaceIfUnchanged(generation uint64, snapshot *Snapshot) bool {
+	return c.replace(snapshot, &generation)
+}
+
+func (c *BankDetailCache) replace(snapshot *Snapshot, generation *uint64) bool {
+	byCurrencyPS := make(map[string]map[string][]*BankDetailView)
+	byID := make(map[string]*BankDetailView, len(snapshot.BankDetails))
+	for _, bankDetail := range snapshot.BankDetails {
+		view := &BankDetailView{
+			BankDetail:     bankDetail,
+			Windows:        bankDetail.LimitWindows(snapshot.CountedAt),
+			PendingAmounts: make(map[float64]int),
+		}
+		byID[bankDetail.ID] = view
+		if byCurrencyPS[bankDetail.Currency] == nil {
+			byCurrencyPS[bankDetail.Currency] = make(map[string][]*BankDetailView)
+		}
+		byCurrencyPS[bankDetail.Currency][bankDetail.PaymentSystem] = append(byCurrencyPS[bankDetail.Currency][bankDetail.PaymentSystem], view)
+	}
+
+	for _, counters := range snapshot.Counters {
+		view, ok := byID[counters.BankDetailID]
+		if !ok {
+			continue
+		}
+		view.PendingCount = counters.PendingCount
+		view.PeriodCounters = PeriodCounters{
+			HourCount:   counters.HourCount,
+			HourAmount:  counters.HourAmount,
+			DayCount:    counters.DayCount,
+			DayAmount:   counters.DayAmount,
+			WeekCount:   counters.WeekCount,
+			WeekAmount:  counters.WeekAmount,
+			MonthCount:  counters.MonthCount,
+			MonthAmount: counters.MonthAmount,
+		}
+		view.LastCompletedAt = counters.LastCompletedAt
+	}
+
+	pending := make(map[string]*pendingOrder, len(snapshot.PendingOrders))
+	for _, order := range snapshot.PendingOrders {
+		if order.BankDetailID == nil {
+			continue
+		}
+		pending[order.ID] = newPendingOrder(order)
+		if view, ok := byID[*order.BankDetailID]; ok {
+			view.PendingAmounts[roundAmount(order.AmountInfo.AmountFiat)]++
+		}
+	}
+
+	traffic := make(map[string]*domain.Traffic, len(snapshot.Traffic))
+	for _, record := range snapshot.Traffic {
+		traffic[trafficKey(record.TraderID, record.MerchantID)] = record
+	}
+
+	balances := snapshot.Balances
+	if balances == nil {
+		balances = make(map[string]float64)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if generation != nil && *generation != c.generation {
+		return false
+	}
+	c.byCurrencyPS = byCurrencyPS
+	c.byID = byID
+	c.traffic = traffic
+	c.balances = balances
+	c.pending = pending
+	c.lastUpdated = time.Now()
+	return true
+}
+
+// PutBankDetail добавляет или заменяет реквизит со счетчиками, посчитанными на момент countedAt
+// (counters = nil - сделок по реквизиту нет). Суммы активных сделок берутся из кэша
+func (c *BankDetailCache) PutBankDetail(bankDetail *domain.BankDetail, counters *domain.BankDetailCounters, countedAt time.Time) {
+	view := &BankDetailView{
+		BankDetail:     bankDetail,
+		Windows:        bankDetail.LimitWindows(countedAt),
+		PendingAmounts: make(map[float64]int),
+	}
+	if counters != nil {
+		view.PendingCount = counters.PendingCount
+		view.PeriodCounters = PeriodCounters{
+			HourCount:   counters.HourCount,
+			HourAmount:  counters.HourAmount,
+			DayCount:    counters.DayCount,
+			DayAmount:   counters.DayAmount,
+			WeekCount:   counters.WeekCount,
+			WeekAmount:  counters.WeekAmount,
+			MonthCount:  counters.MonthCount,
+			MonthAmount: counters.MonthAmount,
+		}
+		view.LastCompletedAt = counters.LastCompletedAt
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+
+	for _, ref := range c.pending {
+		if ref.BankDetailID == bankDetail.ID {
+			view.PendingAmounts[roundAmount(ref.AmountFiat)]++
+		}
+	}
+	c.removeBankDetail(bankDetail.ID)
+	c.byID[bankDetail.ID] = view
+	if c.byCurrencyPS[bankDetail.Currency] == nil {
+		c.byCurrencyPS[bankDetail.Currency] = make(map[string][]*BankDetailView)
+	}
+	c.byCurrencyPS[bankDetail.Currency][bankDetail.PaymentSystem] = append(c.byCurrencyPS[bankDetail.Currency][bankDetail.PaymentSystem], view)
+}
+
+// RemoveBankDetail убирает реквизит из подбора до следующей перезагрузки кэша
+func (c *BankDetailCache) RemoveBankDetail(bankDetailID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+	c.removeBankDetail(bankDetailID)
+}
+
+// removeBankDetail вызывается под записью
+func (c *BankDetailCache) removeBankDetail(bankDetailID string) {
+	view, ok := c.byID[bankDetailID]
+	if !ok {
+		return
+	}
+	delete(c.byID, bankDetailID)
+
+	byPS := c.byCurrencyPS[view.BankDetail.Currency]
+	views := byPS[view.BankDetail.PaymentSystem]
+	remaining := make([]*BankDetailView, 0, len(views))
+	for _, other := range views {
+		if other != view {
+			remaining = append(remaining, other)
+		}
+	}
+	byPS[view.BankDetail.PaymentSystem] = remaining
+}
+
+// ReplaceTraffic заменяет записи трафика, для которых match возвращает true, записями traffic
+func (c *BankDetailCache) ReplaceTraffic(match func(traffic *domain.Traffic) bool, traffic []*domain.Traffic) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+
+	for key, record := range c.traffic {
+		if match(record) {
+			delete(c.traffic, key)
+		}
+	}
+	for _, record := range traffic {
+		c.traffic[trafficKey(record.TraderID, record.MerchantID)] = record
+	}
+}
+
+// LastUpdated - время последней полной перезагрузки
+func (c *BankDetailCache) LastUpdated() time.Time {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.lastUpdated
+}
+
+// Size - количество реквизитов в кэше
+func (c *BankDetailCache) Size() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.byID)
+}
+
+func newPendingOrder(order *domain.Order) *pendingOrder {
+	createdAt := order.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	return &pendingOrder{
+		BankDetailID: *order.BankDetailID,
+		TraderID:     order.RequisiteDetails.TraderID,
+		AmountFiat:   order.AmountInfo.AmountFiat,
+		AmountCrypto: order.AmountInfo.AmountCrypto,
+		CreatedAt:    createdAt,
+	}
+}
+
+// OrderAssigned учитывает сделку, назначенную на реквизит (создание или перенос на другой реквизит).
+// Средства трейдера замораживаются, поэтому кэшированный баланс уменьшается на сумму сделки
+func (c *BankDetailCache) OrderAssigned(order *domain.Order) {
+	if order.BankDetailID == nil || order.Status != domain.StatusPending {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if previous, ok := c.pending[order.ID]; ok {
+		c.releasePending(previous, false)
+	}
+
+	ref := newPendingOrder(order)
+	c.pending[order.ID] = ref
+	c.balances[ref.TraderID] -= ref.AmountCrypto
+
+	view, ok := c.byID[ref.BankDetailID]
+	if !ok {
+		return
+	}
+	rollover(view, time.Now())
+	view.PendingCount++
+	view.PendingAmounts[roundAmount(ref.AmountFiat)]++
+	view.add(view.Windows, ref.CreatedAt, ref.AmountFiat, 1)
+}
+
+// OrderStatusChanged учитывает закрытие активной сделки. Завершенная сделка остается в счетчиках
+// окон лимитов, отмененная - убирается из них, и средства возвращаются в баланс трейдера
+func (c *BankDetailCache) OrderStatusChanged(orderID string, status domain.OrderStatus) {
+	if status == domain.StatusPending {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ref, ok := c.pending[orderID]
+	if !ok {
+		return
+	}
+	delete(c.pending, orderID)
+	c.releasePending(ref, status == domain.StatusCompleted)
+}
+
+// releasePending убирает вклад активной сделки из счетчиков реквизита
+func (c *BankDetailCache) releasePending(ref *pendingOrder, completed bool) {
+	if !completed {
+		c.balances[ref.TraderID] += ref.AmountCrypto
+	}
+
+	view, ok := c.byID[ref.BankDetailID]
+	if !ok {
+		return
+	}
+	rollover(view, time.Now())
+	if view.PendingCount > 0 {
+		view.PendingCount--
+	}
+	amount := roundAmount(ref.AmountFiat)
+	if view.PendingAmounts[amount] <= 1 {
+		delete(view.PendingAmounts, amount)
+	} else {
+		view.PendingAmounts[amount]--
+	}
+
+	if completed {
+		// Как и в SQL-подборе, задержка отсчитывается от создания последней завершенной сделки
+		if view.LastCompletedAt == nil || view.LastCompletedAt.Before(ref.CreatedAt) {
+			createdAt := ref.CreatedAt
+			view.LastCompletedAt = &createdAt
+		}
+		return
+	}
+
+	view.add(view.Windows, ref.CreatedAt, ref.AmountFiat, -1)
+}
+
+// BankDetailSuspended применяет приостановку реквизита (until = nil - снятие), не дожидаясь перезагрузки кэша.
+// Реквизит подменяется копией: прежний указатель мог уйти из кэша в результаты подбора
+func (c *BankDetailCache) BankDetailSuspended(bankDetailID string, until *time.Time, reason string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	view, ok := c.byID[bankDetailID]
+	if !ok {
+		return
+	}
+	bankDetail := *view.BankDetail
+	bankDetail.SuspendedUntil = until
+	bankDetail.SuspendReason = reason
+	view.BankDetail = &bankDetail
+}
+
+// rollover обнуляет счетчики календарных окон реквизита, которые начались заново с момента подсчета.
+// Вызывается под записью. Скользящие окна сдвигаются непрерывно и пересчитываются при полной
+// перезагрузке кэша: до нее вышедшие из окна сделки остаются в счетчиках, и лимиты проверяются с запасом
+func rollover(view *BankDetailView, now time.Time) {
+	if view.BankDetail.LimitMode == domain.LimitModeRolling {
+		return
+	}
+	windows := view.BankDetail.LimitWindows(now)
+	view.PeriodCounters = view.PeriodCounters.since(view.Windows, windows)
+	view.Windows = windows
+}
+
+// periodCounters возвращает счетчики реквизита с учетом смены календарных окон, которую кэш еще не обработал
+func (c *BankDetailCache) periodCounters(view *BankDetailView, now time.Time) PeriodCounters {
+	if view.BankDetail.LimitMode == domain.LimitModeRolling {
+		return view.PeriodCounters
+	}
+	return view.PeriodCounters.since(view.Windows, view.BankDetail.LimitWindows(now))
+}
@@ -1,360 +1,122 @@
 package cascade
 
-// import (
-// 	"context"
-// 	"fmt"
-// 	"log/slog"
-// 	"math/rand/v2"
-// 	"sort"
-// 	"sync"
-// 	"time"
-
-// 	"github.com/LavaJover/shvark-order-service/internal/delivery/http/handlers"
-// 	"github.com/LavaJover/shvark-order-service/internal/domain"
-// 	metrics "github.com/rcrowley/go-metrics"
-// 	"gorm.io/gorm"
-// )
-
-// // Каскадный engine для подбора реквизитов
-// type CascadeEngine struct {
-//     filters        []Filter
-//     scorer         Scorer
-//     cache          Cache
-//     walletService  handlers.HTTPWalletHandler
-//     trafficRepo    domain.TrafficRepository
-// }
-
-// // Фильтры применяются последовательно, от быстрых к медленным
-// type Filter interface {
-//     Filter(ctx context.Context, req *MatchRequest, candidates []*BankDetail) ([]*BankDetail, error)
-//     Priority() int // Приоритет выполнения (чем меньше - тем раньше)
-// }
-
-// // Scorer для взвешенного выбора
-// type Scorer interface {
-//     Score(bankDetail *domain.BankDetail, traffic *domain.Traffic) float64
-//     Select(candidates []*domain.BankDetail) *domain.BankDetail
-// }
-
-// type Cache struct {
-
-// }
-
-// // ----------------------------------------- Оптимизированные структуры данных
-
-// // Денормализованная модель для быстрого поиска
-// type BankDetailView struct {
-//     ID                    string    `json:"id"`
-//     TraderID              string    `json:"trader_id"`
-//     Currency              string    `json:"currency"`
-//     PaymentSystem         string    `json:"payment_system"`
-//     MinAmount            float64   `json:"min_amount"`
-//     MaxAmount            float64   `json:"max_amount"`
-//     BankCode             string    `json:"bank_code"`
-//     NspkCode             string    `json:"nspk_code"`
-//     Enabled              bool      `json:"enabled"`
-// 	MaxOrdersSimultaneosly  int32
-// 	MaxAmountDay			float64
-// 	MaxAmountMonth  		float64
-// 	MaxQuantityDay			int32
-// 	MaxQuantityMonth		int32
-// 	MinOrderAmount 			float32
-// 	MaxOrderAmount 			float32
-// 	Delay 					time.Duration
-    
-//     // Денормализованные поля из Traffic
-//     TrafficEnabled       bool      `json:"traffic_enabled"`
-//     TraderPriority       float64   `json:"trader_priority"`
-//     TraderRewardPercent  float64   `json:"trader_reward_percent"`
-//     PlatformFee          float64   `json:"platform_fee"`
-    
-//     // Денормализованные счетчики
-//     CurrentPendingCount  int32     `json:"current_pending_count"`
-//     TodayOrdersCount     int32     `json:"today_orders_count"`
-//     TodayOrdersAmount    float64   `json:"today_orders_amount"`
-//     MonthOrdersCount     int32     `json:"month_orders_count"`
-//     MonthOrdersAmount    float64   `json:"month_orders_amount"`
-//     LastCompletedAt      *time.Time `json:"last_completed_at"`
-    
-//     // Кэшированный баланс (обновляется раз в 30 сек)
-//     CachedBalance        float64   `json:"cached_balance"`
-//     BalanceUpdatedAt     time.Time `json:"balance_updated_at"`
-// }
-
-// // In-memory кэш для быстрого доступа
-// type BankDetailCache struct {
-//     byCurrencyPS     map[string]map[string][]*BankDetailView // currency -> payment_system -> []bank_details
-//     byTrader         map[string]*BankDetailView
-//     mutex            sync.RWMutex
-//     lastUpdated      time.Time
-// }
-
-
-// // ------------------------------- Каскадные фильтры
-
-// // 1. Быстрый статический фильтр (in-memory)
-// type StaticFilter struct {
-//     cache *BankDetailCache
-// }
-
-// func (f *StaticFilter) Filter(ctx context.Context, req *MatchRequest, candidates []*BankDetailView) ([]*BankDetailView, error) {
-//     // Используем предварительно отфильтрованные данные из кэша
-//     key := fmt.Sprintf("%s:%s", req.Currency, req.PaymentSystem)
-    
-//     f.cache.mutex.RLock()
-//     defer f.cache.mutex.RUnlock()
-    
-//     currencyMap, ok := f.cache.byCurrencyPS[req.Currency]
-//     if !ok {
-//         return nil, nil
-//     }
-    
-//     candidates, ok = currencyMap[req.PaymentSystem]
-//     if !ok {
-//         return nil, nil
-//     }
-    
-//     // Фильтрация по сумме и банковским кодам
-//     var filtered []*BankDetailView
-//     for _, bd := range candidates {
-//         if bd.Enabled && bd.TrafficEnabled &&
-//            req.AmountFiat >= bd.MinAmount && req.AmountFiat <= bd.MaxAmount {
-            
-//             if req.BankCode == "" || bd.BankCode == req.BankCode {
-//                 if req.NspkCode == "" || bd.NspkCode == req.NspkCode {
-//                     filtered = append(filtered, bd)
-//                 }
-//             }
-//         }
-//     }
-    
-//     return filtered, nil
-// }
-
-// func (f *StaticFilter) Priority() int { return 1 }
-
-// // 2. Фильтр по лимитам (быстрая проверка денормализованных данных)
-// type LimitsFilter struct{}
-
-// func (f *LimitsFilter) Filter(ctx context.Context, req *MatchRequest, candidates []*BankDetailView) ([]*BankDetailView, error) {
-//     var filtered []*BankDetailView
-    
-//     for _, bd := range candidates {
-//         if bd.CurrentPendingCount >= bd.MaxOrdersSimultaneosly {
-//             continue
-//         }
-//         if bd.TodayOrdersCount+1 > bd.MaxQuantityDay {
-//             continue
-//         }
-//         if bd.TodayOrdersAmount+req.AmountFiat > bd.MaxAmountDay {
-//             continue
-//         }
-//         if bd.MonthOrdersCount+1 > bd.MaxQuantityMonth {
-//             continue
-//         }
-//         if bd.MonthOrdersAmount+req.AmountFiat > bd.MaxAmountMonth {
-//             continue
-//         }
-        
-//         // Проверка задержки
-//         if bd.LastCompletedAt != nil {
-//             requiredDelay := time.Duration(bd.Delay) * time.Second
-//             if time.Since(*bd.LastCompletedAt) < requiredDelay {
-//                 continue
-//             }
-//         }
-        
-//         filtered = append(filtered, bd)
-//     }
-    
-//     return filtered, nil
-// }
-
-// func (f *LimitsFilter) Priority() int { return 2 }
-
-// // 3. Фильтр по балансу (с кэшированием)
-// type BalanceFilter struct {
-//     walletService handlers.HTTPWalletHandler
-// }
-
-// func (f *BalanceFilter) Filter(ctx context.Context, req *MatchRequest, candidates []*BankDetailView) ([]*BankDetailView, error) {
-//     if len(candidates) == 0 {
-//         return candidates, nil
-//     }
-    
-//     // Группируем по трейдерам для пакетного запроса
-//     traderIDs := make([]string, 0, len(candidates))
-//     traderMap := make(map[string][]*BankDetailView)
-    
-//     for _, bd := range candidates {
-//         traderMap[bd.TraderID] = append(traderMap[bd.TraderID], bd)
-//         traderIDs = append(traderIDs, bd.TraderID)
-//     }
-    
-//     // Пакетный запрос балансов
-//     balances, err := f.walletService.GetBatchBalances(ctx, traderIDs)
-//     if err != nil {
-//         return nil, err
-//     }
-    
-//     var filtered []*BankDetailView
-//     for traderID, traderCandidates := range traderMap {
-//         balance, exists := balances[traderID]
-//         if !exists || balance < req.AmountCrypto {
-//             continue
-//         }
-        
-//         filtered = append(filtered, traderCandidates...)
-//     }
-    
-//     return filtered, nil
-// }
-
-// func (f *BalanceFilter) Priority() int { return 3 }
-
-
-// // --------------------------------- Система взвешенного отбора
-
-// type WeightedSelector struct {
-//     random *rand.Rand
-// }
-
-// func (s *WeightedSelector) Select(candidates []*BankDetailView) *BankDetailView {
-//     if len(candidates) == 0 {
-//         return nil
-//     }
-//     if len(candidates) == 1 {
-//         return candidates[0]
-//     }
-    
-//     // Вычисляем общий вес (сумму приоритетов)
-//     totalWeight := 0.0
-//     for _, candidate := range candidates {
-//         totalWeight += candidate.TraderPriority
-//     }
-    
-//     // Взвешенный случайный выбор
-//     r := s.random.Float64() * totalWeight
-//     cumulative := 0.0
-    
-//     for _, candidate := range candidates {
-//         cumulative += candidate.TraderPriority
-//         if r <= cumulative {
-//             return candidate
-//         }
-//     }
-    
-//     // Fallback - возвращаем кандидата с максимальным приоритетом
-//     return s.selectByMaxPriority(candidates)
-// }
-
-// func (s *WeightedSelector) selectByMaxPriority(candidates []*BankDetailView) *BankDetailView {
-//     var best *BankDetailView
-//     maxPriority := -1.0
-    
-//     for _, candidate := range candidates {
-//         if candidate.TraderPriority > maxPriority {
-//             maxPriority = candidate.TraderPriority
-//             best = candidate
-//         }
-//     }
-    
-//     return best
-// }
-
-// //-------------------------------------------- Основной engine каскада
-// type CascadeMatchEngine struct {
-//     filters []Filter
-//     selector *WeightedSelector
-//     cache    *BankDetailCache
-// }
-
-// func (e *CascadeMatchEngine) FindBestBankDetail(
-//     ctx context.Context, 
-//     req *MatchRequest,
-// ) (*BankDetailView, error) {
-    
-//     // Сортируем фильтры по приоритету
-//     sort.Slice(e.filters, func(i, j int) bool {
-//         return e.filters[i].Priority() < e.filters[j].Priority()
-//     })
-    
-//     var candidates []*BankDetailView
-    
-//     // Последовательно применяем фильтры
-//     for _, filter := range e.filters {
-//         start := time.Now()
-        
-//         filtered, err := filter.Filter(ctx, req, candidates)
-//         if err != nil {
-//             return nil, fmt.Errorf("filter failed: %w", err)
-//         }
-        
-//         candidates = filtered
-//         // metrics.FilterDuration.WithLabelValues(filter.Name()).Observe(time.Since(start).Seconds())
-        
-//         // Ранний выход если кандидатов не осталось
-//         if len(candidates) == 0 {
-//             // metrics.CascadeAborted.WithLabelValues(filter.Name()).Inc()
-//             return nil, nil
-//         }
-        
-//         // metrics.CandidatesAfterFilter.WithLabelValues(filter.Name()).Set(float64(len(candidates)))
-//     }
-    
-//     // Выбор лучшего кандидата
-//     if len(candidates) == 0 {
-//         return nil, nil
-//     }
-    
-//     return e.selector.Select(candidates), nil
-// }
-
-// //--------------------------обновление кэша
-// type CacheUpdater struct {
-//     db           *gorm.DB
-//     cache        *BankDetailCache
-//     walletService handlers.HTTPWalletHandler
-//     interval     time.Duration
-// }
-
-// func (u *CacheUpdater) Start() {
-//     ticker := time.NewTicker(u.interval)
-//     go func() {
-//         for range ticker.C {
-//             u.updateCache()
-//         }
-//     }()
-// }
-
-// func (u *CacheUpdater) updateCache() {
-//     // Загрузка денормализованных данных одним запросом
-//     var bankDetails []*BankDetailView
-    
-//     err := u.db.Raw(`
-//         SELECT 
-//             bd.*,
-//             t.enabled as traffic_enabled,
-//             t.trader_priority,
-//             t.trader_reward_percent,
-//             t.platform_fee
-//         FROM bank_detail_models bd
-//         LEFT JOIN traffic_models t ON t.trader_id = bd.trader_id 
-//             AND t.merchant_id = ? -- merchant_id будет подставляться динамически
-//         WHERE bd.deleted_at IS NULL
-//     `, u.getActiveMerchants()).Scan(&bankDetails).Error
-    
-//     if err != nil {
-//         slog.Error("Failed to update bank details cache", "error", err)
-//         return
-//     }
-    
-//     // Пакетное обновление балансов
-//     u.updateBalances(bankDetails)
-    
-//     // Обновление in-memory кэша
-//     u.cache.Update(bankDetails)
-    
-//     // metrics.CacheUpdateTime.SetToCurrentTime()
-//     // metrics.CacheSize.Set(float64(len(bankDetails)))
-// }
\ No newline at end of file
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/metrics"
+)
+
+// MatchRequest - параметры подбора реквизита для пай-ин сделки
+type MatchRequest struct {
+	MerchantID    string
+	AmountFiat    float64
+	AmountCrypto  float64
+	Currency      string
+	PaymentSystem string
+	BankCode      string
+	NspkCode      string
+}
+
+// Filter - этап каскада. Фильтры применяются последовательно, от быстрых к медленным.
+// Фильтр вызывается под блокировкой кэша на чтение и не должен ходить во внешние сервисы
+type Filter interface {
+	Filter(ctx context.Context, req *MatchRequest, cache *BankDetailCache, candidates []*BankDetailView) ([]*BankDetailView, error)
+	Name() string
+	Priority() int // чем меньше - тем раньше
+}
+
+// CascadeMatchEngine подбирает реквизиты по in-memory кэшу вместо SQL-запроса
+// с блокировкой (FindSuitableBankDetailsInTx)
+type CascadeMatchEngine struct {
+	filters []Filter
+	cache   *BankDetailCache
+	metrics *metrics.CascadeMetrics
+}
+
+func NewCascadeMatchEngine(cache *BankDetailCache, cascadeMetrics *metrics.CascadeMetrics, filters ...Filter) *CascadeMatchEngine {
+	if len(filters) == 0 {
+		filters = []Filter{&StaticFilter{}, &LimitsFilter{}, &TrafficFilter{}, &BalanceFilter{}}
+	}
+	sorted := append([]Filter(nil), filters...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority() < sorted[j].Priority()
+	})
+
+	return &CascadeMatchEngine{
+		filters: sorted,
+		cache:   cache,
+		metrics: cascadeMetrics,
+	}
+}
+
+// Cache - кэш реквизитов каскада
+func (e *CascadeMatchEngine) Cache() *BankDetailCache {
+	return e.cache
+}
+
+// FindSuitableBankDetails возвращает реквизиты, прошедшие все фильтры каскада
+func (e *CascadeMatchEngine) FindSuitableBankDetails(ctx context.Context, req *MatchRequest) ([]*domain.BankDetail, error) {
+	e.cache.mutex.RLock()
+	defer e.cache.mutex.RUnlock()
+
+	if e.cache.lastUpdated.IsZero() {
+		return nil, fmt.Errorf("bank detail cache is not loaded yet")
+	}
+
+	var candidates []*BankDetailView
+	for _, filter := range e.filters {
+		start := time.Now()
+
+		filtered, err := filter.Filter(ctx, req, e.cache, candidates)
+		if err != nil {
+			return nil, fmt.Errorf("filter %s failed: %w", filter.Name(), err)
+		}
+		candidates = filtered
+
+		if e.metrics != nil {
+			e.metrics.RecordFilter(filter.Name(), time.Since(start), len(candidates))
+		}
+
+		// Ранний выход, если кандидатов не осталось
+		if len(candidates) == 0 {
+			return []*domain.BankDetail{}, nil
+		}
+	}
+
+	bankDetails := make([]*domain.BankDetail, len(candidates))
+	for i, view := range candidates {
+		bankDetail := *view.BankDetail
+		bankDetails[i] = &bankDetail
+	}
+	return bankDetails, nil
+}
+
+// TraderPriority - приоритет трейдера в трафике с мерчантом, false - трафика нет в кэше
+func (e *CascadeMatchEngine) TraderPriority(traderID, merchantID string) (float64, bool) {
+	e.cache.mutex.RLock()
+	defer e.cache.mutex.RUnlock()
+	traffic, ok := e.cache.traffic[trafficKey(traderID, merchantID)]
+	if !ok {
+		return 0, false
+	}
+	return traffic.TraderPriority, true
+}
+
+// OrderAssigned - сделка создана или перенесена на реквизит
+func (e *CascadeMatchEngine) OrderAssigned(order *domain.Order) {
+	e.cache.OrderAssigned(order)
+}
+
+// OrderStatusChanged - сделка сменила статус
+func (e *CascadeMatchEngine) OrderStatusChanged(orderID string, status domain.OrderStatus) {
+	e.cache.OrderStatusChanged(orderID, status)
+}
+
+// BankDetailSuspended - реквизит приостановлен или приостановка снята (until = nil)
+func (e *CascadeMatchEngine) BankDetailSuspended(bankDetailID string, until *time.Time, reason string) {
+	e.cache.BankDetailSuspended(bankDetailID, until, reason)
+}
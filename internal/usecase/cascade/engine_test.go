@@ -0,0 +1,264 @@
+package cascade
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/mappers"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/models"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/postgres/repository"
+	"github.com/google/uuid"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	fixtureMerchantID    = "merchant-1"
+	fixtureTraders       = 200
+	fixtureBankDetails   = 10000
+	fixturePendingOrders = 5000
+)
+
+// fixtureSnapshot - синтетические реквизиты, трафик, счетчики и активные сделки.
+// Часть реквизитов отсекается каждым фильтром каскада, чтобы замер проходил все этапы
+func fixtureSnapshot(now time.Time) *Snapshot {
+	paymentSystems := []string{"SBP", "C2C"}
+	bankCodes := []string{"sber", "tinkoff", "alfa", "vtb"}
+
+	snapshot := &Snapshot{
+		Balances:  make(map[string]float64, fixtureTraders),
+		CountedAt: now,
+	}
+	for t := 0; t < fixtureTraders; t++ {
+		traderID := fmt.Sprintf("trader-%d", t)
+		snapshot.Balances[traderID] = float64(100 + t%50*10)
+		snapshot.Traffic = append(snapshot.Traffic, &domain.Traffic{
+			ID:                  fmt.Sprintf("traffic-%d", t),
+			MerchantID:          fixtureMerchantID,
+			TraderID:            traderID,
+			TraderRewardPercent: 0.07,
+			TraderPriority:      float64(t%10 + 1),
+			Enabled:             true,
+			ActivityParams: domain.TrafficActivityParams{
+				MerchantUnlocked:  true,
+				TraderUnlocked:    t%20 != 0,
+				AntifraudUnlocked: true,
+				ManuallyUnlocked:  true,
+			},
+			BusinessParams: domain.TrafficBusinessParams{
+				MerchantDealsDuration: 20 * time.Minute,
+				UniqueAmountStep:      float64(t % 2),
+				UniqueAmountMaxSteps:  5,
+			},
+		})
+	}
+
+	for i := 0; i < fixtureBankDetails; i++ {
+		bankDetail := &domain.BankDetail{
+			ID: fmt.Sprintf("bank-detail-%d", i),
+			SearchParams: domain.SearchParams{
+				MaxOrdersSimultaneosly: 5,
+				MaxAmountDay:           500000,
+				MaxAmountMonth:         5000000,
+				MaxQuantityDay:         100,
+				MaxQuantityMonth:       1000,
+				MinOrderAmount:         float32(100 * (i % 5)),
+				MaxOrderAmount:         50000,
+				Enabled:                i%50 != 0,
+			},
+			TraderInfo: domain.TraderInfo{TraderID: fmt.Sprintf("trader-%d", i%fixtureTraders)},
+			PaymentDetails: domain.PaymentDetails{
+				PaymentSystem: paymentSystems[i%len(paymentSystems)],
+				BankInfo:      domain.BankInfo{BankCode: bankCodes[i%len(bankCodes)]},
+			},
+			Currency: "RUB",
+		}
+		snapshot.BankDetails = append(snapshot.BankDetails, bankDetail)
+		snapshot.Counters = append(snapshot.Counters, &domain.BankDetailCounters{
+			BankDetailID: bankDetail.ID,
+			DayCount:     int32(i % 120),
+			DayAmount:    float64(i%120) * 1500,
+			MonthCount:   int32(i % 120),
+			MonthAmount:  float64(i%120) * 1500,
+		})
+	}
+
+	bankDetailID := func(i int) *string {
+		id := fmt.Sprintf("bank-detail-%d", i*7%fixtureBankDetails)
+		return &id
+	}
+	for i := 0; i < fixturePendingOrders; i++ {
+		snapshot.PendingOrders = append(snapshot.PendingOrders, &domain.Order{
+			ID:           fmt.Sprintf("order-%d", i),
+			Status:       domain.StatusPending,
+			BankDetailID: bankDetailID(i),
+			AmountInfo: domain.AmountInfo{
+				AmountFiat:   float64(1000 + i%4*500),
+				AmountCrypto: float64(1000+i%4*500) / 95,
+			},
+			RequisiteDetails: domain.RequisiteDetails{TraderID: fmt.Sprintf("trader-%d", i*7%fixtureBankDetails%fixtureTraders)},
+			CreatedAt:        now.Add(-time.Duration(i%60) * time.Second),
+		})
+	}
+
+	return snapshot
+}
+
+// fixtureCache - кэш, заполненный fixtureSnapshot
+func fixtureCache(now time.Time) *BankDetailCache {
+	cache := NewBankDetailCache()
+	cache.Replace(fixtureSnapshot(now))
+	return cache
+}
+
+func BenchmarkCascadeMatchEngine(b *testing.B) {
+	engine := NewCascadeMatchEngine(fixtureCache(time.Now()), nil)
+	req := &MatchRequest{
+		MerchantID:    fixtureMerchantID,
+		AmountFiat:    1500,
+		AmountCrypto:  1500.0 / 95,
+		Currency:      "RUB",
+		PaymentSystem: "SBP",
+	}
+
+	bankDetails, err := engine.FindSuitableBankDetails(context.Background(), req)
+	if err != nil {
+		b.Fatalf("cascade failed: %v", err)
+	}
+	if len(bankDetails) == 0 {
+		b.Fatalf("fixture has no suitable bank details")
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := engine.FindSuitableBankDetails(context.Background(), req); err != nil {
+			b.Fatalf("cascade failed: %v", err)
+		}
+	}
+}
+
+// Бенчмарк с БД запускается, только если задан DSN тестовой базы postgres
+const testDSNEnv = "ORDER_SERVICE_TEST_DSN"
+
+// fixtureUUID - постоянный uuid для ID из fixtureSnapshot: в БД ID реквизитов и трейдеров - uuid
+func fixtureUUID(id string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(id)).String()
+}
+
+// seedFixture записывает fixtureSnapshot в транзакцию tx
+func seedFixture(b *testing.B, tx *gorm.DB, snapshot *Snapshot) {
+	b.Helper()
+
+	traffic := make([]*models.TrafficModel, 0, len(snapshot.Traffic))
+	for _, t := range snapshot.Traffic {
+		traffic = append(traffic, &models.TrafficModel{
+			ID:                   fixtureUUID(t.ID),
+			MerchantID:           t.MerchantID,
+			TraderID:             fixtureUUID(t.TraderID),
+			TraderRewardPercent:  t.TraderRewardPercent,
+			TraderPriority:       t.TraderPriority,
+			Enabled:              t.Enabled,
+			AntifraudUnlocked:    t.ActivityParams.AntifraudUnlocked,
+			MerchantUnlocked:     t.ActivityParams.MerchantUnlocked,
+			TraderUnlocked:       t.ActivityParams.TraderUnlocked,
+			ManuallyUnlocked:     t.ActivityParams.ManuallyUnlocked,
+			UniqueAmountStep:     t.BusinessParams.UniqueAmountStep,
+			UniqueAmountMaxSteps: t.BusinessParams.UniqueAmountMaxSteps,
+		})
+	}
+	if err := tx.Omit("UnlockSnapshot").CreateInBatches(traffic, 500).Error; err != nil {
+		b.Fatalf("failed to seed traffic: %v", err)
+	}
+
+	bankDetails := make([]*models.BankDetailModel, 0, len(snapshot.BankDetails))
+	for _, bankDetail := range snapshot.BankDetails {
+		model := mappers.ToGORMBankDetail(bankDetail)
+		model.ID = fixtureUUID(bankDetail.ID)
+		model.TraderID = fixtureUUID(bankDetail.TraderID)
+		bankDetails = append(bankDetails, model)
+	}
+	if err := tx.CreateInBatches(bankDetails, 500).Error; err != nil {
+		b.Fatalf("failed to seed bank details: %v", err)
+	}
+
+	orders := make([]*models.OrderModel, 0, len(snapshot.PendingOrders))
+	for _, order := range snapshot.PendingOrders {
+		bankDetailID := fixtureUUID(*order.BankDetailID)
+		orders = append(orders, &models.OrderModel{
+			ID:            fixtureUUID(order.ID),
+			MerchantID:    fixtureMerchantID,
+			AmountFiat:    order.AmountInfo.AmountFiat,
+			AmountCrypto:  order.AmountInfo.AmountCrypto,
+			Currency:      "RUB",
+			Status:        order.Status,
+			BankDetailsID: &bankDetailID,
+			TraderID:      fixtureUUID(order.RequisiteDetails.TraderID),
+			Type:          string(domain.TypePayIn),
+			ExpiresAt:     order.CreatedAt.Add(20 * time.Minute),
+			CreatedAt:     order.CreatedAt,
+		})
+	}
+	if err := tx.Omit(clause.Associations).CreateInBatches(orders, 500).Error; err != nil {
+		b.Fatalf("failed to seed orders: %v", err)
+	}
+}
+
+// Тот же объем данных, что у BenchmarkCascadeMatchEngine, но подбор идет запросами к БД
+// с блокировкой реквизитов. Данные пишутся в транзакцию, которая откатывается после замера
+func BenchmarkFindSuitableBankDetailsInTx(b *testing.B) {
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		b.Skipf("%s is not set", testDSNEnv)
+	}
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		b.Fatalf("failed to open test db: %v", err)
+	}
+	if err := db.AutoMigrate(&models.BankDetailModel{}, &models.OrderModel{}, &models.TrafficModel{}); err != nil {
+		b.Fatalf("failed to migrate test db: %v", err)
+	}
+
+	// Репозиторий пишет в лог каждый этап подбора
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(io.Discard)
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		b.Fatalf("failed to begin tx: %v", tx.Error)
+	}
+	defer tx.Rollback()
+
+	seedFixture(b, tx, fixtureSnapshot(time.Now()))
+	repo := repository.NewDefaultBankDetailRepo(tx)
+	query := &domain.SuitablleBankDetailsQuery{
+		AmountFiat:    1500,
+		PaymentSystem: "SBP",
+		Currency:      "RUB",
+		MerchantID:    fixtureMerchantID,
+	}
+
+	bankDetails, err := repo.FindSuitableBankDetailsInTx(query)
+	if err != nil {
+		b.Fatalf("search failed: %v", err)
+	}
+	if len(bankDetails) == 0 {
+		b.Fatalf("fixture has no suitable bank details")
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.FindSuitableBankDetailsInTx(query); err != nil {
+			b.Fatalf("search failed: %v", err)
+		}
+	}
+}
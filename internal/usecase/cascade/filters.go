@@ -0,0 +1,151 @@
+package cascade
+
+import (
+	"context"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+// StaticFilter - реквизиты валюты и платежной системы из кэша, отбор по сумме, банку, расписанию работы
+// и приостановке по здоровью реквизита
+type StaticFilter struct{}
+
+func (f *StaticFilter) Name() string  { return "static" }
+func (f *StaticFilter) Priority() int { return 1 }
+
+func (f *StaticFilter) Filter(ctx context.Context, req *MatchRequest, cache *BankDetailCache, _ []*BankDetailView) ([]*BankDetailView, error) {
+	candidates := cache.byCurrencyPS[req.Currency][req.PaymentSystem]
+	now := time.Now()
+
+	var filtered []*BankDetailView
+	for _, view := range candidates {
+		bankDetail := view.BankDetail
+		if !bankDetail.Enabled || bankDetail.IsSuspended(now) {
+			continue
+		}
+		if req.AmountFiat < float64(bankDetail.MinOrderAmount) || req.AmountFiat > float64(bankDetail.MaxOrderAmount) {
+			continue
+		}
+		if req.BankCode != "" && bankDetail.BankCode != req.BankCode {
+			continue
+		}
+		if req.NspkCode != "" && bankDetail.NspkCode != req.NspkCode {
+			continue
+		}
+		if !bankDetail.Schedule.IsAvailable(now) {
+			continue
+		}
+		filtered = append(filtered, view)
+	}
+
+	return filtered, nil
+}
+
+// LimitsFilter - лимиты реквизита по денормализованным счетчикам, задержка после
+// завершенной сделки и активная сделка на ту же сумму
+type LimitsFilter struct{}
+
+func (f *LimitsFilter) Name() string  { return "limits" }
+func (f *LimitsFilter) Priority() int { return 2 }
+
+func (f *LimitsFilter) Filter(ctx context.Context, req *MatchRequest, cache *BankDetailCache, candidates []*BankDetailView) ([]*BankDetailView, error) {
+	now := time.Now()
+
+	var filtered []*BankDetailView
+	for _, view := range candidates {
+		bankDetail := view.BankDetail
+		counters := cache.periodCounters(view, now)
+
+		if view.PendingCount >= bankDetail.MaxOrdersSimultaneosly {
+			continue
+		}
+		// Часовые и недельные лимиты необязательные: 0 - без ограничения
+		if bankDetail.MaxQuantityHour > 0 && counters.HourCount+1 > bankDetail.MaxQuantityHour {
+			continue
+		}
+		if bankDetail.MaxAmountHour > 0 && counters.HourAmount+req.AmountFiat > bankDetail.MaxAmountHour {
+			continue
+		}
+		if counters.DayCount+1 > bankDetail.MaxQuantityDay || counters.DayAmount+req.AmountFiat > bankDetail.MaxAmountDay {
+			continue
+		}
+		if bankDetail.MaxQuantityWeek > 0 && counters.WeekCount+1 > bankDetail.MaxQuantityWeek {
+			continue
+		}
+		if bankDetail.MaxAmountWeek > 0 && counters.WeekAmount+req.AmountFiat > bankDetail.MaxAmountWeek {
+			continue
+		}
+		if counters.MonthCount+1 > bankDetail.MaxQuantityMonth || counters.MonthAmount+req.AmountFiat > bankDetail.MaxAmountMonth {
+			continue
+		}
+		if view.LastCompletedAt != nil && now.Sub(*view.LastCompletedAt) < bankDetail.Delay {
+			continue
+		}
+		// Трафик с уникальными суммами сам сдвинет сумму сделки, если на реквизите осталась свободная сумма
+		if view.PendingAmounts[roundAmount(req.AmountFiat)] > 0 {
+			traffic, ok := cache.traffic[trafficKey(bankDetail.TraderID, req.MerchantID)]
+			if !ok || !hasFreeUniqueAmount(view, req.AmountFiat, traffic.BusinessParams) {
+				continue
+			}
+		}
+		filtered = append(filtered, view)
+	}
+
+	return filtered, nil
+}
+
+// TrafficFilter - у трейдера есть открытый трафик с мерчантом, и сейчас время его работы
+type TrafficFilter struct{}
+
+func (f *TrafficFilter) Name() string  { return "traffic" }
+func (f *TrafficFilter) Priority() int { return 3 }
+
+func (f *TrafficFilter) Filter(ctx context.Context, req *MatchRequest, cache *BankDetailCache, candidates []*BankDetailView) ([]*BankDetailView, error) {
+	now := time.Now()
+
+	var filtered []*BankDetailView
+	for _, view := range candidates {
+		traffic, ok := cache.traffic[trafficKey(view.BankDetail.TraderID, req.MerchantID)]
+		if !ok || traffic.UnavailableReason(now) != "" {
+			continue
+		}
+		filtered = append(filtered, view)
+	}
+
+	return filtered, nil
+}
+
+// BalanceFilter - кэшированного баланса трейдера хватает на сделку. Баланс перечитывается
+// при перезагрузке кэша, устаревший баланс ловит заморозка при создании сделки
+type BalanceFilter struct{}
+
+func (f *BalanceFilter) Name() string  { return "balance" }
+func (f *BalanceFilter) Priority() int { return 4 }
+
+func (f *BalanceFilter) Filter(ctx context.Context, req *MatchRequest, cache *BankDetailCache, candidates []*BankDetailView) ([]*BankDetailView, error) {
+	var filtered []*BankDetailView
+	for _, view := range candidates {
+		balance, ok := cache.balances[view.BankDetail.TraderID]
+		if !ok || balance < req.AmountCrypto {
+			continue
+		}
+		filtered = append(filtered, view)
+	}
+
+	return filtered, nil
+}
+
+// hasFreeUniqueAmount - среди сумм, которые перебирает сдвиг уникальной суммы при создании сделки,
+// есть сумма без активной сделки на реквизите
+func hasFreeUniqueAmount(view *BankDetailView, amountFiat float64, params domain.TrafficBusinessParams) bool {
+	if params.UniqueAmountStep <= 0 {
+		return false
+	}
+	for step := int32(0); step <= params.UniqueAmountMaxSteps; step++ {
+		if view.PendingAmounts[roundAmount(amountFiat+float64(step)*params.UniqueAmountStep)] == 0 {
+			return true
+		}
+	}
+	return false
+}
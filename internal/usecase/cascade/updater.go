@@ -0,0 +1,251 @@
+package cascade
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/metrics"
+)
+
+// Размер страницы при загрузке реквизитов и трафика
+const loadPageSize = 500
+
+// Сколько раз перезагрузка повторяется, если кэш обновлялся точечно во время загрузки снимка
+const maxRefreshAttempts = 3
+
+// BalanceSource - пакетное получение доступных балансов трейдеров
+type BalanceSource interface {
+	GetTraderBalancesBatch(traderIDs []string) (map[string]float64, error)
+}
+
+// CacheUpdater периодически перезагружает кэш каскада из БД и кошелька и точечно обновляет его
+// после изменения реквизитов и трафика. Инкрементальные обновления счетчиков сделок между
+// перезагрузками могут потеряться, если пришлись на время загрузки снимка - следующая перезагрузка их исправит
+type CacheUpdater struct {
+	cache          *BankDetailCache
+	bankDetailRepo domain.BankDetailRepository
+	trafficRepo    domain.TrafficRepository
+	orderRepo      domain.OrderRepository
+	balances       BalanceSource
+	metrics        *metrics.CascadeMetrics
+	interval       time.Duration
+}
+
+func NewCacheUpdater(
+	cache *BankDetailCache,
+	bankDetailRepo domain.BankDetailRepository,
+	trafficRepo domain.TrafficRepository,
+	orderRepo domain.OrderRepository,
+	balances BalanceSource,
+	cascadeMetrics *metrics.CascadeMetrics,
+	interval time.Duration,
+) *CacheUpdater {
+	return &CacheUpdater{
+		cache:          cache,
+		bankDetailRepo: bankDetailRepo,
+		trafficRepo:    trafficRepo,
+		orderRepo:      orderRepo,
+		balances:       balances,
+		metrics:        cascadeMetrics,
+		interval:       interval,
+	}
+}
+
+// Start загружает кэш сразу и затем раз в interval до отмены контекста
+func (u *CacheUpdater) Start(ctx context.Context) {
+	u.refreshAndLog()
+
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			u.refreshAndLog()
+		}
+	}
+}
+
+func (u *CacheUpdater) refreshAndLog() {
+	if err := u.Refresh(); err != nil {
+		slog.Error("failed to refresh cascade bank detail cache", "error", err)
+		if u.metrics != nil {
+			u.metrics.RecordCacheRefreshFailure()
+		}
+	}
+}
+
+// Refresh загружает снимок реквизитов, трафика, счетчиков сделок и балансов и заменяет им кэш.
+// Если во время загрузки кэш обновлялся точечно, снимок может быть старше обновления и загружается заново
+func (u *CacheUpdater) Refresh() error {
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		generation := u.cache.Generation()
+
+		snapshot, err := u.loadSnapshot(start)
+		if err != nil {
+			return err
+		}
+		if u.cache.ReplaceIfUnchanged(generation, snapshot) {
+			if u.metrics != nil {
+				u.metrics.RecordCacheRefresh(time.Since(start), len(snapshot.BankDetails))
+			}
+			return nil
+		}
+		if attempt >= maxRefreshAttempts {
+			return fmt.Errorf("cache changed during %d refresh attempts", attempt)
+		}
+	}
+}
+
+func (u *CacheUpdater) loadSnapshot(start time.Time) (*Snapshot, error) {
+	bankDetails, err := u.loadBankDetails()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bank details: %w", err)
+	}
+	traffic, err := u.loadTraffic()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load traffic: %w", err)
+	}
+	// Окна лимитов у каждого реквизита свои (режим и часовой пояс)
+	windows := make(map[string]domain.LimitWindows, len(bankDetails))
+	for _, bankDetail := range bankDetails {
+		windows[bankDetail.ID] = bankDetail.LimitWindows(start)
+	}
+	counters, err := u.bankDetailRepo.GetBankDetailCounters(windows)
+	if err != nil {
+		return nil, err
+	}
+	pendingOrders, err := u.orderRepo.FindPendingOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	traderIDs := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, bankDetail := range bankDetails {
+		if !seen[bankDetail.TraderID] {
+			seen[bankDetail.TraderID] = true
+			traderIDs = append(traderIDs, bankDetail.TraderID)
+		}
+	}
+	balances, err := u.balances.GetTraderBalancesBatch(traderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trader balances: %w", err)
+	}
+
+	return &Snapshot{
+		BankDetails:   bankDetails,
+		Traffic:       traffic,
+		Counters:      counters,
+		PendingOrders: pendingOrders,
+		Balances:      balances,
+		CountedAt:     start,
+	}, nil
+}
+
+// InvalidateBankDetail перечитывает реквизит после изменения, не дожидаясь перезагрузки кэша.
+// Выключенный или удаленный реквизит убирается из подбора. Если прочитать реквизит не удалось,
+// он тоже убирается: до перезагрузки кэша лучше не подобрать реквизит, чем подобрать устаревший
+func (u *CacheUpdater) InvalidateBankDetail(bankDetailID string) {
+	enabled := true
+	bankDetails, _, err := u.bankDetailRepo.GetBankDetails(domain.GetBankDetailsFilter{
+		BankDetailID: &bankDetailID,
+		Enabled:      &enabled,
+		Page:         1,
+		Limit:        1,
+	})
+	if err != nil || len(bankDetails) == 0 {
+		if err != nil {
+			slog.Error("failed to reload bank detail into cascade cache", "bank_detail_id", bankDetailID, "error", err)
+		}
+		u.cache.RemoveBankDetail(bankDetailID)
+		return
+	}
+
+	bankDetail := bankDetails[0]
+	now := time.Now()
+	counters, err := u.bankDetailRepo.GetBankDetailCounters(map[string]domain.LimitWindows{
+		bankDetail.ID: bankDetail.LimitWindows(now),
+	})
+	if err != nil {
+		slog.Error("failed to reload bank detail counters into cascade cache", "bank_detail_id", bankDetailID, "error", err)
+		u.cache.RemoveBankDetail(bankDetailID)
+		return
+	}
+	var bankDetailCounters *domain.BankDetailCounters
+	if len(counters) > 0 {
+		bankDetailCounters = counters[0]
+	}
+	u.cache.PutBankDetail(bankDetail, bankDetailCounters, now)
+}
+
+// InvalidateTrader перечитывает трафик трейдера со всеми мерчантами. Если прочитать не удалось,
+// трафик убирается из кэша, и реквизиты трейдера не подбираются до перезагрузки
+func (u *CacheUpdater) InvalidateTrader(traderID string) {
+	traffic, err := u.trafficRepo.GetTrafficByTraderID(traderID)
+	if err != nil {
+		slog.Error("failed to reload trader traffic into cascade cache", "trader_id", traderID, "error", err)
+		traffic = nil
+	}
+	u.cache.ReplaceTraffic(func(record *domain.Traffic) bool { return record.TraderID == traderID }, traffic)
+}
+
+// InvalidateMerchant перечитывает трафик мерчанта со всеми трейдерами
+func (u *CacheUpdater) InvalidateMerchant(merchantID string) {
+	traffic, err := u.trafficRepo.GetTrafficByMerchantID(merchantID)
+	if err != nil {
+		slog.Error("failed to reload merchant traffic into cascade cache", "merchant_id", merchantID, "error", err)
+		traffic = nil
+	}
+	u.cache.ReplaceTraffic(func(record *domain.Traffic) bool { return record.MerchantID == merchantID }, traffic)
+}
+
+// InvalidateAll перечитывает весь трафик. Используется, когда запись меняется по ID трафика.
+// При ошибке чтения трафик убирается из кэша, как в InvalidateTrader
+func (u *CacheUpdater) InvalidateAll() {
+	traffic, err := u.loadTraffic()
+	if err != nil {
+		slog.Error("failed to reload traffic into cascade cache", "error", err)
+		traffic = nil
+	}
+	u.cache.ReplaceTraffic(func(*domain.Traffic) bool { return true }, traffic)
+}
+
+func (u *CacheUpdater) loadBankDetails() ([]*domain.BankDetail, error) {
+	enabled := true
+	var result []*domain.BankDetail
+	for page := 1; ; page++ {
+		bankDetails, _, err := u.bankDetailRepo.GetBankDetails(domain.GetBankDetailsFilter{
+			Enabled: &enabled,
+			Page:    page,
+			Limit:   loadPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, bankDetails...)
+		if len(bankDetails) < loadPageSize {
+			return result, nil
+		}
+	}
+}
+
+func (u *CacheUpdater) loadTraffic() ([]*domain.Traffic, error) {
+	var result []*domain.Traffic
+	for page := int32(1); ; page++ {
+		traffic, err := u.trafficRepo.GetTrafficRecords(page, loadPageSize)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, traffic...)
+		if len(traffic) < loadPageSize {
+			return result, nil
+		}
+	}
+}
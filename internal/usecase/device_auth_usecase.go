@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+type DeviceAuthUsecase interface {
+	// Режим проверки: off, log, enforce
+	Mode() string
+	// Выдает устройству новый секрет. Secret в ответе - единственный раз, когда он виден в открытом виде
+	IssueDeviceSecret(deviceID string) (*domain.DeviceCredential, error)
+	// Выдает новый секрет, прежние действуют еще rotationGrace. Только для трейдера-владельца устройства
+	RotateDeviceSecret(deviceID, traderID string) (*domain.DeviceCredential, error)
+	// Отзывает все секреты устройства сразу. Только для трейдера-владельца устройства
+	RevokeDeviceSecrets(deviceID, traderID string) error
+	// Проверяет подпись и принадлежность устройства. Отказ - *domain.DeviceAuthError, записывается в аудит
+	AuthenticateDevice(req *domain.DeviceAuthRequest) (*domain.Device, error)
+	// Удаляет подписи запросов, повтор которых уже отклоняется по времени
+	PurgeExpiredNonces() error
+}
+
+// DefaultDeviceAuthUsecase хранит подписи принятых запросов в БД до конца replayWindow, чтобы повтор
+// перехваченного запроса отклонялся на любом экземпляре сервиса и после перезапуска
+type DefaultDeviceAuthUsecase struct {
+	deviceRepo     domain.DeviceRepository
+	credentialRepo domain.DeviceCredentialRepository
+	mode           string
+	replayWindow   time.Duration
+	rotationGrace  time.Duration
+}
+
+func NewDefaultDeviceAuthUsecase(
+	deviceRepo domain.DeviceRepository,
+	credentialRepo domain.DeviceCredentialRepository,
+	mode string,
+	replayWindow time.Duration,
+	rotationGrace time.Duration,
+) (*DefaultDeviceAuthUsecase, error) {
+	switch mode {
+	case domain.DeviceAuthModeOff, domain.DeviceAuthModeLog, domain.DeviceAuthModeEnforce:
+	default:
+		return nil, fmt.Errorf("unknown device auth mode: %s", mode)
+	}
+	return &DefaultDeviceAuthUsecase{
+		deviceRepo:     deviceRepo,
+		credentialRepo: credentialRepo,
+		mode:           mode,
+		replayWindow:   replayWindow,
+		rotationGrace:  rotationGrace,
+	}, nil
+}
+
+func (uc *DefaultDeviceAuthUsecase) Mode() string {
+	return uc.mode
+}
+
+func (uc *DefaultDeviceAuthUsecase) IssueDeviceSecret(deviceID string) (*domain.DeviceCredential, error) {
+	if _, err := uc.deviceRepo.GetDeviceByID(deviceID); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate device secret: %w", err)
+	}
+	credential := &domain.DeviceCredential{
+		ID:        uuid.New().String(),
+		DeviceID:  deviceID,
+		Secret:    base64.RawURLEncoding.EncodeToString(secret),
+		CreatedAt: time.Now(),
+	}
+	if err := uc.credentialRepo.CreateDeviceCredential(credential); err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+func (uc *DefaultDeviceAuthUsecase) RotateDeviceSecret(deviceID, traderID string) (*domain.DeviceCredential, error) {
+	if err := uc.checkOwner(deviceID, traderID); err != nil {
+		return nil, err
+	}
+	if err := uc.credentialRepo.ExpireDeviceCredentials(deviceID, time.Now().Add(uc.rotationGrace)); err != nil {
+		return nil, err
+	}
+	return uc.IssueDeviceSecret(deviceID)
+}
+
+func (uc *DefaultDeviceAuthUsecase) RevokeDeviceSecrets(deviceID, traderID string) error {
+	if err := uc.checkOwner(deviceID, traderID); err != nil {
+		return err
+	}
+	return uc.credentialRepo.RevokeDeviceCredentials(deviceID, time.Now())
+}
+
+// checkOwner - секретами устройства распоряжается только его трейдер: знать идентификатор
+// устройства недостаточно, иначе любой получил бы действующий секрет
+func (uc *DefaultDeviceAuthUsecase) checkOwner(deviceID, traderID string) error {
+	device, err := uc.deviceRepo.GetDeviceByID(deviceID)
+	if err != nil {
+		return err
+	}
+	if traderID == "" || device.TraderID != traderID {
+		return fmt.Errorf("%w: device %s", domain.ErrDeviceNotOwned, deviceID)
+	}
+	return nil
+}
+
+func (uc *DefaultDeviceAuthUsecase) AuthenticateDevice(req *domain.DeviceAuthRequest) (*domain.Device, error) {
+	if req.DeviceID == "" || req.Timestamp == "" || req.Signature == "" {
+		return nil, uc.fail(req, domain.DeviceAuthMissingCredentials)
+	}
+
+	now := time.Now()
+	timestamp, err := strconv.ParseInt(req.Timestamp, 10, 64)
+	if err != nil {
+		return nil, uc.fail(req, domain.DeviceAuthBadTimestamp)
+	}
+	if skew := now.Sub(time.Unix(timestamp, 0)); skew > uc.replayWindow || skew < -uc.replayWindow {
+		return nil, uc.fail(req, domain.DeviceAuthBadTimestamp)
+	}
+
+	device, err := uc.deviceRepo.GetDeviceByID(req.DeviceID)
+	if err != nil {
+		if errors.Is(err, domain.ErrDeviceNotFound) {
+			return nil, uc.fail(req, domain.DeviceAuthUnknownDevice)
+		}
+		return nil, err
+	}
+	credentials, err := uc.credentialRepo.GetActiveDeviceCredentials(req.DeviceID, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(credentials) == 0 {
+		return nil, uc.fail(req, domain.DeviceAuthNoActiveSecret)
+	}
+
+	signed := false
+	for _, credential := range credentials {
+		expected := domain.DeviceRequestSignature(credential.Secret, req.Method, req.Timestamp, req.Payload)
+		if hmac.Equal([]byte(expected), []byte(req.Signature)) {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return nil, uc.fail(req, domain.DeviceAuthBadSignature)
+	}
+	// Запрос с этой подписью принимается до timestamp+replayWindow, столько же хранится подпись
+	fresh, err := uc.credentialRepo.SaveDeviceRequestNonce(req.DeviceID, req.Signature, time.Unix(timestamp, 0).Add(uc.replayWindow))
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, uc.fail(req, domain.DeviceAuthReplay)
+	}
+
+	// Подписавшее устройство может отправлять запросы только от своего имени
+	if req.ClaimedDeviceID != "" && req.ClaimedDeviceID != device.DeviceID {
+		return nil, uc.fail(req, domain.DeviceAuthDeviceMismatch)
+	}
+	if req.ClaimedTraderID != "" && req.ClaimedTraderID != device.TraderID {
+		return nil, uc.fail(req, domain.DeviceAuthTraderMismatch)
+	}
+	return device, nil
+}
+
+func (uc *DefaultDeviceAuthUsecase) PurgeExpiredNonces() error {
+	deleted, err := uc.credentialRepo.DeleteExpiredDeviceRequestNonces(time.Now())
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("🧹 [DEVICE-AUTH] Deleted %d expired request nonces", deleted)
+	}
+	return nil
+}
+
+// fail записывает отказ в аудит безопасности. Ошибка записи аудита не меняет результат проверки
+func (uc *DefaultDeviceAuthUsecase) fail(req *domain.DeviceAuthRequest, reason string) error {
+	failure := &domain.DeviceAuthFailure{
+		ID:              uuid.New().String(),
+		DeviceID:        req.DeviceID,
+		ClaimedDeviceID: req.ClaimedDeviceID,
+		ClaimedTraderID: req.ClaimedTraderID,
+		Method:          req.Method,
+		PeerAddr:        req.PeerAddr,
+		Reason:          reason,
+		Enforced:        uc.mode == domain.DeviceAuthModeEnforce,
+		CreatedAt:       time.Now(),
+	}
+	if err := uc.credentialRepo.SaveDeviceAuthFailure(failure); err != nil {
+		log.Printf("⚠️  [DEVICE-AUTH] Failed to save auth failure: %v", err)
+	}
+	log.Printf("🚫 [DEVICE-AUTH] %s: device=%s, claimed_device=%s, claimed_trader=%s, method=%s, peer=%s, enforced=%v",
+		reason, req.DeviceID, req.ClaimedDeviceID, req.ClaimedTraderID, req.Method, req.PeerAddr, failure.Enforced)
+	return &domain.DeviceAuthError{Reason: reason}
+}
@@ -0,0 +1,383 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+const testMethod = "/order.DeviceService/UpdateDeviceLiveness"
+
+// fakeDeviceRepo - устройства в памяти, нужен только GetDeviceByID
+type fakeDeviceRepo struct {
+	domain.DeviceRepository
+	devices map[string]*domain.Device
+}
+
+func (r *fakeDeviceRepo) GetDeviceByID(deviceID string) (*domain.Device, error) {
+	device, ok := r.devices[deviceID]
+	if !ok {
+		return nil, domain.ErrDeviceNotFound
+	}
+	return device, nil
+}
+
+// fakeCredentialRepo повторяет условия запросов DefaultDeviceCredentialRepository в памяти
+type fakeCredentialRepo struct {
+	credentials []*domain.DeviceCredential
+	failures    []*domain.DeviceAuthFailure
+	nonces      map[string]time.Time
+}
+
+func newFakeCredentialRepo() *fakeCredentialRepo {
+	return &fakeCredentialRepo{nonces: make(map[string]time.Time)}
+}
+
+func (r *fakeCredentialRepo) CreateDeviceCredential(credential *domain.DeviceCredential) error {
+	stored := *credential
+	r.credentials = append(r.credentials, &stored)
+	return nil
+}
+
+func (r *fakeCredentialRepo) GetActiveDeviceCredentials(deviceID string, now time.Time) ([]*domain.DeviceCredential, error) {
+	var active []*domain.DeviceCredential
+	for _, credential := range r.credentials {
+		if credential.DeviceID == deviceID && credential.Active(now) {
+			active = append(active, credential)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool { return active[i].CreatedAt.After(active[j].CreatedAt) })
+	return active, nil
+}
+
+func (r *fakeCredentialRepo) ExpireDeviceCredentials(deviceID string, expiresAt time.Time) error {
+	for _, credential := range r.credentials {
+		if credential.DeviceID == deviceID && credential.RevokedAt == nil &&
+			(credential.ExpiresAt == nil || credential.ExpiresAt.After(expiresAt)) {
+			expires := expiresAt
+			credential.ExpiresAt = &expires
+		}
+	}
+	return nil
+}
+
+func (r *fakeCredentialRepo) RevokeDeviceCredentials(deviceID string, revokedAt time.Time) error {
+	for _, credential := range r.credentials {
+		if credential.DeviceID == deviceID && credential.RevokedAt == nil {
+			revoked := revokedAt
+			credential.RevokedAt = &revoked
+		}
+	}
+	return nil
+}
+
+func (r *fakeCredentialRepo) SaveDeviceAuthFailure(failure *domain.DeviceAuthFailure) error {
+	r.failures = append(r.failures, failure)
+	return nil
+}
+
+func (r *fakeCredentialRepo) SaveDeviceRequestNonce(deviceID, signature string, expiresAt time.Time) (bool, error) {
+	key := deviceID + "/" + signature
+	if _, ok := r.nonces[key]; ok {
+		return false, nil
+	}
+	r.nonces[key] = expiresAt
+	return true, nil
+}
+
+func (r *fakeCredentialRepo) DeleteExpiredDeviceRequestNonces(now time.Time) (int64, error) {
+	var deleted int64
+	for key, expiresAt := range r.nonces {
+		if expiresAt.Before(now) {
+			delete(r.nonces, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func newTestDeviceAuth(t *testing.T, credentials *fakeCredentialRepo, rotationGrace time.Duration) *DefaultDeviceAuthUsecase {
+	t.Helper()
+	devices := &fakeDeviceRepo{devices: map[string]*domain.Device{
+		"device-1": {DeviceID: "device-1", TraderID: "trader-1"},
+		"device-2": {DeviceID: "device-2", TraderID: "trader-2"},
+	}}
+	uc, err := NewDefaultDeviceAuthUsecase(devices, credentials, domain.DeviceAuthModeEnforce, 5*time.Minute, rotationGrace)
+	if err != nil {
+		t.Fatalf("NewDefaultDeviceAuthUsecase failed: %v", err)
+	}
+	return uc
+}
+
+// signedRequest - запрос устройства deviceID, подписанный secret в момент at
+func signedRequest(deviceID, secret string, at time.Time, payload string) *domain.DeviceAuthRequest {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return &domain.DeviceAuthRequest{
+		Method:          testMethod,
+		DeviceID:        deviceID,
+		Timestamp:       timestamp,
+		Signature:       domain.DeviceRequestSignature(secret, testMethod, timestamp, []byte(payload)),
+		Payload:         []byte(payload),
+		ClaimedDeviceID: deviceID,
+	}
+}
+
+// authReason - причина отказа или "" при успешной проверке
+func authReason(t *testing.T, uc *DefaultDeviceAuthUsecase, req *domain.DeviceAuthRequest) string {
+	t.Helper()
+	_, err := uc.AuthenticateDevice(req)
+	if err == nil {
+		return ""
+	}
+	var authErr *domain.DeviceAuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("AuthenticateDevice returned %v, want *domain.DeviceAuthError", err)
+	}
+	return authErr.Reason
+}
+
+func TestAuthenticateDevice(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		modify func(req *domain.DeviceAuthRequest)
+		want   string
+	}{
+		{name: "valid request", modify: func(req *domain.DeviceAuthRequest) {}},
+		{
+			name:   "no signature",
+			modify: func(req *domain.DeviceAuthRequest) { req.Signature = "" },
+			want:   domain.DeviceAuthMissingCredentials,
+		},
+		{
+			name:   "timestamp is not a number",
+			modify: func(req *domain.DeviceAuthRequest) { req.Timestamp = "yesterday" },
+			want:   domain.DeviceAuthBadTimestamp,
+		},
+		{
+			name: "timestamp older than the replay window",
+			modify: func(req *domain.DeviceAuthRequest) {
+				*req = *signedRequest("device-1", "secret-1", now.Add(-6*time.Minute), "5:device-1")
+			},
+			want: domain.DeviceAuthBadTimestamp,
+		},
+		{
+			name: "timestamp ahead of the replay window",
+			modify: func(req *domain.DeviceAuthRequest) {
+				*req = *signedRequest("device-1", "secret-1", now.Add(6*time.Minute), "5:device-1")
+			},
+			want: domain.DeviceAuthBadTimestamp,
+		},
+		{
+			name: "clock skew within the replay window",
+			modify: func(req *domain.DeviceAuthRequest) {
+				*req = *signedRequest("device-1", "secret-1", now.Add(-4*time.Minute), "5:device-1")
+			},
+		},
+		{
+			name:   "unknown device",
+			modify: func(req *domain.DeviceAuthRequest) { req.DeviceID = "device-404" },
+			want:   domain.DeviceAuthUnknownDevice,
+		},
+		{
+			name:   "device without a secret",
+			modify: func(req *domain.DeviceAuthRequest) { *req = *signedRequest("device-2", "secret-1", now, "5:device-2") },
+			want:   domain.DeviceAuthNoActiveSecret,
+		},
+		{
+			name:   "wrong secret",
+			modify: func(req *domain.DeviceAuthRequest) { *req = *signedRequest("device-1", "stolen", now, "5:device-1") },
+			want:   domain.DeviceAuthBadSignature,
+		},
+		{
+			name:   "body changed after signing",
+			modify: func(req *domain.DeviceAuthRequest) { req.Payload = []byte("5:device-2") },
+			want:   domain.DeviceAuthBadSignature,
+		},
+		{
+			name:   "signature of another method",
+			modify: func(req *domain.DeviceAuthRequest) { req.Method = "/order.OrderService/ProcessAutomaticPayment" },
+			want:   domain.DeviceAuthBadSignature,
+		},
+		{
+			name:   "request on behalf of another device",
+			modify: func(req *domain.DeviceAuthRequest) { req.ClaimedDeviceID = "device-2" },
+			want:   domain.DeviceAuthDeviceMismatch,
+		},
+		{
+			name:   "request on behalf of another trader",
+			modify: func(req *domain.DeviceAuthRequest) { req.ClaimedTraderID = "trader-2" },
+			want:   domain.DeviceAuthTraderMismatch,
+		},
+		{
+			name:   "request on behalf of the owner",
+			modify: func(req *domain.DeviceAuthRequest) { req.ClaimedTraderID = "trader-1" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credentials := newFakeCredentialRepo()
+			credentials.CreateDeviceCredential(&domain.DeviceCredential{ID: "credential-1", DeviceID: "device-1", Secret: "secret-1", CreatedAt: now})
+			uc := newTestDeviceAuth(t, credentials, time.Hour)
+
+			req := signedRequest("device-1", "secret-1", now, "5:device-1")
+			tt.modify(req)
+			if got := authReason(t, uc, req); got != tt.want {
+				t.Fatalf("reason = %q, want %q", got, tt.want)
+			}
+
+			// Каждый отказ попадает в аудит
+			if tt.want == "" && len(credentials.failures) != 0 {
+				t.Errorf("accepted request was audited as %s", credentials.failures[0].Reason)
+			}
+			if tt.want != "" && (len(credentials.failures) != 1 || credentials.failures[0].Reason != tt.want || !credentials.failures[0].Enforced) {
+				t.Errorf("audit = %+v, want one enforced %s failure", credentials.failures, tt.want)
+			}
+		})
+	}
+}
+
+// Повтор запроса отклоняется и на другом экземпляре сервиса: подписи хранятся в общей БД
+func TestAuthenticateDeviceReplay(t *testing.T) {
+	now := time.Now()
+	credentials := newFakeCredentialRepo()
+	credentials.CreateDeviceCredential(&domain.DeviceCredential{ID: "credential-1", DeviceID: "device-1", Secret: "secret-1", CreatedAt: now})
+	first := newTestDeviceAuth(t, credentials, time.Hour)
+	second := newTestDeviceAuth(t, credentials, time.Hour)
+
+	req := signedRequest("device-1", "secret-1", now, "5:device-1")
+	if got := authReason(t, first, req); got != "" {
+		t.Fatalf("first request rejected: %s", got)
+	}
+	if got := authReason(t, first, req); got != domain.DeviceAuthReplay {
+		t.Errorf("replay on the same instance: reason = %q, want %q", got, domain.DeviceAuthReplay)
+	}
+	if got := authReason(t, second, req); got != domain.DeviceAuthReplay {
+		t.Errorf("replay on another instance: reason = %q, want %q", got, domain.DeviceAuthReplay)
+	}
+
+	// Следующий запрос с новым временем принимается
+	if got := authReason(t, second, signedRequest("device-1", "secret-1", now.Add(time.Second), "5:device-1")); got != "" {
+		t.Errorf("fresh request rejected: %s", got)
+	}
+}
+
+func TestPurgeExpiredNonces(t *testing.T) {
+	credentials := newFakeCredentialRepo()
+	credentials.nonces["device-1/old"] = time.Now().Add(-time.Minute)
+	credentials.nonces["device-1/fresh"] = time.Now().Add(time.Minute)
+	uc := newTestDeviceAuth(t, credentials, time.Hour)
+
+	if err := uc.PurgeExpiredNonces(); err != nil {
+		t.Fatalf("PurgeExpiredNonces failed: %v", err)
+	}
+	if _, ok := credentials.nonces["device-1/old"]; ok {
+		t.Errorf("expired nonce was not deleted")
+	}
+	if _, ok := credentials.nonces["device-1/fresh"]; !ok {
+		t.Errorf("nonce within the replay window was deleted")
+	}
+}
+
+func TestRotateDeviceSecret(t *testing.T) {
+	tests := []struct {
+		name          string
+		rotationGrace time.Duration
+		oldSecretWant string
+	}{
+		{name: "old secret works during rotation grace", rotationGrace: time.Hour},
+		{name: "old secret stops working after rotation grace", rotationGrace: -time.Second, oldSecretWant: domain.DeviceAuthBadSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credentials := newFakeCredentialRepo()
+			uc := newTestDeviceAuth(t, credentials, tt.rotationGrace)
+			old, err := uc.IssueDeviceSecret("device-1")
+			if err != nil {
+				t.Fatalf("IssueDeviceSecret failed: %v", err)
+			}
+			rotated, err := uc.RotateDeviceSecret("device-1", "trader-1")
+			if err != nil {
+				t.Fatalf("RotateDeviceSecret failed: %v", err)
+			}
+			if rotated.Secret == old.Secret {
+				t.Fatalf("rotation returned the old secret")
+			}
+
+			now := time.Now()
+			if got := authReason(t, uc, signedRequest("device-1", rotated.Secret, now, "5:device-1")); got != "" {
+				t.Errorf("new secret rejected: %s", got)
+			}
+			if got := authReason(t, uc, signedRequest("device-1", old.Secret, now.Add(time.Second), "5:device-1")); got != tt.oldSecretWant {
+				t.Errorf("old secret: reason = %q, want %q", got, tt.oldSecretWant)
+			}
+		})
+	}
+}
+
+func TestRevokeDeviceSecrets(t *testing.T) {
+	credentials := newFakeCredentialRepo()
+	uc := newTestDeviceAuth(t, credentials, time.Hour)
+	old, _ := uc.IssueDeviceSecret("device-1")
+	rotated, _ := uc.RotateDeviceSecret("device-1", "trader-1")
+
+	if err := uc.RevokeDeviceSecrets("device-1", "trader-1"); err != nil {
+		t.Fatalf("RevokeDeviceSecrets failed: %v", err)
+	}
+	now := time.Now()
+	for i, secret := range []string{old.Secret, rotated.Secret} {
+		req := signedRequest("device-1", secret, now.Add(time.Duration(i)*time.Second), "5:device-1")
+		if got := authReason(t, uc, req); got != domain.DeviceAuthNoActiveSecret {
+			t.Errorf("secret %d after revocation: reason = %q, want %q", i, got, domain.DeviceAuthNoActiveSecret)
+		}
+	}
+}
+
+// Секретами распоряжается только трейдер-владелец устройства
+func TestDeviceSecretOwnership(t *testing.T) {
+	tests := []struct {
+		name     string
+		deviceID string
+		traderID string
+		want     error
+	}{
+		{name: "owner", deviceID: "device-1", traderID: "trader-1"},
+		{name: "another trader", deviceID: "device-1", traderID: "trader-2", want: domain.ErrDeviceNotOwned},
+		{name: "no trader", deviceID: "device-1", traderID: "", want: domain.ErrDeviceNotOwned},
+		{name: "unknown device", deviceID: "device-404", traderID: "trader-1", want: domain.ErrDeviceNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credentials := newFakeCredentialRepo()
+			uc := newTestDeviceAuth(t, credentials, time.Hour)
+			if _, err := uc.IssueDeviceSecret("device-1"); err != nil {
+				t.Fatalf("IssueDeviceSecret failed: %v", err)
+			}
+
+			_, rotateErr := uc.RotateDeviceSecret(tt.deviceID, tt.traderID)
+			revokeErr := uc.RevokeDeviceSecrets(tt.deviceID, tt.traderID)
+			for operation, err := range map[string]error{"rotate": rotateErr, "revoke": revokeErr} {
+				if tt.want == nil && err != nil {
+					t.Errorf("%s failed: %v", operation, err)
+				}
+				if tt.want != nil && !errors.Is(err, tt.want) {
+					t.Errorf("%s = %v, want %v", operation, err, tt.want)
+				}
+			}
+			// Отказ не выдает новый секрет и не отзывает действующий
+			if tt.want != nil && len(credentials.credentials) != 1 {
+				t.Errorf("rejected rotation issued a secret: %d credentials", len(credentials.credentials))
+			}
+			if tt.want != nil && credentials.credentials[0].RevokedAt != nil {
+				t.Errorf("rejected revocation revoked the secret")
+			}
+		})
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 type DeviceUsecase interface {
-	CreateDevice(input *devicedto.CreateDeviceInput) error
+	CreateDevice(input *devicedto.CreateDeviceInput) (*domain.Device, error)
 	DeleteDevice(input *devicedto.DeleteDeviceInput) error
 	EditDevice(input *devicedto.EditDeviceInput) error
 	GetTraderDevices(input *devicedto.GetTraderDevicesInput) (*devicedto.GetTraderDevicesOutput, error)
@@ -30,17 +30,21 @@ func NewDefaultDeviceUsecase(deviceRepo domain.DeviceRepository) *DefaultDeviceU
 	}
 }
 
-func (uc *DefaultDeviceUsecase) CreateDevice(input *devicedto.CreateDeviceInput) error {
+func (uc *DefaultDeviceUsecase) CreateDevice(input *devicedto.CreateDeviceInput) (*domain.Device, error) {
 	idGenerator, err := nanoid.Standard(15)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return uc.deviceRepo.CreateDevice(&domain.Device{
+	device := &domain.Device{
 		DeviceID: idGenerator(),
 		DeviceName: input.DeviceName,
 		TraderID: input.TraderID,
 		Enabled: input.Enabled,
-	})
+	}
+	if err := uc.deviceRepo.CreateDevice(device); err != nil {
+		return nil, err
+	}
+	return device, nil
 }
 
 func (uc *DefaultDeviceUsecase) DeleteDevice(input *devicedto.DeleteDeviceInput) error {
@@ -15,6 +15,11 @@ import (
 )
 
 func (disputeUc *DefaultDisputeUsecase) AcceptDispute(disputeID string) error {
+	return disputeUc.acceptDispute(disputeID, domain.ActorOperator, "")
+}
+
+// acceptDispute - принятие диспута с указанием инициатора и причины для истории статусов сделки
+func (disputeUc *DefaultDisputeUsecase) acceptDispute(disputeID, actor, reason string) error {
 	dispute, err := disputeUc.disputeRepo.GetDisputeByID(disputeID)
 	if err != nil {
 		return err
@@ -23,7 +28,7 @@ func (disputeUc *DefaultDisputeUsecase) AcceptDispute(disputeID string) error {
 	if err != nil {
 		return err
 	}
-	if order.Status != domain.StatusDisputeCreated {
+	if !disputeUc.stateMachine.CanApply(domain.OrderOpDisputeAccept, order.Status) {
 		return fmt.Errorf("invalid order status to accept dispute: %s", order.Status)
 	}
 	traffic, err := disputeUc.trafficRepo.GetTrafficByTraderMerchant(order.RequisiteDetails.TraderID, order.MerchantInfo.MerchantID)
@@ -52,6 +57,8 @@ func (disputeUc *DefaultDisputeUsecase) AcceptDispute(disputeID string) error {
 		NewOrderAmountFiat: dispute.DisputeAmountFiat,
 		NewOrderAmountCrypto: dispute.DisputeAmountCrypto,
 		NewOrderAmountCryptoRate: dispute.DisputeCryptoRate,		
+		Actor: actor,
+		Reason: reason,
 		WalletOp: &WalletOperation{
 			Type: "release",
 			Request: walletRequest.ReleaseRequest{
@@ -65,19 +72,16 @@ func (disputeUc *DefaultDisputeUsecase) AcceptDispute(disputeID string) error {
 		},
 		CreatedAt: time.Now(),
 	}
+	if order.CallbackUrl != "" {
+		op.Callback = notifier.OrderCallback(order, string(domain.StatusCompleted))
+		op.Callback.ReconciliationSum = dispute.DisputeAmountCrypto
+		op.Callback.ReconciliationAmount = dispute.DisputeAmountFiat
+		op.Callback.ReconciliationRate = dispute.DisputeCryptoRate
+	}
 
 	if err := disputeUc.ProcessDisputeOperation(context.Background(), op); err != nil {
 		return err
 	}
-	
-	if order.CallbackUrl != "" {
-		notifier.SendCallback(
-			order.CallbackUrl,
-			order.MerchantInfo.MerchantOrderID,
-			string(domain.StatusCompleted),
-			dispute.DisputeAmountCrypto, dispute.DisputeAmountFiat, dispute.DisputeCryptoRate,
-		)
-	}
 	return nil
 }
 
@@ -87,7 +91,7 @@ func (disputeUc *DefaultDisputeUsecase) AcceptExpiredDisputes() error {
 		return err
 	}
 	for _, dispute := range disputes {
-		if err := disputeUc.AcceptDispute(dispute.ID); err != nil {
+		if err := disputeUc.acceptDispute(dispute.ID, domain.ActorScheduler, "dispute ttl expired"); err != nil {
 			log.Printf("failed to accept dispute %s\n", dispute.ID)
 			return status.Error(codes.Internal, err.Error())
 		}
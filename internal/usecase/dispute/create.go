@@ -1,14 +1,14 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
-	"log/slog"
 	"time"
 
 	"github.com/LavaJover/shvark-order-service/internal/domain"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
-	publisher "github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
 	disputedto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/dispute"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
 	"github.com/jaevor/go-nanoid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -21,7 +21,7 @@ func (disputeUc *DefaultDisputeUsecase) CreateDispute(input *disputedto.CreateDi
 	if err != nil {
 		return err
 	}
-	if (order.Status != domain.StatusCanceled) && (order.Status != domain.StatusCompleted) {
+	if !disputeUc.stateMachine.CanApply(domain.OrderOpDisputeOpen, order.Status) {
 		return status.Error(codes.FailedPrecondition, "invalid order status")
 	}
 	idGenerator, err := nanoid.Standard(15)
@@ -47,51 +47,41 @@ func (disputeUc *DefaultDisputeUsecase) CreateDispute(input *disputedto.CreateDi
 		AutoAcceptAt: time.Now().Add(input.Ttl),
 	}
 
-	err = disputeUc.disputeRepo.CreateDispute(&dispute)
+	// Сумма диспута замораживается в транзакции его создания: если сделку параллельно изменили
+	// и переход отклонен, деньги трейдера не зависнут в заморозке, а диспут не останется открытым
+	freezeAmount := dispute.DisputeAmountCrypto
+	if order.Status == domain.StatusCompleted {
+		freezeAmount -= order.AmountInfo.AmountCrypto
+	}
+	walletFunc := func() error {
+		return disputeUc.walletHandler.Freeze(order.RequisiteDetails.TraderID, fmt.Sprintf("%s_dispute_%s", dispute.OrderID, dispute.ID), freezeAmount)
+	}
+
+	// Событие об открытии диспута сохраняется в outbox вместе со сменой статуса сделки
+	events, err := disputeUc.events.DisputeEvents(orderpb.EventType_EVENT_TYPE_DISPUTE_OPENED, order, &dispute, "🆘Открыт диспут")
 	if err != nil {
 		return err
 	}
-	go func(event publisher.DisputeEvent){
-		if err := disputeUc.kafkaPublisher.PublishDispute(event); err != nil {
-			slog.Error("failed to publish kafka dispute event", "stage", "creating", "error",err.Error())
-		}
-	}(publisher.DisputeEvent{
-		DisputeID: dispute.ID,
-		OrderID: dispute.OrderID,
-		TraderID: order.RequisiteDetails.TraderID,
-		OrderAmountFiat: order.AmountInfo.AmountFiat,
-		DisputeAmountFiat: dispute.DisputeAmountFiat,
-		ProofUrl: dispute.ProofUrl,
+	transition := &domain.OrderStatusTransition{
+		OrderID: order.ID,
+		FromStatus: order.Status,
+		ToStatus: domain.StatusDisputeCreated,
+		Actor: domain.ActorOperator,
+		Operation: domain.OrderOpDisputeOpen,
 		Reason: dispute.Reason,
-		Status: "🆘Открыт диспут",
-		BankName: order.RequisiteDetails.BankName,
-		Phone: order.RequisiteDetails.Phone,
-		CardNumber: order.RequisiteDetails.CardNumber,
-		Owner: order.RequisiteDetails.Owner,
-	})
-
-	if order.Status == domain.StatusCompleted {
-		err = disputeUc.walletHandler.Freeze(order.RequisiteDetails.TraderID, fmt.Sprintf("%s_dispute_%s", dispute.OrderID, dispute.ID), dispute.DisputeAmountCrypto-order.AmountInfo.AmountCrypto)
-		if err != nil {
-			return status.Error(codes.Internal, err.Error())
-		}
-	}else {
-		err = disputeUc.walletHandler.Freeze(order.RequisiteDetails.TraderID, fmt.Sprintf("%s_dispute_%s", dispute.OrderID, dispute.ID), dispute.DisputeAmountCrypto)
-		if err != nil {
-			return status.Error(codes.Internal, err.Error())
-		}
 	}
-	err = disputeUc.orderRepo.UpdateOrderStatus(order.ID, domain.StatusDisputeCreated)
+	if order.CallbackUrl != "" {
+		transition.Callback = notifier.OrderCallback(order, string(domain.StatusDisputeCreated))
+	}
+	err = disputeUc.disputeRepo.OpenDispute(&dispute, transition, events, walletFunc)
 	if err != nil {
-		return err
+		if errors.Is(err, domain.ErrInvalidStatusTransition) || errors.Is(err, domain.ErrConcurrentModification) {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
 	}
-	if order.CallbackUrl != "" {
-		notifier.SendCallback(
-			order.CallbackUrl,
-			order.MerchantInfo.MerchantOrderID,
-			string(domain.StatusDisputeCreated),
-			0, 0, 0,
-		)
+	if disputeUc.health != nil {
+		disputeUc.health.DisputeOpened(order)
 	}
 	return nil
 }
\ No newline at end of file
@@ -26,6 +26,7 @@ func (disputeUc *DefaultDisputeUsecase) FreezeDispute(disputeID string) error {
 		Operation: "freeze",
 		OldDisputeStatus: dispute.Status,
 		NewDisputeStatus: domain.DisputeFreezed,
+		Actor: domain.ActorOperator,
 		WalletOp: nil,
 		CreatedAt: time.Now(),
 	}
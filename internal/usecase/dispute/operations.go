@@ -23,6 +23,10 @@ type DisputeOperation struct {
 	NewOrderAmountFiat float64
 	NewOrderAmountCrypto float64
 	NewOrderAmountCryptoRate float64
+	Actor       string                              `json:"actor"` // кто инициировал переход (domain.Actor*)
+	Reason      string                              `json:"reason,omitempty"`
+	Events      []*domain.OutboxEvent               `json:"-"` // события, записываемые в outbox вместе со сменой статуса
+	Callback    *domain.CallbackDelivery            `json:"-"` // колбэк мерчанту, ставится в очередь вместе со сменой статуса
     WalletOp    *WalletOperation         			`json:"wallet_op,omitempty"`
     CreatedAt   time.Time                			`json:"created_at"`
 }
@@ -59,13 +63,21 @@ func (disputeUc *DefaultDisputeUsecase) processCriticalOperations(ctx context.Co
         }
     }
 
+    actor := op.Actor
+    if actor == "" {
+        actor = domain.ActorSystem
+    }
+
     return disputeUc.disputeRepo.ProcessDisputeCriticalOperation(
         op.DisputeID, 
 		op.OrderID,
         op.NewDisputeStatus,
-		op.NewOrderStatus,
+		op.OldOrderStatus, op.NewOrderStatus,
 		op.NewOrderAmountFiat, op.NewOrderAmountCrypto, op.NewOrderAmountCryptoRate,
         op.Operation, // передаем тип операции
+        actor, op.Reason,
+        op.Events,
+        op.Callback,
         walletFunc,
     )
 }
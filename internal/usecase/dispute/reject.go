@@ -22,7 +22,7 @@ func (disputeUc *DefaultDisputeUsecase) RejectDispute(disputeID string) error {
 	if err != nil {
 		return err
 	}
-	if order.Status != domain.StatusDisputeCreated {
+	if !disputeUc.stateMachine.CanApply(domain.OrderOpDisputeReject, order.Status) {
 		return fmt.Errorf("invalid order status to reject dispute: %s", order.Status)
 	}
 	op := &DisputeOperation{
@@ -33,6 +33,7 @@ func (disputeUc *DefaultDisputeUsecase) RejectDispute(disputeID string) error {
 		NewDisputeStatus: domain.DisputeRejected,
 		OldOrderStatus: order.Status,
 		NewOrderStatus: dispute.OrderStatusOriginal,
+		Actor: domain.ActorOperator,
 		WalletOp: &WalletOperation{
 			Type: "release",
 			Request: walletRequest.ReleaseRequest{
@@ -45,17 +46,13 @@ func (disputeUc *DefaultDisputeUsecase) RejectDispute(disputeID string) error {
 		},
 		CreatedAt: time.Now(),
 	}
+	// Сделка возвращается в статус до диспута, о нем и сообщаем мерчанту
+	if order.CallbackUrl != "" {
+		op.Callback = notifier.OrderCallback(order, string(dispute.OrderStatusOriginal))
+	}
 	if err := disputeUc.ProcessDisputeOperation(context.Background(), op); err != nil {
 		return err
 	}
-	if order.CallbackUrl != "" {
-		notifier.SendCallback(
-			order.CallbackUrl,
-			order.MerchantInfo.MerchantOrderID,
-			string(domain.StatusCanceled),
-			0, 0, 0,
-		)
-	}
 	
 	return nil
 }
\ No newline at end of file
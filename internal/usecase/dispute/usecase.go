@@ -3,9 +3,11 @@ package usecase
 import (
 	"github.com/LavaJover/shvark-order-service/internal/delivery/http/handlers"
 	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
 	publisher "github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
 	"github.com/LavaJover/shvark-order-service/internal/usecase"
 	disputedto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/dispute"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/health"
 )
 
 type DisputeUsecase interface {
@@ -27,6 +29,11 @@ type DefaultDisputeUsecase struct {
 	kafkaPublisher *publisher.KafkaPublisher
 	teamRelationsUsecase usecase.TeamRelationsUsecase
 	bankDetailRepo domain.BankDetailRepository
+	stateMachine *domain.OrderStateMachine
+	callbacks *notifier.CallbackQueue
+	events *publisher.EventWriter
+	// nil - здоровье реквизитов не отслеживается
+	health *health.Monitor
 }
 
 func NewDefaultDisputeUsecase(
@@ -37,6 +44,9 @@ func NewDefaultDisputeUsecase(
 	kafkaPublisher *publisher.KafkaPublisher,
 	teamRelationsUsecase usecase.TeamRelationsUsecase,
 	bankDetailRepo domain.BankDetailRepository,
+	callbacks *notifier.CallbackQueue,
+	events *publisher.EventWriter,
+	healthMonitor *health.Monitor,
 	) *DefaultDisputeUsecase {
 	return &DefaultDisputeUsecase{
 		disputeRepo: disputeRepo,
@@ -46,5 +56,9 @@ func NewDefaultDisputeUsecase(
 		kafkaPublisher: kafkaPublisher,
 		teamRelationsUsecase: teamRelationsUsecase,
 		bankDetailRepo: bankDetailRepo,
+		stateMachine: domain.NewOrderStateMachine(),
+		callbacks: callbacks,
+		events: events,
+		health: healthMonitor,
 	}
 }
\ No newline at end of file
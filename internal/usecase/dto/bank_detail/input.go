@@ -18,10 +18,16 @@ type SearchParams struct {
 	MaxAmountMonth  		float64
 	MaxQuantityDay			int32
 	MaxQuantityMonth		int32
+	MaxAmountHour			float64
+	MaxQuantityHour			int32
+	MaxAmountWeek			float64
+	MaxQuantityWeek			int32
 	MinOrderAmount 			float32
 	MaxOrderAmount 			float32
 	Delay 					time.Duration
 	Enabled 				bool
+	LimitMode 				string
+	Timezone 				string
 }
 
 type DeviceInfo struct {
@@ -29,4 +29,9 @@ type TrafficAntifraudParams struct {
 
 type TrafficBusinessParams struct {
 	MerchantDealsDuration time.Duration
+	UniqueAmountStep 		float64
+	UniqueAmountMaxSteps 	int32
+	AmountTolerance 		float64
+	AmountTolerancePercent 	float64
+	SelectionStrategy 		string
 }
\ No newline at end of file
@@ -0,0 +1,232 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	publisher "github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/cascade"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
+)
+
+// Monitor следит за здоровьем реквизитов: по отменам и спорам их сделок приостанавливает реквизит
+// на время остывания, снимает истекшие приостановки и уведомляет трейдера событием в Kafka
+type Monitor struct {
+	repo           domain.BankDetailHealthRepository
+	bankDetailRepo domain.BankDetailRepository
+	orderRepo      domain.OrderRepository
+	outboxRepo     domain.OutboxRepository
+	events         *publisher.EventWriter
+	policy         domain.BankDetailHealthPolicy
+	interval       time.Duration
+	// nil - каскад подбора выключен, приостановка видна SQL-подбору сразу
+	cascade *cascade.CascadeMatchEngine
+}
+
+func NewMonitor(
+	repo domain.BankDetailHealthRepository,
+	bankDetailRepo domain.BankDetailRepository,
+	orderRepo domain.OrderRepository,
+	outboxRepo domain.OutboxRepository,
+	events *publisher.EventWriter,
+	policy domain.BankDetailHealthPolicy,
+	interval time.Duration,
+	cascadeEngine *cascade.CascadeMatchEngine,
+) *Monitor {
+	return &Monitor{
+		repo:           repo,
+		bankDetailRepo: bankDetailRepo,
+		orderRepo:      orderRepo,
+		outboxRepo:     outboxRepo,
+		events:         events,
+		policy:         policy,
+		interval:       interval,
+		cascade:        cascadeEngine,
+	}
+}
+
+// Start снимает истекшие приостановки сразу и затем раз в interval до отмены контекста
+func (m *Monitor) Start(ctx context.Context) {
+	m.resumeExpired()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.resumeExpired()
+		}
+	}
+}
+
+func (m *Monitor) resumeExpired() {
+	bankDetails, err := m.repo.FindExpiredSuspensions(time.Now())
+	if err != nil {
+		slog.Error("failed to find expired bank detail suspensions", "error", err)
+		return
+	}
+	for _, bankDetail := range bankDetails {
+		if _, err := m.resume(bankDetail.ID); err != nil {
+			slog.Error("failed to resume bank detail", "bank_detail_id", bankDetail.ID, "error", err)
+		}
+	}
+}
+
+// OrderClosed учитывает закрытие сделки реквизита: отмена увеличивает счетчики и может приостановить
+// реквизит, завершение сбрасывает счетчик отмен подряд. Ошибки только логируются - сделка уже закрыта
+func (m *Monitor) OrderClosed(orderID string, status domain.OrderStatus) {
+	if status != domain.StatusCanceled && status != domain.StatusCompleted {
+		return
+	}
+	order, err := m.orderRepo.GetOrderByID(orderID)
+	if err != nil {
+		slog.Error("bank detail health: failed to get order", "order_id", orderID, "error", err)
+		return
+	}
+	if order.BankDetailID == nil || *order.BankDetailID == "" {
+		return
+	}
+	bankDetailID := *order.BankDetailID
+
+	if status == domain.StatusCompleted {
+		if err := m.repo.RecordBankDetailCompletion(bankDetailID); err != nil {
+			slog.Error("bank detail health: failed to record completion", "bank_detail_id", bankDetailID, "error", err)
+		}
+		return
+	}
+
+	now := time.Now()
+	health, err := m.repo.RecordBankDetailCancel(bankDetailID, order.RequisiteDetails.TraderID, now)
+	if err != nil {
+		slog.Error("bank detail health: failed to record cancel", "bank_detail_id", bankDetailID, "error", err)
+		return
+	}
+	m.evaluate(health, now)
+}
+
+// DisputeOpened учитывает спор по сделке реквизита
+func (m *Monitor) DisputeOpened(order *domain.Order) {
+	if order.BankDetailID == nil || *order.BankDetailID == "" {
+		return
+	}
+	bankDetailID := *order.BankDetailID
+
+	now := time.Now()
+	health, err := m.repo.RecordBankDetailDispute(bankDetailID, order.RequisiteDetails.TraderID, now)
+	if err != nil {
+		slog.Error("bank detail health: failed to record dispute", "bank_detail_id", bankDetailID, "error", err)
+		return
+	}
+	m.evaluate(health, now)
+}
+
+// evaluate приостанавливает реквизит, если его счетчики нарушают политику
+func (m *Monitor) evaluate(health *domain.BankDetailHealth, now time.Time) {
+	if health.SuspendedUntil != nil && now.Before(*health.SuspendedUntil) {
+		return
+	}
+	if err := m.countWindows(health, now); err != nil {
+		slog.Error("bank detail health: failed to count window events", "bank_detail_id", health.BankDetailID, "error", err)
+		return
+	}
+	reason := m.policy.Violation(health)
+	if reason == "" {
+		return
+	}
+
+	until := now.Add(m.policy.Cooldown)
+	suspended, err := m.repo.SuspendBankDetail(health.BankDetailID, reason, now, until)
+	if err != nil {
+		slog.Error("bank detail health: failed to suspend bank detail", "bank_detail_id", health.BankDetailID, "error", err)
+		return
+	}
+	if !suspended {
+		return
+	}
+	slog.Info("bank detail suspended",
+		"bank_detail_id", health.BankDetailID,
+		"trader_id", health.TraderID,
+		"reason", reason,
+		"until", until,
+	)
+	if m.cascade != nil {
+		m.cascade.BankDetailSuspended(health.BankDetailID, &until, reason)
+	}
+	m.notify(orderpb.EventType_EVENT_TYPE_BANK_DETAIL_SUSPENDED, health)
+}
+
+// countWindows считает отмены и споры реквизита в окнах политики
+func (m *Monitor) countWindows(health *domain.BankDetailHealth, now time.Time) error {
+	var err error
+	if m.policy.MaxWindowCancels > 0 {
+		health.WindowCancels, err = m.repo.CountBankDetailCancels(health.BankDetailID, health.WindowStart(now, m.policy.CancelWindow))
+		if err != nil {
+			return err
+		}
+	}
+	if m.policy.MaxWindowDisputes > 0 {
+		health.WindowDisputes, err = m.repo.CountBankDetailDisputes(health.BankDetailID, health.WindowStart(now, m.policy.DisputeWindow))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Resume снимает приостановку реквизита вручную, не дожидаясь конца остывания
+func (m *Monitor) Resume(bankDetailID string) (*domain.BankDetailHealth, error) {
+	if _, err := m.resume(bankDetailID); err != nil {
+		return nil, err
+	}
+	return m.GetHealth(bankDetailID)
+}
+
+func (m *Monitor) resume(bankDetailID string) (bool, error) {
+	resumed, err := m.repo.ResumeBankDetail(bankDetailID, time.Now())
+	if err != nil || !resumed {
+		return resumed, err
+	}
+	slog.Info("bank detail resumed", "bank_detail_id", bankDetailID)
+	if m.cascade != nil {
+		m.cascade.BankDetailSuspended(bankDetailID, nil, "")
+	}
+	health, err := m.repo.GetBankDetailHealth(bankDetailID)
+	if err != nil {
+		slog.Error("bank detail health: failed to get health", "bank_detail_id", bankDetailID, "error", err)
+		return true, nil
+	}
+	m.notify(orderpb.EventType_EVENT_TYPE_BANK_DETAIL_RESUMED, health)
+	return true, nil
+}
+
+// GetHealth - здоровье реквизита со счетчиками в окнах политики на текущий момент
+func (m *Monitor) GetHealth(bankDetailID string) (*domain.BankDetailHealth, error) {
+	health, err := m.repo.GetBankDetailHealth(bankDetailID)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.countWindows(health, time.Now()); err != nil {
+		return nil, err
+	}
+	return health, nil
+}
+
+// notify сохраняет событие для трейдера в outbox
+func (m *Monitor) notify(eventType orderpb.EventType, health *domain.BankDetailHealth) {
+	bankDetail, err := m.bankDetailRepo.GetBankDetailByID(health.BankDetailID)
+	if err == nil {
+		var events []*domain.OutboxEvent
+		events, err = m.events.BankDetailEvents(eventType, bankDetail, health)
+		for i := 0; err == nil && i < len(events); i++ {
+			err = m.outboxRepo.EnqueueOutboxEvent(events[i])
+		}
+	}
+	if err != nil {
+		slog.Error("failed to enqueue bank detail events to outbox", "bank_detail_id", health.BankDetailID, "event_type", publisher.EventTypeName(eventType), "error", err)
+	}
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+type NotificationTemplateUsecase interface {
+	CreateNotificationTemplate(template *domain.NotificationTemplate) (*domain.NotificationTemplate, error)
+	UpdateNotificationTemplate(template *domain.NotificationTemplate) (*domain.NotificationTemplate, error)
+	DeleteNotificationTemplate(templateID string) error
+	GetNotificationTemplate(templateID string) (*domain.NotificationTemplate, error)
+	ListNotificationTemplates(bankCode string, enabledOnly bool) ([]*domain.NotificationTemplate, error)
+	// Разбирает текст шаблоном без сохранения: для проверки шаблона перед созданием
+	TestNotificationTemplate(template *domain.NotificationTemplate, text string) (*domain.ParsedNotification, error)
+	// Разбирает текст уведомления включенными шаблонами банка bankCode, nil - ни один шаблон не подошел
+	ParseNotification(bankCode, text string) (*domain.ParsedNotification, error)
+}
+
+// compiledTemplate - включенный шаблон со скомпилированным выражением
+type compiledTemplate struct {
+	template *domain.NotificationTemplate
+	re       *regexp.Regexp
+}
+
+// DefaultNotificationTemplateUsecase хранит скомпилированные включенные шаблоны в памяти.
+// Изменения через этот экземпляр сервиса сбрасывают их сразу, изменения на других
+// экземплярах становятся видны не позже чем через cacheTTL
+type DefaultNotificationTemplateUsecase struct {
+	templateRepo domain.NotificationTemplateRepository
+	cacheTTL     time.Duration
+
+	mutex      sync.RWMutex
+	compiled   []compiledTemplate
+	loadedAt   time.Time
+	generation uint64 // растет при каждом сбросе
+}
+
+func NewDefaultNotificationTemplateUsecase(templateRepo domain.NotificationTemplateRepository, cacheTTL time.Duration) *DefaultNotificationTemplateUsecase {
+	return &DefaultNotificationTemplateUsecase{
+		templateRepo: templateRepo,
+		cacheTTL:     cacheTTL,
+	}
+}
+
+func (uc *DefaultNotificationTemplateUsecase) CreateNotificationTemplate(template *domain.NotificationTemplate) (*domain.NotificationTemplate, error) {
+	if err := template.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidNotificationTemplate, err)
+	}
+	template.ID = uuid.New().String()
+	template.CreatedAt = time.Now()
+	template.UpdatedAt = template.CreatedAt
+	if err := uc.templateRepo.CreateNotificationTemplate(template); err != nil {
+		return nil, err
+	}
+	uc.invalidate()
+	return template, nil
+}
+
+func (uc *DefaultNotificationTemplateUsecase) UpdateNotificationTemplate(template *domain.NotificationTemplate) (*domain.NotificationTemplate, error) {
+	if err := template.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidNotificationTemplate, err)
+	}
+	template.UpdatedAt = time.Now()
+	if err := uc.templateRepo.UpdateNotificationTemplate(template); err != nil {
+		return nil, err
+	}
+	uc.invalidate()
+	return uc.templateRepo.GetNotificationTemplate(template.ID)
+}
+
+func (uc *DefaultNotificationTemplateUsecase) DeleteNotificationTemplate(templateID string) error {
+	if err := uc.templateRepo.DeleteNotificationTemplate(templateID); err != nil {
+		return err
+	}
+	uc.invalidate()
+	return nil
+}
+
+func (uc *DefaultNotificationTemplateUsecase) GetNotificationTemplate(templateID string) (*domain.NotificationTemplate, error) {
+	return uc.templateRepo.GetNotificationTemplate(templateID)
+}
+
+func (uc *DefaultNotificationTemplateUsecase) ListNotificationTemplates(bankCode string, enabledOnly bool) ([]*domain.NotificationTemplate, error) {
+	return uc.templateRepo.ListNotificationTemplates(bankCode, enabledOnly)
+}
+
+func (uc *DefaultNotificationTemplateUsecase) TestNotificationTemplate(template *domain.NotificationTemplate, text string) (*domain.ParsedNotification, error) {
+	re, err := template.Compile()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidNotificationTemplate, err)
+	}
+	parsed, ok := template.Parse(re, text)
+	if !ok {
+		return nil, nil
+	}
+	return parsed, nil
+}
+
+// ParseNotification разбирает текст только шаблонами банка bankCode, заявленного устройством
+// в payment_system. Шаблоны других банков не пробуются: похожий текст другого банка давал бы
+// ложное расхождение по банку и блокировал законный платеж
+func (uc *DefaultNotificationTemplateUsecase) ParseNotification(bankCode, text string) (*domain.ParsedNotification, error) {
+	compiled, err := uc.templates()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range compiled {
+		if !strings.EqualFold(c.template.BankCode, bankCode) {
+			continue
+		}
+		if parsed, ok := c.template.Parse(c.re, text); ok {
+			return parsed, nil
+		}
+	}
+	return nil, nil
+}
+
+// templates возвращает скомпилированные включенные шаблоны, перечитывая их из БД по истечении cacheTTL
+func (uc *DefaultNotificationTemplateUsecase) templates() ([]compiledTemplate, error) {
+	uc.mutex.RLock()
+	compiled, loadedAt, generation := uc.compiled, uc.loadedAt, uc.generation
+	uc.mutex.RUnlock()
+	if !loadedAt.IsZero() && time.Since(loadedAt) < uc.cacheTTL {
+		return compiled, nil
+	}
+
+	templates, err := uc.templateRepo.ListNotificationTemplates("", true)
+	if err != nil {
+		return nil, err
+	}
+	compiled = make([]compiledTemplate, 0, len(templates))
+	for _, template := range templates {
+		re, err := template.Compile()
+		if err != nil {
+			// Шаблон проверяется при сохранении, сюда попадает только измененный в обход API
+			continue
+		}
+		compiled = append(compiled, compiledTemplate{template: template, re: re})
+	}
+
+	// Шаблоны, прочитанные до сброса, в кэш не попадают
+	uc.mutex.Lock()
+	if generation == uc.generation {
+		uc.compiled, uc.loadedAt = compiled, time.Now()
+	}
+	uc.mutex.Unlock()
+	return compiled, nil
+}
+
+func (uc *DefaultNotificationTemplateUsecase) invalidate() {
+	uc.mutex.Lock()
+	uc.loadedAt = time.Time{}
+	uc.generation++
+	uc.mutex.Unlock()
+}
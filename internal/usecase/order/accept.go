@@ -14,15 +14,16 @@ func (uc *DefaultOrderUsecase) AcceptOrder(orderID string) error {
 		return err
 	}
 
-	if order.Status != domain.StatusCreated {
+	if !uc.StateMachine.CanApply(domain.OrderOpAccept, order.Status) {
 		return domain.ErrResolveDisputeFailed
 	}
 
 	op := &OrderOperation{
 		OrderID:   orderID,
-		Operation: "accept",
+		Operation: domain.OrderOpAccept,
 		OldStatus: domain.StatusCreated,
 		NewStatus: domain.StatusPending,
+		Actor:     domain.ActorOperator,
 		WalletOp: nil,
 		CreatedAt: time.Now(),
 	}
@@ -2,13 +2,12 @@ package usecase
 
 import (
 	"context"
-	"log/slog"
 	"time"
 
 	walletRequest "github.com/LavaJover/shvark-order-service/internal/delivery/http/dto/wallet/request"
 	"github.com/LavaJover/shvark-order-service/internal/domain"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
-	publisher "github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -20,12 +19,12 @@ func (uc *DefaultOrderUsecase) ApproveOrder(orderID string) error {
 		return err
 	}
 
-	if order.Status != domain.StatusPending {
+	if !uc.StateMachine.CanApply(domain.OrderOpApprove, order.Status) {
 		return domain.ErrResolveDisputeFailed
 	}
 
 	if order.Type == domain.TypePayIn {
-		return uc.processPayInApprove(order)
+		return uc.processPayInApprove(order, nil)
 	} else if order.Type == domain.TypePayOut {
 		return uc.processPayOutApprove(order)
 	}
@@ -33,7 +32,9 @@ func (uc *DefaultOrderUsecase) ApproveOrder(orderID string) error {
 	return status.Errorf(codes.Internal, "failed to approve order: unknown order type")
 }
 
-func (uc *DefaultOrderUsecase) processPayInApprove(order *domain.Order) error {
+// processPayInApprove закрывает пай-ин оператором. link - платеж из очереди непривязанных,
+// привязываемый в той же транзакции, nil - без привязки
+func (uc *DefaultOrderUsecase) processPayInApprove(order *domain.Order, link *domain.UnmatchedPaymentLink) error {
 	orderID := order.ID
 	// Search for team relations to find commission users
 	var commissionUsers []walletRequest.CommissionUser
@@ -48,9 +49,10 @@ func (uc *DefaultOrderUsecase) processPayInApprove(order *domain.Order) error {
 	}
 	op := &OrderOperation{
 		OrderID:   orderID,
-		Operation: "approve",
+		Operation: domain.OrderOpApprove,
 		OldStatus: domain.StatusPending,
 		NewStatus: domain.StatusCompleted,
+		Actor:     domain.ActorOperator,
 		WalletOp: &WalletOperation{
 			Type: "release",
 			Request: walletRequest.ReleaseRequest{
@@ -62,40 +64,27 @@ func (uc *DefaultOrderUsecase) processPayInApprove(order *domain.Order) error {
 				CommissionUsers: commissionUsers,
 			},
 		},
+		PaymentLink: link,
 		CreatedAt: time.Now(),
 	}
 
-	if err := uc.ProcessOrderOperation(context.Background(), op); err != nil {
+	events, err := uc.Events.OrderEvents(orderpb.EventType_EVENT_TYPE_ORDER_COMPLETED, order, "✅Сделка закрыта")
+	if err != nil {
 		return err
 	}
-
-	go func(event publisher.OrderEvent){
-		if err := uc.Publisher.PublishOrder(event); err != nil {
-			slog.Error("failed to publish kafka OrderEvent", "stage", "approving", "error", err.Error())
-		}
-	}(publisher.OrderEvent{
-		OrderID: order.ID,
-		TraderID: order.RequisiteDetails.TraderID,
-		Status: "✅Сделка закрыта",
-		AmountFiat: order.AmountInfo.AmountFiat,
-		Currency: order.AmountInfo.Currency,
-		BankName: order.RequisiteDetails.BankName,
-		Phone: order.RequisiteDetails.Phone,
-		CardNumber: order.RequisiteDetails.CardNumber,
-		Owner: order.RequisiteDetails.Owner,
-	})
-
+	op.Events = events
 	if order.CallbackUrl != "" {
-		notifier.SendCallback(
-			order.CallbackUrl,
-			order.MerchantInfo.MerchantOrderID,
-			string(domain.StatusCompleted),
-			0, 0, order.AmountInfo.CryptoRate,
-		)
+		op.Callback = notifier.OrderCallback(order, string(domain.StatusCompleted))
+		op.Callback.ReconciliationRate = order.AmountInfo.CryptoRate
+	}
+
+	if err := uc.ProcessOrderOperation(context.Background(), op); err != nil {
+		return err
 	}
 
 	// ✅ ЗАПИСЬ МЕТРИКИ ЗАВЕРШЕННОГО ЗАКАЗА (с payment_system)
 	uc.recordOrderCompletedMetrics(order, order.RequisiteDetails.PaymentSystem)
+	uc.signalCapacityFreed()
 
 	return nil
 }
@@ -115,9 +104,10 @@ func (uc *DefaultOrderUsecase) processPayOutApprove(order *domain.Order) error {
 	// }
 	op := &OrderOperation{
 		OrderID:   orderID,
-		Operation: "approve",
+		Operation: domain.OrderOpApprove,
 		OldStatus: domain.StatusPending,
 		NewStatus: domain.StatusCompleted,
+		Actor:     domain.ActorOperator,
 		WalletOp: &WalletOperation{
 			Type: "release",
 			Request: walletRequest.ReleaseRequest{
@@ -132,33 +122,17 @@ func (uc *DefaultOrderUsecase) processPayOutApprove(order *domain.Order) error {
 		CreatedAt: time.Now(),
 	}
 
-	if err := uc.ProcessOrderOperation(context.Background(), op); err != nil {
+	events, err := uc.Events.OrderEvents(orderpb.EventType_EVENT_TYPE_ORDER_COMPLETED, order, "✅Выплата завершена")
+	if err != nil {
 		return err
 	}
-
-	go func(event publisher.OrderEvent){
-		if err := uc.Publisher.PublishOrder(event); err != nil {
-			slog.Error("failed to publish kafka OrderEvent", "stage", "approving", "error", err.Error())
-		}
-	}(publisher.OrderEvent{
-		OrderID: order.ID,
-		TraderID: order.RequisiteDetails.TraderID,
-		Status: "✅Выплата завершена",
-		AmountFiat: order.AmountInfo.AmountFiat,
-		Currency: order.AmountInfo.Currency,
-		BankName: order.RequisiteDetails.BankName,
-		Phone: order.RequisiteDetails.Phone,
-		CardNumber: order.RequisiteDetails.CardNumber,
-		Owner: order.RequisiteDetails.Owner,
-	})
-
+	op.Events = events
 	if order.CallbackUrl != "" {
-		notifier.SendCallback(
-			order.CallbackUrl,
-			order.MerchantInfo.MerchantOrderID,
-			string(domain.StatusCompleted),
-			0, 0, 0,
-		)
+		op.Callback = notifier.OrderCallback(order, string(domain.StatusCompleted))
+	}
+
+	if err := uc.ProcessOrderOperation(context.Background(), op); err != nil {
+		return err
 	}
 
 	// ✅ ЗАПИСЬ МЕТРИКИ ЗАВЕРШЕННОГО ЗАКАЗА (с payment_system)
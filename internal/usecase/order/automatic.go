@@ -4,15 +4,16 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
-	"log/slog"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/LavaJover/shvark-order-service/internal/domain"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
-	publisher "github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
 	walletRequest "github.com/LavaJover/shvark-order-service/internal/delivery/http/dto/wallet/request"
 	"github.com/google/uuid"
 )
@@ -29,8 +30,104 @@ type AutomaticPaymentRequest struct {
 	Metadata      map[string]string
 }
 
+// notificationClaimTimeout - через сколько незавершенная обработка уведомления считается прерванной,
+// и повтор уведомления обрабатывается заново
+const notificationClaimTimeout = 5 * time.Minute
+
+// ProcessAutomaticPayment обрабатывает уведомление ровно один раз: уведомление записывается по хэшу до
+// поиска сделок, повторы получают результат первой обработки с действием already_processed
 func (uc *DefaultOrderUsecase) ProcessAutomaticPayment(ctx context.Context, req *AutomaticPaymentRequest) (*domain.AutomaticPaymentResult, error) {
     startTime := time.Now()
+    paymentLog := &domain.PaymentProcessingLog{
+        ID:            uuid.New().String(),
+        PaymentHash:   uc.generatePaymentHash(req),
+        DeviceID:      req.Group,
+        Amount:        req.Amount,
+        PaymentSystem: req.PaymentSystem,
+        ProcessedAt:   startTime,
+    }
+
+    claimed, existing, err := uc.OrderRepo.ClaimPaymentNotification(ctx, paymentLog, startTime.Add(-notificationClaimTimeout))
+    if err != nil {
+        // Без записи повтор нельзя отличить от нового уведомления, поэтому устройство должно прислать его еще раз
+        return nil, fmt.Errorf("failed to record payment notification: %w", err)
+    }
+    if !claimed {
+        return uc.duplicateNotification(ctx, req, existing, startTime), nil
+    }
+
+    result, err := uc.processAutomaticPayment(ctx, req, paymentLog, startTime)
+    if err != nil {
+        if releaseErr := uc.OrderRepo.ReleasePaymentNotification(ctx, paymentLog); releaseErr != nil {
+            log.Printf("⚠️  [AUTOMATIC] Failed to release notification %s: %v", paymentLog.PaymentHash, releaseErr)
+        }
+        return nil, err
+    }
+
+    completedAt := time.Now()
+    paymentLog.CompletedAt = &completedAt
+    paymentLog.Result = result
+    for _, orderResult := range result.Results {
+        if orderResult.Success {
+            paymentLog.OrderID = orderResult.OrderID
+            paymentLog.Success = true
+            break
+        }
+        if orderResult.Error != "" {
+            paymentLog.Error = orderResult.Error
+        }
+    }
+    if err := uc.OrderRepo.CompletePaymentNotification(ctx, paymentLog); err != nil {
+        // Сделка уже закрыта, а запись с закрываемой сделкой не перехватывается:
+        // повторы получат already_processed без результата
+        log.Printf("⚠️  [AUTOMATIC] Failed to complete notification %s: %v", paymentLog.PaymentHash, err)
+    }
+    return result, nil
+}
+
+// duplicateNotification - ответ на повтор уже записанного уведомления: результат первой обработки
+// с действием already_processed. Пока первая обработка не завершилась, результатов в ответе нет
+func (uc *DefaultOrderUsecase) duplicateNotification(ctx context.Context, req *AutomaticPaymentRequest, existing *domain.PaymentProcessingLog, startTime time.Time) *domain.AutomaticPaymentResult {
+    log.Printf("🔁 [AUTOMATIC] Duplicate notification: device=%s, amount=%.2f, hash=%s", req.Group, req.Amount, existing.PaymentHash)
+
+    result := &domain.AutomaticPaymentResult{
+        Action:  domain.AutomaticActionAlreadyProcessed,
+        Message: "notification is being processed",
+    }
+    switch {
+    case existing.Result != nil:
+        result.Message = fmt.Sprintf("notification already processed at %s: %s",
+            existing.ProcessedAt.Format(time.RFC3339), existing.Result.Action)
+        result.Results = existing.Result.Results
+    case existing.OrderID != "":
+        result.Message = fmt.Sprintf("notification reached approval of order %s", existing.OrderID)
+    }
+
+    automaticLog := &domain.AutomaticLog{
+        ID:             uuid.New().String(),
+        DeviceID:       req.Group,
+        TraderID:       req.TraderID,
+        OrderID:        existing.OrderID,
+        Amount:         req.Amount,
+        PaymentSystem:  req.PaymentSystem,
+        Direction:      req.Direction,
+        Methods:        req.Methods,
+        ReceivedAt:     time.Unix(req.ReceivedAt, 0),
+        Text:           req.Text,
+        Action:         domain.AutomaticActionDuplicate,
+        ErrorMessage:   result.Message,
+        ProcessingTime: time.Since(startTime).Milliseconds(),
+        CreatedAt:      time.Now(),
+    }
+    if saveErr := uc.OrderRepo.SaveAutomaticLog(ctx, automaticLog); saveErr != nil {
+        log.Printf("⚠️  [AUTOMATIC] Failed to save log: %v", saveErr)
+    }
+    return result
+}
+
+// processAutomaticPayment ищет и закрывает сделку по уведомлению. paymentLog - запись уведомления
+// этого обработчика: перед закрытием сделки в ней отмечается сделка, чтобы повтор не закрыл другую
+func (uc *DefaultOrderUsecase) processAutomaticPayment(ctx context.Context, req *AutomaticPaymentRequest, paymentLog *domain.PaymentProcessingLog, startTime time.Time) (*domain.AutomaticPaymentResult, error) {
     
     log.Printf("🤖 [AUTOMATIC] Starting payment processing: device=%s, amount=%.2f, payment_system=%s", 
         req.Group, req.Amount, req.PaymentSystem)
@@ -48,11 +145,16 @@ func (uc *DefaultOrderUsecase) ProcessAutomaticPayment(ctx context.Context, req
         CreatedAt:     time.Now(),
 		TraderID: 	   req.TraderID,
     }
+
+    // Сверяем данные устройства с разбором текста уведомления на сервере
+    if result, mismatched := uc.checkNotificationText(ctx, req, automaticLog, startTime); mismatched {
+        return result, nil
+    }
     
     // 1. Поиск подходящих сделок
     log.Printf("🔍 [AUTOMATIC] Searching for matching orders: device=%s, amount=%.2f", req.Group, req.Amount)
     
-    orders, err := uc.findMatchingOrders(ctx, req)
+    candidates, err := uc.findMatchingOrders(ctx, req)
     if err != nil {
         log.Printf("❌ [AUTOMATIC] Error searching orders: %v", err)
         
@@ -70,9 +172,18 @@ func (uc *DefaultOrderUsecase) ProcessAutomaticPayment(ctx context.Context, req
         return nil, fmt.Errorf("failed to find matching orders: %w", err)
     }
     
-    automaticLog.OrdersFound = len(orders)
+    automaticLog.OrdersFound = len(candidates)
     
-    if len(orders) == 0 {
+    if len(candidates) == 0 {
+        // Клиент мог заплатить сразу после отмены сделки по таймауту
+        result, recovered, err := uc.tryRecoverCanceledOrder(ctx, req, paymentLog, automaticLog, startTime)
+        if err != nil {
+            return nil, err
+        }
+        if recovered {
+            return result, nil
+        }
+
         log.Printf("⚠️  [AUTOMATIC] No matching orders found: device=%s, amount=%.2f", req.Group, req.Amount)
         
         automaticLog.Action = "not_found"
@@ -82,6 +193,9 @@ func (uc *DefaultOrderUsecase) ProcessAutomaticPayment(ctx context.Context, req
         if saveErr := uc.OrderRepo.SaveAutomaticLog(ctx, automaticLog); saveErr != nil {
             log.Printf("⚠️  [AUTOMATIC] Failed to save log: %v", saveErr)
         }
+
+        // Деньги пришли, поэтому платеж остается в очереди для привязки к сделке
+        uc.saveUnmatchedPayment(req, nil)
         
         return &domain.AutomaticPaymentResult{
             Action:  "not_found",
@@ -89,14 +203,25 @@ func (uc *DefaultOrderUsecase) ProcessAutomaticPayment(ctx context.Context, req
         }, nil
     }
     
-    log.Printf("✅ [AUTOMATIC] Found %d matching order(s)", len(orders))
+    log.Printf("✅ [AUTOMATIC] Found %d matching order(s)", len(candidates))
     
     // Логируем каждый найденный заказ
-    for i, order := range orders {
-        log.Printf("   [%d] OrderID=%s, Amount=%.2f, Status=%s, TraderID=%s, BankName=%s", 
-            i+1, order.ID, order.AmountInfo.AmountFiat, order.Status, 
+    for i, candidate := range candidates {
+        order := candidate.Order
+        log.Printf("   [%d] OrderID=%s, Amount=%.2f, Diff=%.2f, Status=%s, TraderID=%s, BankName=%s", 
+            i+1, order.ID, order.AmountInfo.AmountFiat, candidate.AmountDiff, order.Status, 
             order.RequisiteDetails.TraderID, order.RequisiteDetails.BankName)
     }
+
+    // Один платеж закрывает одну сделку: ближайшую по сумме, при равенстве - самую старую
+    best, tied := domain.PickMatchCandidate(candidates)
+    if best == nil {
+        return uc.escalateAmbiguousPayment(ctx, req, automaticLog, tied, startTime), nil
+    }
+    orders := []*domain.Order{best}
+    if err := uc.OrderRepo.MarkPaymentNotificationApproving(ctx, paymentLog, best.ID); err != nil {
+        return nil, err
+    }
     
     // 2. Обработка найденных сделок
     results := make([]domain.OrderProcessingResult, 0, len(orders))
@@ -105,7 +230,7 @@ func (uc *DefaultOrderUsecase) ProcessAutomaticPayment(ctx context.Context, req
     for _, order := range orders {
         log.Printf("🔄 [AUTOMATIC] Processing order %s", order.ID)
         
-        result, err := uc.processSingleOrder(ctx, order, req)
+        result, err := uc.processSingleOrder(ctx, order, req, nil)
         if err != nil {
             log.Printf("❌ [AUTOMATIC] Failed to process order %s: %v", order.ID, err)
             automaticLog.ErrorMessage = err.Error()
@@ -121,7 +246,7 @@ func (uc *DefaultOrderUsecase) ProcessAutomaticPayment(ctx context.Context, req
                 automaticLog.OrderID = order.ID
                 automaticLog.TraderID = order.RequisiteDetails.TraderID
                 automaticLog.BankName = order.RequisiteDetails.BankName
-                automaticLog.CardNumber = order.RequisiteDetails.CardNumber
+                automaticLog.CardNumber = domain.MaskCardNumber(order.RequisiteDetails.CardNumber)
             }
         } else {
             log.Printf("⚠️  [AUTOMATIC] Order %s: %s", order.ID, result.Action)
@@ -154,32 +279,174 @@ func (uc *DefaultOrderUsecase) ProcessAutomaticPayment(ctx context.Context, req
 }
 
 
-func (uc *DefaultOrderUsecase) findMatchingOrders(ctx context.Context, req *AutomaticPaymentRequest) ([]*domain.Order, error) {
+// escalateAmbiguousPayment не закрывает ни одну сделку, если платеж одинаково подходит сделкам
+// на разных реквизитах: по уведомлению нельзя понять, какая из них оплачена. Решение за оператором
+func (uc *DefaultOrderUsecase) escalateAmbiguousPayment(ctx context.Context, req *AutomaticPaymentRequest, automaticLog *domain.AutomaticLog, tied []*domain.Order, startTime time.Time) *domain.AutomaticPaymentResult {
+    orderIDs := make([]string, len(tied))
+    for i, order := range tied {
+        orderIDs[i] = order.ID
+    }
+    message := fmt.Sprintf("payment matches %d orders on different bank details equally: %s",
+        len(tied), strings.Join(orderIDs, ", "))
+    log.Printf("⚠️  [AUTOMATIC] %s: device=%s, amount=%.2f", message, req.Group, req.Amount)
+
+    automaticLog.Action = domain.AutomaticActionAmbiguous
+    automaticLog.Success = false
+    automaticLog.ErrorMessage = message
+    automaticLog.ProcessingTime = time.Since(startTime).Milliseconds()
+    if saveErr := uc.OrderRepo.SaveAutomaticLog(ctx, automaticLog); saveErr != nil {
+        log.Printf("⚠️  [AUTOMATIC] Failed to save log: %v", saveErr)
+    }
+
+    // Оператор выберет сделку из очереди непривязанных платежей
+    uc.saveUnmatchedPayment(req, orderIDs)
+
+    return &domain.AutomaticPaymentResult{
+        Action:  domain.AutomaticActionAmbiguous,
+        Message: message,
+    }
+}
+
+// checkNotificationText разбирает текст уведомления шаблонами банка из payment_system и сверяет сумму и направление
+// с тем, что прислало устройство. При расхождении сделки не закрываются, а в лог пишется parse_mismatch.
+// Текст, который не подошел ни под один шаблон, обрабатывается по данным устройства, как раньше
+func (uc *DefaultOrderUsecase) checkNotificationText(ctx context.Context, req *AutomaticPaymentRequest, automaticLog *domain.AutomaticLog, startTime time.Time) (*domain.AutomaticPaymentResult, bool) {
+    if uc.Notifications == nil || strings.TrimSpace(req.Text) == "" {
+        return nil, false
+    }
+    parsed, err := uc.Notifications.ParseNotification(req.PaymentSystem, req.Text)
+    if err != nil {
+        log.Printf("⚠️  [AUTOMATIC] Failed to parse notification text: %v", err)
+        return nil, false
+    }
+    if parsed == nil {
+        log.Printf("⚠️  [AUTOMATIC] No notification template matched: device=%s, payment_system=%s", req.Group, req.PaymentSystem)
+        return nil, false
+    }
+
+    mismatches := parsed.Mismatches(req.Amount, req.PaymentSystem, req.Direction)
+    if len(mismatches) == 0 {
+        return nil, false
+    }
+
+    message := fmt.Sprintf("notification text does not match device data (template %s): %s",
+        parsed.TemplateID, strings.Join(mismatches, "; "))
+    log.Printf("❌ [AUTOMATIC] %s: device=%s", message, req.Group)
+
+    automaticLog.Action = domain.AutomaticActionParseMismatch
+    automaticLog.Success = false
+    automaticLog.ErrorMessage = message
+    automaticLog.ProcessingTime = time.Since(startTime).Milliseconds()
+    if saveErr := uc.OrderRepo.SaveAutomaticLog(ctx, automaticLog); saveErr != nil {
+        log.Printf("⚠️  [AUTOMATIC] Failed to save log: %v", saveErr)
+    }
+
+    return &domain.AutomaticPaymentResult{
+        Action:  domain.AutomaticActionParseMismatch,
+        Message: message,
+    }, true
+}
+
+// tryRecoverCanceledOrder восстанавливает недавно отмененную сделку, подходящую под уведомление.
+// Возвращает false, если такой сделки нет - тогда уведомление обрабатывается как not_found.
+// Ошибка - запись уведомления перехвачена другим обработчиком, сделка не восстанавливается
+func (uc *DefaultOrderUsecase) tryRecoverCanceledOrder(ctx context.Context, req *AutomaticPaymentRequest, paymentLog *domain.PaymentProcessingLog, automaticLog *domain.AutomaticLog, startTime time.Time) (*domain.AutomaticPaymentResult, bool, error) {
+    order, candidates, err := uc.findRecoverableOrder(req)
+    if err != nil {
+        log.Printf("⚠️  [AUTOMATIC] Failed to search recently canceled orders: %v", err)
+        return nil, false, nil
+    }
+    if order == nil {
+        if candidates > 1 {
+            log.Printf("⚠️  [AUTOMATIC] %d recently canceled orders match, skipping recovery: device=%s, amount=%.2f", candidates, req.Group, req.Amount)
+        }
+        return nil, false, nil
+    }
+
+    if err := uc.OrderRepo.MarkPaymentNotificationApproving(ctx, paymentLog, order.ID); err != nil {
+        return nil, false, err
+    }
+    log.Printf("♻️  [AUTOMATIC] Recovering recently canceled order %s", order.ID)
+
+    result := domain.OrderProcessingResult{OrderID: order.ID, Action: "recovered", Success: true}
+    automaticLog.OrdersFound = 1
+    automaticLog.OrderID = order.ID
+    automaticLog.TraderID = order.RequisiteDetails.TraderID
+    automaticLog.BankName = order.RequisiteDetails.BankName
+    automaticLog.CardNumber = domain.MaskCardNumber(order.RequisiteDetails.CardNumber)
+    automaticLog.Action = "recovered"
+    automaticLog.Success = true
+
+    if err := uc.recoverCanceledOrder(ctx, order, req.Amount, domain.ActorAutomatic, "late payment notification", nil); err != nil {
+        log.Printf("❌ [AUTOMATIC] Failed to recover order %s: %v", order.ID, err)
+        result = domain.OrderProcessingResult{OrderID: order.ID, Action: "failed", Success: false, Error: err.Error()}
+        automaticLog.Action = "failed"
+        automaticLog.Success = false
+        automaticLog.ErrorMessage = err.Error()
+    }
+
+    automaticLog.ProcessingTime = time.Since(startTime).Milliseconds()
+    if saveErr := uc.OrderRepo.SaveAutomaticLog(ctx, automaticLog); saveErr != nil {
+        log.Printf("⚠️  [AUTOMATIC] Failed to save log: %v", saveErr)
+    }
+
+    return &domain.AutomaticPaymentResult{
+        Action:  "processed",
+        Results: []domain.OrderProcessingResult{result},
+    }, true, nil
+}
+
+// findMatchingOrders ищет сделки устройства в статусе PENDING, подходящие под уведомление по банку,
+// сумме (с допуском AutomaticMatching) и времени создания
+func (uc *DefaultOrderUsecase) findMatchingOrders(ctx context.Context, req *AutomaticPaymentRequest) ([]domain.MatchCandidate, error) {
 	// Поиск по device_id (group) и статусу PENDING
 	orders, err := uc.OrderRepo.FindPendingOrdersByDeviceID(req.Group)
 	if err != nil {
 		return nil, err
 	}
 
-	// Фильтрация по сумме (с допуском ±1%) и банку
-	var matchingOrders []*domain.Order
+	receivedAt := time.Unix(req.ReceivedAt, 0)
+	tolerances := make(map[string]domain.AmountTolerance)
+	var candidates []domain.MatchCandidate
 	for _, order := range orders {
-		if uc.isAmountMatching(order.AmountInfo.AmountFiat, req.Amount) && order.RequisiteDetails.BankCode == req.PaymentSystem{
-			matchingOrders = append(matchingOrders, order)
+		if order.RequisiteDetails.BankCode != req.PaymentSystem {
+			continue
+		}
+		// Сделка, созданная после получения уведомления, не может быть им оплачена
+		if !uc.AutomaticMatching.InWindow(order.CreatedAt, receivedAt) {
+			continue
+		}
+		if diff, ok := uc.amountDiff(order, req.Amount, tolerances); ok {
+			candidates = append(candidates, domain.MatchCandidate{Order: order, AmountDiff: diff})
 		}
 	}
 
-	return matchingOrders, nil
+	return candidates, nil
 }
 
-func (uc *DefaultOrderUsecase) isAmountMatching(orderAmount, paymentAmount float64) bool {
-	// Допуск 1% для учета возможных расхождений
-	diff := math.Abs((orderAmount - paymentAmount))
-	allowedDiff := orderAmount * 0
-	return diff <= allowedDiff
+// amountDiff - расхождение суммы сделки с суммой платежа и признак, что оно в пределах допуска.
+// tolerances кэширует допуски трафика в рамках одного уведомления
+func (uc *DefaultOrderUsecase) amountDiff(order *domain.Order, paymentAmount float64, tolerances map[string]domain.AmountTolerance) (float64, bool) {
+	key := order.RequisiteDetails.TraderID + "|" + order.MerchantInfo.MerchantID
+	tolerance, ok := tolerances[key]
+	if !ok {
+		traffic, err := uc.TrafficUsecase.GetTrafficByTraderMerchant(order.RequisiteDetails.TraderID, order.MerchantInfo.MerchantID)
+		if err != nil {
+			// Без трафика действует допуск банка или допуск по умолчанию
+			log.Printf("⚠️  [AUTOMATIC] Failed to get traffic for order %s: %v", order.ID, err)
+			traffic = nil
+		}
+		tolerance = uc.AutomaticMatching.Tolerance(traffic, order.RequisiteDetails.BankCode)
+		tolerances[key] = tolerance
+	}
+
+	diff := math.Abs(order.AmountInfo.AmountFiat - paymentAmount)
+	return diff, diff <= tolerance.Allowed(order.AmountInfo.AmountFiat)
 }
 
-func (uc *DefaultOrderUsecase) processSingleOrder(ctx context.Context, order *domain.Order, req *AutomaticPaymentRequest) (domain.OrderProcessingResult, error) {
+// processSingleOrder закрывает сделку по уведомлению. link - платеж из очереди непривязанных,
+// привязываемый в той же транзакции, nil - без привязки
+func (uc *DefaultOrderUsecase) processSingleOrder(ctx context.Context, order *domain.Order, req *AutomaticPaymentRequest, link *domain.UnmatchedPaymentLink) (domain.OrderProcessingResult, error) {
 	// Проверяем, не обработана ли уже сделка
 	if order.Status != domain.StatusPending {
 		return domain.OrderProcessingResult{
@@ -204,9 +471,10 @@ func (uc *DefaultOrderUsecase) processSingleOrder(ctx context.Context, order *do
 	// Создаем операцию для подтверждения сделки
 	op := &OrderOperation{
 		OrderID:   order.ID,
-		Operation: "auto_approve",
+		Operation: domain.OrderOpAutoApprove,
 		OldStatus: domain.StatusPending,
 		NewStatus: domain.StatusCompleted,
+		Actor:     domain.ActorAutomatic,
 		WalletOp: &WalletOperation{
 			Type: "release",
 			Request: walletRequest.ReleaseRequest{
@@ -224,11 +492,36 @@ func (uc *DefaultOrderUsecase) processSingleOrder(ctx context.Context, order *do
 		// 	"payment_system":    req.PaymentSystem,
 		// 	"source":            "sms_parser",
 		// },
+		PaymentLink: link,
 		CreatedAt: time.Now(),
 	}
 
+	// Событие о закрытии пишется в outbox в той же транзакции
+	order.Metrics.AutomaticCompleted = true
+	events, err := uc.Events.OrderEvents(orderpb.EventType_EVENT_TYPE_ORDER_COMPLETED, order, "✅ Автоматически закрыта")
+	if err != nil {
+		return domain.OrderProcessingResult{
+			OrderID: order.ID,
+			Action:  "failed",
+			Success: false,
+			Error:   err.Error(),
+		}, err
+	}
+	op.Events = events
+	if order.CallbackUrl != "" {
+		op.Callback = notifier.OrderCallback(order, string(domain.StatusCompleted))
+	}
+
 	// Выполняем операцию
 	if err := uc.ProcessOrderOperation(ctx, op); err != nil {
+		// Сделку параллельно подтвердили или отменили - повторно не обрабатываем
+		if errors.Is(err, domain.ErrConcurrentModification) {
+			return domain.OrderProcessingResult{
+				OrderID: order.ID,
+				Action:  "already_processed",
+				Success: false,
+			}, nil
+		}
 		return domain.OrderProcessingResult{
 			OrderID: order.ID,
 			Action:  "failed",
@@ -237,17 +530,7 @@ func (uc *DefaultOrderUsecase) processSingleOrder(ctx context.Context, order *do
 		}, err
 	}
 
-	// Публикуем событие
-	go uc.publishAutomaticApprovalEvent(order, req)
-
-	if order.CallbackUrl != "" {
-		notifier.SendCallback(
-			order.CallbackUrl,
-			order.MerchantInfo.MerchantOrderID,
-			string(domain.StatusCompleted),
-			0, 0, 0,
-		)
-	}
+	uc.signalCapacityFreed()
 
 	return domain.OrderProcessingResult{
 		OrderID: order.ID,
@@ -256,42 +539,11 @@ func (uc *DefaultOrderUsecase) processSingleOrder(ctx context.Context, order *do
 	}, nil
 }
 
+// generatePaymentHash - хэш уведомления: устройство, сумма, время получения и текст без различий
+// в регистре и пробелах, чтобы повтор того же уведомления давал тот же хэш
 func (uc *DefaultOrderUsecase) generatePaymentHash(req *AutomaticPaymentRequest) string {
-	// Создаем уникальный хэш для уведомления чтобы избежать дублирующей обработки
-	data := fmt.Sprintf("%s_%.2f_%d", req.Group, req.Amount, req.ReceivedAt)
+	text := strings.ToLower(strings.Join(strings.Fields(req.Text), " "))
+	data := fmt.Sprintf("%s\x00%.2f\x00%d\x00%s", req.Group, req.Amount, req.ReceivedAt, text)
 	hash := sha256.Sum256([]byte(data))
 	return hex.EncodeToString(hash[:])
 }
-
-func (uc *DefaultOrderUsecase) ensureIdempotency(ctx context.Context, orderID string, paymentHash string) (bool, error) {
-	processed, err := uc.OrderRepo.CheckDuplicatePayment(ctx, orderID, paymentHash)
-	if err != nil {
-		return false, err
-	}
-	return processed, nil
-}
-
-func (uc *DefaultOrderUsecase) publishAutomaticApprovalEvent(order *domain.Order, req *AutomaticPaymentRequest) {
-	event := publisher.OrderEvent{
-		OrderID:     order.ID,
-		TraderID:    order.RequisiteDetails.TraderID,
-		Status:      "✅ Автоматически закрыта",
-		AmountFiat:  order.AmountInfo.AmountFiat,
-		Currency:    order.AmountInfo.Currency,
-		BankName:    order.RequisiteDetails.BankName,
-		Phone:       order.RequisiteDetails.Phone,
-		CardNumber:  order.RequisiteDetails.CardNumber,
-		Owner:       order.RequisiteDetails.Owner,
-		// Metadata: map[string]interface{}{
-		// 	"automatic":    true,
-		// 	"payment_system": req.PaymentSystem,
-		// 	"source":       "sms_parser",
-		// },
-	}
-	
-	if err := uc.Publisher.PublishOrder(event); err != nil {
-		slog.Error("failed to publish automatic approval event", 
-			"order_id", order.ID, 
-			"error", err.Error())
-	}
-}
\ No newline at end of file
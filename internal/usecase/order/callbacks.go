@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// GetCallbackDeliveries возвращает журнал доставки колбэков по сделке
+func (uc *DefaultOrderUsecase) GetCallbackDeliveries(orderID string) ([]*domain.CallbackDelivery, error) {
+	order, err := uc.OrderRepo.GetOrderByID(orderID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "order not found: %v", err)
+	}
+	deliveries, err := uc.Callbacks.GetDeliveries(order.ID, order.MerchantInfo.MerchantOrderID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get callback deliveries: %v", err)
+	}
+	return deliveries, nil
+}
+
+// ResendCallback ставит колбэк на повторную отправку (в том числе из DEAD_LETTER)
+func (uc *DefaultOrderUsecase) ResendCallback(deliveryID string) (*domain.CallbackDelivery, error) {
+	delivery, err := uc.Callbacks.Resend(deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "callback delivery not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to resend callback: %v", err)
+	}
+	return delivery, nil
+}
+
+// GetMerchantCallbackSettings возвращает настройки колбэков мерчанта (по умолчанию - неподписанный GET)
+func (uc *DefaultOrderUsecase) GetMerchantCallbackSettings(merchantID string) (*domain.MerchantCallbackSettings, error) {
+	settings, err := uc.CallbackSettingsRepo.GetMerchantCallbackSettings(merchantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &domain.MerchantCallbackSettings{MerchantID: merchantID, Format: domain.CallbackFormatGet}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get callback settings: %v", err)
+	}
+	return settings, nil
+}
+
+// SetMerchantCallbackSettings меняет формат колбэков мерчанта.
+// При rotateSecret генерируется новый секрет подписи, при removeSecret подпись отключается.
+func (uc *DefaultOrderUsecase) SetMerchantCallbackSettings(merchantID string, format domain.CallbackFormat, rotateSecret, removeSecret bool) (*domain.MerchantCallbackSettings, error) {
+	if format != domain.CallbackFormatGet && format != domain.CallbackFormatJSON {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown callback format: %s", format)
+	}
+	if rotateSecret && removeSecret {
+		return nil, status.Error(codes.InvalidArgument, "rotate_secret and remove_secret are mutually exclusive")
+	}
+
+	settings, err := uc.GetMerchantCallbackSettings(merchantID)
+	if err != nil {
+		return nil, err
+	}
+	settings.Format = format
+
+	switch {
+	case rotateSecret:
+		secret, err := generateCallbackSecret()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate secret: %v", err)
+		}
+		settings.Secret = secret
+	case removeSecret:
+		settings.Secret = ""
+	}
+
+	if err := uc.CallbackSettingsRepo.SaveMerchantCallbackSettings(settings); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save callback settings: %v", err)
+	}
+	return settings, nil
+}
+
+func generateCallbackSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -3,44 +3,50 @@ package usecase
 import (
 	"context"
 	"log"
-	"log/slog"
 	"time"
 
 	walletRequest "github.com/LavaJover/shvark-order-service/internal/delivery/http/dto/wallet/request"
 	"github.com/LavaJover/shvark-order-service/internal/domain"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
-	publisher "github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 func (uc *DefaultOrderUsecase) CancelOrder(orderID string) error {
+	return uc.cancelOrder(orderID, domain.ActorOperator, "")
+}
+
+// cancelOrder - отмена сделки с указанием инициатора и причины для истории статусов
+func (uc *DefaultOrderUsecase) cancelOrder(orderID, actor, reason string) error {
 	// Find exact order
 	order, err := uc.GetOrderByID(orderID)
 	if err != nil {
 		return err
 	}
 
-	if order.Status != domain.StatusPending && order.Status != domain.StatusDisputeCreated{
+	if !uc.StateMachine.CanApply(domain.OrderOpCancel, order.Status) {
 		return domain.ErrCancelOrder
 	}
 
 	if order.Type == domain.TypePayIn {
-		return uc.processPayInCancel(order)
+		return uc.processPayInCancel(order, actor, reason)
 	}else if order.Type == domain.TypePayOut {
-		return uc.processPayOutCancel(order)
+		return uc.processPayOutCancel(order, actor, reason)
 	}
 
 	return status.Errorf(codes.Internal, "failed to cancel order: unknown order type")
 }
 
-func (uc *DefaultOrderUsecase) processPayInCancel(order *domain.Order) error {
+func (uc *DefaultOrderUsecase) processPayInCancel(order *domain.Order, actor, reason string) error {
 	orderID := order.ID
 	op := &OrderOperation{
         OrderID:   orderID,
-        Operation: "cancel",
+        Operation: domain.OrderOpCancel,
         OldStatus: order.Status,
         NewStatus: domain.StatusCanceled,
+        Actor:     actor,
+        Reason:    reason,
         WalletOp: &WalletOperation{
             Type: "release",
             Request: walletRequest.ReleaseRequest{
@@ -54,48 +60,35 @@ func (uc *DefaultOrderUsecase) processPayInCancel(order *domain.Order) error {
 		CreatedAt: time.Now(),
 	}
 
-	if err := uc.ProcessOrderOperation(context.Background(), op); err != nil {
+	events, err := uc.Events.OrderEvents(orderpb.EventType_EVENT_TYPE_ORDER_CANCELED, order, "⛔️Отмена сделки")
+	if err != nil {
 		return err
 	}
-
-	go func(event publisher.OrderEvent){
-		if err := uc.Publisher.PublishOrder(event); err != nil {
-			slog.Error("failed to publish kafka order event", "stage", "cancelling", "error", err.Error())
-		}
-	}(publisher.OrderEvent{
-		OrderID: order.ID,
-		TraderID: order.RequisiteDetails.TraderID,
-		Status: "⛔️Отмена сделки",
-		AmountFiat: order.AmountInfo.AmountFiat,
-		Currency: order.AmountInfo.Currency,
-		BankName: order.RequisiteDetails.BankName,
-		Phone: order.RequisiteDetails.Phone,
-		CardNumber: order.RequisiteDetails.CardNumber,
-		Owner: order.RequisiteDetails.Owner,
-	})
-
+	op.Events = events
 	if order.CallbackUrl != "" {
-		notifier.SendCallback(
-			order.CallbackUrl,
-			order.MerchantInfo.MerchantOrderID,
-			string(domain.StatusCanceled),
-			0, 0, 0,
-		)
+		op.Callback = notifier.OrderCallback(order, string(domain.StatusCanceled))
+	}
+
+	if err := uc.ProcessOrderOperation(context.Background(), op); err != nil {
+		return err
 	}
 
 	// ✅ ЗАПИСЬ МЕТРИКИ ОТМЕНЕННОГО ЗАКАЗА (с payment_system)
 	uc.recordOrderCanceledMetrics(order, order.RequisiteDetails.PaymentSystem)
+	uc.signalCapacityFreed()
 	
 	return nil
 }
 
-func (uc *DefaultOrderUsecase) processPayOutCancel(order *domain.Order) error {
+func (uc *DefaultOrderUsecase) processPayOutCancel(order *domain.Order, actor, reason string) error {
 	orderID := order.ID
 	op := &OrderOperation{
         OrderID:   orderID,
-        Operation: "cancel",
+        Operation: domain.OrderOpCancel,
         OldStatus: order.Status,
         NewStatus: domain.StatusCanceled,
+        Actor:     actor,
+        Reason:    reason,
         WalletOp: &WalletOperation{
             Type: "release",
             Request: walletRequest.ReleaseRequest{
@@ -109,33 +102,17 @@ func (uc *DefaultOrderUsecase) processPayOutCancel(order *domain.Order) error {
 		CreatedAt: time.Now(),
 	}
 
-	if err := uc.ProcessOrderOperation(context.Background(), op); err != nil {
+	events, err := uc.Events.OrderEvents(orderpb.EventType_EVENT_TYPE_ORDER_CANCELED, order, "⛔️Отмена выплаты")
+	if err != nil {
 		return err
 	}
-
-	go func(event publisher.OrderEvent){
-		if err := uc.Publisher.PublishOrder(event); err != nil {
-			slog.Error("failed to publish kafka order event", "stage", "cancelling", "error", err.Error())
-		}
-	}(publisher.OrderEvent{
-		OrderID: order.ID,
-		TraderID: order.RequisiteDetails.TraderID,
-		Status: "⛔️Отмена выплаты",
-		AmountFiat: order.AmountInfo.AmountFiat,
-		Currency: order.AmountInfo.Currency,
-		BankName: order.RequisiteDetails.BankName,
-		Phone: order.RequisiteDetails.Phone,
-		CardNumber: order.RequisiteDetails.CardNumber,
-		Owner: order.RequisiteDetails.Owner,
-	})
-
+	op.Events = events
 	if order.CallbackUrl != "" {
-		notifier.SendCallback(
-			order.CallbackUrl,
-			order.MerchantInfo.MerchantOrderID,
-			string(domain.StatusCanceled),
-			0, 0, 0,
-		)
+		op.Callback = notifier.OrderCallback(order, string(domain.StatusCanceled))
+	}
+
+	if err := uc.ProcessOrderOperation(context.Background(), op); err != nil {
+		return err
 	}
 
 	// ✅ ЗАПИСЬ МЕТРИКИ ОТМЕНЕННОГО ЗАКАЗА (с payment_system)
@@ -151,7 +128,7 @@ func (uc *DefaultOrderUsecase) CancelExpiredOrders(ctx context.Context) error {
 	}
 
 	for _, order := range orders {
-		err = uc.CancelOrder(order.ID)
+		err = uc.cancelOrder(order.ID, domain.ActorScheduler, "order expired")
 		if err != nil {
 			log.Printf("Failed to cancel order %s to timeout! Error: %v\n", order.ID, err)
 		}
@@ -1,16 +1,19 @@
 package usecase
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
-	"math/rand"
 	"time"
 
 	"github.com/LavaJover/shvark-order-service/internal/domain"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
-	publisher "github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
 	"github.com/LavaJover/shvark-order-service/internal/usecase"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/cascade"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/selection"
 	bankdetaildto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/bank_detail"
 	orderdto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/order"
 	"github.com/google/uuid"
@@ -18,62 +21,90 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-func (uc *DefaultOrderUsecase) PickBestBankDetail(bankDetails []*domain.BankDetail, merchantID string) (*domain.BankDetail, error) {
+func (uc *DefaultOrderUsecase) PickBestBankDetail(bankDetails []*domain.BankDetail, traffics *TrafficSnapshot) (*domain.BankDetail, error) {
 	if len(bankDetails) == 0 {
 		return nil, fmt.Errorf("no available bank details provided to pick the best")
 	}
-	type Trader struct {
-		TraderID 		string
-		Priority 		float64
-		BankDetailIndex int
+	merchantID := traffics.MerchantID
+	if err := traffics.Load(bankDetails); err != nil {
+		return nil, err
 	}
-	var traders []*Trader
-	totalPriority := 0.0
 
+	candidates := make([]*selection.Candidate, len(bankDetails))
 	for i, bankDetail := range bankDetails {
-		traderID := bankDetail.TraderID
-		traffic, err := uc.TrafficUsecase.GetTrafficByTraderMerchant(traderID, merchantID)
+		candidates[i] = &selection.Candidate{ID: bankDetail.ID}
+		// Приоритеты трейдеров уже есть в кэше каскада
+		if uc.Cascade != nil {
+			if priority, ok := uc.Cascade.TraderPriority(bankDetail.TraderID, merchantID); ok {
+				candidates[i].Priority = priority * uc.routingMultiplier(bankDetail.ID, bankDetail.TraderID)
+				continue
+			}
+		}
+		traffic, err := traffics.Get(bankDetail.TraderID)
 		if err != nil {
 			fmt.Println("Error while picking trader: " + err.Error())
 			return nil, err
 		}
-		traders = append(traders, &Trader{
-			TraderID: traffic.TraderID,
-			Priority: traffic.TraderPriority,
-			BankDetailIndex: i,
-		})
-		totalPriority += traffic.TraderPriority
+		candidates[i].Priority = traffic.TraderPriority * uc.routingMultiplier(bankDetail.ID, bankDetail.TraderID)
 	}
 
-	// [0, totalPriority]
-	rand.Seed(time.Now().UnixNano())
-	r := rand.Float64() * totalPriority
+	index := uc.selectCandidate(merchantID, domain.TypePayIn, candidates)
+	if index < 0 {
+		return nil, fmt.Errorf("no available bank details provided to pick the best")
+	}
+	return uc.pickTraderBankDetail(bankDetails, candidates, index, traffics), nil
+}
 
-	// random shuffle of array
-	rand.Shuffle(len(traders), func(i, j int) {
-		traders[i], traders[j] = traders[j], traders[i]
-	})
+// pickTraderBankDetail применяет стратегию из трафика выбранного трейдера: она имеет приоритет над
+// стратегией мерчанта и заново выбирает среди реквизитов этого трейдера. Трейдер по-прежнему выбирается
+// стратегией мерчанта, у пай-аутов реквизитов нет, поэтому для них действует только стратегия мерчанта
+func (uc *DefaultOrderUsecase) pickTraderBankDetail(
+	bankDetails []*domain.BankDetail,
+	candidates []*selection.Candidate,
+	index int,
+	traffics *TrafficSnapshot,
+) *domain.BankDetail {
+	chosen := bankDetails[index]
+	traffic, err := traffics.Get(chosen.TraderID)
+	if err != nil || traffic.BusinessParams.SelectionStrategy == "" {
+		return chosen
+	}
 
-	// pick trader regarding weight
-	accumulated := 0.0
-	for _, trader := range traders {
-		accumulated += trader.Priority
-		if r <= accumulated {
-			return bankDetails[trader.BankDetailIndex], nil
+	traderBankDetails := make([]*domain.BankDetail, 0)
+	traderCandidates := make([]*selection.Candidate, 0)
+	for i, bankDetail := range bankDetails {
+		if bankDetail.TraderID == chosen.TraderID {
+			traderBankDetails = append(traderBankDetails, bankDetail)
+			traderCandidates = append(traderCandidates, &selection.Candidate{ID: bankDetail.ID, Priority: candidates[i].Priority})
 		}
 	}
+	if len(traderBankDetails) < 2 {
+		return chosen
+	}
 
-	return bankDetails[traders[len(traders)-1].BankDetailIndex], nil
+	// Очередь round_robin своя у каждой пары мерчант-трейдер
+	scope := string(domain.TypePayIn) + ":" + traffics.MerchantID + ":" + chosen.TraderID
+	traderIndex := uc.selectWithStrategy(uc.Strategies.Get(traffic.BusinessParams.SelectionStrategy), scope, domain.TypePayIn, traderCandidates)
+	if traderIndex < 0 {
+		return chosen
+	}
+	return traderBankDetails[traderIndex]
 }
 
-func (uc *DefaultOrderUsecase) FilterByTraffic(bankDetails []*domain.BankDetail, merchantID string) ([]*domain.BankDetail, error) {
+// FilterByTraffic оставляет реквизиты трейдеров с незаблокированным трафиком. Трафик всех трейдеров
+// загружается в снимок одним запросом
+func (uc *DefaultOrderUsecase) FilterByTraffic(bankDetails []*domain.BankDetail, traffics *TrafficSnapshot) ([]*domain.BankDetail, error) {
+	if err := traffics.Load(bankDetails); err != nil {
+		return nil, err
+	}
+	now := time.Now()
 	result := make([]*domain.BankDetail, 0)
 	for _, bankDetail := range bankDetails {
-		traffic, err := uc.TrafficUsecase.GetTrafficByTraderMerchant(bankDetail.TraderID, merchantID)
+		traffic, err := traffics.Get(bankDetail.TraderID)
 		if err != nil {
 			continue
 		}
-		if traffic.ActivityParams.AntifraudUnlocked && traffic.ActivityParams.ManuallyUnlocked && traffic.ActivityParams.MerchantUnlocked && traffic.ActivityParams.TraderUnlocked {
+		if traffic.UnavailableReason(now) == "" {
 			result = append(result, bankDetail)
 		}
 	}
@@ -180,7 +211,7 @@ func (uc *DefaultOrderUsecase) FindEligibleBankDetails(input *orderdto.CreatePay
 		log.Printf("Отсеились по статическим параметрам\n")
 	}
 	// 0) Filter by Traffic
-	bankDetails, err = uc.FilterByTraffic(bankDetails, input.MerchantParams.MerchantID)
+	bankDetails, err = uc.FilterByTraffic(bankDetails, uc.newTrafficSnapshot(input.MerchantParams.MerchantID))
 	if err != nil {
 		return nil, err
 	}
@@ -228,8 +259,9 @@ func (uc *DefaultOrderUsecase) CreatePayInOrder(createOrderInput *orderdto.Creat
     }
 
     // searching for eligible bank details
+    traffics := uc.newTrafficSnapshot(createOrderInput.MerchantID)
     t := time.Now()
-    bankDetails, err := uc.FindEligibleBankDetailsWithLock(createOrderInput)
+    bankDetails, err := uc.FindEligibleBankDetailsWithLock(createOrderInput, traffics)
     if err != nil {
         return nil, status.Error(codes.NotFound, "no eligible bank detail"+err.Error())
     }
@@ -241,29 +273,32 @@ func (uc *DefaultOrderUsecase) CreatePayInOrder(createOrderInput *orderdto.Creat
     log.Printf("Для заявки найдены доступные реквизиты!\n")
 
     if createOrderInput.AdvancedParams.CallbackUrl != "" {
-        notifier.SendCallback(
-            createOrderInput.AdvancedParams.CallbackUrl,
-            createOrderInput.MerchantOrderID,
-            string(domain.StatusCreated),
-            0, 0, 0,
-        )
+        uc.Callbacks.Enqueue(&domain.CallbackDelivery{
+            MerchantID: createOrderInput.MerchantID,
+            MerchantOrderID: createOrderInput.MerchantOrderID,
+            ClientID: createOrderInput.ClientID,
+            CallbackUrl: createOrderInput.AdvancedParams.CallbackUrl,
+            OrderStatus: string(domain.StatusCreated),
+            AmountFiat: createOrderInput.AmountFiat,
+            AmountCrypto: createOrderInput.AmountCrypto,
+            Currency: createOrderInput.Currency,
+            UsdRate: createOrderInput.CryptoRate,
+        })
     }
 
     // business logic to pick best bank detail
     t = time.Now()
-    chosenBankDetail, err := uc.PickBestBankDetail(bankDetails, createOrderInput.MerchantID)
+    chosenBankDetail, err := uc.PickBestBankDetail(bankDetails, traffics)
     if err != nil {
         return nil, status.Errorf(codes.NotFound, "failed to pick best bank detail for order")
     }
     slog.Info("PickBestBankDetail done", "elapsed", time.Since(t))
 
     // Get trader reward percent and save to order
-    t = time.Now()
-    traffic, err := uc.TrafficUsecase.GetTrafficByTraderMerchant(chosenBankDetail.TraderID, createOrderInput.MerchantID)
+    traffic, err := traffics.Get(chosenBankDetail.TraderID)
     if err != nil {
         return nil, err
     }
-    slog.Info("GetTrafficByTraderMerchant done", "elapsed", time.Since(t))
     traderReward := traffic.TraderRewardPercent
     platformFee := traffic.PlatformFee
 
@@ -304,8 +339,14 @@ func (uc *DefaultOrderUsecase) CreatePayInOrder(createOrderInput *orderdto.Creat
         Metrics: domain.Metrics{},
     }
     
+    // Событие о сделке пишется в outbox вместе с ней и уходит в Kafka после коммита
+    events, err := uc.Events.OrderEvents(orderpb.EventType_EVENT_TYPE_ORDER_CREATED, &order, "🔥Новая сделка")
+    if err != nil {
+        return nil, err
+    }
+
     t = time.Now()
-    err = uc.OrderRepo.CreateOrder(&order)
+    err = uc.OrderRepo.CreateOrder(&order, events)
     if err != nil {
         return nil, err
     }
@@ -317,31 +358,12 @@ func (uc *DefaultOrderUsecase) CreatePayInOrder(createOrderInput *orderdto.Creat
         return nil, status.Error(codes.Internal, err.Error())
     }
     slog.Info("WalletHandler.Freeze done", "elapsed", time.Since(t))
-
-    // Publish to kafka асинхронно
-    go func(event publisher.OrderEvent) {
-        if err := uc.Publisher.PublishOrder(event); err != nil {
-            slog.Error("failed to publish OrderEvent:created", "error", err.Error())
-        }
-    }(publisher.OrderEvent{
-        OrderID:   order.ID,
-        TraderID:  order.RequisiteDetails.TraderID,
-        Status:    "🔥Новая сделка",
-        AmountFiat: order.AmountInfo.AmountFiat,
-        Currency:  order.AmountInfo.Currency,
-        BankName:  order.RequisiteDetails.BankName,
-        Phone:     order.RequisiteDetails.Phone,
-        CardNumber: order.RequisiteDetails.CardNumber,
-        Owner:     order.RequisiteDetails.Owner,
-    })
+    if uc.Cascade != nil {
+        uc.Cascade.OrderAssigned(&order)
+    }
 
     if order.CallbackUrl != "" {
-        notifier.SendCallback(
-            order.CallbackUrl,
-            order.MerchantInfo.MerchantOrderID,
-            string(domain.StatusPending),
-            0, 0, 0,
-        )
+        uc.Callbacks.Enqueue(notifier.OrderCallback(&order, string(domain.StatusPending)))
     }
 
     slog.Info("CreateOrder finished", "total_elapsed", time.Since(start))
@@ -361,7 +383,7 @@ func (uc *DefaultOrderUsecase) CreatePayInOrderAtomic(createOrderInput *orderdto
         createOrderInput.CryptoRate *= 1.1
         createOrderInput.AmountCrypto /= 1.1
     }
-    slog.Info("%s\n%s\n%f\n", createOrderInput.MerchantID, createOrderInput.PaymentSystem, createOrderInput.AmountFiat)
+    slog.Info("creating pay-in order", "merchant_id", createOrderInput.MerchantID, "payment_system", createOrderInput.PaymentSystem, "amount_fiat", createOrderInput.AmountFiat)
 
     // ===== НОВОЕ: Переменные для метрик =====
 	paymentSystem := createOrderInput.PaymentSystem
@@ -397,13 +419,19 @@ func (uc *DefaultOrderUsecase) CreatePayInOrderAtomic(createOrderInput *orderdto
     bankDetailRepo := uc.BankDetailUsecase.(*usecase.DefaultBankDetailUsecase).GetBankDetailRepo()
     bankDetailRepoWithTx := bankDetailRepo.WithTx(txRepo)
 
-    // Поиск реквизитов в транзакции с блокировкой
-    bankDetails, err := uc.findEligibleBankDetailsInTx(bankDetailRepoWithTx, createOrderInput)
+    // Поиск реквизитов в транзакции с блокировкой. Трафик читается один раз на всю сделку
+    traffics := uc.newTrafficSnapshot(createOrderInput.MerchantID)
+    bankDetails, err := uc.findEligibleBankDetailsInTx(bankDetailRepoWithTx, createOrderInput, traffics)
     if err != nil {
         return nil, status.Error(codes.NotFound, "no eligible bank detail"+err.Error())
     }
     
     if len(bankDetails) == 0 {
+        // Мерчант включил ожидание - сделка ждет освобождения реквизитов в очереди
+        if waitWindow, ok := uc.waitWindowFor(createOrderInput.MerchantID); ok {
+            return uc.createWaitingOrderInTx(txRepo, createOrderInput, waitWindow, &committed)
+        }
+
         log.Printf("Реквизиты для заявки не найдены! Сохраняем с StatusFailed\n")
         
         // Создаём заявку с StatusFailed
@@ -434,7 +462,7 @@ func (uc *DefaultOrderUsecase) CreatePayInOrderAtomic(createOrderInput *orderdto
         }
         
         // Сохраняем в БД через транзакцию
-        if err := txRepo.CreateOrderInTx(&order); err != nil {
+        if err := txRepo.CreateOrderInTx(&order, nil); err != nil {
             log.Printf("Ошибка сохранения заявки: %v\n", err)
             return nil, fmt.Errorf("failed to save order: %w", err)
         }
@@ -455,21 +483,6 @@ func (uc *DefaultOrderUsecase) CreatePayInOrderAtomic(createOrderInput *orderdto
     
     log.Printf("Для заявки найдены доступные реквизиты!\n")
 
-    // Выбор лучшего реквизита
-    chosenBankDetail, err := uc.PickBestBankDetail(bankDetails, createOrderInput.MerchantID)
-    if err != nil {
-        return nil, status.Errorf(codes.NotFound, "failed to pick best bank detail for order")
-    }
-
-    // Получение трафика
-    traffic, err := uc.TrafficUsecase.GetTrafficByTraderMerchant(chosenBankDetail.TraderID, createOrderInput.MerchantID)
-    if err != nil {
-        return nil, err
-    }
-    
-    traderReward := traffic.TraderRewardPercent
-    platformFee := traffic.PlatformFee
-
     // Создаем заказ
     order := domain.Order{
         ID:     uuid.New().String(),
@@ -485,31 +498,30 @@ func (uc *DefaultOrderUsecase) CreatePayInOrderAtomic(createOrderInput *orderdto
             CryptoRate:   createOrderInput.CryptoRate,
             Currency:     createOrderInput.Currency,
         },
-        BankDetailID:  &chosenBankDetail.ID,
         Type:          domain.TypePayIn,
         Recalculated:  createOrderInput.Recalculated,
         Shuffle:       createOrderInput.Shuffle,
-        TraderReward:  traderReward,
-        PlatformFee:   platformFee,
         CallbackUrl:   createOrderInput.CallbackUrl,
-        ExpiresAt:     time.Now().Add(traffic.BusinessParams.MerchantDealsDuration),
-
-        RequisiteDetails: domain.RequisiteDetails{
-            TraderID: chosenBankDetail.TraderID,
-            CardNumber: chosenBankDetail.CardNumber,
-            Phone: chosenBankDetail.Phone,
-            Owner: chosenBankDetail.Owner,
-            PaymentSystem: chosenBankDetail.PaymentSystem,
-            BankName: chosenBankDetail.BankName,
-            BankCode: chosenBankDetail.BankCode,
-            NspkCode: chosenBankDetail.NspkCode,
-            DeviceID: chosenBankDetail.DeviceID,
-        },
         Metrics: domain.Metrics{},
     }
 
-    // Сохраняем заказ в транзакции
-    err = txRepo.CreateOrderInTx(&order)
+    // Выбор лучшего реквизита (реквизит каскада перепроверяется под блокировкой). Если трафик работает
+    // с уникальными суммами, сумма сдвигается на выбранном реквизите, а реквизит без свободной суммы уступает место следующему
+    chosenBankDetail, err := uc.pickBankDetailInTx(txRepo, bankDetailRepoWithTx, suitableBankDetailsQuery(createOrderInput), &order, bankDetails, traffics)
+    if err != nil {
+        if errors.Is(err, domain.ErrNoUniqueAmount) {
+            return nil, status.Error(codes.ResourceExhausted, err.Error())
+        }
+        return nil, status.Errorf(codes.NotFound, "failed to pick best bank detail for order")
+    }
+
+    events, err := uc.Events.OrderEvents(orderpb.EventType_EVENT_TYPE_ORDER_CREATED, &order, "🔥Новая сделка")
+    if err != nil {
+        return nil, err
+    }
+
+    // Сохраняем заказ и событие о нем в транзакции
+    err = txRepo.CreateOrderInTx(&order, events)
     if err != nil {
         return nil, err
     }
@@ -524,22 +536,21 @@ func (uc *DefaultOrderUsecase) CreatePayInOrderAtomic(createOrderInput *orderdto
     uc.recordOrderCreatedMetrics(&order, paymentSystem)
 
 
-    // Отправляем колбэк о создании
+    // Отправляем колбэк о создании (с суммой после сдвига и исходной суммой мерчанта)
     if createOrderInput.AdvancedParams.CallbackUrl != "" {
-        notifier.SendCallback(
-            createOrderInput.AdvancedParams.CallbackUrl,
-            createOrderInput.MerchantOrderID,
-            string(domain.StatusCreated),
-            0, 0, 0,
-        )
+        uc.Callbacks.Enqueue(notifier.OrderCallback(&order, string(domain.StatusCreated)))
     }
 
-    // Freeze crypto (после коммита транзакции)
-    if err := uc.WalletHandler.Freeze(chosenBankDetail.TraderID, order.ID, createOrderInput.AmountCrypto); err != nil {
-        // Если freeze не удался, отменяем заказ
+    // Freeze crypto (после коммита транзакции). При неудаче сделка переносится на следующий реквизит
+    chosenBankDetail, err = uc.freezeWithFallback(&order, chosenBankDetail, bankDetails, traffics, suitableBankDetailsQuery(createOrderInput))
+    if err != nil {
+        // Если freeze не удался ни на одном реквизите, отменяем заказ
         uc.cancelOrderDueToFreezeFailure(&order, err)
         return nil, status.Error(codes.Internal, err.Error())
     }
+    if uc.Cascade != nil {
+        uc.Cascade.OrderAssigned(&order)
+    }
 
     // Публикация в Kafka и колбэки (асинхронно)
     uc.sendOrderNotifications(&order, chosenBankDetail)
@@ -552,22 +563,282 @@ func (uc *DefaultOrderUsecase) CreatePayInOrderAtomic(createOrderInput *orderdto
     }, nil
 }
 // Вспомогательные методы для атомарного создания
-func (uc *DefaultOrderUsecase) findEligibleBankDetailsInTx(bankDetailRepo domain.BankDetailRepository, input *orderdto.CreatePayInOrderInput) ([]*domain.BankDetail, error) {
+
+// createWaitingOrderInTx сохраняет сделку в статусе WAITING. Реквизит назначит воркер очереди ожидания
+func (uc *DefaultOrderUsecase) createWaitingOrderInTx(txRepo domain.OrderRepository, createOrderInput *orderdto.CreatePayInOrderInput, waitWindow time.Duration, committed *bool) (*orderdto.OrderOutput, error) {
+    order := newWaitingOrder(createOrderInput, waitWindow)
+
+    if err := txRepo.CreateOrderInTx(&order, nil); err != nil {
+        return nil, fmt.Errorf("failed to save order: %w", err)
+    }
+    if err := txRepo.Commit(); err != nil {
+        return nil, fmt.Errorf("failed to commit: %w", err)
+    }
+    *committed = true
+
+    log.Printf("Реквизиты для заявки %s не найдены, ожидаем до %s\n", order.ID, order.ExpiresAt.Format(time.RFC3339))
+
+    if order.CallbackUrl != "" {
+        uc.Callbacks.Enqueue(&domain.CallbackDelivery{
+            OrderID: order.ID,
+            MerchantID: createOrderInput.MerchantID,
+            MerchantOrderID: createOrderInput.MerchantOrderID,
+            ClientID: createOrderInput.ClientID,
+            CallbackUrl: createOrderInput.AdvancedParams.CallbackUrl,
+            OrderStatus: string(domain.StatusCreated),
+            AmountFiat: createOrderInput.AmountFiat,
+            AmountCrypto: createOrderInput.AmountCrypto,
+            Currency: createOrderInput.Currency,
+            UsdRate: createOrderInput.CryptoRate,
+        })
+    }
+
+    return &orderdto.OrderOutput{
+        Order: order,
+    }, nil
+}
+
+// freezeWithFallback замораживает крипту у трейдера выбранного реквизита. Если заморозка не удалась
+// (например, баланс трейдера заняли параллельные сделки), сделка переносится на следующий реквизит
+// из кандидатов - всего не более FreezeMaxAttempts попыток. Возвращает реквизит, на котором удалась заморозка
+func (uc *DefaultOrderUsecase) freezeWithFallback(order *domain.Order, chosen *domain.BankDetail, candidates []*domain.BankDetail, traffics *TrafficSnapshot, query *domain.SuitablleBankDetailsQuery) (*domain.BankDetail, error) {
+    maxAttempts := uc.FreezeMaxAttempts
+    if maxAttempts < 1 {
+        maxAttempts = 1
+    }
+
+    for attempt := 1; ; attempt++ {
+        freezeErr := uc.WalletHandler.Freeze(chosen.TraderID, order.ID, order.AmountInfo.AmountCrypto)
+        if freezeErr == nil {
+            return chosen, nil
+        }
+        slog.Warn("freeze failed", "order_id", order.ID, "trader_id", chosen.TraderID, "attempt", attempt, "error", freezeErr)
+
+        // Баланс общий для всех реквизитов трейдера - исключаем трейдера целиком
+        candidates = excludeTraderBankDetails(candidates, chosen.TraderID)
+        if attempt >= maxAttempts || len(candidates) == 0 {
+            uc.recordFreezeFallbackMetrics(order, "exhausted")
+            return nil, freezeErr
+        }
+
+        reason := fmt.Sprintf("freeze failed for trader %s: %v", chosen.TraderID, freezeErr)
+        next, err := uc.reassignToNextCandidate(order, &candidates, traffics, query, reason)
+        if err != nil {
+            return nil, fmt.Errorf("failed to reassign order after freeze failure: %w", err)
+        }
+        if next == nil {
+            uc.recordFreezeFallbackMetrics(order, "exhausted")
+            return nil, freezeErr
+        }
+
+        slog.Info("order reassigned after freeze failure", "order_id", order.ID, "from_trader_id", chosen.TraderID, "to_trader_id", next.TraderID)
+        uc.recordFreezeFallbackMetrics(order, "reassigned")
+        chosen = next
+    }
+}
+
+// reassignToNextCandidate переносит сделку на лучший из оставшихся кандидатов. Кандидаты подобраны
+// до создания сделки, и за это время их лимиты могли занять другие сделки, поэтому реквизит заново
+// проверяется под блокировкой строки. Не прошедшие проверку реквизиты убираются из candidates.
+// nil без ошибки - подходящих кандидатов не осталось
+func (uc *DefaultOrderUsecase) reassignToNextCandidate(order *domain.Order, candidates *[]*domain.BankDetail, traffics *TrafficSnapshot, query *domain.SuitablleBankDetailsQuery, reason string) (*domain.BankDetail, error) {
+    for len(*candidates) > 0 {
+        next, err := uc.PickBestBankDetail(*candidates, traffics)
+        if err != nil {
+            return nil, nil
+        }
+        reassigned, err := uc.reassignOrderInTx(order, next, traffics, query, reason)
+        if err != nil {
+            return nil, err
+        }
+        if reassigned {
+            return next, nil
+        }
+        slog.Info("fallback bank detail is no longer eligible", "order_id", order.ID, "bank_detail_id", next.ID)
+        *candidates = excludeBankDetail(*candidates, next.ID)
+    }
+    return nil, nil
+}
+
+// reassignOrderInTx в одной транзакции блокирует и проверяет реквизит, подбирает уникальную сумму
+// и переносит на него сделку. false - реквизит больше не подходит, сделка не изменена
+func (uc *DefaultOrderUsecase) reassignOrderInTx(order *domain.Order, next *domain.BankDetail, traffics *TrafficSnapshot, query *domain.SuitablleBankDetailsQuery, reason string) (bool, error) {
+    traffic, err := traffics.Get(next.TraderID)
+    if err != nil {
+        return false, nil
+    }
+
+    txRepo, err := uc.OrderRepo.BeginTx()
+    if err != nil {
+        return false, fmt.Errorf("failed to begin transaction: %w", err)
+    }
+    committed := false
+    defer func() {
+        if !committed {
+            if rollbackErr := txRepo.Rollback(); rollbackErr != nil {
+                slog.Error("Failed to rollback transaction", "error", rollbackErr)
+            }
+        }
+    }()
+
+    bankDetailRepo := uc.BankDetailUsecase.(*usecase.DefaultBankDetailUsecase).GetBankDetailRepo().WithTx(txRepo)
+    locked, err := bankDetailRepo.LockBankDetailIfEligibleInTx(next.ID, query)
+    if err != nil {
+        return false, err
+    }
+    if locked == nil {
+        return false, nil
+    }
+
+    reassigned := *order
+    assignBankDetail(&reassigned, next, traffic)
+    // Сумма должна быть уникальной уже на новом реквизите
+    if err := uc.applyUniqueAmount(txRepo, &reassigned, next.ID, traffic.BusinessParams); err != nil {
+        if errors.Is(err, domain.ErrNoUniqueAmount) {
+            return false, nil
+        }
+        return false, err
+    }
+
+    // Новый трейдер получает событие о сделке на своем реквизите
+    events, err := uc.Events.OrderEvents(orderpb.EventType_EVENT_TYPE_ORDER_CREATED, &reassigned, "🔥Новая сделка")
+    if err != nil {
+        return false, err
+    }
+
+    err = txRepo.ReassignOrderRequisitesInTx(&domain.OrderStatusTransition{
+        OrderID:    order.ID,
+        FromStatus: domain.StatusPending,
+        ToStatus:   domain.StatusPending,
+        Actor:      domain.ActorSystem,
+        Operation:  domain.OrderOpReassign,
+        Reason:     reason,
+    }, &reassigned, events)
+    if err != nil {
+        return false, err
+    }
+    if err := txRepo.Commit(); err != nil {
+        return false, fmt.Errorf("failed to commit transaction: %w", err)
+    }
+    committed = true
+
+    *order = reassigned
+    return true, nil
+}
+
+// errNoEligibleBankDetail - все кандидаты каскада отсеялись при проверке под блокировкой
+var errNoEligibleBankDetail = errors.New("no eligible bank detail left after locked check")
+
+// pickBankDetailInTx выбирает лучший реквизит из кандидатов, назначает его сделке и сдвигает
+// сумму сделки на уникальную. Реквизит, на котором свободных сумм не осталось, исключается, и выбирается
+// следующий. ErrNoUniqueAmount - свободной суммы нет ни на одном кандидате.
+// Каскад подбирает кандидатов по кэшу без блокировок, поэтому выбранный им реквизит заново проверяется
+// под блокировкой строки в транзакции сделки; не прошедший проверку реквизит тоже исключается
+func (uc *DefaultOrderUsecase) pickBankDetailInTx(orderRepo domain.OrderRepository, bankDetailRepo domain.BankDetailRepository, query *domain.SuitablleBankDetailsQuery, order *domain.Order, candidates []*domain.BankDetail, traffics *TrafficSnapshot) (*domain.BankDetail, error) {
+    noUniqueAmount := false
+    for len(candidates) > 0 {
+        chosen, err := uc.PickBestBankDetail(candidates, traffics)
+        if err != nil {
+            return nil, err
+        }
+        traffic, err := traffics.Get(chosen.TraderID)
+        if err != nil {
+            return nil, err
+        }
+
+        if uc.Cascade != nil {
+            locked, err := bankDetailRepo.LockBankDetailIfEligibleInTx(chosen.ID, query)
+            if err != nil {
+                return nil, err
+            }
+            if locked == nil {
+                slog.Info("cascade bank detail is no longer eligible, trying next candidate", "order_id", order.ID, "bank_detail_id", chosen.ID)
+                candidates = excludeBankDetail(candidates, chosen.ID)
+                continue
+            }
+        }
+
+        assignBankDetail(order, chosen, traffic)
+        err = uc.applyUniqueAmount(orderRepo, order, chosen.ID, traffic.BusinessParams)
+        if err == nil {
+            return chosen, nil
+        }
+        if !errors.Is(err, domain.ErrNoUniqueAmount) {
+            return nil, err
+        }
+        slog.Info("no unique amount left on bank detail, trying next candidate", "order_id", order.ID, "bank_detail_id", chosen.ID)
+        noUniqueAmount = true
+        candidates = excludeBankDetail(candidates, chosen.ID)
+    }
+    if noUniqueAmount {
+        return nil, fmt.Errorf("%w on any of the candidates", domain.ErrNoUniqueAmount)
+    }
+    return nil, errNoEligibleBankDetail
+}
+
+// assignBankDetail назначает сделке реквизит и условия трафика его трейдера
+func assignBankDetail(order *domain.Order, bankDetail *domain.BankDetail, traffic *domain.Traffic) {
+    order.BankDetailID = &bankDetail.ID
+    order.TraderReward = traffic.TraderRewardPercent
+    order.PlatformFee = traffic.PlatformFee
+    order.ExpiresAt = time.Now().Add(traffic.BusinessParams.MerchantDealsDuration)
+    order.RequisiteDetails = domain.RequisiteDetails{
+        TraderID: bankDetail.TraderID,
+        CardNumber: bankDetail.CardNumber,
+        Phone: bankDetail.Phone,
+        Owner: bankDetail.Owner,
+        PaymentSystem: bankDetail.PaymentSystem,
+        BankName: bankDetail.BankName,
+        BankCode: bankDetail.BankCode,
+        NspkCode: bankDetail.NspkCode,
+        DeviceID: bankDetail.DeviceID,
+    }
+}
+
+func excludeTraderBankDetails(bankDetails []*domain.BankDetail, traderID string) []*domain.BankDetail {
+    result := make([]*domain.BankDetail, 0, len(bankDetails))
+    for _, bankDetail := range bankDetails {
+        if bankDetail.TraderID != traderID {
+            result = append(result, bankDetail)
+        }
+    }
+    return result
+}
+
+func excludeBankDetail(bankDetails []*domain.BankDetail, bankDetailID string) []*domain.BankDetail {
+    result := make([]*domain.BankDetail, 0, len(bankDetails))
+    for _, bankDetail := range bankDetails {
+        if bankDetail.ID != bankDetailID {
+            result = append(result, bankDetail)
+        }
+    }
+    return result
+}
+
+// suitableBankDetailsQuery - условия подбора реквизита для сделки
+func suitableBankDetailsQuery(input *orderdto.CreatePayInOrderInput) *domain.SuitablleBankDetailsQuery {
+    return &domain.SuitablleBankDetailsQuery{
+        AmountFiat: input.AmountFiat,
+        Currency: input.Currency,
+        PaymentSystem: input.PaymentSystem,
+        BankCode: input.BankInfo.BankCode,
+        NspkCode: input.BankInfo.NspkCode,
+        MerchantID: input.MerchantParams.MerchantID,
+    }
+}
+
+func (uc *DefaultOrderUsecase) findEligibleBankDetailsInTx(bankDetailRepo domain.BankDetailRepository, input *orderdto.CreatePayInOrderInput, traffics *TrafficSnapshot) ([]*domain.BankDetail, error) {
+	if uc.Cascade != nil {
+		return uc.findEligibleBankDetailsCascade(input)
+	}
+
 	t := time.Now()
 	searchDuration := 0.0
 	defer func() {
 		searchDuration = time.Since(t).Seconds()
 	}()
 	
-	bankDetails, err := bankDetailRepo.FindSuitableBankDetailsInTx(
-		&domain.SuitablleBankDetailsQuery{
-			AmountFiat: input.AmountFiat,
-			Currency: input.Currency,
-			PaymentSystem: input.PaymentSystem,
-			BankCode: input.BankInfo.BankCode,
-			NspkCode: input.BankInfo.NspkCode,
-		},
-	)
+	bankDetails, err := bankDetailRepo.FindSuitableBankDetailsInTx(suitableBankDetailsQuery(input))
 
 	if err != nil {
 		// ❌ ОШИБКА - ЗАПИСЫВАЕМ МЕТРИКУ
@@ -589,7 +860,7 @@ func (uc *DefaultOrderUsecase) findEligibleBankDetailsInTx(bankDetailRepo domain
 	uc.Metrics.RecordBankDetailsSearchDuration(input.MerchantParams.MerchantID, input.PaymentSystem, searchDuration, true)
 
 	// 0) Filter by Traffic
-	bankDetails, err = uc.FilterByTraffic(bankDetails, input.MerchantParams.MerchantID)
+	bankDetails, err = uc.FilterByTraffic(bankDetails, traffics)
 	if err != nil {
 		return nil, err
 	}
@@ -612,6 +883,32 @@ func (uc *DefaultOrderUsecase) findEligibleBankDetailsInTx(bankDetailRepo domain
 }
 
 
+// findEligibleBankDetailsCascade подбирает реквизиты in-memory каскадом: статические параметры,
+// лимиты, трафик и баланс проверяются по кэшу без запросов в БД и кошелек
+func (uc *DefaultOrderUsecase) findEligibleBankDetailsCascade(input *orderdto.CreatePayInOrderInput) ([]*domain.BankDetail, error) {
+	t := time.Now()
+	bankDetails, err := uc.Cascade.FindSuitableBankDetails(context.Background(), &cascade.MatchRequest{
+		MerchantID:    input.MerchantParams.MerchantID,
+		AmountFiat:    input.AmountFiat,
+		AmountCrypto:  input.AmountCrypto,
+		Currency:      input.Currency,
+		PaymentSystem: input.PaymentSystem,
+		BankCode:      input.BankInfo.BankCode,
+		NspkCode:      input.BankInfo.NspkCode,
+	})
+	searchDuration := time.Since(t).Seconds()
+
+	if err != nil || len(bankDetails) == 0 {
+		uc.Metrics.RecordBankDetailsNotFound(input.MerchantParams.MerchantID, input.PaymentSystem, input.Currency, input.AmountFiat)
+		uc.Metrics.RecordBankDetailsSearchDuration(input.MerchantParams.MerchantID, input.PaymentSystem, searchDuration, false)
+		return bankDetails, err
+	}
+
+	uc.Metrics.RecordBankDetailsFound(input.MerchantParams.MerchantID, input.PaymentSystem)
+	uc.Metrics.RecordBankDetailsSearchDuration(input.MerchantParams.MerchantID, input.PaymentSystem, searchDuration, true)
+	return bankDetails, nil
+}
+
 func (uc *DefaultOrderUsecase) checkIdempotencyInTx(orderRepo domain.OrderRepository, clientID string) error {
     orders, err := orderRepo.GetCreatedOrdersByClientIDInTx(clientID)
     if len(orders) != 0 || err != nil {
@@ -620,7 +917,7 @@ func (uc *DefaultOrderUsecase) checkIdempotencyInTx(orderRepo domain.OrderReposi
     return nil
 }
 
-func (uc *DefaultOrderUsecase) FindEligibleBankDetailsWithLock(input *orderdto.CreatePayInOrderInput) ([]*domain.BankDetail, error) {
+func (uc *DefaultOrderUsecase) FindEligibleBankDetailsWithLock(input *orderdto.CreatePayInOrderInput, traffics *TrafficSnapshot) ([]*domain.BankDetail, error) {
     // Используем метод с блокировкой вместо обычного
     bankDetails, err := uc.BankDetailUsecase.FindSuitableBankDetailsWithLock(
         &bankdetaildto.FindSuitableBankDetailsInput{
@@ -641,7 +938,7 @@ func (uc *DefaultOrderUsecase) FindEligibleBankDetailsWithLock(input *orderdto.C
     }
 
     // 0) Filter by Traffic
-    bankDetails, err = uc.FilterByTraffic(bankDetails, input.MerchantParams.MerchantID)
+    bankDetails, err = uc.FilterByTraffic(bankDetails, traffics)
     if err != nil {
         return nil, err
     }
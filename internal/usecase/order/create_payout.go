@@ -3,29 +3,34 @@ package usecase
 import (
 	"fmt"
 	"log/slog"
-	"math/rand"
 	"time"
 
 	"github.com/LavaJover/shvark-order-service/internal/domain"
-	"github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
-	publisher "github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/usdt"
 	orderdto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/order"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/selection"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-func (uc *DefaultOrderUsecase) pickTraderForPayOut(trafficRecords []*domain.Traffic) (*domain.Traffic, error) {
+func (uc *DefaultOrderUsecase) pickTraderForPayOut(trafficRecords []*domain.Traffic, merchantID string) (*domain.Traffic, error) {
     // Фильтруем активных трейдеров с проверкой всех условий
     activeTraders := make([]*domain.Traffic, 0, len(trafficRecords))
-    var totalPriority float64
+    candidates := make([]*selection.Candidate, 0, len(trafficRecords))
 
+    now := time.Now()
     for _, traffic := range trafficRecords {
         if !traffic.Enabled {
             continue
         }
 
+        // Трейдер вне расписания работы трафика
+        if !traffic.Schedule.IsAvailable(now) {
+            continue
+        }
+
         activity := traffic.ActivityParams
         if !activity.MerchantUnlocked || !activity.TraderUnlocked || 
            !activity.AntifraudUnlocked || !activity.ManuallyUnlocked {
@@ -37,7 +42,10 @@ func (uc *DefaultOrderUsecase) pickTraderForPayOut(trafficRecords []*domain.Traf
         }
 
         activeTraders = append(activeTraders, traffic)
-        totalPriority += traffic.TraderPriority
+        candidates = append(candidates, &selection.Candidate{
+            ID:       traffic.TraderID,
+            Priority: traffic.TraderPriority * uc.routingMultiplier("", traffic.TraderID),
+        })
     }
 
     if len(activeTraders) == 0 {
@@ -48,19 +56,8 @@ func (uc *DefaultOrderUsecase) pickTraderForPayOut(trafficRecords []*domain.Traf
         return activeTraders[0], nil
     }
 
-    // Взвешенный случайный выбор
-    randomValue := rand.Float64() * totalPriority
-    var cumulativePriority float64
-
-    for _, traffic := range activeTraders {
-        cumulativePriority += traffic.TraderPriority
-        if randomValue <= cumulativePriority {
-            return traffic, nil
-        }
-    }
-
-    // Фоллбэк на последнего трейдера (достижимо только при ошибках округления)
-    return activeTraders[len(activeTraders)-1], nil
+    // Выбор стратегией мерчанта
+    return activeTraders[uc.selectCandidate(merchantID, domain.TypePayOut, candidates)], nil
 }
 
 func (uc *DefaultOrderUsecase) CreatePayOutOrder (createOrderInput *orderdto.CreatePayOutOrderInput) (*orderdto.OrderOutput, error) {
@@ -87,19 +84,23 @@ func (uc *DefaultOrderUsecase) CreatePayOutOrder (createOrderInput *orderdto.Cre
 		return nil, status.Errorf(codes.Internal, "Failed to fetch traffic records")
 	}
 
-	chosenTraffic, err := uc.pickTraderForPayOut(trafficRecords)
+	chosenTraffic, err := uc.pickTraderForPayOut(trafficRecords, merchantID)
 	if err != nil {
 		slog.Error("failed to pick trader for pay out", "error", err.Error())
 		return nil, status.Errorf(codes.NotFound, "no available traders active")
 	}
 
     if createOrderInput.AdvancedParams.CallbackUrl != "" {
-        notifier.SendCallback(
-            createOrderInput.AdvancedParams.CallbackUrl,
-            createOrderInput.MerchantOrderID,
-            string(domain.StatusCreated),
-            0, 0, 0,
-        )
+        uc.Callbacks.Enqueue(&domain.CallbackDelivery{
+            MerchantID: createOrderInput.MerchantID,
+            MerchantOrderID: createOrderInput.MerchantOrderID,
+            ClientID: createOrderInput.ClientID,
+            CallbackUrl: createOrderInput.AdvancedParams.CallbackUrl,
+            OrderStatus: string(domain.StatusCreated),
+            AmountFiat: createOrderInput.AmoutFiat,
+            Currency: createOrderInput.PaymentDetails.Currency,
+            UsdRate: createOrderInput.UsdRate,
+        })
     }
 
     traderReward := chosenTraffic.TraderRewardPercent
@@ -142,7 +143,13 @@ func (uc *DefaultOrderUsecase) CreatePayOutOrder (createOrderInput *orderdto.Cre
         Metrics: domain.Metrics{},
     }
     
-    err = uc.OrderRepo.CreateOrder(&order)
+    // Событие о выплате пишется в outbox вместе с ней и уходит в Kafka после коммита
+    events, err := uc.Events.OrderEvents(orderpb.EventType_EVENT_TYPE_ORDER_CREATED, &order, "🔥Новая Выплата")
+    if err != nil {
+        return nil, err
+    }
+
+    err = uc.OrderRepo.CreateOrder(&order, events)
     if err != nil {
         return nil, err
     }
@@ -153,23 +160,6 @@ func (uc *DefaultOrderUsecase) CreatePayOutOrder (createOrderInput *orderdto.Cre
         return nil, status.Error(codes.Internal, err.Error())
     }
 
-    // Publish to kafka асинхронно
-    go func(event publisher.OrderEvent) {
-        if err := uc.Publisher.PublishOrder(event); err != nil {
-            slog.Error("failed to publish OrderEvent:created", "error", err.Error())
-        }
-    }(publisher.OrderEvent{
-        OrderID:   order.ID,
-        TraderID:  order.RequisiteDetails.TraderID,
-        Status:    "🔥Новая Выплата",
-        AmountFiat: order.AmountInfo.AmountFiat,
-        Currency:  order.AmountInfo.Currency,
-        BankName:  order.RequisiteDetails.BankName,
-        Phone:     order.RequisiteDetails.Phone,
-        CardNumber: order.RequisiteDetails.CardNumber,
-        Owner:     order.RequisiteDetails.Owner,
-    })
-
     return &orderdto.OrderOutput{
         Order:     order,
         BankDetail: domain.BankDetail{
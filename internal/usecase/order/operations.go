@@ -8,7 +8,6 @@ import (
 
 	"github.com/LavaJover/shvark-order-service/internal/domain"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
-	publisher "github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
 	walletRequest "github.com/LavaJover/shvark-order-service/internal/delivery/http/dto/wallet/request"
 )
 
@@ -20,15 +19,27 @@ type OrderOperation struct {
     Operation   string                    `json:"operation"` // "create", "approve", "cancel"
     OldStatus   domain.OrderStatus        `json:"old_status"`
     NewStatus   domain.OrderStatus        `json:"new_status"`
+    Actor       string                    `json:"actor"`  // кто инициировал переход (domain.Actor*)
+    Reason      string                    `json:"reason,omitempty"`
     WalletOp    *WalletOperation         `json:"wallet_op,omitempty"`
+    NewAmounts  *domain.AmountInfo       `json:"new_amounts,omitempty"` // суммы сделки, обновляемые вместе со статусом
+    Events      []*domain.OutboxEvent    `json:"-"` // события, записываемые в outbox вместе со сменой статуса
+    PaymentLink *domain.UnmatchedPaymentLink `json:"-"` // непривязанный платеж, привязываемый вместе со сменой статуса
+    Callback    *domain.CallbackDelivery `json:"-"` // колбэк мерчанту, ставится в очередь вместе со сменой статуса
     CreatedAt   time.Time                `json:"created_at"`
 }
 
 type WalletOperation struct {
-    Type    string  `json:"type"` // "freeze", "release"
+    Type    string  `json:"type"` // "freeze", "release", "freeze_release"
     Request interface{} `json:"request"`
 }
 
+// FreezeReleaseRequest - повторная заморозка и сразу выплата (восстановление отмененной сделки)
+type FreezeReleaseRequest struct {
+    Freeze  walletRequest.FreezeRequest  `json:"freeze"`
+    Release walletRequest.ReleaseRequest `json:"release"`
+}
+
 // OrderTransactionState - состояние транзакции операции
 type OrderTransactionState struct {
     OrderID         string    `json:"order_id"`
@@ -68,12 +79,38 @@ func (uc *DefaultOrderUsecase) processCriticalOperations(ctx context.Context, op
         }
     }
 
-    return uc.OrderRepo.ProcessOrderCriticalOperation(
-        op.OrderID, 
-        op.NewStatus, 
-        op.Operation, // передаем тип операции
-        walletFunc,
-    )
+    actor := op.Actor
+    if actor == "" {
+        actor = domain.ActorSystem
+    }
+
+    transition := &domain.OrderStatusTransition{
+        OrderID:    op.OrderID,
+        FromStatus: op.OldStatus,
+        ToStatus:   op.NewStatus,
+        Actor:      actor,
+        Operation:  op.Operation, // передаем тип операции
+        Reason:     op.Reason,
+        CreatedAt:  op.CreatedAt,
+        Callback:   op.Callback,
+    }
+
+    var err error
+    if op.PaymentLink != nil {
+        err = uc.OrderRepo.ProcessOrderCriticalOperationWithPaymentLink(transition, op.NewAmounts, op.PaymentLink, op.Events, walletFunc)
+    } else if op.NewAmounts != nil {
+        err = uc.OrderRepo.ProcessOrderCriticalOperationWithAmounts(transition, *op.NewAmounts, op.Events, walletFunc)
+    } else {
+        err = uc.OrderRepo.ProcessOrderCriticalOperation(transition, op.Events, walletFunc)
+    }
+    if err == nil && uc.Cascade != nil {
+        uc.Cascade.OrderStatusChanged(op.OrderID, op.NewStatus)
+    }
+    // Отмены из-за сбоя заморозки - не вина реквизита и в его здоровье не учитываются
+    if err == nil && uc.Health != nil && actor != domain.ActorSystem {
+        uc.Health.OrderClosed(op.OrderID, op.NewStatus)
+    }
+    return err
 }
 
 // processWalletOperation - обработка операций с кошельком
@@ -85,6 +122,25 @@ func (uc *DefaultOrderUsecase) processWalletOperation(walletOp *WalletOperation)
     case "release":
         req := walletOp.Request.(walletRequest.ReleaseRequest)
         return uc.WalletHandler.Release(req)
+    case "freeze_release":
+        req := walletOp.Request.(FreezeReleaseRequest)
+        if err := uc.WalletHandler.Freeze(req.Freeze.TraderID, req.Freeze.OrderID, req.Freeze.Amount); err != nil {
+            return err
+        }
+        if err := uc.WalletHandler.Release(req.Release); err != nil {
+            // Выплата не прошла - возвращаем замороженное трейдеру, как при отмене, иначе заморозка повиснет
+            if unfreezeErr := uc.WalletHandler.Release(walletRequest.ReleaseRequest{
+                TraderID:      req.Freeze.TraderID,
+                MerchantID:    req.Release.MerchantID,
+                OrderID:       req.Freeze.OrderID,
+                RewardPercent: 1,
+                PlatformFee:   1,
+            }); unfreezeErr != nil {
+                slog.Error("failed to unfreeze after release failure", "wallet_order_id", req.Freeze.OrderID, "trader_id", req.Freeze.TraderID, "release_error", err.Error(), "error", unfreezeErr.Error())
+            }
+            return err
+        }
+        return nil
     default:
         return fmt.Errorf("unknown wallet operation: %s", walletOp.Type)
     }
@@ -94,45 +150,19 @@ func (uc *DefaultOrderUsecase) cancelOrderDueToFreezeFailure(order *domain.Order
     slog.Error("Freeze failed after order creation, canceling order", "order_id", order.ID, "error", freezeErr)
     
     // Пытаемся отменить заказ
-    if err := uc.CancelOrder(order.ID); err != nil {
+    if err := uc.cancelOrder(order.ID, domain.ActorSystem, fmt.Sprintf("freeze failed: %v", freezeErr)); err != nil {
         slog.Error("Failed to cancel order after freeze failure", "order_id", order.ID, "error", err)
     }
     
     // Отправляем колбэк об ошибке
     if order.CallbackUrl != "" {
-        notifier.SendCallback(
-            order.CallbackUrl,
-            order.MerchantInfo.MerchantOrderID,
-            string(domain.StatusFailed),
-            0, 0, 0,
-        )
+        uc.Callbacks.Enqueue(notifier.OrderCallback(order, string(domain.StatusFailed)))
     }
 }
 
 func (uc *DefaultOrderUsecase) sendOrderNotifications(order *domain.Order, bankDetail *domain.BankDetail) {
-    // Publish to Kafka асинхронно
-    go func(event publisher.OrderEvent) {
-        if err := uc.Publisher.PublishOrder(event); err != nil {
-            slog.Error("failed to publish OrderEvent:created", "error", err.Error())
-        }
-    }(publisher.OrderEvent{
-        OrderID:   order.ID,
-        TraderID:  order.RequisiteDetails.TraderID,
-        Status:    "🔥Новая сделка",
-        AmountFiat: order.AmountInfo.AmountFiat,
-        Currency:  order.AmountInfo.Currency,
-        BankName:  order.RequisiteDetails.BankName,
-        Phone:     order.RequisiteDetails.Phone,
-        CardNumber: order.RequisiteDetails.CardNumber,
-        Owner:     order.RequisiteDetails.Owner,
-    })
-
+    // Событие о сделке уже записано в outbox в транзакции ее создания
     if order.CallbackUrl != "" {
-        notifier.SendCallback(
-            order.CallbackUrl,
-            order.MerchantInfo.MerchantOrderID,
-            string(domain.StatusPending),
-            0, 0, 0,
-        )
+        uc.Callbacks.Enqueue(notifier.OrderCallback(order, string(domain.StatusPending)))
     }
-}
\ No newline at end of file
+}
@@ -134,4 +134,13 @@ func (uc *DefaultOrderUsecase) recordOrderPendingRequisitesMetrics(order *domain
         order.AmountInfo.AmountFiat,
     )
 	uc.Metrics.MerchantAmountPendingRequisitesGauge.WithLabelValues(order.MerchantInfo.MerchantID, order.AmountInfo.Currency).Add(order.AmountInfo.AmountFiat)
-}
\ No newline at end of file
+}
+
+// recordFreezeFallbackMetrics - вызывается при переносе сделки на другой реквизит после неудачной заморозки
+func (uc *DefaultOrderUsecase) recordFreezeFallbackMetrics(order *domain.Order, outcome string) {
+	if uc.Metrics == nil {
+		return
+	}
+
+	uc.Metrics.RecordFreezeFallback(order.MerchantInfo.MerchantID, order.RequisiteDetails.PaymentSystem, outcome)
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/LavaJover/shvark-order-service/internal/domain"
 	orderdto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/order"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (uc *DefaultOrderUsecase) GetOrderStatistics(traderID string, dateFrom, dateTo time.Time) (*domain.OrderStatistics, error) {
@@ -122,4 +124,15 @@ func (uc *DefaultOrderUsecase) GetOrdersByTraderID(
 
 func (uc *DefaultOrderUsecase) FindExpiredOrders() ([]*domain.Order, error) {
 	return uc.OrderRepo.FindExpiredOrders()
-}
\ No newline at end of file
+}
+// GetOrderHistory возвращает историю переходов статусов сделки
+func (uc *DefaultOrderUsecase) GetOrderHistory(orderID string) ([]*domain.OrderStatusTransition, error) {
+	if _, err := uc.OrderRepo.GetOrderByID(orderID); err != nil {
+		return nil, status.Errorf(codes.NotFound, "order not found: %v", err)
+	}
+	transitions, err := uc.OrderRepo.GetOrderHistory(orderID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order history: %v", err)
+	}
+	return transitions, nil
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	walletRequest "github.com/LavaJover/shvark-order-service/internal/delivery/http/dto/wallet/request"
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoverCanceledOrder закрывает отмененную пай-ин сделку, которую клиент оплатил после отмены.
+// paidAmountFiat - фактически полученная сумма, 0 - сумма сделки
+func (uc *DefaultOrderUsecase) RecoverCanceledOrder(orderID string, paidAmountFiat float64, reason string) error {
+	order, err := uc.GetOrderByID(orderID)
+	if err != nil {
+		return err
+	}
+	return uc.recoverCanceledOrder(context.Background(), order, paidAmountFiat, domain.ActorOperator, reason, nil)
+}
+
+// recoverCanceledOrder повторно замораживает крипту трейдера (средства были разморожены при отмене),
+// сразу выплачивает ее с вознаграждением, комиссией платформы и комиссиями тимлидов
+// и переводит сделку CANCELED -> COMPLETED с фактической суммой оплаты. link - платеж из очереди
+// непривязанных, привязываемый в той же транзакции, nil - без привязки
+func (uc *DefaultOrderUsecase) recoverCanceledOrder(ctx context.Context, order *domain.Order, paidAmountFiat float64, actor, reason string, link *domain.UnmatchedPaymentLink) error {
+	if order.Type != domain.TypePayIn {
+		return status.Error(codes.FailedPrecondition, "only pay-in orders can be recovered")
+	}
+	if !uc.StateMachine.CanApply(domain.OrderOpRecover, order.Status) {
+		return fmt.Errorf("%w: cannot recover order in status %s", domain.ErrInvalidStatusTransition, order.Status)
+	}
+
+	// Пересчитываем сумму в крипте по курсу сделки, если клиент заплатил не ту сумму
+	amounts := order.AmountInfo
+	if paidAmountFiat > 0 && paidAmountFiat != order.AmountInfo.AmountFiat {
+		if order.AmountInfo.CryptoRate <= 0 {
+			return status.Error(codes.FailedPrecondition, "order has no crypto rate to recalculate amount")
+		}
+		amounts.AmountFiat = paidAmountFiat
+		amounts.AmountCrypto = paidAmountFiat / order.AmountInfo.CryptoRate
+	}
+
+	// Search for team relations to find commission users
+	var commissionUsers []walletRequest.CommissionUser
+	teamRelations, err := uc.TeamRelationsUsecase.GetRelationshipsByTraderID(order.RequisiteDetails.TraderID)
+	if err == nil {
+		for _, teamRelation := range teamRelations {
+			commissionUsers = append(commissionUsers, walletRequest.CommissionUser{
+				UserID:     teamRelation.TeamLeadID,
+				Commission: teamRelation.TeamRelationshipRapams.Commission,
+			})
+		}
+	}
+
+	// Первичная заморозка уже освобождена отменой, поэтому используем отдельный идентификатор операции
+	walletOrderID := fmt.Sprintf("%s_recovery", order.ID)
+	op := &OrderOperation{
+		OrderID:   order.ID,
+		Operation: domain.OrderOpRecover,
+		OldStatus: domain.StatusCanceled,
+		NewStatus: domain.StatusCompleted,
+		Actor:     actor,
+		Reason:    reason,
+		WalletOp: &WalletOperation{
+			Type: "freeze_release",
+			Request: FreezeReleaseRequest{
+				Freeze: walletRequest.FreezeRequest{
+					TraderID: order.RequisiteDetails.TraderID,
+					OrderID:  walletOrderID,
+					Amount:   amounts.AmountCrypto,
+				},
+				Release: walletRequest.ReleaseRequest{
+					TraderID:        order.RequisiteDetails.TraderID,
+					MerchantID:      order.MerchantInfo.MerchantID,
+					OrderID:         walletOrderID,
+					RewardPercent:   order.TraderReward,
+					PlatformFee:     order.PlatformFee,
+					CommissionUsers: commissionUsers,
+				},
+			},
+		},
+		NewAmounts:  &amounts,
+		PaymentLink: link,
+		CreatedAt:   time.Now(),
+	}
+
+	recovered := *order
+	recovered.AmountInfo = amounts
+	recovered.Metrics.AutomaticCompleted = actor == domain.ActorAutomatic
+
+	events, err := uc.Events.OrderEvents(orderpb.EventType_EVENT_TYPE_ORDER_COMPLETED, &recovered, "✅Сделка восстановлена")
+	if err != nil {
+		return err
+	}
+	op.Events = events
+	if order.CallbackUrl != "" {
+		op.Callback = notifier.OrderCallback(order, string(domain.StatusCompleted))
+		op.Callback.ReconciliationSum = amounts.AmountCrypto
+		op.Callback.ReconciliationAmount = amounts.AmountFiat
+		op.Callback.ReconciliationRate = amounts.CryptoRate
+	}
+
+	if err := uc.ProcessOrderOperation(ctx, op); err != nil {
+		return err
+	}
+
+	log.Printf("♻️ [RECOVERY] Order %s recovered by %s: paid %.2f of %.2f %s",
+		order.ID, actor, amounts.AmountFiat, order.AmountInfo.AmountFiat, order.AmountInfo.Currency)
+
+	uc.recordOrderCompletedMetrics(&recovered, order.RequisiteDetails.PaymentSystem)
+
+	return nil
+}
+
+// findRecoverableOrder ищет среди сделок устройства, отмененных по таймауту не раньше AutoRecoverWindow назад,
+// единственную, подходящую под уведомление об оплате. Сделки, отмененные трейдером, оператором или
+// из-за сбоя заморозки, автоматически не восстанавливаются. При нескольких кандидатах восстановление не выполняется
+func (uc *DefaultOrderUsecase) findRecoverableOrder(req *AutomaticPaymentRequest) (*domain.Order, int, error) {
+	if uc.AutoRecoverWindow <= 0 {
+		return nil, 0, nil
+	}
+
+	orders, err := uc.OrderRepo.FindRecentlyCanceledOrdersByDeviceID(req.Group, domain.ActorScheduler, time.Now().Add(-uc.AutoRecoverWindow))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tolerances := make(map[string]domain.AmountTolerance)
+	var matching []*domain.Order
+	for _, order := range orders {
+		if order.RequisiteDetails.BankCode != req.PaymentSystem {
+			continue
+		}
+		if _, ok := uc.amountDiff(order, req.Amount, tolerances); ok {
+			matching = append(matching, order)
+		}
+	}
+
+	if len(matching) != 1 {
+		return nil, len(matching), nil
+	}
+	return matching[0], 1, nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/routing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// routingMultiplier - множитель веса кандидата по истории сделок, 1 - скоры выключены
+func (uc *DefaultOrderUsecase) routingMultiplier(bankDetailID, traderID string) float64 {
+	if uc.RoutingScores == nil {
+		return 1
+	}
+	return uc.RoutingScores.Multiplier(bankDetailID, traderID)
+}
+
+// GetRoutingScores - рассчитанные скоры маршрутизации реквизитов и трейдеров
+func (uc *DefaultOrderUsecase) GetRoutingScores(filter domain.RoutingScoreFilter) ([]*domain.RoutingScore, int64, error) {
+	if uc.RoutingScores == nil {
+		return nil, 0, status.Error(codes.FailedPrecondition, "routing scores are disabled")
+	}
+	scores, total, err := uc.RoutingScores.GetRoutingScores(filter)
+	if err != nil {
+		return nil, 0, status.Errorf(codes.Internal, "failed to get routing scores: %v", err)
+	}
+	return scores, total, nil
+}
+
+// SetRoutingScoreOverride задает скор реквизита или трейдера вручную, override = nil возвращает рассчитанный
+func (uc *DefaultOrderUsecase) SetRoutingScoreOverride(subjectType domain.RoutingSubject, subjectID string, override *float64) (*domain.RoutingScore, error) {
+	if uc.RoutingScores == nil {
+		return nil, status.Error(codes.FailedPrecondition, "routing scores are disabled")
+	}
+	if override != nil && (*override < 0 || *override > routing.MaxOverride) {
+		return nil, status.Errorf(codes.InvalidArgument, "override must be between 0 and %.0f", routing.MaxOverride)
+	}
+
+	var traderID string
+	switch subjectType {
+	case domain.RoutingSubjectTrader:
+		traderID = subjectID
+	case domain.RoutingSubjectBankDetail:
+		bankDetail, err := uc.BankDetailUsecase.GetBankDetailByID(subjectID)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "bank detail not found: %v", err)
+		}
+		traderID = bankDetail.TraderID
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown subject type: %s", subjectType)
+	}
+
+	score, err := uc.RoutingScores.SetOverride(subjectType, subjectID, traderID, override)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set routing score override: %v", err)
+	}
+	return score, nil
+}
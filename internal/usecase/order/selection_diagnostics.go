@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/usecase"
+	orderdto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/order"
+)
+
+// ExplainBankDetailSelection повторяет подбор реквизита для пай-ин сделки без создания сделки
+// и возвращает всех кандидатов с этапом и причиной отсева. Прошедшие кандидаты получают
+// вероятность выбора по приоритету трейдера в трафике
+func (uc *DefaultOrderUsecase) ExplainBankDetailSelection(input *orderdto.CreatePayInOrderInput) ([]*domain.BankDetailSelectionCandidate, error) {
+	bankDetailRepo := uc.BankDetailUsecase.(*usecase.DefaultBankDetailUsecase).GetBankDetailRepo()
+	candidates, err := bankDetailRepo.ExplainSuitableBankDetails(&domain.SuitablleBankDetailsQuery{
+		AmountFiat:    input.AmountFiat,
+		Currency:      input.Currency,
+		PaymentSystem: input.PaymentSystem,
+		BankCode:      input.BankInfo.BankCode,
+		NspkCode:      input.BankInfo.NspkCode,
+		MerchantID:    input.MerchantParams.MerchantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain bank detail search: %w", err)
+	}
+
+	// Трафик трейдера с мерчантом
+	traffics := uc.newTrafficSnapshot(input.MerchantParams.MerchantID)
+	traderIDs := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if !candidate.Eliminated {
+			traderIDs = append(traderIDs, candidate.TraderID)
+		}
+	}
+	if err := traffics.LoadTraders(traderIDs); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	priorities := make(map[string]float64)
+	var passed []*domain.BankDetailSelectionCandidate
+	for _, candidate := range candidates {
+		if candidate.Eliminated {
+			continue
+		}
+		candidate.Stage = domain.SelectionStageTraffic
+		traffic, err := traffics.Get(candidate.TraderID)
+		if err != nil || traffic == nil {
+			candidate.Reason = "traffic_not_found"
+			candidate.Eliminated = true
+			continue
+		}
+		if reason := traffic.UnavailableReason(now); reason != "" {
+			candidate.Reason = reason
+			candidate.Eliminated = true
+			continue
+		}
+		priorities[candidate.TraderID] = traffic.TraderPriority
+		passed = append(passed, candidate)
+	}
+
+	// Доступный баланс трейдеров
+	if len(passed) > 0 {
+		traderIDs := make([]string, 0, len(priorities))
+		for traderID := range priorities {
+			traderIDs = append(traderIDs, traderID)
+		}
+		balances, err := uc.WalletHandler.GetTraderBalancesBatch(traderIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trader balances: %w", err)
+		}
+
+		remaining := passed[:0]
+		for _, candidate := range passed {
+			candidate.Stage = domain.SelectionStageBalance
+			balance, ok := balances[candidate.TraderID]
+			switch {
+			case !ok:
+				candidate.Reason = "balance_not_found"
+				candidate.Eliminated = true
+			case balance < input.AmountCrypto:
+				candidate.Reason = "insufficient_balance"
+				candidate.Eliminated = true
+			default:
+				remaining = append(remaining, candidate)
+			}
+		}
+		passed = remaining
+	}
+
+	// Вероятность выбора известна заранее только для weighted_random,
+	// для остальных стратегий она зависит от очереди и статистики сделок
+	weighted := uc.selectionStrategy(input.MerchantID).Name() == domain.StrategyWeightedRandom
+	totalPriority := 0.0
+	for _, candidate := range passed {
+		candidate.Stage = domain.SelectionStageWeighting
+		candidate.Weight = priorities[candidate.TraderID] * uc.routingMultiplier(candidate.BankDetailID, candidate.TraderID)
+		totalPriority += candidate.Weight
+	}
+	for _, candidate := range passed {
+		if weighted && totalPriority > 0 {
+			candidate.Probability = candidate.Weight / totalPriority
+		}
+		if candidate.Weight <= 0 {
+			candidate.Reason = "zero_priority"
+		}
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/selection"
+)
+
+// Окно, за которое считается доля завершенных сделок для highest_completion_rate
+const selectionStatsWindow = 24 * time.Hour
+
+// selectionStrategy - стратегия выбора из настроек мерчанта или стратегия по умолчанию
+func (uc *DefaultOrderUsecase) selectionStrategy(merchantID string) selection.Strategy {
+	if uc.MatchingSettingsRepo == nil {
+		return uc.Strategies.Get("")
+	}
+	settings, err := uc.GetMerchantMatchingSettings(merchantID)
+	if err != nil {
+		slog.Warn("failed to get merchant selection strategy, using default", "merchant_id", merchantID, "error", err)
+		return uc.Strategies.Get("")
+	}
+	return uc.Strategies.Get(settings.SelectionStrategy)
+}
+
+// selectCandidate выбирает кандидата стратегией мерчанта и возвращает его индекс в candidates
+func (uc *DefaultOrderUsecase) selectCandidate(
+	merchantID string,
+	orderType domain.OrderType,
+	candidates []*selection.Candidate,
+) int {
+	// Очереди round_robin для пай-инов и пай-аутов мерчанта независимы
+	return uc.selectWithStrategy(uc.selectionStrategy(merchantID), string(orderType)+":"+merchantID, orderType, candidates)
+}
+
+// selectWithStrategy выбирает кандидата стратегией strategy с очередью scope и возвращает его индекс
+// в candidates. Кандидаты с нулевым приоритетом участвуют, только если других нет. Статистика сделок
+// загружается, только если она нужна стратегии; без нее стратегия выбирает среди равных случайно
+func (uc *DefaultOrderUsecase) selectWithStrategy(
+	strategy selection.Strategy,
+	scope string,
+	orderType domain.OrderType,
+	candidates []*selection.Candidate,
+) int {
+	eligible := make([]*selection.Candidate, 0, len(candidates))
+	indexes := make([]int, 0, len(candidates))
+	for i, candidate := range candidates {
+		if candidate.Priority > 0 {
+			eligible = append(eligible, candidate)
+			indexes = append(indexes, i)
+		}
+	}
+	if len(eligible) == 0 {
+		eligible = candidates
+		indexes = indexes[:0]
+		for i := range candidates {
+			indexes = append(indexes, i)
+		}
+	}
+
+	if strategy.NeedsStats() && len(eligible) > 1 {
+		ids := make([]string, len(eligible))
+		for i, candidate := range eligible {
+			ids[i] = candidate.ID
+		}
+		stats, err := uc.OrderRepo.GetAssignmentStats(ids, orderType == domain.TypePayOut, orderType, time.Now().Add(-selectionStatsWindow))
+		if err != nil {
+			slog.Warn("failed to get assignment stats for selection", "strategy", strategy.Name(), "error", err)
+		}
+		for _, candidate := range eligible {
+			if stat, ok := stats[candidate.ID]; ok {
+				candidate.PendingCount = stat.PendingCount
+				candidate.LastAssignedAt = stat.LastAssignedAt
+				candidate.Completed = stat.Completed
+				candidate.Canceled = stat.Canceled
+			}
+		}
+	}
+
+	index := strategy.Select(scope, eligible)
+	if index < 0 {
+		return -1
+	}
+	return indexes[index]
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/usecase"
+)
+
+// TrafficSnapshot - трафик трейдеров с мерчантом для одной сделки. Фильтр по трафику, выбор
+// реквизита и условия сделки (вознаграждение, комиссия, время жизни) берутся из одних и тех же записей
+type TrafficSnapshot struct {
+	MerchantID string
+	traffic    usecase.TrafficUsecase
+	byTrader   map[string]*domain.Traffic
+	loaded     map[string]bool // трейдеры, которых уже искали, в том числе без трафика
+}
+
+func (uc *DefaultOrderUsecase) newTrafficSnapshot(merchantID string) *TrafficSnapshot {
+	return &TrafficSnapshot{
+		MerchantID: merchantID,
+		traffic:    uc.TrafficUsecase,
+		byTrader:   make(map[string]*domain.Traffic),
+		loaded:     make(map[string]bool),
+	}
+}
+
+// Load загружает одним запросом трафик трейдеров реквизитов, которых еще нет в снимке
+func (s *TrafficSnapshot) Load(bankDetails []*domain.BankDetail) error {
+	traderIDs := make([]string, len(bankDetails))
+	for i, bankDetail := range bankDetails {
+		traderIDs[i] = bankDetail.TraderID
+	}
+	return s.LoadTraders(traderIDs)
+}
+
+// LoadTraders загружает одним запросом трафик трейдеров, которых еще нет в снимке
+func (s *TrafficSnapshot) LoadTraders(traderIDs []string) error {
+	missing := make([]string, 0, len(traderIDs))
+	for _, traderID := range traderIDs {
+		if !s.loaded[traderID] {
+			s.loaded[traderID] = true
+			missing = append(missing, traderID)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	traffics, err := s.traffic.GetTrafficByTradersForMerchant(s.MerchantID, missing)
+	if err != nil {
+		for _, traderID := range missing {
+			delete(s.loaded, traderID)
+		}
+		return fmt.Errorf("failed to load traffic: %w", err)
+	}
+	for traderID, traffic := range traffics {
+		s.byTrader[traderID] = traffic
+	}
+	return nil
+}
+
+// Get - трафик трейдера с мерчантом. Если трейдера еще нет в снимке, он загружается
+func (s *TrafficSnapshot) Get(traderID string) (*domain.Traffic, error) {
+	if !s.loaded[traderID] {
+		if err := s.LoadTraders([]string{traderID}); err != nil {
+			return nil, err
+		}
+	}
+	traffic, ok := s.byTrader[traderID]
+	if !ok {
+		return nil, fmt.Errorf("traffic not found for trader %s and merchant %s", traderID, s.MerchantID)
+	}
+	return traffic, nil
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+// applyUniqueAmount сдвигает сумму сделки вверх шагами UniqueAmountStep, пока она не станет
+// уникальной среди активных сделок реквизита - так автоматика по сумме однозначно находит сделку.
+// Исходная сумма мерчанта сохраняется в OriginalAmountFiat, сумма в крипте пересчитывается по курсу сделки.
+// Если трафик не работает с уникальными суммами, сделке возвращается исходная сумма
+func (uc *DefaultOrderUsecase) applyUniqueAmount(orderRepo domain.OrderRepository, order *domain.Order, bankDetailID string, params domain.TrafficBusinessParams) error {
+	base := order.AmountInfo.OriginalAmountFiat
+	if base == 0 {
+		base = order.AmountInfo.AmountFiat
+		order.AmountInfo.OriginalAmountFiat = base
+	}
+
+	if params.UniqueAmountStep <= 0 {
+		setOrderAmountFiat(order, base)
+		return nil
+	}
+
+	pendingAmounts, err := orderRepo.GetPendingAmountsByBankDetailID(bankDetailID)
+	if err != nil {
+		return fmt.Errorf("failed to get pending amounts: %w", err)
+	}
+	taken := make(map[float64]bool, len(pendingAmounts))
+	for _, amount := range pendingAmounts {
+		taken[roundAmount(amount)] = true
+	}
+
+	for step := int32(0); step <= params.UniqueAmountMaxSteps; step++ {
+		amount := roundAmount(base + float64(step)*params.UniqueAmountStep)
+		if !taken[amount] {
+			setOrderAmountFiat(order, amount)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s, amount %.2f", domain.ErrNoUniqueAmount, bankDetailID, base)
+}
+
+func setOrderAmountFiat(order *domain.Order, amountFiat float64) {
+	if order.AmountInfo.AmountFiat == amountFiat {
+		return
+	}
+	order.AmountInfo.AmountFiat = amountFiat
+	if order.AmountInfo.CryptoRate > 0 {
+		order.AmountInfo.AmountCrypto = amountFiat / order.AmountInfo.CryptoRate
+	}
+}
+
+// roundAmount - суммы сравниваются с точностью до копеек
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
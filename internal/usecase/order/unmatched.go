@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// saveUnmatchedPayment сохраняет уведомление, для которого не нашлось сделки или нашлось несколько
+// одинаково подходящих (candidateOrderIDs). Списания (direction out) не сохраняются: это не оплата сделки
+func (uc *DefaultOrderUsecase) saveUnmatchedPayment(req *AutomaticPaymentRequest, candidateOrderIDs []string) {
+	if req.Direction == domain.NotificationDirectionOut {
+		return
+	}
+	payment := &domain.UnmatchedPayment{
+		ID:                uuid.New().String(),
+		PaymentHash:       uc.generatePaymentHash(req),
+		DeviceID:          req.Group,
+		TraderID:          req.TraderID,
+		Amount:            req.Amount,
+		PaymentSystem:     req.PaymentSystem,
+		Direction:         req.Direction,
+		Text:              req.Text,
+		ReceivedAt:        time.Unix(req.ReceivedAt, 0),
+		Status:            domain.UnmatchedPaymentPending,
+		CandidateOrderIDs: candidateOrderIDs,
+		CreatedAt:         time.Now(),
+	}
+	if err := uc.UnmatchedPaymentRepo.CreateUnmatchedPayment(payment); err != nil {
+		log.Printf("⚠️  [AUTOMATIC] Failed to save unmatched payment: device=%s, amount=%.2f: %v", req.Group, req.Amount, err)
+		return
+	}
+	log.Printf("📥 [AUTOMATIC] Unmatched payment saved: id=%s, device=%s, amount=%.2f, candidates=%d", payment.ID, req.Group, req.Amount, len(candidateOrderIDs))
+}
+
+func (uc *DefaultOrderUsecase) ListUnmatchedPayments(filter domain.UnmatchedPaymentFilter) ([]*domain.UnmatchedPayment, int64, error) {
+	return uc.UnmatchedPaymentRepo.ListUnmatchedPayments(filter)
+}
+
+// LinkPaymentToOrder - оператор привязывает платеж к сделке: сделка в PENDING закрывается,
+// отмененная восстанавливается с суммой платежа. Платеж должен прийти на устройство и трейдеру
+// сделки, а его сумма - отличаться от суммы сделки не больше допуска автоматики. Привязка
+// сохраняется в одной транзакции с закрытием сделки
+func (uc *DefaultOrderUsecase) LinkPaymentToOrder(paymentID, orderID, reason string) (*domain.UnmatchedPayment, error) {
+	payment, err := uc.UnmatchedPaymentRepo.GetUnmatchedPaymentByID(paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != domain.UnmatchedPaymentPending {
+		return nil, fmt.Errorf("%w to order %s", domain.ErrUnmatchedPaymentAlreadyLinked, payment.OrderID)
+	}
+	order, err := uc.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Type != domain.TypePayIn {
+		return nil, status.Error(codes.FailedPrecondition, "only pay-in orders can be linked to a payment")
+	}
+	// Деньги одного трейдера не должны закрывать сделку другого
+	if order.RequisiteDetails.DeviceID != payment.DeviceID ||
+		(payment.TraderID != "" && order.RequisiteDetails.TraderID != payment.TraderID) {
+		return nil, status.Errorf(codes.FailedPrecondition, "payment was received by device %s of trader %s, order belongs to device %s of trader %s",
+			payment.DeviceID, payment.TraderID, order.RequisiteDetails.DeviceID, order.RequisiteDetails.TraderID)
+	}
+	if _, ok := uc.amountDiff(order, payment.Amount, make(map[string]domain.AmountTolerance)); !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "payment amount %.2f is outside the tolerance of order amount %.2f",
+			payment.Amount, order.AmountInfo.AmountFiat)
+	}
+
+	link := &domain.UnmatchedPaymentLink{
+		PaymentID: payment.ID,
+		OrderID:   order.ID,
+		LinkedBy:  domain.ActorOperator,
+		LinkedAt:  time.Now(),
+	}
+	switch order.Status {
+	case domain.StatusPending:
+		err = uc.processPayInApprove(order, link)
+	case domain.StatusCanceled:
+		err = uc.recoverCanceledOrder(context.Background(), order, payment.Amount, domain.ActorOperator, reason, link)
+	default:
+		return nil, fmt.Errorf("%w: cannot link payment to order in status %s", domain.ErrInvalidStatusTransition, order.Status)
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("🔗 [UNMATCHED] Payment %s linked to order %s by operator: %s", payment.ID, order.ID, reason)
+	return uc.UnmatchedPaymentRepo.GetUnmatchedPaymentByID(paymentID)
+}
+
+// MatchUnmatchedPayments повторно сопоставляет непривязанные платежи последних DeferredMatchWindow
+// со сделками устройства: сделка могла получить реквизит устройства уже после прихода денег.
+// При нескольких одинаково подходящих сделках на разных реквизитах платеж остается оператору
+func (uc *DefaultOrderUsecase) MatchUnmatchedPayments(ctx context.Context) error {
+	if uc.DeferredMatchWindow <= 0 {
+		return nil
+	}
+	payments, err := uc.UnmatchedPaymentRepo.FindPendingUnmatchedPayments(time.Now().Add(-uc.DeferredMatchWindow))
+	if err != nil {
+		return err
+	}
+
+	for _, payment := range payments {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := uc.matchUnmatchedPayment(ctx, payment); err != nil {
+			log.Printf("⚠️  [UNMATCHED] Failed to match payment %s: %v", payment.ID, err)
+		}
+	}
+	return nil
+}
+
+func (uc *DefaultOrderUsecase) matchUnmatchedPayment(ctx context.Context, payment *domain.UnmatchedPayment) error {
+	orders, err := uc.OrderRepo.FindPendingOrdersByDeviceID(payment.DeviceID)
+	if err != nil {
+		return err
+	}
+
+	tolerances := make(map[string]domain.AmountTolerance)
+	var candidates []domain.MatchCandidate
+	for _, order := range orders {
+		if order.RequisiteDetails.BankCode != payment.PaymentSystem {
+			continue
+		}
+		// Сделка создана до платежа (в пределах окна сопоставления) или вскоре после него
+		if order.CreatedAt.After(payment.ReceivedAt.Add(uc.DeferredMatchWindow)) {
+			continue
+		}
+		if uc.AutomaticMatching.Window > 0 && order.CreatedAt.Before(payment.ReceivedAt.Add(-uc.AutomaticMatching.Window)) {
+			continue
+		}
+		if diff, ok := uc.amountDiff(order, payment.Amount, tolerances); ok {
+			candidates = append(candidates, domain.MatchCandidate{Order: order, AmountDiff: diff})
+		}
+	}
+
+	order, tied := domain.PickMatchCandidate(candidates)
+	if order == nil {
+		if len(tied) > 0 {
+			log.Printf("⚠️  [UNMATCHED] Payment %s matches %d orders equally, left for operator", payment.ID, len(tied))
+		}
+		return nil
+	}
+
+	startTime := time.Now()
+	req := &AutomaticPaymentRequest{
+		Group:         payment.DeviceID,
+		Amount:        payment.Amount,
+		PaymentSystem: payment.PaymentSystem,
+		Direction:     payment.Direction,
+		ReceivedAt:    payment.ReceivedAt.Unix(),
+		Text:          payment.Text,
+		TraderID:      payment.TraderID,
+	}
+	result, err := uc.processSingleOrder(ctx, order, req, &domain.UnmatchedPaymentLink{
+		PaymentID: payment.ID,
+		OrderID:   order.ID,
+		LinkedBy:  domain.ActorAutomatic,
+		LinkedAt:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if !result.Success {
+		return fmt.Errorf("order %s: %s", order.ID, result.Action)
+	}
+
+	log.Printf("✅ [UNMATCHED] Payment %s matched to order %s", payment.ID, order.ID)
+	automaticLog := &domain.AutomaticLog{
+		ID:             uuid.New().String(),
+		DeviceID:       payment.DeviceID,
+		TraderID:       order.RequisiteDetails.TraderID,
+		OrderID:        order.ID,
+		Amount:         payment.Amount,
+		PaymentSystem:  payment.PaymentSystem,
+		Direction:      payment.Direction,
+		ReceivedAt:     payment.ReceivedAt,
+		Text:           payment.Text,
+		Action:         "approved",
+		Success:        true,
+		OrdersFound:    len(candidates),
+		BankName:       order.RequisiteDetails.BankName,
+		CardNumber:     domain.MaskCardNumber(order.RequisiteDetails.CardNumber),
+		ProcessingTime: time.Since(startTime).Milliseconds(),
+		CreatedAt:      time.Now(),
+	}
+	if saveErr := uc.OrderRepo.SaveAutomaticLog(ctx, automaticLog); saveErr != nil {
+		log.Printf("⚠️  [UNMATCHED] Failed to save log: %v", saveErr)
+	}
+	return nil
+}
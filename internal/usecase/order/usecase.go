@@ -6,9 +6,14 @@ import (
 
 	"github.com/LavaJover/shvark-order-service/internal/delivery/http/handlers"
 	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/kafka"
 	"github.com/LavaJover/shvark-order-service/internal/infrastructure/metrics"
 	"github.com/LavaJover/shvark-order-service/internal/usecase"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/cascade"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/health"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/routing"
+	"github.com/LavaJover/shvark-order-service/internal/usecase/selection"
 	orderdto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/order"
 )
 
@@ -21,6 +26,22 @@ type OrderUsecase interface {
 	ApproveOrder(orderID string) error
 	CancelOrder(orderID string) error
     CancelExpiredOrders(context.Context) error
+    GetOrderHistory(orderID string) ([]*domain.OrderStatusTransition, error)
+    RecoverCanceledOrder(orderID string, paidAmountFiat float64, reason string) error
+    GetCallbackDeliveries(orderID string) ([]*domain.CallbackDelivery, error)
+    ResendCallback(deliveryID string) (*domain.CallbackDelivery, error)
+    GetMerchantCallbackSettings(merchantID string) (*domain.MerchantCallbackSettings, error)
+    SetMerchantCallbackSettings(merchantID string, format domain.CallbackFormat, rotateSecret, removeSecret bool) (*domain.MerchantCallbackSettings, error)
+    GetMerchantMatchingSettings(merchantID string) (*domain.MerchantMatchingSettings, error)
+    SetMerchantMatchingSettings(settings *domain.MerchantMatchingSettings) (*domain.MerchantMatchingSettings, error)
+    MatchWaitingOrders(ctx context.Context) error
+    CapacityFreed() <-chan struct{}
+    ExplainBankDetailSelection(input *orderdto.CreatePayInOrderInput) ([]*domain.BankDetailSelectionCandidate, error)
+    GetRoutingScores(filter domain.RoutingScoreFilter) ([]*domain.RoutingScore, int64, error)
+    SetRoutingScoreOverride(subjectType domain.RoutingSubject, subjectID string, override *float64) (*domain.RoutingScore, error)
+    ListUnmatchedPayments(filter domain.UnmatchedPaymentFilter) ([]*domain.UnmatchedPayment, int64, error)
+    LinkPaymentToOrder(paymentID, orderID, reason string) (*domain.UnmatchedPayment, error)
+    MatchUnmatchedPayments(ctx context.Context) error
 
 	GetOrderByID(orderID string) (*domain.Order, error)
 	GetOrderByMerchantOrderID(merchantOrderID string) (*domain.Order, error)
@@ -46,6 +67,38 @@ type DefaultOrderUsecase struct {
 	TeamRelationsUsecase usecase.TeamRelationsUsecase
 	Publisher 			*publisher.KafkaPublisher
 	Metrics				*metrics.OrderMetrics	
+	StateMachine		*domain.OrderStateMachine
+	OutboxRepo			domain.OutboxRepository
+	Callbacks			*notifier.CallbackQueue
+	CallbackSettingsRepo domain.MerchantCallbackSettingsRepository
+	Events				*publisher.EventWriter
+	// Окно автоматического восстановления отмененных сделок (0 - выключено)
+	AutoRecoverWindow	time.Duration
+	// Сколько реквизитов пробовать при неудачной заморозке во время создания сделки
+	FreezeMaxAttempts	int
+	MatchingSettingsRepo domain.MerchantMatchingSettingsRepository
+	// Окно ожидания реквизитов, если мерчант не задал свое
+	DefaultWaitWindow	time.Duration
+	// Сколько сделок из очереди ожидания обрабатывать за один проход
+	WaitlistBatchSize	int
+	// In-memory каскад подбора реквизитов (nil - подбор SQL-запросом в транзакции)
+	Cascade				*cascade.CascadeMatchEngine
+	// Стратегии выбора реквизита для пай-ина и трейдера для пай-аута
+	Strategies			*selection.Registry
+	// Скоры маршрутизации по истории сделок (nil - веса кандидатов только по приоритету трафика)
+	RoutingScores		*routing.Service
+	// Здоровье реквизитов: приостановка после серии отмен (nil - выключено)
+	Health				*health.Monitor
+	// Серверный разбор текста уведомлений банка для сверки с данными устройства (nil - выключен)
+	Notifications		usecase.NotificationTemplateUsecase
+	// Допуск суммы и окно времени при сопоставлении уведомлений об оплате со сделками
+	AutomaticMatching	domain.AutomaticMatchingPolicy
+	// Уведомления об оплате без подходящей сделки
+	UnmatchedPaymentRepo domain.UnmatchedPaymentRepository
+	// Сколько непривязанный платеж сопоставляется с новыми сделками устройства (0 - только вручную)
+	DeferredMatchWindow	time.Duration
+	// Сигнал воркеру очереди ожидания: сделка закрыта или отменена, мощности освободились
+	capacityFreed		chan struct{}
 }
 
 func NewDefaultOrderUsecase(
@@ -55,7 +108,24 @@ func NewDefaultOrderUsecase(
 	bankDetailUsecase usecase.BankDetailUsecase,
 	kafkaPublisher *publisher.KafkaPublisher,
 	teamRelationsUsecase usecase.TeamRelationsUsecase,
-	orderMetrics *metrics.OrderMetrics) *DefaultOrderUsecase {
+	orderMetrics *metrics.OrderMetrics,
+	outboxRepo domain.OutboxRepository,
+	callbacks *notifier.CallbackQueue,
+	callbackSettingsRepo domain.MerchantCallbackSettingsRepository,
+	events *publisher.EventWriter,
+	autoRecoverWindow time.Duration,
+	freezeMaxAttempts int,
+	matchingSettingsRepo domain.MerchantMatchingSettingsRepository,
+	defaultWaitWindow time.Duration,
+	waitlistBatchSize int,
+	cascadeEngine *cascade.CascadeMatchEngine,
+	strategies *selection.Registry,
+	routingScores *routing.Service,
+	healthMonitor *health.Monitor,
+	notifications usecase.NotificationTemplateUsecase,
+	automaticMatching domain.AutomaticMatchingPolicy,
+	unmatchedPaymentRepo domain.UnmatchedPaymentRepository,
+	deferredMatchWindow time.Duration) *DefaultOrderUsecase {
 
 	return &DefaultOrderUsecase{
 		OrderRepo: orderRepo,
@@ -65,5 +135,24 @@ func NewDefaultOrderUsecase(
 		Publisher: kafkaPublisher,
 		TeamRelationsUsecase: teamRelationsUsecase,
 		Metrics: orderMetrics,
+		StateMachine: domain.NewOrderStateMachine(),
+		OutboxRepo: outboxRepo,
+		Callbacks: callbacks,
+		CallbackSettingsRepo: callbackSettingsRepo,
+		Events: events,
+		AutoRecoverWindow: autoRecoverWindow,
+		FreezeMaxAttempts: freezeMaxAttempts,
+		MatchingSettingsRepo: matchingSettingsRepo,
+		DefaultWaitWindow: defaultWaitWindow,
+		WaitlistBatchSize: waitlistBatchSize,
+		Cascade: cascadeEngine,
+		Strategies: strategies,
+		RoutingScores: routingScores,
+		Health: healthMonitor,
+		Notifications: notifications,
+		AutomaticMatching: automaticMatching,
+		UnmatchedPaymentRepo: unmatchedPaymentRepo,
+		DeferredMatchWindow: deferredMatchWindow,
+		capacityFreed: make(chan struct{}, 1),
 	}
 }
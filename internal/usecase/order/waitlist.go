@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+	"github.com/LavaJover/shvark-order-service/internal/infrastructure/bitwire/notifier"
+	"github.com/LavaJover/shvark-order-service/internal/usecase"
+	orderdto "github.com/LavaJover/shvark-order-service/internal/usecase/dto/order"
+	orderpb "github.com/LavaJover/shvark-order-service/proto/gen/order"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// GetMerchantMatchingSettings возвращает настройки подбора реквизитов мерчанта (по умолчанию - без ожидания)
+func (uc *DefaultOrderUsecase) GetMerchantMatchingSettings(merchantID string) (*domain.MerchantMatchingSettings, error) {
+	settings, err := uc.MatchingSettingsRepo.GetMerchantMatchingSettings(merchantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &domain.MerchantMatchingSettings{MerchantID: merchantID}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get matching settings: %v", err)
+	}
+	return settings, nil
+}
+
+// SetMerchantMatchingSettings включает или выключает ожидание реквизитов и задает стратегию выбора для сделок мерчанта
+func (uc *DefaultOrderUsecase) SetMerchantMatchingSettings(settings *domain.MerchantMatchingSettings) (*domain.MerchantMatchingSettings, error) {
+	if settings.WaitWindow < 0 {
+		return nil, status.Error(codes.InvalidArgument, "wait window must not be negative")
+	}
+	if !settings.SelectionStrategy.Valid() {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown selection strategy: %s", settings.SelectionStrategy)
+	}
+
+	current, err := uc.GetMerchantMatchingSettings(settings.MerchantID)
+	if err != nil {
+		return nil, err
+	}
+	current.WaitForRequisites = settings.WaitForRequisites
+	current.WaitWindow = settings.WaitWindow
+	current.SelectionStrategy = settings.SelectionStrategy
+
+	if err := uc.MatchingSettingsRepo.SaveMerchantMatchingSettings(current); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save matching settings: %v", err)
+	}
+	return current, nil
+}
+
+// CapacityFreed - сигнал о том, что сделка закрыта или отменена и реквизиты могли освободиться
+func (uc *DefaultOrderUsecase) CapacityFreed() <-chan struct{} {
+	return uc.capacityFreed
+}
+
+// signalCapacityFreed будит воркер очереди ожидания, не блокируясь, если сигнал уже ждет обработки
+func (uc *DefaultOrderUsecase) signalCapacityFreed() {
+	select {
+	case uc.capacityFreed <- struct{}{}:
+	default:
+	}
+}
+
+// waitWindowFor возвращает окно ожидания реквизитов для мерчанта, false - мерчант ожидание не включал
+func (uc *DefaultOrderUsecase) waitWindowFor(merchantID string) (time.Duration, bool) {
+	if uc.MatchingSettingsRepo == nil {
+		return 0, false
+	}
+	settings, err := uc.GetMerchantMatchingSettings(merchantID)
+	if err != nil {
+		slog.Error("failed to get matching settings, order will not wait", "merchant_id", merchantID, "error", err)
+		return 0, false
+	}
+	if !settings.WaitForRequisites {
+		return 0, false
+	}
+	if settings.WaitWindow > 0 {
+		return settings.WaitWindow, true
+	}
+	return uc.DefaultWaitWindow, uc.DefaultWaitWindow > 0
+}
+
+// newWaitingOrder - сделка без реквизита в очереди ожидания. Параметры поиска реквизита
+// хранятся в RequisiteDetails, ExpiresAt - конец окна ожидания
+func newWaitingOrder(input *orderdto.CreatePayInOrderInput, waitWindow time.Duration) domain.Order {
+	return domain.Order{
+		ID:     uuid.New().String(),
+		Status: domain.StatusWaiting,
+		MerchantInfo: domain.MerchantInfo{
+			MerchantID:      input.MerchantID,
+			MerchantOrderID: input.MerchantOrderID,
+			ClientID:        input.ClientID,
+		},
+		AmountInfo: domain.AmountInfo{
+			AmountFiat:   input.AmountFiat,
+			AmountCrypto: input.AmountCrypto,
+			CryptoRate:   input.CryptoRate,
+			Currency:     input.Currency,
+		},
+		Type:         domain.TypePayIn,
+		Recalculated: input.Recalculated,
+		Shuffle:      input.Shuffle,
+		CallbackUrl:  input.CallbackUrl,
+		RequisiteDetails: domain.RequisiteDetails{
+			PaymentSystem: input.PaymentSystem,
+			BankCode:      input.BankInfo.BankCode,
+			NspkCode:      input.BankInfo.NspkCode,
+		},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(waitWindow),
+	}
+}
+
+// waitingOrderSearchInput восстанавливает параметры поиска реквизита по сделке из очереди ожидания
+func waitingOrderSearchInput(order *domain.Order) *orderdto.CreatePayInOrderInput {
+	return &orderdto.CreatePayInOrderInput{
+		MerchantParams: orderdto.MerchantParams{
+			MerchantID:      order.MerchantInfo.MerchantID,
+			MerchantOrderID: order.MerchantInfo.MerchantOrderID,
+			ClientID:        order.MerchantInfo.ClientID,
+		},
+		PaymentSearchParams: orderdto.PaymentSearchParams{
+			CryptoRate:    order.AmountInfo.CryptoRate,
+			AmountFiat:    order.AmountInfo.AmountFiat,
+			AmountCrypto:  order.AmountInfo.AmountCrypto,
+			Currency:      order.AmountInfo.Currency,
+			PaymentSystem: order.RequisiteDetails.PaymentSystem,
+			BankInfo: orderdto.BankInfo{
+				BankCode: order.RequisiteDetails.BankCode,
+				NspkCode: order.RequisiteDetails.NspkCode,
+			},
+		},
+		AdvancedParams: orderdto.AdvancedParams{
+			Shuffle:      order.Shuffle,
+			CallbackUrl:  order.CallbackUrl,
+			Recalculated: order.Recalculated,
+		},
+		Type: string(domain.TypePayIn),
+	}
+}
+
+// MatchWaitingOrders - один проход по очереди ожидания: сделки с истекшим окном переводятся в FAILED,
+// остальным повторно ищется реквизит. Старые сделки обрабатываются первыми
+func (uc *DefaultOrderUsecase) MatchWaitingOrders(ctx context.Context) error {
+	orders, err := uc.OrderRepo.FindWaitingOrders(uc.WaitlistBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if time.Now().After(order.ExpiresAt) {
+			if err := uc.expireWaitingOrder(order); err != nil {
+				slog.Error("failed to expire waiting order", "order_id", order.ID, "error", err)
+			}
+			continue
+		}
+
+		if err := uc.assignWaitingOrder(order); err != nil {
+			slog.Error("failed to assign waiting order", "order_id", order.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// expireWaitingOrder - реквизит не нашелся за окно ожидания, сделка переводится в FAILED
+func (uc *DefaultOrderUsecase) expireWaitingOrder(order *domain.Order) error {
+	op := &OrderOperation{
+		OrderID:   order.ID,
+		Operation: domain.OrderOpWaitExpire,
+		OldStatus: domain.StatusWaiting,
+		NewStatus: domain.StatusFailed,
+		Actor:     domain.ActorScheduler,
+		Reason:    "no bank details within wait window",
+		CreatedAt: time.Now(),
+	}
+	if err := uc.ProcessOrderOperation(context.Background(), op); err != nil {
+		// Сделку параллельно назначили - она больше не в очереди
+		if errors.Is(err, domain.ErrConcurrentModification) {
+			return nil
+		}
+		return err
+	}
+
+	slog.Info("waiting order expired without bank details", "order_id", order.ID, "merchant_id", order.MerchantInfo.MerchantID)
+
+	if order.CallbackUrl != "" {
+		uc.Callbacks.Enqueue(notifier.OrderCallback(order, string(domain.StatusFailed)))
+	}
+
+	uc.recordOrderPendingRequisitesMetrics(order)
+
+	return nil
+}
+
+// assignWaitingOrder повторяет поиск реквизита для сделки из очереди ожидания. Если реквизит нашелся,
+// сделка переходит WAITING -> PENDING, средства трейдера замораживаются, мерчант получает
+// колбэк PENDING, в Kafka уходит событие о создании сделки
+func (uc *DefaultOrderUsecase) assignWaitingOrder(order *domain.Order) error {
+	input := waitingOrderSearchInput(order)
+
+	txRepo, err := uc.OrderRepo.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	var committed bool
+	defer func() {
+		if !committed {
+			if rollbackErr := txRepo.Rollback(); rollbackErr != nil {
+				slog.Error("Failed to rollback transaction", "error", rollbackErr)
+			}
+		}
+	}()
+
+	bankDetailRepo := uc.BankDetailUsecase.(*usecase.DefaultBankDetailUsecase).GetBankDetailRepo()
+	traffics := uc.newTrafficSnapshot(input.MerchantID)
+	bankDetails, err := uc.findEligibleBankDetailsInTx(bankDetailRepo.WithTx(txRepo), input, traffics)
+	if err != nil {
+		return err
+	}
+	if len(bankDetails) == 0 {
+		return nil
+	}
+
+	assigned := *order
+	assigned.Status = domain.StatusPending
+	chosenBankDetail, err := uc.pickBankDetailInTx(txRepo, bankDetailRepo.WithTx(txRepo), suitableBankDetailsQuery(input), &assigned, bankDetails, traffics)
+	if err != nil {
+		// Подходящего реквизита со свободной суммой нет - сделка ждет дальше
+		if errors.Is(err, domain.ErrNoUniqueAmount) || errors.Is(err, errNoEligibleBankDetail) {
+			return nil
+		}
+		return err
+	}
+
+	events, err := uc.Events.OrderEvents(orderpb.EventType_EVENT_TYPE_ORDER_CREATED, &assigned, "🔥Новая сделка")
+	if err != nil {
+		return err
+	}
+
+	err = txRepo.ReassignOrderRequisitesInTx(&domain.OrderStatusTransition{
+		OrderID:    order.ID,
+		FromStatus: domain.StatusWaiting,
+		ToStatus:   domain.StatusPending,
+		Actor:      domain.ActorScheduler,
+		Operation:  domain.OrderOpAssign,
+		Reason:     fmt.Sprintf("waited %s for bank details", time.Since(order.CreatedAt).Round(time.Second)),
+	}, &assigned, events)
+	if err != nil {
+		if errors.Is(err, domain.ErrConcurrentModification) {
+			return nil
+		}
+		return err
+	}
+
+	if err := txRepo.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+
+	slog.Info("waiting order assigned", "order_id", order.ID, "trader_id", chosenBankDetail.TraderID, "waited", time.Since(order.CreatedAt))
+
+	uc.recordOrderCreatedMetrics(&assigned, input.PaymentSystem)
+
+	chosenBankDetail, err = uc.freezeWithFallback(&assigned, chosenBankDetail, bankDetails, traffics, suitableBankDetailsQuery(input))
+	if err != nil {
+		uc.cancelOrderDueToFreezeFailure(&assigned, err)
+		return err
+	}
+	if uc.Cascade != nil {
+		uc.Cascade.OrderAssigned(&assigned)
+	}
+
+	uc.sendOrderNotifications(&assigned, chosenBankDetail)
+
+	return nil
+}
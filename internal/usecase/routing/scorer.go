@@ -0,0 +1,106 @@
+package routing
+
+import (
+	"sort"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+// Границы скора: кандидат не исключается из выбора полностью и не забирает весь поток
+const (
+	minScore = 0.1
+	maxScore = 3.0
+)
+
+// MaxOverride - предел переопределения скора админом. 0 - кандидат не получает сделок, пока есть другие
+const MaxOverride = 10.0
+
+// CalculateScores рассчитывает скоры по статистике сделок. Скор - произведение множителей
+// относительно среднего по платформе:
+//   - доля завершенных сделок / средняя доля по платформе;
+//   - медиана времени завершения по платформе / медиана кандидата, в пределах [0.5, 1.5];
+//   - 1 - доля сделок со спорами;
+//   - 0.9 + 0.2 * доля сделок, завершенных автоматикой.
+//
+// Кандидатам, закрывшим меньше minOrders сделок, ставится нейтральный скор 1, статистика сохраняется
+func CalculateScores(subjectType domain.RoutingSubject, stats []*domain.RoutingStats, minOrders int, calculatedAt time.Time) []*domain.RoutingScore {
+	var completed, closed int
+	medians := make([]float64, 0, len(stats))
+	for _, stat := range stats {
+		completed += stat.Completed
+		closed += stat.Completed + stat.Canceled
+		if stat.MedianCompleteSeconds > 0 {
+			medians = append(medians, stat.MedianCompleteSeconds)
+		}
+	}
+	platformCompletion := 0.0
+	if closed > 0 {
+		platformCompletion = float64(completed) / float64(closed)
+	}
+	platformMedian := median(medians)
+
+	scores := make([]*domain.RoutingScore, 0, len(stats))
+	for _, stat := range stats {
+		orders := stat.Completed + stat.Canceled
+		score := &domain.RoutingScore{
+			SubjectType:        subjectType,
+			SubjectID:          stat.SubjectID,
+			TraderID:           stat.TraderID,
+			Score:              1,
+			Orders:             orders,
+			MedianCompleteTime: time.Duration(stat.MedianCompleteSeconds * float64(time.Second)),
+			CalculatedAt:       calculatedAt,
+		}
+		if orders > 0 {
+			score.CompletionRate = float64(stat.Completed) / float64(orders)
+			score.DisputeRate = float64(stat.Disputed) / float64(orders)
+		}
+		if stat.Completed > 0 {
+			score.AutomaticShare = float64(stat.AutomaticCompleted) / float64(stat.Completed)
+		}
+		if subjectType == domain.RoutingSubjectTrader {
+			score.TraderID = stat.SubjectID
+		}
+
+		if orders >= minOrders && orders > 0 {
+			completionFactor := 1.0
+			if platformCompletion > 0 {
+				completionFactor = score.CompletionRate / platformCompletion
+			}
+			speedFactor := 1.0
+			if platformMedian > 0 && stat.MedianCompleteSeconds > 0 {
+				speedFactor = clamp(platformMedian/stat.MedianCompleteSeconds, 0.5, 1.5)
+			}
+			disputeFactor := 1 - score.DisputeRate
+			automaticFactor := 0.9 + 0.2*score.AutomaticShare
+
+			score.Score = clamp(completionFactor*speedFactor*disputeFactor*automaticFactor, minScore, maxScore)
+		}
+		scores = append(scores, score)
+	}
+	return scores
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func clamp(value, low, high float64) float64 {
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
@@ -0,0 +1,145 @@
+package routing
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+// Service периодически пересчитывает скоры маршрутизации и держит их в памяти для выбора кандидатов.
+// Несколько экземпляров сервиса считают одно и то же, переопределения админов подхватываются
+// при каждом пересчете
+type Service struct {
+	repo      domain.RoutingScoreRepository
+	window    time.Duration
+	minOrders int
+	interval  time.Duration
+
+	mutex       sync.RWMutex
+	bankDetails map[string]*domain.RoutingScore
+	traders     map[string]*domain.RoutingScore
+}
+
+func NewService(repo domain.RoutingScoreRepository, window time.Duration, minOrders int, interval time.Duration) *Service {
+	return &Service{
+		repo:        repo,
+		window:      window,
+		minOrders:   minOrders,
+		interval:    interval,
+		bankDetails: make(map[string]*domain.RoutingScore),
+		traders:     make(map[string]*domain.RoutingScore),
+	}
+}
+
+// Start пересчитывает скоры сразу и затем раз в interval до отмены контекста
+func (s *Service) Start(ctx context.Context) {
+	s.refreshAndLog()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refreshAndLog()
+		}
+	}
+}
+
+func (s *Service) refreshAndLog() {
+	if err := s.Refresh(); err != nil {
+		slog.Error("failed to refresh routing scores", "error", err)
+	}
+}
+
+// Refresh пересчитывает скоры реквизитов и трейдеров, сохраняет их и перезагружает в память
+func (s *Service) Refresh() error {
+	calculatedAt := time.Now()
+	since := calculatedAt.Add(-s.window)
+
+	var scores []*domain.RoutingScore
+	for _, subjectType := range []domain.RoutingSubject{domain.RoutingSubjectBankDetail, domain.RoutingSubjectTrader} {
+		stats, err := s.repo.GetRoutingStats(subjectType, since)
+		if err != nil {
+			return err
+		}
+		scores = append(scores, CalculateScores(subjectType, stats, s.minOrders, calculatedAt)...)
+	}
+	if err := s.repo.SaveRoutingScores(scores, calculatedAt); err != nil {
+		return err
+	}
+
+	// Перечитываем из БД, чтобы подхватить переопределения
+	saved, err := s.repo.GetAllRoutingScores()
+	if err != nil {
+		return err
+	}
+	s.replace(saved)
+	return nil
+}
+
+func (s *Service) replace(scores []*domain.RoutingScore) {
+	bankDetails := make(map[string]*domain.RoutingScore)
+	traders := make(map[string]*domain.RoutingScore)
+	for _, score := range scores {
+		switch score.SubjectType {
+		case domain.RoutingSubjectBankDetail:
+			bankDetails[score.SubjectID] = score
+		case domain.RoutingSubjectTrader:
+			traders[score.SubjectID] = score
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.bankDetails = bankDetails
+	s.traders = traders
+}
+
+// Multiplier - множитель веса кандидата. Используется скор реквизита, если он переопределен
+// или рассчитан по достаточному числу сделок, иначе - скор трейдера, иначе 1.
+// Для выбора трейдера (пай-аут) bankDetailID пустой
+func (s *Service) Multiplier(bankDetailID, traderID string) float64 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if score, ok := s.bankDetails[bankDetailID]; ok && s.significant(score) {
+		return score.Effective()
+	}
+	if score, ok := s.traders[traderID]; ok && s.significant(score) {
+		return score.Effective()
+	}
+	return 1
+}
+
+func (s *Service) significant(score *domain.RoutingScore) bool {
+	return score.Override != nil || score.Orders >= s.minOrders
+}
+
+// GetRoutingScores - сохраненные скоры для просмотра админами
+func (s *Service) GetRoutingScores(filter domain.RoutingScoreFilter) ([]*domain.RoutingScore, int64, error) {
+	return s.repo.GetRoutingScores(filter)
+}
+
+// SetOverride задает или снимает (override = nil) переопределение и сразу применяет его на этом экземпляре
+func (s *Service) SetOverride(subjectType domain.RoutingSubject, subjectID, traderID string, override *float64) (*domain.RoutingScore, error) {
+	score, err := s.repo.SetRoutingScoreOverride(subjectType, subjectID, traderID, override)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	switch subjectType {
+	case domain.RoutingSubjectBankDetail:
+		s.bankDetails[subjectID] = score
+	case domain.RoutingSubjectTrader:
+		s.traders[subjectID] = score
+	}
+	return score, nil
+}
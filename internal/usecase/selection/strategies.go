@@ -0,0 +1,219 @@
+package selection
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+// WeightedRandom - случайный выбор с вероятностью, пропорциональной приоритету трейдера.
+// Если у всех кандидатов нулевой приоритет - равновероятный выбор
+type WeightedRandom struct {
+	random *lockedRand
+}
+
+func (s *WeightedRandom) Name() domain.SelectionStrategy {
+	return domain.StrategyWeightedRandom
+}
+
+func (s *WeightedRandom) NeedsStats() bool {
+	return false
+}
+
+func (s *WeightedRandom) Select(scope string, candidates []*Candidate) int {
+	if len(candidates) <= 1 {
+		return len(candidates) - 1
+	}
+
+	totalPriority := 0.0
+	for _, candidate := range candidates {
+		if candidate.Priority > 0 {
+			totalPriority += candidate.Priority
+		}
+	}
+	if totalPriority == 0 {
+		return s.random.Intn(len(candidates))
+	}
+
+	r := s.random.Float64() * totalPriority
+	accumulated := 0.0
+	last := 0
+	for i, candidate := range candidates {
+		if candidate.Priority <= 0 {
+			continue
+		}
+		accumulated += candidate.Priority
+		if r < accumulated {
+			return i
+		}
+		last = i
+	}
+
+	// Достижимо только при ошибках округления
+	return last
+}
+
+// RoundRobin - кандидаты по очереди в порядке ID. Состояние хранится по scope, поэтому
+// очередь одного мерчанта не сдвигается сделками другого. Набор кандидатов между выборами
+// может меняться: выбирается следующий по порядку ID после выбранного в прошлый раз
+type RoundRobin struct {
+	mutex sync.Mutex
+	last  map[string]string // scope -> ID последнего выбранного кандидата
+}
+
+func NewRoundRobin() *RoundRobin {
+	return &RoundRobin{last: make(map[string]string)}
+}
+
+func (s *RoundRobin) Name() domain.SelectionStrategy {
+	return domain.StrategyRoundRobin
+}
+
+func (s *RoundRobin) NeedsStats() bool {
+	return false
+}
+
+func (s *RoundRobin) Select(scope string, candidates []*Candidate) int {
+	if len(candidates) <= 1 {
+		return len(candidates) - 1
+	}
+
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return candidates[order[i]].ID < candidates[order[j]].ID
+	})
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	chosen := order[0]
+	if last, ok := s.last[scope]; ok {
+		for _, i := range order {
+			if candidates[i].ID > last {
+				chosen = i
+				break
+			}
+		}
+	}
+	s.last[scope] = candidates[chosen].ID
+	return chosen
+}
+
+// LeastRecentlyAssigned - кандидат, дольше всех не получавший сделку. Кандидаты без сделок - первыми,
+// среди равных выбор случайный
+type LeastRecentlyAssigned struct {
+	random *lockedRand
+}
+
+func (s *LeastRecentlyAssigned) Name() domain.SelectionStrategy {
+	return domain.StrategyLeastRecentlyAssigned
+}
+
+func (s *LeastRecentlyAssigned) NeedsStats() bool {
+	return true
+}
+
+func (s *LeastRecentlyAssigned) Select(scope string, candidates []*Candidate) int {
+	return selectBest(s.random, candidates, compareLastAssigned)
+}
+
+// LowestLoad - кандидат с наименьшим числом активных сделок, при равенстве - дольше всех без сделки
+type LowestLoad struct {
+	random *lockedRand
+}
+
+func (s *LowestLoad) Name() domain.SelectionStrategy {
+	return domain.StrategyLowestLoad
+}
+
+func (s *LowestLoad) NeedsStats() bool {
+	return true
+}
+
+func (s *LowestLoad) Select(scope string, candidates []*Candidate) int {
+	return selectBest(s.random, candidates, func(a, b *Candidate) int {
+		if a.PendingCount != b.PendingCount {
+			if a.PendingCount < b.PendingCount {
+				return -1
+			}
+			return 1
+		}
+		return compareLastAssigned(a, b)
+	})
+}
+
+// HighestCompletionRate - кандидат с лучшей долей завершенных сделок, при равенстве - с меньшей нагрузкой
+type HighestCompletionRate struct {
+	random *lockedRand
+}
+
+func (s *HighestCompletionRate) Name() domain.SelectionStrategy {
+	return domain.StrategyHighestCompletionRate
+}
+
+func (s *HighestCompletionRate) NeedsStats() bool {
+	return true
+}
+
+func (s *HighestCompletionRate) Select(scope string, candidates []*Candidate) int {
+	return selectBest(s.random, candidates, func(a, b *Candidate) int {
+		rateA, rateB := a.CompletionRate(), b.CompletionRate()
+		if rateA != rateB {
+			if rateA > rateB {
+				return -1
+			}
+			return 1
+		}
+		if a.PendingCount != b.PendingCount {
+			if a.PendingCount < b.PendingCount {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+}
+
+// compareLastAssigned: отрицательное значение - a дольше без сделки, чем b
+func compareLastAssigned(a, b *Candidate) int {
+	switch {
+	case a.LastAssignedAt == nil && b.LastAssignedAt == nil:
+		return 0
+	case a.LastAssignedAt == nil:
+		return -1
+	case b.LastAssignedAt == nil:
+		return 1
+	case a.LastAssignedAt.Before(*b.LastAssignedAt):
+		return -1
+	case b.LastAssignedAt.Before(*a.LastAssignedAt):
+		return 1
+	}
+	return 0
+}
+
+// selectBest возвращает индекс лучшего кандидата по compare (отрицательное значение - a лучше b).
+// Среди равных лучших выбор равновероятный, чтобы не отдавать все сделки первому по порядку
+func selectBest(random *lockedRand, candidates []*Candidate, compare func(a, b *Candidate) int) int {
+	if len(candidates) <= 1 {
+		return len(candidates) - 1
+	}
+
+	best := []int{0}
+	for i := 1; i < len(candidates); i++ {
+		switch c := compare(candidates[i], candidates[best[0]]); {
+		case c < 0:
+			best = []int{i}
+		case c == 0:
+			best = append(best, i)
+		}
+	}
+
+	if len(best) == 1 {
+		return best[0]
+	}
+	return best[random.Intn(len(best))]
+}
@@ -0,0 +1,163 @@
+package selection
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+// Зерно фиксировано, чтобы распределение выборов было воспроизводимым
+const (
+	simSeed       = 42
+	simRounds     = 10000
+	simPendingTTL = 5
+)
+
+// simTrader - синтетический кандидат: приоритет в трафике и доля завершенных сделок
+type simTrader struct {
+	id             string
+	priority       float64
+	completionRate float64
+}
+
+var simTraders = []simTrader{
+	{id: "trader-a", priority: 1, completionRate: 0.95},
+	{id: "trader-b", priority: 2, completionRate: 0.80},
+	{id: "trader-c", priority: 3, completionRate: 0.60},
+	{id: "trader-d", priority: 4, completionRate: 0.90},
+}
+
+// simulate прогоняет стратегию rounds раз и возвращает, сколько раз был выбран каждый трейдер.
+// Статистика сделок обновляется так, как если бы каждая выбранная сделка оставалась активной
+// pendingTTL выборов и завершалась с долей успеха трейдера
+func simulate(strategy Strategy, rounds int, seed int64, pendingTTL int) []int {
+	outcomes := rand.New(rand.NewSource(seed))
+	candidates := make([]*Candidate, len(simTraders))
+	for i, t := range simTraders {
+		candidates[i] = &Candidate{ID: t.id, Priority: t.priority}
+	}
+
+	counts := make([]int, len(simTraders))
+	releases := make(map[int][]int) // раунд -> трейдеры, чьи сделки закрываются в этом раунде
+	now := time.Now()
+	for round := 0; round < rounds; round++ {
+		for _, i := range releases[round] {
+			candidates[i].PendingCount--
+			if outcomes.Float64() < simTraders[i].completionRate {
+				candidates[i].Completed++
+			} else {
+				candidates[i].Canceled++
+			}
+		}
+		delete(releases, round)
+
+		index := strategy.Select("sim", candidates)
+		counts[index]++
+
+		assignedAt := now.Add(time.Duration(round) * time.Second)
+		candidates[index].LastAssignedAt = &assignedAt
+		candidates[index].PendingCount++
+		releases[round+pendingTTL] = append(releases[round+pendingTTL], index)
+	}
+	return counts
+}
+
+// assertEven - сделки распределены поровну, с точностью до одной
+func assertEven(t *testing.T, counts []int) {
+	t.Helper()
+	fewest, most := counts[0], counts[0]
+	for _, count := range counts {
+		if count < fewest {
+			fewest = count
+		}
+		if count > most {
+			most = count
+		}
+	}
+	if most-fewest > 1 {
+		t.Errorf("counts = %v, want an even split", counts)
+	}
+}
+
+// assertShares - доля каждого трейдера отличается от ожидаемой не больше чем на tolerance
+func assertShares(t *testing.T, counts []int, want []float64, tolerance float64) {
+	t.Helper()
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	for i, count := range counts {
+		share := float64(count) / float64(total)
+		if share < want[i]-tolerance || share > want[i]+tolerance {
+			t.Errorf("%s share = %.3f, want %.3f ± %.3f (counts %v)", simTraders[i].id, share, want[i], tolerance, counts)
+		}
+	}
+}
+
+// assertMost - трейдер с индексом best выбран чаще остальных
+func assertMost(t *testing.T, counts []int, best int) {
+	t.Helper()
+	for i, count := range counts {
+		if i != best && count >= counts[best] {
+			t.Errorf("%s chosen %d times, not fewer than %s (%d), counts %v",
+				simTraders[i].id, count, simTraders[best].id, counts[best], counts)
+		}
+	}
+}
+
+func TestStrategiesSimulation(t *testing.T) {
+	tests := []struct {
+		strategy domain.SelectionStrategy
+		check    func(t *testing.T, counts []int)
+	}{
+		{
+			// Доля пропорциональна приоритету: 1/10, 2/10, 3/10, 4/10
+			strategy: domain.StrategyWeightedRandom,
+			check: func(t *testing.T, counts []int) {
+				assertShares(t, counts, []float64{0.1, 0.2, 0.3, 0.4}, 0.02)
+			},
+		},
+		{
+			strategy: domain.StrategyRoundRobin,
+			check:    assertEven,
+		},
+		{
+			strategy: domain.StrategyLeastRecentlyAssigned,
+			check:    assertEven,
+		},
+		{
+			strategy: domain.StrategyLowestLoad,
+			check:    assertEven,
+		},
+		{
+			// Лучшая доля завершенных сделок у trader-a
+			strategy: domain.StrategyHighestCompletionRate,
+			check: func(t *testing.T, counts []int) {
+				assertMost(t, counts, 0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			strategy := NewRegistry(tt.strategy, simSeed).Get(tt.strategy)
+			if strategy.Name() != tt.strategy {
+				t.Fatalf("registry returned %s, want %s", strategy.Name(), tt.strategy)
+			}
+			tt.check(t, simulate(strategy, simRounds, simSeed, simPendingTTL))
+		})
+	}
+}
+
+func TestStrategiesSimulationReproducible(t *testing.T) {
+	strategy := domain.StrategyWeightedRandom
+	first := simulate(NewRegistry(strategy, simSeed).Get(strategy), simRounds, simSeed, simPendingTTL)
+	second := simulate(NewRegistry(strategy, simSeed).Get(strategy), simRounds, simSeed, simPendingTTL)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("same seed gave different counts: %v and %v", first, second)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package selection
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+// Candidate - кандидат на сделку: реквизит для пай-ина или трейдер для пай-аута
+type Candidate struct {
+	ID             string
+	Priority       float64    // приоритет трейдера в трафике с мерчантом
+	PendingCount   int        // активные сделки
+	LastAssignedAt *time.Time // nil - сделок еще не было
+	Completed      int        // завершенные за окно статистики
+	Canceled       int        // отмененные за окно статистики
+}
+
+// CompletionRate - доля завершенных сделок со сглаживанием Лапласа:
+// у кандидата без истории 0.5, а одна отмена не опускает долю до нуля
+func (c *Candidate) CompletionRate() float64 {
+	return float64(c.Completed+1) / float64(c.Completed+c.Canceled+2)
+}
+
+// Strategy выбирает одного кандидата из подходящих
+type Strategy interface {
+	Name() domain.SelectionStrategy
+	// NeedsStats - нужна ли статистика сделок (PendingCount, LastAssignedAt, Completed, Canceled)
+	NeedsStats() bool
+	// Select возвращает индекс выбранного кандидата, -1 - кандидатов нет.
+	// scope - область, в которой стратегия хранит состояние между выборами (например, мерчант)
+	Select(scope string, candidates []*Candidate) int
+}
+
+// lockedRand - генератор случайных чисел, общий для стратегий и безопасный для конкурентного доступа
+type lockedRand struct {
+	mutex  sync.Mutex
+	random *rand.Rand
+}
+
+func (r *lockedRand) Float64() float64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.random.Float64()
+}
+
+func (r *lockedRand) Intn(n int) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.random.Intn(n)
+}
+
+// Registry - стратегии выбора с общим генератором случайных чисел
+type Registry struct {
+	strategies map[domain.SelectionStrategy]Strategy
+	fallback   Strategy
+}
+
+// NewRegistry создает все стратегии. defaultStrategy используется, если мерчант не выбрал свою
+// (пусто или неизвестное значение - weighted_random). seed = 0 - генератор инициализируется временем,
+// иначе последовательность выборов воспроизводима
+func NewRegistry(defaultStrategy domain.SelectionStrategy, seed int64) *Registry {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	random := &lockedRand{random: rand.New(rand.NewSource(seed))}
+
+	registry := &Registry{strategies: make(map[domain.SelectionStrategy]Strategy)}
+	for _, strategy := range []Strategy{
+		&WeightedRandom{random: random},
+		NewRoundRobin(),
+		&LeastRecentlyAssigned{random: random},
+		&LowestLoad{random: random},
+		&HighestCompletionRate{random: random},
+	} {
+		registry.strategies[strategy.Name()] = strategy
+	}
+
+	registry.fallback = registry.strategies[domain.StrategyWeightedRandom]
+	if strategy, ok := registry.strategies[defaultStrategy]; ok {
+		registry.fallback = strategy
+	}
+	return registry
+}
+
+// Get возвращает стратегию по имени или стратегию по умолчанию
+func (r *Registry) Get(name domain.SelectionStrategy) Strategy {
+	if strategy, ok := r.strategies[name]; ok {
+		return strategy
+	}
+	return r.fallback
+}
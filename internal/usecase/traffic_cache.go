@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"github.com/LavaJover/shvark-order-service/internal/domain"
+)
+
+// TrafficCache - кэш трафика трейдер-мерчант с коротким TTL для подбора реквизитов.
+// Кэш локальный для экземпляра сервиса: изменения через этот экземпляр сбрасывают его сразу,
+// изменения на других экземплярах становятся видны не позже чем через TTL
+type TrafficCache struct {
+	ttl        time.Duration
+	mutex      sync.RWMutex
+	entries    map[string]map[string]*trafficCacheEntry // trader_id -> merchant_id -> запись
+	generation uint64                                   // растет при каждом сбросе
+}
+
+type trafficCacheEntry struct {
+	traffic   domain.Traffic
+	expiresAt time.Time
+}
+
+func NewTrafficCache(ttl time.Duration) *TrafficCache {
+	return &TrafficCache{
+		ttl:     ttl,
+		entries: make(map[string]map[string]*trafficCacheEntry),
+	}
+}
+
+// Get возвращает копию закэшированной записи, если она не истекла
+func (c *TrafficCache) Get(traderID, merchantID string) (*domain.Traffic, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	entry, ok := c.entries[traderID][merchantID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	traffic := entry.traffic
+	return &traffic, true
+}
+
+// Generation - номер поколения кэша. Его нужно запомнить до чтения из БД и передать в Put,
+// чтобы запись, прочитанная до сброса, не попала в кэш после него
+func (c *TrafficCache) Generation() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.generation
+}
+
+// Put кладет записи в кэш, если с момента generation кэш не сбрасывался
+func (c *TrafficCache) Put(generation uint64, traffics ...*domain.Traffic) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	expiresAt := time.Now().Add(c.ttl)
+	for _, traffic := range traffics {
+		if c.entries[traffic.TraderID] == nil {
+			c.entries[traffic.TraderID] = make(map[string]*trafficCacheEntry)
+		}
+		c.entries[traffic.TraderID][traffic.MerchantID] = &trafficCacheEntry{
+			traffic:   *traffic,
+			expiresAt: expiresAt,
+		}
+	}
+}
+
+// InvalidateTrader сбрасывает трафик трейдера со всеми мерчантами
+func (c *TrafficCache) InvalidateTrader(traderID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+	delete(c.entries, traderID)
+}
+
+// InvalidateMerchant сбрасывает трафик мерчанта со всеми трейдерами
+func (c *TrafficCache) InvalidateMerchant(merchantID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+	for _, byMerchant := range c.entries {
+		delete(byMerchant, merchantID)
+	}
+}
+
+// InvalidateAll сбрасывает весь кэш. Используется, когда запись меняется по ID трафика
+func (c *TrafficCache) InvalidateAll() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+	c.entries = make(map[string]map[string]*trafficCacheEntry)
+}
@@ -14,6 +14,7 @@ type TrafficUsecase interface {
 	GetTrafficByID(trafficID string) (*domain.Traffic, error)
 	DeleteTraffic(trafficID string) error
 	GetTrafficByTraderMerchant(traderID, merchantID string) (*domain.Traffic, error)
+	GetTrafficByTradersForMerchant(merchantID string, traderIDs []string) (map[string]*domain.Traffic, error)
 	DisableTraderTraffic(traderID string) error
 	EnableTraderTraffic(traderID string) error
 	GetTraderTrafficStatus(traderID string) (bool, error)
@@ -25,17 +26,34 @@ type TrafficUsecase interface {
 	GetLockStatuses(trafficID string) (*trafficdto.LockStatusesResponse, error)
 	GetTrafficByTraderID(traderID string) ([]*domain.Traffic, error) // НОВОЕ
 	GetTrafficByMerchantID(merchantID string) ([]*domain.Traffic, error)
+
+	// Расписание работы трафика
+	SetTrafficSchedule(trafficID string, schedule *domain.Schedule) error
+	GetTrafficSchedule(trafficID string) (*domain.Schedule, error)
+	DeleteTrafficSchedule(trafficID string) error
+}
+
+// TrafficInvalidator - кэш, в котором есть трафик: его нужно обновить после изменения трафика
+type TrafficInvalidator interface {
+	InvalidateTrader(traderID string)
+	InvalidateMerchant(merchantID string)
+	InvalidateAll()
 }
 
 type DefaultTrafficUsecase struct {
 	TrafficRepo domain.TrafficRepository
+	// Кэш трафика для подбора реквизитов (nil - без кэша)
+	Cache 		*TrafficCache
+	// Кэш каскада подбора реквизитов (nil - каскад выключен)
+	Cascade 	TrafficInvalidator
 }
 
-func NewDefaultTrafficUsecase(trafficRepo domain.TrafficRepository) *DefaultTrafficUsecase {
-	return &DefaultTrafficUsecase{TrafficRepo: trafficRepo}
+func NewDefaultTrafficUsecase(trafficRepo domain.TrafficRepository, cache *TrafficCache, cascade TrafficInvalidator) *DefaultTrafficUsecase {
+	return &DefaultTrafficUsecase{TrafficRepo: trafficRepo, Cache: cache, Cascade: cascade}
 }
 
 func (uc *DefaultTrafficUsecase) AddTraffic(traffic *domain.Traffic) error {
+	defer uc.invalidateTrader(traffic.TraderID)
 	return uc.TrafficRepo.CreateTraffic(traffic)
 }
 
@@ -45,10 +63,12 @@ func (uc *DefaultTrafficUsecase) EditTraffic(input *trafficdto.EditTrafficInput)
 		return fmt.Errorf("id is required")
 	}
 
+	defer uc.invalidateAll()
 	return uc.TrafficRepo.UpdateTraffic(input)
 }
 
 func (uc *DefaultTrafficUsecase) DeleteTraffic(trafficID string) error {
+	defer uc.invalidateAll()
 	return uc.TrafficRepo.DeleteTraffic(trafficID)
 }
 
@@ -61,14 +81,67 @@ func (uc *DefaultTrafficUsecase) GetTrafficRecords(page, limit int32) ([]*domain
 }
 
 func (uc *DefaultTrafficUsecase) GetTrafficByTraderMerchant(traderID, merchantID string) (*domain.Traffic, error) {
-	return uc.TrafficRepo.GetTrafficByTraderMerchant(traderID, merchantID)
+	if uc.Cache == nil {
+		return uc.TrafficRepo.GetTrafficByTraderMerchant(traderID, merchantID)
+	}
+	if traffic, ok := uc.Cache.Get(traderID, merchantID); ok {
+		return traffic, nil
+	}
+
+	generation := uc.Cache.Generation()
+	traffic, err := uc.TrafficRepo.GetTrafficByTraderMerchant(traderID, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	uc.Cache.Put(generation, traffic)
+	return traffic, nil
+}
+
+// GetTrafficByTradersForMerchant возвращает трафик трейдеров с мерчантом по trader_id.
+// Чего нет в кэше, загружается одним запросом. Трейдеров без трафика в результате нет
+func (uc *DefaultTrafficUsecase) GetTrafficByTradersForMerchant(merchantID string, traderIDs []string) (map[string]*domain.Traffic, error) {
+	result := make(map[string]*domain.Traffic, len(traderIDs))
+	missing := make([]string, 0, len(traderIDs))
+	for _, traderID := range traderIDs {
+		if _, ok := result[traderID]; ok {
+			continue
+		}
+		if uc.Cache != nil {
+			if traffic, ok := uc.Cache.Get(traderID, merchantID); ok {
+				result[traderID] = traffic
+				continue
+			}
+		}
+		missing = append(missing, traderID)
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	var generation uint64
+	if uc.Cache != nil {
+		generation = uc.Cache.Generation()
+	}
+	traffics, err := uc.TrafficRepo.GetTrafficByTradersForMerchant(merchantID, missing)
+	if err != nil {
+		return nil, err
+	}
+	if uc.Cache != nil {
+		uc.Cache.Put(generation, traffics...)
+	}
+	for _, traffic := range traffics {
+		result[traffic.TraderID] = traffic
+	}
+	return result, nil
 }
 
 func (uc *DefaultTrafficUsecase) DisableTraderTraffic(traderID string) error {
+	defer uc.invalidateTrader(traderID)
 	return uc.TrafficRepo.DisableTraderTraffic(traderID)
 }
 
 func (uc *DefaultTrafficUsecase) EnableTraderTraffic(traderID string) error {
+	defer uc.invalidateTrader(traderID)
 	return uc.TrafficRepo.EnableTraderTraffic(traderID)
 }
 
@@ -77,18 +150,50 @@ func (uc *DefaultTrafficUsecase) GetTraderTrafficStatus(traderID string) (bool,
 }
 
 func (uc *DefaultTrafficUsecase) SetTraderLockTrafficStatus(traderID string, unlocked bool) error {
+	defer uc.invalidateTrader(traderID)
 	return uc.TrafficRepo.SetTraderLockTrafficStatus(traderID, unlocked)
 }
 func (uc *DefaultTrafficUsecase) SetMerchantLockTrafficStatus(merchantID string, unlocked bool) error {
+	defer uc.invalidateMerchant(merchantID)
 	return uc.TrafficRepo.SetMerchantLockTrafficStatus(merchantID, unlocked)
 }
 func (uc *DefaultTrafficUsecase) SetManuallyLockTrafficStatus(trafficID string, unlocked bool) error {
+	defer uc.invalidateAll()
 	return uc.TrafficRepo.SetManuallyLockTrafficStatus(trafficID, unlocked)
 }
 func (uc *DefaultTrafficUsecase) SetAntifraudLockTrafficStatus(traderID string, unlocked bool) error {
+	defer uc.invalidateTrader(traderID)
 	return uc.TrafficRepo.SetAntifraudLockTrafficStatus(traderID, unlocked)
 }
 
+// Кэш сбрасывается после записи, даже неудачной: состояние БД после ошибки неизвестно
+func (uc *DefaultTrafficUsecase) invalidateTrader(traderID string) {
+	if uc.Cache != nil {
+		uc.Cache.InvalidateTrader(traderID)
+	}
+	if uc.Cascade != nil {
+		uc.Cascade.InvalidateTrader(traderID)
+	}
+}
+
+func (uc *DefaultTrafficUsecase) invalidateMerchant(merchantID string) {
+	if uc.Cache != nil {
+		uc.Cache.InvalidateMerchant(merchantID)
+	}
+	if uc.Cascade != nil {
+		uc.Cascade.InvalidateMerchant(merchantID)
+	}
+}
+
+func (uc *DefaultTrafficUsecase) invalidateAll() {
+	if uc.Cache != nil {
+		uc.Cache.InvalidateAll()
+	}
+	if uc.Cascade != nil {
+		uc.Cascade.InvalidateAll()
+	}
+}
+
 // GetLockStatuses возвращает все статусы блокировки для указанного трафика
 func (uc *DefaultTrafficUsecase) GetLockStatuses(trafficID string) (*trafficdto.LockStatusesResponse, error) {
 	if trafficID == "" {
@@ -142,3 +247,40 @@ func (uc *DefaultTrafficUsecase) GetTrafficByMerchantID(merchantID string) ([]*d
 
     return uc.TrafficRepo.GetTrafficByMerchantID(merchantID)
 }
+
+func (uc *DefaultTrafficUsecase) SetTrafficSchedule(trafficID string, schedule *domain.Schedule) error {
+	if trafficID == "" {
+		return fmt.Errorf("traffic_id is required")
+	}
+	if schedule == nil {
+		return fmt.Errorf("schedule is required")
+	}
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+
+	defer uc.invalidateAll()
+	return uc.TrafficRepo.SetTrafficSchedule(trafficID, schedule)
+}
+
+// GetTrafficSchedule возвращает расписание трафика, nil - расписания нет
+func (uc *DefaultTrafficUsecase) GetTrafficSchedule(trafficID string) (*domain.Schedule, error) {
+	if trafficID == "" {
+		return nil, fmt.Errorf("traffic_id is required")
+	}
+
+	traffic, err := uc.TrafficRepo.GetTrafficByID(trafficID)
+	if err != nil {
+		return nil, err
+	}
+	return traffic.Schedule, nil
+}
+
+func (uc *DefaultTrafficUsecase) DeleteTrafficSchedule(trafficID string) error {
+	if trafficID == "" {
+		return fmt.Errorf("traffic_id is required")
+	}
+
+	defer uc.invalidateAll()
+	return uc.TrafficRepo.SetTrafficSchedule(trafficID, nil)
+}
@@ -0,0 +1,81 @@
+// Package callbacksig - подпись колбэков сервиса сделок. Сервис подписывает колбэки через
+// SignatureHeaders, мерчанты проверяют их через VerifySignature (пакет можно импортировать)
+package callbacksig
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Заголовки подписи колбэка
+const (
+	SignatureHeader          = "X-Signature"
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	ErrMissingSignature = errors.New("callback signature is missing")
+	ErrInvalidSignature = errors.New("callback signature is invalid")
+	ErrSignatureExpired = errors.New("callback signature timestamp is outside of tolerance")
+)
+
+// Sign подписывает колбэк секретом мерчанта.
+//
+// Алгоритм:
+//  1. message = "<timestamp>.<body>", где timestamp - unix-время в секундах из заголовка X-Signature-Timestamp,
+//     а body - тело POST-запроса (JSON) либо строка запроса без "?" для GET-колбэков;
+//  2. signature = hex(HMAC-SHA256(secret, message));
+//  3. в заголовке X-Signature передается "sha256=<signature>".
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignatureHeaders возвращает заголовки подписи для отправки колбэка
+func SignatureHeaders(secret string, body []byte, now time.Time) map[string]string {
+	timestamp := now.Unix()
+	return map[string]string{
+		SignatureTimestampHeader: strconv.FormatInt(timestamp, 10),
+		SignatureHeader:          Sign(secret, timestamp, body),
+	}
+}
+
+// VerifySignature проверяет подпись полученного колбэка на стороне мерчанта.
+// timestampHeader и signatureHeader - значения заголовков X-Signature-Timestamp и X-Signature,
+// body - сырое тело запроса (для GET - строка запроса без "?").
+// tolerance ограничивает возраст подписи для защиты от повторной отправки; 0 - без ограничения.
+func VerifySignature(secret, timestampHeader, signatureHeader string, body []byte, tolerance time.Duration) error {
+	if timestampHeader == "" || signatureHeader == "" {
+		return ErrMissingSignature
+	}
+	if !strings.HasPrefix(signatureHeader, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+
+	timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if tolerance > 0 {
+		age := time.Since(time.Unix(timestamp, 0))
+		if age > tolerance || age < -tolerance {
+			return ErrSignatureExpired
+		}
+	}
+
+	expected := Sign(secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(signatureHeader)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package callbacksig
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Эталон для мерчантов: hex(HMAC-SHA256("merchant-secret", `1718000000.{"order_id":"order-1"}`))
+func TestSignVector(t *testing.T) {
+	got := Sign("merchant-secret", 1718000000, []byte(`{"order_id":"order-1"}`))
+	want := "sha256=cfc0aa6bdcb1b1552307a805613092985313461ddb007f11e63a8e464b52bcaa"
+	if got != want {
+		t.Errorf("signature = %s, want %s", got, want)
+	}
+}
+
+func TestVerifySignatureRoundTrip(t *testing.T) {
+	body := []byte(`{"order_id":"order-1","status":"COMPLETED"}`)
+	headers := SignatureHeaders("merchant-secret", body, time.Now())
+
+	err := VerifySignature("merchant-secret", headers[SignatureTimestampHeader], headers[SignatureHeader], body, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("VerifySignature failed: %v", err)
+	}
+}
+
+func TestVerifySignatureRejects(t *testing.T) {
+	const secret = "merchant-secret"
+	body := []byte(`{"order_id":"order-1","status":"COMPLETED"}`)
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := Sign(secret, now.Unix(), body)
+
+	old := now.Add(-10 * time.Minute).Unix()
+	future := now.Add(10 * time.Minute).Unix()
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      string
+		tolerance time.Duration
+		want      error
+	}{
+		{name: "no timestamp", secret: secret, signature: signature, body: string(body), tolerance: time.Minute, want: ErrMissingSignature},
+		{name: "no signature", secret: secret, timestamp: timestamp, body: string(body), tolerance: time.Minute, want: ErrMissingSignature},
+		{name: "changed body", secret: secret, timestamp: timestamp, signature: signature,
+			body: `{"order_id":"order-1","status":"CANCELED"}`, tolerance: time.Minute, want: ErrInvalidSignature},
+		{name: "other secret", secret: "other-secret", timestamp: timestamp, signature: signature, body: string(body), tolerance: time.Minute, want: ErrInvalidSignature},
+		{name: "changed timestamp", secret: secret, timestamp: strconv.FormatInt(now.Unix()+1, 10), signature: signature,
+			body: string(body), tolerance: time.Minute, want: ErrInvalidSignature},
+		{name: "signature without prefix", secret: secret, timestamp: timestamp, signature: signature[len("sha256="):],
+			body: string(body), tolerance: time.Minute, want: ErrInvalidSignature},
+		{name: "truncated signature", secret: secret, timestamp: timestamp, signature: signature[:len(signature)-1],
+			body: string(body), tolerance: time.Minute, want: ErrInvalidSignature},
+		{name: "timestamp is not a number", secret: secret, timestamp: "now", signature: signature, body: string(body), tolerance: time.Minute, want: ErrInvalidSignature},
+		{name: "expired", secret: secret, timestamp: strconv.FormatInt(old, 10), signature: Sign(secret, old, body),
+			body: string(body), tolerance: 5 * time.Minute, want: ErrSignatureExpired},
+		{name: "from the future", secret: secret, timestamp: strconv.FormatInt(future, 10), signature: Sign(secret, future, body),
+			body: string(body), tolerance: 5 * time.Minute, want: ErrSignatureExpired},
+		{name: "old signature without tolerance", secret: secret, timestamp: strconv.FormatInt(old, 10), signature: Sign(secret, old, body),
+			body: string(body)},
+		{name: "old signature within tolerance", secret: secret, timestamp: strconv.FormatInt(old, 10), signature: Sign(secret, old, body),
+			body: string(body), tolerance: 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature(tt.secret, tt.timestamp, tt.signature, []byte(tt.body), tt.tolerance)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("VerifySignature = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
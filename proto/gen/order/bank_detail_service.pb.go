@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -46,8 +47,19 @@ type BankDetail struct {
 	InflowCurrency         string                 `protobuf:"bytes,20,opt,name=inflow_currency,json=inflowCurrency,proto3" json:"inflow_currency,omitempty"`
 	BankCode               string                 `protobuf:"bytes,21,opt,name=bank_code,json=bankCode,proto3" json:"bank_code,omitempty"`
 	NspkCode               string                 `protobuf:"bytes,22,opt,name=nspk_code,json=nspkCode,proto3" json:"nspk_code,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	// Часовые и недельные лимиты, 0 - без ограничения
+	MaxAmountHour   float64 `protobuf:"fixed64,23,opt,name=max_amount_hour,json=maxAmountHour,proto3" json:"max_amount_hour,omitempty"`
+	MaxQuantityHour int32   `protobuf:"varint,24,opt,name=max_quantity_hour,json=maxQuantityHour,proto3" json:"max_quantity_hour,omitempty"`
+	MaxAmountWeek   float64 `protobuf:"fixed64,25,opt,name=max_amount_week,json=maxAmountWeek,proto3" json:"max_amount_week,omitempty"`
+	MaxQuantityWeek int32   `protobuf:"varint,26,opt,name=max_quantity_week,json=maxQuantityWeek,proto3" json:"max_quantity_week,omitempty"`
+	// Окна лимитов: "calendar" (по умолчанию) или "rolling"
+	LimitMode string `protobuf:"bytes,27,opt,name=limit_mode,json=limitMode,proto3" json:"limit_mode,omitempty"`
+	// IANA-имя часового пояса календарных окон, пусто - пояс сервера
+	Timezone string `protobuf:"bytes,28,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// Банк из справочника BIN по номеру карты не совпал с указанным трейдером, только для чтения
+	BinMismatch   bool `protobuf:"varint,29,opt,name=bin_mismatch,json=binMismatch,proto3" json:"bin_mismatch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BankDetail) Reset() {
@@ -234,6 +246,55 @@ func (x *BankDetail) GetNspkCode() string {
 	return ""
 }
 
+func (x *BankDetail) GetMaxAmountHour() float64 {
+	if x != nil {
+		return x.MaxAmountHour
+	}
+	return 0
+}
+
+func (x *BankDetail) GetMaxQuantityHour() int32 {
+	if x != nil {
+		return x.MaxQuantityHour
+	}
+	return 0
+}
+
+func (x *BankDetail) GetMaxAmountWeek() float64 {
+	if x != nil {
+		return x.MaxAmountWeek
+	}
+	return 0
+}
+
+func (x *BankDetail) GetMaxQuantityWeek() int32 {
+	if x != nil {
+		return x.MaxQuantityWeek
+	}
+	return 0
+}
+
+func (x *BankDetail) GetLimitMode() string {
+	if x != nil {
+		return x.LimitMode
+	}
+	return ""
+}
+
+func (x *BankDetail) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *BankDetail) GetBinMismatch() bool {
+	if x != nil {
+		return x.BinMismatch
+	}
+	return false
+}
+
 type CreateBankDetailRequest struct {
 	state                  protoimpl.MessageState `protogen:"open.v1"`
 	TraderId               string                 `protobuf:"bytes,1,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
@@ -257,6 +318,12 @@ type CreateBankDetailRequest struct {
 	InflowCurrency         string                 `protobuf:"bytes,19,opt,name=inflow_currency,json=inflowCurrency,proto3" json:"inflow_currency,omitempty"`
 	BankCode               string                 `protobuf:"bytes,20,opt,name=bank_code,json=bankCode,proto3" json:"bank_code,omitempty"`
 	NspkCode               string                 `protobuf:"bytes,21,opt,name=nspk_code,json=nspkCode,proto3" json:"nspk_code,omitempty"`
+	MaxAmountHour          float64                `protobuf:"fixed64,22,opt,name=max_amount_hour,json=maxAmountHour,proto3" json:"max_amount_hour,omitempty"`
+	MaxQuantityHour        int32                  `protobuf:"varint,23,opt,name=max_quantity_hour,json=maxQuantityHour,proto3" json:"max_quantity_hour,omitempty"`
+	MaxAmountWeek          float64                `protobuf:"fixed64,24,opt,name=max_amount_week,json=maxAmountWeek,proto3" json:"max_amount_week,omitempty"`
+	MaxQuantityWeek        int32                  `protobuf:"varint,25,opt,name=max_quantity_week,json=maxQuantityWeek,proto3" json:"max_quantity_week,omitempty"`
+	LimitMode              string                 `protobuf:"bytes,26,opt,name=limit_mode,json=limitMode,proto3" json:"limit_mode,omitempty"`
+	Timezone               string                 `protobuf:"bytes,27,opt,name=timezone,proto3" json:"timezone,omitempty"`
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
@@ -438,6 +505,48 @@ func (x *CreateBankDetailRequest) GetNspkCode() string {
 	return ""
 }
 
+func (x *CreateBankDetailRequest) GetMaxAmountHour() float64 {
+	if x != nil {
+		return x.MaxAmountHour
+	}
+	return 0
+}
+
+func (x *CreateBankDetailRequest) GetMaxQuantityHour() int32 {
+	if x != nil {
+		return x.MaxQuantityHour
+	}
+	return 0
+}
+
+func (x *CreateBankDetailRequest) GetMaxAmountWeek() float64 {
+	if x != nil {
+		return x.MaxAmountWeek
+	}
+	return 0
+}
+
+func (x *CreateBankDetailRequest) GetMaxQuantityWeek() int32 {
+	if x != nil {
+		return x.MaxQuantityWeek
+	}
+	return 0
+}
+
+func (x *CreateBankDetailRequest) GetLimitMode() string {
+	if x != nil {
+		return x.LimitMode
+	}
+	return ""
+}
+
+func (x *CreateBankDetailRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
 type CreateBankDetailResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BankDetailId  string                 `protobuf:"bytes,1,opt,name=bank_detail_id,json=bankDetailId,proto3" json:"bank_detail_id,omitempty"`
@@ -1174,123 +1283,1229 @@ func (x *GetBankDetailsResponse) GetPagination() *Pagination {
 	return nil
 }
 
-var File_order_bank_detail_service_proto protoreflect.FileDescriptor
+type SetBankDetailScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BankDetailId  string                 `protobuf:"bytes,1,opt,name=bank_detail_id,json=bankDetailId,proto3" json:"bank_detail_id,omitempty"`
+	Schedule      *Schedule              `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_order_bank_detail_service_proto_rawDesc = "" +
-	"\n" +
-	"\x1forder/bank_detail_service.proto\x12\x05order\x1a\x1egoogle/protobuf/duration.proto\x1a\x18order/common_types.proto\"\x81\x06\n" +
-	"\n" +
-	"BankDetail\x12$\n" +
-	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\x12\x1b\n" +
-	"\ttrader_id\x18\x02 \x01(\tR\btraderId\x12\x1a\n" +
-	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x18\n" +
-	"\acountry\x18\x04 \x01(\tR\acountry\x12\x1d\n" +
-	"\n" +
-	"min_amount\x18\x05 \x01(\x01R\tminAmount\x12\x1d\n" +
-	"\n" +
-	"max_amount\x18\x06 \x01(\x01R\tmaxAmount\x12\x1b\n" +
-	"\tbank_name\x18\a \x01(\tR\bbankName\x12%\n" +
-	"\x0epayment_system\x18\b \x01(\tR\rpaymentSystem\x12\x18\n" +
-	"\aenabled\x18\t \x01(\bR\aenabled\x12/\n" +
-	"\x05delay\x18\n" +
-	" \x01(\v2\x19.google.protobuf.DurationR\x05delay\x12\x1f\n" +
-	"\vcard_number\x18\v \x01(\tR\n" +
-	"cardNumber\x12\x14\n" +
-	"\x05phone\x18\f \x01(\tR\x05phone\x12\x14\n" +
-	"\x05owner\x18\r \x01(\tR\x05owner\x128\n" +
-	"\x18max_orders_simultaneosly\x18\x0e \x01(\x05R\x16maxOrdersSimultaneosly\x12$\n" +
-	"\x0emax_amount_day\x18\x0f \x01(\x01R\fmaxAmountDay\x12(\n" +
-	"\x10max_amount_month\x18\x10 \x01(\x01R\x0emaxAmountMonth\x12(\n" +
-	"\x10max_quantity_day\x18\x11 \x01(\x01R\x0emaxQuantityDay\x12,\n" +
-	"\x12max_quantity_month\x18\x12 \x01(\x01R\x10maxQuantityMonth\x12\x1b\n" +
-	"\tdevice_id\x18\x13 \x01(\tR\bdeviceId\x12'\n" +
-	"\x0finflow_currency\x18\x14 \x01(\tR\x0einflowCurrency\x12\x1b\n" +
-	"\tbank_code\x18\x15 \x01(\tR\bbankCode\x12\x1b\n" +
-	"\tnspk_code\x18\x16 \x01(\tR\bnspkCode\"\xe8\x05\n" +
-	"\x17CreateBankDetailRequest\x12\x1b\n" +
-	"\ttrader_id\x18\x01 \x01(\tR\btraderId\x12\x1a\n" +
-	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x18\n" +
-	"\acountry\x18\x03 \x01(\tR\acountry\x12\x1d\n" +
-	"\n" +
-	"min_amount\x18\x04 \x01(\x01R\tminAmount\x12\x1d\n" +
-	"\n" +
-	"max_amount\x18\x05 \x01(\x01R\tmaxAmount\x12\x1b\n" +
-	"\tbank_name\x18\x06 \x01(\tR\bbankName\x12%\n" +
-	"\x0epayment_system\x18\a \x01(\tR\rpaymentSystem\x12\x18\n" +
-	"\aenabled\x18\b \x01(\bR\aenabled\x12/\n" +
-	"\x05delay\x18\t \x01(\v2\x19.google.protobuf.DurationR\x05delay\x12\x1f\n" +
-	"\vcard_number\x18\n" +
-	" \x01(\tR\n" +
-	"cardNumber\x12\x14\n" +
-	"\x05phone\x18\v \x01(\tR\x05phone\x12\x14\n" +
-	"\x05owner\x18\f \x01(\tR\x05owner\x128\n" +
-	"\x18max_orders_simultaneosly\x18\r \x01(\x05R\x16maxOrdersSimultaneosly\x12$\n" +
-	"\x0emax_amount_day\x18\x0e \x01(\x01R\fmaxAmountDay\x12(\n" +
-	"\x10max_amount_month\x18\x0f \x01(\x01R\x0emaxAmountMonth\x12(\n" +
-	"\x10max_quantity_day\x18\x10 \x01(\x01R\x0emaxQuantityDay\x12,\n" +
-	"\x12max_quantity_month\x18\x11 \x01(\x01R\x10maxQuantityMonth\x12\x1b\n" +
-	"\tdevice_id\x18\x12 \x01(\tR\bdeviceId\x12'\n" +
-	"\x0finflow_currency\x18\x13 \x01(\tR\x0einflowCurrency\x12\x1b\n" +
-	"\tbank_code\x18\x14 \x01(\tR\bbankCode\x12\x1b\n" +
-	"\tnspk_code\x18\x15 \x01(\tR\bnspkCode\"@\n" +
-	"\x18CreateBankDetailResponse\x12$\n" +
-	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\"M\n" +
-	"\x17UpdateBankDetailRequest\x122\n" +
-	"\vbank_detail\x18\x01 \x01(\v2\x11.order.BankDetailR\n" +
-	"bankDetail\"\x1a\n" +
-	"\x18UpdateBankDetailResponse\"@\n" +
-	"\x18GetBankDetailByIDRequest\x12$\n" +
-	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\"O\n" +
-	"\x19GetBankDetailByIDResponse\x122\n" +
-	"\vbank_detail\x18\x01 \x01(\v2\x11.order.BankDetailR\n" +
-	"bankDetail\"?\n" +
-	"\x17DeleteBankDetailRequest\x12$\n" +
-	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\"\x1a\n" +
-	"\x18DeleteBankDetailResponse\"\xcf\x01\n" +
-	"\x1fGetBankDetailsByTraderIDRequest\x12\x1b\n" +
-	"\ttrader_id\x18\x01 \x01(\tR\btraderId\x12\x12\n" +
-	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x17\n" +
-	"\asort_by\x18\x04 \x01(\tR\x06sortBy\x12\x1d\n" +
-	"\n" +
-	"sort_order\x18\x05 \x01(\tR\tsortOrder\x12-\n" +
-	"\afilters\x18\x06 \x01(\v2\x13.order.OrderFiltersR\afilters\"\x8b\x01\n" +
-	" GetBankDetailsByTraderIDResponse\x124\n" +
-	"\fbank_details\x18\x01 \x03(\v2\x11.order.BankDetailR\vbankDetails\x121\n" +
-	"\n" +
-	"pagination\x18\x02 \x01(\v2\x11.order.PaginationR\n" +
-	"pagination\"\xfa\x01\n" +
-	"\x0eBankDetailStat\x12$\n" +
-	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\x12.\n" +
-	"\x13current_count_today\x18\x02 \x01(\x05R\x11currentCountToday\x12.\n" +
-	"\x13current_count_month\x18\x03 \x01(\x05R\x11currentCountMonth\x120\n" +
-	"\x14current_amount_today\x18\x04 \x01(\x01R\x12currentAmountToday\x120\n" +
-	"\x14current_amount_month\x18\x05 \x01(\x01R\x12currentAmountMonth\"C\n" +
-	"$GetBankDetailsStatsByTraderIDRequest\x12\x1b\n" +
-	"\ttrader_id\x18\x01 \x01(\tR\btraderId\"h\n" +
-	"%GetBankDetailsStatsByTraderIDResponse\x12?\n" +
-	"\x10bank_detail_stat\x18\x01 \x03(\v2\x15.order.BankDetailStatR\x0ebankDetailStat\"\xc9\x02\n" +
-	"\x15GetBankDetailsRequest\x12 \n" +
-	"\ttrader_id\x18\x01 \x01(\tH\x00R\btraderId\x88\x01\x01\x12 \n" +
-	"\tbank_code\x18\x02 \x01(\tH\x01R\bbankCode\x88\x01\x01\x12\x1d\n" +
-	"\aenabled\x18\x03 \x01(\bH\x02R\aenabled\x88\x01\x01\x12*\n" +
-	"\x0epayment_system\x18\x04 \x01(\tH\x03R\rpaymentSystem\x88\x01\x01\x12)\n" +
-	"\x0ebank_detail_id\x18\x05 \x01(\tH\x04R\fbankDetailId\x88\x01\x01\x12\x12\n" +
-	"\x04page\x18\x06 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\a \x01(\x05R\x05limitB\f\n" +
-	"\n" +
-	"_trader_idB\f\n" +
-	"\n" +
-	"_bank_codeB\n" +
-	"\n" +
-	"\b_enabledB\x11\n" +
-	"\x0f_payment_systemB\x11\n" +
-	"\x0f_bank_detail_id\"\x81\x01\n" +
-	"\x16GetBankDetailsResponse\x124\n" +
-	"\fbank_details\x18\x01 \x03(\v2\x11.order.BankDetailR\vbankDetails\x121\n" +
-	"\n" +
-	"pagination\x18\x02 \x01(\v2\x11.order.PaginationR\n" +
-	"pagination2\xa2\x05\n" +
+func (x *SetBankDetailScheduleRequest) Reset() {
+	*x = SetBankDetailScheduleRequest{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBankDetailScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBankDetailScheduleRequest) ProtoMessage() {}
+
+func (x *SetBankDetailScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBankDetailScheduleRequest.ProtoReflect.Descriptor instead.
+func (*SetBankDetailScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetBankDetailScheduleRequest) GetBankDetailId() string {
+	if x != nil {
+		return x.BankDetailId
+	}
+	return ""
+}
+
+func (x *SetBankDetailScheduleRequest) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type SetBankDetailScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBankDetailScheduleResponse) Reset() {
+	*x = SetBankDetailScheduleResponse{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBankDetailScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBankDetailScheduleResponse) ProtoMessage() {}
+
+func (x *SetBankDetailScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBankDetailScheduleResponse.ProtoReflect.Descriptor instead.
+func (*SetBankDetailScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{17}
+}
+
+type GetBankDetailScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BankDetailId  string                 `protobuf:"bytes,1,opt,name=bank_detail_id,json=bankDetailId,proto3" json:"bank_detail_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBankDetailScheduleRequest) Reset() {
+	*x = GetBankDetailScheduleRequest{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBankDetailScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBankDetailScheduleRequest) ProtoMessage() {}
+
+func (x *GetBankDetailScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBankDetailScheduleRequest.ProtoReflect.Descriptor instead.
+func (*GetBankDetailScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetBankDetailScheduleRequest) GetBankDetailId() string {
+	if x != nil {
+		return x.BankDetailId
+	}
+	return ""
+}
+
+type GetBankDetailScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"` // не задано - расписания нет, реквизит доступен всегда
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBankDetailScheduleResponse) Reset() {
+	*x = GetBankDetailScheduleResponse{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBankDetailScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBankDetailScheduleResponse) ProtoMessage() {}
+
+func (x *GetBankDetailScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBankDetailScheduleResponse.ProtoReflect.Descriptor instead.
+func (*GetBankDetailScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetBankDetailScheduleResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type DeleteBankDetailScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BankDetailId  string                 `protobuf:"bytes,1,opt,name=bank_detail_id,json=bankDetailId,proto3" json:"bank_detail_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBankDetailScheduleRequest) Reset() {
+	*x = DeleteBankDetailScheduleRequest{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBankDetailScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBankDetailScheduleRequest) ProtoMessage() {}
+
+func (x *DeleteBankDetailScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBankDetailScheduleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBankDetailScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteBankDetailScheduleRequest) GetBankDetailId() string {
+	if x != nil {
+		return x.BankDetailId
+	}
+	return ""
+}
+
+type DeleteBankDetailScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBankDetailScheduleResponse) Reset() {
+	*x = DeleteBankDetailScheduleResponse{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBankDetailScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBankDetailScheduleResponse) ProtoMessage() {}
+
+func (x *DeleteBankDetailScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBankDetailScheduleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBankDetailScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{21}
+}
+
+type GetEffectiveAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TraderId      string                 `protobuf:"bytes,1,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEffectiveAvailabilityRequest) Reset() {
+	*x = GetEffectiveAvailabilityRequest{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEffectiveAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEffectiveAvailabilityRequest) ProtoMessage() {}
+
+func (x *GetEffectiveAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEffectiveAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*GetEffectiveAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetEffectiveAvailabilityRequest) GetTraderId() string {
+	if x != nil {
+		return x.TraderId
+	}
+	return ""
+}
+
+type BankDetailAvailability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BankDetailId  string                 `protobuf:"bytes,1,opt,name=bank_detail_id,json=bankDetailId,proto3" json:"bank_detail_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	InSchedule    bool                   `protobuf:"varint,3,opt,name=in_schedule,json=inSchedule,proto3" json:"in_schedule,omitempty"`
+	Available     bool                   `protobuf:"varint,4,opt,name=available,proto3" json:"available,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"` // disabled, suspended, outside_schedule
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BankDetailAvailability) Reset() {
+	*x = BankDetailAvailability{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BankDetailAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BankDetailAvailability) ProtoMessage() {}
+
+func (x *BankDetailAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BankDetailAvailability.ProtoReflect.Descriptor instead.
+func (*BankDetailAvailability) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BankDetailAvailability) GetBankDetailId() string {
+	if x != nil {
+		return x.BankDetailId
+	}
+	return ""
+}
+
+func (x *BankDetailAvailability) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *BankDetailAvailability) GetInSchedule() bool {
+	if x != nil {
+		return x.InSchedule
+	}
+	return false
+}
+
+func (x *BankDetailAvailability) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *BankDetailAvailability) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type TrafficAvailability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrafficId     string                 `protobuf:"bytes,1,opt,name=traffic_id,json=trafficId,proto3" json:"traffic_id,omitempty"`
+	MerchantId    string                 `protobuf:"bytes,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	InSchedule    bool                   `protobuf:"varint,4,opt,name=in_schedule,json=inSchedule,proto3" json:"in_schedule,omitempty"`
+	Available     bool                   `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"` // disabled, причина блокировки, outside_schedule
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrafficAvailability) Reset() {
+	*x = TrafficAvailability{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrafficAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficAvailability) ProtoMessage() {}
+
+func (x *TrafficAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficAvailability.ProtoReflect.Descriptor instead.
+func (*TrafficAvailability) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TrafficAvailability) GetTrafficId() string {
+	if x != nil {
+		return x.TrafficId
+	}
+	return ""
+}
+
+func (x *TrafficAvailability) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *TrafficAvailability) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *TrafficAvailability) GetInSchedule() bool {
+	if x != nil {
+		return x.InSchedule
+	}
+	return false
+}
+
+func (x *TrafficAvailability) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *TrafficAvailability) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetEffectiveAvailabilityResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	TraderId      string                    `protobuf:"bytes,1,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	CheckedAt     *timestamppb.Timestamp    `protobuf:"bytes,2,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	BankDetails   []*BankDetailAvailability `protobuf:"bytes,3,rep,name=bank_details,json=bankDetails,proto3" json:"bank_details,omitempty"`
+	Traffic       []*TrafficAvailability    `protobuf:"bytes,4,rep,name=traffic,proto3" json:"traffic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEffectiveAvailabilityResponse) Reset() {
+	*x = GetEffectiveAvailabilityResponse{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEffectiveAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEffectiveAvailabilityResponse) ProtoMessage() {}
+
+func (x *GetEffectiveAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEffectiveAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*GetEffectiveAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetEffectiveAvailabilityResponse) GetTraderId() string {
+	if x != nil {
+		return x.TraderId
+	}
+	return ""
+}
+
+func (x *GetEffectiveAvailabilityResponse) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *GetEffectiveAvailabilityResponse) GetBankDetails() []*BankDetailAvailability {
+	if x != nil {
+		return x.BankDetails
+	}
+	return nil
+}
+
+func (x *GetEffectiveAvailabilityResponse) GetTraffic() []*TrafficAvailability {
+	if x != nil {
+		return x.Traffic
+	}
+	return nil
+}
+
+type BankDetailHealth struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	BankDetailId       string                 `protobuf:"bytes,1,opt,name=bank_detail_id,json=bankDetailId,proto3" json:"bank_detail_id,omitempty"`
+	TraderId           string                 `protobuf:"bytes,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	ConsecutiveCancels int32                  `protobuf:"varint,3,opt,name=consecutive_cancels,json=consecutiveCancels,proto3" json:"consecutive_cancels,omitempty"`
+	WindowCancels      int32                  `protobuf:"varint,4,opt,name=window_cancels,json=windowCancels,proto3" json:"window_cancels,omitempty"`
+	WindowDisputes     int32                  `protobuf:"varint,5,opt,name=window_disputes,json=windowDisputes,proto3" json:"window_disputes,omitempty"`
+	Suspensions        int32                  `protobuf:"varint,6,opt,name=suspensions,proto3" json:"suspensions,omitempty"`
+	LastCancelAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_cancel_at,json=lastCancelAt,proto3" json:"last_cancel_at,omitempty"`
+	LastDisputeAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_dispute_at,json=lastDisputeAt,proto3" json:"last_dispute_at,omitempty"`
+	LastSuspendedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_suspended_at,json=lastSuspendedAt,proto3" json:"last_suspended_at,omitempty"`
+	ResumedAt          *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=resumed_at,json=resumedAt,proto3" json:"resumed_at,omitempty"`
+	// Не задано - реквизит не приостановлен
+	SuspendedUntil *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=suspended_until,json=suspendedUntil,proto3" json:"suspended_until,omitempty"`
+	SuspendReason  string                 `protobuf:"bytes,12,opt,name=suspend_reason,json=suspendReason,proto3" json:"suspend_reason,omitempty"` // consecutive_cancels, window_cancels, disputes
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BankDetailHealth) Reset() {
+	*x = BankDetailHealth{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BankDetailHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BankDetailHealth) ProtoMessage() {}
+
+func (x *BankDetailHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BankDetailHealth.ProtoReflect.Descriptor instead.
+func (*BankDetailHealth) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BankDetailHealth) GetBankDetailId() string {
+	if x != nil {
+		return x.BankDetailId
+	}
+	return ""
+}
+
+func (x *BankDetailHealth) GetTraderId() string {
+	if x != nil {
+		return x.TraderId
+	}
+	return ""
+}
+
+func (x *BankDetailHealth) GetConsecutiveCancels() int32 {
+	if x != nil {
+		return x.ConsecutiveCancels
+	}
+	return 0
+}
+
+func (x *BankDetailHealth) GetWindowCancels() int32 {
+	if x != nil {
+		return x.WindowCancels
+	}
+	return 0
+}
+
+func (x *BankDetailHealth) GetWindowDisputes() int32 {
+	if x != nil {
+		return x.WindowDisputes
+	}
+	return 0
+}
+
+func (x *BankDetailHealth) GetSuspensions() int32 {
+	if x != nil {
+		return x.Suspensions
+	}
+	return 0
+}
+
+func (x *BankDetailHealth) GetLastCancelAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCancelAt
+	}
+	return nil
+}
+
+func (x *BankDetailHealth) GetLastDisputeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastDisputeAt
+	}
+	return nil
+}
+
+func (x *BankDetailHealth) GetLastSuspendedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSuspendedAt
+	}
+	return nil
+}
+
+func (x *BankDetailHealth) GetResumedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResumedAt
+	}
+	return nil
+}
+
+func (x *BankDetailHealth) GetSuspendedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SuspendedUntil
+	}
+	return nil
+}
+
+func (x *BankDetailHealth) GetSuspendReason() string {
+	if x != nil {
+		return x.SuspendReason
+	}
+	return ""
+}
+
+type GetBankDetailHealthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BankDetailId  string                 `protobuf:"bytes,1,opt,name=bank_detail_id,json=bankDetailId,proto3" json:"bank_detail_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBankDetailHealthRequest) Reset() {
+	*x = GetBankDetailHealthRequest{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBankDetailHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBankDetailHealthRequest) ProtoMessage() {}
+
+func (x *GetBankDetailHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBankDetailHealthRequest.ProtoReflect.Descriptor instead.
+func (*GetBankDetailHealthRequest) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetBankDetailHealthRequest) GetBankDetailId() string {
+	if x != nil {
+		return x.BankDetailId
+	}
+	return ""
+}
+
+type GetBankDetailHealthResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Health        *BankDetailHealth      `protobuf:"bytes,1,opt,name=health,proto3" json:"health,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBankDetailHealthResponse) Reset() {
+	*x = GetBankDetailHealthResponse{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBankDetailHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBankDetailHealthResponse) ProtoMessage() {}
+
+func (x *GetBankDetailHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBankDetailHealthResponse.ProtoReflect.Descriptor instead.
+func (*GetBankDetailHealthResponse) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetBankDetailHealthResponse) GetHealth() *BankDetailHealth {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+type ResumeBankDetailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BankDetailId  string                 `protobuf:"bytes,1,opt,name=bank_detail_id,json=bankDetailId,proto3" json:"bank_detail_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeBankDetailRequest) Reset() {
+	*x = ResumeBankDetailRequest{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeBankDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeBankDetailRequest) ProtoMessage() {}
+
+func (x *ResumeBankDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeBankDetailRequest.ProtoReflect.Descriptor instead.
+func (*ResumeBankDetailRequest) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ResumeBankDetailRequest) GetBankDetailId() string {
+	if x != nil {
+		return x.BankDetailId
+	}
+	return ""
+}
+
+type ResumeBankDetailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Health        *BankDetailHealth      `protobuf:"bytes,1,opt,name=health,proto3" json:"health,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeBankDetailResponse) Reset() {
+	*x = ResumeBankDetailResponse{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeBankDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeBankDetailResponse) ProtoMessage() {}
+
+func (x *ResumeBankDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeBankDetailResponse.ProtoReflect.Descriptor instead.
+func (*ResumeBankDetailResponse) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ResumeBankDetailResponse) GetHealth() *BankDetailHealth {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+type BinRecord struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Prefix         string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	BankName       string                 `protobuf:"bytes,2,opt,name=bank_name,json=bankName,proto3" json:"bank_name,omitempty"`
+	BankCode       string                 `protobuf:"bytes,3,opt,name=bank_code,json=bankCode,proto3" json:"bank_code,omitempty"`
+	NspkCode       string                 `protobuf:"bytes,4,opt,name=nspk_code,json=nspkCode,proto3" json:"nspk_code,omitempty"`
+	PaymentNetwork string                 `protobuf:"bytes,5,opt,name=payment_network,json=paymentNetwork,proto3" json:"payment_network,omitempty"`
+	Country        string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BinRecord) Reset() {
+	*x = BinRecord{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BinRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BinRecord) ProtoMessage() {}
+
+func (x *BinRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BinRecord.ProtoReflect.Descriptor instead.
+func (*BinRecord) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BinRecord) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *BinRecord) GetBankName() string {
+	if x != nil {
+		return x.BankName
+	}
+	return ""
+}
+
+func (x *BinRecord) GetBankCode() string {
+	if x != nil {
+		return x.BankCode
+	}
+	return ""
+}
+
+func (x *BinRecord) GetNspkCode() string {
+	if x != nil {
+		return x.NspkCode
+	}
+	return ""
+}
+
+func (x *BinRecord) GetPaymentNetwork() string {
+	if x != nil {
+		return x.PaymentNetwork
+	}
+	return ""
+}
+
+func (x *BinRecord) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+type LookupBinRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Номер карты или его первые цифры, не меньше 6
+	CardNumber    string `protobuf:"bytes,1,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupBinRequest) Reset() {
+	*x = LookupBinRequest{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupBinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupBinRequest) ProtoMessage() {}
+
+func (x *LookupBinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupBinRequest.ProtoReflect.Descriptor instead.
+func (*LookupBinRequest) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *LookupBinRequest) GetCardNumber() string {
+	if x != nil {
+		return x.CardNumber
+	}
+	return ""
+}
+
+type LookupBinResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Bin           *BinRecord             `protobuf:"bytes,2,opt,name=bin,proto3" json:"bin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupBinResponse) Reset() {
+	*x = LookupBinResponse{}
+	mi := &file_order_bank_detail_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupBinResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupBinResponse) ProtoMessage() {}
+
+func (x *LookupBinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_bank_detail_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupBinResponse.ProtoReflect.Descriptor instead.
+func (*LookupBinResponse) Descriptor() ([]byte, []int) {
+	return file_order_bank_detail_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *LookupBinResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *LookupBinResponse) GetBin() *BinRecord {
+	if x != nil {
+		return x.Bin
+	}
+	return nil
+}
+
+var File_order_bank_detail_service_proto protoreflect.FileDescriptor
+
+const file_order_bank_detail_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1forder/bank_detail_service.proto\x12\x05order\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18order/common_types.proto\"\x87\b\n" +
+	"\n" +
+	"BankDetail\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\tR\btraderId\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x18\n" +
+	"\acountry\x18\x04 \x01(\tR\acountry\x12\x1d\n" +
+	"\n" +
+	"min_amount\x18\x05 \x01(\x01R\tminAmount\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\x06 \x01(\x01R\tmaxAmount\x12\x1b\n" +
+	"\tbank_name\x18\a \x01(\tR\bbankName\x12%\n" +
+	"\x0epayment_system\x18\b \x01(\tR\rpaymentSystem\x12\x18\n" +
+	"\aenabled\x18\t \x01(\bR\aenabled\x12/\n" +
+	"\x05delay\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationR\x05delay\x12\x1f\n" +
+	"\vcard_number\x18\v \x01(\tR\n" +
+	"cardNumber\x12\x14\n" +
+	"\x05phone\x18\f \x01(\tR\x05phone\x12\x14\n" +
+	"\x05owner\x18\r \x01(\tR\x05owner\x128\n" +
+	"\x18max_orders_simultaneosly\x18\x0e \x01(\x05R\x16maxOrdersSimultaneosly\x12$\n" +
+	"\x0emax_amount_day\x18\x0f \x01(\x01R\fmaxAmountDay\x12(\n" +
+	"\x10max_amount_month\x18\x10 \x01(\x01R\x0emaxAmountMonth\x12(\n" +
+	"\x10max_quantity_day\x18\x11 \x01(\x01R\x0emaxQuantityDay\x12,\n" +
+	"\x12max_quantity_month\x18\x12 \x01(\x01R\x10maxQuantityMonth\x12\x1b\n" +
+	"\tdevice_id\x18\x13 \x01(\tR\bdeviceId\x12'\n" +
+	"\x0finflow_currency\x18\x14 \x01(\tR\x0einflowCurrency\x12\x1b\n" +
+	"\tbank_code\x18\x15 \x01(\tR\bbankCode\x12\x1b\n" +
+	"\tnspk_code\x18\x16 \x01(\tR\bnspkCode\x12&\n" +
+	"\x0fmax_amount_hour\x18\x17 \x01(\x01R\rmaxAmountHour\x12*\n" +
+	"\x11max_quantity_hour\x18\x18 \x01(\x05R\x0fmaxQuantityHour\x12&\n" +
+	"\x0fmax_amount_week\x18\x19 \x01(\x01R\rmaxAmountWeek\x12*\n" +
+	"\x11max_quantity_week\x18\x1a \x01(\x05R\x0fmaxQuantityWeek\x12\x1d\n" +
+	"\n" +
+	"limit_mode\x18\x1b \x01(\tR\tlimitMode\x12\x1a\n" +
+	"\btimezone\x18\x1c \x01(\tR\btimezone\x12!\n" +
+	"\fbin_mismatch\x18\x1d \x01(\bR\vbinMismatch\"\xcb\a\n" +
+	"\x17CreateBankDetailRequest\x12\x1b\n" +
+	"\ttrader_id\x18\x01 \x01(\tR\btraderId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x1d\n" +
+	"\n" +
+	"min_amount\x18\x04 \x01(\x01R\tminAmount\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\x05 \x01(\x01R\tmaxAmount\x12\x1b\n" +
+	"\tbank_name\x18\x06 \x01(\tR\bbankName\x12%\n" +
+	"\x0epayment_system\x18\a \x01(\tR\rpaymentSystem\x12\x18\n" +
+	"\aenabled\x18\b \x01(\bR\aenabled\x12/\n" +
+	"\x05delay\x18\t \x01(\v2\x19.google.protobuf.DurationR\x05delay\x12\x1f\n" +
+	"\vcard_number\x18\n" +
+	" \x01(\tR\n" +
+	"cardNumber\x12\x14\n" +
+	"\x05phone\x18\v \x01(\tR\x05phone\x12\x14\n" +
+	"\x05owner\x18\f \x01(\tR\x05owner\x128\n" +
+	"\x18max_orders_simultaneosly\x18\r \x01(\x05R\x16maxOrdersSimultaneosly\x12$\n" +
+	"\x0emax_amount_day\x18\x0e \x01(\x01R\fmaxAmountDay\x12(\n" +
+	"\x10max_amount_month\x18\x0f \x01(\x01R\x0emaxAmountMonth\x12(\n" +
+	"\x10max_quantity_day\x18\x10 \x01(\x01R\x0emaxQuantityDay\x12,\n" +
+	"\x12max_quantity_month\x18\x11 \x01(\x01R\x10maxQuantityMonth\x12\x1b\n" +
+	"\tdevice_id\x18\x12 \x01(\tR\bdeviceId\x12'\n" +
+	"\x0finflow_currency\x18\x13 \x01(\tR\x0einflowCurrency\x12\x1b\n" +
+	"\tbank_code\x18\x14 \x01(\tR\bbankCode\x12\x1b\n" +
+	"\tnspk_code\x18\x15 \x01(\tR\bnspkCode\x12&\n" +
+	"\x0fmax_amount_hour\x18\x16 \x01(\x01R\rmaxAmountHour\x12*\n" +
+	"\x11max_quantity_hour\x18\x17 \x01(\x05R\x0fmaxQuantityHour\x12&\n" +
+	"\x0fmax_amount_week\x18\x18 \x01(\x01R\rmaxAmountWeek\x12*\n" +
+	"\x11max_quantity_week\x18\x19 \x01(\x05R\x0fmaxQuantityWeek\x12\x1d\n" +
+	"\n" +
+	"limit_mode\x18\x1a \x01(\tR\tlimitMode\x12\x1a\n" +
+	"\btimezone\x18\x1b \x01(\tR\btimezone\"@\n" +
+	"\x18CreateBankDetailResponse\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\"M\n" +
+	"\x17UpdateBankDetailRequest\x122\n" +
+	"\vbank_detail\x18\x01 \x01(\v2\x11.order.BankDetailR\n" +
+	"bankDetail\"\x1a\n" +
+	"\x18UpdateBankDetailResponse\"@\n" +
+	"\x18GetBankDetailByIDRequest\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\"O\n" +
+	"\x19GetBankDetailByIDResponse\x122\n" +
+	"\vbank_detail\x18\x01 \x01(\v2\x11.order.BankDetailR\n" +
+	"bankDetail\"?\n" +
+	"\x17DeleteBankDetailRequest\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\"\x1a\n" +
+	"\x18DeleteBankDetailResponse\"\xcf\x01\n" +
+	"\x1fGetBankDetailsByTraderIDRequest\x12\x1b\n" +
+	"\ttrader_id\x18\x01 \x01(\tR\btraderId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x17\n" +
+	"\asort_by\x18\x04 \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x05 \x01(\tR\tsortOrder\x12-\n" +
+	"\afilters\x18\x06 \x01(\v2\x13.order.OrderFiltersR\afilters\"\x8b\x01\n" +
+	" GetBankDetailsByTraderIDResponse\x124\n" +
+	"\fbank_details\x18\x01 \x03(\v2\x11.order.BankDetailR\vbankDetails\x121\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x11.order.PaginationR\n" +
+	"pagination\"\xfa\x01\n" +
+	"\x0eBankDetailStat\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\x12.\n" +
+	"\x13current_count_today\x18\x02 \x01(\x05R\x11currentCountToday\x12.\n" +
+	"\x13current_count_month\x18\x03 \x01(\x05R\x11currentCountMonth\x120\n" +
+	"\x14current_amount_today\x18\x04 \x01(\x01R\x12currentAmountToday\x120\n" +
+	"\x14current_amount_month\x18\x05 \x01(\x01R\x12currentAmountMonth\"C\n" +
+	"$GetBankDetailsStatsByTraderIDRequest\x12\x1b\n" +
+	"\ttrader_id\x18\x01 \x01(\tR\btraderId\"h\n" +
+	"%GetBankDetailsStatsByTraderIDResponse\x12?\n" +
+	"\x10bank_detail_stat\x18\x01 \x03(\v2\x15.order.BankDetailStatR\x0ebankDetailStat\"\xc9\x02\n" +
+	"\x15GetBankDetailsRequest\x12 \n" +
+	"\ttrader_id\x18\x01 \x01(\tH\x00R\btraderId\x88\x01\x01\x12 \n" +
+	"\tbank_code\x18\x02 \x01(\tH\x01R\bbankCode\x88\x01\x01\x12\x1d\n" +
+	"\aenabled\x18\x03 \x01(\bH\x02R\aenabled\x88\x01\x01\x12*\n" +
+	"\x0epayment_system\x18\x04 \x01(\tH\x03R\rpaymentSystem\x88\x01\x01\x12)\n" +
+	"\x0ebank_detail_id\x18\x05 \x01(\tH\x04R\fbankDetailId\x88\x01\x01\x12\x12\n" +
+	"\x04page\x18\x06 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limitB\f\n" +
+	"\n" +
+	"_trader_idB\f\n" +
+	"\n" +
+	"_bank_codeB\n" +
+	"\n" +
+	"\b_enabledB\x11\n" +
+	"\x0f_payment_systemB\x11\n" +
+	"\x0f_bank_detail_id\"\x81\x01\n" +
+	"\x16GetBankDetailsResponse\x124\n" +
+	"\fbank_details\x18\x01 \x03(\v2\x11.order.BankDetailR\vbankDetails\x121\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x11.order.PaginationR\n" +
+	"pagination\"q\n" +
+	"\x1cSetBankDetailScheduleRequest\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\x12+\n" +
+	"\bschedule\x18\x02 \x01(\v2\x0f.order.ScheduleR\bschedule\"\x1f\n" +
+	"\x1dSetBankDetailScheduleResponse\"D\n" +
+	"\x1cGetBankDetailScheduleRequest\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\"L\n" +
+	"\x1dGetBankDetailScheduleResponse\x12+\n" +
+	"\bschedule\x18\x01 \x01(\v2\x0f.order.ScheduleR\bschedule\"G\n" +
+	"\x1fDeleteBankDetailScheduleRequest\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\"\"\n" +
+	" DeleteBankDetailScheduleResponse\">\n" +
+	"\x1fGetEffectiveAvailabilityRequest\x12\x1b\n" +
+	"\ttrader_id\x18\x01 \x01(\tR\btraderId\"\xaf\x01\n" +
+	"\x16BankDetailAvailability\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x1f\n" +
+	"\vin_schedule\x18\x03 \x01(\bR\n" +
+	"inSchedule\x12\x1c\n" +
+	"\tavailable\x18\x04 \x01(\bR\tavailable\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\xc6\x01\n" +
+	"\x13TrafficAvailability\x12\x1d\n" +
+	"\n" +
+	"traffic_id\x18\x01 \x01(\tR\ttrafficId\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\tR\n" +
+	"merchantId\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12\x1f\n" +
+	"\vin_schedule\x18\x04 \x01(\bR\n" +
+	"inSchedule\x12\x1c\n" +
+	"\tavailable\x18\x05 \x01(\bR\tavailable\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\"\xf2\x01\n" +
+	" GetEffectiveAvailabilityResponse\x12\x1b\n" +
+	"\ttrader_id\x18\x01 \x01(\tR\btraderId\x129\n" +
+	"\n" +
+	"checked_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\x12@\n" +
+	"\fbank_details\x18\x03 \x03(\v2\x1d.order.BankDetailAvailabilityR\vbankDetails\x124\n" +
+	"\atraffic\x18\x04 \x03(\v2\x1a.order.TrafficAvailabilityR\atraffic\"\xed\x04\n" +
+	"\x10BankDetailHealth\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\tR\btraderId\x12/\n" +
+	"\x13consecutive_cancels\x18\x03 \x01(\x05R\x12consecutiveCancels\x12%\n" +
+	"\x0ewindow_cancels\x18\x04 \x01(\x05R\rwindowCancels\x12'\n" +
+	"\x0fwindow_disputes\x18\x05 \x01(\x05R\x0ewindowDisputes\x12 \n" +
+	"\vsuspensions\x18\x06 \x01(\x05R\vsuspensions\x12@\n" +
+	"\x0elast_cancel_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\flastCancelAt\x12B\n" +
+	"\x0flast_dispute_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rlastDisputeAt\x12F\n" +
+	"\x11last_suspended_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0flastSuspendedAt\x129\n" +
+	"\n" +
+	"resumed_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tresumedAt\x12C\n" +
+	"\x0fsuspended_until\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0esuspendedUntil\x12%\n" +
+	"\x0esuspend_reason\x18\f \x01(\tR\rsuspendReason\"B\n" +
+	"\x1aGetBankDetailHealthRequest\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\"N\n" +
+	"\x1bGetBankDetailHealthResponse\x12/\n" +
+	"\x06health\x18\x01 \x01(\v2\x17.order.BankDetailHealthR\x06health\"?\n" +
+	"\x17ResumeBankDetailRequest\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\"K\n" +
+	"\x18ResumeBankDetailResponse\x12/\n" +
+	"\x06health\x18\x01 \x01(\v2\x17.order.BankDetailHealthR\x06health\"\xbd\x01\n" +
+	"\tBinRecord\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x1b\n" +
+	"\tbank_name\x18\x02 \x01(\tR\bbankName\x12\x1b\n" +
+	"\tbank_code\x18\x03 \x01(\tR\bbankCode\x12\x1b\n" +
+	"\tnspk_code\x18\x04 \x01(\tR\bnspkCode\x12'\n" +
+	"\x0fpayment_network\x18\x05 \x01(\tR\x0epaymentNetwork\x12\x18\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\"3\n" +
+	"\x10LookupBinRequest\x12\x1f\n" +
+	"\vcard_number\x18\x01 \x01(\tR\n" +
+	"cardNumber\"M\n" +
+	"\x11LookupBinResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\"\n" +
+	"\x03bin\x18\x02 \x01(\v2\x10.order.BinRecordR\x03bin2\xb7\n" +
+	"\n" +
 	"\x11BankDetailService\x12S\n" +
 	"\x10CreateBankDetail\x12\x1e.order.CreateBankDetailRequest\x1a\x1f.order.CreateBankDetailResponse\x12S\n" +
 	"\x10UpdateBankDetail\x12\x1e.order.UpdateBankDetailRequest\x1a\x1f.order.UpdateBankDetailResponse\x12S\n" +
@@ -1298,7 +2513,14 @@ const file_order_bank_detail_service_proto_rawDesc = "" +
 	"\x11GetBankDetailByID\x12\x1f.order.GetBankDetailByIDRequest\x1a .order.GetBankDetailByIDResponse\x12k\n" +
 	"\x18GetBankDetailsByTraderID\x12&.order.GetBankDetailsByTraderIDRequest\x1a'.order.GetBankDetailsByTraderIDResponse\x12z\n" +
 	"\x1dGetBankDetailsStatsByTraderID\x12+.order.GetBankDetailsStatsByTraderIDRequest\x1a,.order.GetBankDetailsStatsByTraderIDResponse\x12M\n" +
-	"\x0eGetBankDetails\x12\x1c.order.GetBankDetailsRequest\x1a\x1d.order.GetBankDetailsResponseB=Z;github.com/LavaJover/shvark-order-service/proto/gen;orderpbb\x06proto3"
+	"\x0eGetBankDetails\x12\x1c.order.GetBankDetailsRequest\x1a\x1d.order.GetBankDetailsResponse\x12b\n" +
+	"\x15SetBankDetailSchedule\x12#.order.SetBankDetailScheduleRequest\x1a$.order.SetBankDetailScheduleResponse\x12b\n" +
+	"\x15GetBankDetailSchedule\x12#.order.GetBankDetailScheduleRequest\x1a$.order.GetBankDetailScheduleResponse\x12k\n" +
+	"\x18DeleteBankDetailSchedule\x12&.order.DeleteBankDetailScheduleRequest\x1a'.order.DeleteBankDetailScheduleResponse\x12k\n" +
+	"\x18GetEffectiveAvailability\x12&.order.GetEffectiveAvailabilityRequest\x1a'.order.GetEffectiveAvailabilityResponse\x12\\\n" +
+	"\x13GetBankDetailHealth\x12!.order.GetBankDetailHealthRequest\x1a\".order.GetBankDetailHealthResponse\x12S\n" +
+	"\x10ResumeBankDetail\x12\x1e.order.ResumeBankDetailRequest\x1a\x1f.order.ResumeBankDetailResponse\x12>\n" +
+	"\tLookupBin\x12\x17.order.LookupBinRequest\x1a\x18.order.LookupBinResponseB=Z;github.com/LavaJover/shvark-order-service/proto/gen;orderpbb\x06proto3"
 
 var (
 	file_order_bank_detail_service_proto_rawDescOnce sync.Once
@@ -1312,7 +2534,7 @@ func file_order_bank_detail_service_proto_rawDescGZIP() []byte {
 	return file_order_bank_detail_service_proto_rawDescData
 }
 
-var file_order_bank_detail_service_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_order_bank_detail_service_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_order_bank_detail_service_proto_goTypes = []any{
 	(*BankDetail)(nil),                            // 0: order.BankDetail
 	(*CreateBankDetailRequest)(nil),               // 1: order.CreateBankDetailRequest
@@ -1330,40 +2552,87 @@ var file_order_bank_detail_service_proto_goTypes = []any{
 	(*GetBankDetailsStatsByTraderIDResponse)(nil), // 13: order.GetBankDetailsStatsByTraderIDResponse
 	(*GetBankDetailsRequest)(nil),                 // 14: order.GetBankDetailsRequest
 	(*GetBankDetailsResponse)(nil),                // 15: order.GetBankDetailsResponse
-	(*durationpb.Duration)(nil),                   // 16: google.protobuf.Duration
-	(*OrderFilters)(nil),                          // 17: order.OrderFilters
-	(*Pagination)(nil),                            // 18: order.Pagination
+	(*SetBankDetailScheduleRequest)(nil),          // 16: order.SetBankDetailScheduleRequest
+	(*SetBankDetailScheduleResponse)(nil),         // 17: order.SetBankDetailScheduleResponse
+	(*GetBankDetailScheduleRequest)(nil),          // 18: order.GetBankDetailScheduleRequest
+	(*GetBankDetailScheduleResponse)(nil),         // 19: order.GetBankDetailScheduleResponse
+	(*DeleteBankDetailScheduleRequest)(nil),       // 20: order.DeleteBankDetailScheduleRequest
+	(*DeleteBankDetailScheduleResponse)(nil),      // 21: order.DeleteBankDetailScheduleResponse
+	(*GetEffectiveAvailabilityRequest)(nil),       // 22: order.GetEffectiveAvailabilityRequest
+	(*BankDetailAvailability)(nil),                // 23: order.BankDetailAvailability
+	(*TrafficAvailability)(nil),                   // 24: order.TrafficAvailability
+	(*GetEffectiveAvailabilityResponse)(nil),      // 25: order.GetEffectiveAvailabilityResponse
+	(*BankDetailHealth)(nil),                      // 26: order.BankDetailHealth
+	(*GetBankDetailHealthRequest)(nil),            // 27: order.GetBankDetailHealthRequest
+	(*GetBankDetailHealthResponse)(nil),           // 28: order.GetBankDetailHealthResponse
+	(*ResumeBankDetailRequest)(nil),               // 29: order.ResumeBankDetailRequest
+	(*ResumeBankDetailResponse)(nil),              // 30: order.ResumeBankDetailResponse
+	(*BinRecord)(nil),                             // 31: order.BinRecord
+	(*LookupBinRequest)(nil),                      // 32: order.LookupBinRequest
+	(*LookupBinResponse)(nil),                     // 33: order.LookupBinResponse
+	(*durationpb.Duration)(nil),                   // 34: google.protobuf.Duration
+	(*OrderFilters)(nil),                          // 35: order.OrderFilters
+	(*Pagination)(nil),                            // 36: order.Pagination
+	(*Schedule)(nil),                              // 37: order.Schedule
+	(*timestamppb.Timestamp)(nil),                 // 38: google.protobuf.Timestamp
 }
 var file_order_bank_detail_service_proto_depIdxs = []int32{
-	16, // 0: order.BankDetail.delay:type_name -> google.protobuf.Duration
-	16, // 1: order.CreateBankDetailRequest.delay:type_name -> google.protobuf.Duration
+	34, // 0: order.BankDetail.delay:type_name -> google.protobuf.Duration
+	34, // 1: order.CreateBankDetailRequest.delay:type_name -> google.protobuf.Duration
 	0,  // 2: order.UpdateBankDetailRequest.bank_detail:type_name -> order.BankDetail
 	0,  // 3: order.GetBankDetailByIDResponse.bank_detail:type_name -> order.BankDetail
-	17, // 4: order.GetBankDetailsByTraderIDRequest.filters:type_name -> order.OrderFilters
+	35, // 4: order.GetBankDetailsByTraderIDRequest.filters:type_name -> order.OrderFilters
 	0,  // 5: order.GetBankDetailsByTraderIDResponse.bank_details:type_name -> order.BankDetail
-	18, // 6: order.GetBankDetailsByTraderIDResponse.pagination:type_name -> order.Pagination
+	36, // 6: order.GetBankDetailsByTraderIDResponse.pagination:type_name -> order.Pagination
 	11, // 7: order.GetBankDetailsStatsByTraderIDResponse.bank_detail_stat:type_name -> order.BankDetailStat
 	0,  // 8: order.GetBankDetailsResponse.bank_details:type_name -> order.BankDetail
-	18, // 9: order.GetBankDetailsResponse.pagination:type_name -> order.Pagination
-	1,  // 10: order.BankDetailService.CreateBankDetail:input_type -> order.CreateBankDetailRequest
-	3,  // 11: order.BankDetailService.UpdateBankDetail:input_type -> order.UpdateBankDetailRequest
-	7,  // 12: order.BankDetailService.DeleteBankDetail:input_type -> order.DeleteBankDetailRequest
-	5,  // 13: order.BankDetailService.GetBankDetailByID:input_type -> order.GetBankDetailByIDRequest
-	9,  // 14: order.BankDetailService.GetBankDetailsByTraderID:input_type -> order.GetBankDetailsByTraderIDRequest
-	12, // 15: order.BankDetailService.GetBankDetailsStatsByTraderID:input_type -> order.GetBankDetailsStatsByTraderIDRequest
-	14, // 16: order.BankDetailService.GetBankDetails:input_type -> order.GetBankDetailsRequest
-	2,  // 17: order.BankDetailService.CreateBankDetail:output_type -> order.CreateBankDetailResponse
-	4,  // 18: order.BankDetailService.UpdateBankDetail:output_type -> order.UpdateBankDetailResponse
-	8,  // 19: order.BankDetailService.DeleteBankDetail:output_type -> order.DeleteBankDetailResponse
-	6,  // 20: order.BankDetailService.GetBankDetailByID:output_type -> order.GetBankDetailByIDResponse
-	10, // 21: order.BankDetailService.GetBankDetailsByTraderID:output_type -> order.GetBankDetailsByTraderIDResponse
-	13, // 22: order.BankDetailService.GetBankDetailsStatsByTraderID:output_type -> order.GetBankDetailsStatsByTraderIDResponse
-	15, // 23: order.BankDetailService.GetBankDetails:output_type -> order.GetBankDetailsResponse
-	17, // [17:24] is the sub-list for method output_type
-	10, // [10:17] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	36, // 9: order.GetBankDetailsResponse.pagination:type_name -> order.Pagination
+	37, // 10: order.SetBankDetailScheduleRequest.schedule:type_name -> order.Schedule
+	37, // 11: order.GetBankDetailScheduleResponse.schedule:type_name -> order.Schedule
+	38, // 12: order.GetEffectiveAvailabilityResponse.checked_at:type_name -> google.protobuf.Timestamp
+	23, // 13: order.GetEffectiveAvailabilityResponse.bank_details:type_name -> order.BankDetailAvailability
+	24, // 14: order.GetEffectiveAvailabilityResponse.traffic:type_name -> order.TrafficAvailability
+	38, // 15: order.BankDetailHealth.last_cancel_at:type_name -> google.protobuf.Timestamp
+	38, // 16: order.BankDetailHealth.last_dispute_at:type_name -> google.protobuf.Timestamp
+	38, // 17: order.BankDetailHealth.last_suspended_at:type_name -> google.protobuf.Timestamp
+	38, // 18: order.BankDetailHealth.resumed_at:type_name -> google.protobuf.Timestamp
+	38, // 19: order.BankDetailHealth.suspended_until:type_name -> google.protobuf.Timestamp
+	26, // 20: order.GetBankDetailHealthResponse.health:type_name -> order.BankDetailHealth
+	26, // 21: order.ResumeBankDetailResponse.health:type_name -> order.BankDetailHealth
+	31, // 22: order.LookupBinResponse.bin:type_name -> order.BinRecord
+	1,  // 23: order.BankDetailService.CreateBankDetail:input_type -> order.CreateBankDetailRequest
+	3,  // 24: order.BankDetailService.UpdateBankDetail:input_type -> order.UpdateBankDetailRequest
+	7,  // 25: order.BankDetailService.DeleteBankDetail:input_type -> order.DeleteBankDetailRequest
+	5,  // 26: order.BankDetailService.GetBankDetailByID:input_type -> order.GetBankDetailByIDRequest
+	9,  // 27: order.BankDetailService.GetBankDetailsByTraderID:input_type -> order.GetBankDetailsByTraderIDRequest
+	12, // 28: order.BankDetailService.GetBankDetailsStatsByTraderID:input_type -> order.GetBankDetailsStatsByTraderIDRequest
+	14, // 29: order.BankDetailService.GetBankDetails:input_type -> order.GetBankDetailsRequest
+	16, // 30: order.BankDetailService.SetBankDetailSchedule:input_type -> order.SetBankDetailScheduleRequest
+	18, // 31: order.BankDetailService.GetBankDetailSchedule:input_type -> order.GetBankDetailScheduleRequest
+	20, // 32: order.BankDetailService.DeleteBankDetailSchedule:input_type -> order.DeleteBankDetailScheduleRequest
+	22, // 33: order.BankDetailService.GetEffectiveAvailability:input_type -> order.GetEffectiveAvailabilityRequest
+	27, // 34: order.BankDetailService.GetBankDetailHealth:input_type -> order.GetBankDetailHealthRequest
+	29, // 35: order.BankDetailService.ResumeBankDetail:input_type -> order.ResumeBankDetailRequest
+	32, // 36: order.BankDetailService.LookupBin:input_type -> order.LookupBinRequest
+	2,  // 37: order.BankDetailService.CreateBankDetail:output_type -> order.CreateBankDetailResponse
+	4,  // 38: order.BankDetailService.UpdateBankDetail:output_type -> order.UpdateBankDetailResponse
+	8,  // 39: order.BankDetailService.DeleteBankDetail:output_type -> order.DeleteBankDetailResponse
+	6,  // 40: order.BankDetailService.GetBankDetailByID:output_type -> order.GetBankDetailByIDResponse
+	10, // 41: order.BankDetailService.GetBankDetailsByTraderID:output_type -> order.GetBankDetailsByTraderIDResponse
+	13, // 42: order.BankDetailService.GetBankDetailsStatsByTraderID:output_type -> order.GetBankDetailsStatsByTraderIDResponse
+	15, // 43: order.BankDetailService.GetBankDetails:output_type -> order.GetBankDetailsResponse
+	17, // 44: order.BankDetailService.SetBankDetailSchedule:output_type -> order.SetBankDetailScheduleResponse
+	19, // 45: order.BankDetailService.GetBankDetailSchedule:output_type -> order.GetBankDetailScheduleResponse
+	21, // 46: order.BankDetailService.DeleteBankDetailSchedule:output_type -> order.DeleteBankDetailScheduleResponse
+	25, // 47: order.BankDetailService.GetEffectiveAvailability:output_type -> order.GetEffectiveAvailabilityResponse
+	28, // 48: order.BankDetailService.GetBankDetailHealth:output_type -> order.GetBankDetailHealthResponse
+	30, // 49: order.BankDetailService.ResumeBankDetail:output_type -> order.ResumeBankDetailResponse
+	33, // 50: order.BankDetailService.LookupBin:output_type -> order.LookupBinResponse
+	37, // [37:51] is the sub-list for method output_type
+	23, // [23:37] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_order_bank_detail_service_proto_init() }
@@ -1379,7 +2648,7 @@ func file_order_bank_detail_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_bank_detail_service_proto_rawDesc), len(file_order_bank_detail_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,6 +26,13 @@ const (
 	BankDetailService_GetBankDetailsByTraderID_FullMethodName      = "/order.BankDetailService/GetBankDetailsByTraderID"
 	BankDetailService_GetBankDetailsStatsByTraderID_FullMethodName = "/order.BankDetailService/GetBankDetailsStatsByTraderID"
 	BankDetailService_GetBankDetails_FullMethodName                = "/order.BankDetailService/GetBankDetails"
+	BankDetailService_SetBankDetailSchedule_FullMethodName         = "/order.BankDetailService/SetBankDetailSchedule"
+	BankDetailService_GetBankDetailSchedule_FullMethodName         = "/order.BankDetailService/GetBankDetailSchedule"
+	BankDetailService_DeleteBankDetailSchedule_FullMethodName      = "/order.BankDetailService/DeleteBankDetailSchedule"
+	BankDetailService_GetEffectiveAvailability_FullMethodName      = "/order.BankDetailService/GetEffectiveAvailability"
+	BankDetailService_GetBankDetailHealth_FullMethodName           = "/order.BankDetailService/GetBankDetailHealth"
+	BankDetailService_ResumeBankDetail_FullMethodName              = "/order.BankDetailService/ResumeBankDetail"
+	BankDetailService_LookupBin_FullMethodName                     = "/order.BankDetailService/LookupBin"
 )
 
 // BankDetailServiceClient is the client API for BankDetailService service.
@@ -39,6 +46,17 @@ type BankDetailServiceClient interface {
 	GetBankDetailsByTraderID(ctx context.Context, in *GetBankDetailsByTraderIDRequest, opts ...grpc.CallOption) (*GetBankDetailsByTraderIDResponse, error)
 	GetBankDetailsStatsByTraderID(ctx context.Context, in *GetBankDetailsStatsByTraderIDRequest, opts ...grpc.CallOption) (*GetBankDetailsStatsByTraderIDResponse, error)
 	GetBankDetails(ctx context.Context, in *GetBankDetailsRequest, opts ...grpc.CallOption) (*GetBankDetailsResponse, error)
+	// Расписание работы реквизита
+	SetBankDetailSchedule(ctx context.Context, in *SetBankDetailScheduleRequest, opts ...grpc.CallOption) (*SetBankDetailScheduleResponse, error)
+	GetBankDetailSchedule(ctx context.Context, in *GetBankDetailScheduleRequest, opts ...grpc.CallOption) (*GetBankDetailScheduleResponse, error)
+	DeleteBankDetailSchedule(ctx context.Context, in *DeleteBankDetailScheduleRequest, opts ...grpc.CallOption) (*DeleteBankDetailScheduleResponse, error)
+	// Что из реквизитов и трафика трейдера участвует в подборе прямо сейчас
+	GetEffectiveAvailability(ctx context.Context, in *GetEffectiveAvailabilityRequest, opts ...grpc.CallOption) (*GetEffectiveAvailabilityResponse, error)
+	// Здоровье реквизита и ручное снятие автоматической приостановки
+	GetBankDetailHealth(ctx context.Context, in *GetBankDetailHealthRequest, opts ...grpc.CallOption) (*GetBankDetailHealthResponse, error)
+	ResumeBankDetail(ctx context.Context, in *ResumeBankDetailRequest, opts ...grpc.CallOption) (*ResumeBankDetailResponse, error)
+	// Банк-эмитент по номеру карты из локального справочника BIN
+	LookupBin(ctx context.Context, in *LookupBinRequest, opts ...grpc.CallOption) (*LookupBinResponse, error)
 }
 
 type bankDetailServiceClient struct {
@@ -119,6 +137,76 @@ func (c *bankDetailServiceClient) GetBankDetails(ctx context.Context, in *GetBan
 	return out, nil
 }
 
+func (c *bankDetailServiceClient) SetBankDetailSchedule(ctx context.Context, in *SetBankDetailScheduleRequest, opts ...grpc.CallOption) (*SetBankDetailScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBankDetailScheduleResponse)
+	err := c.cc.Invoke(ctx, BankDetailService_SetBankDetailSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankDetailServiceClient) GetBankDetailSchedule(ctx context.Context, in *GetBankDetailScheduleRequest, opts ...grpc.CallOption) (*GetBankDetailScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBankDetailScheduleResponse)
+	err := c.cc.Invoke(ctx, BankDetailService_GetBankDetailSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankDetailServiceClient) DeleteBankDetailSchedule(ctx context.Context, in *DeleteBankDetailScheduleRequest, opts ...grpc.CallOption) (*DeleteBankDetailScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBankDetailScheduleResponse)
+	err := c.cc.Invoke(ctx, BankDetailService_DeleteBankDetailSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankDetailServiceClient) GetEffectiveAvailability(ctx context.Context, in *GetEffectiveAvailabilityRequest, opts ...grpc.CallOption) (*GetEffectiveAvailabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEffectiveAvailabilityResponse)
+	err := c.cc.Invoke(ctx, BankDetailService_GetEffectiveAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankDetailServiceClient) GetBankDetailHealth(ctx context.Context, in *GetBankDetailHealthRequest, opts ...grpc.CallOption) (*GetBankDetailHealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBankDetailHealthResponse)
+	err := c.cc.Invoke(ctx, BankDetailService_GetBankDetailHealth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankDetailServiceClient) ResumeBankDetail(ctx context.Context, in *ResumeBankDetailRequest, opts ...grpc.CallOption) (*ResumeBankDetailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeBankDetailResponse)
+	err := c.cc.Invoke(ctx, BankDetailService_ResumeBankDetail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankDetailServiceClient) LookupBin(ctx context.Context, in *LookupBinRequest, opts ...grpc.CallOption) (*LookupBinResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupBinResponse)
+	err := c.cc.Invoke(ctx, BankDetailService_LookupBin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BankDetailServiceServer is the server API for BankDetailService service.
 // All implementations must embed UnimplementedBankDetailServiceServer
 // for forward compatibility.
@@ -130,6 +218,17 @@ type BankDetailServiceServer interface {
 	GetBankDetailsByTraderID(context.Context, *GetBankDetailsByTraderIDRequest) (*GetBankDetailsByTraderIDResponse, error)
 	GetBankDetailsStatsByTraderID(context.Context, *GetBankDetailsStatsByTraderIDRequest) (*GetBankDetailsStatsByTraderIDResponse, error)
 	GetBankDetails(context.Context, *GetBankDetailsRequest) (*GetBankDetailsResponse, error)
+	// Расписание работы реквизита
+	SetBankDetailSchedule(context.Context, *SetBankDetailScheduleRequest) (*SetBankDetailScheduleResponse, error)
+	GetBankDetailSchedule(context.Context, *GetBankDetailScheduleRequest) (*GetBankDetailScheduleResponse, error)
+	DeleteBankDetailSchedule(context.Context, *DeleteBankDetailScheduleRequest) (*DeleteBankDetailScheduleResponse, error)
+	// Что из реквизитов и трафика трейдера участвует в подборе прямо сейчас
+	GetEffectiveAvailability(context.Context, *GetEffectiveAvailabilityRequest) (*GetEffectiveAvailabilityResponse, error)
+	// Здоровье реквизита и ручное снятие автоматической приостановки
+	GetBankDetailHealth(context.Context, *GetBankDetailHealthRequest) (*GetBankDetailHealthResponse, error)
+	ResumeBankDetail(context.Context, *ResumeBankDetailRequest) (*ResumeBankDetailResponse, error)
+	// Банк-эмитент по номеру карты из локального справочника BIN
+	LookupBin(context.Context, *LookupBinRequest) (*LookupBinResponse, error)
 	mustEmbedUnimplementedBankDetailServiceServer()
 }
 
@@ -161,6 +260,27 @@ func (UnimplementedBankDetailServiceServer) GetBankDetailsStatsByTraderID(contex
 func (UnimplementedBankDetailServiceServer) GetBankDetails(context.Context, *GetBankDetailsRequest) (*GetBankDetailsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBankDetails not implemented")
 }
+func (UnimplementedBankDetailServiceServer) SetBankDetailSchedule(context.Context, *SetBankDetailScheduleRequest) (*SetBankDetailScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBankDetailSchedule not implemented")
+}
+func (UnimplementedBankDetailServiceServer) GetBankDetailSchedule(context.Context, *GetBankDetailScheduleRequest) (*GetBankDetailScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBankDetailSchedule not implemented")
+}
+func (UnimplementedBankDetailServiceServer) DeleteBankDetailSchedule(context.Context, *DeleteBankDetailScheduleRequest) (*DeleteBankDetailScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBankDetailSchedule not implemented")
+}
+func (UnimplementedBankDetailServiceServer) GetEffectiveAvailability(context.Context, *GetEffectiveAvailabilityRequest) (*GetEffectiveAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEffectiveAvailability not implemented")
+}
+func (UnimplementedBankDetailServiceServer) GetBankDetailHealth(context.Context, *GetBankDetailHealthRequest) (*GetBankDetailHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBankDetailHealth not implemented")
+}
+func (UnimplementedBankDetailServiceServer) ResumeBankDetail(context.Context, *ResumeBankDetailRequest) (*ResumeBankDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeBankDetail not implemented")
+}
+func (UnimplementedBankDetailServiceServer) LookupBin(context.Context, *LookupBinRequest) (*LookupBinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupBin not implemented")
+}
 func (UnimplementedBankDetailServiceServer) mustEmbedUnimplementedBankDetailServiceServer() {}
 func (UnimplementedBankDetailServiceServer) testEmbeddedByValue()                           {}
 
@@ -308,6 +428,132 @@ func _BankDetailService_GetBankDetails_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BankDetailService_SetBankDetailSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBankDetailScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankDetailServiceServer).SetBankDetailSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankDetailService_SetBankDetailSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankDetailServiceServer).SetBankDetailSchedule(ctx, req.(*SetBankDetailScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankDetailService_GetBankDetailSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBankDetailScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankDetailServiceServer).GetBankDetailSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankDetailService_GetBankDetailSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankDetailServiceServer).GetBankDetailSchedule(ctx, req.(*GetBankDetailScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankDetailService_DeleteBankDetailSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBankDetailScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankDetailServiceServer).DeleteBankDetailSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankDetailService_DeleteBankDetailSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankDetailServiceServer).DeleteBankDetailSchedule(ctx, req.(*DeleteBankDetailScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankDetailService_GetEffectiveAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEffectiveAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankDetailServiceServer).GetEffectiveAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankDetailService_GetEffectiveAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankDetailServiceServer).GetEffectiveAvailability(ctx, req.(*GetEffectiveAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankDetailService_GetBankDetailHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBankDetailHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankDetailServiceServer).GetBankDetailHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankDetailService_GetBankDetailHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankDetailServiceServer).GetBankDetailHealth(ctx, req.(*GetBankDetailHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankDetailService_ResumeBankDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeBankDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankDetailServiceServer).ResumeBankDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankDetailService_ResumeBankDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankDetailServiceServer).ResumeBankDetail(ctx, req.(*ResumeBankDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankDetailService_LookupBin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupBinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankDetailServiceServer).LookupBin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankDetailService_LookupBin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankDetailServiceServer).LookupBin(ctx, req.(*LookupBinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BankDetailService_ServiceDesc is the grpc.ServiceDesc for BankDetailService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -343,6 +589,34 @@ var BankDetailService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBankDetails",
 			Handler:    _BankDetailService_GetBankDetails_Handler,
 		},
+		{
+			MethodName: "SetBankDetailSchedule",
+			Handler:    _BankDetailService_SetBankDetailSchedule_Handler,
+		},
+		{
+			MethodName: "GetBankDetailSchedule",
+			Handler:    _BankDetailService_GetBankDetailSchedule_Handler,
+		},
+		{
+			MethodName: "DeleteBankDetailSchedule",
+			Handler:    _BankDetailService_DeleteBankDetailSchedule_Handler,
+		},
+		{
+			MethodName: "GetEffectiveAvailability",
+			Handler:    _BankDetailService_GetEffectiveAvailability_Handler,
+		},
+		{
+			MethodName: "GetBankDetailHealth",
+			Handler:    _BankDetailService_GetBankDetailHealth_Handler,
+		},
+		{
+			MethodName: "ResumeBankDetail",
+			Handler:    _BankDetailService_ResumeBankDetail_Handler,
+		},
+		{
+			MethodName: "LookupBin",
+			Handler:    _BankDetailService_LookupBin_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "order/bank_detail_service.proto",
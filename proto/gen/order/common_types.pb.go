@@ -190,6 +190,234 @@ func (x *Pagination) GetItemsPerPage() int64 {
 	return 0
 }
 
+// Расписание работы реквизита или трафика. Минуты отсчитываются от полуночи в поясе расписания,
+// end_minute меньше start_minute - интервал переходит через полночь
+type TimeRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartMinute   int32                  `protobuf:"varint,1,opt,name=start_minute,json=startMinute,proto3" json:"start_minute,omitempty"`
+	EndMinute     int32                  `protobuf:"varint,2,opt,name=end_minute,json=endMinute,proto3" json:"end_minute,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeRange) Reset() {
+	*x = TimeRange{}
+	mi := &file_order_common_types_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeRange) ProtoMessage() {}
+
+func (x *TimeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_order_common_types_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeRange.ProtoReflect.Descriptor instead.
+func (*TimeRange) Descriptor() ([]byte, []int) {
+	return file_order_common_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TimeRange) GetStartMinute() int32 {
+	if x != nil {
+		return x.StartMinute
+	}
+	return 0
+}
+
+func (x *TimeRange) GetEndMinute() int32 {
+	if x != nil {
+		return x.EndMinute
+	}
+	return 0
+}
+
+type ScheduleInterval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Weekday       int32                  `protobuf:"varint,1,opt,name=weekday,proto3" json:"weekday,omitempty"` // 0 - воскресенье, 6 - суббота
+	StartMinute   int32                  `protobuf:"varint,2,opt,name=start_minute,json=startMinute,proto3" json:"start_minute,omitempty"`
+	EndMinute     int32                  `protobuf:"varint,3,opt,name=end_minute,json=endMinute,proto3" json:"end_minute,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleInterval) Reset() {
+	*x = ScheduleInterval{}
+	mi := &file_order_common_types_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleInterval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleInterval) ProtoMessage() {}
+
+func (x *ScheduleInterval) ProtoReflect() protoreflect.Message {
+	mi := &file_order_common_types_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleInterval.ProtoReflect.Descriptor instead.
+func (*ScheduleInterval) Descriptor() ([]byte, []int) {
+	return file_order_common_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ScheduleInterval) GetWeekday() int32 {
+	if x != nil {
+		return x.Weekday
+	}
+	return 0
+}
+
+func (x *ScheduleInterval) GetStartMinute() int32 {
+	if x != nil {
+		return x.StartMinute
+	}
+	return 0
+}
+
+func (x *ScheduleInterval) GetEndMinute() int32 {
+	if x != nil {
+		return x.EndMinute
+	}
+	return 0
+}
+
+// Особый день (праздник), заменяет недельные интервалы. Без интервалов - выходной
+type ScheduleException struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"` // YYYY-MM-DD
+	Ranges        []*TimeRange           `protobuf:"bytes,2,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleException) Reset() {
+	*x = ScheduleException{}
+	mi := &file_order_common_types_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleException) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleException) ProtoMessage() {}
+
+func (x *ScheduleException) ProtoReflect() protoreflect.Message {
+	mi := &file_order_common_types_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleException.ProtoReflect.Descriptor instead.
+func (*ScheduleException) Descriptor() ([]byte, []int) {
+	return file_order_common_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ScheduleException) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *ScheduleException) GetRanges() []*TimeRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+// Без недельных интервалов работа круглосуточная, кроме дней-исключений
+type Schedule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timezone      string                 `protobuf:"bytes,1,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Weekly        []*ScheduleInterval    `protobuf:"bytes,2,rep,name=weekly,proto3" json:"weekly,omitempty"`
+	Exceptions    []*ScheduleException   `protobuf:"bytes,3,rep,name=exceptions,proto3" json:"exceptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	mi := &file_order_common_types_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule) ProtoMessage() {}
+
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_order_common_types_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_order_common_types_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Schedule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Schedule) GetWeekly() []*ScheduleInterval {
+	if x != nil {
+		return x.Weekly
+	}
+	return nil
+}
+
+func (x *Schedule) GetExceptions() []*ScheduleException {
+	if x != nil {
+		return x.Exceptions
+	}
+	return nil
+}
+
 var File_order_common_types_proto protoreflect.FileDescriptor
 
 const file_order_common_types_proto_rawDesc = "" +
@@ -211,7 +439,25 @@ const file_order_common_types_proto_rawDesc = "" +
 	"totalPages\x12\x1f\n" +
 	"\vtotal_items\x18\x03 \x01(\x03R\n" +
 	"totalItems\x12$\n" +
-	"\x0eitems_per_page\x18\x04 \x01(\x03R\fitemsPerPageB=Z;github.com/LavaJover/shvark-order-service/proto/gen;orderpbb\x06proto3"
+	"\x0eitems_per_page\x18\x04 \x01(\x03R\fitemsPerPage\"M\n" +
+	"\tTimeRange\x12!\n" +
+	"\fstart_minute\x18\x01 \x01(\x05R\vstartMinute\x12\x1d\n" +
+	"\n" +
+	"end_minute\x18\x02 \x01(\x05R\tendMinute\"n\n" +
+	"\x10ScheduleInterval\x12\x18\n" +
+	"\aweekday\x18\x01 \x01(\x05R\aweekday\x12!\n" +
+	"\fstart_minute\x18\x02 \x01(\x05R\vstartMinute\x12\x1d\n" +
+	"\n" +
+	"end_minute\x18\x03 \x01(\x05R\tendMinute\"Q\n" +
+	"\x11ScheduleException\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12(\n" +
+	"\x06ranges\x18\x02 \x03(\v2\x10.order.TimeRangeR\x06ranges\"\x91\x01\n" +
+	"\bSchedule\x12\x1a\n" +
+	"\btimezone\x18\x01 \x01(\tR\btimezone\x12/\n" +
+	"\x06weekly\x18\x02 \x03(\v2\x17.order.ScheduleIntervalR\x06weekly\x128\n" +
+	"\n" +
+	"exceptions\x18\x03 \x03(\v2\x18.order.ScheduleExceptionR\n" +
+	"exceptionsB=Z;github.com/LavaJover/shvark-order-service/proto/gen;orderpbb\x06proto3"
 
 var (
 	file_order_common_types_proto_rawDescOnce sync.Once
@@ -225,20 +471,27 @@ func file_order_common_types_proto_rawDescGZIP() []byte {
 	return file_order_common_types_proto_rawDescData
 }
 
-var file_order_common_types_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_order_common_types_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_order_common_types_proto_goTypes = []any{
 	(*OrderFilters)(nil),          // 0: order.OrderFilters
 	(*Pagination)(nil),            // 1: order.Pagination
-	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*TimeRange)(nil),             // 2: order.TimeRange
+	(*ScheduleInterval)(nil),      // 3: order.ScheduleInterval
+	(*ScheduleException)(nil),     // 4: order.ScheduleException
+	(*Schedule)(nil),              // 5: order.Schedule
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
 }
 var file_order_common_types_proto_depIdxs = []int32{
-	2, // 0: order.OrderFilters.date_from:type_name -> google.protobuf.Timestamp
-	2, // 1: order.OrderFilters.date_to:type_name -> google.protobuf.Timestamp
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	6, // 0: order.OrderFilters.date_from:type_name -> google.protobuf.Timestamp
+	6, // 1: order.OrderFilters.date_to:type_name -> google.protobuf.Timestamp
+	2, // 2: order.ScheduleException.ranges:type_name -> order.TimeRange
+	3, // 3: order.Schedule.weekly:type_name -> order.ScheduleInterval
+	4, // 4: order.Schedule.exceptions:type_name -> order.ScheduleException
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_order_common_types_proto_init() }
@@ -252,7 +505,7 @@ func file_order_common_types_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_common_types_proto_rawDesc), len(file_order_common_types_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -554,8 +554,12 @@ func (x *CreateDeviceRequest) GetManuallyUnlocked() bool {
 	return false
 }
 
+// device_secret выдается только при создании и ротации, сервис не возвращает его повторно
 type CreateDeviceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeviceSecret  string                 `protobuf:"bytes,2,opt,name=device_secret,json=deviceSecret,proto3" json:"device_secret,omitempty"`
+	SecretId      string                 `protobuf:"bytes,3,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -590,6 +594,27 @@ func (*CreateDeviceResponse) Descriptor() ([]byte, []int) {
 	return file_order_device_service_proto_rawDescGZIP(), []int{9}
 }
 
+func (x *CreateDeviceResponse) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *CreateDeviceResponse) GetDeviceSecret() string {
+	if x != nil {
+		return x.DeviceSecret
+	}
+	return ""
+}
+
+func (x *CreateDeviceResponse) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
 type GetTraderDevicesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TraderId      string                 `protobuf:"bytes,1,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
@@ -898,6 +923,200 @@ func (*DeleteDeviceResponse) Descriptor() ([]byte, []int) {
 	return file_order_device_service_proto_rawDescGZIP(), []int{16}
 }
 
+// Прежние секреты устройства действуют еще rotation_grace после ротации.
+// trader_id - трейдер, от имени которого вызывает шлюз, должен владеть устройством
+type RotateDeviceSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	TraderId      string                 `protobuf:"bytes,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateDeviceSecretRequest) Reset() {
+	*x = RotateDeviceSecretRequest{}
+	mi := &file_order_device_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateDeviceSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateDeviceSecretRequest) ProtoMessage() {}
+
+func (x *RotateDeviceSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_device_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateDeviceSecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateDeviceSecretRequest) Descriptor() ([]byte, []int) {
+	return file_order_device_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RotateDeviceSecretRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *RotateDeviceSecretRequest) GetTraderId() string {
+	if x != nil {
+		return x.TraderId
+	}
+	return ""
+}
+
+type RotateDeviceSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceSecret  string                 `protobuf:"bytes,1,opt,name=device_secret,json=deviceSecret,proto3" json:"device_secret,omitempty"`
+	SecretId      string                 `protobuf:"bytes,2,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateDeviceSecretResponse) Reset() {
+	*x = RotateDeviceSecretResponse{}
+	mi := &file_order_device_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateDeviceSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateDeviceSecretResponse) ProtoMessage() {}
+
+func (x *RotateDeviceSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_device_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateDeviceSecretResponse.ProtoReflect.Descriptor instead.
+func (*RotateDeviceSecretResponse) Descriptor() ([]byte, []int) {
+	return file_order_device_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RotateDeviceSecretResponse) GetDeviceSecret() string {
+	if x != nil {
+		return x.DeviceSecret
+	}
+	return ""
+}
+
+func (x *RotateDeviceSecretResponse) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+type RevokeDeviceSecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	TraderId      string                 `protobuf:"bytes,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeDeviceSecretsRequest) Reset() {
+	*x = RevokeDeviceSecretsRequest{}
+	mi := &file_order_device_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeDeviceSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeDeviceSecretsRequest) ProtoMessage() {}
+
+func (x *RevokeDeviceSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_device_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeDeviceSecretsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeDeviceSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_order_device_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RevokeDeviceSecretsRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *RevokeDeviceSecretsRequest) GetTraderId() string {
+	if x != nil {
+		return x.TraderId
+	}
+	return ""
+}
+
+type RevokeDeviceSecretsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeDeviceSecretsResponse) Reset() {
+	*x = RevokeDeviceSecretsResponse{}
+	mi := &file_order_device_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeDeviceSecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeDeviceSecretsResponse) ProtoMessage() {}
+
+func (x *RevokeDeviceSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_device_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeDeviceSecretsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeDeviceSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_order_device_service_proto_rawDescGZIP(), []int{20}
+}
+
 var File_order_device_service_proto protoreflect.FileDescriptor
 
 const file_order_device_service_proto_rawDesc = "" +
@@ -942,8 +1161,11 @@ const file_order_device_service_proto_rawDesc = "" +
 	"deviceName\x12\x1b\n" +
 	"\ttrader_id\x18\x02 \x01(\tR\btraderId\x12\x18\n" +
 	"\aenabled\x18\x03 \x01(\bR\aenabled\x12+\n" +
-	"\x11manually_unlocked\x18\a \x01(\bR\x10manuallyUnlocked\"\x16\n" +
-	"\x14CreateDeviceResponse\"6\n" +
+	"\x11manually_unlocked\x18\a \x01(\bR\x10manuallyUnlocked\"u\n" +
+	"\x14CreateDeviceResponse\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12#\n" +
+	"\rdevice_secret\x18\x02 \x01(\tR\fdeviceSecret\x12\x1b\n" +
+	"\tsecret_id\x18\x03 \x01(\tR\bsecretId\"6\n" +
 	"\x17GetTraderDevicesRequest\x12\x1b\n" +
 	"\ttrader_id\x18\x01 \x01(\tR\btraderId\"C\n" +
 	"\x18GetTraderDevicesResponse\x12'\n" +
@@ -958,7 +1180,17 @@ const file_order_device_service_proto_rawDesc = "" +
 	"\x12EditDeviceResponse\"2\n" +
 	"\x13DeleteDeviceRequest\x12\x1b\n" +
 	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"\x16\n" +
-	"\x14DeleteDeviceResponse2\xd3\x04\n" +
+	"\x14DeleteDeviceResponse\"U\n" +
+	"\x19RotateDeviceSecretRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\tR\btraderId\"^\n" +
+	"\x1aRotateDeviceSecretResponse\x12#\n" +
+	"\rdevice_secret\x18\x01 \x01(\tR\fdeviceSecret\x12\x1b\n" +
+	"\tsecret_id\x18\x02 \x01(\tR\bsecretId\"V\n" +
+	"\x1aRevokeDeviceSecretsRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\tR\btraderId\"\x1d\n" +
+	"\x1bRevokeDeviceSecretsResponse2\x8c\x06\n" +
 	"\rDeviceService\x12G\n" +
 	"\fCreateDevice\x12\x1a.order.CreateDeviceRequest\x1a\x1b.order.CreateDeviceResponse\x12S\n" +
 	"\x10GetTraderDevices\x12\x1e.order.GetTraderDevicesRequest\x1a\x1f.order.GetTraderDevicesResponse\x12G\n" +
@@ -967,7 +1199,9 @@ const file_order_device_service_proto_rawDesc = "" +
 	"EditDevice\x12\x18.order.EditDeviceRequest\x1a\x19.order.EditDeviceResponse\x12_\n" +
 	"\x14UpdateDeviceLiveness\x12\".order.UpdateDeviceLivenessRequest\x1a#.order.UpdateDeviceLivenessResponse\x12P\n" +
 	"\x0fGetDeviceStatus\x12\x1d.order.GetDeviceStatusRequest\x1a\x1e.order.GetDeviceStatusResponse\x12e\n" +
-	"\x16GetTraderDevicesStatus\x12$.order.GetTraderDevicesStatusRequest\x1a%.order.GetTraderDevicesStatusResponseB=Z;github.com/LavaJover/shvark-order-service/proto/gen;orderpbb\x06proto3"
+	"\x16GetTraderDevicesStatus\x12$.order.GetTraderDevicesStatusRequest\x1a%.order.GetTraderDevicesStatusResponse\x12Y\n" +
+	"\x12RotateDeviceSecret\x12 .order.RotateDeviceSecretRequest\x1a!.order.RotateDeviceSecretResponse\x12\\\n" +
+	"\x13RevokeDeviceSecrets\x12!.order.RevokeDeviceSecretsRequest\x1a\".order.RevokeDeviceSecretsResponseB=Z;github.com/LavaJover/shvark-order-service/proto/gen;orderpbb\x06proto3"
 
 var (
 	file_order_device_service_proto_rawDescOnce sync.Once
@@ -981,7 +1215,7 @@ func file_order_device_service_proto_rawDescGZIP() []byte {
 	return file_order_device_service_proto_rawDescData
 }
 
-var file_order_device_service_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_order_device_service_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_order_device_service_proto_goTypes = []any{
 	(*UpdateDeviceLivenessRequest)(nil),    // 0: order.UpdateDeviceLivenessRequest
 	(*UpdateDeviceLivenessResponse)(nil),   // 1: order.UpdateDeviceLivenessResponse
@@ -1000,12 +1234,16 @@ var file_order_device_service_proto_goTypes = []any{
 	(*EditDeviceResponse)(nil),             // 14: order.EditDeviceResponse
 	(*DeleteDeviceRequest)(nil),            // 15: order.DeleteDeviceRequest
 	(*DeleteDeviceResponse)(nil),           // 16: order.DeleteDeviceResponse
-	(*timestamppb.Timestamp)(nil),          // 17: google.protobuf.Timestamp
+	(*RotateDeviceSecretRequest)(nil),      // 17: order.RotateDeviceSecretRequest
+	(*RotateDeviceSecretResponse)(nil),     // 18: order.RotateDeviceSecretResponse
+	(*RevokeDeviceSecretsRequest)(nil),     // 19: order.RevokeDeviceSecretsRequest
+	(*RevokeDeviceSecretsResponse)(nil),    // 20: order.RevokeDeviceSecretsResponse
+	(*timestamppb.Timestamp)(nil),          // 21: google.protobuf.Timestamp
 }
 var file_order_device_service_proto_depIdxs = []int32{
 	6,  // 0: order.GetTraderDevicesStatusResponse.devices:type_name -> order.DeviceStatus
-	17, // 1: order.Device.created_at:type_name -> google.protobuf.Timestamp
-	17, // 2: order.Device.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 1: order.Device.created_at:type_name -> google.protobuf.Timestamp
+	21, // 2: order.Device.updated_at:type_name -> google.protobuf.Timestamp
 	7,  // 3: order.GetTraderDevicesResponse.devices:type_name -> order.Device
 	12, // 4: order.EditDeviceRequest.params:type_name -> order.EditDeviceParams
 	8,  // 5: order.DeviceService.CreateDevice:input_type -> order.CreateDeviceRequest
@@ -1015,15 +1253,19 @@ var file_order_device_service_proto_depIdxs = []int32{
 	0,  // 9: order.DeviceService.UpdateDeviceLiveness:input_type -> order.UpdateDeviceLivenessRequest
 	2,  // 10: order.DeviceService.GetDeviceStatus:input_type -> order.GetDeviceStatusRequest
 	4,  // 11: order.DeviceService.GetTraderDevicesStatus:input_type -> order.GetTraderDevicesStatusRequest
-	9,  // 12: order.DeviceService.CreateDevice:output_type -> order.CreateDeviceResponse
-	11, // 13: order.DeviceService.GetTraderDevices:output_type -> order.GetTraderDevicesResponse
-	16, // 14: order.DeviceService.DeleteDevice:output_type -> order.DeleteDeviceResponse
-	14, // 15: order.DeviceService.EditDevice:output_type -> order.EditDeviceResponse
-	1,  // 16: order.DeviceService.UpdateDeviceLiveness:output_type -> order.UpdateDeviceLivenessResponse
-	3,  // 17: order.DeviceService.GetDeviceStatus:output_type -> order.GetDeviceStatusResponse
-	5,  // 18: order.DeviceService.GetTraderDevicesStatus:output_type -> order.GetTraderDevicesStatusResponse
-	12, // [12:19] is the sub-list for method output_type
-	5,  // [5:12] is the sub-list for method input_type
+	17, // 12: order.DeviceService.RotateDeviceSecret:input_type -> order.RotateDeviceSecretRequest
+	19, // 13: order.DeviceService.RevokeDeviceSecrets:input_type -> order.RevokeDeviceSecretsRequest
+	9,  // 14: order.DeviceService.CreateDevice:output_type -> order.CreateDeviceResponse
+	11, // 15: order.DeviceService.GetTraderDevices:output_type -> order.GetTraderDevicesResponse
+	16, // 16: order.DeviceService.DeleteDevice:output_type -> order.DeleteDeviceResponse
+	14, // 17: order.DeviceService.EditDevice:output_type -> order.EditDeviceResponse
+	1,  // 18: order.DeviceService.UpdateDeviceLiveness:output_type -> order.UpdateDeviceLivenessResponse
+	3,  // 19: order.DeviceService.GetDeviceStatus:output_type -> order.GetDeviceStatusResponse
+	5,  // 20: order.DeviceService.GetTraderDevicesStatus:output_type -> order.GetTraderDevicesStatusResponse
+	18, // 21: order.DeviceService.RotateDeviceSecret:output_type -> order.RotateDeviceSecretResponse
+	20, // 22: order.DeviceService.RevokeDeviceSecrets:output_type -> order.RevokeDeviceSecretsResponse
+	14, // [14:23] is the sub-list for method output_type
+	5,  // [5:14] is the sub-list for method input_type
 	5,  // [5:5] is the sub-list for extension type_name
 	5,  // [5:5] is the sub-list for extension extendee
 	0,  // [0:5] is the sub-list for field type_name
@@ -1040,7 +1282,7 @@ func file_order_device_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_device_service_proto_rawDesc), len(file_order_device_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,6 +26,8 @@ const (
 	DeviceService_UpdateDeviceLiveness_FullMethodName   = "/order.DeviceService/UpdateDeviceLiveness"
 	DeviceService_GetDeviceStatus_FullMethodName        = "/order.DeviceService/GetDeviceStatus"
 	DeviceService_GetTraderDevicesStatus_FullMethodName = "/order.DeviceService/GetTraderDevicesStatus"
+	DeviceService_RotateDeviceSecret_FullMethodName     = "/order.DeviceService/RotateDeviceSecret"
+	DeviceService_RevokeDeviceSecrets_FullMethodName    = "/order.DeviceService/RevokeDeviceSecrets"
 )
 
 // DeviceServiceClient is the client API for DeviceService service.
@@ -40,6 +42,9 @@ type DeviceServiceClient interface {
 	UpdateDeviceLiveness(ctx context.Context, in *UpdateDeviceLivenessRequest, opts ...grpc.CallOption) (*UpdateDeviceLivenessResponse, error)
 	GetDeviceStatus(ctx context.Context, in *GetDeviceStatusRequest, opts ...grpc.CallOption) (*GetDeviceStatusResponse, error)
 	GetTraderDevicesStatus(ctx context.Context, in *GetTraderDevicesStatusRequest, opts ...grpc.CallOption) (*GetTraderDevicesStatusResponse, error)
+	// Секреты для подписи запросов устройства (заголовки x-device-id, x-device-timestamp, x-device-signature)
+	RotateDeviceSecret(ctx context.Context, in *RotateDeviceSecretRequest, opts ...grpc.CallOption) (*RotateDeviceSecretResponse, error)
+	RevokeDeviceSecrets(ctx context.Context, in *RevokeDeviceSecretsRequest, opts ...grpc.CallOption) (*RevokeDeviceSecretsResponse, error)
 }
 
 type deviceServiceClient struct {
@@ -120,6 +125,26 @@ func (c *deviceServiceClient) GetTraderDevicesStatus(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *deviceServiceClient) RotateDeviceSecret(ctx context.Context, in *RotateDeviceSecretRequest, opts ...grpc.CallOption) (*RotateDeviceSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateDeviceSecretResponse)
+	err := c.cc.Invoke(ctx, DeviceService_RotateDeviceSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RevokeDeviceSecrets(ctx context.Context, in *RevokeDeviceSecretsRequest, opts ...grpc.CallOption) (*RevokeDeviceSecretsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeDeviceSecretsResponse)
+	err := c.cc.Invoke(ctx, DeviceService_RevokeDeviceSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DeviceServiceServer is the server API for DeviceService service.
 // All implementations must embed UnimplementedDeviceServiceServer
 // for forward compatibility.
@@ -132,6 +157,9 @@ type DeviceServiceServer interface {
 	UpdateDeviceLiveness(context.Context, *UpdateDeviceLivenessRequest) (*UpdateDeviceLivenessResponse, error)
 	GetDeviceStatus(context.Context, *GetDeviceStatusRequest) (*GetDeviceStatusResponse, error)
 	GetTraderDevicesStatus(context.Context, *GetTraderDevicesStatusRequest) (*GetTraderDevicesStatusResponse, error)
+	// Секреты для подписи запросов устройства (заголовки x-device-id, x-device-timestamp, x-device-signature)
+	RotateDeviceSecret(context.Context, *RotateDeviceSecretRequest) (*RotateDeviceSecretResponse, error)
+	RevokeDeviceSecrets(context.Context, *RevokeDeviceSecretsRequest) (*RevokeDeviceSecretsResponse, error)
 	mustEmbedUnimplementedDeviceServiceServer()
 }
 
@@ -163,6 +191,12 @@ func (UnimplementedDeviceServiceServer) GetDeviceStatus(context.Context, *GetDev
 func (UnimplementedDeviceServiceServer) GetTraderDevicesStatus(context.Context, *GetTraderDevicesStatusRequest) (*GetTraderDevicesStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTraderDevicesStatus not implemented")
 }
+func (UnimplementedDeviceServiceServer) RotateDeviceSecret(context.Context, *RotateDeviceSecretRequest) (*RotateDeviceSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateDeviceSecret not implemented")
+}
+func (UnimplementedDeviceServiceServer) RevokeDeviceSecrets(context.Context, *RevokeDeviceSecretsRequest) (*RevokeDeviceSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeDeviceSecrets not implemented")
+}
 func (UnimplementedDeviceServiceServer) mustEmbedUnimplementedDeviceServiceServer() {}
 func (UnimplementedDeviceServiceServer) testEmbeddedByValue()                       {}
 
@@ -310,6 +344,42 @@ func _DeviceService_GetTraderDevicesStatus_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeviceService_RotateDeviceSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateDeviceSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RotateDeviceSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RotateDeviceSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RotateDeviceSecret(ctx, req.(*RotateDeviceSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RevokeDeviceSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeDeviceSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RevokeDeviceSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RevokeDeviceSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RevokeDeviceSecrets(ctx, req.(*RevokeDeviceSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DeviceService_ServiceDesc is the grpc.ServiceDesc for DeviceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -345,6 +415,14 @@ var DeviceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTraderDevicesStatus",
 			Handler:    _DeviceService_GetTraderDevicesStatus_Handler,
 		},
+		{
+			MethodName: "RotateDeviceSecret",
+			Handler:    _DeviceService_RotateDeviceSecret_Handler,
+		},
+		{
+			MethodName: "RevokeDeviceSecrets",
+			Handler:    _DeviceService_RevokeDeviceSecrets_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "order/device_service.proto",
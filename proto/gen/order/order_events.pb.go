@@ -0,0 +1,695 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: order/order_events.proto
+
+package orderpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Тип события. Строковое имя для потребителей: order.created, order.completed,
+// order.canceled, dispute.opened, bank_detail.suspended, bank_detail.resumed
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED           EventType = 0
+	EventType_EVENT_TYPE_ORDER_CREATED         EventType = 1
+	EventType_EVENT_TYPE_ORDER_COMPLETED       EventType = 2
+	EventType_EVENT_TYPE_ORDER_CANCELED        EventType = 3
+	EventType_EVENT_TYPE_DISPUTE_OPENED        EventType = 4
+	EventType_EVENT_TYPE_BANK_DETAIL_SUSPENDED EventType = 5
+	EventType_EVENT_TYPE_BANK_DETAIL_RESUMED   EventType = 6
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_ORDER_CREATED",
+		2: "EVENT_TYPE_ORDER_COMPLETED",
+		3: "EVENT_TYPE_ORDER_CANCELED",
+		4: "EVENT_TYPE_DISPUTE_OPENED",
+		5: "EVENT_TYPE_BANK_DETAIL_SUSPENDED",
+		6: "EVENT_TYPE_BANK_DETAIL_RESUMED",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED":           0,
+		"EVENT_TYPE_ORDER_CREATED":         1,
+		"EVENT_TYPE_ORDER_COMPLETED":       2,
+		"EVENT_TYPE_ORDER_CANCELED":        3,
+		"EVENT_TYPE_DISPUTE_OPENED":        4,
+		"EVENT_TYPE_BANK_DETAIL_SUSPENDED": 5,
+		"EVENT_TYPE_BANK_DETAIL_RESUMED":   6,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_order_events_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_order_order_events_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_order_order_events_proto_rawDescGZIP(), []int{0}
+}
+
+// EventEnvelope - версионированное событие по сделке, диспуту или реквизиту.
+// В событиях по реквизиту order_id и суммы не заполняются.
+// Текст для отображения формируют потребители по event_type.
+type EventEnvelope struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion   uint32                 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	EventId         string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType       EventType              `protobuf:"varint,3,opt,name=event_type,json=eventType,proto3,enum=order.EventType" json:"event_type,omitempty"`
+	OccurredAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	OrderId         string                 `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	MerchantId      string                 `protobuf:"bytes,6,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	MerchantOrderId string                 `protobuf:"bytes,7,opt,name=merchant_order_id,json=merchantOrderId,proto3" json:"merchant_order_id,omitempty"`
+	TraderId        string                 `protobuf:"bytes,8,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	OrderType       string                 `protobuf:"bytes,9,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+	OrderStatus     string                 `protobuf:"bytes,10,opt,name=order_status,json=orderStatus,proto3" json:"order_status,omitempty"`
+	Amounts         *EventAmounts          `protobuf:"bytes,11,opt,name=amounts,proto3" json:"amounts,omitempty"`
+	Requisite       *EventRequisite        `protobuf:"bytes,12,opt,name=requisite,proto3" json:"requisite,omitempty"`
+	Automatic       bool                   `protobuf:"varint,13,opt,name=automatic,proto3" json:"automatic,omitempty"`
+	Dispute         *DisputeEventData      `protobuf:"bytes,14,opt,name=dispute,proto3" json:"dispute,omitempty"`
+	BankDetail      *BankDetailEventData   `protobuf:"bytes,15,opt,name=bank_detail,json=bankDetail,proto3" json:"bank_detail,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EventEnvelope) Reset() {
+	*x = EventEnvelope{}
+	mi := &file_order_order_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventEnvelope) ProtoMessage() {}
+
+func (x *EventEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventEnvelope.ProtoReflect.Descriptor instead.
+func (*EventEnvelope) Descriptor() ([]byte, []int) {
+	return file_order_order_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EventEnvelope) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *EventEnvelope) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetEventType() EventType {
+	if x != nil {
+		return x.EventType
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *EventEnvelope) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetMerchantOrderId() string {
+	if x != nil {
+		return x.MerchantOrderId
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetTraderId() string {
+	if x != nil {
+		return x.TraderId
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetOrderStatus() string {
+	if x != nil {
+		return x.OrderStatus
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetAmounts() *EventAmounts {
+	if x != nil {
+		return x.Amounts
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetRequisite() *EventRequisite {
+	if x != nil {
+		return x.Requisite
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetAutomatic() bool {
+	if x != nil {
+		return x.Automatic
+	}
+	return false
+}
+
+func (x *EventEnvelope) GetDispute() *DisputeEventData {
+	if x != nil {
+		return x.Dispute
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetBankDetail() *BankDetailEventData {
+	if x != nil {
+		return x.BankDetail
+	}
+	return nil
+}
+
+type EventAmounts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AmountFiat    float64                `protobuf:"fixed64,1,opt,name=amount_fiat,json=amountFiat,proto3" json:"amount_fiat,omitempty"`
+	AmountCrypto  float64                `protobuf:"fixed64,2,opt,name=amount_crypto,json=amountCrypto,proto3" json:"amount_crypto,omitempty"`
+	CryptoRate    float64                `protobuf:"fixed64,3,opt,name=crypto_rate,json=cryptoRate,proto3" json:"crypto_rate,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventAmounts) Reset() {
+	*x = EventAmounts{}
+	mi := &file_order_order_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventAmounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventAmounts) ProtoMessage() {}
+
+func (x *EventAmounts) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventAmounts.ProtoReflect.Descriptor instead.
+func (*EventAmounts) Descriptor() ([]byte, []int) {
+	return file_order_order_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EventAmounts) GetAmountFiat() float64 {
+	if x != nil {
+		return x.AmountFiat
+	}
+	return 0
+}
+
+func (x *EventAmounts) GetAmountCrypto() float64 {
+	if x != nil {
+		return x.AmountCrypto
+	}
+	return 0
+}
+
+func (x *EventAmounts) GetCryptoRate() float64 {
+	if x != nil {
+		return x.CryptoRate
+	}
+	return 0
+}
+
+func (x *EventAmounts) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type EventRequisite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BankName      string                 `protobuf:"bytes,1,opt,name=bank_name,json=bankName,proto3" json:"bank_name,omitempty"`
+	PaymentSystem string                 `protobuf:"bytes,2,opt,name=payment_system,json=paymentSystem,proto3" json:"payment_system,omitempty"`
+	Phone         string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	CardNumber    string                 `protobuf:"bytes,4,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
+	Owner         string                 `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventRequisite) Reset() {
+	*x = EventRequisite{}
+	mi := &file_order_order_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventRequisite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventRequisite) ProtoMessage() {}
+
+func (x *EventRequisite) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventRequisite.ProtoReflect.Descriptor instead.
+func (*EventRequisite) Descriptor() ([]byte, []int) {
+	return file_order_order_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EventRequisite) GetBankName() string {
+	if x != nil {
+		return x.BankName
+	}
+	return ""
+}
+
+func (x *EventRequisite) GetPaymentSystem() string {
+	if x != nil {
+		return x.PaymentSystem
+	}
+	return ""
+}
+
+func (x *EventRequisite) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *EventRequisite) GetCardNumber() string {
+	if x != nil {
+		return x.CardNumber
+	}
+	return ""
+}
+
+func (x *EventRequisite) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+type DisputeEventData struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId           string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	Status              string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason              string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	ProofUrl            string                 `protobuf:"bytes,4,opt,name=proof_url,json=proofUrl,proto3" json:"proof_url,omitempty"`
+	DisputeAmountFiat   float64                `protobuf:"fixed64,5,opt,name=dispute_amount_fiat,json=disputeAmountFiat,proto3" json:"dispute_amount_fiat,omitempty"`
+	DisputeAmountCrypto float64                `protobuf:"fixed64,6,opt,name=dispute_amount_crypto,json=disputeAmountCrypto,proto3" json:"dispute_amount_crypto,omitempty"`
+	DisputeCryptoRate   float64                `protobuf:"fixed64,7,opt,name=dispute_crypto_rate,json=disputeCryptoRate,proto3" json:"dispute_crypto_rate,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DisputeEventData) Reset() {
+	*x = DisputeEventData{}
+	mi := &file_order_order_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisputeEventData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeEventData) ProtoMessage() {}
+
+func (x *DisputeEventData) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeEventData.ProtoReflect.Descriptor instead.
+func (*DisputeEventData) Descriptor() ([]byte, []int) {
+	return file_order_order_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DisputeEventData) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *DisputeEventData) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DisputeEventData) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DisputeEventData) GetProofUrl() string {
+	if x != nil {
+		return x.ProofUrl
+	}
+	return ""
+}
+
+func (x *DisputeEventData) GetDisputeAmountFiat() float64 {
+	if x != nil {
+		return x.DisputeAmountFiat
+	}
+	return 0
+}
+
+func (x *DisputeEventData) GetDisputeAmountCrypto() float64 {
+	if x != nil {
+		return x.DisputeAmountCrypto
+	}
+	return 0
+}
+
+func (x *DisputeEventData) GetDisputeCryptoRate() float64 {
+	if x != nil {
+		return x.DisputeCryptoRate
+	}
+	return 0
+}
+
+// Приостановка реквизита по его здоровью или ее снятие
+type BankDetailEventData struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	BankDetailId       string                 `protobuf:"bytes,1,opt,name=bank_detail_id,json=bankDetailId,proto3" json:"bank_detail_id,omitempty"`
+	Reason             string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	SuspendedUntil     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=suspended_until,json=suspendedUntil,proto3" json:"suspended_until,omitempty"`
+	ConsecutiveCancels int32                  `protobuf:"varint,4,opt,name=consecutive_cancels,json=consecutiveCancels,proto3" json:"consecutive_cancels,omitempty"`
+	WindowCancels      int32                  `protobuf:"varint,5,opt,name=window_cancels,json=windowCancels,proto3" json:"window_cancels,omitempty"`
+	WindowDisputes     int32                  `protobuf:"varint,6,opt,name=window_disputes,json=windowDisputes,proto3" json:"window_disputes,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BankDetailEventData) Reset() {
+	*x = BankDetailEventData{}
+	mi := &file_order_order_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BankDetailEventData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BankDetailEventData) ProtoMessage() {}
+
+func (x *BankDetailEventData) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BankDetailEventData.ProtoReflect.Descriptor instead.
+func (*BankDetailEventData) Descriptor() ([]byte, []int) {
+	return file_order_order_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BankDetailEventData) GetBankDetailId() string {
+	if x != nil {
+		return x.BankDetailId
+	}
+	return ""
+}
+
+func (x *BankDetailEventData) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BankDetailEventData) GetSuspendedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SuspendedUntil
+	}
+	return nil
+}
+
+func (x *BankDetailEventData) GetConsecutiveCancels() int32 {
+	if x != nil {
+		return x.ConsecutiveCancels
+	}
+	return 0
+}
+
+func (x *BankDetailEventData) GetWindowCancels() int32 {
+	if x != nil {
+		return x.WindowCancels
+	}
+	return 0
+}
+
+func (x *BankDetailEventData) GetWindowDisputes() int32 {
+	if x != nil {
+		return x.WindowDisputes
+	}
+	return 0
+}
+
+var File_order_order_events_proto protoreflect.FileDescriptor
+
+const file_order_order_events_proto_rawDesc = "" +
+	"\n" +
+	"\x18order/order_events.proto\x12\x05order\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf8\x04\n" +
+	"\rEventEnvelope\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\rR\rschemaVersion\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12/\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\x0e2\x10.order.EventTypeR\teventType\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x19\n" +
+	"\border_id\x18\x05 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vmerchant_id\x18\x06 \x01(\tR\n" +
+	"merchantId\x12*\n" +
+	"\x11merchant_order_id\x18\a \x01(\tR\x0fmerchantOrderId\x12\x1b\n" +
+	"\ttrader_id\x18\b \x01(\tR\btraderId\x12\x1d\n" +
+	"\n" +
+	"order_type\x18\t \x01(\tR\torderType\x12!\n" +
+	"\forder_status\x18\n" +
+	" \x01(\tR\vorderStatus\x12-\n" +
+	"\aamounts\x18\v \x01(\v2\x13.order.EventAmountsR\aamounts\x123\n" +
+	"\trequisite\x18\f \x01(\v2\x15.order.EventRequisiteR\trequisite\x12\x1c\n" +
+	"\tautomatic\x18\r \x01(\bR\tautomatic\x121\n" +
+	"\adispute\x18\x0e \x01(\v2\x17.order.DisputeEventDataR\adispute\x12;\n" +
+	"\vbank_detail\x18\x0f \x01(\v2\x1a.order.BankDetailEventDataR\n" +
+	"bankDetail\"\x91\x01\n" +
+	"\fEventAmounts\x12\x1f\n" +
+	"\vamount_fiat\x18\x01 \x01(\x01R\n" +
+	"amountFiat\x12#\n" +
+	"\ramount_crypto\x18\x02 \x01(\x01R\famountCrypto\x12\x1f\n" +
+	"\vcrypto_rate\x18\x03 \x01(\x01R\n" +
+	"cryptoRate\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"\xa1\x01\n" +
+	"\x0eEventRequisite\x12\x1b\n" +
+	"\tbank_name\x18\x01 \x01(\tR\bbankName\x12%\n" +
+	"\x0epayment_system\x18\x02 \x01(\tR\rpaymentSystem\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x1f\n" +
+	"\vcard_number\x18\x04 \x01(\tR\n" +
+	"cardNumber\x12\x14\n" +
+	"\x05owner\x18\x05 \x01(\tR\x05owner\"\x92\x02\n" +
+	"\x10DisputeEventData\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1b\n" +
+	"\tproof_url\x18\x04 \x01(\tR\bproofUrl\x12.\n" +
+	"\x13dispute_amount_fiat\x18\x05 \x01(\x01R\x11disputeAmountFiat\x122\n" +
+	"\x15dispute_amount_crypto\x18\x06 \x01(\x01R\x13disputeAmountCrypto\x12.\n" +
+	"\x13dispute_crypto_rate\x18\a \x01(\x01R\x11disputeCryptoRate\"\x99\x02\n" +
+	"\x13BankDetailEventData\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12C\n" +
+	"\x0fsuspended_until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0esuspendedUntil\x12/\n" +
+	"\x13consecutive_cancels\x18\x04 \x01(\x05R\x12consecutiveCancels\x12%\n" +
+	"\x0ewindow_cancels\x18\x05 \x01(\x05R\rwindowCancels\x12'\n" +
+	"\x0fwindow_disputes\x18\x06 \x01(\x05R\x0ewindowDisputes*\xed\x01\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18EVENT_TYPE_ORDER_CREATED\x10\x01\x12\x1e\n" +
+	"\x1aEVENT_TYPE_ORDER_COMPLETED\x10\x02\x12\x1d\n" +
+	"\x19EVENT_TYPE_ORDER_CANCELED\x10\x03\x12\x1d\n" +
+	"\x19EVENT_TYPE_DISPUTE_OPENED\x10\x04\x12$\n" +
+	" EVENT_TYPE_BANK_DETAIL_SUSPENDED\x10\x05\x12\"\n" +
+	"\x1eEVENT_TYPE_BANK_DETAIL_RESUMED\x10\x06B=Z;github.com/LavaJover/shvark-order-service/proto/gen;orderpbb\x06proto3"
+
+var (
+	file_order_order_events_proto_rawDescOnce sync.Once
+	file_order_order_events_proto_rawDescData []byte
+)
+
+func file_order_order_events_proto_rawDescGZIP() []byte {
+	file_order_order_events_proto_rawDescOnce.Do(func() {
+		file_order_order_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_order_order_events_proto_rawDesc), len(file_order_order_events_proto_rawDesc)))
+	})
+	return file_order_order_events_proto_rawDescData
+}
+
+var file_order_order_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_order_order_events_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_order_order_events_proto_goTypes = []any{
+	(EventType)(0),                // 0: order.EventType
+	(*EventEnvelope)(nil),         // 1: order.EventEnvelope
+	(*EventAmounts)(nil),          // 2: order.EventAmounts
+	(*EventRequisite)(nil),        // 3: order.EventRequisite
+	(*DisputeEventData)(nil),      // 4: order.DisputeEventData
+	(*BankDetailEventData)(nil),   // 5: order.BankDetailEventData
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_order_order_events_proto_depIdxs = []int32{
+	0, // 0: order.EventEnvelope.event_type:type_name -> order.EventType
+	6, // 1: order.EventEnvelope.occurred_at:type_name -> google.protobuf.Timestamp
+	2, // 2: order.EventEnvelope.amounts:type_name -> order.EventAmounts
+	3, // 3: order.EventEnvelope.requisite:type_name -> order.EventRequisite
+	4, // 4: order.EventEnvelope.dispute:type_name -> order.DisputeEventData
+	5, // 5: order.EventEnvelope.bank_detail:type_name -> order.BankDetailEventData
+	6, // 6: order.BankDetailEventData.suspended_until:type_name -> google.protobuf.Timestamp
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_order_order_events_proto_init() }
+func file_order_order_events_proto_init() {
+	if File_order_order_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_order_events_proto_rawDesc), len(file_order_order_events_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_order_order_events_proto_goTypes,
+		DependencyIndexes: file_order_order_events_proto_depIdxs,
+		EnumInfos:         file_order_order_events_proto_enumTypes,
+		MessageInfos:      file_order_order_events_proto_msgTypes,
+	}.Build()
+	File_order_order_events_proto = out.File
+	file_order_order_events_proto_goTypes = nil
+	file_order_order_events_proto_depIdxs = nil
+}
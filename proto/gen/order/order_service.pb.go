@@ -23,27 +23,27 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type AcceptOrderRequest struct {
+type GetOrderHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AcceptOrderRequest) Reset() {
-	*x = AcceptOrderRequest{}
+func (x *GetOrderHistoryRequest) Reset() {
+	*x = GetOrderHistoryRequest{}
 	mi := &file_order_order_service_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AcceptOrderRequest) String() string {
+func (x *GetOrderHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AcceptOrderRequest) ProtoMessage() {}
+func (*GetOrderHistoryRequest) ProtoMessage() {}
 
-func (x *AcceptOrderRequest) ProtoReflect() protoreflect.Message {
+func (x *GetOrderHistoryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_order_order_service_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -55,38 +55,46 @@ func (x *AcceptOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AcceptOrderRequest.ProtoReflect.Descriptor instead.
-func (*AcceptOrderRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetOrderHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryRequest) Descriptor() ([]byte, []int) {
 	return file_order_order_service_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *AcceptOrderRequest) GetOrderId() string {
+func (x *GetOrderHistoryRequest) GetOrderId() string {
 	if x != nil {
 		return x.OrderId
 	}
 	return ""
 }
 
-type AcceptOrderResponse struct {
+type OrderStatusTransition struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	FromStatus    string                 `protobuf:"bytes,3,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"` // пустая строка - создание сделки
+	ToStatus      string                 `protobuf:"bytes,4,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"` // system, merchant, operator, automatic, scheduler
+	Operation     string                 `protobuf:"bytes,6,opt,name=operation,proto3" json:"operation,omitempty"`
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AcceptOrderResponse) Reset() {
-	*x = AcceptOrderResponse{}
+func (x *OrderStatusTransition) Reset() {
+	*x = OrderStatusTransition{}
 	mi := &file_order_order_service_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AcceptOrderResponse) String() string {
+func (x *OrderStatusTransition) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AcceptOrderResponse) ProtoMessage() {}
+func (*OrderStatusTransition) ProtoMessage() {}
 
-func (x *AcceptOrderResponse) ProtoReflect() protoreflect.Message {
+func (x *OrderStatusTransition) ProtoReflect() protoreflect.Message {
 	mi := &file_order_order_service_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -98,39 +106,88 @@ func (x *AcceptOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AcceptOrderResponse.ProtoReflect.Descriptor instead.
-func (*AcceptOrderResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderStatusTransition.ProtoReflect.Descriptor instead.
+func (*OrderStatusTransition) Descriptor() ([]byte, []int) {
 	return file_order_order_service_proto_rawDescGZIP(), []int{1}
 }
 
-type CreatePayOutOrderRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	MerchantId      string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
-	ClientId        string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	MerchantOrderId string                 `protobuf:"bytes,4,opt,name=merchant_order_id,json=merchantOrderId,proto3" json:"merchant_order_id,omitempty"`
-	Shuffle         int32                  `protobuf:"varint,5,opt,name=shuffle,proto3" json:"shuffle,omitempty"`
-	CallbackUrl     string                 `protobuf:"bytes,6,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
-	Type            string                 `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
-	PaymentDetails  *PaymentDetails        `protobuf:"bytes,8,opt,name=payment_details,json=paymentDetails,proto3" json:"payment_details,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *OrderStatusTransition) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
 }
 
-func (x *CreatePayOutOrderRequest) Reset() {
-	*x = CreatePayOutOrderRequest{}
+func (x *OrderStatusTransition) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderStatusTransition) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusTransition) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusTransition) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *OrderStatusTransition) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *OrderStatusTransition) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderStatusTransition) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetOrderHistoryResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Transitions   []*OrderStatusTransition `protobuf:"bytes,1,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryResponse) Reset() {
+	*x = GetOrderHistoryResponse{}
 	mi := &file_order_order_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePayOutOrderRequest) String() string {
+func (x *GetOrderHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePayOutOrderRequest) ProtoMessage() {}
+func (*GetOrderHistoryResponse) ProtoMessage() {}
 
-func (x *CreatePayOutOrderRequest) ProtoReflect() protoreflect.Message {
+func (x *GetOrderHistoryResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_order_order_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -142,95 +199,145 @@ func (x *CreatePayOutOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePayOutOrderRequest.ProtoReflect.Descriptor instead.
-func (*CreatePayOutOrderRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetOrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryResponse) Descriptor() ([]byte, []int) {
 	return file_order_order_service_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CreatePayOutOrderRequest) GetMerchantId() string {
+func (x *GetOrderHistoryResponse) GetTransitions() []*OrderStatusTransition {
 	if x != nil {
-		return x.MerchantId
+		return x.Transitions
 	}
-	return ""
+	return nil
 }
 
-func (x *CreatePayOutOrderRequest) GetClientId() string {
-	if x != nil {
-		return x.ClientId
-	}
-	return ""
+// Закрытие отмененной пай-ин сделки, оплаченной клиентом после отмены
+type RecoverCanceledOrderRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderId        string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	PaidAmountFiat float64                `protobuf:"fixed64,2,opt,name=paid_amount_fiat,json=paidAmountFiat,proto3" json:"paid_amount_fiat,omitempty"` // фактически оплаченная сумма, 0 - сумма сделки
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *CreatePayOutOrderRequest) GetExpiresAt() *timestamppb.Timestamp {
+func (x *RecoverCanceledOrderRequest) Reset() {
+	*x = RecoverCanceledOrderRequest{}
+	mi := &file_order_order_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoverCanceledOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoverCanceledOrderRequest) ProtoMessage() {}
+
+func (x *RecoverCanceledOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[3]
 	if x != nil {
-		return x.ExpiresAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *CreatePayOutOrderRequest) GetMerchantOrderId() string {
+// Deprecated: Use RecoverCanceledOrderRequest.ProtoReflect.Descriptor instead.
+func (*RecoverCanceledOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecoverCanceledOrderRequest) GetOrderId() string {
 	if x != nil {
-		return x.MerchantOrderId
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *CreatePayOutOrderRequest) GetShuffle() int32 {
+func (x *RecoverCanceledOrderRequest) GetPaidAmountFiat() float64 {
 	if x != nil {
-		return x.Shuffle
+		return x.PaidAmountFiat
 	}
 	return 0
 }
 
-func (x *CreatePayOutOrderRequest) GetCallbackUrl() string {
+func (x *RecoverCanceledOrderRequest) GetReason() string {
 	if x != nil {
-		return x.CallbackUrl
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *CreatePayOutOrderRequest) GetType() string {
+type RecoverCanceledOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoverCanceledOrderResponse) Reset() {
+	*x = RecoverCanceledOrderResponse{}
+	mi := &file_order_order_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoverCanceledOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoverCanceledOrderResponse) ProtoMessage() {}
+
+func (x *RecoverCanceledOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[4]
 	if x != nil {
-		return x.Type
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CreatePayOutOrderRequest) GetPaymentDetails() *PaymentDetails {
+// Deprecated: Use RecoverCanceledOrderResponse.ProtoReflect.Descriptor instead.
+func (*RecoverCanceledOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RecoverCanceledOrderResponse) GetMessage() string {
 	if x != nil {
-		return x.PaymentDetails
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-type PaymentDetails struct {
+type GetCallbackDeliveriesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CardNumber    string                 `protobuf:"bytes,1,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
-	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
-	Owner         string                 `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
-	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
-	AmountFiat    float64                `protobuf:"fixed64,5,opt,name=amount_fiat,json=amountFiat,proto3" json:"amount_fiat,omitempty"`
-	PaymentSystem string                 `protobuf:"bytes,6,opt,name=payment_system,json=paymentSystem,proto3" json:"payment_system,omitempty"`
-	BankInfo      *BankInfo              `protobuf:"bytes,7,opt,name=bank_info,json=bankInfo,proto3" json:"bank_info,omitempty"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PaymentDetails) Reset() {
-	*x = PaymentDetails{}
-	mi := &file_order_order_service_proto_msgTypes[3]
+func (x *GetCallbackDeliveriesRequest) Reset() {
+	*x = GetCallbackDeliveriesRequest{}
+	mi := &file_order_order_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PaymentDetails) String() string {
+func (x *GetCallbackDeliveriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PaymentDetails) ProtoMessage() {}
+func (*GetCallbackDeliveriesRequest) ProtoMessage() {}
 
-func (x *PaymentDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[3]
+func (x *GetCallbackDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -241,84 +348,2705 @@ func (x *PaymentDetails) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PaymentDetails.ProtoReflect.Descriptor instead.
-func (*PaymentDetails) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetCallbackDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*GetCallbackDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *PaymentDetails) GetCardNumber() string {
+func (x *GetCallbackDeliveriesRequest) GetOrderId() string {
 	if x != nil {
-		return x.CardNumber
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *PaymentDetails) GetPhone() string {
+type CallbackDelivery struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId         string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	MerchantOrderId string                 `protobuf:"bytes,3,opt,name=merchant_order_id,json=merchantOrderId,proto3" json:"merchant_order_id,omitempty"`
+	CallbackUrl     string                 `protobuf:"bytes,4,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	OrderStatus     string                 `protobuf:"bytes,5,opt,name=order_status,json=orderStatus,proto3" json:"order_status,omitempty"`
+	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts        int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastHttpCode    int32                  `protobuf:"varint,8,opt,name=last_http_code,json=lastHttpCode,proto3" json:"last_http_code,omitempty"`
+	LastError       string                 `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	NextAttemptAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	DeliveredAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CallbackDelivery) Reset() {
+	*x = CallbackDelivery{}
+	mi := &file_order_order_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallbackDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallbackDelivery) ProtoMessage() {}
+
+func (x *CallbackDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[6]
 	if x != nil {
-		return x.Phone
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallbackDelivery.ProtoReflect.Descriptor instead.
+func (*CallbackDelivery) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CallbackDelivery) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *PaymentDetails) GetOwner() string {
+func (x *CallbackDelivery) GetOrderId() string {
 	if x != nil {
-		return x.Owner
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CallbackDelivery) GetMerchantOrderId() string {
+	if x != nil {
+		return x.MerchantOrderId
+	}
+	return ""
+}
+
+func (x *CallbackDelivery) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+func (x *CallbackDelivery) GetOrderStatus() string {
+	if x != nil {
+		return x.OrderStatus
+	}
+	return ""
+}
+
+func (x *CallbackDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CallbackDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *CallbackDelivery) GetLastHttpCode() int32 {
+	if x != nil {
+		return x.LastHttpCode
+	}
+	return 0
+}
+
+func (x *CallbackDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
 	}
 	return ""
 }
 
-func (x *PaymentDetails) GetCurrency() string {
+func (x *CallbackDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *CallbackDelivery) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+func (x *CallbackDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetCallbackDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*CallbackDelivery    `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCallbackDeliveriesResponse) Reset() {
+	*x = GetCallbackDeliveriesResponse{}
+	mi := &file_order_order_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCallbackDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCallbackDeliveriesResponse) ProtoMessage() {}
+
+func (x *GetCallbackDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCallbackDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*GetCallbackDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCallbackDeliveriesResponse) GetDeliveries() []*CallbackDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type ResendCallbackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId    string                 `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendCallbackRequest) Reset() {
+	*x = ResendCallbackRequest{}
+	mi := &file_order_order_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendCallbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendCallbackRequest) ProtoMessage() {}
+
+func (x *ResendCallbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendCallbackRequest.ProtoReflect.Descriptor instead.
+func (*ResendCallbackRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ResendCallbackRequest) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+type ResendCallbackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delivery      *CallbackDelivery      `protobuf:"bytes,1,opt,name=delivery,proto3" json:"delivery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendCallbackResponse) Reset() {
+	*x = ResendCallbackResponse{}
+	mi := &file_order_order_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendCallbackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendCallbackResponse) ProtoMessage() {}
+
+func (x *ResendCallbackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendCallbackResponse.ProtoReflect.Descriptor instead.
+func (*ResendCallbackResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ResendCallbackResponse) GetDelivery() *CallbackDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+type MerchantCallbackSettings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId    string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"` // GET | JSON
+	Signed        bool                   `protobuf:"varint,3,opt,name=signed,proto3" json:"signed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MerchantCallbackSettings) Reset() {
+	*x = MerchantCallbackSettings{}
+	mi := &file_order_order_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MerchantCallbackSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MerchantCallbackSettings) ProtoMessage() {}
+
+func (x *MerchantCallbackSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MerchantCallbackSettings.ProtoReflect.Descriptor instead.
+func (*MerchantCallbackSettings) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MerchantCallbackSettings) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *MerchantCallbackSettings) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *MerchantCallbackSettings) GetSigned() bool {
+	if x != nil {
+		return x.Signed
+	}
+	return false
+}
+
+type GetMerchantCallbackSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId    string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMerchantCallbackSettingsRequest) Reset() {
+	*x = GetMerchantCallbackSettingsRequest{}
+	mi := &file_order_order_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMerchantCallbackSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMerchantCallbackSettingsRequest) ProtoMessage() {}
+
+func (x *GetMerchantCallbackSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMerchantCallbackSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetMerchantCallbackSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetMerchantCallbackSettingsRequest) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+type GetMerchantCallbackSettingsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Settings      *MerchantCallbackSettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMerchantCallbackSettingsResponse) Reset() {
+	*x = GetMerchantCallbackSettingsResponse{}
+	mi := &file_order_order_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMerchantCallbackSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMerchantCallbackSettingsResponse) ProtoMessage() {}
+
+func (x *GetMerchantCallbackSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMerchantCallbackSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetMerchantCallbackSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetMerchantCallbackSettingsResponse) GetSettings() *MerchantCallbackSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetMerchantCallbackSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId    string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	RotateSecret  bool                   `protobuf:"varint,3,opt,name=rotate_secret,json=rotateSecret,proto3" json:"rotate_secret,omitempty"`
+	RemoveSecret  bool                   `protobuf:"varint,4,opt,name=remove_secret,json=removeSecret,proto3" json:"remove_secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMerchantCallbackSettingsRequest) Reset() {
+	*x = SetMerchantCallbackSettingsRequest{}
+	mi := &file_order_order_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMerchantCallbackSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMerchantCallbackSettingsRequest) ProtoMessage() {}
+
+func (x *SetMerchantCallbackSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMerchantCallbackSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetMerchantCallbackSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SetMerchantCallbackSettingsRequest) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *SetMerchantCallbackSettingsRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *SetMerchantCallbackSettingsRequest) GetRotateSecret() bool {
+	if x != nil {
+		return x.RotateSecret
+	}
+	return false
+}
+
+func (x *SetMerchantCallbackSettingsRequest) GetRemoveSecret() bool {
+	if x != nil {
+		return x.RemoveSecret
+	}
+	return false
+}
+
+type SetMerchantCallbackSettingsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Settings      *MerchantCallbackSettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	Secret        string                    `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"` // возвращается только при rotate_secret
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMerchantCallbackSettingsResponse) Reset() {
+	*x = SetMerchantCallbackSettingsResponse{}
+	mi := &file_order_order_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMerchantCallbackSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMerchantCallbackSettingsResponse) ProtoMessage() {}
+
+func (x *SetMerchantCallbackSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMerchantCallbackSettingsResponse.ProtoReflect.Descriptor instead.
+func (*SetMerchantCallbackSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetMerchantCallbackSettingsResponse) GetSettings() *MerchantCallbackSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *SetMerchantCallbackSettingsResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+// Ожидание реквизитов: сделка без реквизитов ждет в статусе WAITING вместо FAILED
+type MerchantMatchingSettings struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId        string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	WaitForRequisites bool                   `protobuf:"varint,2,opt,name=wait_for_requisites,json=waitForRequisites,proto3" json:"wait_for_requisites,omitempty"`
+	WaitWindow        *durationpb.Duration   `protobuf:"bytes,3,opt,name=wait_window,json=waitWindow,proto3" json:"wait_window,omitempty"` // не задано - окно по умолчанию
+	// weighted_random, round_robin, least_recently_assigned, lowest_load, highest_completion_rate.
+	// Пусто - стратегия по умолчанию из конфигурации
+	SelectionStrategy string `protobuf:"bytes,4,opt,name=selection_strategy,json=selectionStrategy,proto3" json:"selection_strategy,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MerchantMatchingSettings) Reset() {
+	*x = MerchantMatchingSettings{}
+	mi := &file_order_order_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MerchantMatchingSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MerchantMatchingSettings) ProtoMessage() {}
+
+func (x *MerchantMatchingSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MerchantMatchingSettings.ProtoReflect.Descriptor instead.
+func (*MerchantMatchingSettings) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MerchantMatchingSettings) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *MerchantMatchingSettings) GetWaitForRequisites() bool {
+	if x != nil {
+		return x.WaitForRequisites
+	}
+	return false
+}
+
+func (x *MerchantMatchingSettings) GetWaitWindow() *durationpb.Duration {
+	if x != nil {
+		return x.WaitWindow
+	}
+	return nil
+}
+
+func (x *MerchantMatchingSettings) GetSelectionStrategy() string {
+	if x != nil {
+		return x.SelectionStrategy
+	}
+	return ""
+}
+
+type GetMerchantMatchingSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId    string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMerchantMatchingSettingsRequest) Reset() {
+	*x = GetMerchantMatchingSettingsRequest{}
+	mi := &file_order_order_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMerchantMatchingSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMerchantMatchingSettingsRequest) ProtoMessage() {}
+
+func (x *GetMerchantMatchingSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMerchantMatchingSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetMerchantMatchingSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetMerchantMatchingSettingsRequest) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+type GetMerchantMatchingSettingsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Settings      *MerchantMatchingSettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMerchantMatchingSettingsResponse) Reset() {
+	*x = GetMerchantMatchingSettingsResponse{}
+	mi := &file_order_order_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMerchantMatchingSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMerchantMatchingSettingsResponse) ProtoMessage() {}
+
+func (x *GetMerchantMatchingSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMerchantMatchingSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetMerchantMatchingSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetMerchantMatchingSettingsResponse) GetSettings() *MerchantMatchingSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetMerchantMatchingSettingsRequest struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Settings      *MerchantMatchingSettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMerchantMatchingSettingsRequest) Reset() {
+	*x = SetMerchantMatchingSettingsRequest{}
+	mi := &file_order_order_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMerchantMatchingSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMerchantMatchingSettingsRequest) ProtoMessage() {}
+
+func (x *SetMerchantMatchingSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMerchantMatchingSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetMerchantMatchingSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetMerchantMatchingSettingsRequest) GetSettings() *MerchantMatchingSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetMerchantMatchingSettingsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Settings      *MerchantMatchingSettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMerchantMatchingSettingsResponse) Reset() {
+	*x = SetMerchantMatchingSettingsResponse{}
+	mi := &file_order_order_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMerchantMatchingSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMerchantMatchingSettingsResponse) ProtoMessage() {}
+
+func (x *SetMerchantMatchingSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMerchantMatchingSettingsResponse.ProtoReflect.Descriptor instead.
+func (*SetMerchantMatchingSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetMerchantMatchingSettingsResponse) GetSettings() *MerchantMatchingSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// Скор маршрутизации - множитель веса реквизита или трейдера по истории пай-ин сделок
+type RoutingScore struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	SubjectType        string                 `protobuf:"bytes,1,opt,name=subject_type,json=subjectType,proto3" json:"subject_type,omitempty"` // bank_detail, trader
+	SubjectId          string                 `protobuf:"bytes,2,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`
+	TraderId           string                 `protobuf:"bytes,3,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	Score              float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`                                         // рассчитанный
+	Override           *float64               `protobuf:"fixed64,5,opt,name=override,proto3,oneof" json:"override,omitempty"`                             // задан админом
+	EffectiveScore     float64                `protobuf:"fixed64,6,opt,name=effective_score,json=effectiveScore,proto3" json:"effective_score,omitempty"` // с учетом переопределения
+	Orders             int32                  `protobuf:"varint,7,opt,name=orders,proto3" json:"orders,omitempty"`                                        // закрытые сделки за окно расчета
+	CompletionRate     float64                `protobuf:"fixed64,8,opt,name=completion_rate,json=completionRate,proto3" json:"completion_rate,omitempty"`
+	MedianCompleteTime *durationpb.Duration   `protobuf:"bytes,9,opt,name=median_complete_time,json=medianCompleteTime,proto3" json:"median_complete_time,omitempty"`
+	DisputeRate        float64                `protobuf:"fixed64,10,opt,name=dispute_rate,json=disputeRate,proto3" json:"dispute_rate,omitempty"`
+	AutomaticShare     float64                `protobuf:"fixed64,11,opt,name=automatic_share,json=automaticShare,proto3" json:"automatic_share,omitempty"`
+	CalculatedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=calculated_at,json=calculatedAt,proto3" json:"calculated_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RoutingScore) Reset() {
+	*x = RoutingScore{}
+	mi := &file_order_order_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoutingScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoutingScore) ProtoMessage() {}
+
+func (x *RoutingScore) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoutingScore.ProtoReflect.Descriptor instead.
+func (*RoutingScore) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RoutingScore) GetSubjectType() string {
+	if x != nil {
+		return x.SubjectType
+	}
+	return ""
+}
+
+func (x *RoutingScore) GetSubjectId() string {
+	if x != nil {
+		return x.SubjectId
+	}
+	return ""
+}
+
+func (x *RoutingScore) GetTraderId() string {
+	if x != nil {
+		return x.TraderId
+	}
+	return ""
+}
+
+func (x *RoutingScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RoutingScore) GetOverride() float64 {
+	if x != nil && x.Override != nil {
+		return *x.Override
+	}
+	return 0
+}
+
+func (x *RoutingScore) GetEffectiveScore() float64 {
+	if x != nil {
+		return x.EffectiveScore
+	}
+	return 0
+}
+
+func (x *RoutingScore) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *RoutingScore) GetCompletionRate() float64 {
+	if x != nil {
+		return x.CompletionRate
+	}
+	return 0
+}
+
+func (x *RoutingScore) GetMedianCompleteTime() *durationpb.Duration {
+	if x != nil {
+		return x.MedianCompleteTime
+	}
+	return nil
+}
+
+func (x *RoutingScore) GetDisputeRate() float64 {
+	if x != nil {
+		return x.DisputeRate
+	}
+	return 0
+}
+
+func (x *RoutingScore) GetAutomaticShare() float64 {
+	if x != nil {
+		return x.AutomaticShare
+	}
+	return 0
+}
+
+func (x *RoutingScore) GetCalculatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CalculatedAt
+	}
+	return nil
+}
+
+type GetRoutingScoresRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubjectType   string                 `protobuf:"bytes,1,opt,name=subject_type,json=subjectType,proto3" json:"subject_type,omitempty"` // пусто - все
+	TraderId      string                 `protobuf:"bytes,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoutingScoresRequest) Reset() {
+	*x = GetRoutingScoresRequest{}
+	mi := &file_order_order_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoutingScoresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoutingScoresRequest) ProtoMessage() {}
+
+func (x *GetRoutingScoresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoutingScoresRequest.ProtoReflect.Descriptor instead.
+func (*GetRoutingScoresRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetRoutingScoresRequest) GetSubjectType() string {
+	if x != nil {
+		return x.SubjectType
+	}
+	return ""
+}
+
+func (x *GetRoutingScoresRequest) GetTraderId() string {
+	if x != nil {
+		return x.TraderId
+	}
+	return ""
+}
+
+func (x *GetRoutingScoresRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetRoutingScoresRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetRoutingScoresResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scores        []*RoutingScore        `protobuf:"bytes,1,rep,name=scores,proto3" json:"scores,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoutingScoresResponse) Reset() {
+	*x = GetRoutingScoresResponse{}
+	mi := &file_order_order_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoutingScoresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoutingScoresResponse) ProtoMessage() {}
+
+func (x *GetRoutingScoresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoutingScoresResponse.ProtoReflect.Descriptor instead.
+func (*GetRoutingScoresResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetRoutingScoresResponse) GetScores() []*RoutingScore {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *GetRoutingScoresResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type SetRoutingScoreOverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubjectType   string                 `protobuf:"bytes,1,opt,name=subject_type,json=subjectType,proto3" json:"subject_type,omitempty"`
+	SubjectId     string                 `protobuf:"bytes,2,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`
+	Override      *float64               `protobuf:"fixed64,3,opt,name=override,proto3,oneof" json:"override,omitempty"` // не задано - вернуть рассчитанный скор
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRoutingScoreOverrideRequest) Reset() {
+	*x = SetRoutingScoreOverrideRequest{}
+	mi := &file_order_order_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRoutingScoreOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRoutingScoreOverrideRequest) ProtoMessage() {}
+
+func (x *SetRoutingScoreOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRoutingScoreOverrideRequest.ProtoReflect.Descriptor instead.
+func (*SetRoutingScoreOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetRoutingScoreOverrideRequest) GetSubjectType() string {
+	if x != nil {
+		return x.SubjectType
+	}
+	return ""
+}
+
+func (x *SetRoutingScoreOverrideRequest) GetSubjectId() string {
+	if x != nil {
+		return x.SubjectId
+	}
+	return ""
+}
+
+func (x *SetRoutingScoreOverrideRequest) GetOverride() float64 {
+	if x != nil && x.Override != nil {
+		return *x.Override
+	}
+	return 0
+}
+
+type SetRoutingScoreOverrideResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Score         *RoutingScore          `protobuf:"bytes,1,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRoutingScoreOverrideResponse) Reset() {
+	*x = SetRoutingScoreOverrideResponse{}
+	mi := &file_order_order_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRoutingScoreOverrideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRoutingScoreOverrideResponse) ProtoMessage() {}
+
+func (x *SetRoutingScoreOverrideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRoutingScoreOverrideResponse.ProtoReflect.Descriptor instead.
+func (*SetRoutingScoreOverrideResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetRoutingScoreOverrideResponse) GetScore() *RoutingScore {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+// Диагностика подбора реквизита: этапы static, limits, traffic, balance, weighting
+type BankDetailSelectionCandidate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BankDetailId  string                 `protobuf:"bytes,1,opt,name=bank_detail_id,json=bankDetailId,proto3" json:"bank_detail_id,omitempty"`
+	TraderId      string                 `protobuf:"bytes,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	Stage         string                 `protobuf:"bytes,3,opt,name=stage,proto3" json:"stage,omitempty"`   // этап, на котором кандидат отсеян, weighting - прошел все фильтры
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // max_simultaneous, duplicate_order, trader_locked, insufficient_balance ...
+	Eliminated    bool                   `protobuf:"varint,5,opt,name=eliminated,proto3" json:"eliminated,omitempty"`
+	Weight        float64                `protobuf:"fixed64,6,opt,name=weight,proto3" json:"weight,omitempty"`           // приоритет трейдера в трафике
+	Probability   float64                `protobuf:"fixed64,7,opt,name=probability,proto3" json:"probability,omitempty"` // вероятность выбора среди прошедших кандидатов (только для weighted_random)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BankDetailSelectionCandidate) Reset() {
+	*x = BankDetailSelectionCandidate{}
+	mi := &file_order_order_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BankDetailSelectionCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BankDetailSelectionCandidate) ProtoMessage() {}
+
+func (x *BankDetailSelectionCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BankDetailSelectionCandidate.ProtoReflect.Descriptor instead.
+func (*BankDetailSelectionCandidate) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BankDetailSelectionCandidate) GetBankDetailId() string {
+	if x != nil {
+		return x.BankDetailId
+	}
+	return ""
+}
+
+func (x *BankDetailSelectionCandidate) GetTraderId() string {
+	if x != nil {
+		return x.TraderId
+	}
+	return ""
+}
+
+func (x *BankDetailSelectionCandidate) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *BankDetailSelectionCandidate) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BankDetailSelectionCandidate) GetEliminated() bool {
+	if x != nil {
+		return x.Eliminated
+	}
+	return false
+}
+
+func (x *BankDetailSelectionCandidate) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *BankDetailSelectionCandidate) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+type ExplainBankDetailSelectionResponse struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Candidates    []*BankDetailSelectionCandidate `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	EligibleCount int32                           `protobuf:"varint,2,opt,name=eligible_count,json=eligibleCount,proto3" json:"eligible_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainBankDetailSelectionResponse) Reset() {
+	*x = ExplainBankDetailSelectionResponse{}
+	mi := &file_order_order_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainBankDetailSelectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainBankDetailSelectionResponse) ProtoMessage() {}
+
+func (x *ExplainBankDetailSelectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainBankDetailSelectionResponse.ProtoReflect.Descriptor instead.
+func (*ExplainBankDetailSelectionResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ExplainBankDetailSelectionResponse) GetCandidates() []*BankDetailSelectionCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *ExplainBankDetailSelectionResponse) GetEligibleCount() int32 {
+	if x != nil {
+		return x.EligibleCount
+	}
+	return 0
+}
+
+type AcceptOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptOrderRequest) Reset() {
+	*x = AcceptOrderRequest{}
+	mi := &file_order_order_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptOrderRequest) ProtoMessage() {}
+
+func (x *AcceptOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptOrderRequest.ProtoReflect.Descriptor instead.
+func (*AcceptOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AcceptOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type AcceptOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptOrderResponse) Reset() {
+	*x = AcceptOrderResponse{}
+	mi := &file_order_order_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptOrderResponse) ProtoMessage() {}
+
+func (x *AcceptOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptOrderResponse.ProtoReflect.Descriptor instead.
+func (*AcceptOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{28}
+}
+
+type CreatePayOutOrderRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId      string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	ClientId        string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	MerchantOrderId string                 `protobuf:"bytes,4,opt,name=merchant_order_id,json=merchantOrderId,proto3" json:"merchant_order_id,omitempty"`
+	Shuffle         int32                  `protobuf:"varint,5,opt,name=shuffle,proto3" json:"shuffle,omitempty"`
+	CallbackUrl     string                 `protobuf:"bytes,6,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	Type            string                 `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
+	PaymentDetails  *PaymentDetails        `protobuf:"bytes,8,opt,name=payment_details,json=paymentDetails,proto3" json:"payment_details,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePayOutOrderRequest) Reset() {
+	*x = CreatePayOutOrderRequest{}
+	mi := &file_order_order_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePayOutOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePayOutOrderRequest) ProtoMessage() {}
+
+func (x *CreatePayOutOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePayOutOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreatePayOutOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreatePayOutOrderRequest) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *CreatePayOutOrderRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *CreatePayOutOrderRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreatePayOutOrderRequest) GetMerchantOrderId() string {
+	if x != nil {
+		return x.MerchantOrderId
+	}
+	return ""
+}
+
+func (x *CreatePayOutOrderRequest) GetShuffle() int32 {
+	if x != nil {
+		return x.Shuffle
+	}
+	return 0
+}
+
+func (x *CreatePayOutOrderRequest) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+func (x *CreatePayOutOrderRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreatePayOutOrderRequest) GetPaymentDetails() *PaymentDetails {
+	if x != nil {
+		return x.PaymentDetails
+	}
+	return nil
+}
+
+type PaymentDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardNumber    string                 `protobuf:"bytes,1,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	Owner         string                 `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	AmountFiat    float64                `protobuf:"fixed64,5,opt,name=amount_fiat,json=amountFiat,proto3" json:"amount_fiat,omitempty"`
+	PaymentSystem string                 `protobuf:"bytes,6,opt,name=payment_system,json=paymentSystem,proto3" json:"payment_system,omitempty"`
+	BankInfo      *BankInfo              `protobuf:"bytes,7,opt,name=bank_info,json=bankInfo,proto3" json:"bank_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentDetails) Reset() {
+	*x = PaymentDetails{}
+	mi := &file_order_order_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentDetails) ProtoMessage() {}
+
+func (x *PaymentDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentDetails.ProtoReflect.Descriptor instead.
+func (*PaymentDetails) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PaymentDetails) GetCardNumber() string {
+	if x != nil {
+		return x.CardNumber
+	}
+	return ""
+}
+
+func (x *PaymentDetails) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *PaymentDetails) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *PaymentDetails) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PaymentDetails) GetAmountFiat() float64 {
+	if x != nil {
+		return x.AmountFiat
+	}
+	return 0
+}
+
+func (x *PaymentDetails) GetPaymentSystem() string {
+	if x != nil {
+		return x.PaymentSystem
+	}
+	return ""
+}
+
+func (x *PaymentDetails) GetBankInfo() *BankInfo {
+	if x != nil {
+		return x.BankInfo
+	}
+	return nil
+}
+
+type BankInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BankCode      string                 `protobuf:"bytes,1,opt,name=bank_code,json=bankCode,proto3" json:"bank_code,omitempty"`
+	BankName      string                 `protobuf:"bytes,2,opt,name=bank_name,json=bankName,proto3" json:"bank_name,omitempty"`
+	NspkCode      string                 `protobuf:"bytes,3,opt,name=nspk_code,json=nspkCode,proto3" json:"nspk_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BankInfo) Reset() {
+	*x = BankInfo{}
+	mi := &file_order_order_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BankInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BankInfo) ProtoMessage() {}
+
+func (x *BankInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BankInfo.ProtoReflect.Descriptor instead.
+func (*BankInfo) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BankInfo) GetBankCode() string {
+	if x != nil {
+		return x.BankCode
+	}
+	return ""
+}
+
+func (x *BankInfo) GetBankName() string {
+	if x != nil {
+		return x.BankName
+	}
+	return ""
+}
+
+func (x *BankInfo) GetNspkCode() string {
+	if x != nil {
+		return x.NspkCode
+	}
+	return ""
+}
+
+type CreatePayOutOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePayOutOrderResponse) Reset() {
+	*x = CreatePayOutOrderResponse{}
+	mi := &file_order_order_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePayOutOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePayOutOrderResponse) ProtoMessage() {}
+
+func (x *CreatePayOutOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePayOutOrderResponse.ProtoReflect.Descriptor instead.
+func (*CreatePayOutOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreatePayOutOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// NotificationTemplate - шаблон SMS или push-уведомления банка. pattern - регулярное выражение (RE2)
+// с именованными группами amount (обязательна), currency, sender, card_tail
+type NotificationTemplate struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BankCode        string                 `protobuf:"bytes,2,opt,name=bank_code,json=bankCode,proto3" json:"bank_code,omitempty"` // как payment_system в ProcessAutomaticPaymentRequest
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Pattern         string                 `protobuf:"bytes,4,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Direction       string                 `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"` // in, out
+	DefaultCurrency string                 `protobuf:"bytes,6,opt,name=default_currency,json=defaultCurrency,proto3" json:"default_currency,omitempty"`
+	Priority        int32                  `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"` // шаблоны банка проверяются по убыванию приоритета
+	Enabled         bool                   `protobuf:"varint,8,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Samples         []*NotificationSample  `protobuf:"bytes,9,rep,name=samples,proto3" json:"samples,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *NotificationTemplate) Reset() {
+	*x = NotificationTemplate{}
+	mi := &file_order_order_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationTemplate) ProtoMessage() {}
+
+func (x *NotificationTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationTemplate.ProtoReflect.Descriptor instead.
+func (*NotificationTemplate) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *NotificationTemplate) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetBankCode() string {
+	if x != nil {
+		return x.BankCode
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetDefaultCurrency() string {
+	if x != nil {
+		return x.DefaultCurrency
+	}
+	return ""
+}
+
+func (x *NotificationTemplate) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *NotificationTemplate) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *NotificationTemplate) GetSamples() []*NotificationSample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+func (x *NotificationTemplate) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *NotificationTemplate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// NotificationSample - образец реального уведомления, шаблон не сохраняется, если образец разбирается иначе
+type NotificationSample struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Expected      *ParsedNotification    `protobuf:"bytes,2,opt,name=expected,proto3" json:"expected,omitempty"` // не задан - шаблон не должен разбирать этот текст
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationSample) Reset() {
+	*x = NotificationSample{}
+	mi := &file_order_order_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationSample) ProtoMessage() {}
+
+func (x *NotificationSample) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationSample.ProtoReflect.Descriptor instead.
+func (*NotificationSample) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *NotificationSample) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *NotificationSample) GetExpected() *ParsedNotification {
+	if x != nil {
+		return x.Expected
+	}
+	return nil
+}
+
+type ParsedNotification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	BankCode      string                 `protobuf:"bytes,2,opt,name=bank_code,json=bankCode,proto3" json:"bank_code,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	SenderName    string                 `protobuf:"bytes,5,opt,name=sender_name,json=senderName,proto3" json:"sender_name,omitempty"`
+	CardTail      string                 `protobuf:"bytes,6,opt,name=card_tail,json=cardTail,proto3" json:"card_tail,omitempty"`
+	Direction     string                 `protobuf:"bytes,7,opt,name=direction,proto3" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParsedNotification) Reset() {
+	*x = ParsedNotification{}
+	mi := &file_order_order_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParsedNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParsedNotification) ProtoMessage() {}
+
+func (x *ParsedNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParsedNotification.ProtoReflect.Descriptor instead.
+func (*ParsedNotification) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ParsedNotification) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *ParsedNotification) GetBankCode() string {
+	if x != nil {
+		return x.BankCode
+	}
+	return ""
+}
+
+func (x *ParsedNotification) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ParsedNotification) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ParsedNotification) GetSenderName() string {
+	if x != nil {
+		return x.SenderName
+	}
+	return ""
+}
+
+func (x *ParsedNotification) GetCardTail() string {
+	if x != nil {
+		return x.CardTail
+	}
+	return ""
+}
+
+func (x *ParsedNotification) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+type CreateNotificationTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *NotificationTemplate  `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateNotificationTemplateRequest) Reset() {
+	*x = CreateNotificationTemplateRequest{}
+	mi := &file_order_order_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNotificationTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNotificationTemplateRequest) ProtoMessage() {}
+
+func (x *CreateNotificationTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNotificationTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateNotificationTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateNotificationTemplateRequest) GetTemplate() *NotificationTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type CreateNotificationTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *NotificationTemplate  `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateNotificationTemplateResponse) Reset() {
+	*x = CreateNotificationTemplateResponse{}
+	mi := &file_order_order_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNotificationTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNotificationTemplateResponse) ProtoMessage() {}
+
+func (x *CreateNotificationTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNotificationTemplateResponse.ProtoReflect.Descriptor instead.
+func (*CreateNotificationTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CreateNotificationTemplateResponse) GetTemplate() *NotificationTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type UpdateNotificationTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *NotificationTemplate  `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationTemplateRequest) Reset() {
+	*x = UpdateNotificationTemplateRequest{}
+	mi := &file_order_order_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationTemplateRequest) ProtoMessage() {}
+
+func (x *UpdateNotificationTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationTemplateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UpdateNotificationTemplateRequest) GetTemplate() *NotificationTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type UpdateNotificationTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *NotificationTemplate  `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationTemplateResponse) Reset() {
+	*x = UpdateNotificationTemplateResponse{}
+	mi := &file_order_order_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationTemplateResponse) ProtoMessage() {}
+
+func (x *UpdateNotificationTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationTemplateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UpdateNotificationTemplateResponse) GetTemplate() *NotificationTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type DeleteNotificationTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNotificationTemplateRequest) Reset() {
+	*x = DeleteNotificationTemplateRequest{}
+	mi := &file_order_order_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotificationTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotificationTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteNotificationTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotificationTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNotificationTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteNotificationTemplateRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+type DeleteNotificationTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNotificationTemplateResponse) Reset() {
+	*x = DeleteNotificationTemplateResponse{}
+	mi := &file_order_order_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotificationTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotificationTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteNotificationTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotificationTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteNotificationTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{41}
+}
+
+type GetNotificationTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BankCode      string                 `protobuf:"bytes,1,opt,name=bank_code,json=bankCode,proto3" json:"bank_code,omitempty"` // пусто - все банки
+	EnabledOnly   bool                   `protobuf:"varint,2,opt,name=enabled_only,json=enabledOnly,proto3" json:"enabled_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationTemplatesRequest) Reset() {
+	*x = GetNotificationTemplatesRequest{}
+	mi := &file_order_order_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationTemplatesRequest) ProtoMessage() {}
+
+func (x *GetNotificationTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetNotificationTemplatesRequest) GetBankCode() string {
+	if x != nil {
+		return x.BankCode
+	}
+	return ""
+}
+
+func (x *GetNotificationTemplatesRequest) GetEnabledOnly() bool {
+	if x != nil {
+		return x.EnabledOnly
+	}
+	return false
+}
+
+type GetNotificationTemplatesResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Templates     []*NotificationTemplate `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationTemplatesResponse) Reset() {
+	*x = GetNotificationTemplatesResponse{}
+	mi := &file_order_order_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationTemplatesResponse) ProtoMessage() {}
+
+func (x *GetNotificationTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetNotificationTemplatesResponse) GetTemplates() []*NotificationTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+// TestNotificationTemplate разбирает текст шаблоном без сохранения
+type TestNotificationTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *NotificationTemplate  `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestNotificationTemplateRequest) Reset() {
+	*x = TestNotificationTemplateRequest{}
+	mi := &file_order_order_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestNotificationTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestNotificationTemplateRequest) ProtoMessage() {}
+
+func (x *TestNotificationTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestNotificationTemplateRequest.ProtoReflect.Descriptor instead.
+func (*TestNotificationTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *TestNotificationTemplateRequest) GetTemplate() *NotificationTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+func (x *TestNotificationTemplateRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type TestNotificationTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matched       bool                   `protobuf:"varint,1,opt,name=matched,proto3" json:"matched,omitempty"`
+	Parsed        *ParsedNotification    `protobuf:"bytes,2,opt,name=parsed,proto3" json:"parsed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestNotificationTemplateResponse) Reset() {
+	*x = TestNotificationTemplateResponse{}
+	mi := &file_order_order_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestNotificationTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestNotificationTemplateResponse) ProtoMessage() {}
+
+func (x *TestNotificationTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestNotificationTemplateResponse.ProtoReflect.Descriptor instead.
+func (*TestNotificationTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *TestNotificationTemplateResponse) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+func (x *TestNotificationTemplateResponse) GetParsed() *ParsedNotification {
+	if x != nil {
+		return x.Parsed
+	}
+	return nil
+}
+
+// UnmatchedPayment - уведомление об оплате без подходящей сделки
+type UnmatchedPayment struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeviceId          string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	TraderId          string                 `protobuf:"bytes,3,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	Amount            float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	PaymentSystem     string                 `protobuf:"bytes,5,opt,name=payment_system,json=paymentSystem,proto3" json:"payment_system,omitempty"`
+	Direction         string                 `protobuf:"bytes,6,opt,name=direction,proto3" json:"direction,omitempty"`
+	Text              string                 `protobuf:"bytes,7,opt,name=text,proto3" json:"text,omitempty"`
+	ReceivedAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	Status            string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"` // PENDING, LINKED
+	OrderId           string                 `protobuf:"bytes,10,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	LinkedBy          string                 `protobuf:"bytes,11,opt,name=linked_by,json=linkedBy,proto3" json:"linked_by,omitempty"` // automatic, operator
+	LinkedAt          *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=linked_at,json=linkedAt,proto3" json:"linked_at,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CandidateOrderIds []string               `protobuf:"bytes,14,rep,name=candidate_order_ids,json=candidateOrderIds,proto3" json:"candidate_order_ids,omitempty"` // сделки, которым платеж подходит одинаково
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UnmatchedPayment) Reset() {
+	*x = UnmatchedPayment{}
+	mi := &file_order_order_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmatchedPayment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmatchedPayment) ProtoMessage() {}
+
+func (x *UnmatchedPayment) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmatchedPayment.ProtoReflect.Descriptor instead.
+func (*UnmatchedPayment) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UnmatchedPayment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UnmatchedPayment) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *UnmatchedPayment) GetTraderId() string {
+	if x != nil {
+		return x.TraderId
+	}
+	return ""
+}
+
+func (x *UnmatchedPayment) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *UnmatchedPayment) GetPaymentSystem() string {
+	if x != nil {
+		return x.PaymentSystem
+	}
+	return ""
+}
+
+func (x *UnmatchedPayment) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *UnmatchedPayment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *UnmatchedPayment) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
+}
+
+func (x *UnmatchedPayment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UnmatchedPayment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UnmatchedPayment) GetLinkedBy() string {
+	if x != nil {
+		return x.LinkedBy
+	}
+	return ""
+}
+
+func (x *UnmatchedPayment) GetLinkedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LinkedAt
+	}
+	return nil
+}
+
+func (x *UnmatchedPayment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UnmatchedPayment) GetCandidateOrderIds() []string {
+	if x != nil {
+		return x.CandidateOrderIds
+	}
+	return nil
+}
+
+type ListUnmatchedPaymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	TraderId      string                 `protobuf:"bytes,2,opt,name=trader_id,json=traderId,proto3" json:"trader_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // пусто - все
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUnmatchedPaymentsRequest) Reset() {
+	*x = ListUnmatchedPaymentsRequest{}
+	mi := &file_order_order_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUnmatchedPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUnmatchedPaymentsRequest) ProtoMessage() {}
+
+func (x *ListUnmatchedPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUnmatchedPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListUnmatchedPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListUnmatchedPaymentsRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *ListUnmatchedPaymentsRequest) GetTraderId() string {
+	if x != nil {
+		return x.TraderId
+	}
+	return ""
+}
+
+func (x *ListUnmatchedPaymentsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListUnmatchedPaymentsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUnmatchedPaymentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListUnmatchedPaymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*UnmatchedPayment    `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUnmatchedPaymentsResponse) Reset() {
+	*x = ListUnmatchedPaymentsResponse{}
+	mi := &file_order_order_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUnmatchedPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUnmatchedPaymentsResponse) ProtoMessage() {}
+
+func (x *ListUnmatchedPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[48]
 	if x != nil {
-		return x.Currency
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *PaymentDetails) GetAmountFiat() float64 {
-	if x != nil {
-		return x.AmountFiat
-	}
-	return 0
+// Deprecated: Use ListUnmatchedPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListUnmatchedPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *PaymentDetails) GetPaymentSystem() string {
+func (x *ListUnmatchedPaymentsResponse) GetPayments() []*UnmatchedPayment {
 	if x != nil {
-		return x.PaymentSystem
+		return x.Payments
 	}
-	return ""
+	return nil
 }
 
-func (x *PaymentDetails) GetBankInfo() *BankInfo {
+func (x *ListUnmatchedPaymentsResponse) GetPagination() *Pagination {
 	if x != nil {
-		return x.BankInfo
+		return x.Pagination
 	}
 	return nil
 }
 
-type BankInfo struct {
+// LinkPaymentToOrder закрывает сделку в PENDING или восстанавливает отмененную с суммой платежа
+type LinkPaymentToOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BankCode      string                 `protobuf:"bytes,1,opt,name=bank_code,json=bankCode,proto3" json:"bank_code,omitempty"`
-	BankName      string                 `protobuf:"bytes,2,opt,name=bank_name,json=bankName,proto3" json:"bank_name,omitempty"`
-	NspkCode      string                 `protobuf:"bytes,3,opt,name=nspk_code,json=nspkCode,proto3" json:"nspk_code,omitempty"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BankInfo) Reset() {
-	*x = BankInfo{}
-	mi := &file_order_order_service_proto_msgTypes[4]
+func (x *LinkPaymentToOrderRequest) Reset() {
+	*x = LinkPaymentToOrderRequest{}
+	mi := &file_order_order_service_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BankInfo) String() string {
+func (x *LinkPaymentToOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BankInfo) ProtoMessage() {}
+func (*LinkPaymentToOrderRequest) ProtoMessage() {}
 
-func (x *BankInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[4]
+func (x *LinkPaymentToOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -329,54 +3057,54 @@ func (x *BankInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BankInfo.ProtoReflect.Descriptor instead.
-func (*BankInfo) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use LinkPaymentToOrderRequest.ProtoReflect.Descriptor instead.
+func (*LinkPaymentToOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *BankInfo) GetBankCode() string {
+func (x *LinkPaymentToOrderRequest) GetPaymentId() string {
 	if x != nil {
-		return x.BankCode
+		return x.PaymentId
 	}
 	return ""
 }
 
-func (x *BankInfo) GetBankName() string {
+func (x *LinkPaymentToOrderRequest) GetOrderId() string {
 	if x != nil {
-		return x.BankName
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *BankInfo) GetNspkCode() string {
+func (x *LinkPaymentToOrderRequest) GetReason() string {
 	if x != nil {
-		return x.NspkCode
+		return x.Reason
 	}
 	return ""
 }
 
-type CreatePayOutOrderResponse struct {
+type LinkPaymentToOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Payment       *UnmatchedPayment      `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreatePayOutOrderResponse) Reset() {
-	*x = CreatePayOutOrderResponse{}
-	mi := &file_order_order_service_proto_msgTypes[5]
+func (x *LinkPaymentToOrderResponse) Reset() {
+	*x = LinkPaymentToOrderResponse{}
+	mi := &file_order_order_service_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreatePayOutOrderResponse) String() string {
+func (x *LinkPaymentToOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePayOutOrderResponse) ProtoMessage() {}
+func (*LinkPaymentToOrderResponse) ProtoMessage() {}
 
-func (x *CreatePayOutOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[5]
+func (x *LinkPaymentToOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_service_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -387,14 +3115,14 @@ func (x *CreatePayOutOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePayOutOrderResponse.ProtoReflect.Descriptor instead.
-func (*CreatePayOutOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use LinkPaymentToOrderResponse.ProtoReflect.Descriptor instead.
+func (*LinkPaymentToOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_service_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *CreatePayOutOrderResponse) GetOrder() *Order {
+func (x *LinkPaymentToOrderResponse) GetPayment() *UnmatchedPayment {
 	if x != nil {
-		return x.Order
+		return x.Payment
 	}
 	return nil
 }
@@ -409,7 +3137,7 @@ type GetAutomaticStatsRequest struct {
 
 func (x *GetAutomaticStatsRequest) Reset() {
 	*x = GetAutomaticStatsRequest{}
-	mi := &file_order_order_service_proto_msgTypes[6]
+	mi := &file_order_order_service_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -421,7 +3149,7 @@ func (x *GetAutomaticStatsRequest) String() string {
 func (*GetAutomaticStatsRequest) ProtoMessage() {}
 
 func (x *GetAutomaticStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[6]
+	mi := &file_order_order_service_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -434,7 +3162,7 @@ func (x *GetAutomaticStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAutomaticStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetAutomaticStatsRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{6}
+	return file_order_order_service_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *GetAutomaticStatsRequest) GetTraderId() string {
@@ -462,7 +3190,7 @@ type DeviceStats struct {
 
 func (x *DeviceStats) Reset() {
 	*x = DeviceStats{}
-	mi := &file_order_order_service_proto_msgTypes[7]
+	mi := &file_order_order_service_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -474,7 +3202,7 @@ func (x *DeviceStats) String() string {
 func (*DeviceStats) ProtoMessage() {}
 
 func (x *DeviceStats) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[7]
+	mi := &file_order_order_service_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -487,7 +3215,7 @@ func (x *DeviceStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeviceStats.ProtoReflect.Descriptor instead.
 func (*DeviceStats) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{7}
+	return file_order_order_service_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *DeviceStats) GetTotalAttempts() int64 {
@@ -526,7 +3254,7 @@ type AutomaticStats struct {
 
 func (x *AutomaticStats) Reset() {
 	*x = AutomaticStats{}
-	mi := &file_order_order_service_proto_msgTypes[8]
+	mi := &file_order_order_service_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -538,7 +3266,7 @@ func (x *AutomaticStats) String() string {
 func (*AutomaticStats) ProtoMessage() {}
 
 func (x *AutomaticStats) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[8]
+	mi := &file_order_order_service_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -551,7 +3279,7 @@ func (x *AutomaticStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AutomaticStats.ProtoReflect.Descriptor instead.
 func (*AutomaticStats) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{8}
+	return file_order_order_service_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *AutomaticStats) GetTotalAttempts() int64 {
@@ -612,7 +3340,7 @@ type GetAutomaticStatsResponse struct {
 
 func (x *GetAutomaticStatsResponse) Reset() {
 	*x = GetAutomaticStatsResponse{}
-	mi := &file_order_order_service_proto_msgTypes[9]
+	mi := &file_order_order_service_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -624,7 +3352,7 @@ func (x *GetAutomaticStatsResponse) String() string {
 func (*GetAutomaticStatsResponse) ProtoMessage() {}
 
 func (x *GetAutomaticStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[9]
+	mi := &file_order_order_service_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -637,7 +3365,7 @@ func (x *GetAutomaticStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAutomaticStatsResponse.ProtoReflect.Descriptor instead.
 func (*GetAutomaticStatsResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{9}
+	return file_order_order_service_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *GetAutomaticStatsResponse) GetStats() *AutomaticStats {
@@ -664,7 +3392,7 @@ type ProcessAutomaticPaymentRequest struct {
 
 func (x *ProcessAutomaticPaymentRequest) Reset() {
 	*x = ProcessAutomaticPaymentRequest{}
-	mi := &file_order_order_service_proto_msgTypes[10]
+	mi := &file_order_order_service_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -676,7 +3404,7 @@ func (x *ProcessAutomaticPaymentRequest) String() string {
 func (*ProcessAutomaticPaymentRequest) ProtoMessage() {}
 
 func (x *ProcessAutomaticPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[10]
+	mi := &file_order_order_service_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -689,7 +3417,7 @@ func (x *ProcessAutomaticPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessAutomaticPaymentRequest.ProtoReflect.Descriptor instead.
 func (*ProcessAutomaticPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{10}
+	return file_order_order_service_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *ProcessAutomaticPaymentRequest) GetGroup() string {
@@ -757,7 +3485,7 @@ func (x *ProcessAutomaticPaymentRequest) GetTraderId() string {
 
 type ProcessAutomaticPaymentResponse struct {
 	state         protoimpl.MessageState   `protogen:"open.v1"`
-	Action        string                   `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"` // approved, not_found, failed
+	Action        string                   `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"` // processed, not_found, parse_mismatch, ambiguous (несколько сделок на разных реквизитах подходят одинаково), already_processed (повтор уведомления, results - первой обработки)
 	Message       string                   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	OrderId       string                   `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	Success       bool                     `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
@@ -768,7 +3496,7 @@ type ProcessAutomaticPaymentResponse struct {
 
 func (x *ProcessAutomaticPaymentResponse) Reset() {
 	*x = ProcessAutomaticPaymentResponse{}
-	mi := &file_order_order_service_proto_msgTypes[11]
+	mi := &file_order_order_service_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -780,7 +3508,7 @@ func (x *ProcessAutomaticPaymentResponse) String() string {
 func (*ProcessAutomaticPaymentResponse) ProtoMessage() {}
 
 func (x *ProcessAutomaticPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[11]
+	mi := &file_order_order_service_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -793,7 +3521,7 @@ func (x *ProcessAutomaticPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessAutomaticPaymentResponse.ProtoReflect.Descriptor instead.
 func (*ProcessAutomaticPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{11}
+	return file_order_order_service_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *ProcessAutomaticPaymentResponse) GetAction() string {
@@ -834,7 +3562,7 @@ func (x *ProcessAutomaticPaymentResponse) GetResults() []*OrderProcessingResult
 type OrderProcessingResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"` // approved, failed, already_processed
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"` // approved, recovered, failed, already_processed
 	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
 	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -843,7 +3571,7 @@ type OrderProcessingResult struct {
 
 func (x *OrderProcessingResult) Reset() {
 	*x = OrderProcessingResult{}
-	mi := &file_order_order_service_proto_msgTypes[12]
+	mi := &file_order_order_service_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -855,7 +3583,7 @@ func (x *OrderProcessingResult) String() string {
 func (*OrderProcessingResult) ProtoMessage() {}
 
 func (x *OrderProcessingResult) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[12]
+	mi := &file_order_order_service_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -868,7 +3596,7 @@ func (x *OrderProcessingResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderProcessingResult.ProtoReflect.Descriptor instead.
 func (*OrderProcessingResult) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{12}
+	return file_order_order_service_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *OrderProcessingResult) GetOrderId() string {
@@ -911,7 +3639,7 @@ type AutomaticLog struct {
 	Methods        []string               `protobuf:"bytes,8,rep,name=methods,proto3" json:"methods,omitempty"`
 	ReceivedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
 	Text           string                 `protobuf:"bytes,10,opt,name=text,proto3" json:"text,omitempty"`
-	Action         string                 `protobuf:"bytes,11,opt,name=action,proto3" json:"action,omitempty"` // found, not_found, approved, failed, search_error
+	Action         string                 `protobuf:"bytes,11,opt,name=action,proto3" json:"action,omitempty"` // found, not_found, approved, failed, search_error, parse_mismatch, ambiguous, duplicate
 	Success        bool                   `protobuf:"varint,12,opt,name=success,proto3" json:"success,omitempty"`
 	OrdersFound    int32                  `protobuf:"varint,13,opt,name=orders_found,json=ordersFound,proto3" json:"orders_found,omitempty"`
 	ErrorMessage   string                 `protobuf:"bytes,14,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
@@ -925,7 +3653,7 @@ type AutomaticLog struct {
 
 func (x *AutomaticLog) Reset() {
 	*x = AutomaticLog{}
-	mi := &file_order_order_service_proto_msgTypes[13]
+	mi := &file_order_order_service_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -937,7 +3665,7 @@ func (x *AutomaticLog) String() string {
 func (*AutomaticLog) ProtoMessage() {}
 
 func (x *AutomaticLog) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[13]
+	mi := &file_order_order_service_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -950,7 +3678,7 @@ func (x *AutomaticLog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AutomaticLog.ProtoReflect.Descriptor instead.
 func (*AutomaticLog) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{13}
+	return file_order_order_service_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *AutomaticLog) GetId() string {
@@ -1095,7 +3823,7 @@ type AutomaticLogFilter struct {
 
 func (x *AutomaticLogFilter) Reset() {
 	*x = AutomaticLogFilter{}
-	mi := &file_order_order_service_proto_msgTypes[14]
+	mi := &file_order_order_service_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1107,7 +3835,7 @@ func (x *AutomaticLogFilter) String() string {
 func (*AutomaticLogFilter) ProtoMessage() {}
 
 func (x *AutomaticLogFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[14]
+	mi := &file_order_order_service_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1120,7 +3848,7 @@ func (x *AutomaticLogFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AutomaticLogFilter.ProtoReflect.Descriptor instead.
 func (*AutomaticLogFilter) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{14}
+	return file_order_order_service_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *AutomaticLogFilter) GetDeviceId() string {
@@ -1188,7 +3916,7 @@ type GetAutomaticLogsRequest struct {
 
 func (x *GetAutomaticLogsRequest) Reset() {
 	*x = GetAutomaticLogsRequest{}
-	mi := &file_order_order_service_proto_msgTypes[15]
+	mi := &file_order_order_service_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1200,7 +3928,7 @@ func (x *GetAutomaticLogsRequest) String() string {
 func (*GetAutomaticLogsRequest) ProtoMessage() {}
 
 func (x *GetAutomaticLogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[15]
+	mi := &file_order_order_service_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1213,7 +3941,7 @@ func (x *GetAutomaticLogsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAutomaticLogsRequest.ProtoReflect.Descriptor instead.
 func (*GetAutomaticLogsRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{15}
+	return file_order_order_service_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *GetAutomaticLogsRequest) GetFilter() *AutomaticLogFilter {
@@ -1233,7 +3961,7 @@ type GetAutomaticLogsResponse struct {
 
 func (x *GetAutomaticLogsResponse) Reset() {
 	*x = GetAutomaticLogsResponse{}
-	mi := &file_order_order_service_proto_msgTypes[16]
+	mi := &file_order_order_service_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1245,7 +3973,7 @@ func (x *GetAutomaticLogsResponse) String() string {
 func (*GetAutomaticLogsResponse) ProtoMessage() {}
 
 func (x *GetAutomaticLogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[16]
+	mi := &file_order_order_service_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1258,7 +3986,7 @@ func (x *GetAutomaticLogsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAutomaticLogsResponse.ProtoReflect.Descriptor instead.
 func (*GetAutomaticLogsResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{16}
+	return file_order_order_service_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *GetAutomaticLogsResponse) GetLogs() []*AutomaticLog {
@@ -1299,7 +4027,7 @@ type GetAllOrdersRequest struct {
 
 func (x *GetAllOrdersRequest) Reset() {
 	*x = GetAllOrdersRequest{}
-	mi := &file_order_order_service_proto_msgTypes[17]
+	mi := &file_order_order_service_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1311,7 +4039,7 @@ func (x *GetAllOrdersRequest) String() string {
 func (*GetAllOrdersRequest) ProtoMessage() {}
 
 func (x *GetAllOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[17]
+	mi := &file_order_order_service_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1324,7 +4052,7 @@ func (x *GetAllOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllOrdersRequest.ProtoReflect.Descriptor instead.
 func (*GetAllOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{17}
+	return file_order_order_service_proto_rawDescGZIP(), []int{62}
 }
 
 func (x *GetAllOrdersRequest) GetTraderId() string {
@@ -1449,7 +4177,7 @@ type GetAllOrdersResponse struct {
 
 func (x *GetAllOrdersResponse) Reset() {
 	*x = GetAllOrdersResponse{}
-	mi := &file_order_order_service_proto_msgTypes[18]
+	mi := &file_order_order_service_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1461,7 +4189,7 @@ func (x *GetAllOrdersResponse) String() string {
 func (*GetAllOrdersResponse) ProtoMessage() {}
 
 func (x *GetAllOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[18]
+	mi := &file_order_order_service_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1474,7 +4202,7 @@ func (x *GetAllOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllOrdersResponse.ProtoReflect.Descriptor instead.
 func (*GetAllOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{18}
+	return file_order_order_service_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *GetAllOrdersResponse) GetOrders() []*Order {
@@ -1510,7 +4238,7 @@ type GetOrdersRequest struct {
 
 func (x *GetOrdersRequest) Reset() {
 	*x = GetOrdersRequest{}
-	mi := &file_order_order_service_proto_msgTypes[19]
+	mi := &file_order_order_service_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1522,7 +4250,7 @@ func (x *GetOrdersRequest) String() string {
 func (*GetOrdersRequest) ProtoMessage() {}
 
 func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[19]
+	mi := &file_order_order_service_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1535,7 +4263,7 @@ func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrdersRequest.ProtoReflect.Descriptor instead.
 func (*GetOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{19}
+	return file_order_order_service_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *GetOrdersRequest) GetDealId() string {
@@ -1634,7 +4362,7 @@ type GetOrdersResponse struct {
 
 func (x *GetOrdersResponse) Reset() {
 	*x = GetOrdersResponse{}
-	mi := &file_order_order_service_proto_msgTypes[20]
+	mi := &file_order_order_service_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1646,7 +4374,7 @@ func (x *GetOrdersResponse) String() string {
 func (*GetOrdersResponse) ProtoMessage() {}
 
 func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[20]
+	mi := &file_order_order_service_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1659,7 +4387,7 @@ func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrdersResponse.ProtoReflect.Descriptor instead.
 func (*GetOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{20}
+	return file_order_order_service_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *GetOrdersResponse) GetContent() []*OrderResponse {
@@ -1759,7 +4487,7 @@ type OrderResponse struct {
 
 func (x *OrderResponse) Reset() {
 	*x = OrderResponse{}
-	mi := &file_order_order_service_proto_msgTypes[21]
+	mi := &file_order_order_service_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1771,7 +4499,7 @@ func (x *OrderResponse) String() string {
 func (*OrderResponse) ProtoMessage() {}
 
 func (x *OrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[21]
+	mi := &file_order_order_service_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1784,7 +4512,7 @@ func (x *OrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderResponse.ProtoReflect.Descriptor instead.
 func (*OrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{21}
+	return file_order_order_service_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *OrderResponse) GetId() string {
@@ -1881,7 +4609,7 @@ type Amount struct {
 
 func (x *Amount) Reset() {
 	*x = Amount{}
-	mi := &file_order_order_service_proto_msgTypes[22]
+	mi := &file_order_order_service_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1893,7 +4621,7 @@ func (x *Amount) String() string {
 func (*Amount) ProtoMessage() {}
 
 func (x *Amount) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[22]
+	mi := &file_order_order_service_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1906,7 +4634,7 @@ func (x *Amount) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Amount.ProtoReflect.Descriptor instead.
 func (*Amount) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{22}
+	return file_order_order_service_proto_rawDescGZIP(), []int{67}
 }
 
 func (x *Amount) GetAmount() float64 {
@@ -1935,7 +4663,7 @@ type Requisites struct {
 
 func (x *Requisites) Reset() {
 	*x = Requisites{}
-	mi := &file_order_order_service_proto_msgTypes[23]
+	mi := &file_order_order_service_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1947,7 +4675,7 @@ func (x *Requisites) String() string {
 func (*Requisites) ProtoMessage() {}
 
 func (x *Requisites) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[23]
+	mi := &file_order_order_service_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1960,7 +4688,7 @@ func (x *Requisites) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Requisites.ProtoReflect.Descriptor instead.
 func (*Requisites) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{23}
+	return file_order_order_service_proto_rawDescGZIP(), []int{68}
 }
 
 func (x *Requisites) GetIssuer() string {
@@ -2005,7 +4733,7 @@ type Pageable struct {
 
 func (x *Pageable) Reset() {
 	*x = Pageable{}
-	mi := &file_order_order_service_proto_msgTypes[24]
+	mi := &file_order_order_service_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2017,7 +4745,7 @@ func (x *Pageable) String() string {
 func (*Pageable) ProtoMessage() {}
 
 func (x *Pageable) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[24]
+	mi := &file_order_order_service_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2030,7 +4758,7 @@ func (x *Pageable) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Pageable.ProtoReflect.Descriptor instead.
 func (*Pageable) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{24}
+	return file_order_order_service_proto_rawDescGZIP(), []int{69}
 }
 
 func (x *Pageable) GetSort() *Sort {
@@ -2086,7 +4814,7 @@ type Sort struct {
 
 func (x *Sort) Reset() {
 	*x = Sort{}
-	mi := &file_order_order_service_proto_msgTypes[25]
+	mi := &file_order_order_service_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2098,7 +4826,7 @@ func (x *Sort) String() string {
 func (*Sort) ProtoMessage() {}
 
 func (x *Sort) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[25]
+	mi := &file_order_order_service_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2111,7 +4839,7 @@ func (x *Sort) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Sort.ProtoReflect.Descriptor instead.
 func (*Sort) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{25}
+	return file_order_order_service_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *Sort) GetUnsorted() bool {
@@ -2146,7 +4874,7 @@ type GetOrderStatisticsRequest struct {
 
 func (x *GetOrderStatisticsRequest) Reset() {
 	*x = GetOrderStatisticsRequest{}
-	mi := &file_order_order_service_proto_msgTypes[26]
+	mi := &file_order_order_service_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2158,7 +4886,7 @@ func (x *GetOrderStatisticsRequest) String() string {
 func (*GetOrderStatisticsRequest) ProtoMessage() {}
 
 func (x *GetOrderStatisticsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[26]
+	mi := &file_order_order_service_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2171,7 +4899,7 @@ func (x *GetOrderStatisticsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderStatisticsRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderStatisticsRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{26}
+	return file_order_order_service_proto_rawDescGZIP(), []int{71}
 }
 
 func (x *GetOrderStatisticsRequest) GetTraderId() string {
@@ -2212,7 +4940,7 @@ type GetOrderStatisticsResponse struct {
 
 func (x *GetOrderStatisticsResponse) Reset() {
 	*x = GetOrderStatisticsResponse{}
-	mi := &file_order_order_service_proto_msgTypes[27]
+	mi := &file_order_order_service_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2224,7 +4952,7 @@ func (x *GetOrderStatisticsResponse) String() string {
 func (*GetOrderStatisticsResponse) ProtoMessage() {}
 
 func (x *GetOrderStatisticsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[27]
+	mi := &file_order_order_service_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2237,7 +4965,7 @@ func (x *GetOrderStatisticsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderStatisticsResponse.ProtoReflect.Descriptor instead.
 func (*GetOrderStatisticsResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{27}
+	return file_order_order_service_proto_rawDescGZIP(), []int{72}
 }
 
 func (x *GetOrderStatisticsResponse) GetTotalOrders() int64 {
@@ -2318,7 +5046,7 @@ type GetOrderDisputesRequest struct {
 
 func (x *GetOrderDisputesRequest) Reset() {
 	*x = GetOrderDisputesRequest{}
-	mi := &file_order_order_service_proto_msgTypes[28]
+	mi := &file_order_order_service_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2330,7 +5058,7 @@ func (x *GetOrderDisputesRequest) String() string {
 func (*GetOrderDisputesRequest) ProtoMessage() {}
 
 func (x *GetOrderDisputesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[28]
+	mi := &file_order_order_service_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2343,7 +5071,7 @@ func (x *GetOrderDisputesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderDisputesRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderDisputesRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{28}
+	return file_order_order_service_proto_rawDescGZIP(), []int{73}
 }
 
 func (x *GetOrderDisputesRequest) GetPage() int64 {
@@ -2405,7 +5133,7 @@ type GetOrderDisputesResponse struct {
 
 func (x *GetOrderDisputesResponse) Reset() {
 	*x = GetOrderDisputesResponse{}
-	mi := &file_order_order_service_proto_msgTypes[29]
+	mi := &file_order_order_service_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2417,7 +5145,7 @@ func (x *GetOrderDisputesResponse) String() string {
 func (*GetOrderDisputesResponse) ProtoMessage() {}
 
 func (x *GetOrderDisputesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[29]
+	mi := &file_order_order_service_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2430,7 +5158,7 @@ func (x *GetOrderDisputesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderDisputesResponse.ProtoReflect.Descriptor instead.
 func (*GetOrderDisputesResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{29}
+	return file_order_order_service_proto_rawDescGZIP(), []int{74}
 }
 
 func (x *GetOrderDisputesResponse) GetDisputes() []*OrderDispute {
@@ -2456,7 +5184,7 @@ type GetOrderByMerchantOrderIDRequest struct {
 
 func (x *GetOrderByMerchantOrderIDRequest) Reset() {
 	*x = GetOrderByMerchantOrderIDRequest{}
-	mi := &file_order_order_service_proto_msgTypes[30]
+	mi := &file_order_order_service_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2468,7 +5196,7 @@ func (x *GetOrderByMerchantOrderIDRequest) String() string {
 func (*GetOrderByMerchantOrderIDRequest) ProtoMessage() {}
 
 func (x *GetOrderByMerchantOrderIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[30]
+	mi := &file_order_order_service_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2481,7 +5209,7 @@ func (x *GetOrderByMerchantOrderIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderByMerchantOrderIDRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderByMerchantOrderIDRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{30}
+	return file_order_order_service_proto_rawDescGZIP(), []int{75}
 }
 
 func (x *GetOrderByMerchantOrderIDRequest) GetMerchantOrderId() string {
@@ -2500,7 +5228,7 @@ type GetOrderByMerchantOrderIDResponse struct {
 
 func (x *GetOrderByMerchantOrderIDResponse) Reset() {
 	*x = GetOrderByMerchantOrderIDResponse{}
-	mi := &file_order_order_service_proto_msgTypes[31]
+	mi := &file_order_order_service_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2512,7 +5240,7 @@ func (x *GetOrderByMerchantOrderIDResponse) String() string {
 func (*GetOrderByMerchantOrderIDResponse) ProtoMessage() {}
 
 func (x *GetOrderByMerchantOrderIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[31]
+	mi := &file_order_order_service_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2525,7 +5253,7 @@ func (x *GetOrderByMerchantOrderIDResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use GetOrderByMerchantOrderIDResponse.ProtoReflect.Descriptor instead.
 func (*GetOrderByMerchantOrderIDResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{31}
+	return file_order_order_service_proto_rawDescGZIP(), []int{76}
 }
 
 func (x *GetOrderByMerchantOrderIDResponse) GetOrder() *Order {
@@ -2544,7 +5272,7 @@ type FreezeOrderDisputeRequest struct {
 
 func (x *FreezeOrderDisputeRequest) Reset() {
 	*x = FreezeOrderDisputeRequest{}
-	mi := &file_order_order_service_proto_msgTypes[32]
+	mi := &file_order_order_service_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2556,7 +5284,7 @@ func (x *FreezeOrderDisputeRequest) String() string {
 func (*FreezeOrderDisputeRequest) ProtoMessage() {}
 
 func (x *FreezeOrderDisputeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[32]
+	mi := &file_order_order_service_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2569,7 +5297,7 @@ func (x *FreezeOrderDisputeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FreezeOrderDisputeRequest.ProtoReflect.Descriptor instead.
 func (*FreezeOrderDisputeRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{32}
+	return file_order_order_service_proto_rawDescGZIP(), []int{77}
 }
 
 func (x *FreezeOrderDisputeRequest) GetDisputeId() string {
@@ -2587,7 +5315,7 @@ type FreezeOrderDisputeResponse struct {
 
 func (x *FreezeOrderDisputeResponse) Reset() {
 	*x = FreezeOrderDisputeResponse{}
-	mi := &file_order_order_service_proto_msgTypes[33]
+	mi := &file_order_order_service_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2599,7 +5327,7 @@ func (x *FreezeOrderDisputeResponse) String() string {
 func (*FreezeOrderDisputeResponse) ProtoMessage() {}
 
 func (x *FreezeOrderDisputeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[33]
+	mi := &file_order_order_service_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2612,7 +5340,7 @@ func (x *FreezeOrderDisputeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FreezeOrderDisputeResponse.ProtoReflect.Descriptor instead.
 func (*FreezeOrderDisputeResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{33}
+	return file_order_order_service_proto_rawDescGZIP(), []int{78}
 }
 
 type CreateOrderDisputeRequest struct {
@@ -2628,7 +5356,7 @@ type CreateOrderDisputeRequest struct {
 
 func (x *CreateOrderDisputeRequest) Reset() {
 	*x = CreateOrderDisputeRequest{}
-	mi := &file_order_order_service_proto_msgTypes[34]
+	mi := &file_order_order_service_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2640,7 +5368,7 @@ func (x *CreateOrderDisputeRequest) String() string {
 func (*CreateOrderDisputeRequest) ProtoMessage() {}
 
 func (x *CreateOrderDisputeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[34]
+	mi := &file_order_order_service_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2653,7 +5381,7 @@ func (x *CreateOrderDisputeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderDisputeRequest.ProtoReflect.Descriptor instead.
 func (*CreateOrderDisputeRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{34}
+	return file_order_order_service_proto_rawDescGZIP(), []int{79}
 }
 
 func (x *CreateOrderDisputeRequest) GetOrderId() string {
@@ -2700,7 +5428,7 @@ type CreateOrderDisputeResponse struct {
 
 func (x *CreateOrderDisputeResponse) Reset() {
 	*x = CreateOrderDisputeResponse{}
-	mi := &file_order_order_service_proto_msgTypes[35]
+	mi := &file_order_order_service_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2712,7 +5440,7 @@ func (x *CreateOrderDisputeResponse) String() string {
 func (*CreateOrderDisputeResponse) ProtoMessage() {}
 
 func (x *CreateOrderDisputeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[35]
+	mi := &file_order_order_service_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2725,7 +5453,7 @@ func (x *CreateOrderDisputeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderDisputeResponse.ProtoReflect.Descriptor instead.
 func (*CreateOrderDisputeResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{35}
+	return file_order_order_service_proto_rawDescGZIP(), []int{80}
 }
 
 func (x *CreateOrderDisputeResponse) GetDisputeId() string {
@@ -2753,7 +5481,7 @@ type OrderDispute struct {
 
 func (x *OrderDispute) Reset() {
 	*x = OrderDispute{}
-	mi := &file_order_order_service_proto_msgTypes[36]
+	mi := &file_order_order_service_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2765,7 +5493,7 @@ func (x *OrderDispute) String() string {
 func (*OrderDispute) ProtoMessage() {}
 
 func (x *OrderDispute) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[36]
+	mi := &file_order_order_service_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2778,7 +5506,7 @@ func (x *OrderDispute) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderDispute.ProtoReflect.Descriptor instead.
 func (*OrderDispute) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{36}
+	return file_order_order_service_proto_rawDescGZIP(), []int{81}
 }
 
 func (x *OrderDispute) GetDisputeId() string {
@@ -2860,7 +5588,7 @@ type AcceptOrderDisputeRequest struct {
 
 func (x *AcceptOrderDisputeRequest) Reset() {
 	*x = AcceptOrderDisputeRequest{}
-	mi := &file_order_order_service_proto_msgTypes[37]
+	mi := &file_order_order_service_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2872,7 +5600,7 @@ func (x *AcceptOrderDisputeRequest) String() string {
 func (*AcceptOrderDisputeRequest) ProtoMessage() {}
 
 func (x *AcceptOrderDisputeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[37]
+	mi := &file_order_order_service_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2885,7 +5613,7 @@ func (x *AcceptOrderDisputeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AcceptOrderDisputeRequest.ProtoReflect.Descriptor instead.
 func (*AcceptOrderDisputeRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{37}
+	return file_order_order_service_proto_rawDescGZIP(), []int{82}
 }
 
 func (x *AcceptOrderDisputeRequest) GetDisputeId() string {
@@ -2904,7 +5632,7 @@ type AcceptOrderDisputeResponse struct {
 
 func (x *AcceptOrderDisputeResponse) Reset() {
 	*x = AcceptOrderDisputeResponse{}
-	mi := &file_order_order_service_proto_msgTypes[38]
+	mi := &file_order_order_service_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2916,7 +5644,7 @@ func (x *AcceptOrderDisputeResponse) String() string {
 func (*AcceptOrderDisputeResponse) ProtoMessage() {}
 
 func (x *AcceptOrderDisputeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[38]
+	mi := &file_order_order_service_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2929,7 +5657,7 @@ func (x *AcceptOrderDisputeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AcceptOrderDisputeResponse.ProtoReflect.Descriptor instead.
 func (*AcceptOrderDisputeResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{38}
+	return file_order_order_service_proto_rawDescGZIP(), []int{83}
 }
 
 func (x *AcceptOrderDisputeResponse) GetMessage() string {
@@ -2948,7 +5676,7 @@ type RejectOrderDisputeRequest struct {
 
 func (x *RejectOrderDisputeRequest) Reset() {
 	*x = RejectOrderDisputeRequest{}
-	mi := &file_order_order_service_proto_msgTypes[39]
+	mi := &file_order_order_service_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2960,7 +5688,7 @@ func (x *RejectOrderDisputeRequest) String() string {
 func (*RejectOrderDisputeRequest) ProtoMessage() {}
 
 func (x *RejectOrderDisputeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[39]
+	mi := &file_order_order_service_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2973,7 +5701,7 @@ func (x *RejectOrderDisputeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RejectOrderDisputeRequest.ProtoReflect.Descriptor instead.
 func (*RejectOrderDisputeRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{39}
+	return file_order_order_service_proto_rawDescGZIP(), []int{84}
 }
 
 func (x *RejectOrderDisputeRequest) GetDisputeId() string {
@@ -2992,7 +5720,7 @@ type RejectOrderDisputeResponse struct {
 
 func (x *RejectOrderDisputeResponse) Reset() {
 	*x = RejectOrderDisputeResponse{}
-	mi := &file_order_order_service_proto_msgTypes[40]
+	mi := &file_order_order_service_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3004,7 +5732,7 @@ func (x *RejectOrderDisputeResponse) String() string {
 func (*RejectOrderDisputeResponse) ProtoMessage() {}
 
 func (x *RejectOrderDisputeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[40]
+	mi := &file_order_order_service_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3017,7 +5745,7 @@ func (x *RejectOrderDisputeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RejectOrderDisputeResponse.ProtoReflect.Descriptor instead.
 func (*RejectOrderDisputeResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{40}
+	return file_order_order_service_proto_rawDescGZIP(), []int{85}
 }
 
 func (x *RejectOrderDisputeResponse) GetMessage() string {
@@ -3036,7 +5764,7 @@ type GetOrderDisputeInfoRequest struct {
 
 func (x *GetOrderDisputeInfoRequest) Reset() {
 	*x = GetOrderDisputeInfoRequest{}
-	mi := &file_order_order_service_proto_msgTypes[41]
+	mi := &file_order_order_service_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3048,7 +5776,7 @@ func (x *GetOrderDisputeInfoRequest) String() string {
 func (*GetOrderDisputeInfoRequest) ProtoMessage() {}
 
 func (x *GetOrderDisputeInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[41]
+	mi := &file_order_order_service_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3061,7 +5789,7 @@ func (x *GetOrderDisputeInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderDisputeInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderDisputeInfoRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{41}
+	return file_order_order_service_proto_rawDescGZIP(), []int{86}
 }
 
 func (x *GetOrderDisputeInfoRequest) GetDisputeId() string {
@@ -3080,7 +5808,7 @@ type GetOrderDisputeInfoResponse struct {
 
 func (x *GetOrderDisputeInfoResponse) Reset() {
 	*x = GetOrderDisputeInfoResponse{}
-	mi := &file_order_order_service_proto_msgTypes[42]
+	mi := &file_order_order_service_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3092,7 +5820,7 @@ func (x *GetOrderDisputeInfoResponse) String() string {
 func (*GetOrderDisputeInfoResponse) ProtoMessage() {}
 
 func (x *GetOrderDisputeInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[42]
+	mi := &file_order_order_service_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3105,7 +5833,7 @@ func (x *GetOrderDisputeInfoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderDisputeInfoResponse.ProtoReflect.Descriptor instead.
 func (*GetOrderDisputeInfoResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{42}
+	return file_order_order_service_proto_rawDescGZIP(), []int{87}
 }
 
 func (x *GetOrderDisputeInfoResponse) GetDispute() *OrderDispute {
@@ -3136,7 +5864,7 @@ type CreatePayInOrderRequest struct {
 
 func (x *CreatePayInOrderRequest) Reset() {
 	*x = CreatePayInOrderRequest{}
-	mi := &file_order_order_service_proto_msgTypes[43]
+	mi := &file_order_order_service_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3148,7 +5876,7 @@ func (x *CreatePayInOrderRequest) String() string {
 func (*CreatePayInOrderRequest) ProtoMessage() {}
 
 func (x *CreatePayInOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[43]
+	mi := &file_order_order_service_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3161,7 +5889,7 @@ func (x *CreatePayInOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePayInOrderRequest.ProtoReflect.Descriptor instead.
 func (*CreatePayInOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{43}
+	return file_order_order_service_proto_rawDescGZIP(), []int{88}
 }
 
 func (x *CreatePayInOrderRequest) GetMerchantId() string {
@@ -3264,7 +5992,7 @@ type CreatePayInOrderResponse struct {
 
 func (x *CreatePayInOrderResponse) Reset() {
 	*x = CreatePayInOrderResponse{}
-	mi := &file_order_order_service_proto_msgTypes[44]
+	mi := &file_order_order_service_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3276,7 +6004,7 @@ func (x *CreatePayInOrderResponse) String() string {
 func (*CreatePayInOrderResponse) ProtoMessage() {}
 
 func (x *CreatePayInOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[44]
+	mi := &file_order_order_service_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3289,7 +6017,7 @@ func (x *CreatePayInOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePayInOrderResponse.ProtoReflect.Descriptor instead.
 func (*CreatePayInOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{44}
+	return file_order_order_service_proto_rawDescGZIP(), []int{89}
 }
 
 func (x *CreatePayInOrderResponse) GetOrder() *Order {
@@ -3308,7 +6036,7 @@ type ApproveOrderRequest struct {
 
 func (x *ApproveOrderRequest) Reset() {
 	*x = ApproveOrderRequest{}
-	mi := &file_order_order_service_proto_msgTypes[45]
+	mi := &file_order_order_service_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3320,7 +6048,7 @@ func (x *ApproveOrderRequest) String() string {
 func (*ApproveOrderRequest) ProtoMessage() {}
 
 func (x *ApproveOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[45]
+	mi := &file_order_order_service_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3333,7 +6061,7 @@ func (x *ApproveOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApproveOrderRequest.ProtoReflect.Descriptor instead.
 func (*ApproveOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{45}
+	return file_order_order_service_proto_rawDescGZIP(), []int{90}
 }
 
 func (x *ApproveOrderRequest) GetOrderId() string {
@@ -3352,7 +6080,7 @@ type ApproveOrderResponse struct {
 
 func (x *ApproveOrderResponse) Reset() {
 	*x = ApproveOrderResponse{}
-	mi := &file_order_order_service_proto_msgTypes[46]
+	mi := &file_order_order_service_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3364,7 +6092,7 @@ func (x *ApproveOrderResponse) String() string {
 func (*ApproveOrderResponse) ProtoMessage() {}
 
 func (x *ApproveOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[46]
+	mi := &file_order_order_service_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3377,7 +6105,7 @@ func (x *ApproveOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApproveOrderResponse.ProtoReflect.Descriptor instead.
 func (*ApproveOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{46}
+	return file_order_order_service_proto_rawDescGZIP(), []int{91}
 }
 
 func (x *ApproveOrderResponse) GetMessage() string {
@@ -3396,7 +6124,7 @@ type CancelOrderRequest struct {
 
 func (x *CancelOrderRequest) Reset() {
 	*x = CancelOrderRequest{}
-	mi := &file_order_order_service_proto_msgTypes[47]
+	mi := &file_order_order_service_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3408,7 +6136,7 @@ func (x *CancelOrderRequest) String() string {
 func (*CancelOrderRequest) ProtoMessage() {}
 
 func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[47]
+	mi := &file_order_order_service_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3421,7 +6149,7 @@ func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
 func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{47}
+	return file_order_order_service_proto_rawDescGZIP(), []int{92}
 }
 
 func (x *CancelOrderRequest) GetOrderId() string {
@@ -3440,7 +6168,7 @@ type CancelOrderResponse struct {
 
 func (x *CancelOrderResponse) Reset() {
 	*x = CancelOrderResponse{}
-	mi := &file_order_order_service_proto_msgTypes[48]
+	mi := &file_order_order_service_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3452,7 +6180,7 @@ func (x *CancelOrderResponse) String() string {
 func (*CancelOrderResponse) ProtoMessage() {}
 
 func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[48]
+	mi := &file_order_order_service_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3465,7 +6193,7 @@ func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
 func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{48}
+	return file_order_order_service_proto_rawDescGZIP(), []int{93}
 }
 
 func (x *CancelOrderResponse) GetMessage() string {
@@ -3484,7 +6212,7 @@ type GetOrderByIDRequest struct {
 
 func (x *GetOrderByIDRequest) Reset() {
 	*x = GetOrderByIDRequest{}
-	mi := &file_order_order_service_proto_msgTypes[49]
+	mi := &file_order_order_service_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3496,7 +6224,7 @@ func (x *GetOrderByIDRequest) String() string {
 func (*GetOrderByIDRequest) ProtoMessage() {}
 
 func (x *GetOrderByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[49]
+	mi := &file_order_order_service_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3509,7 +6237,7 @@ func (x *GetOrderByIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderByIDRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderByIDRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{49}
+	return file_order_order_service_proto_rawDescGZIP(), []int{94}
 }
 
 func (x *GetOrderByIDRequest) GetOrderId() string {
@@ -3528,7 +6256,7 @@ type GetOrderByIDResponse struct {
 
 func (x *GetOrderByIDResponse) Reset() {
 	*x = GetOrderByIDResponse{}
-	mi := &file_order_order_service_proto_msgTypes[50]
+	mi := &file_order_order_service_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3540,7 +6268,7 @@ func (x *GetOrderByIDResponse) String() string {
 func (*GetOrderByIDResponse) ProtoMessage() {}
 
 func (x *GetOrderByIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[50]
+	mi := &file_order_order_service_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3553,7 +6281,7 @@ func (x *GetOrderByIDResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderByIDResponse.ProtoReflect.Descriptor instead.
 func (*GetOrderByIDResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{50}
+	return file_order_order_service_proto_rawDescGZIP(), []int{95}
 }
 
 func (x *GetOrderByIDResponse) GetOrder() *Order {
@@ -3583,13 +6311,14 @@ type Order struct {
 	MerchantId          string                 `protobuf:"bytes,16,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
 	Type                string                 `protobuf:"bytes,17,opt,name=type,proto3" json:"type,omitempty"`
 	Metrics             *OrderMetrics          `protobuf:"bytes,18,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	OriginalAmountFiat  float64                `protobuf:"fixed64,19,opt,name=original_amount_fiat,json=originalAmountFiat,proto3" json:"original_amount_fiat,omitempty"` // сумма мерчанта, amount_fiat может быть сдвинута для уникальности
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
 
 func (x *Order) Reset() {
 	*x = Order{}
-	mi := &file_order_order_service_proto_msgTypes[51]
+	mi := &file_order_order_service_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3601,7 +6330,7 @@ func (x *Order) String() string {
 func (*Order) ProtoMessage() {}
 
 func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[51]
+	mi := &file_order_order_service_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3614,7 +6343,7 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Order.ProtoReflect.Descriptor instead.
 func (*Order) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{51}
+	return file_order_order_service_proto_rawDescGZIP(), []int{96}
 }
 
 func (x *Order) GetOrderId() string {
@@ -3743,6 +6472,13 @@ func (x *Order) GetMetrics() *OrderMetrics {
 	return nil
 }
 
+func (x *Order) GetOriginalAmountFiat() float64 {
+	if x != nil {
+		return x.OriginalAmountFiat
+	}
+	return 0
+}
+
 type OrderMetrics struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	CompletedAt        *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
@@ -3755,7 +6491,7 @@ type OrderMetrics struct {
 
 func (x *OrderMetrics) Reset() {
 	*x = OrderMetrics{}
-	mi := &file_order_order_service_proto_msgTypes[52]
+	mi := &file_order_order_service_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3767,7 +6503,7 @@ func (x *OrderMetrics) String() string {
 func (*OrderMetrics) ProtoMessage() {}
 
 func (x *OrderMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[52]
+	mi := &file_order_order_service_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3780,7 +6516,7 @@ func (x *OrderMetrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderMetrics.ProtoReflect.Descriptor instead.
 func (*OrderMetrics) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{52}
+	return file_order_order_service_proto_rawDescGZIP(), []int{97}
 }
 
 func (x *OrderMetrics) GetCompletedAt() *timestamppb.Timestamp {
@@ -3825,7 +6561,7 @@ type GetOrdersByTraderIDRequest struct {
 
 func (x *GetOrdersByTraderIDRequest) Reset() {
 	*x = GetOrdersByTraderIDRequest{}
-	mi := &file_order_order_service_proto_msgTypes[53]
+	mi := &file_order_order_service_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3837,7 +6573,7 @@ func (x *GetOrdersByTraderIDRequest) String() string {
 func (*GetOrdersByTraderIDRequest) ProtoMessage() {}
 
 func (x *GetOrdersByTraderIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[53]
+	mi := &file_order_order_service_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3850,7 +6586,7 @@ func (x *GetOrdersByTraderIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrdersByTraderIDRequest.ProtoReflect.Descriptor instead.
 func (*GetOrdersByTraderIDRequest) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{53}
+	return file_order_order_service_proto_rawDescGZIP(), []int{98}
 }
 
 func (x *GetOrdersByTraderIDRequest) GetTraderId() string {
@@ -3905,7 +6641,7 @@ type GetOrdersByTraderIDResponse struct {
 
 func (x *GetOrdersByTraderIDResponse) Reset() {
 	*x = GetOrdersByTraderIDResponse{}
-	mi := &file_order_order_service_proto_msgTypes[54]
+	mi := &file_order_order_service_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3917,7 +6653,7 @@ func (x *GetOrdersByTraderIDResponse) String() string {
 func (*GetOrdersByTraderIDResponse) ProtoMessage() {}
 
 func (x *GetOrdersByTraderIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_order_service_proto_msgTypes[54]
+	mi := &file_order_order_service_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3930,7 +6666,7 @@ func (x *GetOrdersByTraderIDResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrdersByTraderIDResponse.ProtoReflect.Descriptor instead.
 func (*GetOrdersByTraderIDResponse) Descriptor() ([]byte, []int) {
-	return file_order_order_service_proto_rawDescGZIP(), []int{54}
+	return file_order_order_service_proto_rawDescGZIP(), []int{99}
 }
 
 func (x *GetOrdersByTraderIDResponse) GetOrders() []*Order {
@@ -3951,7 +6687,139 @@ var File_order_order_service_proto protoreflect.FileDescriptor
 
 const file_order_order_service_proto_rawDesc = "" +
 	"\n" +
-	"\x19order/order_service.proto\x12\x05order\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1forder/bank_detail_service.proto\x1a\x18order/common_types.proto\"/\n" +
+	"\x19order/order_service.proto\x12\x05order\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1forder/bank_detail_service.proto\x1a\x18order/common_types.proto\"3\n" +
+	"\x16GetOrderHistoryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\x87\x02\n" +
+	"\x15OrderStatusTransition\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vfrom_status\x18\x03 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x04 \x01(\tR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x1c\n" +
+	"\toperation\x18\x06 \x01(\tR\toperation\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"Y\n" +
+	"\x17GetOrderHistoryResponse\x12>\n" +
+	"\vtransitions\x18\x01 \x03(\v2\x1c.order.OrderStatusTransitionR\vtransitions\"z\n" +
+	"\x1bRecoverCanceledOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12(\n" +
+	"\x10paid_amount_fiat\x18\x02 \x01(\x01R\x0epaidAmountFiat\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"8\n" +
+	"\x1cRecoverCanceledOrderResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"9\n" +
+	"\x1cGetCallbackDeliveriesRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\xe6\x03\n" +
+	"\x10CallbackDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12*\n" +
+	"\x11merchant_order_id\x18\x03 \x01(\tR\x0fmerchantOrderId\x12!\n" +
+	"\fcallback_url\x18\x04 \x01(\tR\vcallbackUrl\x12!\n" +
+	"\forder_status\x18\x05 \x01(\tR\vorderStatus\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12$\n" +
+	"\x0elast_http_code\x18\b \x01(\x05R\flastHttpCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\x12B\n" +
+	"\x0fnext_attempt_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x12=\n" +
+	"\fdelivered_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"X\n" +
+	"\x1dGetCallbackDeliveriesResponse\x127\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x17.order.CallbackDeliveryR\n" +
+	"deliveries\"8\n" +
+	"\x15ResendCallbackRequest\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\tR\n" +
+	"deliveryId\"M\n" +
+	"\x16ResendCallbackResponse\x123\n" +
+	"\bdelivery\x18\x01 \x01(\v2\x17.order.CallbackDeliveryR\bdelivery\"k\n" +
+	"\x18MerchantCallbackSettings\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x16\n" +
+	"\x06signed\x18\x03 \x01(\bR\x06signed\"E\n" +
+	"\"GetMerchantCallbackSettingsRequest\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\"b\n" +
+	"#GetMerchantCallbackSettingsResponse\x12;\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1f.order.MerchantCallbackSettingsR\bsettings\"\xa7\x01\n" +
+	"\"SetMerchantCallbackSettingsRequest\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12#\n" +
+	"\rrotate_secret\x18\x03 \x01(\bR\frotateSecret\x12#\n" +
+	"\rremove_secret\x18\x04 \x01(\bR\fremoveSecret\"z\n" +
+	"#SetMerchantCallbackSettingsResponse\x12;\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1f.order.MerchantCallbackSettingsR\bsettings\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"\xd6\x01\n" +
+	"\x18MerchantMatchingSettings\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\x12.\n" +
+	"\x13wait_for_requisites\x18\x02 \x01(\bR\x11waitForRequisites\x12:\n" +
+	"\vwait_window\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"waitWindow\x12-\n" +
+	"\x12selection_strategy\x18\x04 \x01(\tR\x11selectionStrategy\"E\n" +
+	"\"GetMerchantMatchingSettingsRequest\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\"b\n" +
+	"#GetMerchantMatchingSettingsResponse\x12;\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1f.order.MerchantMatchingSettingsR\bsettings\"a\n" +
+	"\"SetMerchantMatchingSettingsRequest\x12;\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1f.order.MerchantMatchingSettingsR\bsettings\"b\n" +
+	"#SetMerchantMatchingSettingsResponse\x12;\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1f.order.MerchantMatchingSettingsR\bsettings\"\xf5\x03\n" +
+	"\fRoutingScore\x12!\n" +
+	"\fsubject_type\x18\x01 \x01(\tR\vsubjectType\x12\x1d\n" +
+	"\n" +
+	"subject_id\x18\x02 \x01(\tR\tsubjectId\x12\x1b\n" +
+	"\ttrader_id\x18\x03 \x01(\tR\btraderId\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\x12\x1f\n" +
+	"\boverride\x18\x05 \x01(\x01H\x00R\boverride\x88\x01\x01\x12'\n" +
+	"\x0feffective_score\x18\x06 \x01(\x01R\x0eeffectiveScore\x12\x16\n" +
+	"\x06orders\x18\a \x01(\x05R\x06orders\x12'\n" +
+	"\x0fcompletion_rate\x18\b \x01(\x01R\x0ecompletionRate\x12K\n" +
+	"\x14median_complete_time\x18\t \x01(\v2\x19.google.protobuf.DurationR\x12medianCompleteTime\x12!\n" +
+	"\fdispute_rate\x18\n" +
+	" \x01(\x01R\vdisputeRate\x12'\n" +
+	"\x0fautomatic_share\x18\v \x01(\x01R\x0eautomaticShare\x12?\n" +
+	"\rcalculated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\fcalculatedAtB\v\n" +
+	"\t_override\"\x83\x01\n" +
+	"\x17GetRoutingScoresRequest\x12!\n" +
+	"\fsubject_type\x18\x01 \x01(\tR\vsubjectType\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\tR\btraderId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"z\n" +
+	"\x18GetRoutingScoresResponse\x12+\n" +
+	"\x06scores\x18\x01 \x03(\v2\x13.order.RoutingScoreR\x06scores\x121\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x11.order.PaginationR\n" +
+	"pagination\"\x90\x01\n" +
+	"\x1eSetRoutingScoreOverrideRequest\x12!\n" +
+	"\fsubject_type\x18\x01 \x01(\tR\vsubjectType\x12\x1d\n" +
+	"\n" +
+	"subject_id\x18\x02 \x01(\tR\tsubjectId\x12\x1f\n" +
+	"\boverride\x18\x03 \x01(\x01H\x00R\boverride\x88\x01\x01B\v\n" +
+	"\t_override\"L\n" +
+	"\x1fSetRoutingScoreOverrideResponse\x12)\n" +
+	"\x05score\x18\x01 \x01(\v2\x13.order.RoutingScoreR\x05score\"\xe9\x01\n" +
+	"\x1cBankDetailSelectionCandidate\x12$\n" +
+	"\x0ebank_detail_id\x18\x01 \x01(\tR\fbankDetailId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\tR\btraderId\x12\x14\n" +
+	"\x05stage\x18\x03 \x01(\tR\x05stage\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1e\n" +
+	"\n" +
+	"eliminated\x18\x05 \x01(\bR\n" +
+	"eliminated\x12\x16\n" +
+	"\x06weight\x18\x06 \x01(\x01R\x06weight\x12 \n" +
+	"\vprobability\x18\a \x01(\x01R\vprobability\"\x90\x01\n" +
+	"\"ExplainBankDetailSelectionResponse\x12C\n" +
+	"\n" +
+	"candidates\x18\x01 \x03(\v2#.order.BankDetailSelectionCandidateR\n" +
+	"candidates\x12%\n" +
+	"\x0eeligible_count\x18\x02 \x01(\x05R\religibleCount\"/\n" +
 	"\x12AcceptOrderRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\"\x15\n" +
 	"\x13AcceptOrderResponse\"\xd0\x02\n" +
@@ -3981,7 +6849,94 @@ const file_order_order_service_proto_rawDesc = "" +
 	"\tbank_name\x18\x02 \x01(\tR\bbankName\x12\x1b\n" +
 	"\tnspk_code\x18\x03 \x01(\tR\bnspkCode\"?\n" +
 	"\x19CreatePayOutOrderResponse\x12\"\n" +
-	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"K\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"\x9b\x03\n" +
+	"\x14NotificationTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tbank_code\x18\x02 \x01(\tR\bbankCode\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\apattern\x18\x04 \x01(\tR\apattern\x12\x1c\n" +
+	"\tdirection\x18\x05 \x01(\tR\tdirection\x12)\n" +
+	"\x10default_currency\x18\x06 \x01(\tR\x0fdefaultCurrency\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\x05R\bpriority\x12\x18\n" +
+	"\aenabled\x18\b \x01(\bR\aenabled\x123\n" +
+	"\asamples\x18\t \x03(\v2\x19.order.NotificationSampleR\asamples\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"_\n" +
+	"\x12NotificationSample\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x125\n" +
+	"\bexpected\x18\x02 \x01(\v2\x19.order.ParsedNotificationR\bexpected\"\xe2\x01\n" +
+	"\x12ParsedNotification\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x12\x1b\n" +
+	"\tbank_code\x18\x02 \x01(\tR\bbankCode\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x1f\n" +
+	"\vsender_name\x18\x05 \x01(\tR\n" +
+	"senderName\x12\x1b\n" +
+	"\tcard_tail\x18\x06 \x01(\tR\bcardTail\x12\x1c\n" +
+	"\tdirection\x18\a \x01(\tR\tdirection\"\\\n" +
+	"!CreateNotificationTemplateRequest\x127\n" +
+	"\btemplate\x18\x01 \x01(\v2\x1b.order.NotificationTemplateR\btemplate\"]\n" +
+	"\"CreateNotificationTemplateResponse\x127\n" +
+	"\btemplate\x18\x01 \x01(\v2\x1b.order.NotificationTemplateR\btemplate\"\\\n" +
+	"!UpdateNotificationTemplateRequest\x127\n" +
+	"\btemplate\x18\x01 \x01(\v2\x1b.order.NotificationTemplateR\btemplate\"]\n" +
+	"\"UpdateNotificationTemplateResponse\x127\n" +
+	"\btemplate\x18\x01 \x01(\v2\x1b.order.NotificationTemplateR\btemplate\"D\n" +
+	"!DeleteNotificationTemplateRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\"$\n" +
+	"\"DeleteNotificationTemplateResponse\"a\n" +
+	"\x1fGetNotificationTemplatesRequest\x12\x1b\n" +
+	"\tbank_code\x18\x01 \x01(\tR\bbankCode\x12!\n" +
+	"\fenabled_only\x18\x02 \x01(\bR\venabledOnly\"]\n" +
+	" GetNotificationTemplatesResponse\x129\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x1b.order.NotificationTemplateR\ttemplates\"n\n" +
+	"\x1fTestNotificationTemplateRequest\x127\n" +
+	"\btemplate\x18\x01 \x01(\v2\x1b.order.NotificationTemplateR\btemplate\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"o\n" +
+	" TestNotificationTemplateResponse\x12\x18\n" +
+	"\amatched\x18\x01 \x01(\bR\amatched\x121\n" +
+	"\x06parsed\x18\x02 \x01(\v2\x19.order.ParsedNotificationR\x06parsed\"\xfe\x03\n" +
+	"\x10UnmatchedPayment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x1b\n" +
+	"\ttrader_id\x18\x03 \x01(\tR\btraderId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12%\n" +
+	"\x0epayment_system\x18\x05 \x01(\tR\rpaymentSystem\x12\x1c\n" +
+	"\tdirection\x18\x06 \x01(\tR\tdirection\x12\x12\n" +
+	"\x04text\x18\a \x01(\tR\x04text\x12;\n" +
+	"\vreceived_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"receivedAt\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x19\n" +
+	"\border_id\x18\n" +
+	" \x01(\tR\aorderId\x12\x1b\n" +
+	"\tlinked_by\x18\v \x01(\tR\blinkedBy\x127\n" +
+	"\tlinked_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\blinkedAt\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12.\n" +
+	"\x13candidate_order_ids\x18\x0e \x03(\tR\x11candidateOrderIds\"\x9a\x01\n" +
+	"\x1cListUnmatchedPaymentsRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1b\n" +
+	"\ttrader_id\x18\x02 \x01(\tR\btraderId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"\x87\x01\n" +
+	"\x1dListUnmatchedPaymentsResponse\x123\n" +
+	"\bpayments\x18\x01 \x03(\v2\x17.order.UnmatchedPaymentR\bpayments\x121\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x11.order.PaginationR\n" +
+	"pagination\"m\n" +
+	"\x19LinkPaymentToOrderRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"O\n" +
+	"\x1aLinkPaymentToOrderResponse\x121\n" +
+	"\apayment\x18\x01 \x01(\v2\x17.order.UnmatchedPaymentR\apayment\"K\n" +
 	"\x18GetAutomaticStatsRequest\x12\x1b\n" +
 	"\ttrader_id\x18\x01 \x01(\tR\btraderId\x12\x12\n" +
 	"\x04days\x18\x02 \x01(\x05R\x04days\"|\n" +
@@ -4302,7 +7257,7 @@ const file_order_order_service_proto_rawDesc = "" +
 	"\x13GetOrderByIDRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\":\n" +
 	"\x14GetOrderByIDResponse\x12\"\n" +
-	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"\xcf\x05\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"\x81\x06\n" +
 	"\x05Order\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x122\n" +
@@ -4328,7 +7283,8 @@ const file_order_order_service_proto_rawDesc = "" +
 	"\vmerchant_id\x18\x10 \x01(\tR\n" +
 	"merchantId\x12\x12\n" +
 	"\x04type\x18\x11 \x01(\tR\x04type\x12-\n" +
-	"\ametrics\x18\x12 \x01(\v2\x13.order.OrderMetricsR\ametrics\"\xec\x01\n" +
+	"\ametrics\x18\x12 \x01(\v2\x13.order.OrderMetricsR\ametrics\x120\n" +
+	"\x14original_amount_fiat\x18\x13 \x01(\x01R\x12originalAmountFiat\"\xec\x01\n" +
 	"\fOrderMetrics\x12=\n" +
 	"\fcompleted_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12=\n" +
 	"\fcancelled_ad\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAd\x12/\n" +
@@ -4346,7 +7302,7 @@ const file_order_order_service_proto_rawDesc = "" +
 	"\x06orders\x18\x01 \x03(\v2\f.order.OrderR\x06orders\x121\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x11.order.PaginationR\n" +
-	"pagination2\xc1\r\n" +
+	"pagination2\x99\x1c\n" +
 	"\fOrderService\x12S\n" +
 	"\x10CreatePayInOrder\x12\x1e.order.CreatePayInOrderRequest\x1a\x1f.order.CreatePayInOrderResponse\x12V\n" +
 	"\x11CreatePayOutOrder\x12\x1f.order.CreatePayOutOrderRequest\x1a .order.CreatePayOutOrderResponse\x12G\n" +
@@ -4367,7 +7323,25 @@ const file_order_order_service_proto_rawDesc = "" +
 	"\fGetAllOrders\x12\x1a.order.GetAllOrdersRequest\x1a\x1b.order.GetAllOrdersResponse\x12h\n" +
 	"\x17ProcessAutomaticPayment\x12%.order.ProcessAutomaticPaymentRequest\x1a&.order.ProcessAutomaticPaymentResponse\x12S\n" +
 	"\x10GetAutomaticLogs\x12\x1e.order.GetAutomaticLogsRequest\x1a\x1f.order.GetAutomaticLogsResponse\x12V\n" +
-	"\x11GetAutomaticStats\x12\x1f.order.GetAutomaticStatsRequest\x1a .order.GetAutomaticStatsResponseB=Z;github.com/LavaJover/shvark-order-service/proto/gen;orderpbb\x06proto3"
+	"\x11GetAutomaticStats\x12\x1f.order.GetAutomaticStatsRequest\x1a .order.GetAutomaticStatsResponse\x12P\n" +
+	"\x0fGetOrderHistory\x12\x1d.order.GetOrderHistoryRequest\x1a\x1e.order.GetOrderHistoryResponse\x12_\n" +
+	"\x14RecoverCanceledOrder\x12\".order.RecoverCanceledOrderRequest\x1a#.order.RecoverCanceledOrderResponse\x12b\n" +
+	"\x15GetCallbackDeliveries\x12#.order.GetCallbackDeliveriesRequest\x1a$.order.GetCallbackDeliveriesResponse\x12M\n" +
+	"\x0eResendCallback\x12\x1c.order.ResendCallbackRequest\x1a\x1d.order.ResendCallbackResponse\x12t\n" +
+	"\x1bGetMerchantCallbackSettings\x12).order.GetMerchantCallbackSettingsRequest\x1a*.order.GetMerchantCallbackSettingsResponse\x12t\n" +
+	"\x1bSetMerchantCallbackSettings\x12).order.SetMerchantCallbackSettingsRequest\x1a*.order.SetMerchantCallbackSettingsResponse\x12t\n" +
+	"\x1bGetMerchantMatchingSettings\x12).order.GetMerchantMatchingSettingsRequest\x1a*.order.GetMerchantMatchingSettingsResponse\x12t\n" +
+	"\x1bSetMerchantMatchingSettings\x12).order.SetMerchantMatchingSettingsRequest\x1a*.order.SetMerchantMatchingSettingsResponse\x12g\n" +
+	"\x1aExplainBankDetailSelection\x12\x1e.order.CreatePayInOrderRequest\x1a).order.ExplainBankDetailSelectionResponse\x12S\n" +
+	"\x10GetRoutingScores\x12\x1e.order.GetRoutingScoresRequest\x1a\x1f.order.GetRoutingScoresResponse\x12h\n" +
+	"\x17SetRoutingScoreOverride\x12%.order.SetRoutingScoreOverrideRequest\x1a&.order.SetRoutingScoreOverrideResponse\x12q\n" +
+	"\x1aCreateNotificationTemplate\x12(.order.CreateNotificationTemplateRequest\x1a).order.CreateNotificationTemplateResponse\x12q\n" +
+	"\x1aUpdateNotificationTemplate\x12(.order.UpdateNotificationTemplateRequest\x1a).order.UpdateNotificationTemplateResponse\x12q\n" +
+	"\x1aDeleteNotificationTemplate\x12(.order.DeleteNotificationTemplateRequest\x1a).order.DeleteNotificationTemplateResponse\x12k\n" +
+	"\x18GetNotificationTemplates\x12&.order.GetNotificationTemplatesRequest\x1a'.order.GetNotificationTemplatesResponse\x12k\n" +
+	"\x18TestNotificationTemplate\x12&.order.TestNotificationTemplateRequest\x1a'.order.TestNotificationTemplateResponse\x12b\n" +
+	"\x15ListUnmatchedPayments\x12#.order.ListUnmatchedPaymentsRequest\x1a$.order.ListUnmatchedPaymentsResponse\x12Y\n" +
+	"\x12LinkPaymentToOrder\x12 .order.LinkPaymentToOrderRequest\x1a!.order.LinkPaymentToOrderResponseB=Z;github.com/LavaJover/shvark-order-service/proto/gen;orderpbb\x06proto3"
 
 var (
 	file_order_order_service_proto_rawDescOnce sync.Once
@@ -4381,170 +7355,287 @@ func file_order_order_service_proto_rawDescGZIP() []byte {
 	return file_order_order_service_proto_rawDescData
 }
 
-var file_order_order_service_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
+var file_order_order_service_proto_msgTypes = make([]protoimpl.MessageInfo, 102)
 var file_order_order_service_proto_goTypes = []any{
-	(*AcceptOrderRequest)(nil),                // 0: order.AcceptOrderRequest
-	(*AcceptOrderResponse)(nil),               // 1: order.AcceptOrderResponse
-	(*CreatePayOutOrderRequest)(nil),          // 2: order.CreatePayOutOrderRequest
-	(*PaymentDetails)(nil),                    // 3: order.PaymentDetails
-	(*BankInfo)(nil),                          // 4: order.BankInfo
-	(*CreatePayOutOrderResponse)(nil),         // 5: order.CreatePayOutOrderResponse
-	(*GetAutomaticStatsRequest)(nil),          // 6: order.GetAutomaticStatsRequest
-	(*DeviceStats)(nil),                       // 7: order.DeviceStats
-	(*AutomaticStats)(nil),                    // 8: order.AutomaticStats
-	(*GetAutomaticStatsResponse)(nil),         // 9: order.GetAutomaticStatsResponse
-	(*ProcessAutomaticPaymentRequest)(nil),    // 10: order.ProcessAutomaticPaymentRequest
-	(*ProcessAutomaticPaymentResponse)(nil),   // 11: order.ProcessAutomaticPaymentResponse
-	(*OrderProcessingResult)(nil),             // 12: order.OrderProcessingResult
-	(*AutomaticLog)(nil),                      // 13: order.AutomaticLog
-	(*AutomaticLogFilter)(nil),                // 14: order.AutomaticLogFilter
-	(*GetAutomaticLogsRequest)(nil),           // 15: order.GetAutomaticLogsRequest
-	(*GetAutomaticLogsResponse)(nil),          // 16: order.GetAutomaticLogsResponse
-	(*GetAllOrdersRequest)(nil),               // 17: order.GetAllOrdersRequest
-	(*GetAllOrdersResponse)(nil),              // 18: order.GetAllOrdersResponse
-	(*GetOrdersRequest)(nil),                  // 19: order.GetOrdersRequest
-	(*GetOrdersResponse)(nil),                 // 20: order.GetOrdersResponse
-	(*OrderResponse)(nil),                     // 21: order.OrderResponse
-	(*Amount)(nil),                            // 22: order.Amount
-	(*Requisites)(nil),                        // 23: order.Requisites
-	(*Pageable)(nil),                          // 24: order.Pageable
-	(*Sort)(nil),                              // 25: order.Sort
-	(*GetOrderStatisticsRequest)(nil),         // 26: order.GetOrderStatisticsRequest
-	(*GetOrderStatisticsResponse)(nil),        // 27: order.GetOrderStatisticsResponse
-	(*GetOrderDisputesRequest)(nil),           // 28: order.GetOrderDisputesRequest
-	(*GetOrderDisputesResponse)(nil),          // 29: order.GetOrderDisputesResponse
-	(*GetOrderByMerchantOrderIDRequest)(nil),  // 30: order.GetOrderByMerchantOrderIDRequest
-	(*GetOrderByMerchantOrderIDResponse)(nil), // 31: order.GetOrderByMerchantOrderIDResponse
-	(*FreezeOrderDisputeRequest)(nil),         // 32: order.FreezeOrderDisputeRequest
-	(*FreezeOrderDisputeResponse)(nil),        // 33: order.FreezeOrderDisputeResponse
-	(*CreateOrderDisputeRequest)(nil),         // 34: order.CreateOrderDisputeRequest
-	(*CreateOrderDisputeResponse)(nil),        // 35: order.CreateOrderDisputeResponse
-	(*OrderDispute)(nil),                      // 36: order.OrderDispute
-	(*AcceptOrderDisputeRequest)(nil),         // 37: order.AcceptOrderDisputeRequest
-	(*AcceptOrderDisputeResponse)(nil),        // 38: order.AcceptOrderDisputeResponse
-	(*RejectOrderDisputeRequest)(nil),         // 39: order.RejectOrderDisputeRequest
-	(*RejectOrderDisputeResponse)(nil),        // 40: order.RejectOrderDisputeResponse
-	(*GetOrderDisputeInfoRequest)(nil),        // 41: order.GetOrderDisputeInfoRequest
-	(*GetOrderDisputeInfoResponse)(nil),       // 42: order.GetOrderDisputeInfoResponse
-	(*CreatePayInOrderRequest)(nil),           // 43: order.CreatePayInOrderRequest
-	(*CreatePayInOrderResponse)(nil),          // 44: order.CreatePayInOrderResponse
-	(*ApproveOrderRequest)(nil),               // 45: order.ApproveOrderRequest
-	(*ApproveOrderResponse)(nil),              // 46: order.ApproveOrderResponse
-	(*CancelOrderRequest)(nil),                // 47: order.CancelOrderRequest
-	(*CancelOrderResponse)(nil),               // 48: order.CancelOrderResponse
-	(*GetOrderByIDRequest)(nil),               // 49: order.GetOrderByIDRequest
-	(*GetOrderByIDResponse)(nil),              // 50: order.GetOrderByIDResponse
-	(*Order)(nil),                             // 51: order.Order
-	(*OrderMetrics)(nil),                      // 52: order.OrderMetrics
-	(*GetOrdersByTraderIDRequest)(nil),        // 53: order.GetOrdersByTraderIDRequest
-	(*GetOrdersByTraderIDResponse)(nil),       // 54: order.GetOrdersByTraderIDResponse
-	nil,                                       // 55: order.AutomaticStats.DeviceStatsEntry
-	nil,                                       // 56: order.ProcessAutomaticPaymentRequest.MetadataEntry
-	(*timestamppb.Timestamp)(nil),             // 57: google.protobuf.Timestamp
-	(*Pagination)(nil),                        // 58: order.Pagination
-	(*durationpb.Duration)(nil),               // 59: google.protobuf.Duration
-	(*BankDetail)(nil),                        // 60: order.BankDetail
-	(*OrderFilters)(nil),                      // 61: order.OrderFilters
+	(*GetOrderHistoryRequest)(nil),              // 0: order.GetOrderHistoryRequest
+	(*OrderStatusTransition)(nil),               // 1: order.OrderStatusTransition
+	(*GetOrderHistoryResponse)(nil),             // 2: order.GetOrderHistoryResponse
+	(*RecoverCanceledOrderRequest)(nil),         // 3: order.RecoverCanceledOrderRequest
+	(*RecoverCanceledOrderResponse)(nil),        // 4: order.RecoverCanceledOrderResponse
+	(*GetCallbackDeliveriesRequest)(nil),        // 5: order.GetCallbackDeliveriesRequest
+	(*CallbackDelivery)(nil),                    // 6: order.CallbackDelivery
+	(*GetCallbackDeliveriesResponse)(nil),       // 7: order.GetCallbackDeliveriesResponse
+	(*ResendCallbackRequest)(nil),               // 8: order.ResendCallbackRequest
+	(*ResendCallbackResponse)(nil),              // 9: order.ResendCallbackResponse
+	(*MerchantCallbackSettings)(nil),            // 10: order.MerchantCallbackSettings
+	(*GetMerchantCallbackSettingsRequest)(nil),  // 11: order.GetMerchantCallbackSettingsRequest
+	(*GetMerchantCallbackSettingsResponse)(nil), // 12: order.GetMerchantCallbackSettingsResponse
+	(*SetMerchantCallbackSettingsRequest)(nil),  // 13: order.SetMerchantCallbackSettingsRequest
+	(*SetMerchantCallbackSettingsResponse)(nil), // 14: order.SetMerchantCallbackSettingsResponse
+	(*MerchantMatchingSettings)(nil),            // 15: order.MerchantMatchingSettings
+	(*GetMerchantMatchingSettingsRequest)(nil),  // 16: order.GetMerchantMatchingSettingsRequest
+	(*GetMerchantMatchingSettingsResponse)(nil), // 17: order.GetMerchantMatchingSettingsResponse
+	(*SetMerchantMatchingSettingsRequest)(nil),  // 18: order.SetMerchantMatchingSettingsRequest
+	(*SetMerchantMatchingSettingsResponse)(nil), // 19: order.SetMerchantMatchingSettingsResponse
+	(*RoutingScore)(nil),                        // 20: order.RoutingScore
+	(*GetRoutingScoresRequest)(nil),             // 21: order.GetRoutingScoresRequest
+	(*GetRoutingScoresResponse)(nil),            // 22: order.GetRoutingScoresResponse
+	(*SetRoutingScoreOverrideRequest)(nil),      // 23: order.SetRoutingScoreOverrideRequest
+	(*SetRoutingScoreOverrideResponse)(nil),     // 24: order.SetRoutingScoreOverrideResponse
+	(*BankDetailSelectionCandidate)(nil),        // 25: order.BankDetailSelectionCandidate
+	(*ExplainBankDetailSelectionResponse)(nil),  // 26: order.ExplainBankDetailSelectionResponse
+	(*AcceptOrderRequest)(nil),                  // 27: order.AcceptOrderRequest
+	(*AcceptOrderResponse)(nil),                 // 28: order.AcceptOrderResponse
+	(*CreatePayOutOrderRequest)(nil),            // 29: order.CreatePayOutOrderRequest
+	(*PaymentDetails)(nil),                      // 30: order.PaymentDetails
+	(*BankInfo)(nil),                            // 31: order.BankInfo
+	(*CreatePayOutOrderResponse)(nil),           // 32: order.CreatePayOutOrderResponse
+	(*NotificationTemplate)(nil),                // 33: order.NotificationTemplate
+	(*NotificationSample)(nil),                  // 34: order.NotificationSample
+	(*ParsedNotification)(nil),                  // 35: order.ParsedNotification
+	(*CreateNotificationTemplateRequest)(nil),   // 36: order.CreateNotificationTemplateRequest
+	(*CreateNotificationTemplateResponse)(nil),  // 37: order.CreateNotificationTemplateResponse
+	(*UpdateNotificationTemplateRequest)(nil),   // 38: order.UpdateNotificationTemplateRequest
+	(*UpdateNotificationTemplateResponse)(nil),  // 39: order.UpdateNotificationTemplateResponse
+	(*DeleteNotificationTemplateRequest)(nil),   // 40: order.DeleteNotificationTemplateRequest
+	(*DeleteNotificationTemplateResponse)(nil),  // 41: order.DeleteNotificationTemplateResponse
+	(*GetNotificationTemplatesRequest)(nil),     // 42: order.GetNotificationTemplatesRequest
+	(*GetNotificationTemplatesResponse)(nil),    // 43: order.GetNotificationTemplatesResponse
+	(*TestNotificationTemplateRequest)(nil),     // 44: order.TestNotificationTemplateRequest
+	(*TestNotificationTemplateResponse)(nil),    // 45: order.TestNotificationTemplateResponse
+	(*UnmatchedPayment)(nil),                    // 46: order.UnmatchedPayment
+	(*ListUnmatchedPaymentsRequest)(nil),        // 47: order.ListUnmatchedPaymentsRequest
+	(*ListUnmatchedPaymentsResponse)(nil),       // 48: order.ListUnmatchedPaymentsResponse
+	(*LinkPaymentToOrderRequest)(nil),           // 49: order.LinkPaymentToOrderRequest
+	(*LinkPaymentToOrderResponse)(nil),          // 50: order.LinkPaymentToOrderResponse
+	(*GetAutomaticStatsRequest)(nil),            // 51: order.GetAutomaticStatsRequest
+	(*DeviceStats)(nil),                         // 52: order.DeviceStats
+	(*AutomaticStats)(nil),                      // 53: order.AutomaticStats
+	(*GetAutomaticStatsResponse)(nil),           // 54: order.GetAutomaticStatsResponse
+	(*ProcessAutomaticPaymentRequest)(nil),      // 55: order.ProcessAutomaticPaymentRequest
+	(*ProcessAutomaticPaymentResponse)(nil),     // 56: order.ProcessAutomaticPaymentResponse
+	(*OrderProcessingResult)(nil),               // 57: order.OrderProcessingResult
+	(*AutomaticLog)(nil),                        // 58: order.AutomaticLog
+	(*AutomaticLogFilter)(nil),                  // 59: order.AutomaticLogFilter
+	(*GetAutomaticLogsRequest)(nil),             // 60: order.GetAutomaticLogsRequest
+	(*GetAutomaticLogsResponse)(nil),            // 61: order.GetAutomaticLogsResponse
+	(*GetAllOrdersRequest)(nil),                 // 62: order.GetAllOrdersRequest
+	(*GetAllOrdersResponse)(nil),                // 63: order.GetAllOrdersResponse
+	(*GetOrdersRequest)(nil),                    // 64: order.GetOrdersRequest
+	(*GetOrdersResponse)(nil),                   // 65: order.GetOrdersResponse
+	(*OrderResponse)(nil),                       // 66: order.OrderResponse
+	(*Amount)(nil),                              // 67: order.Amount
+	(*Requisites)(nil),                          // 68: order.Requisites
+	(*Pageable)(nil),                            // 69: order.Pageable
+	(*Sort)(nil),                                // 70: order.Sort
+	(*GetOrderStatisticsRequest)(nil),           // 71: order.GetOrderStatisticsRequest
+	(*GetOrderStatisticsResponse)(nil),          // 72: order.GetOrderStatisticsResponse
+	(*GetOrderDisputesRequest)(nil),             // 73: order.GetOrderDisputesRequest
+	(*GetOrderDisputesResponse)(nil),            // 74: order.GetOrderDisputesResponse
+	(*GetOrderByMerchantOrderIDRequest)(nil),    // 75: order.GetOrderByMerchantOrderIDRequest
+	(*GetOrderByMerchantOrderIDResponse)(nil),   // 76: order.GetOrderByMerchantOrderIDResponse
+	(*FreezeOrderDisputeRequest)(nil),           // 77: order.FreezeOrderDisputeRequest
+	(*FreezeOrderDisputeResponse)(nil),          // 78: order.FreezeOrderDisputeResponse
+	(*CreateOrderDisputeRequest)(nil),           // 79: order.CreateOrderDisputeRequest
+	(*CreateOrderDisputeResponse)(nil),          // 80: order.CreateOrderDisputeResponse
+	(*OrderDispute)(nil),                        // 81: order.OrderDispute
+	(*AcceptOrderDisputeRequest)(nil),           // 82: order.AcceptOrderDisputeRequest
+	(*AcceptOrderDisputeResponse)(nil),          // 83: order.AcceptOrderDisputeResponse
+	(*RejectOrderDisputeRequest)(nil),           // 84: order.RejectOrderDisputeRequest
+	(*RejectOrderDisputeResponse)(nil),          // 85: order.RejectOrderDisputeResponse
+	(*GetOrderDisputeInfoRequest)(nil),          // 86: order.GetOrderDisputeInfoRequest
+	(*GetOrderDisputeInfoResponse)(nil),         // 87: order.GetOrderDisputeInfoResponse
+	(*CreatePayInOrderRequest)(nil),             // 88: order.CreatePayInOrderRequest
+	(*CreatePayInOrderResponse)(nil),            // 89: order.CreatePayInOrderResponse
+	(*ApproveOrderRequest)(nil),                 // 90: order.ApproveOrderRequest
+	(*ApproveOrderResponse)(nil),                // 91: order.ApproveOrderResponse
+	(*CancelOrderRequest)(nil),                  // 92: order.CancelOrderRequest
+	(*CancelOrderResponse)(nil),                 // 93: order.CancelOrderResponse
+	(*GetOrderByIDRequest)(nil),                 // 94: order.GetOrderByIDRequest
+	(*GetOrderByIDResponse)(nil),                // 95: order.GetOrderByIDResponse
+	(*Order)(nil),                               // 96: order.Order
+	(*OrderMetrics)(nil),                        // 97: order.OrderMetrics
+	(*GetOrdersByTraderIDRequest)(nil),          // 98: order.GetOrdersByTraderIDRequest
+	(*GetOrdersByTraderIDResponse)(nil),         // 99: order.GetOrdersByTraderIDResponse
+	nil,                                         // 100: order.AutomaticStats.DeviceStatsEntry
+	nil,                                         // 101: order.ProcessAutomaticPaymentRequest.MetadataEntry
+	(*timestamppb.Timestamp)(nil),               // 102: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                 // 103: google.protobuf.Duration
+	(*Pagination)(nil),                          // 104: order.Pagination
+	(*BankDetail)(nil),                          // 105: order.BankDetail
+	(*OrderFilters)(nil),                        // 106: order.OrderFilters
 }
 var file_order_order_service_proto_depIdxs = []int32{
-	57, // 0: order.CreatePayOutOrderRequest.expires_at:type_name -> google.protobuf.Timestamp
-	3,  // 1: order.CreatePayOutOrderRequest.payment_details:type_name -> order.PaymentDetails
-	4,  // 2: order.PaymentDetails.bank_info:type_name -> order.BankInfo
-	51, // 3: order.CreatePayOutOrderResponse.order:type_name -> order.Order
-	55, // 4: order.AutomaticStats.device_stats:type_name -> order.AutomaticStats.DeviceStatsEntry
-	8,  // 5: order.GetAutomaticStatsResponse.stats:type_name -> order.AutomaticStats
-	56, // 6: order.ProcessAutomaticPaymentRequest.metadata:type_name -> order.ProcessAutomaticPaymentRequest.MetadataEntry
-	12, // 7: order.ProcessAutomaticPaymentResponse.results:type_name -> order.OrderProcessingResult
-	57, // 8: order.AutomaticLog.received_at:type_name -> google.protobuf.Timestamp
-	57, // 9: order.AutomaticLog.created_at:type_name -> google.protobuf.Timestamp
-	57, // 10: order.AutomaticLogFilter.start_date:type_name -> google.protobuf.Timestamp
-	57, // 11: order.AutomaticLogFilter.end_date:type_name -> google.protobuf.Timestamp
-	14, // 12: order.GetAutomaticLogsRequest.filter:type_name -> order.AutomaticLogFilter
-	13, // 13: order.GetAutomaticLogsResponse.logs:type_name -> order.AutomaticLog
-	57, // 14: order.GetAllOrdersRequest.time_opening_start:type_name -> google.protobuf.Timestamp
-	57, // 15: order.GetAllOrdersRequest.time_opening_end:type_name -> google.protobuf.Timestamp
-	51, // 16: order.GetAllOrdersResponse.orders:type_name -> order.Order
-	58, // 17: order.GetAllOrdersResponse.pagination:type_name -> order.Pagination
-	57, // 18: order.GetOrdersRequest.time_opening_start:type_name -> google.protobuf.Timestamp
-	57, // 19: order.GetOrdersRequest.time_opening_end:type_name -> google.protobuf.Timestamp
-	21, // 20: order.GetOrdersResponse.content:type_name -> order.OrderResponse
-	24, // 21: order.GetOrdersResponse.pageable:type_name -> order.Pageable
-	25, // 22: order.GetOrdersResponse.sort:type_name -> order.Sort
-	57, // 23: order.OrderResponse.time_opening:type_name -> google.protobuf.Timestamp
-	57, // 24: order.OrderResponse.time_expires:type_name -> google.protobuf.Timestamp
-	57, // 25: order.OrderResponse.time_complete:type_name -> google.protobuf.Timestamp
-	22, // 26: order.OrderResponse.sum_invoice:type_name -> order.Amount
-	22, // 27: order.OrderResponse.sum_deal:type_name -> order.Amount
-	23, // 28: order.OrderResponse.requisites:type_name -> order.Requisites
-	25, // 29: order.Pageable.sort:type_name -> order.Sort
-	57, // 30: order.GetOrderStatisticsRequest.date_from:type_name -> google.protobuf.Timestamp
-	57, // 31: order.GetOrderStatisticsRequest.date_to:type_name -> google.protobuf.Timestamp
-	36, // 32: order.GetOrderDisputesResponse.disputes:type_name -> order.OrderDispute
-	58, // 33: order.GetOrderDisputesResponse.pagination:type_name -> order.Pagination
-	51, // 34: order.GetOrderByMerchantOrderIDResponse.order:type_name -> order.Order
-	59, // 35: order.CreateOrderDisputeRequest.ttl:type_name -> google.protobuf.Duration
-	51, // 36: order.OrderDispute.order:type_name -> order.Order
-	57, // 37: order.OrderDispute.accept_at:type_name -> google.protobuf.Timestamp
-	36, // 38: order.GetOrderDisputeInfoResponse.dispute:type_name -> order.OrderDispute
-	57, // 39: order.CreatePayInOrderRequest.expires_at:type_name -> google.protobuf.Timestamp
-	51, // 40: order.CreatePayInOrderResponse.order:type_name -> order.Order
-	51, // 41: order.GetOrderByIDResponse.order:type_name -> order.Order
-	60, // 42: order.Order.bank_detail:type_name -> order.BankDetail
-	57, // 43: order.Order.expires_at:type_name -> google.protobuf.Timestamp
-	57, // 44: order.Order.created_at:type_name -> google.protobuf.Timestamp
-	57, // 45: order.Order.updated_at:type_name -> google.protobuf.Timestamp
-	52, // 46: order.Order.metrics:type_name -> order.OrderMetrics
-	57, // 47: order.OrderMetrics.completed_at:type_name -> google.protobuf.Timestamp
-	57, // 48: order.OrderMetrics.cancelled_ad:type_name -> google.protobuf.Timestamp
-	61, // 49: order.GetOrdersByTraderIDRequest.filters:type_name -> order.OrderFilters
-	51, // 50: order.GetOrdersByTraderIDResponse.orders:type_name -> order.Order
-	58, // 51: order.GetOrdersByTraderIDResponse.pagination:type_name -> order.Pagination
-	7,  // 52: order.AutomaticStats.DeviceStatsEntry.value:type_name -> order.DeviceStats
-	43, // 53: order.OrderService.CreatePayInOrder:input_type -> order.CreatePayInOrderRequest
-	2,  // 54: order.OrderService.CreatePayOutOrder:input_type -> order.CreatePayOutOrderRequest
-	45, // 55: order.OrderService.ApproveOrder:input_type -> order.ApproveOrderRequest
-	47, // 56: order.OrderService.CancelOrder:input_type -> order.CancelOrderRequest
-	0,  // 57: order.OrderService.AcceptOrder:input_type -> order.AcceptOrderRequest
-	49, // 58: order.OrderService.GetOrderByID:input_type -> order.GetOrderByIDRequest
-	30, // 59: order.OrderService.GetOrderByMerchantOrderID:input_type -> order.GetOrderByMerchantOrderIDRequest
-	53, // 60: order.OrderService.GetOrdersByTraderID:input_type -> order.GetOrdersByTraderIDRequest
-	34, // 61: order.OrderService.CreateOrderDispute:input_type -> order.CreateOrderDisputeRequest
-	37, // 62: order.OrderService.AcceptOrderDispute:input_type -> order.AcceptOrderDisputeRequest
-	39, // 63: order.OrderService.RejectOrderDispute:input_type -> order.RejectOrderDisputeRequest
-	41, // 64: order.OrderService.GetOrderDisputeInfo:input_type -> order.GetOrderDisputeInfoRequest
-	32, // 65: order.OrderService.FreezeOrderDispute:input_type -> order.FreezeOrderDisputeRequest
-	28, // 66: order.OrderService.GetOrderDisputes:input_type -> order.GetOrderDisputesRequest
-	26, // 67: order.OrderService.GetOrderStatistics:input_type -> order.GetOrderStatisticsRequest
-	19, // 68: order.OrderService.GetOrders:input_type -> order.GetOrdersRequest
-	17, // 69: order.OrderService.GetAllOrders:input_type -> order.GetAllOrdersRequest
-	10, // 70: order.OrderService.ProcessAutomaticPayment:input_type -> order.ProcessAutomaticPaymentRequest
-	15, // 71: order.OrderService.GetAutomaticLogs:input_type -> order.GetAutomaticLogsRequest
-	6,  // 72: order.OrderService.GetAutomaticStats:input_type -> order.GetAutomaticStatsRequest
-	44, // 73: order.OrderService.CreatePayInOrder:output_type -> order.CreatePayInOrderResponse
-	5,  // 74: order.OrderService.CreatePayOutOrder:output_type -> order.CreatePayOutOrderResponse
-	46, // 75: order.OrderService.ApproveOrder:output_type -> order.ApproveOrderResponse
-	48, // 76: order.OrderService.CancelOrder:output_type -> order.CancelOrderResponse
-	1,  // 77: order.OrderService.AcceptOrder:output_type -> order.AcceptOrderResponse
-	50, // 78: order.OrderService.GetOrderByID:output_type -> order.GetOrderByIDResponse
-	31, // 79: order.OrderService.GetOrderByMerchantOrderID:output_type -> order.GetOrderByMerchantOrderIDResponse
-	54, // 80: order.OrderService.GetOrdersByTraderID:output_type -> order.GetOrdersByTraderIDResponse
-	35, // 81: order.OrderService.CreateOrderDispute:output_type -> order.CreateOrderDisputeResponse
-	38, // 82: order.OrderService.AcceptOrderDispute:output_type -> order.AcceptOrderDisputeResponse
-	40, // 83: order.OrderService.RejectOrderDispute:output_type -> order.RejectOrderDisputeResponse
-	42, // 84: order.OrderService.GetOrderDisputeInfo:output_type -> order.GetOrderDisputeInfoResponse
-	33, // 85: order.OrderService.FreezeOrderDispute:output_type -> order.FreezeOrderDisputeResponse
-	29, // 86: order.OrderService.GetOrderDisputes:output_type -> order.GetOrderDisputesResponse
-	27, // 87: order.OrderService.GetOrderStatistics:output_type -> order.GetOrderStatisticsResponse
-	20, // 88: order.OrderService.GetOrders:output_type -> order.GetOrdersResponse
-	18, // 89: order.OrderService.GetAllOrders:output_type -> order.GetAllOrdersResponse
-	11, // 90: order.OrderService.ProcessAutomaticPayment:output_type -> order.ProcessAutomaticPaymentResponse
-	16, // 91: order.OrderService.GetAutomaticLogs:output_type -> order.GetAutomaticLogsResponse
-	9,  // 92: order.OrderService.GetAutomaticStats:output_type -> order.GetAutomaticStatsResponse
-	73, // [73:93] is the sub-list for method output_type
-	53, // [53:73] is the sub-list for method input_type
-	53, // [53:53] is the sub-list for extension type_name
-	53, // [53:53] is the sub-list for extension extendee
-	0,  // [0:53] is the sub-list for field type_name
+	102, // 0: order.OrderStatusTransition.created_at:type_name -> google.protobuf.Timestamp
+	1,   // 1: order.GetOrderHistoryResponse.transitions:type_name -> order.OrderStatusTransition
+	102, // 2: order.CallbackDelivery.next_attempt_at:type_name -> google.protobuf.Timestamp
+	102, // 3: order.CallbackDelivery.delivered_at:type_name -> google.protobuf.Timestamp
+	102, // 4: order.CallbackDelivery.created_at:type_name -> google.protobuf.Timestamp
+	6,   // 5: order.GetCallbackDeliveriesResponse.deliveries:type_name -> order.CallbackDelivery
+	6,   // 6: order.ResendCallbackResponse.delivery:type_name -> order.CallbackDelivery
+	10,  // 7: order.GetMerchantCallbackSettingsResponse.settings:type_name -> order.MerchantCallbackSettings
+	10,  // 8: order.SetMerchantCallbackSettingsResponse.settings:type_name -> order.MerchantCallbackSettings
+	103, // 9: order.MerchantMatchingSettings.wait_window:type_name -> google.protobuf.Duration
+	15,  // 10: order.GetMerchantMatchingSettingsResponse.settings:type_name -> order.MerchantMatchingSettings
+	15,  // 11: order.SetMerchantMatchingSettingsRequest.settings:type_name -> order.MerchantMatchingSettings
+	15,  // 12: order.SetMerchantMatchingSettingsResponse.settings:type_name -> order.MerchantMatchingSettings
+	103, // 13: order.RoutingScore.median_complete_time:type_name -> google.protobuf.Duration
+	102, // 14: order.RoutingScore.calculated_at:type_name -> google.protobuf.Timestamp
+	20,  // 15: order.GetRoutingScoresResponse.scores:type_name -> order.RoutingScore
+	104, // 16: order.GetRoutingScoresResponse.pagination:type_name -> order.Pagination
+	20,  // 17: order.SetRoutingScoreOverrideResponse.score:type_name -> order.RoutingScore
+	25,  // 18: order.ExplainBankDetailSelectionResponse.candidates:type_name -> order.BankDetailSelectionCandidate
+	102, // 19: order.CreatePayOutOrderRequest.expires_at:type_name -> google.protobuf.Timestamp
+	30,  // 20: order.CreatePayOutOrderRequest.payment_details:type_name -> order.PaymentDetails
+	31,  // 21: order.PaymentDetails.bank_info:type_name -> order.BankInfo
+	96,  // 22: order.CreatePayOutOrderResponse.order:type_name -> order.Order
+	34,  // 23: order.NotificationTemplate.samples:type_name -> order.NotificationSample
+	102, // 24: order.NotificationTemplate.created_at:type_name -> google.protobuf.Timestamp
+	102, // 25: order.NotificationTemplate.updated_at:type_name -> google.protobuf.Timestamp
+	35,  // 26: order.NotificationSample.expected:type_name -> order.ParsedNotification
+	33,  // 27: order.CreateNotificationTemplateRequest.template:type_name -> order.NotificationTemplate
+	33,  // 28: order.CreateNotificationTemplateResponse.template:type_name -> order.NotificationTemplate
+	33,  // 29: order.UpdateNotificationTemplateRequest.template:type_name -> order.NotificationTemplate
+	33,  // 30: order.UpdateNotificationTemplateResponse.template:type_name -> order.NotificationTemplate
+	33,  // 31: order.GetNotificationTemplatesResponse.templates:type_name -> order.NotificationTemplate
+	33,  // 32: order.TestNotificationTemplateRequest.template:type_name -> order.NotificationTemplate
+	35,  // 33: order.TestNotificationTemplateResponse.parsed:type_name -> order.ParsedNotification
+	102, // 34: order.UnmatchedPayment.received_at:type_name -> google.protobuf.Timestamp
+	102, // 35: order.UnmatchedPayment.linked_at:type_name -> google.protobuf.Timestamp
+	102, // 36: order.UnmatchedPayment.created_at:type_name -> google.protobuf.Timestamp
+	46,  // 37: order.ListUnmatchedPaymentsResponse.payments:type_name -> order.UnmatchedPayment
+	104, // 38: order.ListUnmatchedPaymentsResponse.pagination:type_name -> order.Pagination
+	46,  // 39: order.LinkPaymentToOrderResponse.payment:type_name -> order.UnmatchedPayment
+	100, // 40: order.AutomaticStats.device_stats:type_name -> order.AutomaticStats.DeviceStatsEntry
+	53,  // 41: order.GetAutomaticStatsResponse.stats:type_name -> order.AutomaticStats
+	101, // 42: order.ProcessAutomaticPaymentRequest.metadata:type_name -> order.ProcessAutomaticPaymentRequest.MetadataEntry
+	57,  // 43: order.ProcessAutomaticPaymentResponse.results:type_name -> order.OrderProcessingResult
+	102, // 44: order.AutomaticLog.received_at:type_name -> google.protobuf.Timestamp
+	102, // 45: order.AutomaticLog.created_at:type_name -> google.protobuf.Timestamp
+	102, // 46: order.AutomaticLogFilter.start_date:type_name -> google.protobuf.Timestamp
+	102, // 47: order.AutomaticLogFilter.end_date:type_name -> google.protobuf.Timestamp
+	59,  // 48: order.GetAutomaticLogsRequest.filter:type_name -> order.AutomaticLogFilter
+	58,  // 49: order.GetAutomaticLogsResponse.logs:type_name -> order.AutomaticLog
+	102, // 50: order.GetAllOrdersRequest.time_opening_start:type_name -> google.protobuf.Timestamp
+	102, // 51: order.GetAllOrdersRequest.time_opening_end:type_name -> google.protobuf.Timestamp
+	96,  // 52: order.GetAllOrdersResponse.orders:type_name -> order.Order
+	104, // 53: order.GetAllOrdersResponse.pagination:type_name -> order.Pagination
+	102, // 54: order.GetOrdersRequest.time_opening_start:type_name -> google.protobuf.Timestamp
+	102, // 55: order.GetOrdersRequest.time_opening_end:type_name -> google.protobuf.Timestamp
+	66,  // 56: order.GetOrdersResponse.content:type_name -> order.OrderResponse
+	69,  // 57: order.GetOrdersResponse.pageable:type_name -> order.Pageable
+	70,  // 58: order.GetOrdersResponse.sort:type_name -> order.Sort
+	102, // 59: order.OrderResponse.time_opening:type_name -> google.protobuf.Timestamp
+	102, // 60: order.OrderResponse.time_expires:type_name -> google.protobuf.Timestamp
+	102, // 61: order.OrderResponse.time_complete:type_name -> google.protobuf.Timestamp
+	67,  // 62: order.OrderResponse.sum_invoice:type_name -> order.Amount
+	67,  // 63: order.OrderResponse.sum_deal:type_name -> order.Amount
+	68,  // 64: order.OrderResponse.requisites:type_name -> order.Requisites
+	70,  // 65: order.Pageable.sort:type_name -> order.Sort
+	102, // 66: order.GetOrderStatisticsRequest.date_from:type_name -> google.protobuf.Timestamp
+	102, // 67: order.GetOrderStatisticsRequest.date_to:type_name -> google.protobuf.Timestamp
+	81,  // 68: order.GetOrderDisputesResponse.disputes:type_name -> order.OrderDispute
+	104, // 69: order.GetOrderDisputesResponse.pagination:type_name -> order.Pagination
+	96,  // 70: order.GetOrderByMerchantOrderIDResponse.order:type_name -> order.Order
+	103, // 71: order.CreateOrderDisputeRequest.ttl:type_name -> google.protobuf.Duration
+	96,  // 72: order.OrderDispute.order:type_name -> order.Order
+	102, // 73: order.OrderDispute.accept_at:type_name -> google.protobuf.Timestamp
+	81,  // 74: order.GetOrderDisputeInfoResponse.dispute:type_name -> order.OrderDispute
+	102, // 75: order.CreatePayInOrderRequest.expires_at:type_name -> google.protobuf.Timestamp
+	96,  // 76: order.CreatePayInOrderResponse.order:type_name -> order.Order
+	96,  // 77: order.GetOrderByIDResponse.order:type_name -> order.Order
+	105, // 78: order.Order.bank_detail:type_name -> order.BankDetail
+	102, // 79: order.Order.expires_at:type_name -> google.protobuf.Timestamp
+	102, // 80: order.Order.created_at:type_name -> google.protobuf.Timestamp
+	102, // 81: order.Order.updated_at:type_name -> google.protobuf.Timestamp
+	97,  // 82: order.Order.metrics:type_name -> order.OrderMetrics
+	102, // 83: order.OrderMetrics.completed_at:type_name -> google.protobuf.Timestamp
+	102, // 84: order.OrderMetrics.cancelled_ad:type_name -> google.protobuf.Timestamp
+	106, // 85: order.GetOrdersByTraderIDRequest.filters:type_name -> order.OrderFilters
+	96,  // 86: order.GetOrdersByTraderIDResponse.orders:type_name -> order.Order
+	104, // 87: order.GetOrdersByTraderIDResponse.pagination:type_name -> order.Pagination
+	52,  // 88: order.AutomaticStats.DeviceStatsEntry.value:type_name -> order.DeviceStats
+	88,  // 89: order.OrderService.CreatePayInOrder:input_type -> order.CreatePayInOrderRequest
+	29,  // 90: order.OrderService.CreatePayOutOrder:input_type -> order.CreatePayOutOrderRequest
+	90,  // 91: order.OrderService.ApproveOrder:input_type -> order.ApproveOrderRequest
+	92,  // 92: order.OrderService.CancelOrder:input_type -> order.CancelOrderRequest
+	27,  // 93: order.OrderService.AcceptOrder:input_type -> order.AcceptOrderRequest
+	94,  // 94: order.OrderService.GetOrderByID:input_type -> order.GetOrderByIDRequest
+	75,  // 95: order.OrderService.GetOrderByMerchantOrderID:input_type -> order.GetOrderByMerchantOrderIDRequest
+	98,  // 96: order.OrderService.GetOrdersByTraderID:input_type -> order.GetOrdersByTraderIDRequest
+	79,  // 97: order.OrderService.CreateOrderDispute:input_type -> order.CreateOrderDisputeRequest
+	82,  // 98: order.OrderService.AcceptOrderDispute:input_type -> order.AcceptOrderDisputeRequest
+	84,  // 99: order.OrderService.RejectOrderDispute:input_type -> order.RejectOrderDisputeRequest
+	86,  // 100: order.OrderService.GetOrderDisputeInfo:input_type -> order.GetOrderDisputeInfoRequest
+	77,  // 101: order.OrderService.FreezeOrderDispute:input_type -> order.FreezeOrderDisputeRequest
+	73,  // 102: order.OrderService.GetOrderDisputes:input_type -> order.GetOrderDisputesRequest
+	71,  // 103: order.OrderService.GetOrderStatistics:input_type -> order.GetOrderStatisticsRequest
+	64,  // 104: order.OrderService.GetOrders:input_type -> order.GetOrdersRequest
+	62,  // 105: order.OrderService.GetAllOrders:input_type -> order.GetAllOrdersRequest
+	55,  // 106: order.OrderService.ProcessAutomaticPayment:input_type -> order.ProcessAutomaticPaymentRequest
+	60,  // 107: order.OrderService.GetAutomaticLogs:input_type -> order.GetAutomaticLogsRequest
+	51,  // 108: order.OrderService.GetAutomaticStats:input_type -> order.GetAutomaticStatsRequest
+	0,   // 109: order.OrderService.GetOrderHistory:input_type -> order.GetOrderHistoryRequest
+	3,   // 110: order.OrderService.RecoverCanceledOrder:input_type -> order.RecoverCanceledOrderRequest
+	5,   // 111: order.OrderService.GetCallbackDeliveries:input_type -> order.GetCallbackDeliveriesRequest
+	8,   // 112: order.OrderService.ResendCallback:input_type -> order.ResendCallbackRequest
+	11,  // 113: order.OrderService.GetMerchantCallbackSettings:input_type -> order.GetMerchantCallbackSettingsRequest
+	13,  // 114: order.OrderService.SetMerchantCallbackSettings:input_type -> order.SetMerchantCallbackSettingsRequest
+	16,  // 115: order.OrderService.GetMerchantMatchingSettings:input_type -> order.GetMerchantMatchingSettingsRequest
+	18,  // 116: order.OrderService.SetMerchantMatchingSettings:input_type -> order.SetMerchantMatchingSettingsRequest
+	88,  // 117: order.OrderService.ExplainBankDetailSelection:input_type -> order.CreatePayInOrderRequest
+	21,  // 118: order.OrderService.GetRoutingScores:input_type -> order.GetRoutingScoresRequest
+	23,  // 119: order.OrderService.SetRoutingScoreOverride:input_type -> order.SetRoutingScoreOverrideRequest
+	36,  // 120: order.OrderService.CreateNotificationTemplate:input_type -> order.CreateNotificationTemplateRequest
+	38,  // 121: order.OrderService.UpdateNotificationTemplate:input_type -> order.UpdateNotificationTemplateRequest
+	40,  // 122: order.OrderService.DeleteNotificationTemplate:input_type -> order.DeleteNotificationTemplateRequest
+	42,  // 123: order.OrderService.GetNotificationTemplates:input_type -> order.GetNotificationTemplatesRequest
+	44,  // 124: order.OrderService.TestNotificationTemplate:input_type -> order.TestNotificationTemplateRequest
+	47,  // 125: order.OrderService.ListUnmatchedPayments:input_type -> order.ListUnmatchedPaymentsRequest
+	49,  // 126: order.OrderService.LinkPaymentToOrder:input_type -> order.LinkPaymentToOrderRequest
+	89,  // 127: order.OrderService.CreatePayInOrder:output_type -> order.CreatePayInOrderResponse
+	32,  // 128: order.OrderService.CreatePayOutOrder:output_type -> order.CreatePayOutOrderResponse
+	91,  // 129: order.OrderService.ApproveOrder:output_type -> order.ApproveOrderResponse
+	93,  // 130: order.OrderService.CancelOrder:output_type -> order.CancelOrderResponse
+	28,  // 131: order.OrderService.AcceptOrder:output_type -> order.AcceptOrderResponse
+	95,  // 132: order.OrderService.GetOrderByID:output_type -> order.GetOrderByIDResponse
+	76,  // 133: order.OrderService.GetOrderByMerchantOrderID:output_type -> order.GetOrderByMerchantOrderIDResponse
+	99,  // 134: order.OrderService.GetOrdersByTraderID:output_type -> order.GetOrdersByTraderIDResponse
+	80,  // 135: order.OrderService.CreateOrderDispute:output_type -> order.CreateOrderDisputeResponse
+	83,  // 136: order.OrderService.AcceptOrderDispute:output_type -> order.AcceptOrderDisputeResponse
+	85,  // 137: order.OrderService.RejectOrderDispute:output_type -> order.RejectOrderDisputeResponse
+	87,  // 138: order.OrderService.GetOrderDisputeInfo:output_type -> order.GetOrderDisputeInfoResponse
+	78,  // 139: order.OrderService.FreezeOrderDispute:output_type -> order.FreezeOrderDisputeResponse
+	74,  // 140: order.OrderService.GetOrderDisputes:output_type -> order.GetOrderDisputesResponse
+	72,  // 141: order.OrderService.GetOrderStatistics:output_type -> order.GetOrderStatisticsResponse
+	65,  // 142: order.OrderService.GetOrders:output_type -> order.GetOrdersResponse
+	63,  // 143: order.OrderService.GetAllOrders:output_type -> order.GetAllOrdersResponse
+	56,  // 144: order.OrderService.ProcessAutomaticPayment:output_type -> order.ProcessAutomaticPaymentResponse
+	61,  // 145: order.OrderService.GetAutomaticLogs:output_type -> order.GetAutomaticLogsResponse
+	54,  // 146: order.OrderService.GetAutomaticStats:output_type -> order.GetAutomaticStatsResponse
+	2,   // 147: order.OrderService.GetOrderHistory:output_type -> order.GetOrderHistoryResponse
+	4,   // 148: order.OrderService.RecoverCanceledOrder:output_type -> order.RecoverCanceledOrderResponse
+	7,   // 149: order.OrderService.GetCallbackDeliveries:output_type -> order.GetCallbackDeliveriesResponse
+	9,   // 150: order.OrderService.ResendCallback:output_type -> order.ResendCallbackResponse
+	12,  // 151: order.OrderService.GetMerchantCallbackSettings:output_type -> order.GetMerchantCallbackSettingsResponse
+	14,  // 152: order.OrderService.SetMerchantCallbackSettings:output_type -> order.SetMerchantCallbackSettingsResponse
+	17,  // 153: order.OrderService.GetMerchantMatchingSettings:output_type -> order.GetMerchantMatchingSettingsResponse
+	19,  // 154: order.OrderService.SetMerchantMatchingSettings:output_type -> order.SetMerchantMatchingSettingsResponse
+	26,  // 155: order.OrderService.ExplainBankDetailSelection:output_type -> order.ExplainBankDetailSelectionResponse
+	22,  // 156: order.OrderService.GetRoutingScores:output_type -> order.GetRoutingScoresResponse
+	24,  // 157: order.OrderService.SetRoutingScoreOverride:output_type -> order.SetRoutingScoreOverrideResponse
+	37,  // 158: order.OrderService.CreateNotificationTemplate:output_type -> order.CreateNotificationTemplateResponse
+	39,  // 159: order.OrderService.UpdateNotificationTemplate:output_type -> order.UpdateNotificationTemplateResponse
+	41,  // 160: order.OrderService.DeleteNotificationTemplate:output_type -> order.DeleteNotificationTemplateResponse
+	43,  // 161: order.OrderService.GetNotificationTemplates:output_type -> order.GetNotificationTemplatesResponse
+	45,  // 162: order.OrderService.TestNotificationTemplate:output_type -> order.TestNotificationTemplateResponse
+	48,  // 163: order.OrderService.ListUnmatchedPayments:output_type -> order.ListUnmatchedPaymentsResponse
+	50,  // 164: order.OrderService.LinkPaymentToOrder:output_type -> order.LinkPaymentToOrderResponse
+	127, // [127:165] is the sub-list for method output_type
+	89,  // [89:127] is the sub-list for method input_type
+	89,  // [89:89] is the sub-list for extension type_name
+	89,  // [89:89] is the sub-list for extension extendee
+	0,   // [0:89] is the sub-list for field type_name
 }
 
 func init() { file_order_order_service_proto_init() }
@@ -4554,17 +7645,19 @@ func file_order_order_service_proto_init() {
 	}
 	file_order_bank_detail_service_proto_init()
 	file_order_common_types_proto_init()
-	file_order_order_service_proto_msgTypes[14].OneofWrappers = []any{}
-	file_order_order_service_proto_msgTypes[17].OneofWrappers = []any{}
-	file_order_order_service_proto_msgTypes[19].OneofWrappers = []any{}
-	file_order_order_service_proto_msgTypes[28].OneofWrappers = []any{}
+	file_order_order_service_proto_msgTypes[20].OneofWrappers = []any{}
+	file_order_order_service_proto_msgTypes[23].OneofWrappers = []any{}
+	file_order_order_service_proto_msgTypes[59].OneofWrappers = []any{}
+	file_order_order_service_proto_msgTypes[62].OneofWrappers = []any{}
+	file_order_order_service_proto_msgTypes[64].OneofWrappers = []any{}
+	file_order_order_service_proto_msgTypes[73].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_order_service_proto_rawDesc), len(file_order_order_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   57,
+			NumMessages:   102,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
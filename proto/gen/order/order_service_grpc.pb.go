@@ -19,26 +19,44 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrderService_CreatePayInOrder_FullMethodName          = "/order.OrderService/CreatePayInOrder"
-	OrderService_CreatePayOutOrder_FullMethodName         = "/order.OrderService/CreatePayOutOrder"
-	OrderService_ApproveOrder_FullMethodName              = "/order.OrderService/ApproveOrder"
-	OrderService_CancelOrder_FullMethodName               = "/order.OrderService/CancelOrder"
-	OrderService_AcceptOrder_FullMethodName               = "/order.OrderService/AcceptOrder"
-	OrderService_GetOrderByID_FullMethodName              = "/order.OrderService/GetOrderByID"
-	OrderService_GetOrderByMerchantOrderID_FullMethodName = "/order.OrderService/GetOrderByMerchantOrderID"
-	OrderService_GetOrdersByTraderID_FullMethodName       = "/order.OrderService/GetOrdersByTraderID"
-	OrderService_CreateOrderDispute_FullMethodName        = "/order.OrderService/CreateOrderDispute"
-	OrderService_AcceptOrderDispute_FullMethodName        = "/order.OrderService/AcceptOrderDispute"
-	OrderService_RejectOrderDispute_FullMethodName        = "/order.OrderService/RejectOrderDispute"
-	OrderService_GetOrderDisputeInfo_FullMethodName       = "/order.OrderService/GetOrderDisputeInfo"
-	OrderService_FreezeOrderDispute_FullMethodName        = "/order.OrderService/FreezeOrderDispute"
-	OrderService_GetOrderDisputes_FullMethodName          = "/order.OrderService/GetOrderDisputes"
-	OrderService_GetOrderStatistics_FullMethodName        = "/order.OrderService/GetOrderStatistics"
-	OrderService_GetOrders_FullMethodName                 = "/order.OrderService/GetOrders"
-	OrderService_GetAllOrders_FullMethodName              = "/order.OrderService/GetAllOrders"
-	OrderService_ProcessAutomaticPayment_FullMethodName   = "/order.OrderService/ProcessAutomaticPayment"
-	OrderService_GetAutomaticLogs_FullMethodName          = "/order.OrderService/GetAutomaticLogs"
-	OrderService_GetAutomaticStats_FullMethodName         = "/order.OrderService/GetAutomaticStats"
+	OrderService_CreatePayInOrder_FullMethodName            = "/order.OrderService/CreatePayInOrder"
+	OrderService_CreatePayOutOrder_FullMethodName           = "/order.OrderService/CreatePayOutOrder"
+	OrderService_ApproveOrder_FullMethodName                = "/order.OrderService/ApproveOrder"
+	OrderService_CancelOrder_FullMethodName                 = "/order.OrderService/CancelOrder"
+	OrderService_AcceptOrder_FullMethodName                 = "/order.OrderService/AcceptOrder"
+	OrderService_GetOrderByID_FullMethodName                = "/order.OrderService/GetOrderByID"
+	OrderService_GetOrderByMerchantOrderID_FullMethodName   = "/order.OrderService/GetOrderByMerchantOrderID"
+	OrderService_GetOrdersByTraderID_FullMethodName         = "/order.OrderService/GetOrdersByTraderID"
+	OrderService_CreateOrderDispute_FullMethodName          = "/order.OrderService/CreateOrderDispute"
+	OrderService_AcceptOrderDispute_FullMethodName          = "/order.OrderService/AcceptOrderDispute"
+	OrderService_RejectOrderDispute_FullMethodName          = "/order.OrderService/RejectOrderDispute"
+	OrderService_GetOrderDisputeInfo_FullMethodName         = "/order.OrderService/GetOrderDisputeInfo"
+	OrderService_FreezeOrderDispute_FullMethodName          = "/order.OrderService/FreezeOrderDispute"
+	OrderService_GetOrderDisputes_FullMethodName            = "/order.OrderService/GetOrderDisputes"
+	OrderService_GetOrderStatistics_FullMethodName          = "/order.OrderService/GetOrderStatistics"
+	OrderService_GetOrders_FullMethodName                   = "/order.OrderService/GetOrders"
+	OrderService_GetAllOrders_FullMethodName                = "/order.OrderService/GetAllOrders"
+	OrderService_ProcessAutomaticPayment_FullMethodName     = "/order.OrderService/ProcessAutomaticPayment"
+	OrderService_GetAutomaticLogs_FullMethodName            = "/order.OrderService/GetAutomaticLogs"
+	OrderService_GetAutomaticStats_FullMethodName           = "/order.OrderService/GetAutomaticStats"
+	OrderService_GetOrderHistory_FullMethodName             = "/order.OrderService/GetOrderHistory"
+	OrderService_RecoverCanceledOrder_FullMethodName        = "/order.OrderService/RecoverCanceledOrder"
+	OrderService_GetCallbackDeliveries_FullMethodName       = "/order.OrderService/GetCallbackDeliveries"
+	OrderService_ResendCallback_FullMethodName              = "/order.OrderService/ResendCallback"
+	OrderService_GetMerchantCallbackSettings_FullMethodName = "/order.OrderService/GetMerchantCallbackSettings"
+	OrderService_SetMerchantCallbackSettings_FullMethodName = "/order.OrderService/SetMerchantCallbackSettings"
+	OrderService_GetMerchantMatchingSettings_FullMethodName = "/order.OrderService/GetMerchantMatchingSettings"
+	OrderService_SetMerchantMatchingSettings_FullMethodName = "/order.OrderService/SetMerchantMatchingSettings"
+	OrderService_ExplainBankDetailSelection_FullMethodName  = "/order.OrderService/ExplainBankDetailSelection"
+	OrderService_GetRoutingScores_FullMethodName            = "/order.OrderService/GetRoutingScores"
+	OrderService_SetRoutingScoreOverride_FullMethodName     = "/order.OrderService/SetRoutingScoreOverride"
+	OrderService_CreateNotificationTemplate_FullMethodName  = "/order.OrderService/CreateNotificationTemplate"
+	OrderService_UpdateNotificationTemplate_FullMethodName  = "/order.OrderService/UpdateNotificationTemplate"
+	OrderService_DeleteNotificationTemplate_FullMethodName  = "/order.OrderService/DeleteNotificationTemplate"
+	OrderService_GetNotificationTemplates_FullMethodName    = "/order.OrderService/GetNotificationTemplates"
+	OrderService_TestNotificationTemplate_FullMethodName    = "/order.OrderService/TestNotificationTemplate"
+	OrderService_ListUnmatchedPayments_FullMethodName       = "/order.OrderService/ListUnmatchedPayments"
+	OrderService_LinkPaymentToOrder_FullMethodName          = "/order.OrderService/LinkPaymentToOrder"
 )
 
 // OrderServiceClient is the client API for OrderService service.
@@ -65,6 +83,26 @@ type OrderServiceClient interface {
 	ProcessAutomaticPayment(ctx context.Context, in *ProcessAutomaticPaymentRequest, opts ...grpc.CallOption) (*ProcessAutomaticPaymentResponse, error)
 	GetAutomaticLogs(ctx context.Context, in *GetAutomaticLogsRequest, opts ...grpc.CallOption) (*GetAutomaticLogsResponse, error)
 	GetAutomaticStats(ctx context.Context, in *GetAutomaticStatsRequest, opts ...grpc.CallOption) (*GetAutomaticStatsResponse, error)
+	GetOrderHistory(ctx context.Context, in *GetOrderHistoryRequest, opts ...grpc.CallOption) (*GetOrderHistoryResponse, error)
+	RecoverCanceledOrder(ctx context.Context, in *RecoverCanceledOrderRequest, opts ...grpc.CallOption) (*RecoverCanceledOrderResponse, error)
+	GetCallbackDeliveries(ctx context.Context, in *GetCallbackDeliveriesRequest, opts ...grpc.CallOption) (*GetCallbackDeliveriesResponse, error)
+	ResendCallback(ctx context.Context, in *ResendCallbackRequest, opts ...grpc.CallOption) (*ResendCallbackResponse, error)
+	GetMerchantCallbackSettings(ctx context.Context, in *GetMerchantCallbackSettingsRequest, opts ...grpc.CallOption) (*GetMerchantCallbackSettingsResponse, error)
+	SetMerchantCallbackSettings(ctx context.Context, in *SetMerchantCallbackSettingsRequest, opts ...grpc.CallOption) (*SetMerchantCallbackSettingsResponse, error)
+	GetMerchantMatchingSettings(ctx context.Context, in *GetMerchantMatchingSettingsRequest, opts ...grpc.CallOption) (*GetMerchantMatchingSettingsResponse, error)
+	SetMerchantMatchingSettings(ctx context.Context, in *SetMerchantMatchingSettingsRequest, opts ...grpc.CallOption) (*SetMerchantMatchingSettingsResponse, error)
+	ExplainBankDetailSelection(ctx context.Context, in *CreatePayInOrderRequest, opts ...grpc.CallOption) (*ExplainBankDetailSelectionResponse, error)
+	GetRoutingScores(ctx context.Context, in *GetRoutingScoresRequest, opts ...grpc.CallOption) (*GetRoutingScoresResponse, error)
+	SetRoutingScoreOverride(ctx context.Context, in *SetRoutingScoreOverrideRequest, opts ...grpc.CallOption) (*SetRoutingScoreOverrideResponse, error)
+	// Шаблоны разбора уведомлений банков для сверки автоматических платежей
+	CreateNotificationTemplate(ctx context.Context, in *CreateNotificationTemplateRequest, opts ...grpc.CallOption) (*CreateNotificationTemplateResponse, error)
+	UpdateNotificationTemplate(ctx context.Context, in *UpdateNotificationTemplateRequest, opts ...grpc.CallOption) (*UpdateNotificationTemplateResponse, error)
+	DeleteNotificationTemplate(ctx context.Context, in *DeleteNotificationTemplateRequest, opts ...grpc.CallOption) (*DeleteNotificationTemplateResponse, error)
+	GetNotificationTemplates(ctx context.Context, in *GetNotificationTemplatesRequest, opts ...grpc.CallOption) (*GetNotificationTemplatesResponse, error)
+	TestNotificationTemplate(ctx context.Context, in *TestNotificationTemplateRequest, opts ...grpc.CallOption) (*TestNotificationTemplateResponse, error)
+	// Уведомления об оплате, для которых не нашлось сделки
+	ListUnmatchedPayments(ctx context.Context, in *ListUnmatchedPaymentsRequest, opts ...grpc.CallOption) (*ListUnmatchedPaymentsResponse, error)
+	LinkPaymentToOrder(ctx context.Context, in *LinkPaymentToOrderRequest, opts ...grpc.CallOption) (*LinkPaymentToOrderResponse, error)
 }
 
 type orderServiceClient struct {
@@ -275,6 +313,186 @@ func (c *orderServiceClient) GetAutomaticStats(ctx context.Context, in *GetAutom
 	return out, nil
 }
 
+func (c *orderServiceClient) GetOrderHistory(ctx context.Context, in *GetOrderHistoryRequest, opts ...grpc.CallOption) (*GetOrderHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrderHistoryResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetOrderHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RecoverCanceledOrder(ctx context.Context, in *RecoverCanceledOrderRequest, opts ...grpc.CallOption) (*RecoverCanceledOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoverCanceledOrderResponse)
+	err := c.cc.Invoke(ctx, OrderService_RecoverCanceledOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetCallbackDeliveries(ctx context.Context, in *GetCallbackDeliveriesRequest, opts ...grpc.CallOption) (*GetCallbackDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCallbackDeliveriesResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetCallbackDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ResendCallback(ctx context.Context, in *ResendCallbackRequest, opts ...grpc.CallOption) (*ResendCallbackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResendCallbackResponse)
+	err := c.cc.Invoke(ctx, OrderService_ResendCallback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetMerchantCallbackSettings(ctx context.Context, in *GetMerchantCallbackSettingsRequest, opts ...grpc.CallOption) (*GetMerchantCallbackSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMerchantCallbackSettingsResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetMerchantCallbackSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) SetMerchantCallbackSettings(ctx context.Context, in *SetMerchantCallbackSettingsRequest, opts ...grpc.CallOption) (*SetMerchantCallbackSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMerchantCallbackSettingsResponse)
+	err := c.cc.Invoke(ctx, OrderService_SetMerchantCallbackSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetMerchantMatchingSettings(ctx context.Context, in *GetMerchantMatchingSettingsRequest, opts ...grpc.CallOption) (*GetMerchantMatchingSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMerchantMatchingSettingsResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetMerchantMatchingSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) SetMerchantMatchingSettings(ctx context.Context, in *SetMerchantMatchingSettingsRequest, opts ...grpc.CallOption) (*SetMerchantMatchingSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMerchantMatchingSettingsResponse)
+	err := c.cc.Invoke(ctx, OrderService_SetMerchantMatchingSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ExplainBankDetailSelection(ctx context.Context, in *CreatePayInOrderRequest, opts ...grpc.CallOption) (*ExplainBankDetailSelectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainBankDetailSelectionResponse)
+	err := c.cc.Invoke(ctx, OrderService_ExplainBankDetailSelection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetRoutingScores(ctx context.Context, in *GetRoutingScoresRequest, opts ...grpc.CallOption) (*GetRoutingScoresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoutingScoresResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetRoutingScores_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) SetRoutingScoreOverride(ctx context.Context, in *SetRoutingScoreOverrideRequest, opts ...grpc.CallOption) (*SetRoutingScoreOverrideResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRoutingScoreOverrideResponse)
+	err := c.cc.Invoke(ctx, OrderService_SetRoutingScoreOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CreateNotificationTemplate(ctx context.Context, in *CreateNotificationTemplateRequest, opts ...grpc.CallOption) (*CreateNotificationTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateNotificationTemplateResponse)
+	err := c.cc.Invoke(ctx, OrderService_CreateNotificationTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) UpdateNotificationTemplate(ctx context.Context, in *UpdateNotificationTemplateRequest, opts ...grpc.CallOption) (*UpdateNotificationTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateNotificationTemplateResponse)
+	err := c.cc.Invoke(ctx, OrderService_UpdateNotificationTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) DeleteNotificationTemplate(ctx context.Context, in *DeleteNotificationTemplateRequest, opts ...grpc.CallOption) (*DeleteNotificationTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteNotificationTemplateResponse)
+	err := c.cc.Invoke(ctx, OrderService_DeleteNotificationTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetNotificationTemplates(ctx context.Context, in *GetNotificationTemplatesRequest, opts ...grpc.CallOption) (*GetNotificationTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNotificationTemplatesResponse)
+	err := c.cc.Invoke(ctx, OrderService_GetNotificationTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) TestNotificationTemplate(ctx context.Context, in *TestNotificationTemplateRequest, opts ...grpc.CallOption) (*TestNotificationTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestNotificationTemplateResponse)
+	err := c.cc.Invoke(ctx, OrderService_TestNotificationTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListUnmatchedPayments(ctx context.Context, in *ListUnmatchedPaymentsRequest, opts ...grpc.CallOption) (*ListUnmatchedPaymentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUnmatchedPaymentsResponse)
+	err := c.cc.Invoke(ctx, OrderService_ListUnmatchedPayments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) LinkPaymentToOrder(ctx context.Context, in *LinkPaymentToOrderRequest, opts ...grpc.CallOption) (*LinkPaymentToOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LinkPaymentToOrderResponse)
+	err := c.cc.Invoke(ctx, OrderService_LinkPaymentToOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility.
@@ -299,6 +517,26 @@ type OrderServiceServer interface {
 	ProcessAutomaticPayment(context.Context, *ProcessAutomaticPaymentRequest) (*ProcessAutomaticPaymentResponse, error)
 	GetAutomaticLogs(context.Context, *GetAutomaticLogsRequest) (*GetAutomaticLogsResponse, error)
 	GetAutomaticStats(context.Context, *GetAutomaticStatsRequest) (*GetAutomaticStatsResponse, error)
+	GetOrderHistory(context.Context, *GetOrderHistoryRequest) (*GetOrderHistoryResponse, error)
+	RecoverCanceledOrder(context.Context, *RecoverCanceledOrderRequest) (*RecoverCanceledOrderResponse, error)
+	GetCallbackDeliveries(context.Context, *GetCallbackDeliveriesRequest) (*GetCallbackDeliveriesResponse, error)
+	ResendCallback(context.Context, *ResendCallbackRequest) (*ResendCallbackResponse, error)
+	GetMerchantCallbackSettings(context.Context, *GetMerchantCallbackSettingsRequest) (*GetMerchantCallbackSettingsResponse, error)
+	SetMerchantCallbackSettings(context.Context, *SetMerchantCallbackSettingsRequest) (*SetMerchantCallbackSettingsResponse, error)
+	GetMerchantMatchingSettings(context.Context, *GetMerchantMatchingSettingsRequest) (*GetMerchantMatchingSettingsResponse, error)
+	SetMerchantMatchingSettings(context.Context, *SetMerchantMatchingSettingsRequest) (*SetMerchantMatchingSettingsResponse, error)
+	ExplainBankDetailSelection(context.Context, *CreatePayInOrderRequest) (*ExplainBankDetailSelectionResponse, error)
+	GetRoutingScores(context.Context, *GetRoutingScoresRequest) (*GetRoutingScoresResponse, error)
+	SetRoutingScoreOverride(context.Context, *SetRoutingScoreOverrideRequest) (*SetRoutingScoreOverrideResponse, error)
+	// Шаблоны разбора уведомлений банков для сверки автоматических платежей
+	CreateNotificationTemplate(context.Context, *CreateNotificationTemplateRequest) (*CreateNotificationTemplateResponse, error)
+	UpdateNotificationTemplate(context.Context, *UpdateNotificationTemplateRequest) (*UpdateNotificationTemplateResponse, error)
+	DeleteNotificationTemplate(context.Context, *DeleteNotificationTemplateRequest) (*DeleteNotificationTemplateResponse, error)
+	GetNotificationTemplates(context.Context, *GetNotificationTemplatesRequest) (*GetNotificationTemplatesResponse, error)
+	TestNotificationTemplate(context.Context, *TestNotificationTemplateRequest) (*TestNotificationTemplateResponse, error)
+	// Уведомления об оплате, для которых не нашлось сделки
+	ListUnmatchedPayments(context.Context, *ListUnmatchedPaymentsRequest) (*ListUnmatchedPaymentsResponse, error)
+	LinkPaymentToOrder(context.Context, *LinkPaymentToOrderRequest) (*LinkPaymentToOrderResponse, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -369,6 +607,60 @@ func (UnimplementedOrderServiceServer) GetAutomaticLogs(context.Context, *GetAut
 func (UnimplementedOrderServiceServer) GetAutomaticStats(context.Context, *GetAutomaticStatsRequest) (*GetAutomaticStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAutomaticStats not implemented")
 }
+func (UnimplementedOrderServiceServer) GetOrderHistory(context.Context, *GetOrderHistoryRequest) (*GetOrderHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderHistory not implemented")
+}
+func (UnimplementedOrderServiceServer) RecoverCanceledOrder(context.Context, *RecoverCanceledOrderRequest) (*RecoverCanceledOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecoverCanceledOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) GetCallbackDeliveries(context.Context, *GetCallbackDeliveriesRequest) (*GetCallbackDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCallbackDeliveries not implemented")
+}
+func (UnimplementedOrderServiceServer) ResendCallback(context.Context, *ResendCallbackRequest) (*ResendCallbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendCallback not implemented")
+}
+func (UnimplementedOrderServiceServer) GetMerchantCallbackSettings(context.Context, *GetMerchantCallbackSettingsRequest) (*GetMerchantCallbackSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMerchantCallbackSettings not implemented")
+}
+func (UnimplementedOrderServiceServer) SetMerchantCallbackSettings(context.Context, *SetMerchantCallbackSettingsRequest) (*SetMerchantCallbackSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMerchantCallbackSettings not implemented")
+}
+func (UnimplementedOrderServiceServer) GetMerchantMatchingSettings(context.Context, *GetMerchantMatchingSettingsRequest) (*GetMerchantMatchingSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMerchantMatchingSettings not implemented")
+}
+func (UnimplementedOrderServiceServer) SetMerchantMatchingSettings(context.Context, *SetMerchantMatchingSettingsRequest) (*SetMerchantMatchingSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMerchantMatchingSettings not implemented")
+}
+func (UnimplementedOrderServiceServer) ExplainBankDetailSelection(context.Context, *CreatePayInOrderRequest) (*ExplainBankDetailSelectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainBankDetailSelection not implemented")
+}
+func (UnimplementedOrderServiceServer) GetRoutingScores(context.Context, *GetRoutingScoresRequest) (*GetRoutingScoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoutingScores not implemented")
+}
+func (UnimplementedOrderServiceServer) SetRoutingScoreOverride(context.Context, *SetRoutingScoreOverrideRequest) (*SetRoutingScoreOverrideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRoutingScoreOverride not implemented")
+}
+func (UnimplementedOrderServiceServer) CreateNotificationTemplate(context.Context, *CreateNotificationTemplateRequest) (*CreateNotificationTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNotificationTemplate not implemented")
+}
+func (UnimplementedOrderServiceServer) UpdateNotificationTemplate(context.Context, *UpdateNotificationTemplateRequest) (*UpdateNotificationTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNotificationTemplate not implemented")
+}
+func (UnimplementedOrderServiceServer) DeleteNotificationTemplate(context.Context, *DeleteNotificationTemplateRequest) (*DeleteNotificationTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNotificationTemplate not implemented")
+}
+func (UnimplementedOrderServiceServer) GetNotificationTemplates(context.Context, *GetNotificationTemplatesRequest) (*GetNotificationTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationTemplates not implemented")
+}
+func (UnimplementedOrderServiceServer) TestNotificationTemplate(context.Context, *TestNotificationTemplateRequest) (*TestNotificationTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestNotificationTemplate not implemented")
+}
+func (UnimplementedOrderServiceServer) ListUnmatchedPayments(context.Context, *ListUnmatchedPaymentsRequest) (*ListUnmatchedPaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUnmatchedPayments not implemented")
+}
+func (UnimplementedOrderServiceServer) LinkPaymentToOrder(context.Context, *LinkPaymentToOrderRequest) (*LinkPaymentToOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkPaymentToOrder not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 func (UnimplementedOrderServiceServer) testEmbeddedByValue()                      {}
 
@@ -750,6 +1042,330 @@ func _OrderService_GetAutomaticStats_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_GetOrderHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrderHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetOrderHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrderHistory(ctx, req.(*GetOrderHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RecoverCanceledOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoverCanceledOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RecoverCanceledOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_RecoverCanceledOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RecoverCanceledOrder(ctx, req.(*RecoverCanceledOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetCallbackDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCallbackDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetCallbackDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetCallbackDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetCallbackDeliveries(ctx, req.(*GetCallbackDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ResendCallback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendCallbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ResendCallback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ResendCallback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ResendCallback(ctx, req.(*ResendCallbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetMerchantCallbackSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMerchantCallbackSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetMerchantCallbackSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetMerchantCallbackSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetMerchantCallbackSettings(ctx, req.(*GetMerchantCallbackSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_SetMerchantCallbackSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMerchantCallbackSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).SetMerchantCallbackSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_SetMerchantCallbackSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).SetMerchantCallbackSettings(ctx, req.(*SetMerchantCallbackSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetMerchantMatchingSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMerchantMatchingSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetMerchantMatchingSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetMerchantMatchingSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetMerchantMatchingSettings(ctx, req.(*GetMerchantMatchingSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_SetMerchantMatchingSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMerchantMatchingSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).SetMerchantMatchingSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_SetMerchantMatchingSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).SetMerchantMatchingSettings(ctx, req.(*SetMerchantMatchingSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ExplainBankDetailSelection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePayInOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ExplainBankDetailSelection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ExplainBankDetailSelection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ExplainBankDetailSelection(ctx, req.(*CreatePayInOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetRoutingScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoutingScoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetRoutingScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetRoutingScores_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetRoutingScores(ctx, req.(*GetRoutingScoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_SetRoutingScoreOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRoutingScoreOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).SetRoutingScoreOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_SetRoutingScoreOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).SetRoutingScoreOverride(ctx, req.(*SetRoutingScoreOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CreateNotificationTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNotificationTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreateNotificationTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CreateNotificationTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreateNotificationTemplate(ctx, req.(*CreateNotificationTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_UpdateNotificationTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNotificationTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).UpdateNotificationTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_UpdateNotificationTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).UpdateNotificationTemplate(ctx, req.(*UpdateNotificationTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_DeleteNotificationTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNotificationTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).DeleteNotificationTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_DeleteNotificationTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).DeleteNotificationTemplate(ctx, req.(*DeleteNotificationTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetNotificationTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetNotificationTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetNotificationTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetNotificationTemplates(ctx, req.(*GetNotificationTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_TestNotificationTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestNotificationTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).TestNotificationTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_TestNotificationTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).TestNotificationTemplate(ctx, req.(*TestNotificationTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListUnmatchedPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnmatchedPaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListUnmatchedPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ListUnmatchedPayments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListUnmatchedPayments(ctx, req.(*ListUnmatchedPaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_LinkPaymentToOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkPaymentToOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).LinkPaymentToOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_LinkPaymentToOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).LinkPaymentToOrder(ctx, req.(*LinkPaymentToOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -837,6 +1453,78 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAutomaticStats",
 			Handler:    _OrderService_GetAutomaticStats_Handler,
 		},
+		{
+			MethodName: "GetOrderHistory",
+			Handler:    _OrderService_GetOrderHistory_Handler,
+		},
+		{
+			MethodName: "RecoverCanceledOrder",
+			Handler:    _OrderService_RecoverCanceledOrder_Handler,
+		},
+		{
+			MethodName: "GetCallbackDeliveries",
+			Handler:    _OrderService_GetCallbackDeliveries_Handler,
+		},
+		{
+			MethodName: "ResendCallback",
+			Handler:    _OrderService_ResendCallback_Handler,
+		},
+		{
+			MethodName: "GetMerchantCallbackSettings",
+			Handler:    _OrderService_GetMerchantCallbackSettings_Handler,
+		},
+		{
+			MethodName: "SetMerchantCallbackSettings",
+			Handler:    _OrderService_SetMerchantCallbackSettings_Handler,
+		},
+		{
+			MethodName: "GetMerchantMatchingSettings",
+			Handler:    _OrderService_GetMerchantMatchingSettings_Handler,
+		},
+		{
+			MethodName: "SetMerchantMatchingSettings",
+			Handler:    _OrderService_SetMerchantMatchingSettings_Handler,
+		},
+		{
+			MethodName: "ExplainBankDetailSelection",
+			Handler:    _OrderService_ExplainBankDetailSelection_Handler,
+		},
+		{
+			MethodName: "GetRoutingScores",
+			Handler:    _OrderService_GetRoutingScores_Handler,
+		},
+		{
+			MethodName: "SetRoutingScoreOverride",
+			Handler:    _OrderService_SetRoutingScoreOverride_Handler,
+		},
+		{
+			MethodName: "CreateNotificationTemplate",
+			Handler:    _OrderService_CreateNotificationTemplate_Handler,
+		},
+		{
+			MethodName: "UpdateNotificationTemplate",
+			Handler:    _OrderService_UpdateNotificationTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteNotificationTemplate",
+			Handler:    _OrderService_DeleteNotificationTemplate_Handler,
+		},
+		{
+			MethodName: "GetNotificationTemplates",
+			Handler:    _OrderService_GetNotificationTemplates_Handler,
+		},
+		{
+			MethodName: "TestNotificationTemplate",
+			Handler:    _OrderService_TestNotificationTemplate_Handler,
+		},
+		{
+			MethodName: "ListUnmatchedPayments",
+			Handler:    _OrderService_ListUnmatchedPayments_Handler,
+		},
+		{
+			MethodName: "LinkPaymentToOrder",
+			Handler:    _OrderService_LinkPaymentToOrder_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "order/order_service.proto",
@@ -911,8 +911,15 @@ func (x *TrafficAntifraudParameters) GetAntifraudRequired() bool {
 type TrafficBusinessParameters struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	MerchantDealsDuration *durationpb.Duration   `protobuf:"bytes,1,opt,name=merchant_deals_duration,json=merchantDealsDuration,proto3" json:"merchant_deals_duration,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	UniqueAmountStep      float64                `protobuf:"fixed64,2,opt,name=unique_amount_step,json=uniqueAmountStep,proto3" json:"unique_amount_step,omitempty"` // шаг сдвига суммы для уникальности на реквизите, 0 - выключено
+	UniqueAmountMaxSteps  int32                  `protobuf:"varint,3,opt,name=unique_amount_max_steps,json=uniqueAmountMaxSteps,proto3" json:"unique_amount_max_steps,omitempty"`
+	// допуск суммы при автоматическом закрытии сделок, оба 0 - допуск банка или общий
+	AmountTolerance        float64 `protobuf:"fixed64,4,opt,name=amount_tolerance,json=amountTolerance,proto3" json:"amount_tolerance,omitempty"`
+	AmountTolerancePercent float64 `protobuf:"fixed64,5,opt,name=amount_tolerance_percent,json=amountTolerancePercent,proto3" json:"amount_tolerance_percent,omitempty"`
+	// стратегия выбора реквизита трейдера, пусто - стратегия мерчанта
+	SelectionStrategy string `protobuf:"bytes,6,opt,name=selection_strategy,json=selectionStrategy,proto3" json:"selection_strategy,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *TrafficBusinessParameters) Reset() {
@@ -952,6 +959,41 @@ func (x *TrafficBusinessParameters) GetMerchantDealsDuration() *durationpb.Durat
 	return nil
 }
 
+func (x *TrafficBusinessParameters) GetUniqueAmountStep() float64 {
+	if x != nil {
+		return x.UniqueAmountStep
+	}
+	return 0
+}
+
+func (x *TrafficBusinessParameters) GetUniqueAmountMaxSteps() int32 {
+	if x != nil {
+		return x.UniqueAmountMaxSteps
+	}
+	return 0
+}
+
+func (x *TrafficBusinessParameters) GetAmountTolerance() float64 {
+	if x != nil {
+		return x.AmountTolerance
+	}
+	return 0
+}
+
+func (x *TrafficBusinessParameters) GetAmountTolerancePercent() float64 {
+	if x != nil {
+		return x.AmountTolerancePercent
+	}
+	return 0
+}
+
+func (x *TrafficBusinessParameters) GetSelectionStrategy() string {
+	if x != nil {
+		return x.SelectionStrategy
+	}
+	return ""
+}
+
 type Traffic struct {
 	state               protoimpl.MessageState      `protogen:"open.v1"`
 	Id                  string                      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1721,11 +1763,267 @@ func (x *GetTraderTrafficStatusResponse) GetStatus() bool {
 	return false
 }
 
+type SetTrafficScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrafficId     string                 `protobuf:"bytes,1,opt,name=traffic_id,json=trafficId,proto3" json:"traffic_id,omitempty"`
+	Schedule      *Schedule              `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTrafficScheduleRequest) Reset() {
+	*x = SetTrafficScheduleRequest{}
+	mi := &file_order_traffic_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTrafficScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTrafficScheduleRequest) ProtoMessage() {}
+
+func (x *SetTrafficScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_traffic_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTrafficScheduleRequest.ProtoReflect.Descriptor instead.
+func (*SetTrafficScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_order_traffic_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SetTrafficScheduleRequest) GetTrafficId() string {
+	if x != nil {
+		return x.TrafficId
+	}
+	return ""
+}
+
+func (x *SetTrafficScheduleRequest) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type SetTrafficScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTrafficScheduleResponse) Reset() {
+	*x = SetTrafficScheduleResponse{}
+	mi := &file_order_traffic_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTrafficScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTrafficScheduleResponse) ProtoMessage() {}
+
+func (x *SetTrafficScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_traffic_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTrafficScheduleResponse.ProtoReflect.Descriptor instead.
+func (*SetTrafficScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_order_traffic_service_proto_rawDescGZIP(), []int{33}
+}
+
+type GetTrafficScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrafficId     string                 `protobuf:"bytes,1,opt,name=traffic_id,json=trafficId,proto3" json:"traffic_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrafficScheduleRequest) Reset() {
+	*x = GetTrafficScheduleRequest{}
+	mi := &file_order_traffic_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrafficScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrafficScheduleRequest) ProtoMessage() {}
+
+func (x *GetTrafficScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_traffic_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrafficScheduleRequest.ProtoReflect.Descriptor instead.
+func (*GetTrafficScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_order_traffic_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetTrafficScheduleRequest) GetTrafficId() string {
+	if x != nil {
+		return x.TrafficId
+	}
+	return ""
+}
+
+type GetTrafficScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"` // not set - no schedule, traffic is always open
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrafficScheduleResponse) Reset() {
+	*x = GetTrafficScheduleResponse{}
+	mi := &file_order_traffic_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrafficScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrafficScheduleResponse) ProtoMessage() {}
+
+func (x *GetTrafficScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_traffic_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrafficScheduleResponse.ProtoReflect.Descriptor instead.
+func (*GetTrafficScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_order_traffic_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetTrafficScheduleResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type DeleteTrafficScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrafficId     string                 `protobuf:"bytes,1,opt,name=traffic_id,json=trafficId,proto3" json:"traffic_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTrafficScheduleRequest) Reset() {
+	*x = DeleteTrafficScheduleRequest{}
+	mi := &file_order_traffic_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTrafficScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTrafficScheduleRequest) ProtoMessage() {}
+
+func (x *DeleteTrafficScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_traffic_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTrafficScheduleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTrafficScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_order_traffic_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteTrafficScheduleRequest) GetTrafficId() string {
+	if x != nil {
+		return x.TrafficId
+	}
+	return ""
+}
+
+type DeleteTrafficScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTrafficScheduleResponse) Reset() {
+	*x = DeleteTrafficScheduleResponse{}
+	mi := &file_order_traffic_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTrafficScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTrafficScheduleResponse) ProtoMessage() {}
+
+func (x *DeleteTrafficScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_traffic_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTrafficScheduleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTrafficScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_order_traffic_service_proto_rawDescGZIP(), []int{37}
+}
+
 var File_order_traffic_service_proto protoreflect.FileDescriptor
 
 const file_order_traffic_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1border/traffic_service.proto\x12\x05order\x1a\x1egoogle/protobuf/duration.proto\"6\n" +
+	"\x1border/traffic_service.proto\x12\x05order\x1a\x1egoogle/protobuf/duration.proto\x1a\x18order/common_types.proto\"6\n" +
 	"\x17GetTraderTrafficRequest\x12\x1b\n" +
 	"\ttrader_id\x18\x01 \x01(\tR\btraderId\"D\n" +
 	"\x18GetTraderTrafficResponse\x12(\n" +
@@ -1784,9 +2082,14 @@ const file_order_traffic_service_proto_rawDesc = "" +
 	"\x11manually_unlocked\x18\x03 \x01(\bR\x10manuallyUnlocked\x12-\n" +
 	"\x12antifraud_unlocked\x18\x04 \x01(\bR\x11antifraudUnlocked\"K\n" +
 	"\x1aTrafficAntifraudParameters\x12-\n" +
-	"\x12antifraud_required\x18\x01 \x01(\bR\x11antifraudRequired\"n\n" +
+	"\x12antifraud_required\x18\x01 \x01(\bR\x11antifraudRequired\"\xe7\x02\n" +
 	"\x19TrafficBusinessParameters\x12Q\n" +
-	"\x17merchant_deals_duration\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x15merchantDealsDuration\"\xe9\x03\n" +
+	"\x17merchant_deals_duration\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x15merchantDealsDuration\x12,\n" +
+	"\x12unique_amount_step\x18\x02 \x01(\x01R\x10uniqueAmountStep\x125\n" +
+	"\x17unique_amount_max_steps\x18\x03 \x01(\x05R\x14uniqueAmountMaxSteps\x12)\n" +
+	"\x10amount_tolerance\x18\x04 \x01(\x01R\x0famountTolerance\x128\n" +
+	"\x18amount_tolerance_percent\x18\x05 \x01(\x01R\x16amountTolerancePercent\x12-\n" +
+	"\x12selection_strategy\x18\x06 \x01(\tR\x11selectionStrategy\"\xe9\x03\n" +
 	"\aTraffic\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vmerchant_id\x18\x02 \x01(\tR\n" +
@@ -1850,8 +2153,21 @@ const file_order_traffic_service_proto_rawDesc = "" +
 	"\x1dGetTraderTrafficStatusRequest\x12\x1b\n" +
 	"\ttrader_id\x18\x01 \x01(\tR\btraderId\"8\n" +
 	"\x1eGetTraderTrafficStatusResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\bR\x06status2\xe1\n" +
+	"\x06status\x18\x01 \x01(\bR\x06status\"g\n" +
+	"\x19SetTrafficScheduleRequest\x12\x1d\n" +
+	"\n" +
+	"traffic_id\x18\x01 \x01(\tR\ttrafficId\x12+\n" +
+	"\bschedule\x18\x02 \x01(\v2\x0f.order.ScheduleR\bschedule\"\x1c\n" +
+	"\x1aSetTrafficScheduleResponse\":\n" +
+	"\x19GetTrafficScheduleRequest\x12\x1d\n" +
+	"\n" +
+	"traffic_id\x18\x01 \x01(\tR\ttrafficId\"I\n" +
+	"\x1aGetTrafficScheduleResponse\x12+\n" +
+	"\bschedule\x18\x01 \x01(\v2\x0f.order.ScheduleR\bschedule\"=\n" +
+	"\x1cDeleteTrafficScheduleRequest\x12\x1d\n" +
 	"\n" +
+	"traffic_id\x18\x01 \x01(\tR\ttrafficId\"\x1f\n" +
+	"\x1dDeleteTrafficScheduleResponse2\xfb\f\n" +
 	"\x0eTrafficService\x12A\n" +
 	"\n" +
 	"AddTraffic\x12\x18.order.AddTrafficRequest\x1a\x19.order.AddTrafficResponse\x12D\n" +
@@ -1867,7 +2183,10 @@ const file_order_traffic_service_proto_rawDesc = "" +
 	"\x1dSetAntifraudLockTrafficStatus\x12+.order.SetAntifraudLockTrafficStatusRequest\x1a,.order.SetAntifraudLockTrafficStatusResponse\x12e\n" +
 	"\x16GetTrafficLockStatuses\x12$.order.GetTrafficLockStatusesRequest\x1a%.order.GetTrafficLockStatusesResponse\x12_\n" +
 	"\x14CheckTrafficUnlocked\x12\".order.CheckTrafficUnlockedRequest\x1a#.order.CheckTrafficUnlockedResponse\x12S\n" +
-	"\x10GetTraderTraffic\x12\x1e.order.GetTraderTrafficRequest\x1a\x1f.order.GetTraderTrafficResponseB=Z;github.com/LavaJover/shvark-order-service/proto/gen;orderpbb\x06proto3"
+	"\x10GetTraderTraffic\x12\x1e.order.GetTraderTrafficRequest\x1a\x1f.order.GetTraderTrafficResponse\x12Y\n" +
+	"\x12SetTrafficSchedule\x12 .order.SetTrafficScheduleRequest\x1a!.order.SetTrafficScheduleResponse\x12Y\n" +
+	"\x12GetTrafficSchedule\x12 .order.GetTrafficScheduleRequest\x1a!.order.GetTrafficScheduleResponse\x12b\n" +
+	"\x15DeleteTrafficSchedule\x12#.order.DeleteTrafficScheduleRequest\x1a$.order.DeleteTrafficScheduleResponseB=Z;github.com/LavaJover/shvark-order-service/proto/gen;orderpbb\x06proto3"
 
 var (
 	file_order_traffic_service_proto_rawDescOnce sync.Once
@@ -1881,7 +2200,7 @@ func file_order_traffic_service_proto_rawDescGZIP() []byte {
 	return file_order_traffic_service_proto_rawDescData
 }
 
-var file_order_traffic_service_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_order_traffic_service_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_order_traffic_service_proto_goTypes = []any{
 	(*GetTraderTrafficRequest)(nil),               // 0: order.GetTraderTrafficRequest
 	(*GetTraderTrafficResponse)(nil),              // 1: order.GetTraderTrafficResponse
@@ -1915,14 +2234,21 @@ var file_order_traffic_service_proto_goTypes = []any{
 	(*EnableTraderTrafficResponse)(nil),           // 29: order.EnableTraderTrafficResponse
 	(*GetTraderTrafficStatusRequest)(nil),         // 30: order.GetTraderTrafficStatusRequest
 	(*GetTraderTrafficStatusResponse)(nil),        // 31: order.GetTraderTrafficStatusResponse
-	(*durationpb.Duration)(nil),                   // 32: google.protobuf.Duration
+	(*SetTrafficScheduleRequest)(nil),             // 32: order.SetTrafficScheduleRequest
+	(*SetTrafficScheduleResponse)(nil),            // 33: order.SetTrafficScheduleResponse
+	(*GetTrafficScheduleRequest)(nil),             // 34: order.GetTrafficScheduleRequest
+	(*GetTrafficScheduleResponse)(nil),            // 35: order.GetTrafficScheduleResponse
+	(*DeleteTrafficScheduleRequest)(nil),          // 36: order.DeleteTrafficScheduleRequest
+	(*DeleteTrafficScheduleResponse)(nil),         // 37: order.DeleteTrafficScheduleResponse
+	(*durationpb.Duration)(nil),                   // 38: google.protobuf.Duration
+	(*Schedule)(nil),                              // 39: order.Schedule
 }
 var file_order_traffic_service_proto_depIdxs = []int32{
 	18, // 0: order.GetTraderTrafficResponse.records:type_name -> order.Traffic
 	15, // 1: order.AddTrafficRequest.activity_params:type_name -> order.TrafficActivityParameters
 	16, // 2: order.AddTrafficRequest.antifraud_params:type_name -> order.TrafficAntifraudParameters
 	17, // 3: order.AddTrafficRequest.business_params:type_name -> order.TrafficBusinessParameters
-	32, // 4: order.TrafficBusinessParameters.merchant_deals_duration:type_name -> google.protobuf.Duration
+	38, // 4: order.TrafficBusinessParameters.merchant_deals_duration:type_name -> google.protobuf.Duration
 	15, // 5: order.Traffic.activity_params:type_name -> order.TrafficActivityParameters
 	16, // 6: order.Traffic.antifraud_params:type_name -> order.TrafficAntifraudParameters
 	17, // 7: order.Traffic.business_params:type_name -> order.TrafficBusinessParameters
@@ -1930,39 +2256,47 @@ var file_order_traffic_service_proto_depIdxs = []int32{
 	16, // 9: order.EditTrafficRequest.antifraud_params:type_name -> order.TrafficAntifraudParameters
 	17, // 10: order.EditTrafficRequest.business_params:type_name -> order.TrafficBusinessParameters
 	18, // 11: order.GetTrafficRecordsResponse.traffic_records:type_name -> order.Traffic
-	14, // 12: order.TrafficService.AddTraffic:input_type -> order.AddTrafficRequest
-	20, // 13: order.TrafficService.EditTraffic:input_type -> order.EditTrafficRequest
-	22, // 14: order.TrafficService.DeleteTraffic:input_type -> order.DeleteTrafficRequest
-	24, // 15: order.TrafficService.GetTrafficRecords:input_type -> order.GetTrafficRecordsRequest
-	26, // 16: order.TrafficService.DisableTraderTraffic:input_type -> order.DisableTraderTrafficRequest
-	28, // 17: order.TrafficService.EnableTraderTraffic:input_type -> order.EnableTraderTrafficRequest
-	30, // 18: order.TrafficService.GetTraderTrafficStatus:input_type -> order.GetTraderTrafficStatusRequest
-	6,  // 19: order.TrafficService.SetTraderLockTrafficStatus:input_type -> order.SetTraderLockTrafficStatusRequest
-	8,  // 20: order.TrafficService.SetMerchantLockTrafficStatus:input_type -> order.SetMerchantLockTrafficStatusRequest
-	10, // 21: order.TrafficService.SetManuallyLockTrafficStatus:input_type -> order.SetManuallyLockTrafficStatusRequest
-	12, // 22: order.TrafficService.SetAntifraudLockTrafficStatus:input_type -> order.SetAntifraudLockTrafficStatusRequest
-	2,  // 23: order.TrafficService.GetTrafficLockStatuses:input_type -> order.GetTrafficLockStatusesRequest
-	4,  // 24: order.TrafficService.CheckTrafficUnlocked:input_type -> order.CheckTrafficUnlockedRequest
-	0,  // 25: order.TrafficService.GetTraderTraffic:input_type -> order.GetTraderTrafficRequest
-	19, // 26: order.TrafficService.AddTraffic:output_type -> order.AddTrafficResponse
-	21, // 27: order.TrafficService.EditTraffic:output_type -> order.EditTrafficResponse
-	23, // 28: order.TrafficService.DeleteTraffic:output_type -> order.DeleteTrafficResponse
-	25, // 29: order.TrafficService.GetTrafficRecords:output_type -> order.GetTrafficRecordsResponse
-	27, // 30: order.TrafficService.DisableTraderTraffic:output_type -> order.DisableTraderTrafficResponse
-	29, // 31: order.TrafficService.EnableTraderTraffic:output_type -> order.EnableTraderTrafficResponse
-	31, // 32: order.TrafficService.GetTraderTrafficStatus:output_type -> order.GetTraderTrafficStatusResponse
-	7,  // 33: order.TrafficService.SetTraderLockTrafficStatus:output_type -> order.SetTraderLockTrafficStatusResponse
-	9,  // 34: order.TrafficService.SetMerchantLockTrafficStatus:output_type -> order.SetMerchantLockTrafficStatusResponse
-	11, // 35: order.TrafficService.SetManuallyLockTrafficStatus:output_type -> order.SetManuallyLockTrafficStatusResponse
-	13, // 36: order.TrafficService.SetAntifraudLockTrafficStatus:output_type -> order.SetAntifraudLockTrafficStatusResponse
-	3,  // 37: order.TrafficService.GetTrafficLockStatuses:output_type -> order.GetTrafficLockStatusesResponse
-	5,  // 38: order.TrafficService.CheckTrafficUnlocked:output_type -> order.CheckTrafficUnlockedResponse
-	1,  // 39: order.TrafficService.GetTraderTraffic:output_type -> order.GetTraderTrafficResponse
-	26, // [26:40] is the sub-list for method output_type
-	12, // [12:26] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	39, // 12: order.SetTrafficScheduleRequest.schedule:type_name -> order.Schedule
+	39, // 13: order.GetTrafficScheduleResponse.schedule:type_name -> order.Schedule
+	14, // 14: order.TrafficService.AddTraffic:input_type -> order.AddTrafficRequest
+	20, // 15: order.TrafficService.EditTraffic:input_type -> order.EditTrafficRequest
+	22, // 16: order.TrafficService.DeleteTraffic:input_type -> order.DeleteTrafficRequest
+	24, // 17: order.TrafficService.GetTrafficRecords:input_type -> order.GetTrafficRecordsRequest
+	26, // 18: order.TrafficService.DisableTraderTraffic:input_type -> order.DisableTraderTrafficRequest
+	28, // 19: order.TrafficService.EnableTraderTraffic:input_type -> order.EnableTraderTrafficRequest
+	30, // 20: order.TrafficService.GetTraderTrafficStatus:input_type -> order.GetTraderTrafficStatusRequest
+	6,  // 21: order.TrafficService.SetTraderLockTrafficStatus:input_type -> order.SetTraderLockTrafficStatusRequest
+	8,  // 22: order.TrafficService.SetMerchantLockTrafficStatus:input_type -> order.SetMerchantLockTrafficStatusRequest
+	10, // 23: order.TrafficService.SetManuallyLockTrafficStatus:input_type -> order.SetManuallyLockTrafficStatusRequest
+	12, // 24: order.TrafficService.SetAntifraudLockTrafficStatus:input_type -> order.SetAntifraudLockTrafficStatusRequest
+	2,  // 25: order.TrafficService.GetTrafficLockStatuses:input_type -> order.GetTrafficLockStatusesRequest
+	4,  // 26: order.TrafficService.CheckTrafficUnlocked:input_type -> order.CheckTrafficUnlockedRequest
+	0,  // 27: order.TrafficService.GetTraderTraffic:input_type -> order.GetTraderTrafficRequest
+	32, // 28: order.TrafficService.SetTrafficSchedule:input_type -> order.SetTrafficScheduleRequest
+	34, // 29: order.TrafficService.GetTrafficSchedule:input_type -> order.GetTrafficScheduleRequest
+	36, // 30: order.TrafficService.DeleteTrafficSchedule:input_type -> order.DeleteTrafficScheduleRequest
+	19, // 31: order.TrafficService.AddTraffic:output_type -> order.AddTrafficResponse
+	21, // 32: order.TrafficService.EditTraffic:output_type -> order.EditTrafficResponse
+	23, // 33: order.TrafficService.DeleteTraffic:output_type -> order.DeleteTrafficResponse
+	25, // 34: order.TrafficService.GetTrafficRecords:output_type -> order.GetTrafficRecordsResponse
+	27, // 35: order.TrafficService.DisableTraderTraffic:output_type -> order.DisableTraderTrafficResponse
+	29, // 36: order.TrafficService.EnableTraderTraffic:output_type -> order.EnableTraderTrafficResponse
+	31, // 37: order.TrafficService.GetTraderTrafficStatus:output_type -> order.GetTraderTrafficStatusResponse
+	7,  // 38: order.TrafficService.SetTraderLockTrafficStatus:output_type -> order.SetTraderLockTrafficStatusResponse
+	9,  // 39: order.TrafficService.SetMerchantLockTrafficStatus:output_type -> order.SetMerchantLockTrafficStatusResponse
+	11, // 40: order.TrafficService.SetManuallyLockTrafficStatus:output_type -> order.SetManuallyLockTrafficStatusResponse
+	13, // 41: order.TrafficService.SetAntifraudLockTrafficStatus:output_type -> order.SetAntifraudLockTrafficStatusResponse
+	3,  // 42: order.TrafficService.GetTrafficLockStatuses:output_type -> order.GetTrafficLockStatusesResponse
+	5,  // 43: order.TrafficService.CheckTrafficUnlocked:output_type -> order.CheckTrafficUnlockedResponse
+	1,  // 44: order.TrafficService.GetTraderTraffic:output_type -> order.GetTraderTrafficResponse
+	33, // 45: order.TrafficService.SetTrafficSchedule:output_type -> order.SetTrafficScheduleResponse
+	35, // 46: order.TrafficService.GetTrafficSchedule:output_type -> order.GetTrafficScheduleResponse
+	37, // 47: order.TrafficService.DeleteTrafficSchedule:output_type -> order.DeleteTrafficScheduleResponse
+	31, // [31:48] is the sub-list for method output_type
+	14, // [14:31] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_order_traffic_service_proto_init() }
@@ -1970,6 +2304,7 @@ func file_order_traffic_service_proto_init() {
 	if File_order_traffic_service_proto != nil {
 		return
 	}
+	file_order_common_types_proto_init()
 	file_order_traffic_service_proto_msgTypes[20].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1977,7 +2312,7 @@ func file_order_traffic_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_traffic_service_proto_rawDesc), len(file_order_traffic_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   32,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
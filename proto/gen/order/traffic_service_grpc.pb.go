@@ -33,6 +33,9 @@ const (
 	TrafficService_GetTrafficLockStatuses_FullMethodName        = "/order.TrafficService/GetTrafficLockStatuses"
 	TrafficService_CheckTrafficUnlocked_FullMethodName          = "/order.TrafficService/CheckTrafficUnlocked"
 	TrafficService_GetTraderTraffic_FullMethodName              = "/order.TrafficService/GetTraderTraffic"
+	TrafficService_SetTrafficSchedule_FullMethodName            = "/order.TrafficService/SetTrafficSchedule"
+	TrafficService_GetTrafficSchedule_FullMethodName            = "/order.TrafficService/GetTrafficSchedule"
+	TrafficService_DeleteTrafficSchedule_FullMethodName         = "/order.TrafficService/DeleteTrafficSchedule"
 )
 
 // TrafficServiceClient is the client API for TrafficService service.
@@ -56,6 +59,10 @@ type TrafficServiceClient interface {
 	GetTrafficLockStatuses(ctx context.Context, in *GetTrafficLockStatusesRequest, opts ...grpc.CallOption) (*GetTrafficLockStatusesResponse, error)
 	CheckTrafficUnlocked(ctx context.Context, in *CheckTrafficUnlockedRequest, opts ...grpc.CallOption) (*CheckTrafficUnlockedResponse, error)
 	GetTraderTraffic(ctx context.Context, in *GetTraderTrafficRequest, opts ...grpc.CallOption) (*GetTraderTrafficResponse, error)
+	// Traffic schedule
+	SetTrafficSchedule(ctx context.Context, in *SetTrafficScheduleRequest, opts ...grpc.CallOption) (*SetTrafficScheduleResponse, error)
+	GetTrafficSchedule(ctx context.Context, in *GetTrafficScheduleRequest, opts ...grpc.CallOption) (*GetTrafficScheduleResponse, error)
+	DeleteTrafficSchedule(ctx context.Context, in *DeleteTrafficScheduleRequest, opts ...grpc.CallOption) (*DeleteTrafficScheduleResponse, error)
 }
 
 type trafficServiceClient struct {
@@ -206,6 +213,36 @@ func (c *trafficServiceClient) GetTraderTraffic(ctx context.Context, in *GetTrad
 	return out, nil
 }
 
+func (c *trafficServiceClient) SetTrafficSchedule(ctx context.Context, in *SetTrafficScheduleRequest, opts ...grpc.CallOption) (*SetTrafficScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetTrafficScheduleResponse)
+	err := c.cc.Invoke(ctx, TrafficService_SetTrafficSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trafficServiceClient) GetTrafficSchedule(ctx context.Context, in *GetTrafficScheduleRequest, opts ...grpc.CallOption) (*GetTrafficScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrafficScheduleResponse)
+	err := c.cc.Invoke(ctx, TrafficService_GetTrafficSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trafficServiceClient) DeleteTrafficSchedule(ctx context.Context, in *DeleteTrafficScheduleRequest, opts ...grpc.CallOption) (*DeleteTrafficScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTrafficScheduleResponse)
+	err := c.cc.Invoke(ctx, TrafficService_DeleteTrafficSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TrafficServiceServer is the server API for TrafficService service.
 // All implementations must embed UnimplementedTrafficServiceServer
 // for forward compatibility.
@@ -227,6 +264,10 @@ type TrafficServiceServer interface {
 	GetTrafficLockStatuses(context.Context, *GetTrafficLockStatusesRequest) (*GetTrafficLockStatusesResponse, error)
 	CheckTrafficUnlocked(context.Context, *CheckTrafficUnlockedRequest) (*CheckTrafficUnlockedResponse, error)
 	GetTraderTraffic(context.Context, *GetTraderTrafficRequest) (*GetTraderTrafficResponse, error)
+	// Traffic schedule
+	SetTrafficSchedule(context.Context, *SetTrafficScheduleRequest) (*SetTrafficScheduleResponse, error)
+	GetTrafficSchedule(context.Context, *GetTrafficScheduleRequest) (*GetTrafficScheduleResponse, error)
+	DeleteTrafficSchedule(context.Context, *DeleteTrafficScheduleRequest) (*DeleteTrafficScheduleResponse, error)
 	mustEmbedUnimplementedTrafficServiceServer()
 }
 
@@ -279,6 +320,15 @@ func (UnimplementedTrafficServiceServer) CheckTrafficUnlocked(context.Context, *
 func (UnimplementedTrafficServiceServer) GetTraderTraffic(context.Context, *GetTraderTrafficRequest) (*GetTraderTrafficResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTraderTraffic not implemented")
 }
+func (UnimplementedTrafficServiceServer) SetTrafficSchedule(context.Context, *SetTrafficScheduleRequest) (*SetTrafficScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTrafficSchedule not implemented")
+}
+func (UnimplementedTrafficServiceServer) GetTrafficSchedule(context.Context, *GetTrafficScheduleRequest) (*GetTrafficScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrafficSchedule not implemented")
+}
+func (UnimplementedTrafficServiceServer) DeleteTrafficSchedule(context.Context, *DeleteTrafficScheduleRequest) (*DeleteTrafficScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTrafficSchedule not implemented")
+}
 func (UnimplementedTrafficServiceServer) mustEmbedUnimplementedTrafficServiceServer() {}
 func (UnimplementedTrafficServiceServer) testEmbeddedByValue()                        {}
 
@@ -552,6 +602,60 @@ func _TrafficService_GetTraderTraffic_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrafficService_SetTrafficSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTrafficScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).SetTrafficSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_SetTrafficSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).SetTrafficSchedule(ctx, req.(*SetTrafficScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrafficService_GetTrafficSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrafficScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).GetTrafficSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_GetTrafficSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).GetTrafficSchedule(ctx, req.(*GetTrafficScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrafficService_DeleteTrafficSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTrafficScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).DeleteTrafficSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_DeleteTrafficSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).DeleteTrafficSchedule(ctx, req.(*DeleteTrafficScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TrafficService_ServiceDesc is the grpc.ServiceDesc for TrafficService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -615,6 +719,18 @@ var TrafficService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTraderTraffic",
 			Handler:    _TrafficService_GetTraderTraffic_Handler,
 		},
+		{
+			MethodName: "SetTrafficSchedule",
+			Handler:    _TrafficService_SetTrafficSchedule_Handler,
+		},
+		{
+			MethodName: "GetTrafficSchedule",
+			Handler:    _TrafficService_GetTrafficSchedule_Handler,
+		},
+		{
+			MethodName: "DeleteTrafficSchedule",
+			Handler:    _TrafficService_DeleteTrafficSchedule_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "order/traffic_service.proto",